@@ -265,6 +265,20 @@ func (c *clientImpl) MergeDLQMessages(
 
 }
 
+func (c *clientImpl) MergeOneDLQMessage(
+	ctx context.Context,
+	request *adminservice.MergeOneDLQMessageRequest,
+	opts ...grpc.CallOption,
+) (*adminservice.MergeOneDLQMessageResponse, error) {
+	client, err := c.getRandomClient()
+	if err != nil {
+		return nil, err
+	}
+	ctx, cancel := c.createContext(ctx)
+	defer cancel()
+	return client.MergeOneDLQMessage(ctx, request, opts...)
+}
+
 func (c *clientImpl) RefreshWorkflowTasks(
 	ctx context.Context,
 	request *adminservice.RefreshWorkflowTasksRequest,
@@ -279,6 +293,187 @@ func (c *clientImpl) RefreshWorkflowTasks(
 	return client.RefreshWorkflowTasks(ctx, request, opts...)
 }
 
+func (c *clientImpl) ResetReplicationAckLevel(
+	ctx context.Context,
+	request *adminservice.ResetReplicationAckLevelRequest,
+	opts ...grpc.CallOption,
+) (*adminservice.ResetReplicationAckLevelResponse, error) {
+	client, err := c.getRandomClient()
+	if err != nil {
+		return nil, err
+	}
+	ctx, cancel := c.createContext(ctx)
+	defer cancel()
+	return client.ResetReplicationAckLevel(ctx, request, opts...)
+}
+
+func (c *clientImpl) DLQReplicationTask(
+	ctx context.Context,
+	request *adminservice.DLQReplicationTaskRequest,
+	opts ...grpc.CallOption,
+) (*adminservice.DLQReplicationTaskResponse, error) {
+	client, err := c.getRandomClient()
+	if err != nil {
+		return nil, err
+	}
+	ctx, cancel := c.createContext(ctx)
+	defer cancel()
+	return client.DLQReplicationTask(ctx, request, opts...)
+}
+
+func (c *clientImpl) RefreshNamespaceCache(
+	ctx context.Context,
+	request *adminservice.RefreshNamespaceCacheRequest,
+	opts ...grpc.CallOption,
+) (*adminservice.RefreshNamespaceCacheResponse, error) {
+	client, err := c.getRandomClient()
+	if err != nil {
+		return nil, err
+	}
+	ctx, cancel := c.createContext(ctx)
+	defer cancel()
+	return client.RefreshNamespaceCache(ctx, request, opts...)
+}
+
+func (c *clientImpl) TailWorkflowExecutionHistory(
+	ctx context.Context,
+	request *adminservice.TailWorkflowExecutionHistoryRequest,
+	opts ...grpc.CallOption,
+) (adminservice.AdminService_TailWorkflowExecutionHistoryClient, error) {
+	client, err := c.getRandomClient()
+	if err != nil {
+		return nil, err
+	}
+	// the stream stays open until the workflow closes, so the call is not bounded by the client timeout
+	return client.TailWorkflowExecutionHistory(ctx, request, opts...)
+}
+
+func (c *clientImpl) RepairShardAckLevels(
+	ctx context.Context,
+	request *adminservice.RepairShardAckLevelsRequest,
+	opts ...grpc.CallOption,
+) (*adminservice.RepairShardAckLevelsResponse, error) {
+	client, err := c.getRandomClient()
+	if err != nil {
+		return nil, err
+	}
+	ctx, cancel := c.createContext(ctx)
+	defer cancel()
+	return client.RepairShardAckLevels(ctx, request, opts...)
+}
+
+func (c *clientImpl) ForceTerminateWorkflowExecution(
+	ctx context.Context,
+	request *adminservice.ForceTerminateWorkflowExecutionRequest,
+	opts ...grpc.CallOption,
+) (*adminservice.ForceTerminateWorkflowExecutionResponse, error) {
+	client, err := c.getRandomClient()
+	if err != nil {
+		return nil, err
+	}
+	ctx, cancel := c.createContext(ctx)
+	defer cancel()
+	return client.ForceTerminateWorkflowExecution(ctx, request, opts...)
+}
+
+func (c *clientImpl) EstimateTaskListBacklog(
+	ctx context.Context,
+	request *adminservice.EstimateTaskListBacklogRequest,
+	opts ...grpc.CallOption,
+) (*adminservice.EstimateTaskListBacklogResponse, error) {
+	client, err := c.getRandomClient()
+	if err != nil {
+		return nil, err
+	}
+	ctx, cancel := c.createContext(ctx)
+	defer cancel()
+	return client.EstimateTaskListBacklog(ctx, request, opts...)
+}
+
+func (c *clientImpl) ListOwnedTaskLists(
+	ctx context.Context,
+	request *adminservice.ListOwnedTaskListsRequest,
+	opts ...grpc.CallOption,
+) (*adminservice.ListOwnedTaskListsResponse, error) {
+	client, err := c.getRandomClient()
+	if err != nil {
+		return nil, err
+	}
+	ctx, cancel := c.createContext(ctx)
+	defer cancel()
+	return client.ListOwnedTaskLists(ctx, request, opts...)
+}
+
+func (c *clientImpl) DescribeTaskListStatus(
+	ctx context.Context,
+	request *adminservice.DescribeTaskListStatusRequest,
+	opts ...grpc.CallOption,
+) (*adminservice.DescribeTaskListStatusResponse, error) {
+	client, err := c.getRandomClient()
+	if err != nil {
+		return nil, err
+	}
+	ctx, cancel := c.createContext(ctx)
+	defer cancel()
+	return client.DescribeTaskListStatus(ctx, request, opts...)
+}
+
+func (c *clientImpl) InjectTask(
+	ctx context.Context,
+	request *adminservice.InjectTaskRequest,
+	opts ...grpc.CallOption,
+) (*adminservice.InjectTaskResponse, error) {
+	client, err := c.getRandomClient()
+	if err != nil {
+		return nil, err
+	}
+	ctx, cancel := c.createContext(ctx)
+	defer cancel()
+	return client.InjectTask(ctx, request, opts...)
+}
+
+func (c *clientImpl) CompleteTasksBelow(
+	ctx context.Context,
+	request *adminservice.CompleteTasksBelowRequest,
+	opts ...grpc.CallOption,
+) (*adminservice.CompleteTasksBelowResponse, error) {
+	client, err := c.getRandomClient()
+	if err != nil {
+		return nil, err
+	}
+	ctx, cancel := c.createContext(ctx)
+	defer cancel()
+	return client.CompleteTasksBelow(ctx, request, opts...)
+}
+
+func (c *clientImpl) ListWorkflowTimers(
+	ctx context.Context,
+	request *adminservice.ListWorkflowTimersRequest,
+	opts ...grpc.CallOption,
+) (*adminservice.ListWorkflowTimersResponse, error) {
+	client, err := c.getRandomClient()
+	if err != nil {
+		return nil, err
+	}
+	ctx, cancel := c.createContext(ctx)
+	defer cancel()
+	return client.ListWorkflowTimers(ctx, request, opts...)
+}
+
+func (c *clientImpl) CancelOrphanedTimer(
+	ctx context.Context,
+	request *adminservice.CancelOrphanedTimerRequest,
+	opts ...grpc.CallOption,
+) (*adminservice.CancelOrphanedTimerResponse, error) {
+	client, err := c.getRandomClient()
+	if err != nil {
+		return nil, err
+	}
+	ctx, cancel := c.createContext(ctx)
+	defer cancel()
+	return client.CancelOrphanedTimer(ctx, request, opts...)
+}
+
 func (c *clientImpl) createContext(parent context.Context) (context.Context, context.CancelFunc) {
 	return context.WithTimeout(parent, c.timeout)
 }
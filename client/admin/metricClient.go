@@ -307,6 +307,23 @@ func (c *metricClient) MergeDLQMessages(
 	return resp, err
 }
 
+func (c *metricClient) MergeOneDLQMessage(
+	ctx context.Context,
+	request *adminservice.MergeOneDLQMessageRequest,
+	opts ...grpc.CallOption,
+) (*adminservice.MergeOneDLQMessageResponse, error) {
+
+	c.metricsClient.IncCounter(metrics.AdminClientMergeOneDLQMessageScope, metrics.ClientRequests)
+	sw := c.metricsClient.StartTimer(metrics.AdminClientMergeOneDLQMessageScope, metrics.ClientLatency)
+	resp, err := c.client.MergeOneDLQMessage(ctx, request, opts...)
+	sw.Stop()
+
+	if err != nil {
+		c.metricsClient.IncCounter(metrics.AdminClientMergeOneDLQMessageScope, metrics.ClientFailures)
+	}
+	return resp, err
+}
+
 func (c *metricClient) RefreshWorkflowTasks(
 	ctx context.Context,
 	request *adminservice.RefreshWorkflowTasksRequest,
@@ -323,3 +340,222 @@ func (c *metricClient) RefreshWorkflowTasks(
 	}
 	return resp, err
 }
+
+func (c *metricClient) ResetReplicationAckLevel(
+	ctx context.Context,
+	request *adminservice.ResetReplicationAckLevelRequest,
+	opts ...grpc.CallOption,
+) (*adminservice.ResetReplicationAckLevelResponse, error) {
+
+	c.metricsClient.IncCounter(metrics.AdminClientResetReplicationAckLevelScope, metrics.ClientRequests)
+	sw := c.metricsClient.StartTimer(metrics.AdminClientResetReplicationAckLevelScope, metrics.ClientLatency)
+	resp, err := c.client.ResetReplicationAckLevel(ctx, request, opts...)
+	sw.Stop()
+
+	if err != nil {
+		c.metricsClient.IncCounter(metrics.AdminClientResetReplicationAckLevelScope, metrics.ClientFailures)
+	}
+	return resp, err
+}
+
+func (c *metricClient) DLQReplicationTask(
+	ctx context.Context,
+	request *adminservice.DLQReplicationTaskRequest,
+	opts ...grpc.CallOption,
+) (*adminservice.DLQReplicationTaskResponse, error) {
+
+	c.metricsClient.IncCounter(metrics.AdminClientDLQReplicationTaskScope, metrics.ClientRequests)
+	sw := c.metricsClient.StartTimer(metrics.AdminClientDLQReplicationTaskScope, metrics.ClientLatency)
+	resp, err := c.client.DLQReplicationTask(ctx, request, opts...)
+	sw.Stop()
+
+	if err != nil {
+		c.metricsClient.IncCounter(metrics.AdminClientDLQReplicationTaskScope, metrics.ClientFailures)
+	}
+	return resp, err
+}
+
+func (c *metricClient) RefreshNamespaceCache(
+	ctx context.Context,
+	request *adminservice.RefreshNamespaceCacheRequest,
+	opts ...grpc.CallOption,
+) (*adminservice.RefreshNamespaceCacheResponse, error) {
+
+	c.metricsClient.IncCounter(metrics.AdminClientRefreshNamespaceCacheScope, metrics.ClientRequests)
+	sw := c.metricsClient.StartTimer(metrics.AdminClientRefreshNamespaceCacheScope, metrics.ClientLatency)
+	resp, err := c.client.RefreshNamespaceCache(ctx, request, opts...)
+	sw.Stop()
+
+	if err != nil {
+		c.metricsClient.IncCounter(metrics.AdminClientRefreshNamespaceCacheScope, metrics.ClientFailures)
+	}
+	return resp, err
+}
+
+func (c *metricClient) TailWorkflowExecutionHistory(
+	ctx context.Context,
+	request *adminservice.TailWorkflowExecutionHistoryRequest,
+	opts ...grpc.CallOption,
+) (adminservice.AdminService_TailWorkflowExecutionHistoryClient, error) {
+
+	c.metricsClient.IncCounter(metrics.AdminClientTailWorkflowExecutionHistoryScope, metrics.ClientRequests)
+	stream, err := c.client.TailWorkflowExecutionHistory(ctx, request, opts...)
+
+	if err != nil {
+		c.metricsClient.IncCounter(metrics.AdminClientTailWorkflowExecutionHistoryScope, metrics.ClientFailures)
+	}
+	return stream, err
+}
+
+func (c *metricClient) RepairShardAckLevels(
+	ctx context.Context,
+	request *adminservice.RepairShardAckLevelsRequest,
+	opts ...grpc.CallOption,
+) (*adminservice.RepairShardAckLevelsResponse, error) {
+
+	c.metricsClient.IncCounter(metrics.AdminClientRepairShardAckLevelsScope, metrics.ClientRequests)
+	sw := c.metricsClient.StartTimer(metrics.AdminClientRepairShardAckLevelsScope, metrics.ClientLatency)
+	resp, err := c.client.RepairShardAckLevels(ctx, request, opts...)
+	sw.Stop()
+
+	if err != nil {
+		c.metricsClient.IncCounter(metrics.AdminClientRepairShardAckLevelsScope, metrics.ClientFailures)
+	}
+	return resp, err
+}
+
+func (c *metricClient) ForceTerminateWorkflowExecution(
+	ctx context.Context,
+	request *adminservice.ForceTerminateWorkflowExecutionRequest,
+	opts ...grpc.CallOption,
+) (*adminservice.ForceTerminateWorkflowExecutionResponse, error) {
+
+	c.metricsClient.IncCounter(metrics.AdminClientForceTerminateWorkflowExecutionScope, metrics.ClientRequests)
+	sw := c.metricsClient.StartTimer(metrics.AdminClientForceTerminateWorkflowExecutionScope, metrics.ClientLatency)
+	resp, err := c.client.ForceTerminateWorkflowExecution(ctx, request, opts...)
+	sw.Stop()
+
+	if err != nil {
+		c.metricsClient.IncCounter(metrics.AdminClientForceTerminateWorkflowExecutionScope, metrics.ClientFailures)
+	}
+	return resp, err
+}
+
+func (c *metricClient) EstimateTaskListBacklog(
+	ctx context.Context,
+	request *adminservice.EstimateTaskListBacklogRequest,
+	opts ...grpc.CallOption,
+) (*adminservice.EstimateTaskListBacklogResponse, error) {
+
+	c.metricsClient.IncCounter(metrics.AdminClientEstimateTaskListBacklogScope, metrics.ClientRequests)
+	sw := c.metricsClient.StartTimer(metrics.AdminClientEstimateTaskListBacklogScope, metrics.ClientLatency)
+	resp, err := c.client.EstimateTaskListBacklog(ctx, request, opts...)
+	sw.Stop()
+
+	if err != nil {
+		c.metricsClient.IncCounter(metrics.AdminClientEstimateTaskListBacklogScope, metrics.ClientFailures)
+	}
+	return resp, err
+}
+
+func (c *metricClient) ListOwnedTaskLists(
+	ctx context.Context,
+	request *adminservice.ListOwnedTaskListsRequest,
+	opts ...grpc.CallOption,
+) (*adminservice.ListOwnedTaskListsResponse, error) {
+
+	c.metricsClient.IncCounter(metrics.AdminClientListOwnedTaskListsScope, metrics.ClientRequests)
+	sw := c.metricsClient.StartTimer(metrics.AdminClientListOwnedTaskListsScope, metrics.ClientLatency)
+	resp, err := c.client.ListOwnedTaskLists(ctx, request, opts...)
+	sw.Stop()
+
+	if err != nil {
+		c.metricsClient.IncCounter(metrics.AdminClientListOwnedTaskListsScope, metrics.ClientFailures)
+	}
+	return resp, err
+}
+
+func (c *metricClient) DescribeTaskListStatus(
+	ctx context.Context,
+	request *adminservice.DescribeTaskListStatusRequest,
+	opts ...grpc.CallOption,
+) (*adminservice.DescribeTaskListStatusResponse, error) {
+
+	c.metricsClient.IncCounter(metrics.AdminClientDescribeTaskListStatusScope, metrics.ClientRequests)
+	sw := c.metricsClient.StartTimer(metrics.AdminClientDescribeTaskListStatusScope, metrics.ClientLatency)
+	resp, err := c.client.DescribeTaskListStatus(ctx, request, opts...)
+	sw.Stop()
+
+	if err != nil {
+		c.metricsClient.IncCounter(metrics.AdminClientDescribeTaskListStatusScope, metrics.ClientFailures)
+	}
+	return resp, err
+}
+
+func (c *metricClient) InjectTask(
+	ctx context.Context,
+	request *adminservice.InjectTaskRequest,
+	opts ...grpc.CallOption,
+) (*adminservice.InjectTaskResponse, error) {
+
+	c.metricsClient.IncCounter(metrics.AdminClientInjectTaskScope, metrics.ClientRequests)
+	sw := c.metricsClient.StartTimer(metrics.AdminClientInjectTaskScope, metrics.ClientLatency)
+	resp, err := c.client.InjectTask(ctx, request, opts...)
+	sw.Stop()
+
+	if err != nil {
+		c.metricsClient.IncCounter(metrics.AdminClientInjectTaskScope, metrics.ClientFailures)
+	}
+	return resp, err
+}
+
+func (c *metricClient) ListWorkflowTimers(
+	ctx context.Context,
+	request *adminservice.ListWorkflowTimersRequest,
+	opts ...grpc.CallOption,
+) (*adminservice.ListWorkflowTimersResponse, error) {
+
+	c.metricsClient.IncCounter(metrics.AdminClientListWorkflowTimersScope, metrics.ClientRequests)
+	sw := c.metricsClient.StartTimer(metrics.AdminClientListWorkflowTimersScope, metrics.ClientLatency)
+	resp, err := c.client.ListWorkflowTimers(ctx, request, opts...)
+	sw.Stop()
+
+	if err != nil {
+		c.metricsClient.IncCounter(metrics.AdminClientListWorkflowTimersScope, metrics.ClientFailures)
+	}
+	return resp, err
+}
+
+func (c *metricClient) CancelOrphanedTimer(
+	ctx context.Context,
+	request *adminservice.CancelOrphanedTimerRequest,
+	opts ...grpc.CallOption,
+) (*adminservice.CancelOrphanedTimerResponse, error) {
+
+	c.metricsClient.IncCounter(metrics.AdminClientCancelOrphanedTimerScope, metrics.ClientRequests)
+	sw := c.metricsClient.StartTimer(metrics.AdminClientCancelOrphanedTimerScope, metrics.ClientLatency)
+	resp, err := c.client.CancelOrphanedTimer(ctx, request, opts...)
+	sw.Stop()
+
+	if err != nil {
+		c.metricsClient.IncCounter(metrics.AdminClientCancelOrphanedTimerScope, metrics.ClientFailures)
+	}
+	return resp, err
+}
+
+func (c *metricClient) CompleteTasksBelow(
+	ctx context.Context,
+	request *adminservice.CompleteTasksBelowRequest,
+	opts ...grpc.CallOption,
+) (*adminservice.CompleteTasksBelowResponse, error) {
+
+	c.metricsClient.IncCounter(metrics.AdminClientCompleteTasksBelowScope, metrics.ClientRequests)
+	sw := c.metricsClient.StartTimer(metrics.AdminClientCompleteTasksBelowScope, metrics.ClientLatency)
+	resp, err := c.client.CompleteTasksBelow(ctx, request, opts...)
+	sw.Stop()
+
+	if err != nil {
+		c.metricsClient.IncCounter(metrics.AdminClientCompleteTasksBelowScope, metrics.ClientFailures)
+	}
+	return resp, err
+}
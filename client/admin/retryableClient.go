@@ -282,6 +282,22 @@ func (c *retryableClient) MergeDLQMessages(
 	return resp, err
 }
 
+func (c *retryableClient) MergeOneDLQMessage(
+	ctx context.Context,
+	request *adminservice.MergeOneDLQMessageRequest,
+	opts ...grpc.CallOption,
+) (*adminservice.MergeOneDLQMessageResponse, error) {
+
+	var resp *adminservice.MergeOneDLQMessageResponse
+	op := func() error {
+		var err error
+		resp, err = c.client.MergeOneDLQMessage(ctx, request, opts...)
+		return err
+	}
+	err := backoff.Retry(op, c.policy, c.isRetryable)
+	return resp, err
+}
+
 func (c *retryableClient) RefreshWorkflowTasks(
 	ctx context.Context,
 	request *adminservice.RefreshWorkflowTasksRequest,
@@ -297,3 +313,211 @@ func (c *retryableClient) RefreshWorkflowTasks(
 	err := backoff.Retry(op, c.policy, c.isRetryable)
 	return resp, err
 }
+
+func (c *retryableClient) ResetReplicationAckLevel(
+	ctx context.Context,
+	request *adminservice.ResetReplicationAckLevelRequest,
+	opts ...grpc.CallOption,
+) (*adminservice.ResetReplicationAckLevelResponse, error) {
+
+	var resp *adminservice.ResetReplicationAckLevelResponse
+	op := func() error {
+		var err error
+		resp, err = c.client.ResetReplicationAckLevel(ctx, request, opts...)
+		return err
+	}
+	err := backoff.Retry(op, c.policy, c.isRetryable)
+	return resp, err
+}
+
+func (c *retryableClient) DLQReplicationTask(
+	ctx context.Context,
+	request *adminservice.DLQReplicationTaskRequest,
+	opts ...grpc.CallOption,
+) (*adminservice.DLQReplicationTaskResponse, error) {
+
+	var resp *adminservice.DLQReplicationTaskResponse
+	op := func() error {
+		var err error
+		resp, err = c.client.DLQReplicationTask(ctx, request, opts...)
+		return err
+	}
+	err := backoff.Retry(op, c.policy, c.isRetryable)
+	return resp, err
+}
+
+func (c *retryableClient) RefreshNamespaceCache(
+	ctx context.Context,
+	request *adminservice.RefreshNamespaceCacheRequest,
+	opts ...grpc.CallOption,
+) (*adminservice.RefreshNamespaceCacheResponse, error) {
+
+	var resp *adminservice.RefreshNamespaceCacheResponse
+	op := func() error {
+		var err error
+		resp, err = c.client.RefreshNamespaceCache(ctx, request, opts...)
+		return err
+	}
+	err := backoff.Retry(op, c.policy, c.isRetryable)
+	return resp, err
+}
+
+func (c *retryableClient) TailWorkflowExecutionHistory(
+	ctx context.Context,
+	request *adminservice.TailWorkflowExecutionHistoryRequest,
+	opts ...grpc.CallOption,
+) (adminservice.AdminService_TailWorkflowExecutionHistoryClient, error) {
+
+	var stream adminservice.AdminService_TailWorkflowExecutionHistoryClient
+	op := func() error {
+		var err error
+		stream, err = c.client.TailWorkflowExecutionHistory(ctx, request, opts...)
+		return err
+	}
+	err := backoff.Retry(op, c.policy, c.isRetryable)
+	return stream, err
+}
+
+func (c *retryableClient) RepairShardAckLevels(
+	ctx context.Context,
+	request *adminservice.RepairShardAckLevelsRequest,
+	opts ...grpc.CallOption,
+) (*adminservice.RepairShardAckLevelsResponse, error) {
+
+	var resp *adminservice.RepairShardAckLevelsResponse
+	op := func() error {
+		var err error
+		resp, err = c.client.RepairShardAckLevels(ctx, request, opts...)
+		return err
+	}
+	err := backoff.Retry(op, c.policy, c.isRetryable)
+	return resp, err
+}
+
+func (c *retryableClient) ForceTerminateWorkflowExecution(
+	ctx context.Context,
+	request *adminservice.ForceTerminateWorkflowExecutionRequest,
+	opts ...grpc.CallOption,
+) (*adminservice.ForceTerminateWorkflowExecutionResponse, error) {
+
+	var resp *adminservice.ForceTerminateWorkflowExecutionResponse
+	op := func() error {
+		var err error
+		resp, err = c.client.ForceTerminateWorkflowExecution(ctx, request, opts...)
+		return err
+	}
+	err := backoff.Retry(op, c.policy, c.isRetryable)
+	return resp, err
+}
+
+func (c *retryableClient) EstimateTaskListBacklog(
+	ctx context.Context,
+	request *adminservice.EstimateTaskListBacklogRequest,
+	opts ...grpc.CallOption,
+) (*adminservice.EstimateTaskListBacklogResponse, error) {
+
+	var resp *adminservice.EstimateTaskListBacklogResponse
+	op := func() error {
+		var err error
+		resp, err = c.client.EstimateTaskListBacklog(ctx, request, opts...)
+		return err
+	}
+	err := backoff.Retry(op, c.policy, c.isRetryable)
+	return resp, err
+}
+
+func (c *retryableClient) ListOwnedTaskLists(
+	ctx context.Context,
+	request *adminservice.ListOwnedTaskListsRequest,
+	opts ...grpc.CallOption,
+) (*adminservice.ListOwnedTaskListsResponse, error) {
+
+	var resp *adminservice.ListOwnedTaskListsResponse
+	op := func() error {
+		var err error
+		resp, err = c.client.ListOwnedTaskLists(ctx, request, opts...)
+		return err
+	}
+	err := backoff.Retry(op, c.policy, c.isRetryable)
+	return resp, err
+}
+
+func (c *retryableClient) DescribeTaskListStatus(
+	ctx context.Context,
+	request *adminservice.DescribeTaskListStatusRequest,
+	opts ...grpc.CallOption,
+) (*adminservice.DescribeTaskListStatusResponse, error) {
+
+	var resp *adminservice.DescribeTaskListStatusResponse
+	op := func() error {
+		var err error
+		resp, err = c.client.DescribeTaskListStatus(ctx, request, opts...)
+		return err
+	}
+	err := backoff.Retry(op, c.policy, c.isRetryable)
+	return resp, err
+}
+
+func (c *retryableClient) InjectTask(
+	ctx context.Context,
+	request *adminservice.InjectTaskRequest,
+	opts ...grpc.CallOption,
+) (*adminservice.InjectTaskResponse, error) {
+
+	var resp *adminservice.InjectTaskResponse
+	op := func() error {
+		var err error
+		resp, err = c.client.InjectTask(ctx, request, opts...)
+		return err
+	}
+	err := backoff.Retry(op, c.policy, c.isRetryable)
+	return resp, err
+}
+
+func (c *retryableClient) ListWorkflowTimers(
+	ctx context.Context,
+	request *adminservice.ListWorkflowTimersRequest,
+	opts ...grpc.CallOption,
+) (*adminservice.ListWorkflowTimersResponse, error) {
+
+	var resp *adminservice.ListWorkflowTimersResponse
+	op := func() error {
+		var err error
+		resp, err = c.client.ListWorkflowTimers(ctx, request, opts...)
+		return err
+	}
+	err := backoff.Retry(op, c.policy, c.isRetryable)
+	return resp, err
+}
+
+func (c *retryableClient) CancelOrphanedTimer(
+	ctx context.Context,
+	request *adminservice.CancelOrphanedTimerRequest,
+	opts ...grpc.CallOption,
+) (*adminservice.CancelOrphanedTimerResponse, error) {
+
+	var resp *adminservice.CancelOrphanedTimerResponse
+	op := func() error {
+		var err error
+		resp, err = c.client.CancelOrphanedTimer(ctx, request, opts...)
+		return err
+	}
+	err := backoff.Retry(op, c.policy, c.isRetryable)
+	return resp, err
+}
+
+func (c *retryableClient) CompleteTasksBelow(
+	ctx context.Context,
+	request *adminservice.CompleteTasksBelowRequest,
+	opts ...grpc.CallOption,
+) (*adminservice.CompleteTasksBelowResponse, error) {
+
+	var resp *adminservice.CompleteTasksBelowResponse
+	op := func() error {
+		var err error
+		resp, err = c.client.CompleteTasksBelow(ctx, request, opts...)
+		return err
+	}
+	err := backoff.Retry(op, c.policy, c.isRetryable)
+	return resp, err
+}
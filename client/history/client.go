@@ -1027,6 +1027,169 @@ func (c *clientImpl) RefreshWorkflowTasks(
 	return response, nil
 }
 
+func (c *clientImpl) ResetReplicationAckLevel(
+	ctx context.Context,
+	request *historyservice.ResetReplicationAckLevelRequest,
+	opts ...grpc.CallOption,
+) (*historyservice.ResetReplicationAckLevelResponse, error) {
+
+	client, err := c.getClientForShardID(int(request.GetShardId()))
+	if err != nil {
+		return nil, err
+	}
+	return client.ResetReplicationAckLevel(ctx, request, opts...)
+}
+
+func (c *clientImpl) GetPendingChildren(
+	ctx context.Context,
+	request *historyservice.GetPendingChildrenRequest,
+	opts ...grpc.CallOption,
+) (*historyservice.GetPendingChildrenResponse, error) {
+
+	client, err := c.getClientForWorkflowID(request.GetExecution().GetWorkflowId())
+	if err != nil {
+		return nil, err
+	}
+	var response *historyservice.GetPendingChildrenResponse
+	op := func(ctx context.Context, client historyservice.HistoryServiceClient) error {
+		var err error
+		ctx, cancel := c.createContext(ctx)
+		defer cancel()
+		response, err = client.GetPendingChildren(ctx, request, opts...)
+		return err
+	}
+	err = c.executeWithRedirect(ctx, client, op)
+	if err != nil {
+		return nil, err
+	}
+	return response, nil
+}
+
+func (c *clientImpl) DLQReplicationTask(
+	ctx context.Context,
+	request *historyservice.DLQReplicationTaskRequest,
+	opts ...grpc.CallOption,
+) (*historyservice.DLQReplicationTaskResponse, error) {
+
+	client, err := c.getClientForShardID(int(request.GetShardId()))
+	if err != nil {
+		return nil, err
+	}
+	return client.DLQReplicationTask(ctx, request, opts...)
+}
+
+func (c *clientImpl) RefreshNamespaceCache(
+	ctx context.Context,
+	request *historyservice.RefreshNamespaceCacheRequest,
+	opts ...grpc.CallOption,
+) (*historyservice.RefreshNamespaceCacheResponse, error) {
+
+	ret, err := c.clients.GetClientForClientKey(request.GetHostAddress())
+	if err != nil {
+		return nil, err
+	}
+	client := ret.(historyservice.HistoryServiceClient)
+	ctx, cancel := c.createContext(ctx)
+	defer cancel()
+	return client.RefreshNamespaceCache(ctx, request, opts...)
+}
+
+func (c *clientImpl) TailWorkflowExecutionHistory(
+	ctx context.Context,
+	request *historyservice.TailWorkflowExecutionHistoryRequest,
+	opts ...grpc.CallOption,
+) (historyservice.HistoryService_TailWorkflowExecutionHistoryClient, error) {
+
+	client, err := c.getClientForWorkflowID(request.Execution.GetWorkflowId())
+	if err != nil {
+		return nil, err
+	}
+	// the stream stays open until the workflow closes, so the call is not bounded by the client timeout
+	return client.TailWorkflowExecutionHistory(ctx, request, opts...)
+}
+
+func (c *clientImpl) RepairShardAckLevels(
+	ctx context.Context,
+	request *historyservice.RepairShardAckLevelsRequest,
+	opts ...grpc.CallOption,
+) (*historyservice.RepairShardAckLevelsResponse, error) {
+
+	client, err := c.getClientForShardID(int(request.GetShardId()))
+	if err != nil {
+		return nil, err
+	}
+	return client.RepairShardAckLevels(ctx, request, opts...)
+}
+
+func (c *clientImpl) ForceTerminateWorkflowExecution(
+	ctx context.Context,
+	request *historyservice.ForceTerminateWorkflowExecutionRequest,
+	opts ...grpc.CallOption,
+) (*historyservice.ForceTerminateWorkflowExecutionResponse, error) {
+	client, err := c.getClientForWorkflowID(request.GetRequest().GetExecution().GetWorkflowId())
+	var response *historyservice.ForceTerminateWorkflowExecutionResponse
+	op := func(ctx context.Context, client historyservice.HistoryServiceClient) error {
+		var err error
+		ctx, cancel := c.createContext(ctx)
+		defer cancel()
+		response, err = client.ForceTerminateWorkflowExecution(ctx, request, opts...)
+		return err
+	}
+	err = c.executeWithRedirect(ctx, client, op)
+	if err != nil {
+		return nil, err
+	}
+	return response, nil
+}
+
+func (c *clientImpl) ListWorkflowTimers(
+	ctx context.Context,
+	request *historyservice.ListWorkflowTimersRequest,
+	opts ...grpc.CallOption,
+) (*historyservice.ListWorkflowTimersResponse, error) {
+	client, err := c.getClientForWorkflowID(request.GetExecution().GetWorkflowId())
+	if err != nil {
+		return nil, err
+	}
+	var response *historyservice.ListWorkflowTimersResponse
+	op := func(ctx context.Context, client historyservice.HistoryServiceClient) error {
+		var err error
+		ctx, cancel := c.createContext(ctx)
+		defer cancel()
+		response, err = client.ListWorkflowTimers(ctx, request, opts...)
+		return err
+	}
+	err = c.executeWithRedirect(ctx, client, op)
+	if err != nil {
+		return nil, err
+	}
+	return response, nil
+}
+
+func (c *clientImpl) CancelOrphanedTimer(
+	ctx context.Context,
+	request *historyservice.CancelOrphanedTimerRequest,
+	opts ...grpc.CallOption,
+) (*historyservice.CancelOrphanedTimerResponse, error) {
+	client, err := c.getClientForWorkflowID(request.GetExecution().GetWorkflowId())
+	if err != nil {
+		return nil, err
+	}
+	var response *historyservice.CancelOrphanedTimerResponse
+	op := func(ctx context.Context, client historyservice.HistoryServiceClient) error {
+		var err error
+		ctx, cancel := c.createContext(ctx)
+		defer cancel()
+		response, err = client.CancelOrphanedTimer(ctx, request, opts...)
+		return err
+	}
+	err = c.executeWithRedirect(ctx, client, op)
+	if err != nil {
+		return nil, err
+	}
+	return response, nil
+}
+
 func (c *clientImpl) createContext(parent context.Context) (context.Context, context.CancelFunc) {
 	return context.WithTimeout(parent, c.timeout)
 }
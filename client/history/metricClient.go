@@ -656,3 +656,154 @@ func (c *metricClient) RefreshWorkflowTasks(
 	}
 	return resp, err
 }
+
+func (c *metricClient) ResetReplicationAckLevel(
+	ctx context.Context,
+	request *historyservice.ResetReplicationAckLevelRequest,
+	opts ...grpc.CallOption,
+) (*historyservice.ResetReplicationAckLevelResponse, error) {
+
+	c.metricsClient.IncCounter(metrics.HistoryClientResetReplicationAckLevelScope, metrics.ClientRequests)
+	sw := c.metricsClient.StartTimer(metrics.HistoryClientResetReplicationAckLevelScope, metrics.ClientLatency)
+	resp, err := c.client.ResetReplicationAckLevel(ctx, request, opts...)
+	sw.Stop()
+
+	if err != nil {
+		c.metricsClient.IncCounter(metrics.HistoryClientResetReplicationAckLevelScope, metrics.ClientFailures)
+	}
+	return resp, err
+}
+
+func (c *metricClient) GetPendingChildren(
+	ctx context.Context,
+	request *historyservice.GetPendingChildrenRequest,
+	opts ...grpc.CallOption,
+) (*historyservice.GetPendingChildrenResponse, error) {
+
+	c.metricsClient.IncCounter(metrics.HistoryClientGetPendingChildrenScope, metrics.ClientRequests)
+	sw := c.metricsClient.StartTimer(metrics.HistoryClientGetPendingChildrenScope, metrics.ClientLatency)
+	resp, err := c.client.GetPendingChildren(ctx, request, opts...)
+	sw.Stop()
+
+	if err != nil {
+		c.metricsClient.IncCounter(metrics.HistoryClientGetPendingChildrenScope, metrics.ClientFailures)
+	}
+	return resp, err
+}
+
+func (c *metricClient) DLQReplicationTask(
+	ctx context.Context,
+	request *historyservice.DLQReplicationTaskRequest,
+	opts ...grpc.CallOption,
+) (*historyservice.DLQReplicationTaskResponse, error) {
+
+	c.metricsClient.IncCounter(metrics.HistoryClientDLQReplicationTaskScope, metrics.ClientRequests)
+	sw := c.metricsClient.StartTimer(metrics.HistoryClientDLQReplicationTaskScope, metrics.ClientLatency)
+	resp, err := c.client.DLQReplicationTask(ctx, request, opts...)
+	sw.Stop()
+
+	if err != nil {
+		c.metricsClient.IncCounter(metrics.HistoryClientDLQReplicationTaskScope, metrics.ClientFailures)
+	}
+	return resp, err
+}
+
+func (c *metricClient) RefreshNamespaceCache(
+	ctx context.Context,
+	request *historyservice.RefreshNamespaceCacheRequest,
+	opts ...grpc.CallOption,
+) (*historyservice.RefreshNamespaceCacheResponse, error) {
+
+	c.metricsClient.IncCounter(metrics.HistoryClientRefreshNamespaceCacheScope, metrics.ClientRequests)
+	sw := c.metricsClient.StartTimer(metrics.HistoryClientRefreshNamespaceCacheScope, metrics.ClientLatency)
+	resp, err := c.client.RefreshNamespaceCache(ctx, request, opts...)
+	sw.Stop()
+
+	if err != nil {
+		c.metricsClient.IncCounter(metrics.HistoryClientRefreshNamespaceCacheScope, metrics.ClientFailures)
+	}
+	return resp, err
+}
+
+func (c *metricClient) TailWorkflowExecutionHistory(
+	ctx context.Context,
+	request *historyservice.TailWorkflowExecutionHistoryRequest,
+	opts ...grpc.CallOption,
+) (historyservice.HistoryService_TailWorkflowExecutionHistoryClient, error) {
+
+	c.metricsClient.IncCounter(metrics.HistoryClientTailWorkflowExecutionHistoryScope, metrics.ClientRequests)
+	stream, err := c.client.TailWorkflowExecutionHistory(ctx, request, opts...)
+
+	if err != nil {
+		c.metricsClient.IncCounter(metrics.HistoryClientTailWorkflowExecutionHistoryScope, metrics.ClientFailures)
+	}
+	return stream, err
+}
+
+func (c *metricClient) RepairShardAckLevels(
+	ctx context.Context,
+	request *historyservice.RepairShardAckLevelsRequest,
+	opts ...grpc.CallOption,
+) (*historyservice.RepairShardAckLevelsResponse, error) {
+
+	c.metricsClient.IncCounter(metrics.HistoryClientRepairShardAckLevelsScope, metrics.ClientRequests)
+	sw := c.metricsClient.StartTimer(metrics.HistoryClientRepairShardAckLevelsScope, metrics.ClientLatency)
+	resp, err := c.client.RepairShardAckLevels(ctx, request, opts...)
+	sw.Stop()
+
+	if err != nil {
+		c.metricsClient.IncCounter(metrics.HistoryClientRepairShardAckLevelsScope, metrics.ClientFailures)
+	}
+	return resp, err
+}
+
+func (c *metricClient) ForceTerminateWorkflowExecution(
+	ctx context.Context,
+	request *historyservice.ForceTerminateWorkflowExecutionRequest,
+	opts ...grpc.CallOption,
+) (*historyservice.ForceTerminateWorkflowExecutionResponse, error) {
+
+	c.metricsClient.IncCounter(metrics.HistoryClientForceTerminateWorkflowExecutionScope, metrics.ClientRequests)
+	sw := c.metricsClient.StartTimer(metrics.HistoryClientForceTerminateWorkflowExecutionScope, metrics.ClientLatency)
+	resp, err := c.client.ForceTerminateWorkflowExecution(ctx, request, opts...)
+	sw.Stop()
+
+	if err != nil {
+		c.metricsClient.IncCounter(metrics.HistoryClientForceTerminateWorkflowExecutionScope, metrics.ClientFailures)
+	}
+	return resp, err
+}
+
+func (c *metricClient) ListWorkflowTimers(
+	ctx context.Context,
+	request *historyservice.ListWorkflowTimersRequest,
+	opts ...grpc.CallOption,
+) (*historyservice.ListWorkflowTimersResponse, error) {
+
+	c.metricsClient.IncCounter(metrics.HistoryClientListWorkflowTimersScope, metrics.ClientRequests)
+	sw := c.metricsClient.StartTimer(metrics.HistoryClientListWorkflowTimersScope, metrics.ClientLatency)
+	resp, err := c.client.ListWorkflowTimers(ctx, request, opts...)
+	sw.Stop()
+
+	if err != nil {
+		c.metricsClient.IncCounter(metrics.HistoryClientListWorkflowTimersScope, metrics.ClientFailures)
+	}
+	return resp, err
+}
+
+func (c *metricClient) CancelOrphanedTimer(
+	ctx context.Context,
+	request *historyservice.CancelOrphanedTimerRequest,
+	opts ...grpc.CallOption,
+) (*historyservice.CancelOrphanedTimerResponse, error) {
+
+	c.metricsClient.IncCounter(metrics.HistoryClientCancelOrphanedTimerScope, metrics.ClientRequests)
+	sw := c.metricsClient.StartTimer(metrics.HistoryClientCancelOrphanedTimerScope, metrics.ClientLatency)
+	resp, err := c.client.CancelOrphanedTimer(ctx, request, opts...)
+	sw.Stop()
+
+	if err != nil {
+		c.metricsClient.IncCounter(metrics.HistoryClientCancelOrphanedTimerScope, metrics.ClientFailures)
+	}
+	return resp, err
+}
@@ -654,3 +654,154 @@ func (c *retryableClient) RefreshWorkflowTasks(
 	err := backoff.Retry(op, c.policy, c.isRetryable)
 	return resp, err
 }
+
+func (c *retryableClient) ResetReplicationAckLevel(
+	ctx context.Context,
+	request *historyservice.ResetReplicationAckLevelRequest,
+	opts ...grpc.CallOption,
+) (*historyservice.ResetReplicationAckLevelResponse, error) {
+
+	var resp *historyservice.ResetReplicationAckLevelResponse
+	op := func() error {
+		var err error
+		resp, err = c.client.ResetReplicationAckLevel(ctx, request, opts...)
+		return err
+	}
+
+	err := backoff.Retry(op, c.policy, c.isRetryable)
+	return resp, err
+}
+
+func (c *retryableClient) GetPendingChildren(
+	ctx context.Context,
+	request *historyservice.GetPendingChildrenRequest,
+	opts ...grpc.CallOption,
+) (*historyservice.GetPendingChildrenResponse, error) {
+
+	var resp *historyservice.GetPendingChildrenResponse
+	op := func() error {
+		var err error
+		resp, err = c.client.GetPendingChildren(ctx, request, opts...)
+		return err
+	}
+
+	err := backoff.Retry(op, c.policy, c.isRetryable)
+	return resp, err
+}
+
+func (c *retryableClient) DLQReplicationTask(
+	ctx context.Context,
+	request *historyservice.DLQReplicationTaskRequest,
+	opts ...grpc.CallOption,
+) (*historyservice.DLQReplicationTaskResponse, error) {
+
+	var resp *historyservice.DLQReplicationTaskResponse
+	op := func() error {
+		var err error
+		resp, err = c.client.DLQReplicationTask(ctx, request, opts...)
+		return err
+	}
+
+	err := backoff.Retry(op, c.policy, c.isRetryable)
+	return resp, err
+}
+
+func (c *retryableClient) RefreshNamespaceCache(
+	ctx context.Context,
+	request *historyservice.RefreshNamespaceCacheRequest,
+	opts ...grpc.CallOption,
+) (*historyservice.RefreshNamespaceCacheResponse, error) {
+
+	var resp *historyservice.RefreshNamespaceCacheResponse
+	op := func() error {
+		var err error
+		resp, err = c.client.RefreshNamespaceCache(ctx, request, opts...)
+		return err
+	}
+
+	err := backoff.Retry(op, c.policy, c.isRetryable)
+	return resp, err
+}
+
+func (c *retryableClient) TailWorkflowExecutionHistory(
+	ctx context.Context,
+	request *historyservice.TailWorkflowExecutionHistoryRequest,
+	opts ...grpc.CallOption,
+) (historyservice.HistoryService_TailWorkflowExecutionHistoryClient, error) {
+
+	var stream historyservice.HistoryService_TailWorkflowExecutionHistoryClient
+	op := func() error {
+		var err error
+		stream, err = c.client.TailWorkflowExecutionHistory(ctx, request, opts...)
+		return err
+	}
+
+	err := backoff.Retry(op, c.policy, c.isRetryable)
+	return stream, err
+}
+
+func (c *retryableClient) RepairShardAckLevels(
+	ctx context.Context,
+	request *historyservice.RepairShardAckLevelsRequest,
+	opts ...grpc.CallOption,
+) (*historyservice.RepairShardAckLevelsResponse, error) {
+
+	var resp *historyservice.RepairShardAckLevelsResponse
+	op := func() error {
+		var err error
+		resp, err = c.client.RepairShardAckLevels(ctx, request, opts...)
+		return err
+	}
+
+	err := backoff.Retry(op, c.policy, c.isRetryable)
+	return resp, err
+}
+
+func (c *retryableClient) ForceTerminateWorkflowExecution(
+	ctx context.Context,
+	request *historyservice.ForceTerminateWorkflowExecutionRequest,
+	opts ...grpc.CallOption,
+) (*historyservice.ForceTerminateWorkflowExecutionResponse, error) {
+
+	var resp *historyservice.ForceTerminateWorkflowExecutionResponse
+	op := func() error {
+		var err error
+		resp, err = c.client.ForceTerminateWorkflowExecution(ctx, request, opts...)
+		return err
+	}
+
+	err := backoff.Retry(op, c.policy, c.isRetryable)
+	return resp, err
+}
+
+func (c *retryableClient) ListWorkflowTimers(
+	ctx context.Context,
+	request *historyservice.ListWorkflowTimersRequest,
+	opts ...grpc.CallOption,
+) (*historyservice.ListWorkflowTimersResponse, error) {
+
+	var resp *historyservice.ListWorkflowTimersResponse
+	op := func() error {
+		var err error
+		resp, err = c.client.ListWorkflowTimers(ctx, request, opts...)
+		return err
+	}
+	err := backoff.Retry(op, c.policy, c.isRetryable)
+	return resp, err
+}
+
+func (c *retryableClient) CancelOrphanedTimer(
+	ctx context.Context,
+	request *historyservice.CancelOrphanedTimerRequest,
+	opts ...grpc.CallOption,
+) (*historyservice.CancelOrphanedTimerResponse, error) {
+
+	var resp *historyservice.CancelOrphanedTimerResponse
+	op := func() error {
+		var err error
+		resp, err = c.client.CancelOrphanedTimer(ctx, request, opts...)
+		return err
+	}
+	err := backoff.Retry(op, c.policy, c.isRetryable)
+	return resp, err
+}
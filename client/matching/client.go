@@ -199,6 +199,46 @@ func (c *clientImpl) ListTaskListPartitions(ctx context.Context, request *matchi
 	return client.ListTaskListPartitions(ctx, request, opts...)
 }
 
+func (c *clientImpl) RefreshNamespaceCache(ctx context.Context, request *matchingservice.RefreshNamespaceCacheRequest, opts ...grpc.CallOption) (*matchingservice.RefreshNamespaceCacheResponse, error) {
+	client, err := c.getClientForHost(request.GetHostAddress())
+	if err != nil {
+		return nil, err
+	}
+	ctx, cancel := c.createContext(ctx)
+	defer cancel()
+	return client.RefreshNamespaceCache(ctx, request, opts...)
+}
+
+func (c *clientImpl) ListOwnedTaskLists(ctx context.Context, request *matchingservice.ListOwnedTaskListsRequest, opts ...grpc.CallOption) (*matchingservice.ListOwnedTaskListsResponse, error) {
+	client, err := c.getClientForHost(request.GetHostAddress())
+	if err != nil {
+		return nil, err
+	}
+	ctx, cancel := c.createContext(ctx)
+	defer cancel()
+	return client.ListOwnedTaskLists(ctx, request, opts...)
+}
+
+func (c *clientImpl) InjectTask(ctx context.Context, request *matchingservice.InjectTaskRequest, opts ...grpc.CallOption) (*matchingservice.InjectTaskResponse, error) {
+	client, err := c.getClientForTasklist(request.TaskList.GetName())
+	if err != nil {
+		return nil, err
+	}
+	ctx, cancel := c.createContext(ctx)
+	defer cancel()
+	return client.InjectTask(ctx, request, opts...)
+}
+
+func (c *clientImpl) CompleteTasksBelow(ctx context.Context, request *matchingservice.CompleteTasksBelowRequest, opts ...grpc.CallOption) (*matchingservice.CompleteTasksBelowResponse, error) {
+	client, err := c.getClientForTasklist(request.TaskList.GetName())
+	if err != nil {
+		return nil, err
+	}
+	ctx, cancel := c.createContext(ctx)
+	defer cancel()
+	return client.CompleteTasksBelow(ctx, request, opts...)
+}
+
 func (c *clientImpl) createContext(parent context.Context) (context.Context, context.CancelFunc) {
 	return context.WithTimeout(parent, c.timeout)
 }
@@ -214,3 +254,11 @@ func (c *clientImpl) getClientForTasklist(key string) (matchingservice.MatchingS
 	}
 	return client.(matchingservice.MatchingServiceClient), nil
 }
+
+func (c *clientImpl) getClientForHost(hostAddress string) (matchingservice.MatchingServiceClient, error) {
+	client, err := c.clients.GetClientForClientKey(hostAddress)
+	if err != nil {
+		return nil, err
+	}
+	return client.(matchingservice.MatchingServiceClient), nil
+}
@@ -242,6 +242,78 @@ func (c *metricClient) ListTaskListPartitions(
 	return resp, err
 }
 
+func (c *metricClient) RefreshNamespaceCache(
+	ctx context.Context,
+	request *matchingservice.RefreshNamespaceCacheRequest,
+	opts ...grpc.CallOption) (*matchingservice.RefreshNamespaceCacheResponse, error) {
+
+	c.metricsClient.IncCounter(metrics.MatchingClientRefreshNamespaceCacheScope, metrics.ClientRequests)
+
+	sw := c.metricsClient.StartTimer(metrics.MatchingClientRefreshNamespaceCacheScope, metrics.ClientLatency)
+	resp, err := c.client.RefreshNamespaceCache(ctx, request, opts...)
+	sw.Stop()
+
+	if err != nil {
+		c.metricsClient.IncCounter(metrics.MatchingClientRefreshNamespaceCacheScope, metrics.ClientFailures)
+	}
+
+	return resp, err
+}
+
+func (c *metricClient) ListOwnedTaskLists(
+	ctx context.Context,
+	request *matchingservice.ListOwnedTaskListsRequest,
+	opts ...grpc.CallOption) (*matchingservice.ListOwnedTaskListsResponse, error) {
+
+	c.metricsClient.IncCounter(metrics.MatchingClientListOwnedTaskListsScope, metrics.ClientRequests)
+
+	sw := c.metricsClient.StartTimer(metrics.MatchingClientListOwnedTaskListsScope, metrics.ClientLatency)
+	resp, err := c.client.ListOwnedTaskLists(ctx, request, opts...)
+	sw.Stop()
+
+	if err != nil {
+		c.metricsClient.IncCounter(metrics.MatchingClientListOwnedTaskListsScope, metrics.ClientFailures)
+	}
+
+	return resp, err
+}
+
+func (c *metricClient) InjectTask(
+	ctx context.Context,
+	request *matchingservice.InjectTaskRequest,
+	opts ...grpc.CallOption) (*matchingservice.InjectTaskResponse, error) {
+
+	c.metricsClient.IncCounter(metrics.MatchingClientInjectTaskScope, metrics.ClientRequests)
+
+	sw := c.metricsClient.StartTimer(metrics.MatchingClientInjectTaskScope, metrics.ClientLatency)
+	resp, err := c.client.InjectTask(ctx, request, opts...)
+	sw.Stop()
+
+	if err != nil {
+		c.metricsClient.IncCounter(metrics.MatchingClientInjectTaskScope, metrics.ClientFailures)
+	}
+
+	return resp, err
+}
+
+func (c *metricClient) CompleteTasksBelow(
+	ctx context.Context,
+	request *matchingservice.CompleteTasksBelowRequest,
+	opts ...grpc.CallOption) (*matchingservice.CompleteTasksBelowResponse, error) {
+
+	c.metricsClient.IncCounter(metrics.MatchingClientCompleteTasksBelowScope, metrics.ClientRequests)
+
+	sw := c.metricsClient.StartTimer(metrics.MatchingClientCompleteTasksBelowScope, metrics.ClientLatency)
+	resp, err := c.client.CompleteTasksBelow(ctx, request, opts...)
+	sw.Stop()
+
+	if err != nil {
+		c.metricsClient.IncCounter(metrics.MatchingClientCompleteTasksBelowScope, metrics.ClientFailures)
+	}
+
+	return resp, err
+}
+
 func (c *metricClient) emitForwardedFromStats(scope int, forwardedFrom string, taskList *tasklistpb.TaskList) {
 	if taskList == nil {
 		return
@@ -189,3 +189,67 @@ func (c *retryableClient) ListTaskListPartitions(
 	err := backoff.Retry(op, c.policy, c.isRetryable)
 	return resp, err
 }
+
+func (c *retryableClient) RefreshNamespaceCache(
+	ctx context.Context,
+	request *matchingservice.RefreshNamespaceCacheRequest,
+	opts ...grpc.CallOption) (*matchingservice.RefreshNamespaceCacheResponse, error) {
+
+	var resp *matchingservice.RefreshNamespaceCacheResponse
+	op := func() error {
+		var err error
+		resp, err = c.client.RefreshNamespaceCache(ctx, request, opts...)
+		return err
+	}
+
+	err := backoff.Retry(op, c.policy, c.isRetryable)
+	return resp, err
+}
+
+func (c *retryableClient) ListOwnedTaskLists(
+	ctx context.Context,
+	request *matchingservice.ListOwnedTaskListsRequest,
+	opts ...grpc.CallOption) (*matchingservice.ListOwnedTaskListsResponse, error) {
+
+	var resp *matchingservice.ListOwnedTaskListsResponse
+	op := func() error {
+		var err error
+		resp, err = c.client.ListOwnedTaskLists(ctx, request, opts...)
+		return err
+	}
+
+	err := backoff.Retry(op, c.policy, c.isRetryable)
+	return resp, err
+}
+
+func (c *retryableClient) InjectTask(
+	ctx context.Context,
+	request *matchingservice.InjectTaskRequest,
+	opts ...grpc.CallOption) (*matchingservice.InjectTaskResponse, error) {
+
+	var resp *matchingservice.InjectTaskResponse
+	op := func() error {
+		var err error
+		resp, err = c.client.InjectTask(ctx, request, opts...)
+		return err
+	}
+
+	err := backoff.Retry(op, c.policy, c.isRetryable)
+	return resp, err
+}
+
+func (c *retryableClient) CompleteTasksBelow(
+	ctx context.Context,
+	request *matchingservice.CompleteTasksBelowRequest,
+	opts ...grpc.CallOption) (*matchingservice.CompleteTasksBelowResponse, error) {
+
+	var resp *matchingservice.CompleteTasksBelowResponse
+	op := func() error {
+		var err error
+		resp, err = c.client.CompleteTasksBelow(ctx, request, opts...)
+		return err
+	}
+
+	err := backoff.Retry(op, c.policy, c.isRetryable)
+	return resp, err
+}
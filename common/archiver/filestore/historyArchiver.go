@@ -22,7 +22,10 @@
 
 // Each Archive() request results in a file named in the format of
 // hash(namespaceID, workflowID, runID)_version.history being created in the specified
-// directory. Workflow histories stored in that file are encoded in JSON format.
+// directory. Workflow histories stored in that file are encoded in JSON format. A request
+// archiving a bounded range of the history instead creates a file named
+// hash(namespaceID, workflowID, runID)_version_firstEventID.history, so that ranges of the
+// same history are kept side by side.
 
 // The Get() method retrieves the archived histories from the directory specified in the
 // URI. It optionally takes in a NextPageToken which specifies the workflow close failover
@@ -30,7 +33,7 @@
 // NextPageToken, caller can also provide a close failover version, in which case, Get() method
 // will return history batches starting from the beginning of that history version. If neither
 // of NextPageToken or close failover version is specified, the highest close failover version
-// will be picked.
+// will be picked. All files archived for the version are merged in event order.
 
 package filestore
 
@@ -39,6 +42,7 @@ import (
 	"errors"
 	"os"
 	"path"
+	"sort"
 	"strconv"
 
 	eventpb "go.temporal.io/temporal-proto/event"
@@ -179,6 +183,10 @@ func (h *historyArchiver) Archive(
 	}
 
 	filename := constructHistoryFilename(request.NamespaceID, request.WorkflowID, request.RunID, request.CloseFailoverVersion)
+	if request.FromEventID > 0 || request.ToEventID > 0 {
+		firstEventID := historyBatches[0].Events[0].GetEventId()
+		filename = constructHistoryRangeFilename(request.NamespaceID, request.WorkflowID, request.RunID, request.CloseFailoverVersion, firstEventID)
+	}
 	if err := writeFile(path.Join(dirPath, filename), encodedHistoryBatches, h.fileMode); err != nil {
 		logger.Error(archiver.ArchiveNonRetriableErrorMsg, tag.ArchivalArchiveFailReason(errWriteFile), tag.Error(err))
 		return err
@@ -231,23 +239,10 @@ func (h *historyArchiver) Get(
 		}
 	}
 
-	filename := constructHistoryFilename(request.NamespaceID, request.WorkflowID, request.RunID, token.CloseFailoverVersion)
-	filepath := path.Join(dirPath, filename)
-	exists, err = fileExists(filepath)
-	if err != nil {
-		return nil, serviceerror.NewInternal(err.Error())
+	historyBatches, err := getHistoryBatches(dirPath, request, token.CloseFailoverVersion)
+	if err == archiver.ErrHistoryNotExist {
+		return nil, serviceerror.NewNotFound(err.Error())
 	}
-	if !exists {
-		return nil, serviceerror.NewNotFound(archiver.ErrHistoryNotExist.Error())
-	}
-
-	encodedHistoryBatches, err := readFile(filepath)
-	if err != nil {
-		return nil, serviceerror.NewInternal(err.Error())
-	}
-
-	encoder := codec.NewJSONPBEncoder()
-	historyBatches, err := encoder.DecodeHistories(encodedHistoryBatches)
 	if err != nil {
 		return nil, serviceerror.NewInternal(err.Error())
 	}
@@ -303,6 +298,62 @@ func getNextHistoryBlob(ctx context.Context, historyIterator archiver.HistoryIte
 	return historyBlob, nil
 }
 
+// getHistoryBatches reads all history files archived for the given close failover version and
+// merges them in event order. Batches already covered by a previous file are skipped, as a range
+// may have been archived again as part of a larger one.
+func getHistoryBatches(dirPath string, request *archiver.GetHistoryRequest, version int64) ([]*eventpb.History, error) {
+	filenames, err := listFilesByPrefix(dirPath, constructHistoryFilenamePrefix(request.NamespaceID, request.WorkflowID, request.RunID))
+	if err != nil {
+		return nil, err
+	}
+
+	firstEventIDs := make(map[string]int64)
+	for _, filename := range filenames {
+		fileVersion, err := extractCloseFailoverVersion(filename)
+		if err != nil || fileVersion != version {
+			continue
+		}
+		firstEventID, err := extractFirstEventID(filename)
+		if err != nil {
+			continue
+		}
+		firstEventIDs[filename] = firstEventID
+	}
+	if len(firstEventIDs) == 0 {
+		return nil, archiver.ErrHistoryNotExist
+	}
+
+	sortedFilenames := make([]string, 0, len(firstEventIDs))
+	for filename := range firstEventIDs {
+		sortedFilenames = append(sortedFilenames, filename)
+	}
+	sort.Slice(sortedFilenames, func(i, j int) bool {
+		return firstEventIDs[sortedFilenames[i]] < firstEventIDs[sortedFilenames[j]]
+	})
+
+	encoder := codec.NewJSONPBEncoder()
+	var historyBatches []*eventpb.History
+	lastEventID := common.EmptyEventID
+	for _, filename := range sortedFilenames {
+		encodedHistoryBatches, err := readFile(path.Join(dirPath, filename))
+		if err != nil {
+			return nil, err
+		}
+		batches, err := encoder.DecodeHistories(encodedHistoryBatches)
+		if err != nil {
+			return nil, err
+		}
+		for _, batch := range batches {
+			if len(batch.Events) == 0 || batch.Events[0].GetEventId() <= lastEventID {
+				continue
+			}
+			historyBatches = append(historyBatches, batch)
+			lastEventID = batch.Events[len(batch.Events)-1].GetEventId()
+		}
+	}
+	return historyBatches, nil
+}
+
 func getHighestVersion(dirPath string, request *archiver.GetHistoryRequest) (*int64, error) {
 	filenames, err := listFilesByPrefix(dirPath, constructHistoryFilenamePrefix(request.NamespaceID, request.WorkflowID, request.RunID))
 	if err != nil {
@@ -526,6 +526,110 @@ func (s *historyArchiverSuite) TestArchiveAndGet() {
 	s.Equal(s.historyBatchesV100, response.HistoryBatches)
 }
 
+func (s *historyArchiverSuite) TestArchiveAndGet_Ranges() {
+	mockCtrl := gomock.NewController(s.T())
+	defer mockCtrl.Finish()
+
+	firstRange := []*eventpb.History{
+		{
+			Events: []*eventpb.HistoryEvent{
+				{
+					EventId:   common.FirstEventID,
+					Timestamp: time.Now().UnixNano(),
+					Version:   testCloseFailoverVersion,
+				},
+				{
+					EventId:   common.FirstEventID + 1,
+					Timestamp: time.Now().UnixNano(),
+					Version:   testCloseFailoverVersion,
+				},
+			},
+		},
+	}
+	secondRange := []*eventpb.History{
+		{
+			Events: []*eventpb.HistoryEvent{
+				{
+					EventId:   common.FirstEventID + 2,
+					Timestamp: time.Now().UnixNano(),
+					Version:   testCloseFailoverVersion,
+				},
+			},
+		},
+		{
+			Events: []*eventpb.HistoryEvent{
+				{
+					EventId:   testNextEventID - 1,
+					Timestamp: time.Now().UnixNano(),
+					Version:   testCloseFailoverVersion,
+				},
+			},
+		},
+	}
+
+	dir, err := ioutil.TempDir("", "TestArchiveAndGet_Ranges")
+	s.NoError(err)
+	defer os.RemoveAll(dir)
+	URI, err := archiver.NewURI("file://" + dir)
+	s.NoError(err)
+
+	// archive the later range first, Get must still return the history in event order
+	ranges := []struct {
+		fromEventID    int64
+		toEventID      int64
+		historyBatches []*eventpb.History
+		isLast         bool
+	}{
+		{fromEventID: common.FirstEventID + 2, toEventID: 0, historyBatches: secondRange, isLast: true},
+		{fromEventID: common.FirstEventID, toEventID: common.FirstEventID + 1, historyBatches: firstRange, isLast: false},
+	}
+	for _, r := range ranges {
+		historyIterator := archiver.NewMockHistoryIterator(mockCtrl)
+		historyBlob := &archiverproto.HistoryBlob{
+			Header: &archiverproto.HistoryBlobHeader{
+				IsLast: r.isLast,
+			},
+			Body: r.historyBatches,
+		}
+		gomock.InOrder(
+			historyIterator.EXPECT().HasNext().Return(true),
+			historyIterator.EXPECT().Next().Return(historyBlob, nil),
+			historyIterator.EXPECT().HasNext().Return(false),
+		)
+
+		historyArchiver := s.newTestHistoryArchiver(historyIterator)
+		archiveRequest := &archiver.ArchiveHistoryRequest{
+			NamespaceID:          testNamespaceID,
+			Namespace:            testNamespace,
+			WorkflowID:           testWorkflowID,
+			RunID:                testRunID,
+			BranchToken:          testBranchToken,
+			NextEventID:          testNextEventID,
+			CloseFailoverVersion: testCloseFailoverVersion,
+			FromEventID:          r.fromEventID,
+			ToEventID:            r.toEventID,
+		}
+		err = historyArchiver.Archive(context.Background(), URI, archiveRequest)
+		s.NoError(err)
+
+		expectedFilename := constructHistoryRangeFilename(testNamespaceID, testWorkflowID, testRunID, testCloseFailoverVersion, r.fromEventID)
+		s.assertFileExists(path.Join(dir, expectedFilename))
+	}
+
+	historyArchiver := s.newTestHistoryArchiver(nil)
+	getRequest := &archiver.GetHistoryRequest{
+		NamespaceID: testNamespaceID,
+		WorkflowID:  testWorkflowID,
+		RunID:       testRunID,
+		PageSize:    testPageSize,
+	}
+	response, err := historyArchiver.Get(context.Background(), URI, getRequest)
+	s.NoError(err)
+	s.NotNil(response)
+	s.Nil(response.NextPageToken)
+	s.Equal(append(firstRange, secondRange...), response.HistoryBatches)
+}
+
 func (s *historyArchiverSuite) newTestHistoryArchiver(historyIterator archiver.HistoryIterator) *historyArchiver {
 	config := &config.FilestoreArchiver{
 		FileMode: testFileModeStr,
@@ -35,6 +35,7 @@ import (
 	eventpb "go.temporal.io/temporal-proto/event"
 
 	archiverproto "github.com/temporalio/temporal/.gen/proto/archiver"
+	"github.com/temporalio/temporal/common"
 	"github.com/temporalio/temporal/common/archiver"
 	"github.com/temporalio/temporal/common/codec"
 )
@@ -188,6 +189,13 @@ func constructHistoryFilename(namespaceID, workflowID, runID string, version int
 	return fmt.Sprintf("%s_%v.history", combinedHash, version)
 }
 
+// constructHistoryRangeFilename constructs the filename of an archived history range, which is
+// keyed by the ID of the first event in the range so that ranges do not overwrite each other
+func constructHistoryRangeFilename(namespaceID, workflowID, runID string, version int64, firstEventID int64) string {
+	combinedHash := constructHistoryFilenamePrefix(namespaceID, workflowID, runID)
+	return fmt.Sprintf("%s_%v_%v.history", combinedHash, version, firstEventID)
+}
+
 func constructHistoryFilenamePrefix(namespaceID, workflowID, runID string) string {
 	return strings.Join([]string{hash(namespaceID), hash(workflowID), hash(runID)}, "")
 }
@@ -225,12 +233,28 @@ func extractCloseFailoverVersion(filename string) (int64, error) {
 	filenameParts := strings.FieldsFunc(filename, func(r rune) bool {
 		return r == '_' || r == '.'
 	})
-	if len(filenameParts) != 3 {
+	if len(filenameParts) != 3 && len(filenameParts) != 4 {
 		return -1, errors.New("unknown filename structure")
 	}
 	return strconv.ParseInt(filenameParts[1], 10, 64)
 }
 
+// extractFirstEventID returns the ID of the first event stored in a history file.
+// Files holding the full history are not keyed by event ID and start at the first event.
+func extractFirstEventID(filename string) (int64, error) {
+	filenameParts := strings.FieldsFunc(filename, func(r rune) bool {
+		return r == '_' || r == '.'
+	})
+	switch len(filenameParts) {
+	case 3:
+		return common.FirstEventID, nil
+	case 4:
+		return strconv.ParseInt(filenameParts[2], 10, 64)
+	default:
+		return -1, errors.New("unknown filename structure")
+	}
+}
+
 func historyMutated(request *archiver.ArchiveHistoryRequest, historyBatches []*eventpb.History, isLast bool) bool {
 	lastBatch := historyBatches[len(historyBatches)-1].Events
 	lastEvent := lastBatch[len(lastBatch)-1]
@@ -311,6 +311,11 @@ func (s *UtilSuite) TestExtractCloseFailoverVersion() {
 			expectedVersion: -100,
 			expectedErr:     false,
 		},
+		{
+			filename:        "11936904199538907273367046253745284795510285995943906173973_5_12.history",
+			expectedVersion: 5,
+			expectedErr:     false,
+		},
 	}
 
 	for _, tc := range testCases {
@@ -324,6 +329,43 @@ func (s *UtilSuite) TestExtractCloseFailoverVersion() {
 	}
 }
 
+func (s *UtilSuite) TestExtractFirstEventID() {
+	testCases := []struct {
+		filename             string
+		expectedFirstEventID int64
+		expectedErr          bool
+	}{
+		{
+			filename:             "11936904199538907273367046253745284795510285995943906173973_5.history",
+			expectedFirstEventID: common.FirstEventID,
+			expectedErr:          false,
+		},
+		{
+			filename:             "11936904199538907273367046253745284795510285995943906173973_5_12.history",
+			expectedFirstEventID: 12,
+			expectedErr:          false,
+		},
+		{
+			filename:    "history",
+			expectedErr: true,
+		},
+		{
+			filename:    "some_random_name.history",
+			expectedErr: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		firstEventID, err := extractFirstEventID(tc.filename)
+		if tc.expectedErr {
+			s.Error(err)
+		} else {
+			s.NoError(err)
+			s.Equal(tc.expectedFirstEventID, firstEventID)
+		}
+	}
+}
+
 func (s *UtilSuite) TestHistoryMutated() {
 	testCases := []struct {
 		historyBatches []*eventpb.History
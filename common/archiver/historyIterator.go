@@ -95,9 +95,13 @@ func newHistoryIterator(
 	historyV2Manager persistence.HistoryManager,
 	targetHistoryBlobSize int,
 ) *historyIterator {
+	firstEventID := common.FirstEventID
+	if request.FromEventID > 0 {
+		firstEventID = request.FromEventID
+	}
 	return &historyIterator{
 		historyIteratorState: historyIteratorState{
-			NextEventID:       common.FirstEventID,
+			NextEventID:       firstEventID,
 			FinishedIteration: false,
 		},
 		request:               request,
@@ -131,12 +135,14 @@ func (i *historyIterator) Next() (*archivergenpb.HistoryBlob, error) {
 		NamespaceId:          i.request.NamespaceID,
 		WorkflowId:           i.request.WorkflowID,
 		RunId:                i.request.RunID,
-		IsLast:               i.FinishedIteration,
+		IsLast:               i.FinishedIteration && i.reachedHistoryEnd(lastEvent.EventId),
 		FirstFailoverVersion: firstEvent.Version,
 		LastFailoverVersion:  lastEvent.Version,
 		FirstEventId:         firstEvent.EventId,
 		LastEventId:          lastEvent.EventId,
 		EventCount:           eventCount,
+		RangeFirstEventId:    i.request.FromEventID,
+		RangeLastEventId:     i.request.ToEventID,
 	}
 
 	return &archivergenpb.HistoryBlob{
@@ -161,8 +167,12 @@ func (i *historyIterator) readHistoryBatches(firstEventID int64) ([]*eventpb.His
 	var historyBatches []*eventpb.History
 	newIterState := historyIteratorState{}
 	for size < targetSize {
+		if i.beyondRange(firstEventID) {
+			newIterState.FinishedIteration = true
+			return historyBatches, newIterState, nil
+		}
 		currHistoryBatches, err := i.readHistory(firstEventID)
-		if _, ok := err.(*serviceerror.NotFound); ok && firstEventID != common.FirstEventID {
+		if _, ok := err.(*serviceerror.NotFound); ok && firstEventID != i.rangeFirstEventID() {
 			newIterState.FinishedIteration = true
 			return historyBatches, newIterState, nil
 		}
@@ -190,8 +200,12 @@ func (i *historyIterator) readHistoryBatches(firstEventID int64) ([]*eventpb.His
 
 	// If you are here, it means the target size is met after adding the last batch of read history.
 	// We need to check if there's more history batches.
+	if i.beyondRange(firstEventID) {
+		newIterState.FinishedIteration = true
+		return historyBatches, newIterState, nil
+	}
 	_, err := i.readHistory(firstEventID)
-	if _, ok := err.(*serviceerror.NotFound); ok && firstEventID != common.FirstEventID {
+	if _, ok := err.(*serviceerror.NotFound); ok && firstEventID != i.rangeFirstEventID() {
 		newIterState.FinishedIteration = true
 		return historyBatches, newIterState, nil
 	}
@@ -204,10 +218,14 @@ func (i *historyIterator) readHistoryBatches(firstEventID int64) ([]*eventpb.His
 }
 
 func (i *historyIterator) readHistory(firstEventID int64) ([]*eventpb.History, error) {
+	maxEventID := common.EndEventID
+	if i.request.ToEventID > 0 {
+		maxEventID = i.request.ToEventID + 1
+	}
 	req := &persistence.ReadHistoryBranchRequest{
 		BranchToken: i.request.BranchToken,
 		MinEventID:  firstEventID,
-		MaxEventID:  common.EndEventID,
+		MaxEventID:  maxEventID,
 		PageSize:    i.historyPageSize,
 		ShardID:     &i.request.ShardID,
 	}
@@ -215,6 +233,25 @@ func (i *historyIterator) readHistory(firstEventID int64) ([]*eventpb.History, e
 	return historyBatches, err
 }
 
+// rangeFirstEventID returns the first event ID of the archived range
+func (i *historyIterator) rangeFirstEventID() int64 {
+	if i.request.FromEventID > 0 {
+		return i.request.FromEventID
+	}
+	return common.FirstEventID
+}
+
+// beyondRange returns true if a batch starting at firstEventID falls after the archived range
+func (i *historyIterator) beyondRange(firstEventID int64) bool {
+	return i.request.ToEventID > 0 && firstEventID > i.request.ToEventID
+}
+
+// reachedHistoryEnd returns true if the archived range extends to the end of the workflow history.
+// Only then is the last blob of the range also the last blob of the history
+func (i *historyIterator) reachedHistoryEnd(lastEventID int64) bool {
+	return i.request.ToEventID == 0 || lastEventID+1 >= i.request.NextEventID
+}
+
 // reset resets iterator to a certain state given its encoded representation
 // if it returns an error, the operation will have no effect on the iterator
 func (i *historyIterator) reset(stateToken []byte) error {
@@ -595,6 +595,93 @@ func (s *HistoryIteratorSuite) TestNext_Success_SameHistoryDifferentPage() {
 	historyV2Manager.AssertExpectations(s.T())
 }
 
+func (s *HistoryIteratorSuite) TestNext_Success_FullHistoryByDefault() {
+	batchInfo := []int{1, 2, 1, 1}
+	pages := []page{
+		{
+			firstbatchIdx:             0,
+			numBatches:                4,
+			firstEventFailoverVersion: 1,
+			lastEventFailoverVersion:  1,
+		},
+	}
+	historyV2Manager := s.constructMockHistoryV2Manager(batchInfo, -1, true, pages...)
+	itr := s.constructTestHistoryIterator(historyV2Manager, testDefaultTargetHistoryBlobSize, nil)
+	blob, err := itr.Next()
+	s.NoError(err)
+	s.Equal(&archivergenpb.HistoryBlobHeader{
+		Namespace:            testNamespace,
+		NamespaceId:          testNamespaceID,
+		WorkflowId:           testWorkflowID,
+		RunId:                testRunID,
+		IsLast:               true,
+		FirstFailoverVersion: 1,
+		LastFailoverVersion:  1,
+		FirstEventId:         common.FirstEventID,
+		LastEventId:          5,
+		EventCount:           5,
+	}, blob.Header)
+	s.False(itr.HasNext())
+	historyV2Manager.AssertExpectations(s.T())
+}
+
+func (s *HistoryIteratorSuite) TestNext_Success_BoundedRange() {
+	// batches start at event 1, 2, 4, 5, 6, 7, 10, 13 and 14
+	batchInfo := []int{1, 2, 1, 1, 1, 3, 3, 1, 3}
+	testShardId := testShardID
+	historyV2Manager := &mocks.HistoryV2Manager{}
+	historyV2Manager.On("ReadHistoryBranchByBatch", &persistence.ReadHistoryBranchRequest{
+		BranchToken: testBranchToken,
+		MinEventID:  4,
+		MaxEventID:  11,
+		PageSize:    testDefaultPersistencePageSize,
+		ShardID:     &testShardId,
+	}).Return(&persistence.ReadHistoryBranchByBatchResponse{
+		History: s.constructHistoryBatches(batchInfo, page{
+			firstbatchIdx:             2,
+			numBatches:                5,
+			firstEventFailoverVersion: 1,
+			lastEventFailoverVersion:  1,
+		}, 4),
+	}, nil).Once()
+
+	itr := newHistoryIterator(&ArchiveHistoryRequest{
+		ShardID:              testShardID,
+		NamespaceID:          testNamespaceID,
+		Namespace:            testNamespace,
+		WorkflowID:           testWorkflowID,
+		RunID:                testRunID,
+		BranchToken:          testBranchToken,
+		NextEventID:          testNextEventID,
+		CloseFailoverVersion: testCloseFailoverVersion,
+		FromEventID:          4,
+		ToEventID:            10,
+	}, historyV2Manager, testDefaultTargetHistoryBlobSize)
+	itr.sizeEstimator = newTestSizeEstimator()
+	s.Equal(int64(4), itr.NextEventID)
+
+	blob, err := itr.Next()
+	s.NoError(err)
+	s.Len(blob.Body, 5)
+	// the batch starting at event 10 is archived as a whole
+	s.Equal(&archivergenpb.HistoryBlobHeader{
+		Namespace:            testNamespace,
+		NamespaceId:          testNamespaceID,
+		WorkflowId:           testWorkflowID,
+		RunId:                testRunID,
+		IsLast:               false,
+		FirstFailoverVersion: 1,
+		LastFailoverVersion:  1,
+		FirstEventId:         4,
+		LastEventId:          12,
+		EventCount:           9,
+		RangeFirstEventId:    4,
+		RangeLastEventId:     10,
+	}, blob.Header)
+	s.False(itr.HasNext())
+	historyV2Manager.AssertExpectations(s.T())
+}
+
 func (s *HistoryIteratorSuite) TestNewIteratorWithState() {
 	itr := s.constructTestHistoryIterator(nil, testDefaultTargetHistoryBlobSize, nil)
 	testIteratorState := historyIteratorState{
@@ -45,6 +45,11 @@ type (
 		BranchToken          []byte
 		NextEventID          int64
 		CloseFailoverVersion int64
+		// FromEventID and ToEventID optionally bound the archived range, both inclusive.
+		// A history batch is archived when its first event falls in the range. Zero
+		// leaves the range unbounded on that side, so by default the full history is archived.
+		FromEventID int64
+		ToEventID   int64
 	}
 
 	// GetHistoryRequest is the request to Get archived history
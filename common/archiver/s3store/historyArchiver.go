@@ -75,14 +75,18 @@ type (
 
 	getHistoryToken struct {
 		CloseFailoverVersion int64
-		BatchIdx             int
+		// RangeFirstEventID is the first event ID of the archived range being read,
+		// zero when the full history was archived at once
+		RangeFirstEventID int64
+		BatchIdx          int
 	}
 
 	uploadProgress struct {
-		BatchIdx      int
-		IteratorState []byte
-		uploadedSize  int64
-		historySize   int64
+		BatchIdx          int
+		RangeFirstEventID int64
+		IteratorState     []byte
+		uploadedSize      int64
+		historySize       int64
 	}
 )
 
@@ -182,6 +186,12 @@ func (h *historyArchiver) Archive(
 			return err
 		}
 		key := constructHistoryKey(URI.Path(), request.NamespaceID, request.WorkflowID, request.RunID, request.CloseFailoverVersion, progress.BatchIdx)
+		if request.FromEventID > 0 || request.ToEventID > 0 {
+			if progress.BatchIdx == 0 {
+				progress.RangeFirstEventID = historyBlob.Body[0].Events[0].GetEventId()
+			}
+			key = constructHistoryRangeKey(URI.Path(), request.NamespaceID, request.WorkflowID, request.RunID, request.CloseFailoverVersion, progress.RangeFirstEventID, progress.BatchIdx)
+		}
 
 		exists, err := keyExists(ctx, h.s3cli, URI, key)
 		if err != nil {
@@ -233,6 +243,7 @@ func loadHistoryIterator(ctx context.Context, request *archiver.ArchiveHistoryRe
 			}
 			progress.IteratorState = nil
 			progress.BatchIdx = 0
+			progress.RangeFirstEventID = 0
 			progress.historySize = 0
 			progress.uploadedSize = 0
 		}
@@ -298,9 +309,17 @@ func (h *historyArchiver) Get(
 			break
 		}
 		key := constructHistoryKey(URI.Path(), request.NamespaceID, request.WorkflowID, request.RunID, token.CloseFailoverVersion, token.BatchIdx)
+		if token.RangeFirstEventID > 0 {
+			key = constructHistoryRangeKey(URI.Path(), request.NamespaceID, request.WorkflowID, request.RunID, token.CloseFailoverVersion, token.RangeFirstEventID, token.BatchIdx)
+		}
 
 		encodedRecord, err := download(ctx, h.s3cli, URI, key)
 		if err != nil {
+			if _, ok := err.(*serviceerror.NotFound); ok && token.RangeFirstEventID == 0 && token.BatchIdx == 0 {
+				// the history was archived in ranges, start from the range holding the first event
+				token.RangeFirstEventID = common.FirstEventID
+				continue
+			}
 			if isRetryableError(err) {
 				return nil, &serviceerror.Internal{Message: err.Error()}
 			}
@@ -326,6 +345,12 @@ func (h *historyArchiver) Get(
 		if historyBlob.Header.IsLast {
 			break
 		}
+		if historyBlob.Header.RangeLastEventId > 0 && historyBlob.Header.LastEventId >= historyBlob.Header.RangeLastEventId {
+			// last blob of an archived range, the next range starts right after it
+			token.RangeFirstEventID = historyBlob.Header.LastEventId + 1
+			token.BatchIdx = 0
+			continue
+		}
 		token.BatchIdx++
 	}
 
@@ -627,6 +627,107 @@ func (s *historyArchiverSuite) TestArchiveAndGet() {
 	s.Equal(append(s.historyBatchesV100[0].Body, s.historyBatchesV100[1].Body...), response.HistoryBatches)
 }
 
+func (s *historyArchiverSuite) TestArchiveAndGet_Ranges() {
+	mockCtrl := gomock.NewController(s.T())
+	defer mockCtrl.Finish()
+
+	firstRange := &archiverproto.HistoryBlob{
+		Header: &archiverproto.HistoryBlobHeader{
+			IsLast:            false,
+			FirstEventId:      common.FirstEventID,
+			LastEventId:       common.FirstEventID + 1,
+			RangeFirstEventId: common.FirstEventID,
+			RangeLastEventId:  common.FirstEventID + 1,
+		},
+		Body: []*eventpb.History{
+			{
+				Events: []*eventpb.HistoryEvent{
+					{
+						EventId:   common.FirstEventID,
+						Timestamp: time.Now().UnixNano(),
+						Version:   testCloseFailoverVersion,
+					},
+					{
+						EventId:   common.FirstEventID + 1,
+						Timestamp: time.Now().UnixNano(),
+						Version:   testCloseFailoverVersion,
+					},
+				},
+			},
+		},
+	}
+	secondRange := &archiverproto.HistoryBlob{
+		Header: &archiverproto.HistoryBlobHeader{
+			IsLast:            true,
+			FirstEventId:      common.FirstEventID + 2,
+			LastEventId:       testNextEventID - 1,
+			RangeFirstEventId: common.FirstEventID + 2,
+		},
+		Body: []*eventpb.History{
+			{
+				Events: []*eventpb.HistoryEvent{
+					{
+						EventId:   common.FirstEventID + 2,
+						Timestamp: time.Now().UnixNano(),
+						Version:   testCloseFailoverVersion,
+					},
+				},
+			},
+			{
+				Events: []*eventpb.HistoryEvent{
+					{
+						EventId:   testNextEventID - 1,
+						Timestamp: time.Now().UnixNano(),
+						Version:   testCloseFailoverVersion,
+					},
+				},
+			},
+		},
+	}
+
+	URI, err := archiver.NewURI(testBucketURI + "/TestArchiveAndGet_Ranges")
+	s.NoError(err)
+	for _, historyBlob := range []*archiverproto.HistoryBlob{firstRange, secondRange} {
+		historyIterator := archiver.NewMockHistoryIterator(mockCtrl)
+		gomock.InOrder(
+			historyIterator.EXPECT().HasNext().Return(true),
+			historyIterator.EXPECT().Next().Return(historyBlob, nil),
+			historyIterator.EXPECT().HasNext().Return(false),
+		)
+
+		historyArchiver := s.newTestHistoryArchiver(historyIterator)
+		archiveRequest := &archiver.ArchiveHistoryRequest{
+			NamespaceID:          testNamespaceID,
+			Namespace:            testNamespace,
+			WorkflowID:           testWorkflowID,
+			RunID:                testRunID,
+			BranchToken:          testBranchToken,
+			NextEventID:          testNextEventID,
+			CloseFailoverVersion: testCloseFailoverVersion,
+			FromEventID:          historyBlob.Header.RangeFirstEventId,
+			ToEventID:            historyBlob.Header.RangeLastEventId,
+		}
+		err = historyArchiver.Archive(context.Background(), URI, archiveRequest)
+		s.NoError(err)
+
+		expectedKey := constructHistoryRangeKey(URI.Path(), testNamespaceID, testWorkflowID, testRunID, testCloseFailoverVersion, historyBlob.Header.FirstEventId, 0)
+		s.assertKeyExists(expectedKey)
+	}
+
+	historyArchiver := s.newTestHistoryArchiver(nil)
+	getRequest := &archiver.GetHistoryRequest{
+		NamespaceID: testNamespaceID,
+		WorkflowID:  testWorkflowID,
+		RunID:       testRunID,
+		PageSize:    testPageSize,
+	}
+	response, err := historyArchiver.Get(context.Background(), URI, getRequest)
+	s.NoError(err)
+	s.NotNil(response)
+	s.Nil(response.NextPageToken)
+	s.Equal(append(firstRange.Body, secondRange.Body...), response.HistoryBatches)
+}
+
 func (s *historyArchiverSuite) newTestHistoryArchiver(historyIterator archiver.HistoryIterator) *historyArchiver {
 	//config := &config.S3Archiver{}
 	//archiver, err := newHistoryArchiver(s.container, config, historyIterator)
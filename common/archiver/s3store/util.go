@@ -137,6 +137,13 @@ func constructHistoryKey(path, namespaceID, workflowID, runID string, version in
 	return fmt.Sprintf("%s%d", prefix, batchIdx)
 }
 
+// constructHistoryRangeKey constructs the key of a blob of an archived history range. Ranges are
+// keyed by the ID of their first event so that they do not overwrite each other.
+func constructHistoryRangeKey(path, namespaceID, workflowID, runID string, version int64, firstEventID int64, batchIdx int) string {
+	prefix := constructHistoryKeyPrefixWithVersion(path, namespaceID, workflowID, runID, version)
+	return fmt.Sprintf("%s%d_%d", prefix, firstEventID, batchIdx)
+}
+
 func constructHistoryKeyPrefixWithVersion(path, namespaceID, workflowID, runID string, version int64) string {
 	prefix := constructHistoryKeyPrefix(path, namespaceID, workflowID, runID)
 	return fmt.Sprintf("%s/%v/", prefix, version)
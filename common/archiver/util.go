@@ -38,6 +38,7 @@ var (
 	errEmptyStartTime        = errors.New("StartTimestamp is empty")
 	errEmptyCloseTime        = errors.New("CloseTimestamp is empty")
 	errEmptyQuery            = errors.New("Query string is empty")
+	errInvalidEventRange     = errors.New("FromEventID and ToEventID do not form a valid range")
 )
 
 // TagLoggerWithArchiveHistoryRequestAndURI tags logger with fields in the archive history request and the URI
@@ -83,6 +84,10 @@ func ValidateHistoryArchiveRequest(request *ArchiveHistoryRequest) error {
 	if request.Namespace == "" {
 		return errEmptyNamespace
 	}
+	if request.FromEventID < 0 || request.ToEventID < 0 ||
+		(request.ToEventID != 0 && request.FromEventID > request.ToEventID) {
+		return errInvalidEventRange
+	}
 	return nil
 }
 
@@ -94,6 +94,7 @@ type (
 		GetNamespaceName(id string) (string, error)
 		GetAllNamespace() map[string]*NamespaceCacheEntry
 		GetCacheSize() (sizeOfCacheByName int64, sizeOfCacheByID int64)
+		RefreshNamespace(name string) error
 	}
 
 	namespaceCache struct {
@@ -382,6 +383,54 @@ func (c *namespaceCache) GetNamespaceName(
 	return entry.info.Name, nil
 }
 
+// RefreshNamespace reloads the namespace with the given name from persistence and replaces
+// the cached entry, without waiting for the periodic refresh. This is meant for operators
+// who changed the namespace record directly in the database
+func (c *namespaceCache) RefreshNamespace(
+	name string,
+) error {
+
+	record, err := c.metadataMgr.GetNamespace(&persistence.GetNamespaceRequest{Name: name})
+	if err != nil {
+		return err
+	}
+	namespace := c.buildEntryFromRecord(record)
+
+	c.refreshLock.Lock()
+	defer c.refreshLock.Unlock()
+
+	newCacheNameToID := newNamespaceCache()
+	newCacheByID := newNamespaceCache()
+	for _, entry := range c.GetAllNamespace() {
+		newCacheNameToID.Put(entry.info.Name, entry.info.ID)
+		newCacheByID.Put(entry.info.ID, entry)
+	}
+
+	if cached, ok := newCacheByID.Get(namespace.info.ID).(*NamespaceCacheEntry); ok && cached.info.Name != namespace.info.Name {
+		// namespace was renamed, drop the stale name mapping
+		newCacheNameToID.Delete(cached.info.Name)
+	}
+	prevEntry, nextEntry, err := c.updateIDToNamespaceCache(newCacheByID, namespace.info.ID, namespace)
+	if err != nil {
+		return err
+	}
+	c.updateNameToIDCache(newCacheNameToID, nextEntry.info.Name, nextEntry.info.ID)
+
+	var prevEntries, nextEntries []*NamespaceCacheEntry
+	if prevEntry != nil {
+		prevEntries = append(prevEntries, prevEntry)
+		nextEntries = append(nextEntries, nextEntry)
+	}
+
+	c.callbackLock.Lock()
+	defer c.callbackLock.Unlock()
+	c.triggerNamespaceChangePrepareCallbackLocked()
+	c.cacheByID.Store(newCacheByID)
+	c.cacheNameToID.Store(newCacheNameToID)
+	c.triggerNamespaceChangeCallbackLocked(prevEntries, nextEntries)
+	return nil
+}
+
 func (c *namespaceCache) refreshLoop() {
 	timer := time.NewTicker(NamespaceCacheRefreshInterval)
 	defer timer.Stop()
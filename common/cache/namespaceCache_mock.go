@@ -191,3 +191,17 @@ func (mr *MockNamespaceCacheMockRecorder) GetCacheSize() *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCacheSize", reflect.TypeOf((*MockNamespaceCache)(nil).GetCacheSize))
 }
+
+// RefreshNamespace mocks base method.
+func (m *MockNamespaceCache) RefreshNamespace(name string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RefreshNamespace", name)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RefreshNamespace indicates an expected call of RefreshNamespace.
+func (mr *MockNamespaceCacheMockRecorder) RefreshNamespace(name interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RefreshNamespace", reflect.TypeOf((*MockNamespaceCache)(nil).RefreshNamespace), name)
+}
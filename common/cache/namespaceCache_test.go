@@ -269,6 +269,59 @@ func (s *namespaceCacheSuite) TestGetNamespace_NonLoaded_GetByID() {
 	s.Equal(entry, entryByID)
 }
 
+func (s *namespaceCacheSuite) TestRefreshNamespace() {
+	s.clusterMetadata.On("IsGlobalNamespaceEnabled").Return(true)
+	namespaceNotificationVersion := int64(999999) // make this notification version really large for test
+	s.metadataMgr.On("GetMetadata").Return(&persistence.GetMetadataResponse{NotificationVersion: namespaceNotificationVersion}, nil)
+	namespaceRecord := &persistence.GetNamespaceResponse{
+		Info: &persistence.NamespaceInfo{ID: uuid.New(), Name: "some random namespace name", Data: make(map[string]string)},
+		Config: &persistence.NamespaceConfig{
+			Retention: 1,
+			BadBinaries: namespacepb.BadBinaries{
+				Binaries: map[string]*namespacepb.BadBinaryInfo{},
+			},
+		},
+		ReplicationConfig: &persistence.NamespaceReplicationConfig{
+			ActiveClusterName: cluster.TestCurrentClusterName,
+			Clusters: []*persistence.ClusterReplicationConfig{
+				{ClusterName: cluster.TestCurrentClusterName},
+				{ClusterName: cluster.TestAlternativeClusterName},
+			},
+		},
+	}
+
+	s.metadataMgr.On("GetNamespace", &persistence.GetNamespaceRequest{Name: namespaceRecord.Info.Name}).Return(namespaceRecord, nil).Once()
+	s.metadataMgr.On("ListNamespaces", &persistence.ListNamespacesRequest{
+		PageSize:      namespaceCacheRefreshPageSize,
+		NextPageToken: nil,
+	}).Return(&persistence.ListNamespacesResponse{
+		Namespaces:    []*persistence.GetNamespaceResponse{namespaceRecord},
+		NextPageToken: nil,
+	}, nil).Once()
+
+	entry, err := s.namespaceCache.GetNamespace(namespaceRecord.Info.Name)
+	s.Nil(err)
+	s.Equal(int32(1), entry.GetConfig().Retention)
+
+	// the record is edited in the database without bumping any version
+	updatedRecord := *namespaceRecord
+	updatedRecord.Config = &persistence.NamespaceConfig{
+		Retention: 7,
+		BadBinaries: namespacepb.BadBinaries{
+			Binaries: map[string]*namespacepb.BadBinaryInfo{},
+		},
+	}
+	s.metadataMgr.On("GetNamespace", &persistence.GetNamespaceRequest{Name: namespaceRecord.Info.Name}).Return(&updatedRecord, nil).Once()
+
+	s.Nil(s.namespaceCache.RefreshNamespace(namespaceRecord.Info.Name))
+	entry, err = s.namespaceCache.GetNamespace(namespaceRecord.Info.Name)
+	s.Nil(err)
+	s.Equal(s.buildEntryFromRecord(&updatedRecord), entry)
+	entry, err = s.namespaceCache.GetNamespaceByID(namespaceRecord.Info.ID)
+	s.Nil(err)
+	s.Equal(int32(7), entry.GetConfig().Retention)
+}
+
 func (s *namespaceCacheSuite) TestRegisterCallback_CatchUp() {
 	namespaceNotificationVersion := int64(0)
 	namespaceRecord1 := &persistence.GetNamespaceResponse{
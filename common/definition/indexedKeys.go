@@ -38,6 +38,7 @@ const (
 	Encoding        = "Encoding"
 	KafkaKey        = "KafkaKey"
 	BinaryChecksums = "BinaryChecksums"
+	FailureCategory = "FailureCategory"
 
 	CustomStringField     = "CustomStringField"
 	CustomKeywordField    = "CustomKeywordField"
@@ -69,6 +70,7 @@ func createDefaultIndexedKeys() map[string]interface{} {
 		CustomDatetimeField:   commonpb.IndexedValueTypeDatetime,
 		TemporalChangeVersion: commonpb.IndexedValueTypeKeyword,
 		BinaryChecksums:       commonpb.IndexedValueTypeKeyword,
+		FailureCategory:       commonpb.IndexedValueTypeKeyword,
 	}
 	for k, v := range systemIndexedKeys {
 		defaultIndexedKeys[k] = v
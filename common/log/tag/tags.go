@@ -264,6 +264,11 @@ func WorkflowDecisionType(decisionType int64) Tag {
 	return newInt64("wf-decision-type", decisionType)
 }
 
+// WorkflowDecisionTypes returns tag for WorkflowDecisionTypes
+func WorkflowDecisionTypes(decisionTypes []string) Tag {
+	return newObjectTag("wf-decision-types", decisionTypes)
+}
+
 // WorkflowQueryType returns tag for WorkflowQueryType
 func WorkflowQueryType(qt string) Tag {
 	return newStringTag("wf-query-type", qt)
@@ -274,6 +279,16 @@ func WorkflowDecisionFailCause(decisionFailCause int64) Tag {
 	return newInt64("wf-decision-fail-cause", decisionFailCause)
 }
 
+// WorkflowDecisionFailMessage returns tag for WorkflowDecisionFailMessage
+func WorkflowDecisionFailMessage(decisionFailMessage string) Tag {
+	return newStringTag("wf-decision-fail-message", decisionFailMessage)
+}
+
+// WorkflowDecisionIndex returns tag for WorkflowDecisionIndex
+func WorkflowDecisionIndex(decisionIndex int) Tag {
+	return newInt("wf-decision-index", decisionIndex)
+}
+
 // WorkflowTaskListType returns tag for WorkflowTaskListType
 func WorkflowTaskListType(taskListType int32) Tag {
 	return newInt32("wf-task-list-type", taskListType)
@@ -0,0 +1,88 @@
+// The MIT License (MIT)
+//
+// Copyright (c) 2020 Temporal Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package messaging
+
+import (
+	"fmt"
+
+	"github.com/Shopify/sarama"
+)
+
+const (
+	// CompressionNone means record batches are not compressed
+	CompressionNone = "none"
+	// CompressionGzip compresses record batches with gzip
+	CompressionGzip = "gzip"
+	// CompressionSnappy compresses record batches with snappy
+	CompressionSnappy = "snappy"
+	// CompressionLZ4 compresses record batches with lz4
+	CompressionLZ4 = "lz4"
+	// CompressionZstd compresses record batches with zstd, which needs kafka 2.1 or later
+	CompressionZstd = "zstd"
+)
+
+type (
+	// CompressionConfig describes how the Kafka producer compresses record batches. Compression is done
+	// natively by Kafka, so consumers decompress transparently whatever the codec used.
+	CompressionConfig struct {
+		// Codec is one of none, gzip, snappy, lz4 or zstd, empty means none
+		Codec string `yaml:"codec"`
+	}
+)
+
+// Validate returns an error if the codec is not supported
+func (c CompressionConfig) Validate() error {
+	switch c.Codec {
+	case "", CompressionNone, CompressionGzip, CompressionSnappy, CompressionLZ4, CompressionZstd:
+		return nil
+	default:
+		return fmt.Errorf("unsupported compression codec: %v", c.Codec)
+	}
+}
+
+// codec returns the name of the codec, none when compression is disabled
+func (c CompressionConfig) codec() string {
+	if c.Codec == "" {
+		return CompressionNone
+	}
+	return c.Codec
+}
+
+// apply configures the sarama producer to compress record batches with the codec
+func (c CompressionConfig) apply(config *sarama.Config) {
+	switch c.Codec {
+	case CompressionGzip:
+		config.Producer.Compression = sarama.CompressionGZIP
+	case CompressionSnappy:
+		config.Producer.Compression = sarama.CompressionSnappy
+	case CompressionLZ4:
+		config.Producer.Compression = sarama.CompressionLZ4
+	case CompressionZstd:
+		config.Producer.Compression = sarama.CompressionZSTD
+		if !config.Version.IsAtLeast(sarama.V2_1_0_0) {
+			config.Version = sarama.V2_1_0_0
+		}
+	default:
+		config.Producer.Compression = sarama.CompressionNone
+	}
+}
@@ -20,9 +20,27 @@
 
 package messaging
 
-import "errors"
+import (
+	"errors"
+	"fmt"
+
+	replicationgenpb "github.com/temporalio/temporal/.gen/proto/replication"
+)
 
 var (
 	// ErrMessageSizeLimit indicate that message is rejected by server due to size limitation
 	ErrMessageSizeLimit = errors.New("message was too large, server rejected it to avoid allocation error")
 )
+
+type (
+	// ReplicationTaskSerializationError indicates that a replication task could not be turned into a message,
+	// either because it failed to serialize or because its task type is not supported
+	ReplicationTaskSerializationError struct {
+		TaskType replicationgenpb.ReplicationTaskType
+		Err      error
+	}
+)
+
+func (e *ReplicationTaskSerializationError) Error() string {
+	return fmt.Sprintf("failed to serialize replication task of type %v: %v", e.TaskType, e.Err)
+}
@@ -26,6 +26,7 @@ import (
 	"fmt"
 	"io/ioutil"
 	"strings"
+	"time"
 
 	"github.com/temporalio/temporal/common/auth"
 
@@ -44,6 +45,7 @@ type (
 	kafkaClient struct {
 		config        *KafkaConfig
 		tlsConfig     *tls.Config
+		serializer    Serializer
 		client        uberKafkaClient.Client
 		metricsClient metrics.Client
 		logger        log.Logger
@@ -80,10 +82,15 @@ func NewKafkaClient(kc *KafkaConfig, metricsClient metrics.Client, zLogger *zap.
 	if err != nil {
 		panic(fmt.Sprintf("Error creating Kafka TLS config %v", err))
 	}
+	serializer, err := NewSerializer(kc.Serializer)
+	if err != nil {
+		panic(fmt.Sprintf("Error creating Kafka serializer %v", err))
+	}
 
 	return &kafkaClient{
 		config:        kc,
 		tlsConfig:     tlsConfig,
+		serializer:    serializer,
 		client:        client,
 		metricsClient: metricsClient,
 		logger:        logger,
@@ -93,26 +100,22 @@ func NewKafkaClient(kc *KafkaConfig, metricsClient metrics.Client, zLogger *zap.
 // NewConsumer is used to create a Kafka consumer
 func (c *kafkaClient) NewConsumer(app, consumerName string, concurrency int) (Consumer, error) {
 	topics := c.config.getTopicsForApplication(app)
-	kafkaClusterNameForTopic := c.config.getKafkaClusterForTopic(topics.Topic)
 	kafkaClusterNameForDLQTopic := c.config.getKafkaClusterForTopic(topics.DLQTopic)
 
-	topic := createUberKafkaTopic(topics.Topic, kafkaClusterNameForTopic)
 	dlq := createUberKafkaTopic(topics.DLQTopic, kafkaClusterNameForDLQTopic)
 
-	return c.newConsumerHelper(topic, dlq, consumerName, concurrency)
+	return c.newConsumerHelper(c.createUberKafkaTopics(topics), dlq, consumerName, concurrency)
 }
 
 // NewConsumerWithClusterName is used to create a Kafka consumer for consuming replication tasks
 func (c *kafkaClient) NewConsumerWithClusterName(currentCluster, sourceCluster, consumerName string, concurrency int) (Consumer, error) {
 	currentTopics := c.config.getTopicsForCadenceCluster(currentCluster)
 	sourceTopics := c.config.getTopicsForCadenceCluster(sourceCluster)
-	kafkaClusterNameForTopic := c.config.getKafkaClusterForTopic(sourceTopics.Topic)
 	kafkaClusterNameForDLQTopic := c.config.getKafkaClusterForTopic(currentTopics.DLQTopic)
 
-	topic := createUberKafkaTopic(sourceTopics.Topic, kafkaClusterNameForTopic)
 	dlq := createUberKafkaTopic(currentTopics.DLQTopic, kafkaClusterNameForDLQTopic)
 
-	return c.newConsumerHelper(topic, dlq, consumerName, concurrency)
+	return c.newConsumerHelper(c.createUberKafkaTopics(sourceTopics), dlq, consumerName, concurrency)
 }
 
 func createUberKafkaTopic(name, cluster string) *uberKafka.Topic {
@@ -122,12 +125,23 @@ func createUberKafkaTopic(name, cluster string) *uberKafka.Topic {
 	}
 }
 
-func (c *kafkaClient) newConsumerHelper(topic, dlq *uberKafka.Topic, consumerName string, concurrency int) (Consumer, error) {
-	topicList := uberKafka.ConsumerTopicList{
-		uberKafka.ConsumerTopic{
+// createUberKafkaTopics creates the topics a consumer of the topic list subscribes to, the dedicated
+// namespace topics the producers of the topic list may publish to included
+func (c *kafkaClient) createUberKafkaTopics(topics TopicList) []*uberKafka.Topic {
+	var uberTopics []*uberKafka.Topic
+	for _, topic := range topics.consumerTopics() {
+		uberTopics = append(uberTopics, createUberKafkaTopic(topic, c.config.getKafkaClusterForTopic(topic)))
+	}
+	return uberTopics
+}
+
+func (c *kafkaClient) newConsumerHelper(topics []*uberKafka.Topic, dlq *uberKafka.Topic, consumerName string, concurrency int) (Consumer, error) {
+	var topicList uberKafka.ConsumerTopicList
+	for _, topic := range topics {
+		topicList = append(topicList, uberKafka.ConsumerTopic{
 			Topic: *topic,
 			DLQ:   *dlq,
-		},
+		})
 	}
 	consumerConfig := uberKafka.NewConsumerConfig(consumerName, topicList)
 	consumerConfig.Concurrency = concurrency
@@ -144,34 +158,63 @@ func (c *kafkaClient) newConsumerHelper(topic, dlq *uberKafka.Topic, consumerNam
 // NewProducer is used to create a Kafka producer
 func (c *kafkaClient) NewProducer(app string) (Producer, error) {
 	topics := c.config.getTopicsForApplication(app)
-	return c.newProducerHelper(topics.Topic)
+	return c.newProducerHelper(topics)
 }
 
 // NewProducerWithClusterName is used to create a Kafka producer for shipping replication tasks
 func (c *kafkaClient) NewProducerWithClusterName(sourceCluster string) (Producer, error) {
 	topics := c.config.getTopicsForCadenceCluster(sourceCluster)
-	return c.newProducerHelper(topics.Topic)
+	return c.newProducerHelper(topics)
 }
 
-func (c *kafkaClient) newProducerHelper(topic string) (Producer, error) {
+func (c *kafkaClient) newProducerHelper(topics TopicList) (Producer, error) {
+	topic := topics.Topic
 	kafkaClusterName := c.config.getKafkaClusterForTopic(topic)
 	brokers := c.config.getBrokersForKafkaCluster(kafkaClusterName)
 
-	config := sarama.NewConfig()
-	config.Producer.Return.Successes = true
-	config.Net.TLS.Enable = c.tlsConfig != nil
-	config.Net.TLS.Config = c.tlsConfig
-
-	producer, err := sarama.NewSyncProducer(brokers, config)
+	producer, err := sarama.NewSyncProducer(brokers, newSaramaConfig(c.tlsConfig, c.config.Compression))
 	if err != nil {
 		return nil, err
 	}
 
 	if c.metricsClient != nil {
 		c.logger.Info("Create producer with metricsClient")
-		return NewMetricProducer(NewKafkaProducer(topic, producer, c.logger), c.metricsClient), nil
+		return NewMetricProducer(NewKafkaProducer(topic, producer, c.config.Compression, c.serializer, topics.topicResolver(), c.metricsClient, c.logger), c.metricsClient), nil
 	}
-	return NewKafkaProducer(topic, producer, c.logger), nil
+	return NewKafkaProducer(topic, producer, c.config.Compression, c.serializer, topics.topicResolver(), nil, c.logger), nil
+}
+
+// NewKafkaLagReporterForTopic creates a lag reporter for the consumer group on the topic, connected to the
+// kafka cluster of the topic with the same config as the producers of the topic
+func NewKafkaLagReporterForTopic(
+	kc *KafkaConfig,
+	topic string,
+	consumerGroup string,
+	interval time.Duration,
+	metricsClient metrics.Client,
+	logger log.Logger,
+) (*KafkaLagReporter, error) {
+	tlsConfig, err := CreateTLSConfig(kc.TLS)
+	if err != nil {
+		return nil, err
+	}
+	brokers := kc.getBrokersForKafkaCluster(kc.getKafkaClusterForTopic(topic))
+	client, err := sarama.NewClient(brokers, newSaramaConfig(tlsConfig, kc.Compression))
+	if err != nil {
+		return nil, err
+	}
+	return NewKafkaLagReporter(topic, consumerGroup, client, interval, metricsClient, logger), nil
+}
+
+func newSaramaConfig(tlsConfig *tls.Config, compression CompressionConfig) *sarama.Config {
+	config := sarama.NewConfig()
+	config.Producer.Return.Successes = true
+	config.Net.TLS.Enable = tlsConfig != nil
+	config.Net.TLS.Config = tlsConfig
+	// record headers carrying the content type need kafka 0.11 or later
+	config.Version = sarama.V0_11_0_0
+	compression.apply(config)
+	return config
 }
 
 // CreateTLSConfig return tls config
@@ -22,6 +22,7 @@ package messaging
 
 import (
 	"fmt"
+	"sort"
 
 	"github.com/temporalio/temporal/common/auth"
 )
@@ -34,6 +35,9 @@ type (
 		Topics         map[string]TopicConfig   `yaml:"topics"`
 		ClusterToTopic map[string]TopicList     `yaml:"cadence-cluster-topics"`
 		Applications   map[string]TopicList     `yaml:"applications"`
+		Compression    CompressionConfig        `yaml:"compression"`
+		// Serializer is the encoding of the published messages, proto or json, empty means proto
+		Serializer string `yaml:"serializer"`
 	}
 
 	// ClusterConfig describes the configuration for a single Kafka cluster
@@ -51,6 +55,9 @@ type (
 		Topic      string `yaml:"topic"`
 		RetryTopic string `yaml:"retry-topic"`
 		DLQTopic   string `yaml:"dlq-topic"`
+		// NamespaceTopics maps namespace ids to the dedicated topics their messages are published to
+		// instead of Topic, the dedicated topics must be on the kafka cluster of Topic
+		NamespaceTopics map[string]string `yaml:"namespace-topics"`
 	}
 )
 
@@ -62,6 +69,12 @@ func (k *KafkaConfig) Validate(checkCluster bool, checkApp bool) {
 	if len(k.Topics) == 0 {
 		panic("Empty Topics Config")
 	}
+	if err := k.Compression.Validate(); err != nil {
+		panic(err.Error())
+	}
+	if _, err := NewSerializer(k.Serializer); err != nil {
+		panic(err.Error())
+	}
 
 	validateTopicsFn := func(topic string) {
 		if topic == "" {
@@ -75,6 +88,16 @@ func (k *KafkaConfig) Validate(checkCluster bool, checkApp bool) {
 		}
 	}
 
+	validateNamespaceTopicsFn := func(topics TopicList) {
+		for _, topic := range topics.NamespaceTopics {
+			validateTopicsFn(topic)
+			// the producer of the topic list is connected to the kafka cluster of the default topic
+			if k.Topics[topic].Cluster != k.Topics[topics.Topic].Cluster {
+				panic(fmt.Sprintf("Namespace Topic %v is not on the Kafka Cluster of Topic %v", topic, topics.Topic))
+			}
+		}
+	}
+
 	if checkCluster {
 		if len(k.ClusterToTopic) == 0 {
 			panic("Empty Cluster To Topics Config")
@@ -82,6 +105,7 @@ func (k *KafkaConfig) Validate(checkCluster bool, checkApp bool) {
 		for _, topics := range k.ClusterToTopic {
 			validateTopicsFn(topics.Topic)
 			validateTopicsFn(topics.DLQTopic)
+			validateNamespaceTopicsFn(topics)
 		}
 	}
 	if checkApp {
@@ -91,8 +115,35 @@ func (k *KafkaConfig) Validate(checkCluster bool, checkApp bool) {
 		for _, topics := range k.Applications {
 			validateTopicsFn(topics.Topic)
 			validateTopicsFn(topics.DLQTopic)
+			validateNamespaceTopicsFn(topics)
+		}
+	}
+}
+
+// topicResolver returns the resolver picking the dedicated topic of a namespace, nil without dedicated topics
+func (t TopicList) topicResolver() TopicResolver {
+	if len(t.NamespaceTopics) == 0 {
+		return nil
+	}
+	return func(namespaceID string) string {
+		return t.NamespaceTopics[namespaceID]
+	}
+}
+
+// consumerTopics returns every topic the messages of the topic list may be published to, the default topic first
+func (t TopicList) consumerTopics() []string {
+	topics := []string{t.Topic}
+	seen := map[string]struct{}{t.Topic: {}}
+	var namespaceTopics []string
+	for _, topic := range t.NamespaceTopics {
+		if _, ok := seen[topic]; ok {
+			continue
 		}
+		seen[topic] = struct{}{}
+		namespaceTopics = append(namespaceTopics, topic)
 	}
+	sort.Strings(namespaceTopics)
+	return append(topics, namespaceTopics...)
 }
 
 func (k *KafkaConfig) getTopicsForCadenceCluster(cadenceCluster string) TopicList {
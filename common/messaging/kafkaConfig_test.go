@@ -0,0 +1,102 @@
+// The MIT License (MIT)
+//
+// Copyright (c) 2020 Temporal Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package messaging
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+)
+
+type (
+	kafkaConfigSuite struct {
+		suite.Suite
+		*require.Assertions
+	}
+)
+
+func TestKafkaConfigSuite(t *testing.T) {
+	s := new(kafkaConfigSuite)
+	suite.Run(t, s)
+}
+
+func (s *kafkaConfigSuite) SetupTest() {
+	s.Assertions = require.New(s.T())
+}
+
+func (s *kafkaConfigSuite) TestNamespaceTopics() {
+	topics := TopicList{
+		Topic:    "default topic",
+		DLQTopic: "dlq topic",
+		NamespaceTopics: map[string]string{
+			"namespace A": "topic A",
+			"namespace B": "topic B",
+			"namespace C": "topic A",
+		},
+	}
+
+	resolver := topics.topicResolver()
+	s.NotNil(resolver)
+	s.Equal("topic A", resolver("namespace A"))
+	s.Equal("topic B", resolver("namespace B"))
+	s.Equal("", resolver("some random namespace"))
+
+	// consumers subscribe once to each topic messages may be published to
+	s.Equal([]string{"default topic", "topic A", "topic B"}, topics.consumerTopics())
+}
+
+func (s *kafkaConfigSuite) TestNamespaceTopics_Empty() {
+	topics := TopicList{
+		Topic:    "default topic",
+		DLQTopic: "dlq topic",
+	}
+	s.Nil(topics.topicResolver())
+	s.Equal([]string{"default topic"}, topics.consumerTopics())
+}
+
+func (s *kafkaConfigSuite) TestValidate_NamespaceTopicOnOtherCluster() {
+	config := &KafkaConfig{
+		Clusters: map[string]ClusterConfig{
+			"cluster A": {Brokers: []string{"127.0.0.1:9092"}},
+			"cluster B": {Brokers: []string{"127.0.0.2:9092"}},
+		},
+		Topics: map[string]TopicConfig{
+			"default topic": {Cluster: "cluster A"},
+			"dlq topic":     {Cluster: "cluster A"},
+			"topic A":       {Cluster: "cluster A"},
+			"topic B":       {Cluster: "cluster B"},
+		},
+		Applications: map[string]TopicList{
+			"some random app": {
+				Topic:           "default topic",
+				DLQTopic:        "dlq topic",
+				NamespaceTopics: map[string]string{"namespace A": "topic A"},
+			},
+		},
+	}
+	s.NotPanics(func() { config.Validate(false, true) })
+
+	config.Applications["some random app"].NamespaceTopics["namespace B"] = "topic B"
+	s.Panics(func() { config.Validate(false, true) })
+}
@@ -21,6 +21,7 @@
 package messaging
 
 import (
+	"github.com/Shopify/sarama"
 	uberKafka "github.com/uber-go/kafka-client/kafka"
 
 	"github.com/temporalio/temporal/common/log"
@@ -36,6 +37,11 @@ type (
 		msgC      chan Message
 		doneC     chan struct{}
 	}
+
+	// headersMessage is implemented by the consumed messages which expose their record headers
+	headersMessage interface {
+		Headers() []*sarama.RecordHeader
+	}
 )
 
 var _ Consumer = (*kafkaConsumer)(nil)
@@ -80,3 +86,17 @@ func (c *kafkaConsumer) Stop() {
 func (c *kafkaConsumer) Messages() <-chan Message {
 	return c.msgC
 }
+
+// getMessageHeader returns the value of a record header of a consumed message, or empty if it has no such header
+func getMessageHeader(msg Message, key string) string {
+	headers, ok := msg.(headersMessage)
+	if !ok {
+		return ""
+	}
+	for _, header := range headers.Headers() {
+		if header != nil && string(header.Key) == key {
+			return string(header.Value)
+		}
+	}
+	return ""
+}
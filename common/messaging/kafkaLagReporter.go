@@ -0,0 +1,173 @@
+// The MIT License (MIT)
+//
+// Copyright (c) 2020 Temporal Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package messaging
+
+import (
+	"fmt"
+	"sync/atomic"
+	"time"
+
+	"github.com/Shopify/sarama"
+
+	"github.com/temporalio/temporal/common"
+	"github.com/temporalio/temporal/common/log"
+	"github.com/temporalio/temporal/common/log/tag"
+	"github.com/temporalio/temporal/common/metrics"
+)
+
+type (
+	// KafkaLagReporter periodically reports how far a consumer group is behind the producers of a topic,
+	// as the sum over partitions of the distance between the high water mark and the committed offset
+	KafkaLagReporter struct {
+		status        int32
+		topic         string
+		consumerGroup string
+		client        sarama.Client
+		interval      time.Duration
+		metricsScope  metrics.Scope
+		logger        log.Logger
+		doneC         chan struct{}
+	}
+)
+
+var _ common.Daemon = (*KafkaLagReporter)(nil)
+
+// NewKafkaLagReporter creates a lag reporter for the consumer group on the topic, the sarama client is
+// expected to be created with the same config as the producers of the topic
+func NewKafkaLagReporter(
+	topic string,
+	consumerGroup string,
+	client sarama.Client,
+	interval time.Duration,
+	metricsClient metrics.Client,
+	logger log.Logger,
+) *KafkaLagReporter {
+	return &KafkaLagReporter{
+		status:        common.DaemonStatusInitialized,
+		topic:         topic,
+		consumerGroup: consumerGroup,
+		client:        client,
+		interval:      interval,
+		metricsScope: metricsClient.Scope(
+			metrics.MessagingClientConsumerLagScope,
+			metrics.KafkaTopicTag(topic),
+			metrics.ConsumerGroupTag(consumerGroup),
+		),
+		logger: logger.WithTags(tag.KafkaTopicName(topic), tag.KafkaConsumerName(consumerGroup)),
+		doneC:  make(chan struct{}),
+	}
+}
+
+// Start starts reporting the consumer lag every interval
+func (r *KafkaLagReporter) Start() {
+	if !atomic.CompareAndSwapInt32(&r.status, common.DaemonStatusInitialized, common.DaemonStatusStarted) {
+		return
+	}
+	go r.reportLoop()
+}
+
+// Stop stops reporting the consumer lag, the sarama client is left open
+func (r *KafkaLagReporter) Stop() {
+	if !atomic.CompareAndSwapInt32(&r.status, common.DaemonStatusStarted, common.DaemonStatusStopped) {
+		return
+	}
+	close(r.doneC)
+}
+
+// ConsumerLag returns the number of messages of each partition of the topic which are not committed
+// by the consumer group yet. Partitions the group never committed to are lagging from their oldest offset
+func (r *KafkaLagReporter) ConsumerLag() (map[int32]int64, error) {
+	partitions, err := r.client.Partitions(r.topic)
+	if err != nil {
+		return nil, err
+	}
+	coordinator, err := r.client.Coordinator(r.consumerGroup)
+	if err != nil {
+		return nil, err
+	}
+
+	request := &sarama.OffsetFetchRequest{ConsumerGroup: r.consumerGroup, Version: 1}
+	for _, partition := range partitions {
+		request.AddPartition(r.topic, partition)
+	}
+	response, err := coordinator.FetchOffset(request)
+	if err != nil {
+		return nil, err
+	}
+
+	lag := make(map[int32]int64, len(partitions))
+	for _, partition := range partitions {
+		block := response.GetBlock(r.topic, partition)
+		if block == nil {
+			return nil, fmt.Errorf("no committed offset returned for partition %v", partition)
+		}
+		if block.Err != sarama.ErrNoError {
+			return nil, block.Err
+		}
+		highWaterMark, err := r.client.GetOffset(r.topic, partition, sarama.OffsetNewest)
+		if err != nil {
+			return nil, err
+		}
+		committed := block.Offset
+		if committed < 0 {
+			if committed, err = r.client.GetOffset(r.topic, partition, sarama.OffsetOldest); err != nil {
+				return nil, err
+			}
+		}
+		if highWaterMark > committed {
+			lag[partition] = highWaterMark - committed
+		} else {
+			lag[partition] = 0
+		}
+	}
+	return lag, nil
+}
+
+func (r *KafkaLagReporter) reportLoop() {
+	ticker := time.NewTicker(r.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-r.doneC:
+			return
+		case <-ticker.C:
+			r.reportLag()
+		}
+	}
+}
+
+func (r *KafkaLagReporter) reportLag() {
+	lag, err := r.ConsumerLag()
+	if err != nil {
+		r.metricsScope.IncCounter(metrics.KafkaConsumerLagFailures)
+		r.logger.Warn("Failed to get kafka consumer lag", tag.Error(err))
+		return
+	}
+
+	var total int64
+	for _, partitionLag := range lag {
+		total += partitionLag
+	}
+	r.metricsScope.UpdateGauge(metrics.KafkaConsumerLagGauge, float64(total))
+}
@@ -0,0 +1,119 @@
+// The MIT License (MIT)
+//
+// Copyright (c) 2020 Temporal Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package messaging
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Shopify/sarama"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+	"github.com/uber-go/tally"
+
+	"github.com/temporalio/temporal/common/log/loggerimpl"
+	"github.com/temporalio/temporal/common/metrics"
+)
+
+type (
+	kafkaLagReporterSuite struct {
+		suite.Suite
+		*require.Assertions
+
+		broker       *sarama.MockBroker
+		client       sarama.Client
+		metricsScope tally.TestScope
+		reporter     *KafkaLagReporter
+	}
+)
+
+const (
+	testLagTopic = "test-topic"
+	testLagGroup = "test-group"
+)
+
+func TestKafkaLagReporterSuite(t *testing.T) {
+	s := new(kafkaLagReporterSuite)
+	suite.Run(t, s)
+}
+
+func (s *kafkaLagReporterSuite) SetupTest() {
+	s.Assertions = require.New(s.T())
+
+	s.broker = sarama.NewMockBroker(s.T(), 1)
+	// partition 0 has committed offsets, partition 1 was never committed to by the group
+	s.broker.SetHandlerByMap(map[string]sarama.MockResponse{
+		"MetadataRequest": sarama.NewMockMetadataResponse(s.T()).
+			SetBroker(s.broker.Addr(), s.broker.BrokerID()).
+			SetLeader(testLagTopic, 0, s.broker.BrokerID()).
+			SetLeader(testLagTopic, 1, s.broker.BrokerID()),
+		"OffsetRequest": sarama.NewMockOffsetResponse(s.T()).
+			SetVersion(1).
+			SetOffset(testLagTopic, 0, sarama.OffsetNewest, 100).
+			SetOffset(testLagTopic, 0, sarama.OffsetOldest, 0).
+			SetOffset(testLagTopic, 1, sarama.OffsetNewest, 50).
+			SetOffset(testLagTopic, 1, sarama.OffsetOldest, 20),
+		"FindCoordinatorRequest": sarama.NewMockFindCoordinatorResponse(s.T()).
+			SetCoordinator(sarama.CoordinatorGroup, testLagGroup, s.broker),
+		"ConsumerMetadataRequest": sarama.NewMockConsumerMetadataResponse(s.T()).
+			SetCoordinator(testLagGroup, s.broker),
+		"OffsetFetchRequest": sarama.NewMockOffsetFetchResponse(s.T()).
+			SetOffset(testLagGroup, testLagTopic, 0, 90, "", sarama.ErrNoError).
+			SetOffset(testLagGroup, testLagTopic, 1, -1, "", sarama.ErrNoError),
+	})
+
+	config := newSaramaConfig(nil)
+	config.Metadata.Retry.Max = 0
+	client, err := sarama.NewClient([]string{s.broker.Addr()}, config)
+	s.NoError(err)
+	s.client = client
+
+	s.metricsScope = tally.NewTestScope("test", nil)
+	s.reporter = NewKafkaLagReporter(
+		testLagTopic,
+		testLagGroup,
+		s.client,
+		time.Hour,
+		metrics.NewClient(s.metricsScope, metrics.Common),
+		loggerimpl.NewNopLogger(),
+	)
+}
+
+func (s *kafkaLagReporterSuite) TearDownTest() {
+	s.NoError(s.client.Close())
+	s.broker.Close()
+}
+
+func (s *kafkaLagReporterSuite) TestConsumerLag() {
+	lag, err := s.reporter.ConsumerLag()
+	s.NoError(err)
+	s.Equal(map[int32]int64{0: 10, 1: 30}, lag)
+}
+
+func (s *kafkaLagReporterSuite) TestReportLag() {
+	s.reporter.reportLag()
+
+	gauge, ok := s.metricsScope.Snapshot().Gauges()["test.kafka_consumer_lag+consumerGroup=test-group,kafkaTopic=test-topic,operation=MessagingClientConsumerLag"]
+	s.True(ok)
+	s.Equal(float64(40), gauge.Value())
+}
@@ -22,33 +22,60 @@ package messaging
 
 import (
 	"errors"
-	"fmt"
 
 	"github.com/Shopify/sarama"
-	"github.com/gogo/protobuf/proto"
 
 	indexergenpb "github.com/temporalio/temporal/.gen/proto/indexer"
 	replicationgenpb "github.com/temporalio/temporal/.gen/proto/replication"
 	"github.com/temporalio/temporal/common/log"
 	"github.com/temporalio/temporal/common/log/tag"
+	"github.com/temporalio/temporal/common/metrics"
 )
 
 type (
 	kafkaProducer struct {
-		topic    string
-		producer sarama.SyncProducer
-		logger   log.Logger
+		topic         string
+		topicResolver TopicResolver
+		producer      sarama.SyncProducer
+		compression   CompressionConfig
+		serializer    Serializer
+		metricsClient metrics.Client
+		logger        log.Logger
 	}
+
+	// TopicResolver returns the topic the messages of a namespace are published to, an empty topic
+	// means the default topic of the producer
+	TopicResolver func(namespaceID string) string
 )
 
 var _ Producer = (*kafkaProducer)(nil)
 
-// NewKafkaProducer is used to create the Kafka based producer implementation
-func NewKafkaProducer(topic string, producer sarama.SyncProducer, logger log.Logger) Producer {
+// NewKafkaProducer is used to create the Kafka based producer implementation. The sarama producer compresses
+// record batches as described by the compression config, see newSaramaConfig, which here only tags the metrics.
+// Messages are encoded by the serializer, binary protobuf when it is nil, and the content type is recorded
+// in the ContentTypeHeaderKey header. The topic resolver, when not nil, picks the topic of each message by
+// its namespace, so the load of high volume namespaces can be isolated on dedicated topics. The metrics
+// client, when not nil, records the size of the message values before compression
+func NewKafkaProducer(
+	topic string,
+	producer sarama.SyncProducer,
+	compression CompressionConfig,
+	serializer Serializer,
+	topicResolver TopicResolver,
+	metricsClient metrics.Client,
+	logger log.Logger,
+) Producer {
+	if serializer == nil {
+		serializer = NewProtoSerializer()
+	}
 	return &kafkaProducer{
-		topic:    topic,
-		producer: producer,
-		logger:   logger.WithTags(tag.KafkaTopicName(topic)),
+		topic:         topic,
+		topicResolver: topicResolver,
+		producer:      producer,
+		compression:   compression,
+		serializer:    serializer,
+		metricsClient: metricsClient,
+		logger:        logger.WithTags(tag.KafkaTopicName(topic)),
 	}
 }
 
@@ -77,20 +104,20 @@ func (p *kafkaProducer) Close() error {
 	return p.convertErr(p.producer.Close())
 }
 
-func (p *kafkaProducer) serializeProto(input proto.Marshaler) ([]byte, error) {
-	payload, err := input.Marshal()
+func (p *kafkaProducer) serialize(input interface{}) ([]byte, string, error) {
+	payload, contentType, err := p.serializer.Serialize(input)
 	if err != nil {
-		p.logger.Error("Failed to serialize proto object", tag.Error(err))
+		p.logger.Error("Failed to serialize message", tag.Error(err))
 
-		return nil, err
+		return nil, "", err
 	}
 
-	return payload, nil
+	return payload, contentType, nil
 }
 
-func (p *kafkaProducer) getKeyForReplicationTask(task *replicationgenpb.ReplicationTask) sarama.Encoder {
+func (p *kafkaProducer) getKeyForReplicationTask(task *replicationgenpb.ReplicationTask) (sarama.Encoder, error) {
 	if task == nil {
-		return nil
+		return nil, nil
 	}
 
 	switch task.GetTaskType() {
@@ -99,60 +126,99 @@ func (p *kafkaProducer) getKeyForReplicationTask(task *replicationgenpb.Replicat
 		// Kafka partition.  This will give us some ordering guarantee for workflow replication tasks at least at
 		// the messaging layer perspective
 		attributes := task.GetHistoryTaskAttributes()
-		return sarama.StringEncoder(attributes.GetWorkflowId())
+		return sarama.StringEncoder(attributes.GetWorkflowId()), nil
 	case replicationgenpb.ReplicationTaskTypeHistoryV2:
 		// Use workflowID as the partition key so all replication tasks for a workflow are dispatched to the same
 		// Kafka partition.  This will give us some ordering guarantee for workflow replication tasks at least at
 		// the messaging layer perspective
 		attributes := task.GetHistoryTaskV2Attributes()
-		return sarama.StringEncoder(attributes.GetWorkflowId())
+		return sarama.StringEncoder(attributes.GetWorkflowId()), nil
 	case replicationgenpb.ReplicationTaskTypeSyncActivity:
 		// Use workflowID as the partition key so all sync activity tasks for a workflow are dispatched to the same
 		// Kafka partition.  This will give us some ordering guarantee for workflow replication tasks atleast at
 		// the messaging layer perspective
 		attributes := task.GetSyncActivityTaskAttributes()
-		return sarama.StringEncoder(attributes.GetWorkflowId())
+		return sarama.StringEncoder(attributes.GetWorkflowId()), nil
 	case replicationgenpb.ReplicationTaskTypeHistoryMetadata,
 		replicationgenpb.ReplicationTaskTypeNamespace,
 		replicationgenpb.ReplicationTaskTypeSyncShardStatus:
-		return nil
+		return nil, nil
 	default:
-		panic(fmt.Sprintf("encounter unsupported replication task type: %v", task.GetTaskType()))
+		return nil, &ReplicationTaskSerializationError{
+			TaskType: task.GetTaskType(),
+			Err:      errors.New("unsupported replication task type"),
+		}
 	}
+}
 
-	return nil
+func (p *kafkaProducer) getNamespaceIDForReplicationTask(task *replicationgenpb.ReplicationTask) string {
+	switch task.GetTaskType() {
+	case replicationgenpb.ReplicationTaskTypeHistory:
+		return task.GetHistoryTaskAttributes().GetNamespaceId()
+	case replicationgenpb.ReplicationTaskTypeHistoryV2:
+		return task.GetHistoryTaskV2Attributes().GetNamespaceId()
+	case replicationgenpb.ReplicationTaskTypeSyncActivity:
+		return task.GetSyncActivityTaskAttributes().GetNamespaceId()
+	case replicationgenpb.ReplicationTaskTypeHistoryMetadata:
+		return task.GetHistoryMetadataTaskAttributes().GetNamespaceId()
+	case replicationgenpb.ReplicationTaskTypeNamespace:
+		return task.GetNamespaceTaskAttributes().GetId()
+	default:
+		return ""
+	}
+}
+
+// getTopic returns the topic the messages of the namespace are published to
+func (p *kafkaProducer) getTopic(namespaceID string) string {
+	if p.topicResolver == nil || namespaceID == "" {
+		return p.topic
+	}
+	if topic := p.topicResolver(namespaceID); topic != "" {
+		return topic
+	}
+	return p.topic
 }
 
 func (p *kafkaProducer) getProducerMessage(message interface{}) (*sarama.ProducerMessage, error) {
 	switch message := message.(type) {
 	case *replicationgenpb.ReplicationTask:
-		payload, err := p.serializeProto(message)
+		partitionKey, err := p.getKeyForReplicationTask(message)
 		if err != nil {
+			p.logger.Error("Failed to get partition key for replication task", tag.Error(err))
 			return nil, err
 		}
-		partitionKey := p.getKeyForReplicationTask(message)
-		msg := &sarama.ProducerMessage{
-			Topic: p.topic,
-			Key:   partitionKey,
-			Value: sarama.ByteEncoder(payload),
+		payload, contentType, err := p.serialize(message)
+		if err != nil {
+			return nil, &ReplicationTaskSerializationError{TaskType: message.GetTaskType(), Err: err}
 		}
-		return msg, nil
+		return p.newProducerMessage(p.getTopic(p.getNamespaceIDForReplicationTask(message)), partitionKey, payload, contentType), nil
 	case *indexergenpb.Message:
-		payload, err := p.serializeProto(message)
+		payload, contentType, err := p.serialize(message)
 		if err != nil {
 			return nil, err
 		}
-		msg := &sarama.ProducerMessage{
-			Topic: p.topic,
-			Key:   sarama.StringEncoder(message.GetWorkflowId()),
-			Value: sarama.ByteEncoder(payload),
-		}
-		return msg, nil
+		return p.newProducerMessage(p.getTopic(message.GetNamespaceId()), sarama.StringEncoder(message.GetWorkflowId()), payload, contentType), nil
 	default:
 		return nil, errors.New("unknown producer message type")
 	}
 }
 
+func (p *kafkaProducer) newProducerMessage(topic string, key sarama.Encoder, payload []byte, contentType string) *sarama.ProducerMessage {
+	if p.metricsClient != nil {
+		scope := p.metricsClient.Scope(metrics.MessagingClientPublishScope, metrics.CompressionCodecTag(p.compression.codec()))
+		scope.RecordHistogramValue(metrics.KafkaProducerPayloadSize, float64(len(payload)))
+	}
+
+	return &sarama.ProducerMessage{
+		Topic: topic,
+		Key:   key,
+		Value: sarama.ByteEncoder(payload),
+		Headers: []sarama.RecordHeader{
+			{Key: []byte(ContentTypeHeaderKey), Value: []byte(contentType)},
+		},
+	}
+}
+
 func (p *kafkaProducer) convertErr(err error) error {
 	switch err {
 	case sarama.ErrMessageSizeTooLarge:
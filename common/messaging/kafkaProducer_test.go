@@ -0,0 +1,316 @@
+// The MIT License (MIT)
+//
+// Copyright (c) 2020 Temporal Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package messaging
+
+import (
+	"bytes"
+	"math"
+	"testing"
+
+	"github.com/Shopify/sarama"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+	"github.com/uber-go/tally"
+
+	indexergenpb "github.com/temporalio/temporal/.gen/proto/indexer"
+	replicationgenpb "github.com/temporalio/temporal/.gen/proto/replication"
+	"github.com/temporalio/temporal/common/codec"
+	"github.com/temporalio/temporal/common/log/loggerimpl"
+	"github.com/temporalio/temporal/common/metrics"
+)
+
+type (
+	kafkaProducerSuite struct {
+		suite.Suite
+		*require.Assertions
+	}
+
+	// consumedMessage is a consumed message which exposes its record headers
+	consumedMessage struct {
+		value   []byte
+		headers []*sarama.RecordHeader
+	}
+)
+
+func TestKafkaProducerSuite(t *testing.T) {
+	s := new(kafkaProducerSuite)
+	suite.Run(t, s)
+}
+
+func (s *kafkaProducerSuite) SetupTest() {
+	s.Assertions = require.New(s.T())
+}
+
+func (s *kafkaProducerSuite) TestNewSaramaConfig_Compression() {
+	testCases := []struct {
+		codec         string
+		expectedCodec sarama.CompressionCodec
+	}{
+		{codec: "", expectedCodec: sarama.CompressionNone},
+		{codec: CompressionNone, expectedCodec: sarama.CompressionNone},
+		{codec: CompressionGzip, expectedCodec: sarama.CompressionGZIP},
+		{codec: CompressionSnappy, expectedCodec: sarama.CompressionSnappy},
+		{codec: CompressionLZ4, expectedCodec: sarama.CompressionLZ4},
+		{codec: CompressionZstd, expectedCodec: sarama.CompressionZSTD},
+	}
+
+	for _, tc := range testCases {
+		config := newSaramaConfig(nil, CompressionConfig{Codec: tc.codec})
+		s.Equal(tc.expectedCodec, config.Producer.Compression)
+		// record headers are still supported whatever the codec
+		s.True(config.Version.IsAtLeast(sarama.V0_11_0_0))
+		s.NoError(config.Validate())
+	}
+
+	// zstd record batches need kafka 2.1 or later
+	config := newSaramaConfig(nil, CompressionConfig{Codec: CompressionZstd})
+	s.True(config.Version.IsAtLeast(sarama.V2_1_0_0))
+}
+
+func (s *kafkaProducerSuite) TestGetProducerMessage_PayloadNotCompressed() {
+	msg := s.newIndexerMessage()
+	payload, err := msg.Marshal()
+	s.NoError(err)
+
+	// the sarama producer compresses record batches, so consumers read the serialized payload as is
+	for _, codec := range []string{CompressionNone, CompressionGzip, CompressionZstd} {
+		producer := s.newProducer(CompressionConfig{Codec: codec})
+		producerMsg, err := producer.getProducerMessage(msg)
+		s.NoError(err)
+		value, err := producerMsg.Value.Encode()
+		s.NoError(err)
+		s.Equal(payload, value)
+		s.Equal([]sarama.RecordHeader{{Key: []byte(ContentTypeHeaderKey), Value: []byte(ContentTypeProto)}}, producerMsg.Headers)
+	}
+}
+
+func (s *kafkaProducerSuite) TestGetProducerMessage_PayloadSizeMetrics() {
+	msg := s.newIndexerMessage()
+	payload, err := msg.Marshal()
+	s.NoError(err)
+	metricsScope := tally.NewTestScope("test", nil)
+	producer := NewKafkaProducer("some random topic", nil, CompressionConfig{Codec: CompressionZstd}, nil, nil, metrics.NewClient(metricsScope, metrics.Common), loggerimpl.NewNopLogger()).(*kafkaProducer)
+
+	_, err = producer.getProducerMessage(msg)
+	s.NoError(err)
+
+	histograms := metricsScope.Snapshot().Histograms()
+	payloadSize, ok := histograms["test.kafka_producer_payload_size+compressionCodec=zstd,operation=MessagingClientPublish"]
+	s.True(ok)
+	s.assertHistogramValue(len(payload), payloadSize.Values())
+}
+
+func (s *kafkaProducerSuite) TestGetProducerMessage_DefaultSerializer() {
+	msg := s.newIndexerMessage()
+	payload, err := msg.Marshal()
+	s.NoError(err)
+	producer := s.newProducer(CompressionConfig{})
+
+	producerMsg, err := producer.getProducerMessage(msg)
+	s.NoError(err)
+	s.Equal(ContentTypeProto, s.header(producerMsg, ContentTypeHeaderKey))
+	value, err := producerMsg.Value.Encode()
+	s.NoError(err)
+	s.Equal(payload, value)
+}
+
+func (s *kafkaProducerSuite) TestGetProducerMessage_JSONSerializer() {
+	msg := s.newIndexerMessage()
+	producer := NewKafkaProducer("some random topic", nil, CompressionConfig{}, NewJSONSerializer(), nil, nil, loggerimpl.NewNopLogger()).(*kafkaProducer)
+
+	producerMsg, err := producer.getProducerMessage(msg)
+	s.NoError(err)
+	s.Equal(ContentTypeJSON, s.header(producerMsg, ContentTypeHeaderKey))
+	s.Equal(sarama.StringEncoder(msg.GetWorkflowId()), producerMsg.Key)
+
+	value, err := producerMsg.Value.Encode()
+	s.NoError(err)
+	s.Contains(string(value), `"workflowId":"some random workflow ID"`)
+	decoded := &indexergenpb.Message{}
+	s.NoError(codec.NewJSONPBEncoder().Decode(value, decoded))
+	s.Equal(msg, decoded)
+}
+
+func (s *kafkaProducerSuite) TestProduceConsume_SerializerRoundTrip() {
+	for _, encoding := range []string{SerializerProto, SerializerJSON} {
+		s.Run(encoding, func() {
+			serializer, err := NewSerializer(encoding)
+			s.NoError(err)
+			msg := s.newIndexerMessage()
+			producer := NewKafkaProducer("some random topic", nil, CompressionConfig{}, serializer, nil, nil, loggerimpl.NewNopLogger()).(*kafkaProducer)
+
+			producerMsg, err := producer.getProducerMessage(msg)
+			s.NoError(err)
+
+			decoded := &indexergenpb.Message{}
+			s.NoError(DeserializeMessage(s.consume(producerMsg), decoded))
+			s.Equal(msg, decoded)
+		})
+	}
+}
+
+func (s *kafkaProducerSuite) TestDeserializeMessage_UnknownContentType() {
+	msg := &consumedMessage{
+		value:   []byte("some random value"),
+		headers: []*sarama.RecordHeader{{Key: []byte(ContentTypeHeaderKey), Value: []byte("some random content type")}},
+	}
+	s.Error(DeserializeMessage(msg, &indexergenpb.Message{}))
+}
+
+func (s *kafkaProducerSuite) TestNewSerializer() {
+	serializer, err := NewSerializer("")
+	s.NoError(err)
+	s.IsType(&protoSerializer{}, serializer)
+
+	serializer, err = NewSerializer(SerializerJSON)
+	s.NoError(err)
+	s.IsType(&jsonSerializer{}, serializer)
+
+	_, err = NewSerializer("some random serializer")
+	s.Error(err)
+}
+
+func (s *kafkaProducerSuite) TestGetProducerMessage_UnknownReplicationTaskType() {
+	msg := &replicationgenpb.ReplicationTask{TaskType: replicationgenpb.ReplicationTaskType(1000)}
+	producer := s.newProducer(CompressionConfig{})
+
+	producerMsg, err := producer.getProducerMessage(msg)
+	s.Nil(producerMsg)
+	s.Error(err)
+	serializationErr, ok := err.(*ReplicationTaskSerializationError)
+	s.True(ok)
+	s.Equal(replicationgenpb.ReplicationTaskType(1000), serializationErr.TaskType)
+
+	metricsScope := tally.NewTestScope("test", nil)
+	metricsProducer := NewMetricProducer(producer, metrics.NewClient(metricsScope, metrics.Common))
+	s.Equal(err, metricsProducer.Publish(msg))
+	counter, ok := metricsScope.Snapshot().Counters()["test.replication_task_serialization_failures+operation=MessagingClientPublish,replicationTaskType=1000"]
+	s.True(ok)
+	s.Equal(int64(1), counter.Value())
+}
+
+func (s *kafkaProducerSuite) TestGetProducerMessage_TopicResolver() {
+	topics := map[string]string{
+		"namespace A": "topic A",
+		"namespace B": "topic B",
+	}
+	resolver := func(namespaceID string) string {
+		return topics[namespaceID]
+	}
+	producer := NewKafkaProducer("default topic", nil, CompressionConfig{}, nil, resolver, nil, loggerimpl.NewNopLogger()).(*kafkaProducer)
+
+	for namespaceID, topic := range topics {
+		msg := &replicationgenpb.ReplicationTask{
+			TaskType: replicationgenpb.ReplicationTaskTypeHistoryV2,
+			Attributes: &replicationgenpb.ReplicationTask_HistoryTaskV2Attributes{
+				HistoryTaskV2Attributes: &replicationgenpb.HistoryTaskV2Attributes{
+					NamespaceId: namespaceID,
+					WorkflowId:  "some random workflow ID",
+				},
+			},
+		}
+		producerMsg, err := producer.getProducerMessage(msg)
+		s.NoError(err)
+		s.Equal(topic, producerMsg.Topic)
+		// the partition key does not depend on the topic
+		s.Equal(sarama.StringEncoder("some random workflow ID"), producerMsg.Key)
+	}
+
+	// namespaces without a dedicated topic are published to the default topic
+	msg := s.newIndexerMessage()
+	producerMsg, err := producer.getProducerMessage(msg)
+	s.NoError(err)
+	s.Equal("default topic", producerMsg.Topic)
+	s.Equal(sarama.StringEncoder(msg.GetWorkflowId()), producerMsg.Key)
+
+	msg.NamespaceId = "namespace B"
+	producerMsg, err = producer.getProducerMessage(msg)
+	s.NoError(err)
+	s.Equal("topic B", producerMsg.Topic)
+}
+
+func (s *kafkaProducerSuite) TestCompressionConfigValidate() {
+	s.NoError(CompressionConfig{}.Validate())
+	s.NoError(CompressionConfig{Codec: CompressionLZ4}.Validate())
+	s.NoError(CompressionConfig{Codec: CompressionZstd}.Validate())
+	s.Error(CompressionConfig{Codec: "brotli"}.Validate())
+}
+
+func (s *kafkaProducerSuite) newProducer(compression CompressionConfig) *kafkaProducer {
+	return NewKafkaProducer("some random topic", nil, compression, nil, nil, nil, loggerimpl.NewNopLogger()).(*kafkaProducer)
+}
+
+func (s *kafkaProducerSuite) newIndexerMessage() *indexergenpb.Message {
+	return &indexergenpb.Message{
+		NamespaceId: "some random namespace ID",
+		WorkflowId:  "some random workflow ID",
+		RunId:       "some random run ID",
+		Version:     123,
+		Fields: map[string]*indexergenpb.Field{
+			"some random field": {StringData: string(bytes.Repeat([]byte("some random value "), 100))},
+		},
+	}
+}
+
+// consume returns the message a consumer receives for a produced message
+func (s *kafkaProducerSuite) consume(msg *sarama.ProducerMessage) Message {
+	value, err := msg.Value.Encode()
+	s.NoError(err)
+	consumed := &consumedMessage{value: value}
+	for i := range msg.Headers {
+		consumed.headers = append(consumed.headers, &msg.Headers[i])
+	}
+	return consumed
+}
+
+// assertHistogramValue asserts that the histogram recorded the single value in the smallest bucket holding it
+func (s *kafkaProducerSuite) assertHistogramValue(value int, buckets map[float64]int64) {
+	var recorded []float64
+	smallest := math.MaxFloat64
+	for upperBound, count := range buckets {
+		if count > 0 {
+			s.Equal(int64(1), count)
+			recorded = append(recorded, upperBound)
+		}
+		if upperBound >= float64(value) && upperBound < smallest {
+			smallest = upperBound
+		}
+	}
+	s.Equal([]float64{smallest}, recorded)
+}
+
+func (s *kafkaProducerSuite) header(msg *sarama.ProducerMessage, key string) string {
+	for _, header := range msg.Headers {
+		if string(header.Key) == key {
+			return string(header.Value)
+		}
+	}
+	return ""
+}
+
+func (m *consumedMessage) Value() []byte                   { return m.value }
+func (m *consumedMessage) Partition() int32                { return 0 }
+func (m *consumedMessage) Offset() int64                   { return 0 }
+func (m *consumedMessage) Ack() error                      { return nil }
+func (m *consumedMessage) Nack() error                     { return nil }
+func (m *consumedMessage) Headers() []*sarama.RecordHeader { return m.headers }
@@ -49,6 +49,12 @@ func (p *metricsProducer) Publish(msg interface{}) error {
 
 	if err != nil {
 		p.metricsClient.IncCounter(metrics.MessagingClientPublishScope, metrics.ClientFailures)
+		if serializationErr, ok := err.(*ReplicationTaskSerializationError); ok {
+			p.metricsClient.Scope(
+				metrics.MessagingClientPublishScope,
+				metrics.ReplicationTaskTypeTag(serializationErr.TaskType.String()),
+			).IncCounter(metrics.ReplicationTaskSerializationFailureCounter)
+		}
 	}
 	return err
 }
@@ -0,0 +1,122 @@
+// The MIT License (MIT)
+//
+// Copyright (c) 2020 Temporal Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package messaging
+
+import (
+	"fmt"
+
+	"github.com/gogo/protobuf/proto"
+
+	"github.com/temporalio/temporal/common/codec"
+)
+
+const (
+	// ContentTypeHeaderKey is the record header carrying the content type of the message value
+	ContentTypeHeaderKey = "contentType"
+
+	// ContentTypeProto means the message value is a binary encoded protobuf message
+	ContentTypeProto = "application/x-protobuf"
+	// ContentTypeJSON means the message value is a JSON encoded protobuf message
+	ContentTypeJSON = "application/json"
+
+	// SerializerProto encodes the published messages as binary protobuf
+	SerializerProto = "proto"
+	// SerializerJSON encodes the published messages as protobuf JSON
+	SerializerJSON = "json"
+)
+
+type (
+	// Serializer encodes the messages published by the Kafka producer,
+	// it returns the message value along with its content type
+	Serializer interface {
+		Serialize(message interface{}) ([]byte, string, error)
+	}
+
+	protoSerializer struct{}
+
+	jsonSerializer struct {
+		encoder *codec.JSONPBEncoder
+	}
+)
+
+// NewProtoSerializer returns the default serializer, which encodes messages as binary protobuf
+func NewProtoSerializer() Serializer {
+	return &protoSerializer{}
+}
+
+// NewJSONSerializer returns a serializer which encodes messages as protobuf JSON,
+// for consumers which can not decode binary protobuf
+func NewJSONSerializer() Serializer {
+	return &jsonSerializer{
+		encoder: codec.NewJSONPBEncoder(),
+	}
+}
+
+// NewSerializer returns the serializer of the encoding, one of proto or json, empty means proto
+func NewSerializer(encoding string) (Serializer, error) {
+	switch encoding {
+	case "", SerializerProto:
+		return NewProtoSerializer(), nil
+	case SerializerJSON:
+		return NewJSONSerializer(), nil
+	default:
+		return nil, fmt.Errorf("unsupported serializer: %v", encoding)
+	}
+}
+
+// DeserializeMessage decodes the value of a consumed message into the proto message with the content type
+// of its ContentTypeHeaderKey header, a message without the header is binary protobuf
+func DeserializeMessage(msg Message, pb proto.Message) error {
+	switch contentType := getMessageHeader(msg, ContentTypeHeaderKey); contentType {
+	case "", ContentTypeProto:
+		return proto.Unmarshal(msg.Value(), pb)
+	case ContentTypeJSON:
+		return codec.NewJSONPBEncoder().Decode(msg.Value(), pb)
+	default:
+		return fmt.Errorf("unsupported content type: %v", contentType)
+	}
+}
+
+func (s *protoSerializer) Serialize(message interface{}) ([]byte, string, error) {
+	marshaler, ok := message.(proto.Marshaler)
+	if !ok {
+		return nil, "", fmt.Errorf("message of type %T is not a proto message", message)
+	}
+	payload, err := marshaler.Marshal()
+	if err != nil {
+		return nil, "", err
+	}
+	return payload, ContentTypeProto, nil
+}
+
+func (s *jsonSerializer) Serialize(message interface{}) ([]byte, string, error) {
+	pb, ok := message.(proto.Message)
+	if !ok {
+		return nil, "", fmt.Errorf("message of type %T is not a proto message", message)
+	}
+	payload, err := s.encoder.Encode(pb)
+	if err != nil {
+		return nil, "", err
+	}
+	return payload, ContentTypeJSON, nil
+}
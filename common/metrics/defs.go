@@ -53,8 +53,12 @@ const (
 	Counter MetricType = iota
 	Timer
 	Gauge
+	Histogram
 )
 
+// payloadSizeBuckets are the buckets of the histograms of payload sizes in bytes, from 256B to 8MB
+var payloadSizeBuckets = tally.MustMakeExponentialValueBuckets(256, 2, 16)
+
 // Service names for all services that emit metrics.
 const (
 	Common = iota
@@ -182,10 +186,24 @@ const (
 	PersistenceCreateTaskScope
 	// PersistenceGetTasksScope tracks GetTasks calls made by service to persistence layer
 	PersistenceGetTasksScope
+	// PersistenceGetTaskScope is the metric scope for persistence.TaskManager.GetTask API
+	PersistenceGetTaskScope
 	// PersistenceCompleteTaskScope tracks CompleteTask calls made by service to persistence layer
 	PersistenceCompleteTaskScope
 	// PersistenceCompleteTasksLessThanScope is the metric scope for persistence.TaskManager.PersistenceCompleteTasksLessThan API
 	PersistenceCompleteTasksLessThanScope
+	// PersistenceMoveTasksScope is the metric scope for persistence.TaskManager.MoveTasks API
+	PersistenceMoveTasksScope
+	// PersistencePurgeExpiredTasksScope is the metric scope for persistence.TaskManager.PurgeExpiredTasks API
+	PersistencePurgeExpiredTasksScope
+	// PersistenceEstimateBacklogCountScope is the metric scope for persistence.TaskManager.EstimateBacklogCount API
+	PersistenceEstimateBacklogCountScope
+	// PersistenceGetTaskListRangeIDScope is the metric scope for persistence.TaskManager.GetTaskListRangeID API
+	PersistenceGetTaskListRangeIDScope
+	// PersistenceRenewLeaseAndUpdateScope is the metric scope for persistence.TaskManager.RenewLeaseAndUpdate API
+	PersistenceRenewLeaseAndUpdateScope
+	// PersistenceGetTasksAndMetadataScope is the metric scope for persistence.TaskManager.GetTasksAndMetadata API
+	PersistenceGetTasksAndMetadataScope
 	// PersistenceLeaseTaskListScope tracks LeaseTaskList calls made by service to persistence layer
 	PersistenceLeaseTaskListScope
 	// PersistenceUpdateTaskListScope tracks PersistenceUpdateTaskListScope calls made by service to persistence layer
@@ -344,6 +362,24 @@ const (
 	HistoryClientMergeDLQMessagesScope
 	// HistoryClientRefreshWorkflowTasksScope tracks RPC calls to history service
 	HistoryClientRefreshWorkflowTasksScope
+	// HistoryClientResetReplicationAckLevelScope tracks RPC calls to history service
+	HistoryClientResetReplicationAckLevelScope
+	// HistoryClientGetPendingChildrenScope tracks RPC calls to history service
+	HistoryClientGetPendingChildrenScope
+	// HistoryClientDLQReplicationTaskScope tracks RPC calls to history service
+	HistoryClientDLQReplicationTaskScope
+	// HistoryClientRefreshNamespaceCacheScope tracks RPC calls to history service
+	HistoryClientRefreshNamespaceCacheScope
+	// HistoryClientTailWorkflowExecutionHistoryScope tracks RPC calls to history service
+	HistoryClientTailWorkflowExecutionHistoryScope
+	// HistoryClientRepairShardAckLevelsScope tracks RPC calls to history service
+	HistoryClientRepairShardAckLevelsScope
+	// HistoryClientForceTerminateWorkflowExecutionScope tracks RPC calls to history service
+	HistoryClientForceTerminateWorkflowExecutionScope
+	// HistoryClientListWorkflowTimersScope tracks RPC calls to history service
+	HistoryClientListWorkflowTimersScope
+	// HistoryClientCancelOrphanedTimerScope tracks RPC calls to history service
+	HistoryClientCancelOrphanedTimerScope
 	// MatchingClientPollForDecisionTaskScope tracks RPC calls to matching service
 	MatchingClientPollForDecisionTaskScope
 	// MatchingClientPollForActivityTaskScope tracks RPC calls to matching service
@@ -362,6 +398,14 @@ const (
 	MatchingClientDescribeTaskListScope
 	// MatchingClientListTaskListPartitionsScope tracks RPC calls to matching service
 	MatchingClientListTaskListPartitionsScope
+	// MatchingClientRefreshNamespaceCacheScope tracks RPC calls to matching service
+	MatchingClientRefreshNamespaceCacheScope
+	// MatchingClientListOwnedTaskListsScope tracks RPC calls to matching service
+	MatchingClientListOwnedTaskListsScope
+	// MatchingClientInjectTaskScope tracks RPC calls to matching service
+	MatchingClientInjectTaskScope
+	// MatchingClientCompleteTasksBelowScope tracks RPC calls to matching service
+	MatchingClientCompleteTasksBelowScope
 	// FrontendClientDeprecateNamespaceScope tracks RPC calls to frontend service
 	FrontendClientDeprecateNamespaceScope
 	// FrontendClientDescribeNamespaceScope tracks RPC calls to frontend service
@@ -470,8 +514,36 @@ const (
 	AdminClientPurgeDLQMessagesScope
 	// AdminClientMergeDLQMessagesScope tracks RPC calls to admin service
 	AdminClientMergeDLQMessagesScope
+	// AdminClientMergeOneDLQMessageScope tracks RPC calls to admin service
+	AdminClientMergeOneDLQMessageScope
 	// AdminClientRefreshWorkflowTasksScope tracks RPC calls to admin service
 	AdminClientRefreshWorkflowTasksScope
+	// AdminClientResetReplicationAckLevelScope tracks RPC calls to admin service
+	AdminClientResetReplicationAckLevelScope
+	// AdminClientDLQReplicationTaskScope tracks RPC calls to admin service
+	AdminClientDLQReplicationTaskScope
+	// AdminClientRefreshNamespaceCacheScope tracks RPC calls to admin service
+	AdminClientRefreshNamespaceCacheScope
+	// AdminClientTailWorkflowExecutionHistoryScope tracks RPC calls to admin service
+	AdminClientTailWorkflowExecutionHistoryScope
+	// AdminClientRepairShardAckLevelsScope tracks RPC calls to admin service
+	AdminClientRepairShardAckLevelsScope
+	// AdminClientForceTerminateWorkflowExecutionScope tracks RPC calls to admin service
+	AdminClientForceTerminateWorkflowExecutionScope
+	// AdminClientEstimateTaskListBacklogScope tracks RPC calls to admin service
+	AdminClientEstimateTaskListBacklogScope
+	// AdminClientListOwnedTaskListsScope tracks RPC calls to admin service
+	AdminClientListOwnedTaskListsScope
+	// AdminClientDescribeTaskListStatusScope tracks RPC calls to admin service
+	AdminClientDescribeTaskListStatusScope
+	// AdminClientInjectTaskScope tracks RPC calls to admin service
+	AdminClientInjectTaskScope
+	// AdminClientCompleteTasksBelowScope tracks RPC calls to admin service
+	AdminClientCompleteTasksBelowScope
+	// AdminClientListWorkflowTimersScope tracks RPC calls to admin service
+	AdminClientListWorkflowTimersScope
+	// AdminClientCancelOrphanedTimerScope tracks RPC calls to admin service
+	AdminClientCancelOrphanedTimerScope
 	// DCRedirectionDeprecateNamespaceScope tracks RPC calls for dc redirection
 	DCRedirectionDeprecateNamespaceScope
 	// DCRedirectionDescribeNamespaceScope tracks RPC calls for dc redirection
@@ -555,6 +627,8 @@ const (
 	MessagingClientPublishScope
 	// MessagingPublishBatchScope tracks Publish calls made by service to messaging layer
 	MessagingClientPublishBatchScope
+	// MessagingClientConsumerLagScope tracks the lag of kafka consumer groups on the topics produced to
+	MessagingClientConsumerLagScope
 
 	// NamespaceCacheScope tracks namespace cache callbacks
 	NamespaceCacheScope
@@ -585,6 +659,8 @@ const (
 	PersistenceGetAllHistoryTreeBranchesScope
 	// PersistenceNamespaceReplicationQueueScope is the metrics scope for namespace replication queue
 	PersistenceNamespaceReplicationQueueScope
+	// PersistenceDecisionTaskDLQScope is the metrics scope for the DLQ of decision tasks
+	PersistenceDecisionTaskDLQScope
 
 	// ClusterMetadataArchivalConfigScope tracks ArchivalConfig calls to ClusterMetadata
 	ClusterMetadataArchivalConfigScope
@@ -683,6 +759,34 @@ const (
 	AdminPurgeDLQMessagesScope
 	//AdminMergeDLQMessagesScope is the metric scope for admin.AdminMergeDLQMessagesScope
 	AdminMergeDLQMessagesScope
+	// AdminMergeOneDLQMessageScope is the metric scope for admin.MergeOneDLQMessage
+	AdminMergeOneDLQMessageScope
+	// AdminResetReplicationAckLevelScope is the metric scope for admin.ResetReplicationAckLevel
+	AdminResetReplicationAckLevelScope
+	// AdminDLQReplicationTaskScope is the metric scope for admin.DLQReplicationTask
+	AdminDLQReplicationTaskScope
+	// AdminRefreshNamespaceCacheScope is the metric scope for admin.RefreshNamespaceCache
+	AdminRefreshNamespaceCacheScope
+	// AdminTailWorkflowExecutionHistoryScope is the metric scope for admin.TailWorkflowExecutionHistory
+	AdminTailWorkflowExecutionHistoryScope
+	// AdminRepairShardAckLevelsScope is the metric scope for admin.RepairShardAckLevels
+	AdminRepairShardAckLevelsScope
+	// AdminForceTerminateWorkflowExecutionScope is the metric scope for admin.ForceTerminateWorkflowExecution
+	AdminForceTerminateWorkflowExecutionScope
+	// AdminEstimateTaskListBacklogScope is the metric scope for admin.EstimateTaskListBacklog
+	AdminEstimateTaskListBacklogScope
+	// AdminListOwnedTaskListsScope is the metric scope for admin.ListOwnedTaskLists
+	AdminListOwnedTaskListsScope
+	// AdminDescribeTaskListStatusScope is the metric scope for admin.DescribeTaskListStatus
+	AdminDescribeTaskListStatusScope
+	// AdminInjectTaskScope is the metric scope for admin.InjectTask
+	AdminInjectTaskScope
+	// AdminCompleteTasksBelowScope is the metric scope for admin.CompleteTasksBelow
+	AdminCompleteTasksBelowScope
+	// AdminListWorkflowTimersScope is the metric scope for admin.ListWorkflowTimers
+	AdminListWorkflowTimersScope
+	// AdminCancelOrphanedTimerScope is the metric scope for admin.CancelOrphanedTimer
+	AdminCancelOrphanedTimerScope
 
 	NumAdminScopes
 )
@@ -841,6 +945,24 @@ const (
 	HistoryReapplyEventsScope
 	// HistoryRefreshWorkflowTasksScope is the scope used by refresh workflow tasks API
 	HistoryRefreshWorkflowTasksScope
+	// HistoryResetReplicationAckLevelScope tracks ResetReplicationAckLevel API calls received by service
+	HistoryResetReplicationAckLevelScope
+	// HistoryRepairShardAckLevelsScope tracks RepairShardAckLevels API calls received by service
+	HistoryRepairShardAckLevelsScope
+	// HistoryGetPendingChildrenScope tracks GetPendingChildren API calls received by service
+	HistoryGetPendingChildrenScope
+	// HistoryDLQReplicationTaskScope tracks DLQReplicationTask API calls received by service
+	HistoryDLQReplicationTaskScope
+	// HistoryRefreshNamespaceCacheScope tracks RefreshNamespaceCache API calls received by service
+	HistoryRefreshNamespaceCacheScope
+	// HistoryTailWorkflowExecutionHistoryScope tracks TailWorkflowExecutionHistory API calls received by service
+	HistoryTailWorkflowExecutionHistoryScope
+	// HistoryForceTerminateWorkflowExecutionScope tracks ForceTerminateWorkflowExecution API calls received by service
+	HistoryForceTerminateWorkflowExecutionScope
+	// HistoryListWorkflowTimersScope tracks ListWorkflowTimers API calls received by service
+	HistoryListWorkflowTimersScope
+	// HistoryCancelOrphanedTimerScope tracks CancelOrphanedTimer API calls received by service
+	HistoryCancelOrphanedTimerScope
 	// TaskPriorityAssignerScope is the scope used by all metric emitted by task priority assigner
 	TaskPriorityAssignerScope
 	// TransferQueueProcessorScope is the scope used by all metric emitted by transfer queue processor
@@ -905,6 +1027,8 @@ const (
 	TimerActiveTaskWorkflowBackoffTimerScope
 	// TimerActiveTaskDeleteHistoryEventScope is the scope used by metric emitted by timer queue processor for processing history event cleanup
 	TimerActiveTaskDeleteHistoryEventScope
+	// TimerActiveTaskCancellationGracePeriodScope is the scope used by metric emitted by timer queue processor for processing cancellation grace periods
+	TimerActiveTaskCancellationGracePeriodScope
 	// TimerStandbyTaskActivityTimeoutScope is the scope used by metric emitted by timer queue processor for processing activity timeouts
 	TimerStandbyTaskActivityTimeoutScope
 	// TimerStandbyTaskDecisionTimeoutScope is the scope used by metric emitted by timer queue processor for processing decision timeouts
@@ -919,6 +1043,8 @@ const (
 	TimerStandbyTaskDeleteHistoryEventScope
 	// TimerStandbyTaskWorkflowBackoffTimerScope is the scope used by metric emitted by timer queue processor for processing retry task.
 	TimerStandbyTaskWorkflowBackoffTimerScope
+	// TimerStandbyTaskCancellationGracePeriodScope is the scope used by metric emitted by timer queue processor for processing cancellation grace periods
+	TimerStandbyTaskCancellationGracePeriodScope
 	// HistoryEventNotificationScope is the scope used by shard history event nitification
 	HistoryEventNotificationScope
 	// ReplicatorQueueProcessorScope is the scope used by all metric emitted by replicator queue processor
@@ -999,6 +1125,14 @@ const (
 	MatchingDescribeTaskListScope
 	// MatchingListTaskListPartitionsScope tracks ListTaskListPartitions API calls received by service
 	MatchingListTaskListPartitionsScope
+	// MatchingRefreshNamespaceCacheScope tracks RefreshNamespaceCache API calls received by service
+	MatchingRefreshNamespaceCacheScope
+	// MatchingListOwnedTaskListsScope tracks ListOwnedTaskLists API calls received by service
+	MatchingListOwnedTaskListsScope
+	// MatchingInjectTaskScope tracks InjectTask API calls received by service
+	MatchingInjectTaskScope
+	// MatchingCompleteTasksBelowScope tracks CompleteTasksBelow API calls received by service
+	MatchingCompleteTasksBelowScope
 
 	NumMatchingScopes
 )
@@ -1080,8 +1214,15 @@ var ScopeDefs = map[ServiceIdx]map[int]scopeDefinition{
 		PersistenceRangeCompleteTimerTaskScope:                   {operation: "RangeCompleteTimerTask"},
 		PersistenceCreateTaskScope:                               {operation: "CreateTask"},
 		PersistenceGetTasksScope:                                 {operation: "GetTasks"},
+		PersistenceGetTaskScope:                                  {operation: "GetTask"},
 		PersistenceCompleteTaskScope:                             {operation: "CompleteTask"},
 		PersistenceCompleteTasksLessThanScope:                    {operation: "CompleteTasksLessThan"},
+		PersistenceMoveTasksScope:                                {operation: "MoveTasks"},
+		PersistencePurgeExpiredTasksScope:                        {operation: "PurgeExpiredTasks"},
+		PersistenceEstimateBacklogCountScope:                     {operation: "EstimateBacklogCount"},
+		PersistenceGetTaskListRangeIDScope:                       {operation: "GetTaskListRangeID"},
+		PersistenceRenewLeaseAndUpdateScope:                      {operation: "RenewLeaseAndUpdate"},
+		PersistenceGetTasksAndMetadataScope:                      {operation: "GetTasksAndMetadata"},
 		PersistenceLeaseTaskListScope:                            {operation: "LeaseTaskList"},
 		PersistenceUpdateTaskListScope:                           {operation: "UpdateTaskList"},
 		PersistenceListTaskListScope:                             {operation: "ListTaskList"},
@@ -1130,6 +1271,7 @@ var ScopeDefs = map[ServiceIdx]map[int]scopeDefinition{
 		PersistenceUpdateDLQAckLevelScope:                        {operation: "UpdateDLQAckLevel"},
 		PersistenceGetDLQAckLevelScope:                           {operation: "GetDLQAckLevel"},
 		PersistenceNamespaceReplicationQueueScope:                {operation: "NamespaceReplicationQueue"},
+		PersistenceDecisionTaskDLQScope:                          {operation: "DecisionTaskDLQ"},
 		PersistenceInitImmutableClusterMetadataScope:             {operation: "InitializeImmutableClusterMetadata"},
 		PersistenceGetImmutableClusterMetadataScope:              {operation: "GetImmutableClusterMetadata"},
 		PersistencePruneClusterMembershipScope:                   {operation: "PruneClusterMembership"},
@@ -1172,6 +1314,15 @@ var ScopeDefs = map[ServiceIdx]map[int]scopeDefinition{
 		HistoryClientPurgeDLQMessagesScope:                    {operation: "HistoryClientPurgeDLQMessagesScope", tags: map[string]string{ServiceRoleTagName: HistoryRoleTagValue}},
 		HistoryClientMergeDLQMessagesScope:                    {operation: "HistoryClientMergeDLQMessagesScope", tags: map[string]string{ServiceRoleTagName: HistoryRoleTagValue}},
 		HistoryClientRefreshWorkflowTasksScope:                {operation: "HistoryClientRefreshWorkflowTasksScope", tags: map[string]string{ServiceRoleTagName: HistoryRoleTagValue}},
+		HistoryClientResetReplicationAckLevelScope:            {operation: "HistoryClientResetReplicationAckLevelScope", tags: map[string]string{ServiceRoleTagName: HistoryRoleTagValue}},
+		HistoryClientGetPendingChildrenScope:                  {operation: "HistoryClientGetPendingChildrenScope", tags: map[string]string{ServiceRoleTagName: HistoryRoleTagValue}},
+		HistoryClientDLQReplicationTaskScope:                  {operation: "HistoryClientDLQReplicationTaskScope", tags: map[string]string{ServiceRoleTagName: HistoryRoleTagValue}},
+		HistoryClientRefreshNamespaceCacheScope:               {operation: "HistoryClientRefreshNamespaceCacheScope", tags: map[string]string{ServiceRoleTagName: HistoryRoleTagValue}},
+		HistoryClientTailWorkflowExecutionHistoryScope:        {operation: "HistoryClientTailWorkflowExecutionHistoryScope", tags: map[string]string{ServiceRoleTagName: HistoryRoleTagValue}},
+		HistoryClientRepairShardAckLevelsScope:                {operation: "HistoryClientRepairShardAckLevelsScope", tags: map[string]string{ServiceRoleTagName: HistoryRoleTagValue}},
+		HistoryClientForceTerminateWorkflowExecutionScope:     {operation: "HistoryClientForceTerminateWorkflowExecutionScope", tags: map[string]string{ServiceRoleTagName: HistoryRoleTagValue}},
+		HistoryClientListWorkflowTimersScope:                  {operation: "HistoryClientListWorkflowTimersScope", tags: map[string]string{ServiceRoleTagName: HistoryRoleTagValue}},
+		HistoryClientCancelOrphanedTimerScope:                 {operation: "HistoryClientCancelOrphanedTimerScope", tags: map[string]string{ServiceRoleTagName: HistoryRoleTagValue}},
 		MatchingClientPollForDecisionTaskScope:                {operation: "MatchingClientPollForDecisionTask", tags: map[string]string{ServiceRoleTagName: MatchingRoleTagValue}},
 		MatchingClientPollForActivityTaskScope:                {operation: "MatchingClientPollForActivityTask", tags: map[string]string{ServiceRoleTagName: MatchingRoleTagValue}},
 		MatchingClientAddActivityTaskScope:                    {operation: "MatchingClientAddActivityTask", tags: map[string]string{ServiceRoleTagName: MatchingRoleTagValue}},
@@ -1181,6 +1332,10 @@ var ScopeDefs = map[ServiceIdx]map[int]scopeDefinition{
 		MatchingClientCancelOutstandingPollScope:              {operation: "MatchingClientCancelOutstandingPoll", tags: map[string]string{ServiceRoleTagName: MatchingRoleTagValue}},
 		MatchingClientDescribeTaskListScope:                   {operation: "MatchingClientDescribeTaskList", tags: map[string]string{ServiceRoleTagName: MatchingRoleTagValue}},
 		MatchingClientListTaskListPartitionsScope:             {operation: "MatchingClientListTaskListPartitions", tags: map[string]string{ServiceRoleTagName: MatchingRoleTagValue}},
+		MatchingClientRefreshNamespaceCacheScope:              {operation: "MatchingClientRefreshNamespaceCache", tags: map[string]string{ServiceRoleTagName: MatchingRoleTagValue}},
+		MatchingClientListOwnedTaskListsScope:                 {operation: "MatchingClientListOwnedTaskLists", tags: map[string]string{ServiceRoleTagName: MatchingRoleTagValue}},
+		MatchingClientInjectTaskScope:                         {operation: "MatchingClientInjectTask", tags: map[string]string{ServiceRoleTagName: MatchingRoleTagValue}},
+		MatchingClientCompleteTasksBelowScope:                 {operation: "MatchingClientCompleteTasksBelow", tags: map[string]string{ServiceRoleTagName: MatchingRoleTagValue}},
 		FrontendClientDeprecateNamespaceScope:                 {operation: "FrontendClientDeprecateNamespace", tags: map[string]string{ServiceRoleTagName: FrontendRoleTagValue}},
 		FrontendClientDescribeNamespaceScope:                  {operation: "FrontendClientDescribeNamespace", tags: map[string]string{ServiceRoleTagName: FrontendRoleTagValue}},
 		FrontendClientDescribeTaskListScope:                   {operation: "FrontendClientDescribeTaskList", tags: map[string]string{ServiceRoleTagName: FrontendRoleTagValue}},
@@ -1236,6 +1391,20 @@ var ScopeDefs = map[ServiceIdx]map[int]scopeDefinition{
 		AdminClientReadDLQMessagesScope:                       {operation: "AdminClientReadDLQMessages", tags: map[string]string{ServiceRoleTagName: AdminRoleTagValue}},
 		AdminClientPurgeDLQMessagesScope:                      {operation: "AdminClientPurgeDLQMessages", tags: map[string]string{ServiceRoleTagName: AdminRoleTagValue}},
 		AdminClientMergeDLQMessagesScope:                      {operation: "AdminClientMergeDLQMessages", tags: map[string]string{ServiceRoleTagName: AdminRoleTagValue}},
+		AdminClientMergeOneDLQMessageScope:                    {operation: "AdminClientMergeOneDLQMessage", tags: map[string]string{ServiceRoleTagName: AdminRoleTagValue}},
+		AdminClientResetReplicationAckLevelScope:              {operation: "AdminClientResetReplicationAckLevel", tags: map[string]string{ServiceRoleTagName: AdminRoleTagValue}},
+		AdminClientDLQReplicationTaskScope:                    {operation: "AdminClientDLQReplicationTask", tags: map[string]string{ServiceRoleTagName: AdminRoleTagValue}},
+		AdminClientRefreshNamespaceCacheScope:                 {operation: "AdminClientRefreshNamespaceCache", tags: map[string]string{ServiceRoleTagName: AdminRoleTagValue}},
+		AdminClientTailWorkflowExecutionHistoryScope:          {operation: "AdminClientTailWorkflowExecutionHistory", tags: map[string]string{ServiceRoleTagName: AdminRoleTagValue}},
+		AdminClientRepairShardAckLevelsScope:                  {operation: "AdminClientRepairShardAckLevels", tags: map[string]string{ServiceRoleTagName: AdminRoleTagValue}},
+		AdminClientForceTerminateWorkflowExecutionScope:       {operation: "AdminClientForceTerminateWorkflowExecution", tags: map[string]string{ServiceRoleTagName: AdminRoleTagValue}},
+		AdminClientEstimateTaskListBacklogScope:               {operation: "AdminClientEstimateTaskListBacklog", tags: map[string]string{ServiceRoleTagName: AdminRoleTagValue}},
+		AdminClientListOwnedTaskListsScope:                    {operation: "AdminClientListOwnedTaskLists", tags: map[string]string{ServiceRoleTagName: AdminRoleTagValue}},
+		AdminClientDescribeTaskListStatusScope:                {operation: "AdminClientDescribeTaskListStatus", tags: map[string]string{ServiceRoleTagName: AdminRoleTagValue}},
+		AdminClientInjectTaskScope:                            {operation: "AdminClientInjectTask", tags: map[string]string{ServiceRoleTagName: AdminRoleTagValue}},
+		AdminClientCompleteTasksBelowScope:                    {operation: "AdminClientCompleteTasksBelow", tags: map[string]string{ServiceRoleTagName: AdminRoleTagValue}},
+		AdminClientListWorkflowTimersScope:                    {operation: "AdminClientListWorkflowTimers", tags: map[string]string{ServiceRoleTagName: AdminRoleTagValue}},
+		AdminClientCancelOrphanedTimerScope:                   {operation: "AdminClientCancelOrphanedTimer", tags: map[string]string{ServiceRoleTagName: AdminRoleTagValue}},
 		DCRedirectionDeprecateNamespaceScope:                  {operation: "DCRedirectionDeprecateNamespace", tags: map[string]string{ServiceRoleTagName: DCRedirectionRoleTagValue}},
 		DCRedirectionDescribeNamespaceScope:                   {operation: "DCRedirectionDescribeNamespace", tags: map[string]string{ServiceRoleTagName: DCRedirectionRoleTagValue}},
 		DCRedirectionDescribeTaskListScope:                    {operation: "DCRedirectionDescribeTaskList", tags: map[string]string{ServiceRoleTagName: DCRedirectionRoleTagValue}},
@@ -1278,6 +1447,7 @@ var ScopeDefs = map[ServiceIdx]map[int]scopeDefinition{
 
 		MessagingClientPublishScope:      {operation: "MessagingClientPublish"},
 		MessagingClientPublishBatchScope: {operation: "MessagingClientPublishBatch"},
+		MessagingClientConsumerLagScope:  {operation: "MessagingClientConsumerLag"},
 
 		NamespaceCacheScope:                                   {operation: "NamespaceCache"},
 		HistoryRereplicationByTransferTaskScope:               {operation: "HistoryRereplicationByTransferTask"},
@@ -1323,6 +1493,7 @@ var ScopeDefs = map[ServiceIdx]map[int]scopeDefinition{
 		AdminReadDLQMessagesScope:                  {operation: "AdminReadDLQMessages"},
 		AdminPurgeDLQMessagesScope:                 {operation: "AdminPurgeDLQMessages"},
 		AdminMergeDLQMessagesScope:                 {operation: "AdminMergeDLQMessages"},
+		AdminMergeOneDLQMessageScope:               {operation: "AdminMergeOneDLQMessage"},
 		AdminDescribeHistoryHostScope:              {operation: "DescribeHistoryHost"},
 		AdminAddSearchAttributeScope:               {operation: "AddSearchAttribute"},
 		AdminDescribeWorkflowExecutionScope:        {operation: "DescribeWorkflowExecution"},
@@ -1333,6 +1504,19 @@ var ScopeDefs = map[ServiceIdx]map[int]scopeDefinition{
 		AdminGetDLQReplicationMessagesScope:        {operation: "AdminGetDLQReplicationMessages"},
 		AdminReapplyEventsScope:                    {operation: "ReapplyEvents"},
 		AdminRefreshWorkflowTasksScope:             {operation: "RefreshWorkflowTasks"},
+		AdminResetReplicationAckLevelScope:         {operation: "ResetReplicationAckLevel"},
+		AdminDLQReplicationTaskScope:               {operation: "DLQReplicationTask"},
+		AdminRefreshNamespaceCacheScope:            {operation: "RefreshNamespaceCache"},
+		AdminTailWorkflowExecutionHistoryScope:     {operation: "TailWorkflowExecutionHistory"},
+		AdminRepairShardAckLevelsScope:             {operation: "RepairShardAckLevels"},
+		AdminForceTerminateWorkflowExecutionScope:  {operation: "ForceTerminateWorkflowExecution"},
+		AdminEstimateTaskListBacklogScope:          {operation: "EstimateTaskListBacklog"},
+		AdminListOwnedTaskListsScope:               {operation: "ListOwnedTaskLists"},
+		AdminDescribeTaskListStatusScope:           {operation: "DescribeTaskListStatus"},
+		AdminInjectTaskScope:                       {operation: "InjectTask"},
+		AdminCompleteTasksBelowScope:               {operation: "CompleteTasksBelow"},
+		AdminListWorkflowTimersScope:               {operation: "ListWorkflowTimers"},
+		AdminCancelOrphanedTimerScope:              {operation: "CancelOrphanedTimer"},
 
 		FrontendStartWorkflowExecutionScope:             {operation: "StartWorkflowExecution"},
 		FrontendPollForDecisionTaskScope:                {operation: "PollForDecisionTask"},
@@ -1413,6 +1597,15 @@ var ScopeDefs = map[ServiceIdx]map[int]scopeDefinition{
 		HistoryShardControllerScope:                            {operation: "ShardController"},
 		HistoryReapplyEventsScope:                              {operation: "EventReapplication"},
 		HistoryRefreshWorkflowTasksScope:                       {operation: "RefreshWorkflowTasks"},
+		HistoryResetReplicationAckLevelScope:                   {operation: "ResetReplicationAckLevel"},
+		HistoryRepairShardAckLevelsScope:                       {operation: "RepairShardAckLevels"},
+		HistoryGetPendingChildrenScope:                         {operation: "GetPendingChildren"},
+		HistoryDLQReplicationTaskScope:                         {operation: "DLQReplicationTask"},
+		HistoryRefreshNamespaceCacheScope:                      {operation: "RefreshNamespaceCache"},
+		HistoryTailWorkflowExecutionHistoryScope:               {operation: "TailWorkflowExecutionHistory"},
+		HistoryForceTerminateWorkflowExecutionScope:            {operation: "ForceTerminateWorkflowExecution"},
+		HistoryListWorkflowTimersScope:                         {operation: "ListWorkflowTimers"},
+		HistoryCancelOrphanedTimerScope:                        {operation: "CancelOrphanedTimer"},
 		TaskPriorityAssignerScope:                              {operation: "TaskPriorityAssigner"},
 		TransferQueueProcessorScope:                            {operation: "TransferQueueProcessor"},
 		TransferActiveQueueProcessorScope:                      {operation: "TransferActiveQueueProcessor"},
@@ -1445,6 +1638,7 @@ var ScopeDefs = map[ServiceIdx]map[int]scopeDefinition{
 		TimerActiveTaskActivityRetryTimerScope:                 {operation: "TimerActiveTaskActivityRetryTimer"},
 		TimerActiveTaskWorkflowBackoffTimerScope:               {operation: "TimerActiveTaskWorkflowBackoffTimer"},
 		TimerActiveTaskDeleteHistoryEventScope:                 {operation: "TimerActiveTaskDeleteHistoryEvent"},
+		TimerActiveTaskCancellationGracePeriodScope:            {operation: "TimerActiveTaskCancellationGracePeriod"},
 		TimerStandbyTaskActivityTimeoutScope:                   {operation: "TimerStandbyTaskActivityTimeout"},
 		TimerStandbyTaskDecisionTimeoutScope:                   {operation: "TimerStandbyTaskDecisionTimeout"},
 		TimerStandbyTaskUserTimerScope:                         {operation: "TimerStandbyTaskUserTimer"},
@@ -1452,6 +1646,7 @@ var ScopeDefs = map[ServiceIdx]map[int]scopeDefinition{
 		TimerStandbyTaskActivityRetryTimerScope:                {operation: "TimerStandbyTaskActivityRetryTimer"},
 		TimerStandbyTaskWorkflowBackoffTimerScope:              {operation: "TimerStandbyTaskWorkflowBackoffTimer"},
 		TimerStandbyTaskDeleteHistoryEventScope:                {operation: "TimerStandbyTaskDeleteHistoryEvent"},
+		TimerStandbyTaskCancellationGracePeriodScope:           {operation: "TimerStandbyTaskCancellationGracePeriod"},
 		HistoryEventNotificationScope:                          {operation: "HistoryEventNotification"},
 		ReplicatorQueueProcessorScope:                          {operation: "ReplicatorQueueProcessor"},
 		ReplicatorTaskHistoryScope:                             {operation: "ReplicatorTaskHistory"},
@@ -1489,6 +1684,10 @@ var ScopeDefs = map[ServiceIdx]map[int]scopeDefinition{
 		MatchingCancelOutstandingPollScope:     {operation: "CancelOutstandingPoll"},
 		MatchingDescribeTaskListScope:          {operation: "DescribeTaskList"},
 		MatchingListTaskListPartitionsScope:    {operation: "ListTaskListPartitions"},
+		MatchingRefreshNamespaceCacheScope:     {operation: "RefreshNamespaceCache"},
+		MatchingListOwnedTaskListsScope:        {operation: "ListOwnedTaskLists"},
+		MatchingInjectTaskScope:                {operation: "InjectTask"},
+		MatchingCompleteTasksBelowScope:        {operation: "CompleteTasksBelow"},
 	},
 	// Worker Scope Names
 	Worker: {
@@ -1615,6 +1814,12 @@ const (
 	NamespaceReplicationTaskAckLevelGauge
 	NamespaceReplicationDLQAckLevelGauge
 	NamespaceReplicationDLQMaxLevelGauge
+	DecisionTaskDLQMaxLevelGauge
+
+	KafkaConsumerLagGauge
+	KafkaConsumerLagFailures
+	ReplicationTaskSerializationFailureCounter
+	KafkaProducerPayloadSize
 
 	NumCommonMetrics // Needs to be last on this list for iota numbering
 )
@@ -1625,6 +1830,7 @@ const (
 	TaskLatency
 	TaskFailures
 	TaskDiscarded
+	TaskMovedToDLQ
 	TaskAttemptTimer
 	TaskStandbyRetryCounter
 	TaskNotActiveCounter
@@ -1655,6 +1861,17 @@ const (
 	EmptyCompletionDecisionsCounter
 	MultipleCompletionDecisionsCounter
 	FailedDecisionsCounter
+	MemoSizeExceededCounter
+	ScheduleActivityDuplicateIDCounter
+	UnknownActivityTaskListCounter
+	DroppedDecisionsCounter
+	DecisionPanicCounter
+	DedupedSignalExternalDecisionsCounter
+	RetryCronContinueAsNewCounter
+	PendingActivitiesLimitExceededCounter
+	ChildWorkflowWithTraceContextCounter
+	ChildWorkflowWithoutTraceContextCounter
+	BufferedEventsCountGauge
 	StaleMutableStateCounter
 	AutoResetPointsLimitExceededCounter
 	AutoResetPointCorruptionCounter
@@ -1701,6 +1918,7 @@ const (
 	HistoryEventNotificationFanoutLatency
 	HistoryEventNotificationInFlightMessageGauge
 	HistoryEventNotificationFailDeliveryCount
+	HistoryEventNotificationIdleExpiredCount
 	EmptyReplicationEventsCounter
 	DuplicateReplicationEventsCounter
 	StaleReplicationEventsCounter
@@ -1752,11 +1970,13 @@ const (
 	ArchiverClientVisibilityRequestCount
 	ArchiverClientVisibilityInlineArchiveAttemptCount
 	ArchiverClientVisibilityInlineArchiveFailureCount
+	ArchiverClientInlineArchiveLatency
 	LastRetrievedMessageID
 	LastProcessedMessageID
 	ReplicationTasksApplied
 	ReplicationTasksFailed
 	ReplicationTasksLag
+	ReplicationTasksLagTime
 	ReplicationTasksFetched
 	ReplicationTasksReturned
 	ReplicationDLQFailed
@@ -1799,6 +2019,7 @@ const (
 	RespondQueryTaskFailedCounter
 	SyncThrottleCounter
 	BufferThrottleCounter
+	BacklogShedCounter
 	SyncMatchLatency
 	AsyncMatchLatency
 	ExpiredTasksCounter
@@ -1816,6 +2037,12 @@ const (
 	LocalToRemoteMatchCounter
 	RemoteToLocalMatchCounter
 	RemoteToRemoteMatchCounter
+	ForwarderBreakerStateGauge
+	LocalMatchCounter
+	ForwardedMatchCounter
+	ForwardFailureCounter
+	SyncMatchRatioGauge
+	EvictedPollersCounter
 
 	NumMatchingMetrics
 )
@@ -1969,6 +2196,13 @@ var MetricDefs = map[ServiceIdx]map[int]metricDefinition{
 		NamespaceReplicationTaskAckLevelGauge: {metricName: "namespace_replication_task_ack_level", metricType: Gauge},
 		NamespaceReplicationDLQAckLevelGauge:  {metricName: "namespace_dlq_ack_level", metricType: Gauge},
 		NamespaceReplicationDLQMaxLevelGauge:  {metricName: "namespace_dlq_max_level", metricType: Gauge},
+		DecisionTaskDLQMaxLevelGauge:          {metricName: "decision_task_dlq_max_level", metricType: Gauge},
+
+		KafkaConsumerLagGauge:    {metricName: "kafka_consumer_lag", metricType: Gauge},
+		KafkaConsumerLagFailures: {metricName: "kafka_consumer_lag_failures", metricType: Counter},
+
+		ReplicationTaskSerializationFailureCounter: {metricName: "replication_task_serialization_failures", metricType: Counter},
+		KafkaProducerPayloadSize:                   {metricName: "kafka_producer_payload_size", metricType: Histogram, buckets: payloadSizeBuckets},
 	},
 	History: {
 		TaskRequests:                                      {metricName: "task_requests", metricType: Counter},
@@ -1976,6 +2210,7 @@ var MetricDefs = map[ServiceIdx]map[int]metricDefinition{
 		TaskAttemptTimer:                                  {metricName: "task_attempt", metricType: Timer},
 		TaskFailures:                                      {metricName: "task_errors", metricType: Counter},
 		TaskDiscarded:                                     {metricName: "task_errors_discarded", metricType: Counter},
+		TaskMovedToDLQ:                                    {metricName: "task_errors_moved_to_dlq", metricType: Counter},
 		TaskStandbyRetryCounter:                           {metricName: "task_errors_standby_retry_counter", metricType: Counter},
 		TaskNotActiveCounter:                              {metricName: "task_errors_not_active_counter", metricType: Counter},
 		TaskLimitExceededCounter:                          {metricName: "task_errors_limit_exceeded_counter", metricType: Counter},
@@ -2003,6 +2238,17 @@ var MetricDefs = map[ServiceIdx]map[int]metricDefinition{
 		EmptyCompletionDecisionsCounter:                   {metricName: "empty_completion_decisions", metricType: Counter},
 		MultipleCompletionDecisionsCounter:                {metricName: "multiple_completion_decisions", metricType: Counter},
 		FailedDecisionsCounter:                            {metricName: "failed_decisions", metricType: Counter},
+		MemoSizeExceededCounter:                           {metricName: "memo_size_exceeded", metricType: Counter},
+		ScheduleActivityDuplicateIDCounter:                {metricName: "schedule_activity_duplicate_id", metricType: Counter},
+		UnknownActivityTaskListCounter:                    {metricName: "unknown_activity_task_list", metricType: Counter},
+		DroppedDecisionsCounter:                           {metricName: "dropped_decisions", metricType: Counter},
+		DecisionPanicCounter:                              {metricName: "decision_panic", metricType: Counter},
+		DedupedSignalExternalDecisionsCounter:             {metricName: "deduped_signal_external_decisions", metricType: Counter},
+		RetryCronContinueAsNewCounter:                     {metricName: "retry_cron_continue_as_new", metricType: Counter},
+		PendingActivitiesLimitExceededCounter:             {metricName: "pending_activities_limit_exceeded", metricType: Counter},
+		ChildWorkflowWithTraceContextCounter:              {metricName: "child_workflow_with_trace_context", metricType: Counter},
+		ChildWorkflowWithoutTraceContextCounter:           {metricName: "child_workflow_without_trace_context", metricType: Counter},
+		BufferedEventsCountGauge:                          {metricName: "buffered_events_count", metricType: Gauge},
 		StaleMutableStateCounter:                          {metricName: "stale_mutable_state", metricType: Counter},
 		AutoResetPointsLimitExceededCounter:               {metricName: "auto_reset_points_exceed_limit", metricType: Counter},
 		AutoResetPointCorruptionCounter:                   {metricName: "auto_reset_point_corruption", metricType: Counter},
@@ -2049,6 +2295,7 @@ var MetricDefs = map[ServiceIdx]map[int]metricDefinition{
 		HistoryEventNotificationFanoutLatency:             {metricName: "history_event_notification_fanout_latency", metricType: Timer},
 		HistoryEventNotificationInFlightMessageGauge:      {metricName: "history_event_notification_inflight_message_gauge", metricType: Gauge},
 		HistoryEventNotificationFailDeliveryCount:         {metricName: "history_event_notification_fail_delivery_count", metricType: Counter},
+		HistoryEventNotificationIdleExpiredCount:          {metricName: "history_event_notification_idle_expired_count", metricType: Counter},
 		EmptyReplicationEventsCounter:                     {metricName: "empty_replication_events", metricType: Counter},
 		DuplicateReplicationEventsCounter:                 {metricName: "duplicate_replication_events", metricType: Counter},
 		StaleReplicationEventsCounter:                     {metricName: "stale_replication_events", metricType: Counter},
@@ -2100,11 +2347,13 @@ var MetricDefs = map[ServiceIdx]map[int]metricDefinition{
 		ArchiverClientVisibilityRequestCount:              {metricName: "archiver_client_visibility_request", metricType: Counter},
 		ArchiverClientVisibilityInlineArchiveAttemptCount: {metricName: "archiver_client_visibility_inline_archive_attempt", metricType: Counter},
 		ArchiverClientVisibilityInlineArchiveFailureCount: {metricName: "archiver_client_visibility_inline_archive_failure", metricType: Counter},
+		ArchiverClientInlineArchiveLatency:                {metricName: "archiver_client_inline_archive_latency", metricType: Timer},
 		LastRetrievedMessageID:                            {metricName: "last_retrieved_message_id", metricType: Gauge},
 		LastProcessedMessageID:                            {metricName: "last_processed_message_id", metricType: Gauge},
 		ReplicationTasksApplied:                           {metricName: "replication_tasks_applied", metricType: Counter},
 		ReplicationTasksFailed:                            {metricName: "replication_tasks_failed", metricType: Counter},
 		ReplicationTasksLag:                               {metricName: "replication_tasks_lag", metricType: Timer},
+		ReplicationTasksLagTime:                           {metricName: "replication_tasks_lag_time", metricType: Timer},
 		ReplicationTasksFetched:                           {metricName: "replication_tasks_fetched", metricType: Timer},
 		ReplicationTasksReturned:                          {metricName: "replication_tasks_returned", metricType: Timer},
 		ReplicationDLQFailed:                              {metricName: "replication_dlq_enqueue_failed", metricType: Counter},
@@ -2143,6 +2392,7 @@ var MetricDefs = map[ServiceIdx]map[int]metricDefinition{
 		RespondQueryTaskFailedCounter: {metricName: "respond_query_failed"},
 		SyncThrottleCounter:           {metricName: "sync_throttle_count"},
 		BufferThrottleCounter:         {metricName: "buffer_throttle_count"},
+		BacklogShedCounter:            {metricName: "backlog_shed_count"},
 		ExpiredTasksCounter:           {metricName: "tasks_expired"},
 		ForwardedCounter:              {metricName: "forwarded"},
 		ForwardTaskCalls:              {metricName: "forward_task_calls"},
@@ -2160,6 +2410,12 @@ var MetricDefs = map[ServiceIdx]map[int]metricDefinition{
 		LocalToRemoteMatchCounter:     {metricName: "local_to_remote_matches"},
 		RemoteToLocalMatchCounter:     {metricName: "remote_to_local_matches"},
 		RemoteToRemoteMatchCounter:    {metricName: "remote_to_remote_matches"},
+		ForwarderBreakerStateGauge:    {metricName: "forwarder_breaker_state", metricType: Gauge},
+		LocalMatchCounter:             {metricName: "local_matches"},
+		ForwardedMatchCounter:         {metricName: "forwarded_matches"},
+		ForwardFailureCounter:         {metricName: "forward_failures"},
+		SyncMatchRatioGauge:           {metricName: "sync_match_ratio", metricType: Gauge},
+		EvictedPollersCounter:         {metricName: "evicted_pollers", metricType: Counter},
 	},
 	Worker: {
 		ReplicatorMessages:                            {metricName: "replicator_messages"},
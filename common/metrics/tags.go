@@ -31,8 +31,21 @@ const (
 	namespace     = "namespace"
 	targetCluster = "target_cluster"
 	taskList      = "tasklist"
+	taskListType  = "tasklistType"
 	workflowType  = "workflowType"
 	activityType  = "activityType"
+	forwardedFrom = "forwardedFrom"
+	duplicateType = "duplicateType"
+	stopCause     = "stopCause"
+	kafkaTopic    = "kafkaTopic"
+	consumerGroup = "consumerGroup"
+
+	archivalTarget  = "archivalTarget"
+	archivalOutcome = "archivalOutcome"
+
+	continueAsNewInitiator = "continueAsNewInitiator"
+	replicationTaskType    = "replicationTaskType"
+	compressionCodec       = "compressionCodec"
 
 	namespaceAllValue = "all"
 	unknownValue      = "_unknown_"
@@ -63,6 +76,10 @@ type (
 		value string
 	}
 
+	taskListTypeTag struct {
+		value string
+	}
+
 	workflowTypeTag struct {
 		value string
 	}
@@ -70,6 +87,46 @@ type (
 	activityTypeTag struct {
 		value string
 	}
+
+	forwardedFromTag struct {
+		value string
+	}
+
+	duplicateTypeTag struct {
+		value string
+	}
+
+	stopCauseTag struct {
+		value string
+	}
+
+	kafkaTopicTag struct {
+		value string
+	}
+
+	consumerGroupTag struct {
+		value string
+	}
+
+	archivalTargetTag struct {
+		value string
+	}
+
+	archivalOutcomeTag struct {
+		value string
+	}
+
+	continueAsNewInitiatorTag struct {
+		value string
+	}
+
+	replicationTaskTypeTag struct {
+		value string
+	}
+
+	compressionCodecTag struct {
+		value string
+	}
 )
 
 // NamespaceTag returns a new namespace tag. For timers, this also ensures that we
@@ -158,6 +215,24 @@ func (d taskListTag) Value() string {
 	return d.value
 }
 
+// TaskListTypeTag returns a new task list type tag.
+func TaskListTypeTag(value string) Tag {
+	if len(value) == 0 {
+		value = unknownValue
+	}
+	return taskListTypeTag{value}
+}
+
+// Key returns the key of the task list type tag
+func (d taskListTypeTag) Key() string {
+	return taskListType
+}
+
+// Value returns the value of the task list type tag
+func (d taskListTypeTag) Value() string {
+	return d.value
+}
+
 // WorkflowTypeTag returns a new workflow type tag.
 func WorkflowTypeTag(value string) Tag {
 	if len(value) == 0 {
@@ -193,3 +268,183 @@ func (d activityTypeTag) Key() string {
 func (d activityTypeTag) Value() string {
 	return d.value
 }
+
+// ForwardedFromTag returns a new forwarded from tag.
+func ForwardedFromTag(value string) Tag {
+	if len(value) == 0 {
+		value = unknownValue
+	}
+	return forwardedFromTag{value}
+}
+
+// Key returns the key of the forwarded from tag
+func (d forwardedFromTag) Key() string {
+	return forwardedFrom
+}
+
+// Value returns the value of the forwarded from tag
+func (d forwardedFromTag) Value() string {
+	return d.value
+}
+
+// DuplicateTypeTag returns a new duplicate type tag.
+func DuplicateTypeTag(value string) Tag {
+	if len(value) == 0 {
+		value = unknownValue
+	}
+	return duplicateTypeTag{value}
+}
+
+// Key returns the key of the duplicate type tag
+func (d duplicateTypeTag) Key() string {
+	return duplicateType
+}
+
+// Value returns the value of the duplicate type tag
+func (d duplicateTypeTag) Value() string {
+	return d.value
+}
+
+// StopCauseTag returns a new stop cause tag.
+func StopCauseTag(value string) Tag {
+	if len(value) == 0 {
+		value = unknownValue
+	}
+	return stopCauseTag{value}
+}
+
+// Key returns the key of the stop cause tag
+func (d stopCauseTag) Key() string {
+	return stopCause
+}
+
+// Value returns the value of the stop cause tag
+func (d stopCauseTag) Value() string {
+	return d.value
+}
+
+// KafkaTopicTag returns a new kafka topic tag.
+func KafkaTopicTag(value string) Tag {
+	if len(value) == 0 {
+		value = unknownValue
+	}
+	return kafkaTopicTag{value}
+}
+
+// Key returns the key of the kafka topic tag
+func (d kafkaTopicTag) Key() string {
+	return kafkaTopic
+}
+
+// Value returns the value of the kafka topic tag
+func (d kafkaTopicTag) Value() string {
+	return d.value
+}
+
+// ConsumerGroupTag returns a new kafka consumer group tag.
+func ConsumerGroupTag(value string) Tag {
+	if len(value) == 0 {
+		value = unknownValue
+	}
+	return consumerGroupTag{value}
+}
+
+// Key returns the key of the kafka consumer group tag
+func (d consumerGroupTag) Key() string {
+	return consumerGroup
+}
+
+// Value returns the value of the kafka consumer group tag
+func (d consumerGroupTag) Value() string {
+	return d.value
+}
+
+// ArchivalTargetTag returns a new archival target tag.
+func ArchivalTargetTag(value string) Tag {
+	if len(value) == 0 {
+		value = unknownValue
+	}
+	return archivalTargetTag{value}
+}
+
+// Key returns the key of the archival target tag
+func (d archivalTargetTag) Key() string {
+	return archivalTarget
+}
+
+// Value returns the value of the archival target tag
+func (d archivalTargetTag) Value() string {
+	return d.value
+}
+
+// ArchivalOutcomeTag returns a new archival outcome tag.
+func ArchivalOutcomeTag(value string) Tag {
+	if len(value) == 0 {
+		value = unknownValue
+	}
+	return archivalOutcomeTag{value}
+}
+
+// Key returns the key of the archival outcome tag
+func (d archivalOutcomeTag) Key() string {
+	return archivalOutcome
+}
+
+// Value returns the value of the archival outcome tag
+func (d archivalOutcomeTag) Value() string {
+	return d.value
+}
+
+// ContinueAsNewInitiatorTag returns a new continue as new initiator tag.
+func ContinueAsNewInitiatorTag(value string) Tag {
+	if len(value) == 0 {
+		value = unknownValue
+	}
+	return continueAsNewInitiatorTag{value}
+}
+
+// Key returns the key of the continue as new initiator tag
+func (d continueAsNewInitiatorTag) Key() string {
+	return continueAsNewInitiator
+}
+
+// Value returns the value of the continue as new initiator tag
+func (d continueAsNewInitiatorTag) Value() string {
+	return d.value
+}
+
+// ReplicationTaskTypeTag returns a new replication task type tag.
+func ReplicationTaskTypeTag(value string) Tag {
+	if len(value) == 0 {
+		value = unknownValue
+	}
+	return replicationTaskTypeTag{value}
+}
+
+// Key returns the key of the replication task type tag
+func (d replicationTaskTypeTag) Key() string {
+	return replicationTaskType
+}
+
+// Value returns the value of the replication task type tag
+func (d replicationTaskTypeTag) Value() string {
+	return d.value
+}
+
+// CompressionCodecTag returns a new compression codec tag.
+func CompressionCodecTag(value string) Tag {
+	if len(value) == 0 {
+		value = unknownValue
+	}
+	return compressionCodecTag{value}
+}
+
+// Key returns the key of the compression codec tag
+func (d compressionCodecTag) Key() string {
+	return compressionCodec
+}
+
+// Value returns the value of the compression codec tag
+func (d compressionCodecTag) Value() string {
+	return d.value
+}
@@ -21,6 +21,8 @@
 package mocks
 
 import (
+	"time"
+
 	"github.com/stretchr/testify/mock"
 
 	"github.com/temporalio/temporal/common/persistence"
@@ -45,6 +47,20 @@ func (_m *TaskManager) GetName() string {
 	return r0
 }
 
+// GetTimePrecision provides a mock function with given fields:
+func (_m *TaskManager) GetTimePrecision() time.Duration {
+	ret := _m.Called()
+
+	var r0 time.Duration
+	if rf, ok := ret.Get(0).(func() time.Duration); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(time.Duration)
+	}
+
+	return r0
+}
+
 // Close provides a mock function with given fields:
 func (_m *TaskManager) Close() {
 	_m.Called()
@@ -131,6 +147,144 @@ func (_m *TaskManager) CompleteTasksLessThan(request *persistence.CompleteTasksL
 	return r0, r1
 }
 
+// MoveTasks provides a mock function with given fields: request
+func (_m *TaskManager) MoveTasks(request *persistence.MoveTasksRequest) (int, error) {
+	ret := _m.Called(request)
+
+	var r0 int
+	if rf, ok := ret.Get(0).(func(*persistence.MoveTasksRequest) int); ok {
+		r0 = rf(request)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(int)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(*persistence.MoveTasksRequest) error); ok {
+		r1 = rf(request)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// PurgeExpiredTasks provides a mock function with given fields: request
+func (_m *TaskManager) PurgeExpiredTasks(request *persistence.PurgeExpiredTasksRequest) (int, error) {
+	ret := _m.Called(request)
+
+	var r0 int
+	if rf, ok := ret.Get(0).(func(*persistence.PurgeExpiredTasksRequest) int); ok {
+		r0 = rf(request)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(int)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(*persistence.PurgeExpiredTasksRequest) error); ok {
+		r1 = rf(request)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// EstimateBacklogCount provides a mock function with given fields: request
+func (_m *TaskManager) EstimateBacklogCount(request *persistence.EstimateBacklogCountRequest) (int64, error) {
+	ret := _m.Called(request)
+
+	var r0 int64
+	if rf, ok := ret.Get(0).(func(*persistence.EstimateBacklogCountRequest) int64); ok {
+		r0 = rf(request)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(int64)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(*persistence.EstimateBacklogCountRequest) error); ok {
+		r1 = rf(request)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetTaskListRangeID provides a mock function with given fields: request
+func (_m *TaskManager) GetTaskListRangeID(request *persistence.GetTaskListRangeIDRequest) (int64, error) {
+	ret := _m.Called(request)
+
+	var r0 int64
+	if rf, ok := ret.Get(0).(func(*persistence.GetTaskListRangeIDRequest) int64); ok {
+		r0 = rf(request)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(int64)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(*persistence.GetTaskListRangeIDRequest) error); ok {
+		r1 = rf(request)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RenewLeaseAndUpdate provides a mock function with given fields: request
+func (_m *TaskManager) RenewLeaseAndUpdate(request *persistence.RenewLeaseAndUpdateRequest) (*persistence.RenewLeaseAndUpdateResponse, error) {
+	ret := _m.Called(request)
+
+	var r0 *persistence.RenewLeaseAndUpdateResponse
+	if rf, ok := ret.Get(0).(func(*persistence.RenewLeaseAndUpdateRequest) *persistence.RenewLeaseAndUpdateResponse); ok {
+		r0 = rf(request)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*persistence.RenewLeaseAndUpdateResponse)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(*persistence.RenewLeaseAndUpdateRequest) error); ok {
+		r1 = rf(request)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetTasksAndMetadata provides a mock function with given fields: request
+func (_m *TaskManager) GetTasksAndMetadata(request *persistence.GetTasksAndMetadataRequest) (*persistence.GetTasksAndMetadataResponse, error) {
+	ret := _m.Called(request)
+
+	var r0 *persistence.GetTasksAndMetadataResponse
+	if rf, ok := ret.Get(0).(func(*persistence.GetTasksAndMetadataRequest) *persistence.GetTasksAndMetadataResponse); ok {
+		r0 = rf(request)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*persistence.GetTasksAndMetadataResponse)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(*persistence.GetTasksAndMetadataRequest) error); ok {
+		r1 = rf(request)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 func (_m *TaskManager) ListTaskList(request *persistence.ListTaskListRequest) (*persistence.ListTaskListResponse, error) {
 	ret := _m.Called(request)
 
@@ -209,3 +363,24 @@ func (_m *TaskManager) GetTasks(request *persistence.GetTasksRequest) (*persiste
 
 	return r0, r1
 }
+
+// GetTask provides a mock function with given fields: request
+func (_m *TaskManager) GetTask(request *persistence.GetTaskRequest) (*persistence.GetTaskResponse, error) {
+	ret := _m.Called(request)
+
+	var r0 *persistence.GetTaskResponse
+	if rf, ok := ret.Get(0).(func(*persistence.GetTaskRequest) *persistence.GetTaskResponse); ok {
+		r0 = rf(request)
+	} else if ret.Get(0) != nil {
+		r0 = ret.Get(0).(*persistence.GetTaskResponse)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(*persistence.GetTaskRequest) error); ok {
+		r1 = rf(request)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
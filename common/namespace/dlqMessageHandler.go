@@ -32,11 +32,13 @@ import (
 )
 
 type (
-	// DLQMessageHandler is the interface handles namespace DLQ messages
+	// DLQMessageHandler is the interface handles namespace DLQ messages.
+	// An empty namespaceID applies the operation to the messages of all namespaces.
 	DLQMessageHandler interface {
-		Read(lastMessageID int, pageSize int, pageToken []byte) ([]*replicationgenpb.ReplicationTask, []byte, error)
-		Purge(lastMessageID int) error
-		Merge(lastMessageID int, pageSize int, pageToken []byte) ([]byte, error)
+		Read(lastMessageID int, pageSize int, pageToken []byte, namespaceID string) ([]*replicationgenpb.ReplicationTask, []byte, error)
+		Purge(lastMessageID int, namespaceID string) error
+		Merge(lastMessageID int, pageSize int, pageToken []byte, namespaceID string) ([]byte, error)
+		MergeOne(messageID int) error
 	}
 
 	dlqMessageHandlerImpl struct {
@@ -64,6 +66,7 @@ func (d *dlqMessageHandlerImpl) Read(
 	lastMessageID int,
 	pageSize int,
 	pageToken []byte,
+	namespaceID string,
 ) ([]*replicationgenpb.ReplicationTask, []byte, error) {
 
 	ackLevel, err := d.namespaceReplicationQueue.GetDLQAckLevel()
@@ -76,12 +79,14 @@ func (d *dlqMessageHandlerImpl) Read(
 		lastMessageID,
 		pageSize,
 		pageToken,
+		namespaceID,
 	)
 }
 
 // PurgeMessages purges namespace replication DLQ messages
 func (d *dlqMessageHandlerImpl) Purge(
 	lastMessageID int,
+	namespaceID string,
 ) error {
 
 	ackLevel, err := d.namespaceReplicationQueue.GetDLQAckLevel()
@@ -92,10 +97,15 @@ func (d *dlqMessageHandlerImpl) Purge(
 	if err := d.namespaceReplicationQueue.RangeDeleteMessagesFromDLQ(
 		ackLevel,
 		lastMessageID,
+		namespaceID,
 	); err != nil {
 		return err
 	}
 
+	if namespaceID != "" {
+		// messages of other namespaces may still be pending in the purged range
+		return nil
+	}
 	if err := d.namespaceReplicationQueue.UpdateDLQAckLevel(
 		lastMessageID,
 	); err != nil {
@@ -110,6 +120,7 @@ func (d *dlqMessageHandlerImpl) Merge(
 	lastMessageID int,
 	pageSize int,
 	pageToken []byte,
+	namespaceID string,
 ) ([]byte, error) {
 
 	ackLevel, err := d.namespaceReplicationQueue.GetDLQAckLevel()
@@ -122,6 +133,7 @@ func (d *dlqMessageHandlerImpl) Merge(
 		lastMessageID,
 		pageSize,
 		pageToken,
+		namespaceID,
 	)
 	if err != nil {
 		return nil, err
@@ -145,13 +157,45 @@ func (d *dlqMessageHandlerImpl) Merge(
 	if err := d.namespaceReplicationQueue.RangeDeleteMessagesFromDLQ(
 		ackLevel,
 		ackedMessageID,
+		namespaceID,
 	); err != nil {
 		d.logger.Error("failed to delete merged tasks on merging namespace DLQ message", tag.Error(err))
 		return nil, err
 	}
+	if namespaceID != "" {
+		return token, nil
+	}
 	if err := d.namespaceReplicationQueue.UpdateDLQAckLevel(ackedMessageID); err != nil {
 		d.logger.Error("failed to update ack level on merging namespace DLQ message", tag.Error(err))
 	}
 
 	return token, nil
 }
+
+// MergeOne merges the namespace replication DLQ message with the given ID, the other messages and the
+// ack level are left untouched
+func (d *dlqMessageHandlerImpl) MergeOne(
+	messageID int,
+) error {
+
+	message, err := d.namespaceReplicationQueue.GetMessageFromDLQ(messageID)
+	if err != nil {
+		return err
+	}
+
+	namespaceTask := message.GetNamespaceTaskAttributes()
+	if namespaceTask == nil {
+		return serviceerror.NewInternal("Encounter non namespace replication task in namespace replication queue.")
+	}
+	if err := d.replicationHandler.Execute(
+		namespaceTask,
+	); err != nil {
+		return err
+	}
+
+	if err := d.namespaceReplicationQueue.DeleteMessageFromDLQ(messageID); err != nil {
+		d.logger.Error("failed to delete merged task on merging namespace DLQ message", tag.Error(err))
+		return err
+	}
+	return nil
+}
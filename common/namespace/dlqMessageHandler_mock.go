@@ -57,9 +57,9 @@ func (m *MockDLQMessageHandler) EXPECT() *MockDLQMessageHandlerMockRecorder {
 }
 
 // Read mocks base method.
-func (m *MockDLQMessageHandler) Read(lastMessageID, pageSize int, pageToken []byte) ([]*replication.ReplicationTask, []byte, error) {
+func (m *MockDLQMessageHandler) Read(lastMessageID, pageSize int, pageToken []byte, namespaceID string) ([]*replication.ReplicationTask, []byte, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Read", lastMessageID, pageSize, pageToken)
+	ret := m.ctrl.Call(m, "Read", lastMessageID, pageSize, pageToken, namespaceID)
 	ret0, _ := ret[0].([]*replication.ReplicationTask)
 	ret1, _ := ret[1].([]byte)
 	ret2, _ := ret[2].(error)
@@ -67,36 +67,50 @@ func (m *MockDLQMessageHandler) Read(lastMessageID, pageSize int, pageToken []by
 }
 
 // Read indicates an expected call of Read.
-func (mr *MockDLQMessageHandlerMockRecorder) Read(lastMessageID, pageSize, pageToken interface{}) *gomock.Call {
+func (mr *MockDLQMessageHandlerMockRecorder) Read(lastMessageID, pageSize, pageToken, namespaceID interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Read", reflect.TypeOf((*MockDLQMessageHandler)(nil).Read), lastMessageID, pageSize, pageToken)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Read", reflect.TypeOf((*MockDLQMessageHandler)(nil).Read), lastMessageID, pageSize, pageToken, namespaceID)
 }
 
 // Purge mocks base method.
-func (m *MockDLQMessageHandler) Purge(lastMessageID int) error {
+func (m *MockDLQMessageHandler) Purge(lastMessageID int, namespaceID string) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Purge", lastMessageID)
+	ret := m.ctrl.Call(m, "Purge", lastMessageID, namespaceID)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // Purge indicates an expected call of Purge.
-func (mr *MockDLQMessageHandlerMockRecorder) Purge(lastMessageID interface{}) *gomock.Call {
+func (mr *MockDLQMessageHandlerMockRecorder) Purge(lastMessageID, namespaceID interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Purge", reflect.TypeOf((*MockDLQMessageHandler)(nil).Purge), lastMessageID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Purge", reflect.TypeOf((*MockDLQMessageHandler)(nil).Purge), lastMessageID, namespaceID)
 }
 
 // Merge mocks base method.
-func (m *MockDLQMessageHandler) Merge(lastMessageID, pageSize int, pageToken []byte) ([]byte, error) {
+func (m *MockDLQMessageHandler) Merge(lastMessageID, pageSize int, pageToken []byte, namespaceID string) ([]byte, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Merge", lastMessageID, pageSize, pageToken)
+	ret := m.ctrl.Call(m, "Merge", lastMessageID, pageSize, pageToken, namespaceID)
 	ret0, _ := ret[0].([]byte)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // Merge indicates an expected call of Merge.
-func (mr *MockDLQMessageHandlerMockRecorder) Merge(lastMessageID, pageSize, pageToken interface{}) *gomock.Call {
+func (mr *MockDLQMessageHandlerMockRecorder) Merge(lastMessageID, pageSize, pageToken, namespaceID interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Merge", reflect.TypeOf((*MockDLQMessageHandler)(nil).Merge), lastMessageID, pageSize, pageToken)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Merge", reflect.TypeOf((*MockDLQMessageHandler)(nil).Merge), lastMessageID, pageSize, pageToken, namespaceID)
+}
+
+// MergeOne mocks base method.
+func (m *MockDLQMessageHandler) MergeOne(messageID int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MergeOne", messageID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MergeOne indicates an expected call of MergeOne.
+func (mr *MockDLQMessageHandlerMockRecorder) MergeOne(messageID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MergeOne", reflect.TypeOf((*MockDLQMessageHandler)(nil).MergeOne), messageID)
 }
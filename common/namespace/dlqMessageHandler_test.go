@@ -93,10 +93,10 @@ func (s *dlqMessageHandlerSuite) TestReadMessages() {
 		},
 	}
 	s.mockReplicationQueue.EXPECT().GetDLQAckLevel().Return(ackLevel, nil).Times(1)
-	s.mockReplicationQueue.EXPECT().GetMessagesFromDLQ(ackLevel, lastMessageID, pageSize, pageToken).
+	s.mockReplicationQueue.EXPECT().GetMessagesFromDLQ(ackLevel, lastMessageID, pageSize, pageToken, "").
 		Return(tasks, nil, nil).Times(1)
 
-	resp, token, err := s.dlqMessageHandler.Read(lastMessageID, pageSize, pageToken)
+	resp, token, err := s.dlqMessageHandler.Read(lastMessageID, pageSize, pageToken, "")
 
 	s.NoError(err)
 	s.Equal(tasks, resp)
@@ -116,10 +116,10 @@ func (s *dlqMessageHandlerSuite) TestReadMessages_ThrowErrorOnGetDLQAckLevel() {
 	}
 	testError := fmt.Errorf("test")
 	s.mockReplicationQueue.EXPECT().GetDLQAckLevel().Return(-1, testError).Times(1)
-	s.mockReplicationQueue.EXPECT().GetMessagesFromDLQ(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+	s.mockReplicationQueue.EXPECT().GetMessagesFromDLQ(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
 		Return(tasks, nil, nil).Times(0)
 
-	_, _, err := s.dlqMessageHandler.Read(lastMessageID, pageSize, pageToken)
+	_, _, err := s.dlqMessageHandler.Read(lastMessageID, pageSize, pageToken, "")
 
 	s.Equal(testError, err)
 }
@@ -132,10 +132,10 @@ func (s *dlqMessageHandlerSuite) TestReadMessages_ThrowErrorOnReadMessages() {
 
 	testError := fmt.Errorf("test")
 	s.mockReplicationQueue.EXPECT().GetDLQAckLevel().Return(ackLevel, nil).Times(1)
-	s.mockReplicationQueue.EXPECT().GetMessagesFromDLQ(ackLevel, lastMessageID, pageSize, pageToken).
+	s.mockReplicationQueue.EXPECT().GetMessagesFromDLQ(ackLevel, lastMessageID, pageSize, pageToken, "").
 		Return(nil, nil, testError).Times(1)
 
-	_, _, err := s.dlqMessageHandler.Read(lastMessageID, pageSize, pageToken)
+	_, _, err := s.dlqMessageHandler.Read(lastMessageID, pageSize, pageToken, "")
 
 	s.Equal(testError, err)
 }
@@ -145,9 +145,49 @@ func (s *dlqMessageHandlerSuite) TestPurgeMessages() {
 	lastMessageID := 20
 
 	s.mockReplicationQueue.EXPECT().GetDLQAckLevel().Return(ackLevel, nil).Times(1)
-	s.mockReplicationQueue.EXPECT().RangeDeleteMessagesFromDLQ(ackLevel, lastMessageID).Return(nil).Times(1)
+	s.mockReplicationQueue.EXPECT().RangeDeleteMessagesFromDLQ(ackLevel, lastMessageID, "").Return(nil).Times(1)
 	s.mockReplicationQueue.EXPECT().UpdateDLQAckLevel(lastMessageID).Return(nil).Times(1)
-	err := s.dlqMessageHandler.Purge(lastMessageID)
+	err := s.dlqMessageHandler.Purge(lastMessageID, "")
+
+	s.NoError(err)
+}
+
+func (s *dlqMessageHandlerSuite) TestReadMessages_FilterByNamespace() {
+	ackLevel := 10
+	lastMessageID := 20
+	pageSize := 100
+	pageToken := []byte{}
+	namespaceID := uuid.New()
+
+	tasks := []*replicationgenpb.ReplicationTask{
+		{
+			TaskType:     replicationgenpb.ReplicationTaskTypeNamespace,
+			SourceTaskId: 1,
+			Attributes: &replicationgenpb.ReplicationTask_NamespaceTaskAttributes{
+				NamespaceTaskAttributes: &replicationgenpb.NamespaceTaskAttributes{Id: namespaceID},
+			},
+		},
+	}
+	s.mockReplicationQueue.EXPECT().GetDLQAckLevel().Return(ackLevel, nil).Times(1)
+	s.mockReplicationQueue.EXPECT().GetMessagesFromDLQ(ackLevel, lastMessageID, pageSize, pageToken, namespaceID).
+		Return(tasks, nil, nil).Times(1)
+
+	resp, token, err := s.dlqMessageHandler.Read(lastMessageID, pageSize, pageToken, namespaceID)
+
+	s.NoError(err)
+	s.Equal(tasks, resp)
+	s.Nil(token)
+}
+
+func (s *dlqMessageHandlerSuite) TestPurgeMessages_FilterByNamespace() {
+	ackLevel := 10
+	lastMessageID := 20
+	namespaceID := uuid.New()
+
+	s.mockReplicationQueue.EXPECT().GetDLQAckLevel().Return(ackLevel, nil).Times(1)
+	s.mockReplicationQueue.EXPECT().RangeDeleteMessagesFromDLQ(ackLevel, lastMessageID, namespaceID).Return(nil).Times(1)
+	s.mockReplicationQueue.EXPECT().UpdateDLQAckLevel(gomock.Any()).Times(0)
+	err := s.dlqMessageHandler.Purge(lastMessageID, namespaceID)
 
 	s.NoError(err)
 }
@@ -157,9 +197,9 @@ func (s *dlqMessageHandlerSuite) TestPurgeMessages_ThrowErrorOnGetDLQAckLevel()
 	testError := fmt.Errorf("test")
 
 	s.mockReplicationQueue.EXPECT().GetDLQAckLevel().Return(-1, testError).Times(1)
-	s.mockReplicationQueue.EXPECT().RangeDeleteMessagesFromDLQ(gomock.Any(), gomock.Any()).Return(nil).Times(0)
+	s.mockReplicationQueue.EXPECT().RangeDeleteMessagesFromDLQ(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil).Times(0)
 	s.mockReplicationQueue.EXPECT().UpdateDLQAckLevel(gomock.Any()).Times(0)
-	err := s.dlqMessageHandler.Purge(lastMessageID)
+	err := s.dlqMessageHandler.Purge(lastMessageID, "")
 
 	s.Equal(testError, err)
 }
@@ -170,9 +210,9 @@ func (s *dlqMessageHandlerSuite) TestPurgeMessages_ThrowErrorOnPurgeMessages() {
 	testError := fmt.Errorf("test")
 
 	s.mockReplicationQueue.EXPECT().GetDLQAckLevel().Return(ackLevel, nil).Times(1)
-	s.mockReplicationQueue.EXPECT().RangeDeleteMessagesFromDLQ(ackLevel, lastMessageID).Return(testError).Times(1)
+	s.mockReplicationQueue.EXPECT().RangeDeleteMessagesFromDLQ(ackLevel, lastMessageID, "").Return(testError).Times(1)
 	s.mockReplicationQueue.EXPECT().UpdateDLQAckLevel(gomock.Any()).Times(0)
-	err := s.dlqMessageHandler.Purge(lastMessageID)
+	err := s.dlqMessageHandler.Purge(lastMessageID, "")
 
 	s.Equal(testError, err)
 }
@@ -198,13 +238,46 @@ func (s *dlqMessageHandlerSuite) TestMergeMessages() {
 		},
 	}
 	s.mockReplicationQueue.EXPECT().GetDLQAckLevel().Return(ackLevel, nil).Times(1)
-	s.mockReplicationQueue.EXPECT().GetMessagesFromDLQ(ackLevel, lastMessageID, pageSize, pageToken).
+	s.mockReplicationQueue.EXPECT().GetMessagesFromDLQ(ackLevel, lastMessageID, pageSize, pageToken, "").
 		Return(tasks, nil, nil).Times(1)
 	s.mockReplicationTaskExecutor.EXPECT().Execute(namespaceAttribute).Return(nil).Times(1)
 	s.mockReplicationQueue.EXPECT().UpdateDLQAckLevel(messageID).Return(nil).Times(1)
-	s.mockReplicationQueue.EXPECT().RangeDeleteMessagesFromDLQ(ackLevel, messageID).Return(nil).Times(1)
+	s.mockReplicationQueue.EXPECT().RangeDeleteMessagesFromDLQ(ackLevel, messageID, "").Return(nil).Times(1)
 
-	token, err := s.dlqMessageHandler.Merge(lastMessageID, pageSize, pageToken)
+	token, err := s.dlqMessageHandler.Merge(lastMessageID, pageSize, pageToken, "")
+	s.NoError(err)
+	s.Nil(token)
+}
+
+func (s *dlqMessageHandlerSuite) TestMergeMessages_FilterByNamespace() {
+	ackLevel := 10
+	lastMessageID := 20
+	pageSize := 100
+	pageToken := []byte{}
+	messageID := 11
+	namespaceID := uuid.New()
+
+	namespaceAttribute := &replicationgenpb.NamespaceTaskAttributes{
+		Id: namespaceID,
+	}
+
+	tasks := []*replicationgenpb.ReplicationTask{
+		{
+			TaskType:     replicationgenpb.ReplicationTaskTypeNamespace,
+			SourceTaskId: int64(messageID),
+			Attributes: &replicationgenpb.ReplicationTask_NamespaceTaskAttributes{
+				NamespaceTaskAttributes: namespaceAttribute,
+			},
+		},
+	}
+	s.mockReplicationQueue.EXPECT().GetDLQAckLevel().Return(ackLevel, nil).Times(1)
+	s.mockReplicationQueue.EXPECT().GetMessagesFromDLQ(ackLevel, lastMessageID, pageSize, pageToken, namespaceID).
+		Return(tasks, nil, nil).Times(1)
+	s.mockReplicationTaskExecutor.EXPECT().Execute(namespaceAttribute).Return(nil).Times(1)
+	s.mockReplicationQueue.EXPECT().RangeDeleteMessagesFromDLQ(ackLevel, messageID, namespaceID).Return(nil).Times(1)
+	s.mockReplicationQueue.EXPECT().UpdateDLQAckLevel(gomock.Any()).Times(0)
+
+	token, err := s.dlqMessageHandler.Merge(lastMessageID, pageSize, pageToken, namespaceID)
 	s.NoError(err)
 	s.Nil(token)
 }
@@ -229,13 +302,13 @@ func (s *dlqMessageHandlerSuite) TestMergeMessages_ThrowErrorOnGetDLQAckLevel()
 		},
 	}
 	s.mockReplicationQueue.EXPECT().GetDLQAckLevel().Return(-1, testError).Times(1)
-	s.mockReplicationQueue.EXPECT().GetMessagesFromDLQ(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+	s.mockReplicationQueue.EXPECT().GetMessagesFromDLQ(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
 		Return(tasks, nil, nil).Times(0)
 	s.mockReplicationTaskExecutor.EXPECT().Execute(gomock.Any()).Times(0)
 	s.mockReplicationQueue.EXPECT().DeleteMessageFromDLQ(gomock.Any()).Times(0)
 	s.mockReplicationQueue.EXPECT().UpdateDLQAckLevel(gomock.Any()).Times(0)
 
-	token, err := s.dlqMessageHandler.Merge(lastMessageID, pageSize, pageToken)
+	token, err := s.dlqMessageHandler.Merge(lastMessageID, pageSize, pageToken, "")
 	s.Equal(testError, err)
 	s.Nil(token)
 }
@@ -248,13 +321,13 @@ func (s *dlqMessageHandlerSuite) TestMergeMessages_ThrowErrorOnGetDLQMessages()
 	testError := fmt.Errorf("test")
 
 	s.mockReplicationQueue.EXPECT().GetDLQAckLevel().Return(ackLevel, nil).Times(1)
-	s.mockReplicationQueue.EXPECT().GetMessagesFromDLQ(ackLevel, lastMessageID, pageSize, pageToken).
+	s.mockReplicationQueue.EXPECT().GetMessagesFromDLQ(ackLevel, lastMessageID, pageSize, pageToken, "").
 		Return(nil, nil, testError).Times(1)
 	s.mockReplicationTaskExecutor.EXPECT().Execute(gomock.Any()).Times(0)
 	s.mockReplicationQueue.EXPECT().DeleteMessageFromDLQ(gomock.Any()).Times(0)
 	s.mockReplicationQueue.EXPECT().UpdateDLQAckLevel(gomock.Any()).Times(0)
 
-	token, err := s.dlqMessageHandler.Merge(lastMessageID, pageSize, pageToken)
+	token, err := s.dlqMessageHandler.Merge(lastMessageID, pageSize, pageToken, "")
 	s.Equal(testError, err)
 	s.Nil(token)
 }
@@ -290,7 +363,7 @@ func (s *dlqMessageHandlerSuite) TestMergeMessages_ThrowErrorOnHandleReceivingTa
 		},
 	}
 	s.mockReplicationQueue.EXPECT().GetDLQAckLevel().Return(ackLevel, nil).Times(1)
-	s.mockReplicationQueue.EXPECT().GetMessagesFromDLQ(ackLevel, lastMessageID, pageSize, pageToken).
+	s.mockReplicationQueue.EXPECT().GetMessagesFromDLQ(ackLevel, lastMessageID, pageSize, pageToken, "").
 		Return(tasks, nil, nil).Times(1)
 	s.mockReplicationTaskExecutor.EXPECT().Execute(namespaceAttribute1).Return(nil).Times(1)
 	s.mockReplicationTaskExecutor.EXPECT().Execute(namespaceAttribute2).Return(testError).Times(1)
@@ -298,7 +371,7 @@ func (s *dlqMessageHandlerSuite) TestMergeMessages_ThrowErrorOnHandleReceivingTa
 	s.mockReplicationQueue.EXPECT().DeleteMessageFromDLQ(messageID2).Times(0)
 	s.mockReplicationQueue.EXPECT().UpdateDLQAckLevel(messageID1).Return(nil).Times(1)
 
-	token, err := s.dlqMessageHandler.Merge(lastMessageID, pageSize, pageToken)
+	token, err := s.dlqMessageHandler.Merge(lastMessageID, pageSize, pageToken, "")
 	s.Equal(testError, err)
 	s.Nil(token)
 }
@@ -334,14 +407,14 @@ func (s *dlqMessageHandlerSuite) TestMergeMessages_ThrowErrorOnDeleteMessages()
 		},
 	}
 	s.mockReplicationQueue.EXPECT().GetDLQAckLevel().Return(ackLevel, nil).Times(1)
-	s.mockReplicationQueue.EXPECT().GetMessagesFromDLQ(ackLevel, lastMessageID, pageSize, pageToken).
+	s.mockReplicationQueue.EXPECT().GetMessagesFromDLQ(ackLevel, lastMessageID, pageSize, pageToken, "").
 		Return(tasks, nil, nil).Times(1)
 	s.mockReplicationTaskExecutor.EXPECT().Execute(namespaceAttribute1).Return(nil).Times(1)
 	s.mockReplicationTaskExecutor.EXPECT().Execute(namespaceAttribute2).Return(nil).Times(1)
-	s.mockReplicationQueue.EXPECT().RangeDeleteMessagesFromDLQ(ackLevel, messageID2).Return(testError).Times(1)
+	s.mockReplicationQueue.EXPECT().RangeDeleteMessagesFromDLQ(ackLevel, messageID2, "").Return(testError).Times(1)
 	s.mockReplicationQueue.EXPECT().UpdateDLQAckLevel(messageID1).Return(nil).Times(1)
 
-	token, err := s.dlqMessageHandler.Merge(lastMessageID, pageSize, pageToken)
+	token, err := s.dlqMessageHandler.Merge(lastMessageID, pageSize, pageToken, "")
 	s.Error(err)
 	s.Nil(token)
 }
@@ -367,13 +440,55 @@ func (s *dlqMessageHandlerSuite) TestMergeMessages_IgnoreErrorOnUpdateDLQAckLeve
 		},
 	}
 	s.mockReplicationQueue.EXPECT().GetDLQAckLevel().Return(ackLevel, nil).Times(1)
-	s.mockReplicationQueue.EXPECT().GetMessagesFromDLQ(ackLevel, lastMessageID, pageSize, pageToken).
+	s.mockReplicationQueue.EXPECT().GetMessagesFromDLQ(ackLevel, lastMessageID, pageSize, pageToken, "").
 		Return(tasks, nil, nil).Times(1)
 	s.mockReplicationTaskExecutor.EXPECT().Execute(namespaceAttribute).Return(nil).Times(1)
-	s.mockReplicationQueue.EXPECT().RangeDeleteMessagesFromDLQ(ackLevel, messageID).Return(nil).Times(1)
+	s.mockReplicationQueue.EXPECT().RangeDeleteMessagesFromDLQ(ackLevel, messageID, "").Return(nil).Times(1)
 	s.mockReplicationQueue.EXPECT().UpdateDLQAckLevel(messageID).Return(testError).Times(1)
 
-	token, err := s.dlqMessageHandler.Merge(lastMessageID, pageSize, pageToken)
+	token, err := s.dlqMessageHandler.Merge(lastMessageID, pageSize, pageToken, "")
 	s.NoError(err)
 	s.Nil(token)
 }
+
+func (s *dlqMessageHandlerSuite) TestMergeOneMessage() {
+	messageID := 12
+	namespaceAttribute := &replicationgenpb.NamespaceTaskAttributes{
+		Id: uuid.New(),
+	}
+	task := &replicationgenpb.ReplicationTask{
+		TaskType:     replicationgenpb.ReplicationTaskTypeNamespace,
+		SourceTaskId: int64(messageID),
+		Attributes: &replicationgenpb.ReplicationTask_NamespaceTaskAttributes{
+			NamespaceTaskAttributes: namespaceAttribute,
+		},
+	}
+	// the messages around the merged one are neither read nor deleted, and the ack level does not move
+	s.mockReplicationQueue.EXPECT().GetMessageFromDLQ(messageID).Return(task, nil).Times(1)
+	s.mockReplicationTaskExecutor.EXPECT().Execute(namespaceAttribute).Return(nil).Times(1)
+	s.mockReplicationQueue.EXPECT().DeleteMessageFromDLQ(messageID).Return(nil).Times(1)
+
+	err := s.dlqMessageHandler.MergeOne(messageID)
+	s.NoError(err)
+}
+
+func (s *dlqMessageHandlerSuite) TestMergeOneMessage_ThrowErrorOnHandleReceivingTask() {
+	messageID := 12
+	namespaceAttribute := &replicationgenpb.NamespaceTaskAttributes{
+		Id: uuid.New(),
+	}
+	task := &replicationgenpb.ReplicationTask{
+		TaskType:     replicationgenpb.ReplicationTaskTypeNamespace,
+		SourceTaskId: int64(messageID),
+		Attributes: &replicationgenpb.ReplicationTask_NamespaceTaskAttributes{
+			NamespaceTaskAttributes: namespaceAttribute,
+		},
+	}
+	testError := fmt.Errorf("test")
+	s.mockReplicationQueue.EXPECT().GetMessageFromDLQ(messageID).Return(task, nil).Times(1)
+	s.mockReplicationTaskExecutor.EXPECT().Execute(namespaceAttribute).Return(testError).Times(1)
+	s.mockReplicationQueue.EXPECT().DeleteMessageFromDLQ(gomock.Any()).Times(0)
+
+	err := s.dlqMessageHandler.MergeOne(messageID)
+	s.Equal(testError, err)
+}
@@ -102,6 +102,9 @@ const (
 const (
 	taskListTaskID = -12345
 	initialRangeID = 1 // Id of the first range of a new task list
+
+	// cassandraTaskTimePrecision is the precision of task times, which matches cassandra timestamps
+	cassandraTaskTimePrecision = time.Millisecond
 )
 
 const (
@@ -595,6 +598,14 @@ workflow_state = ? ` +
 		`and task_id > ? ` +
 		`and task_id <= ?`
 
+	templateGetTaskQuery = `SELECT task, task_encoding ` +
+		`FROM tasks ` +
+		`WHERE namespace_id = ? ` +
+		`and task_list_name = ? ` +
+		`and task_list_type = ? ` +
+		`and type = ? ` +
+		`and task_id = ?`
+
 	templateCompleteTaskQuery = `DELETE FROM tasks ` +
 		`WHERE namespace_id = ? ` +
 		`and task_list_name = ? ` +
@@ -609,6 +620,15 @@ workflow_state = ? ` +
 		`AND type = ? ` +
 		`AND task_id <= ? `
 
+	templateGetMaxTaskIDQuery = `SELECT task_id ` +
+		`FROM tasks ` +
+		`WHERE namespace_id = ? ` +
+		`and task_list_name = ? ` +
+		`and task_list_type = ? ` +
+		`and type = ? ` +
+		`ORDER BY type DESC, task_id DESC ` +
+		`LIMIT 1`
+
 	templateGetTaskList = `SELECT ` +
 		`range_id, ` +
 		`task_list, ` +
@@ -730,6 +750,11 @@ func (d *cassandraStore) GetName() string {
 	return cassandraPersistenceName
 }
 
+// GetTimePrecision returns the precision the created time and expiry of tasks are stored at
+func (d *cassandraPersistence) GetTimePrecision() time.Duration {
+	return cassandraTaskTimePrecision
+}
+
 // Close releases the underlying resources held by this object
 func (d *cassandraStore) Close() {
 	if d.session != nil {
@@ -2364,6 +2389,10 @@ func (d *cassandraPersistence) CreateTasks(request *p.CreateTasksRequest) (*p.Cr
 
 	for _, task := range request.Tasks {
 		ttl := GetTaskTTL(task.Data)
+		task, err := p.TruncateTaskTimes(task, cassandraTaskTimePrecision)
+		if err != nil {
+			return nil, serviceerror.NewInternal(fmt.Sprintf("CreateTasks operation failed. Error : %v", err))
+		}
 		datablob, err := serialization.TaskInfoToBlob(task)
 		if err != nil {
 			return nil, serviceerror.NewInternal(fmt.Sprintf("CreateTasks operation failed during serialization. Error : %v", err))
@@ -2520,6 +2549,34 @@ PopulateTasks:
 	return response, nil
 }
 
+// From TaskManager interface
+func (d *cassandraPersistence) GetTask(request *p.GetTaskRequest) (*p.GetTaskResponse, error) {
+	query := d.session.Query(templateGetTaskQuery,
+		request.NamespaceID.Downcast(),
+		request.TaskList,
+		request.TaskType,
+		rowTypeTask,
+		request.TaskID,
+	)
+	var taskBytes []byte
+	var taskEncoding string
+	if err := query.Scan(&taskBytes, &taskEncoding); err != nil {
+		if err == gocql.ErrNotFound {
+			return nil, serviceerror.NewNotFound(fmt.Sprintf("Task not found. TaskList: %v, TaskType: %v, TaskID: %v", request.TaskList, request.TaskType, request.TaskID))
+		}
+		if isThrottlingError(err) {
+			return nil, serviceerror.NewResourceExhausted(fmt.Sprintf("GetTask operation failed. TaskList: %v, TaskType: %v, Error: %v", request.TaskList, request.TaskType, err))
+		}
+		return nil, serviceerror.NewInternal(fmt.Sprintf("GetTask operation failed. TaskList: %v, TaskType: %v, Error: %v", request.TaskList, request.TaskType, err))
+	}
+
+	task, err := serialization.TaskInfoFromBlob(taskBytes, taskEncoding)
+	if err != nil {
+		return nil, convertCommonErrors("GetTask", err)
+	}
+	return &p.GetTaskResponse{Task: task}, nil
+}
+
 // From TaskManager interface
 func (d *cassandraPersistence) CompleteTask(request *p.CompleteTaskRequest) error {
 	tli := request.TaskList
@@ -2557,6 +2614,227 @@ func (d *cassandraPersistence) CompleteTasksLessThan(request *p.CompleteTasksLes
 	return p.UnknownNumRowsAffected, nil
 }
 
+// PurgeExpiredTasks does nothing, tasks with an expiry are written with a TTL and removed by cassandra
+func (d *cassandraPersistence) PurgeExpiredTasks(request *p.PurgeExpiredTasksRequest) (int, error) {
+	return 0, nil
+}
+
+// EstimateBacklogCount returns the distance between the ack level of the task list and the end of the range of
+// task ids in its partition. The highest task id is read from the clustering order, so no task is scanned
+func (d *cassandraPersistence) EstimateBacklogCount(request *p.EstimateBacklogCountRequest) (int64, error) {
+	query := d.session.Query(templateGetTaskList,
+		request.NamespaceID.Downcast(),
+		request.TaskList,
+		request.TaskType,
+		rowTypeTaskList,
+		taskListTaskID,
+	)
+	var rangeID int64
+	var tlBytes []byte
+	var tlEncoding string
+	if err := query.Scan(&rangeID, &tlBytes, &tlEncoding); err != nil {
+		if err == gocql.ErrNotFound {
+			return 0, nil
+		}
+		if isThrottlingError(err) {
+			return 0, serviceerror.NewResourceExhausted(fmt.Sprintf("EstimateBacklogCount operation failed. TaskList: %v, TaskType: %v, Error: %v", request.TaskList, request.TaskType, err))
+		}
+		return 0, serviceerror.NewInternal(fmt.Sprintf("EstimateBacklogCount operation failed. TaskList: %v, TaskType: %v, Error: %v", request.TaskList, request.TaskType, err))
+	}
+	tli, err := serialization.TaskListInfoFromBlob(tlBytes, tlEncoding)
+	if err != nil {
+		return 0, serviceerror.NewInternal(fmt.Sprintf("EstimateBacklogCount operation failed during serialization. TaskList: %v, TaskType: %v, Error: %v", request.TaskList, request.TaskType, err))
+	}
+
+	query = d.session.Query(templateGetMaxTaskIDQuery,
+		request.NamespaceID.Downcast(),
+		request.TaskList,
+		request.TaskType,
+		rowTypeTask,
+	)
+	var maxTaskID int64
+	if err := query.Scan(&maxTaskID); err != nil {
+		if err == gocql.ErrNotFound {
+			return 0, nil
+		}
+		if isThrottlingError(err) {
+			return 0, serviceerror.NewResourceExhausted(fmt.Sprintf("EstimateBacklogCount operation failed. TaskList: %v, TaskType: %v, Error: %v", request.TaskList, request.TaskType, err))
+		}
+		return 0, serviceerror.NewInternal(fmt.Sprintf("EstimateBacklogCount operation failed. TaskList: %v, TaskType: %v, Error: %v", request.TaskList, request.TaskType, err))
+	}
+	if maxTaskID <= tli.GetAckLevel() {
+		return 0, nil
+	}
+	return maxTaskID - tli.GetAckLevel(), nil
+}
+
+// GetTaskListRangeID reads the range id of the task list row, the task list info blob is not decoded
+func (d *cassandraPersistence) GetTaskListRangeID(request *p.GetTaskListRangeIDRequest) (int64, error) {
+	query := d.session.Query(templateGetTaskList,
+		request.NamespaceID.Downcast(),
+		request.TaskList,
+		request.TaskType,
+		rowTypeTaskList,
+		taskListTaskID,
+	)
+	var rangeID int64
+	var tlBytes []byte
+	var tlEncoding string
+	if err := query.Scan(&rangeID, &tlBytes, &tlEncoding); err != nil {
+		if err == gocql.ErrNotFound {
+			return 0, serviceerror.NewNotFound(fmt.Sprintf("Task list not found. TaskList: %v, TaskType: %v", request.TaskList, request.TaskType))
+		}
+		if isThrottlingError(err) {
+			return 0, serviceerror.NewResourceExhausted(fmt.Sprintf("GetTaskListRangeID operation failed. TaskList: %v, TaskType: %v, Error: %v", request.TaskList, request.TaskType, err))
+		}
+		return 0, serviceerror.NewInternal(fmt.Sprintf("GetTaskListRangeID operation failed. TaskList: %v, TaskType: %v, Error: %v", request.TaskList, request.TaskType, err))
+	}
+	return rangeID, nil
+}
+
+// RenewLeaseAndUpdate bumps the range id and writes the task list info with a single conditional update
+func (d *cassandraPersistence) RenewLeaseAndUpdate(request *p.RenewLeaseAndUpdateRequest) (*p.RenewLeaseAndUpdateResponse, error) {
+	tli := *request.TaskListInfo
+	tli.LastUpdated = types.TimestampNow()
+	datablob, err := serialization.TaskListInfoToBlob(&tli)
+	if err != nil {
+		return nil, convertCommonErrors("RenewLeaseAndUpdate", err)
+	}
+
+	newRangeID := request.RangeID + 1
+	query := d.session.Query(templateUpdateTaskListQuery,
+		newRangeID,
+		datablob.Data,
+		datablob.Encoding,
+		tli.GetNamespaceId(),
+		&tli.Name,
+		tli.TaskType,
+		rowTypeTaskList,
+		taskListTaskID,
+		request.RangeID,
+	)
+
+	previous := make(map[string]interface{})
+	applied, err := query.MapScanCAS(previous)
+	if err != nil {
+		if isThrottlingError(err) {
+			return nil, serviceerror.NewResourceExhausted(fmt.Sprintf("RenewLeaseAndUpdate operation failed. Error: %v", err))
+		}
+		return nil, serviceerror.NewInternal(fmt.Sprintf("RenewLeaseAndUpdate operation failed. Error: %v", err))
+	}
+	if !applied {
+		return nil, &p.ConditionFailedError{
+			Msg: fmt.Sprintf("renewLeaseAndUpdate: taskList:%v, taskListType:%v, haveRangeID:%v, gotRangeID:%v",
+				tli.Name, tli.TaskType, request.RangeID, previous["range_id"]),
+		}
+	}
+
+	return &p.RenewLeaseAndUpdateResponse{TaskListInfo: &p.PersistedTaskListInfo{
+		Data:    &tli,
+		RangeID: newRangeID,
+	}}, nil
+}
+
+// GetTasksAndMetadata reads the tasks and then the task list row, both live in the same partition
+func (d *cassandraPersistence) GetTasksAndMetadata(request *p.GetTasksAndMetadataRequest) (*p.GetTasksAndMetadataResponse, error) {
+	tasksResp, err := d.GetTasks(&p.GetTasksRequest{
+		NamespaceID:  request.NamespaceID,
+		TaskList:     request.TaskList,
+		TaskType:     request.TaskType,
+		ReadLevel:    request.ReadLevel,
+		MaxReadLevel: &request.MaxReadLevel,
+		BatchSize:    request.BatchSize,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	query := d.session.Query(templateGetTaskList,
+		request.NamespaceID.Downcast(),
+		request.TaskList,
+		request.TaskType,
+		rowTypeTaskList,
+		taskListTaskID,
+	)
+	var rangeID int64
+	var tlBytes []byte
+	var tlEncoding string
+	if err := query.Scan(&rangeID, &tlBytes, &tlEncoding); err != nil {
+		if err == gocql.ErrNotFound {
+			return nil, serviceerror.NewNotFound(fmt.Sprintf("Task list not found. TaskList: %v, TaskType: %v", request.TaskList, request.TaskType))
+		}
+		if isThrottlingError(err) {
+			return nil, serviceerror.NewResourceExhausted(fmt.Sprintf("GetTasksAndMetadata operation failed. TaskList: %v, TaskType: %v, Error: %v", request.TaskList, request.TaskType, err))
+		}
+		return nil, serviceerror.NewInternal(fmt.Sprintf("GetTasksAndMetadata operation failed. TaskList: %v, TaskType: %v, Error: %v", request.TaskList, request.TaskType, err))
+	}
+	tli, err := serialization.TaskListInfoFromBlob(tlBytes, tlEncoding)
+	if err != nil {
+		return nil, convertCommonErrors("GetTasksAndMetadata", err)
+	}
+
+	return &p.GetTasksAndMetadataResponse{
+		Tasks: tasksResp.Tasks,
+		TaskListInfo: &p.PersistedTaskListInfo{
+			Data:    tli,
+			RangeID: rangeID,
+		},
+	}, nil
+}
+
+// MoveTasks re-homes tasks from the source task list to the destination task list. The tasks are created
+// in the destination with the new task IDs, guarded by the destination range ID, before they are deleted
+// from the source. The two task lists are different partitions so the deletes are a separate batch, and a
+// failed delete leaves the moved tasks in both task lists, which history dedupes when the task is started
+func (d *cassandraPersistence) MoveTasks(request *p.MoveTasksRequest) (int, error) {
+	src := request.SourceTaskList
+	resp, err := d.GetTasks(&p.GetTasksRequest{
+		NamespaceID:  src.NamespaceID,
+		TaskList:     src.Name,
+		TaskType:     src.TaskType,
+		ReadLevel:    -1,
+		MaxReadLevel: common.Int64Ptr(math.MaxInt64),
+		BatchSize:    len(request.TaskIDs),
+	})
+	if err != nil {
+		return 0, err
+	}
+	if len(resp.Tasks) == 0 {
+		return 0, nil
+	}
+
+	tasks := make([]*persistenceblobs.AllocatedTaskInfo, len(resp.Tasks))
+	for i, task := range resp.Tasks {
+		tasks[i] = &persistenceblobs.AllocatedTaskInfo{
+			Data:   task.Data,
+			TaskId: request.TaskIDs[i],
+		}
+	}
+	if _, err := d.CreateTasks(&p.CreateTasksRequest{
+		TaskListInfo: request.DestinationTaskList,
+		Tasks:        tasks,
+	}); err != nil {
+		return 0, err
+	}
+
+	batch := d.session.NewBatch(gocql.LoggedBatch)
+	for _, task := range resp.Tasks {
+		batch.Query(templateCompleteTaskQuery,
+			src.NamespaceID.Downcast(),
+			src.Name,
+			src.TaskType,
+			rowTypeTask,
+			task.GetTaskId())
+	}
+	if err := d.session.ExecuteBatch(batch); err != nil {
+		if isThrottlingError(err) {
+			return 0, serviceerror.NewResourceExhausted(fmt.Sprintf("MoveTasks operation failed. Error: %v", err))
+		}
+		return 0, serviceerror.NewInternal(fmt.Sprintf("MoveTasks operation failed. Error: %v", err))
+	}
+	return len(resp.Tasks), nil
+}
+
 func (d *cassandraPersistence) GetTimerIndexTasks(request *p.GetTimerIndexTasksRequest) (*p.GetTimerIndexTasksResponse,
 	error) {
 	// Reading timer tasks need to be quorum level consistent, otherwise we could loose task
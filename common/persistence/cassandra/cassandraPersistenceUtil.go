@@ -835,6 +835,11 @@ func createReplicationTasks(
 			return serviceerror.NewInternal(fmt.Sprintf("Unknow replication type: %v", task.GetType()))
 		}
 
+		taskVisTs, err := types.TimestampProto(task.GetVisibilityTimestamp())
+		if err != nil {
+			return err
+		}
+
 		datablob, err := serialization.ReplicationTaskInfoToBlob(&persistenceblobs.ReplicationTaskInfo{
 			NamespaceId:             primitives.MustParseUUID(namespaceID),
 			WorkflowId:              workflowID,
@@ -851,6 +856,7 @@ func createReplicationTasks(
 			BranchToken:             branchToken,
 			LastReplicationInfo:     lastReplicationInfo,
 			ResetWorkflow:           resetWorkflow,
+			VisibilityTimestamp:     taskVisTs,
 		})
 
 		if err != nil {
@@ -912,6 +918,9 @@ func createTimerTasks(
 		case *p.WorkflowTimeoutTask:
 			// noop
 
+		case *p.CancellationGracePeriodTask:
+			// noop
+
 		case *p.DeleteHistoryEventTask:
 			// noop
 
@@ -48,6 +48,9 @@ type (
 		GetNamespaceReplicationQueue() persistence.NamespaceReplicationQueue
 		SetNamespaceReplicationQueue(persistence.NamespaceReplicationQueue)
 
+		GetDecisionTaskDLQ() persistence.DecisionTaskDLQ
+		SetDecisionTaskDLQ(persistence.DecisionTaskDLQ)
+
 		GetShardManager() persistence.ShardManager
 		SetShardManager(persistence.ShardManager)
 
@@ -65,6 +68,7 @@ type (
 		taskManager               persistence.TaskManager
 		visibilityManager         persistence.VisibilityManager
 		namespaceReplicationQueue persistence.NamespaceReplicationQueue
+		decisionTaskDLQ           persistence.DecisionTaskDLQ
 		shardManager              persistence.ShardManager
 		historyManager            persistence.HistoryManager
 		executionManagerFactory   persistence.ExecutionManagerFactory
@@ -105,6 +109,11 @@ func NewBeanFromFactory(
 		return nil, err
 	}
 
+	decisionTaskDLQ, err := factory.NewDecisionTaskDLQ()
+	if err != nil {
+		return nil, err
+	}
+
 	shardMgr, err := factory.NewShardManager()
 	if err != nil {
 		return nil, err
@@ -121,6 +130,7 @@ func NewBeanFromFactory(
 		taskMgr,
 		visibilityMgr,
 		namespaceReplicationQueue,
+		decisionTaskDLQ,
 		shardMgr,
 		historyMgr,
 		factory,
@@ -134,6 +144,7 @@ func NewBean(
 	taskManager persistence.TaskManager,
 	visibilityManager persistence.VisibilityManager,
 	namespaceReplicationQueue persistence.NamespaceReplicationQueue,
+	decisionTaskDLQ persistence.DecisionTaskDLQ,
 	shardManager persistence.ShardManager,
 	historyManager persistence.HistoryManager,
 	executionManagerFactory persistence.ExecutionManagerFactory,
@@ -144,6 +155,7 @@ func NewBean(
 		taskManager:               taskManager,
 		visibilityManager:         visibilityManager,
 		namespaceReplicationQueue: namespaceReplicationQueue,
+		decisionTaskDLQ:           decisionTaskDLQ,
 		shardManager:              shardManager,
 		historyManager:            historyManager,
 		executionManagerFactory:   executionManagerFactory,
@@ -251,6 +263,26 @@ func (s *BeanImpl) SetNamespaceReplicationQueue(
 	s.namespaceReplicationQueue = namespaceReplicationQueue
 }
 
+// GetDecisionTaskDLQ get DecisionTaskDLQ
+func (s *BeanImpl) GetDecisionTaskDLQ() persistence.DecisionTaskDLQ {
+
+	s.RLock()
+	defer s.RUnlock()
+
+	return s.decisionTaskDLQ
+}
+
+// SetDecisionTaskDLQ set DecisionTaskDLQ
+func (s *BeanImpl) SetDecisionTaskDLQ(
+	decisionTaskDLQ persistence.DecisionTaskDLQ,
+) {
+
+	s.Lock()
+	defer s.Unlock()
+
+	s.decisionTaskDLQ = decisionTaskDLQ
+}
+
 // GetShardManager get ShardManager
 func (s *BeanImpl) GetShardManager() persistence.ShardManager {
 
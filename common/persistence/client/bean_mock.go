@@ -186,6 +186,32 @@ func (mr *MockBeanMockRecorder) GetNamespaceReplicationQueue() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetNamespaceReplicationQueue", reflect.TypeOf((*MockBean)(nil).GetNamespaceReplicationQueue))
 }
 
+// GetDecisionTaskDLQ mocks base method.
+func (m *MockBean) GetDecisionTaskDLQ() persistence.DecisionTaskDLQ {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDecisionTaskDLQ")
+	ret0, _ := ret[0].(persistence.DecisionTaskDLQ)
+	return ret0
+}
+
+// GetDecisionTaskDLQ indicates an expected call of GetDecisionTaskDLQ.
+func (mr *MockBeanMockRecorder) GetDecisionTaskDLQ() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDecisionTaskDLQ", reflect.TypeOf((*MockBean)(nil).GetDecisionTaskDLQ))
+}
+
+// SetDecisionTaskDLQ mocks base method.
+func (m *MockBean) SetDecisionTaskDLQ(arg0 persistence.DecisionTaskDLQ) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "SetDecisionTaskDLQ", arg0)
+}
+
+// SetDecisionTaskDLQ indicates an expected call of SetDecisionTaskDLQ.
+func (mr *MockBeanMockRecorder) SetDecisionTaskDLQ(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetDecisionTaskDLQ", reflect.TypeOf((*MockBean)(nil).SetDecisionTaskDLQ), arg0)
+}
+
 // SetNamespaceReplicationQueue mocks base method.
 func (m *MockBean) SetNamespaceReplicationQueue(arg0 persistence.NamespaceReplicationQueue) {
 	m.ctrl.T.Helper()
@@ -54,6 +54,8 @@ type (
 		NewVisibilityManager() (p.VisibilityManager, error)
 		// NewNamespaceReplicationQueue returns a new queue for namespace replication
 		NewNamespaceReplicationQueue() (p.NamespaceReplicationQueue, error)
+		// NewDecisionTaskDLQ returns a new DLQ for decision tasks which cannot be processed
+		NewDecisionTaskDLQ() (p.DecisionTaskDLQ, error)
 		// NewClusterMetadata returns a new manager for cluster specific metadata
 		NewClusterMetadataManager() (p.ClusterMetadataManager, error)
 	}
@@ -299,6 +301,22 @@ func (f *factoryImpl) NewNamespaceReplicationQueue() (p.NamespaceReplicationQueu
 	return p.NewNamespaceReplicationQueue(result, f.clusterName, f.metricsClient, f.logger), nil
 }
 
+func (f *factoryImpl) NewDecisionTaskDLQ() (p.DecisionTaskDLQ, error) {
+	ds := f.datastores[storeTypeQueue]
+	result, err := ds.factory.NewQueue(p.DecisionTaskQueueType)
+	if err != nil {
+		return nil, err
+	}
+	if ds.ratelimit != nil {
+		result = p.NewQueuePersistenceRateLimitedClient(result, ds.ratelimit, f.logger)
+	}
+	if f.metricsClient != nil {
+		result = p.NewQueuePersistenceMetricsClient(result, f.metricsClient, f.logger)
+	}
+
+	return p.NewDecisionTaskDLQ(result, f.metricsClient), nil
+}
+
 // Close closes this factory
 func (f *factoryImpl) Close() {
 	ds := f.datastores[storeTypeExecution]
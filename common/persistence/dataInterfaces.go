@@ -67,6 +67,7 @@ type QueueType int
 // Negative numbers are reserved for DLQ
 const (
 	NamespaceReplicationQueueType QueueType = iota + 1
+	DecisionTaskQueueType
 )
 
 // Create Workflow Execution Mode
@@ -161,6 +162,7 @@ const (
 	TaskTypeDeleteHistoryEvent
 	TaskTypeActivityRetryTimer
 	TaskTypeWorkflowBackoffTimer
+	TaskTypeCancellationGracePeriod
 )
 
 // UnknownNumRowsAffected is returned when the number of rows that an API affected cannot be determined
@@ -303,6 +305,12 @@ type (
 		AutoResetPoints                    *executionpb.ResetPoints
 		Memo                               map[string][]byte
 		SearchAttributes                   map[string][]byte
+		MarkerChecksums                    map[string][]byte
+		SignalControlIDs                   map[string]*persistenceblobs.SignalControlInfo
+		// pending cancel workflow decision deferred by the cancellation grace period, rebuilt from its marker event
+		CancelGracePeriodExpiryTime       time.Time
+		CancelGracePeriodCompletedEventID int64
+		CancelGracePeriodDetails          []byte
 		// for retry
 		Attempt            int32
 		HasRetryPolicy     bool
@@ -501,6 +509,13 @@ type (
 		TimeoutType         int // 0 for retry, 1 for cron.
 	}
 
+	// CancellationGracePeriodTask cancels the workflow once the grace period of a cancel workflow decision is over
+	CancellationGracePeriodTask struct {
+		VisibilityTimestamp time.Time
+		TaskID              int64
+		Version             int64
+	}
+
 	// HistoryReplicationTask is the replication task created for shipping history replication events to other clusters
 	HistoryReplicationTask struct {
 		VisibilityTimestamp time.Time
@@ -986,6 +1001,19 @@ type (
 		Tasks []*persistenceblobs.AllocatedTaskInfo
 	}
 
+	// GetTaskRequest is used to retrieve a single task of a task list by its task id
+	GetTaskRequest struct {
+		NamespaceID primitives.UUID
+		TaskList    string
+		TaskType    int32
+		TaskID      int64
+	}
+
+	// GetTaskResponse is the response to GetTaskRequest
+	GetTaskResponse struct {
+		Task *persistenceblobs.AllocatedTaskInfo
+	}
+
 	// CompleteTaskRequest is used to complete a task
 	CompleteTaskRequest struct {
 		TaskList *TaskListKey
@@ -1001,6 +1029,59 @@ type (
 		Limit        int   // Limit on the max number of tasks that can be completed. Required param
 	}
 
+	// MoveTasksRequest contains the request params needed to invoke MoveTasks API
+	MoveTasksRequest struct {
+		SourceTaskList      *TaskListKey
+		DestinationTaskList *PersistedTaskListInfo // Lease of the destination task list, its range ID is checked
+		TaskIDs             []int64                // New task IDs allocated under the destination lease, one per moved task
+	}
+
+	// PurgeExpiredTasksRequest contains the request params needed to invoke PurgeExpiredTasks API
+	PurgeExpiredTasksRequest struct {
+		BatchSize int // Number of task lists and tasks read at a time. Required param
+	}
+
+	// EstimateBacklogCountRequest contains the request params needed to invoke EstimateBacklogCount API
+	EstimateBacklogCountRequest struct {
+		NamespaceID primitives.UUID
+		TaskList    string
+		TaskType    int32
+	}
+
+	// GetTaskListRangeIDRequest contains the request params needed to invoke GetTaskListRangeID API
+	GetTaskListRangeIDRequest struct {
+		NamespaceID primitives.UUID
+		TaskList    string
+		TaskType    int32
+	}
+
+	// GetTasksAndMetadataRequest contains the request params needed to invoke GetTasksAndMetadata API
+	GetTasksAndMetadataRequest struct {
+		NamespaceID  primitives.UUID
+		TaskList     string
+		TaskType     int32
+		ReadLevel    int64 // range exclusive
+		MaxReadLevel int64 // range inclusive
+		BatchSize    int
+	}
+
+	// GetTasksAndMetadataResponse is the response to GetTasksAndMetadata
+	GetTasksAndMetadataResponse struct {
+		Tasks        []*persistenceblobs.AllocatedTaskInfo
+		TaskListInfo *PersistedTaskListInfo
+	}
+
+	// RenewLeaseAndUpdateRequest contains the request params needed to invoke RenewLeaseAndUpdate API
+	RenewLeaseAndUpdateRequest struct {
+		RangeID      int64 // range ID of the lease being renewed
+		TaskListInfo *persistenceblobs.TaskListInfo
+	}
+
+	// RenewLeaseAndUpdateResponse is the response to RenewLeaseAndUpdate
+	RenewLeaseAndUpdateResponse struct {
+		TaskListInfo *PersistedTaskListInfo
+	}
+
 	// GetTimerIndexTasksRequest is the request for GetTimerIndexTasks
 	// TODO: replace this with an iterator that can configure min and max index.
 	GetTimerIndexTasksRequest struct {
@@ -1457,12 +1538,18 @@ type (
 	TaskManager interface {
 		Closeable
 		GetName() string
+		// GetTimePrecision returns the precision the created time and expiry of tasks are stored at,
+		// tasks read back have these times truncated to it
+		GetTimePrecision() time.Duration
 		LeaseTaskList(request *LeaseTaskListRequest) (*LeaseTaskListResponse, error)
 		UpdateTaskList(request *UpdateTaskListRequest) (*UpdateTaskListResponse, error)
 		ListTaskList(request *ListTaskListRequest) (*ListTaskListResponse, error)
 		DeleteTaskList(request *DeleteTaskListRequest) error
 		CreateTasks(request *CreateTasksRequest) (*CreateTasksResponse, error)
 		GetTasks(request *GetTasksRequest) (*GetTasksResponse, error)
+		// GetTask returns the task with the exact task id of the task list. A task which was never
+		// created, or which was already completed, is not found.
+		GetTask(request *GetTaskRequest) (*GetTaskResponse, error)
 		CompleteTask(request *CompleteTaskRequest) error
 		// CompleteTasksLessThan completes tasks less than or equal to the given task id
 		// This API takes a limit parameter which specifies the count of maxRows that
@@ -1474,6 +1561,35 @@ type (
 		//  - number of rows actually deleted, if limit is honored
 		//  - UnknownNumRowsDeleted, when all rows below value are deleted
 		CompleteTasksLessThan(request *CompleteTasksLessThanRequest) (int, error)
+		// MoveTasks re-homes up to len(TaskIDs) tasks, in task id order, from the source task list to
+		// the destination task list. The moved tasks are given the new task ids of the request, which
+		// the caller allocates under its lease of the destination task list, and the move fails with
+		// ConditionFailedError if that lease was lost. The source task list should not be owned by a
+		// matching host while its tasks are moved. On success, this method returns the number of tasks moved.
+		MoveTasks(request *MoveTasksRequest) (int, error)
+		// PurgeExpiredTasks deletes the tasks of all task lists whose expiry is in the past, and
+		// is meant to be run periodically as a background sweep. Stores which expire tasks by
+		// themselves, like cassandra with TTL, do nothing. On success, this method returns the
+		// number of tasks deleted.
+		PurgeExpiredTasks(request *PurgeExpiredTasksRequest) (int, error)
+		// EstimateBacklogCount returns the approximate number of tasks of a task list which are not
+		// acked yet, from the distance between the ack level and the highest task id persisted. Task
+		// ids skipped on range renewal are counted, so the estimate can be above the real backlog.
+		// A task list which was never leased has no backlog.
+		EstimateBacklogCount(request *EstimateBacklogCountRequest) (int64, error)
+		// GetTaskListRangeID returns the range id the task list is currently leased with, without
+		// renewing the lease. A host compares it with the range id of its own lease to detect that
+		// another host took the task list over. A task list which was never leased is not found.
+		GetTaskListRangeID(request *GetTaskListRangeIDRequest) (int64, error)
+		// RenewLeaseAndUpdate renews the lease held with the request range id and updates the task
+		// list info, including the ack level, in one conditional write. The renewed lease has the
+		// next range id. The write fails with ConditionFailedError if the range id is stale.
+		RenewLeaseAndUpdate(request *RenewLeaseAndUpdateRequest) (*RenewLeaseAndUpdateResponse, error)
+		// GetTasksAndMetadata returns a batch of tasks like GetTasks together with the task list info
+		// and range id. The task list info is read after the tasks, so a host which sees its own range
+		// id in the response still held the lease when the tasks were read. A task list which was
+		// never leased is not found.
+		GetTasksAndMetadata(request *GetTasksAndMetadataRequest) (*GetTasksAndMetadataResponse, error)
 	}
 
 	// HistoryManager is used to manager workflow history events
@@ -1628,8 +1744,11 @@ func (d *DecisionTask) SetTaskID(id int64) {
 	d.TaskID = id
 }
 
-// GetVisibilityTimestamp get the visibility timestamp
+// GetVisibilityTimestamp get the visibility timestamp, which is empty for tasks written before it was recorded
 func (d ReplicationTaskInfoWrapper) GetVisibilityTimestamp() *types.Timestamp {
+	if visibilityTimestamp := d.ReplicationTaskInfo.GetVisibilityTimestamp(); visibilityTimestamp != nil {
+		return visibilityTimestamp
+	}
 	return &types.Timestamp{}
 }
 
@@ -1958,6 +2077,41 @@ func (r *WorkflowBackoffTimerTask) SetVisibilityTimestamp(t time.Time) {
 	r.VisibilityTimestamp = t
 }
 
+// GetType returns the type of the cancellation grace period task
+func (u *CancellationGracePeriodTask) GetType() int {
+	return TaskTypeCancellationGracePeriod
+}
+
+// GetVersion returns the version of the cancellation grace period task
+func (u *CancellationGracePeriodTask) GetVersion() int64 {
+	return u.Version
+}
+
+// SetVersion returns the version of the cancellation grace period task
+func (u *CancellationGracePeriodTask) SetVersion(version int64) {
+	u.Version = version
+}
+
+// GetTaskID returns the sequence ID of the cancellation grace period task
+func (u *CancellationGracePeriodTask) GetTaskID() int64 {
+	return u.TaskID
+}
+
+// SetTaskID sets the sequence ID of the cancellation grace period task
+func (u *CancellationGracePeriodTask) SetTaskID(id int64) {
+	u.TaskID = id
+}
+
+// GetVisibilityTimestamp gets the visibility time stamp
+func (u *CancellationGracePeriodTask) GetVisibilityTimestamp() time.Time {
+	return u.VisibilityTimestamp
+}
+
+// SetVisibilityTimestamp gets the visibility time stamp
+func (u *CancellationGracePeriodTask) SetVisibilityTimestamp(t time.Time) {
+	u.VisibilityTimestamp = t
+}
+
 // GetType returns the type of the timeout task.
 func (u *WorkflowTimeoutTask) GetType() int {
 	return TaskTypeWorkflowTimeout
@@ -2251,6 +2405,33 @@ func UnixNanoToDBTimestamp(timestamp int64) int64 {
 	return timestamp / (1000 * 1000) // Milliseconds are 10⁻³, nanoseconds are 10⁻⁹, (-9) - (-3) = -6, so divide by 10⁶
 }
 
+// TruncateTaskTimes returns a copy of the task with its created time and expiry truncated to the given precision
+func TruncateTaskTimes(task *persistenceblobs.AllocatedTaskInfo, precision time.Duration) (*persistenceblobs.AllocatedTaskInfo, error) {
+	if task.Data == nil {
+		return task, nil
+	}
+	data := *task.Data
+	var err error
+	if data.CreatedTime, err = truncateTimestamp(data.CreatedTime, precision); err != nil {
+		return nil, err
+	}
+	if data.Expiry, err = truncateTimestamp(data.Expiry, precision); err != nil {
+		return nil, err
+	}
+	return &persistenceblobs.AllocatedTaskInfo{Data: &data, TaskId: task.TaskId}, nil
+}
+
+func truncateTimestamp(ts *types.Timestamp, precision time.Duration) (*types.Timestamp, error) {
+	if ts == nil {
+		return nil, nil
+	}
+	t, err := types.TimestampFromProto(ts)
+	if err != nil {
+		return nil, err
+	}
+	return types.TimestampProto(t.Truncate(precision))
+}
+
 // NewHistoryBranchToken return a new branch token
 func NewHistoryBranchToken(treeID []byte) ([]byte, error) {
 	branchID := uuid.NewRandom()
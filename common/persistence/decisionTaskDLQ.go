@@ -0,0 +1,114 @@
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+//go:generate mockgen -copyright_file ../../LICENSE -package $GOPACKAGE -source $GOFILE -destination decisionTaskDLQ_mock.go -self_package github.com/temporalio/temporal/common/persistence
+
+package persistence
+
+import (
+	"fmt"
+
+	"github.com/temporalio/temporal/.gen/proto/persistenceblobs"
+	"github.com/temporalio/temporal/common/metrics"
+)
+
+var _ DecisionTaskDLQ = (*decisionTaskDLQImpl)(nil)
+
+// NewDecisionTaskDLQ creates a new DecisionTaskDLQ instance
+func NewDecisionTaskDLQ(
+	queue Queue,
+	metricsClient metrics.Client,
+) DecisionTaskDLQ {
+	return &decisionTaskDLQImpl{
+		queue:         queue,
+		metricsClient: metricsClient,
+	}
+}
+
+type (
+	decisionTaskDLQImpl struct {
+		queue         Queue
+		metricsClient metrics.Client
+	}
+
+	// DecisionTaskDLQ holds the transfer decision tasks which kept failing to be processed,
+	// so they can be inspected offline and redriven instead of being retried forever
+	DecisionTaskDLQ interface {
+		Put(task *persistenceblobs.TransferTaskInfo) error
+		Read(lastMessageID int, pageSize int, pageToken []byte) ([]*persistenceblobs.TransferTaskInfo, int, []byte, error)
+		RangeDelete(lastMessageID int) error
+	}
+)
+
+func (q *decisionTaskDLQImpl) Put(
+	task *persistenceblobs.TransferTaskInfo,
+) error {
+
+	bytes, err := task.Marshal()
+	if err != nil {
+		return fmt.Errorf("failed to encode decision task: %v", err)
+	}
+	messageID, err := q.queue.EnqueueMessageToDLQ(bytes)
+	if err != nil {
+		return err
+	}
+
+	q.metricsClient.Scope(
+		metrics.PersistenceDecisionTaskDLQScope,
+	).UpdateGauge(
+		metrics.DecisionTaskDLQMaxLevelGauge,
+		float64(messageID),
+	)
+	return nil
+}
+
+// Read reads a page of the decision tasks put into the DLQ, up to lastMessageID inclusive,
+// along with the message ID of the last task of the page
+func (q *decisionTaskDLQImpl) Read(
+	lastMessageID int,
+	pageSize int,
+	pageToken []byte,
+) ([]*persistenceblobs.TransferTaskInfo, int, []byte, error) {
+
+	messages, token, err := q.queue.ReadMessagesFromDLQ(emptyMessageID, lastMessageID, pageSize, pageToken)
+	if err != nil {
+		return nil, emptyMessageID, nil, err
+	}
+
+	var tasks []*persistenceblobs.TransferTaskInfo
+	lastReadMessageID := emptyMessageID
+	for _, message := range messages {
+		task := &persistenceblobs.TransferTaskInfo{}
+		if err := task.Unmarshal(message.Payload); err != nil {
+			return nil, emptyMessageID, nil, fmt.Errorf("failed to decode dlq decision task: %v", err)
+		}
+		tasks = append(tasks, task)
+		lastReadMessageID = message.ID
+	}
+	return tasks, lastReadMessageID, token, nil
+}
+
+// RangeDelete deletes the decision tasks put into the DLQ, up to lastMessageID inclusive
+func (q *decisionTaskDLQImpl) RangeDelete(
+	lastMessageID int,
+) error {
+
+	return q.queue.RangeDeleteMessagesFromDLQ(emptyMessageID, lastMessageID)
+}
@@ -180,6 +180,11 @@ func (m *executionManagerImpl) DeserializeExecutionInfo(
 		AutoResetPoints:                    autoResetPoints,
 		SearchAttributes:                   info.SearchAttributes,
 		Memo:                               info.Memo,
+		MarkerChecksums:                    info.MarkerChecksums,
+		SignalControlIDs:                   info.SignalControlIDs,
+		CancelGracePeriodExpiryTime:        info.CancelGracePeriodExpiryTime,
+		CancelGracePeriodCompletedEventID:  info.CancelGracePeriodCompletedEventID,
+		CancelGracePeriodDetails:           info.CancelGracePeriodDetails,
 	}
 	newStats := &ExecutionStats{
 		HistorySize: info.HistorySize,
@@ -500,6 +505,11 @@ func (m *executionManagerImpl) SerializeExecutionInfo(
 		ExpirationSeconds:                  info.ExpirationSeconds,
 		Memo:                               info.Memo,
 		SearchAttributes:                   info.SearchAttributes,
+		MarkerChecksums:                    info.MarkerChecksums,
+		SignalControlIDs:                   info.SignalControlIDs,
+		CancelGracePeriodExpiryTime:        info.CancelGracePeriodExpiryTime,
+		CancelGracePeriodCompletedEventID:  info.CancelGracePeriodCompletedEventID,
+		CancelGracePeriodDetails:           info.CancelGracePeriodDetails,
 
 		// attributes which are not related to mutable state
 		HistorySize: stats.HistorySize,
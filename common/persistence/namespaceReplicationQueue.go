@@ -29,6 +29,8 @@ import (
 	"sync/atomic"
 	"time"
 
+	"go.temporal.io/temporal-proto/serviceerror"
+
 	replicationgenpb "github.com/temporalio/temporal/.gen/proto/replication"
 	"github.com/temporalio/temporal/common"
 	"github.com/temporalio/temporal/common/log"
@@ -40,6 +42,7 @@ const (
 	purgeInterval                    = 5 * time.Minute
 	emptyMessageID                   = -1
 	localNamespaceReplicationCluster = "namespaceReplication"
+	dlqFilterScanPageSize            = 1000
 )
 
 var _ NamespaceReplicationQueue = (*namespaceReplicationQueueImpl)(nil)
@@ -82,10 +85,11 @@ type (
 		GetReplicationMessages(lastMessageID int, maxCount int) ([]*replicationgenpb.ReplicationTask, int, error)
 		UpdateAckLevel(lastProcessedMessageID int, clusterName string) error
 		GetAckLevels() (map[string]int, error)
-		GetMessagesFromDLQ(firstMessageID int, lastMessageID int, pageSize int, pageToken []byte) ([]*replicationgenpb.ReplicationTask, []byte, error)
+		GetMessagesFromDLQ(firstMessageID int, lastMessageID int, pageSize int, pageToken []byte, namespaceID string) ([]*replicationgenpb.ReplicationTask, []byte, error)
+		GetMessageFromDLQ(messageID int) (*replicationgenpb.ReplicationTask, error)
 		UpdateDLQAckLevel(lastProcessedMessageID int) error
 		GetDLQAckLevel() (int, error)
-		RangeDeleteMessagesFromDLQ(firstMessageID int, lastMessageID int) error
+		RangeDeleteMessagesFromDLQ(firstMessageID int, lastMessageID int, namespaceID string) error
 		DeleteMessageFromDLQ(messageID int) error
 	}
 )
@@ -188,11 +192,14 @@ func (q *namespaceReplicationQueueImpl) GetAckLevels() (map[string]int, error) {
 	return q.queue.GetAckLevels()
 }
 
+// GetMessagesFromDLQ reads a page of DLQ messages. If namespaceID is not empty, only the messages
+// of that namespace are returned, so a page may hold fewer than pageSize messages even if more remain.
 func (q *namespaceReplicationQueueImpl) GetMessagesFromDLQ(
 	firstMessageID int,
 	lastMessageID int,
 	pageSize int,
 	pageToken []byte,
+	namespaceID string,
 ) ([]*replicationgenpb.ReplicationTask, []byte, error) {
 
 	messages, token, err := q.queue.ReadMessagesFromDLQ(firstMessageID, lastMessageID, pageSize, pageToken)
@@ -207,6 +214,9 @@ func (q *namespaceReplicationQueueImpl) GetMessagesFromDLQ(
 		if err != nil {
 			return nil, nil, fmt.Errorf("failed to decode dlq task: %v", err)
 		}
+		if !matchNamespaceID(replicationTask, namespaceID) {
+			continue
+		}
 
 		// Overwrite to local cluster message id
 		replicationTask.SourceTaskId = int64(message.ID)
@@ -216,6 +226,21 @@ func (q *namespaceReplicationQueueImpl) GetMessagesFromDLQ(
 	return replicationTasks, token, nil
 }
 
+// GetMessageFromDLQ returns the DLQ message with the given ID, or a NotFound error if there is none
+func (q *namespaceReplicationQueueImpl) GetMessageFromDLQ(
+	messageID int,
+) (*replicationgenpb.ReplicationTask, error) {
+
+	tasks, _, err := q.GetMessagesFromDLQ(messageID-1, messageID, 1, nil, "")
+	if err != nil {
+		return nil, err
+	}
+	if len(tasks) == 0 {
+		return nil, serviceerror.NewNotFound(fmt.Sprintf("DLQ message %v not found.", messageID))
+	}
+	return tasks[0], nil
+}
+
 func (q *namespaceReplicationQueueImpl) UpdateDLQAckLevel(
 	lastProcessedMessageID int,
 ) error {
@@ -249,11 +274,18 @@ func (q *namespaceReplicationQueueImpl) GetDLQAckLevel() (int, error) {
 	return ackLevel, nil
 }
 
+// RangeDeleteMessagesFromDLQ deletes DLQ messages in (firstMessageID, lastMessageID]. If namespaceID
+// is not empty, only the messages of that namespace are deleted.
 func (q *namespaceReplicationQueueImpl) RangeDeleteMessagesFromDLQ(
 	firstMessageID int,
 	lastMessageID int,
+	namespaceID string,
 ) error {
 
+	if namespaceID != "" {
+		return q.deleteNamespaceMessagesFromDLQ(firstMessageID, lastMessageID, namespaceID)
+	}
+
 	if err := q.queue.RangeDeleteMessagesFromDLQ(
 		firstMessageID,
 		lastMessageID,
@@ -264,6 +296,38 @@ func (q *namespaceReplicationQueueImpl) RangeDeleteMessagesFromDLQ(
 	return nil
 }
 
+func (q *namespaceReplicationQueueImpl) deleteNamespaceMessagesFromDLQ(
+	firstMessageID int,
+	lastMessageID int,
+	namespaceID string,
+) error {
+
+	// messages of other namespaces are interleaved with the ones to delete, so scan the
+	// whole range first and delete one by one after, to not invalidate the page token
+	var messageIDs []int
+	var pageToken []byte
+	for {
+		tasks, token, err := q.GetMessagesFromDLQ(firstMessageID, lastMessageID, dlqFilterScanPageSize, pageToken, namespaceID)
+		if err != nil {
+			return err
+		}
+		for _, task := range tasks {
+			messageIDs = append(messageIDs, int(task.GetSourceTaskId()))
+		}
+		if len(token) == 0 {
+			break
+		}
+		pageToken = token
+	}
+
+	for _, messageID := range messageIDs {
+		if err := q.queue.DeleteMessageFromDLQ(messageID); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 func (q *namespaceReplicationQueueImpl) DeleteMessageFromDLQ(
 	messageID int,
 ) error {
@@ -271,6 +335,17 @@ func (q *namespaceReplicationQueueImpl) DeleteMessageFromDLQ(
 	return q.queue.DeleteMessageFromDLQ(messageID)
 }
 
+func matchNamespaceID(
+	task *replicationgenpb.ReplicationTask,
+	namespaceID string,
+) bool {
+
+	if namespaceID == "" {
+		return true
+	}
+	return task.GetNamespaceTaskAttributes().GetId() == namespaceID
+}
+
 func (q *namespaceReplicationQueueImpl) purgeAckedMessages() error {
 	ackLevelByCluster, err := q.GetAckLevels()
 	if err != nil {
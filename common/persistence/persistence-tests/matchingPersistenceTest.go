@@ -22,6 +22,7 @@ package persistencetests
 
 import (
 	"fmt"
+	"math"
 	"os"
 	"testing"
 	"time"
@@ -31,6 +32,7 @@ import (
 	log "github.com/sirupsen/logrus"
 	"github.com/stretchr/testify/require"
 	executionpb "go.temporal.io/temporal-proto/execution"
+	"go.temporal.io/temporal-proto/serviceerror"
 
 	"github.com/temporalio/temporal/.gen/proto/persistenceblobs"
 	p "github.com/temporalio/temporal/common/persistence"
@@ -45,6 +47,10 @@ type (
 		// not merely log an error
 		*require.Assertions
 	}
+
+	fixedTaskIDAllocator struct {
+		taskIDs []int64
+	}
 )
 
 // TimePrecision is needed to account for database timestamp precision.
@@ -119,6 +125,101 @@ func (s *MatchingPersistenceSuite) TestCreateTask() {
 	}
 }
 
+// TestCreateTaskTimePrecision test
+func (s *MatchingPersistenceSuite) TestCreateTaskTimePrecision() {
+	precision := s.TaskMgr.GetTimePrecision()
+	s.True(precision > 0)
+
+	namespaceID := primitives.UUID(uuid.NewRandom())
+	taskList := "create-task-time-precision-" + uuid.New()
+	leaseResp, err := s.TaskMgr.LeaseTaskList(&p.LeaseTaskListRequest{
+		NamespaceID: namespaceID,
+		TaskList:    taskList,
+		TaskType:    p.TaskListTypeActivity,
+	})
+	s.NoError(err)
+
+	// nanoseconds are set on purpose, so that they are below the precision of every store
+	createdTime := time.Unix(1500000000, 123456789)
+	expiry := time.Now().Add(time.Hour).Truncate(time.Second).Add(987654321 * time.Nanosecond)
+	createdTimeProto, err := types.TimestampProto(createdTime)
+	s.NoError(err)
+	expiryProto, err := types.TimestampProto(expiry)
+	s.NoError(err)
+	taskID, err := s.MatchingTaskIDAllocator.AllocateTaskID()
+	s.NoError(err)
+	_, err = s.TaskMgr.CreateTasks(&p.CreateTasksRequest{
+		TaskListInfo: leaseResp.TaskListInfo,
+		Tasks: []*persistenceblobs.AllocatedTaskInfo{{
+			Data: &persistenceblobs.TaskInfo{
+				NamespaceId: namespaceID,
+				WorkflowId:  "create-task-time-precision-test",
+				RunId:       primitives.MustParseUUID(uuid.New()),
+				ScheduleId:  5,
+				Expiry:      expiryProto,
+				CreatedTime: createdTimeProto,
+			},
+			TaskId: taskID,
+		}},
+	})
+	s.NoError(err)
+
+	resp, err := s.GetTasks(namespaceID, taskList, p.TaskListTypeActivity, 10)
+	s.NoError(err)
+	s.Equal(1, len(resp.Tasks))
+	cTime, err := types.TimestampFromProto(resp.Tasks[0].Data.GetCreatedTime())
+	s.NoError(err)
+	s.True(createdTime.Truncate(precision).Equal(cTime), "expected: %v, actual: %v", createdTime.Truncate(precision), cTime)
+	s.EqualTimesWithPrecision(createdTime, cTime, precision)
+	if s.TaskMgr.GetName() != "cassandra" {
+		// cassandra uses TTL and expiry isn't stored as part of task state
+		eTime, err := types.TimestampFromProto(resp.Tasks[0].Data.GetExpiry())
+		s.NoError(err)
+		s.True(expiry.Truncate(precision).Equal(eTime), "expected: %v, actual: %v", expiry.Truncate(precision), eTime)
+	}
+}
+
+// TestCreateTaskWithFixedTaskIDs test
+func (s *MatchingPersistenceSuite) TestCreateTaskWithFixedTaskIDs() {
+	defaultAllocator := s.MatchingTaskIDAllocator
+	defer func() { s.MatchingTaskIDAllocator = defaultAllocator }()
+	s.MatchingTaskIDAllocator = &fixedTaskIDAllocator{taskIDs: []int64{1001, 1002, 1003}}
+
+	namespaceID := primitives.MustParseUUID("5ea1bd0d-5d3f-4e1e-9a6e-58f1c3e25d7a")
+	workflowExecution := executionpb.WorkflowExecution{WorkflowId: "create-task-fixed-id-test",
+		RunId: "2c2f5f6e-6f0b-4d8c-9d0e-4b9a0b3d7c11"}
+	decisionTaskList := "fixed-id-decision-" + uuid.New()
+	decisionTaskID, err := s.CreateDecisionTask(namespaceID, workflowExecution, decisionTaskList, 5)
+	s.NoError(err)
+	s.Equal(int64(1001), decisionTaskID)
+
+	resp, err := s.GetTasks(namespaceID, decisionTaskList, p.TaskListTypeDecision, 100)
+	s.NoError(err)
+	s.Equal(1, len(resp.Tasks))
+	s.Equal(int64(1001), resp.Tasks[0].GetTaskId())
+
+	activities := map[int64]string{
+		10: "fixed-id-activity-" + uuid.New(),
+		20: "fixed-id-activity-" + uuid.New(),
+	}
+	activityTaskIDs, err := s.CreateActivityTasks(namespaceID, workflowExecution, activities)
+	s.NoError(err)
+	s.ElementsMatch([]int64{1002, 1003}, activityTaskIDs)
+
+	var persistedTaskIDs []int64
+	for _, tlName := range activities {
+		resp, err := s.GetTasks(namespaceID, tlName, p.TaskListTypeActivity, 100)
+		s.NoError(err)
+		s.Equal(1, len(resp.Tasks))
+		persistedTaskIDs = append(persistedTaskIDs, resp.Tasks[0].GetTaskId())
+	}
+	s.ElementsMatch(activityTaskIDs, persistedTaskIDs)
+
+	// allocator is exhausted
+	_, err = s.CreateDecisionTask(namespaceID, workflowExecution, decisionTaskList, 6)
+	s.Error(err)
+}
+
 // TestGetDecisionTasks test
 func (s *MatchingPersistenceSuite) TestGetDecisionTasks() {
 	namespaceID := primitives.MustParseUUID("aeac8287-527b-4b35-80a9-667cb47e7c6d")
@@ -185,6 +286,46 @@ func (s *MatchingPersistenceSuite) TestGetTasksWithNoMaxReadLevel() {
 	}
 }
 
+// TestGetTask test
+func (s *MatchingPersistenceSuite) TestGetTask() {
+	namespaceID := primitives.UUID(uuid.NewRandom())
+	workflowExecution := executionpb.WorkflowExecution{WorkflowId: "get-task-test",
+		RunId: uuid.New()}
+	taskList := "get-task-" + uuid.New()
+
+	scheduleIDs := map[int64]int64{}
+	for _, scheduleID := range []int64{10, 20, 30} {
+		taskIDs, err := s.CreateActivityTasks(namespaceID, workflowExecution, map[int64]string{scheduleID: taskList})
+		s.NoError(err)
+		s.Equal(1, len(taskIDs))
+		scheduleIDs[taskIDs[0]] = scheduleID
+	}
+
+	for taskID, scheduleID := range scheduleIDs {
+		response, err := s.TaskMgr.GetTask(&p.GetTaskRequest{
+			NamespaceID: namespaceID,
+			TaskList:    taskList,
+			TaskType:    p.TaskListTypeActivity,
+			TaskID:      taskID,
+		})
+		s.NoError(err)
+		s.Equal(taskID, response.Task.GetTaskId())
+		s.Equal(scheduleID, response.Task.Data.GetScheduleId())
+	}
+
+	missingTaskID, err := s.MatchingTaskIDAllocator.AllocateTaskID()
+	s.NoError(err)
+	_, err = s.TaskMgr.GetTask(&p.GetTaskRequest{
+		NamespaceID: namespaceID,
+		TaskList:    taskList,
+		TaskType:    p.TaskListTypeActivity,
+		TaskID:      missingTaskID,
+	})
+	s.Error(err)
+	_, ok := err.(*serviceerror.NotFound)
+	s.True(ok, "a task id which was never created is not found")
+}
+
 // TestCompleteDecisionTask test
 func (s *MatchingPersistenceSuite) TestCompleteDecisionTask() {
 	namespaceID := primitives.MustParseUUID("f1116985-d1f1-40e0-aba9-83344db915bc")
@@ -293,6 +434,431 @@ func (s *MatchingPersistenceSuite) TestCompleteTasksLessThan() {
 	}
 }
 
+// TestMoveTasks test
+func (s *MatchingPersistenceSuite) TestMoveTasks() {
+	namespaceID := primitives.UUID(uuid.NewRandom())
+	srcTaskList := "move-tasks-src-" + uuid.New()
+	dstTaskList := "move-tasks-dst-" + uuid.New()
+	wfExec := executionpb.WorkflowExecution{
+		WorkflowId: "move-tasks-test",
+		RunId:      uuid.New(),
+	}
+	_, err := s.CreateActivityTasks(namespaceID, wfExec, map[int64]string{
+		10: srcTaskList,
+		20: srcTaskList,
+		30: srcTaskList,
+		40: srcTaskList,
+		50: srcTaskList,
+	})
+	s.NoError(err)
+
+	resp, err := s.GetTasks(namespaceID, srcTaskList, p.TaskListTypeActivity, 10)
+	s.NoError(err)
+	s.Equal(5, len(resp.Tasks))
+	tasks := resp.Tasks
+
+	// the destination already has a task with the same id as the first source task
+	leaseResp, err := s.TaskMgr.LeaseTaskList(&p.LeaseTaskListRequest{
+		NamespaceID: namespaceID,
+		TaskList:    dstTaskList,
+		TaskType:    p.TaskListTypeActivity,
+	})
+	s.NoError(err)
+	dstLease := leaseResp.TaskListInfo
+	_, err = s.TaskMgr.CreateTasks(&p.CreateTasksRequest{
+		TaskListInfo: dstLease,
+		Tasks: []*persistenceblobs.AllocatedTaskInfo{{
+			Data: &persistenceblobs.TaskInfo{
+				NamespaceId: namespaceID,
+				WorkflowId:  wfExec.WorkflowId,
+				RunId:       primitives.MustParseUUID(wfExec.RunId),
+				ScheduleId:  60,
+				CreatedTime: types.TimestampNow(),
+			},
+			TaskId: tasks[0].GetTaskId(),
+		}},
+	})
+	s.NoError(err)
+
+	req := &p.MoveTasksRequest{
+		SourceTaskList:      &p.TaskListKey{NamespaceID: namespaceID, Name: srcTaskList, TaskType: p.TaskListTypeActivity},
+		DestinationTaskList: dstLease,
+		TaskIDs:             s.allocateTaskIDs(3),
+	}
+	nMoved, err := s.TaskMgr.MoveTasks(req)
+	s.NoError(err)
+	s.Equal(3, nMoved)
+
+	dstResp, err := s.GetTasks(namespaceID, dstTaskList, p.TaskListTypeActivity, 10)
+	s.NoError(err)
+	s.Equal(4, len(dstResp.Tasks))
+	s.Equal(tasks[0].GetTaskId(), dstResp.Tasks[0].GetTaskId())
+	s.Equal(int64(60), dstResp.Tasks[0].Data.GetScheduleId())
+	for i, t := range dstResp.Tasks[1:] {
+		s.Equal(req.TaskIDs[i], t.GetTaskId())
+		s.Equal(tasks[i].Data.GetScheduleId(), t.Data.GetScheduleId())
+	}
+
+	srcResp, err := s.GetTasks(namespaceID, srcTaskList, p.TaskListTypeActivity, 10)
+	s.NoError(err)
+	s.Equal(2, len(srcResp.Tasks))
+	s.Equal(tasks[3].GetTaskId(), srcResp.Tasks[0].GetTaskId())
+	s.Equal(tasks[4].GetTaskId(), srcResp.Tasks[1].GetTaskId())
+
+	// the destination lease is lost, nothing is moved
+	_, err = s.TaskMgr.LeaseTaskList(&p.LeaseTaskListRequest{
+		NamespaceID: namespaceID,
+		TaskList:    dstTaskList,
+		TaskType:    p.TaskListTypeActivity,
+	})
+	s.NoError(err)
+	req.TaskIDs = s.allocateTaskIDs(10)
+	_, err = s.TaskMgr.MoveTasks(req)
+	s.Error(err)
+	_, ok := err.(*p.ConditionFailedError)
+	s.True(ok)
+
+	srcResp, err = s.GetTasks(namespaceID, srcTaskList, p.TaskListTypeActivity, 10)
+	s.NoError(err)
+	s.Equal(2, len(srcResp.Tasks))
+	dstResp, err = s.GetTasks(namespaceID, dstTaskList, p.TaskListTypeActivity, 10)
+	s.NoError(err)
+	s.Equal(4, len(dstResp.Tasks))
+}
+
+func (s *MatchingPersistenceSuite) allocateTaskIDs(count int) []int64 {
+	taskIDs := make([]int64, count)
+	for i := range taskIDs {
+		taskID, err := s.MatchingTaskIDAllocator.AllocateTaskID()
+		s.NoError(err)
+		taskIDs[i] = taskID
+	}
+	return taskIDs
+}
+
+// TestPurgeExpiredTasks test
+func (s *MatchingPersistenceSuite) TestPurgeExpiredTasks() {
+	if s.TaskMgr.GetName() == "cassandra" {
+		s.T().Skip("this test is not applicable for cassandra persistence (uses TTL based deletes)")
+	}
+
+	namespaceID := primitives.UUID(uuid.NewRandom())
+	taskList := "purge-expired-tasks-" + uuid.New()
+	leaseResp, err := s.TaskMgr.LeaseTaskList(&p.LeaseTaskListRequest{
+		NamespaceID: namespaceID,
+		TaskList:    taskList,
+		TaskType:    p.TaskListTypeActivity,
+	})
+	s.NoError(err)
+
+	past, err := types.TimestampProto(time.Now().Add(-time.Minute))
+	s.NoError(err)
+	future, err := types.TimestampProto(time.Now().Add(time.Hour))
+	s.NoError(err)
+	expiries := []*types.Timestamp{past, future, past, nil, future}
+
+	var tasks []*persistenceblobs.AllocatedTaskInfo
+	for i, expiry := range expiries {
+		taskID, err := s.MatchingTaskIDAllocator.AllocateTaskID()
+		s.NoError(err)
+		tasks = append(tasks, &persistenceblobs.AllocatedTaskInfo{
+			Data: &persistenceblobs.TaskInfo{
+				NamespaceId: namespaceID,
+				WorkflowId:  "purge-expired-tasks-test",
+				RunId:       primitives.MustParseUUID(uuid.New()),
+				ScheduleId:  int64(i),
+				Expiry:      expiry,
+				CreatedTime: types.TimestampNow(),
+			},
+			TaskId: taskID,
+		})
+	}
+	_, err = s.TaskMgr.CreateTasks(&p.CreateTasksRequest{
+		TaskListInfo: leaseResp.TaskListInfo,
+		Tasks:        tasks,
+	})
+	s.NoError(err)
+
+	// a small batch size makes the sweep page through task lists and tasks;
+	// other tests may leave expired tasks behind, so those can be purged too
+	nPurged, err := s.TaskMgr.PurgeExpiredTasks(&p.PurgeExpiredTasksRequest{BatchSize: 2})
+	s.NoError(err)
+	s.True(nPurged >= 2)
+
+	resp, err := s.GetTasks(namespaceID, taskList, p.TaskListTypeActivity, 10)
+	s.NoError(err)
+	s.Equal(3, len(resp.Tasks))
+	s.Equal(tasks[1].GetTaskId(), resp.Tasks[0].GetTaskId())
+	s.Equal(tasks[3].GetTaskId(), resp.Tasks[1].GetTaskId())
+	s.Equal(tasks[4].GetTaskId(), resp.Tasks[2].GetTaskId())
+
+	// tasks which are not expired are left alone by later sweeps
+	_, err = s.TaskMgr.PurgeExpiredTasks(&p.PurgeExpiredTasksRequest{BatchSize: 2})
+	s.NoError(err)
+	resp, err = s.GetTasks(namespaceID, taskList, p.TaskListTypeActivity, 10)
+	s.NoError(err)
+	s.Equal(3, len(resp.Tasks))
+}
+
+// TestEstimateBacklogCount test
+func (s *MatchingPersistenceSuite) TestEstimateBacklogCount() {
+	namespaceID := primitives.UUID(uuid.NewRandom())
+	taskList := "estimate-backlog-count-" + uuid.New()
+	request := &p.EstimateBacklogCountRequest{
+		NamespaceID: namespaceID,
+		TaskList:    taskList,
+		TaskType:    p.TaskListTypeActivity,
+	}
+
+	count, err := s.TaskMgr.EstimateBacklogCount(request)
+	s.NoError(err)
+	s.Equal(int64(0), count, "a task list which was never leased has no backlog")
+
+	leaseResp, err := s.TaskMgr.LeaseTaskList(&p.LeaseTaskListRequest{
+		NamespaceID: namespaceID,
+		TaskList:    taskList,
+		TaskType:    p.TaskListTypeActivity,
+	})
+	s.NoError(err)
+
+	const numTasks = 20
+	var tasks []*persistenceblobs.AllocatedTaskInfo
+	for i := 0; i < numTasks; i++ {
+		taskID, err := s.MatchingTaskIDAllocator.AllocateTaskID()
+		s.NoError(err)
+		tasks = append(tasks, &persistenceblobs.AllocatedTaskInfo{
+			Data: &persistenceblobs.TaskInfo{
+				NamespaceId: namespaceID,
+				WorkflowId:  "estimate-backlog-count-test",
+				RunId:       primitives.MustParseUUID(uuid.New()),
+				ScheduleId:  int64(i),
+				CreatedTime: types.TimestampNow(),
+			},
+			TaskId: taskID,
+		})
+	}
+	_, err = s.TaskMgr.CreateTasks(&p.CreateTasksRequest{
+		TaskListInfo: leaseResp.TaskListInfo,
+		Tasks:        tasks,
+	})
+	s.NoError(err)
+
+	// the allocator is shared by the suite, so acking up to the task before the first one created
+	// here leaves only these tasks in the backlog
+	taskListInfo := leaseResp.TaskListInfo.Data
+	taskListInfo.AckLevel = tasks[0].GetTaskId() - 1
+	_, err = s.TaskMgr.UpdateTaskList(&p.UpdateTaskListRequest{
+		TaskListInfo: taskListInfo,
+		RangeID:      leaseResp.TaskListInfo.RangeID,
+	})
+	s.NoError(err)
+
+	// task ids are allocated in sequence here, so the estimate only exceeds the backlog by ids
+	// handed out to other task lists in between
+	tolerance := int64(numTasks / 10)
+	count, err = s.TaskMgr.EstimateBacklogCount(request)
+	s.NoError(err)
+	s.InDelta(numTasks, count, float64(tolerance))
+	s.True(count >= numTasks)
+
+	taskListInfo.AckLevel = tasks[numTasks/2-1].GetTaskId()
+	_, err = s.TaskMgr.UpdateTaskList(&p.UpdateTaskListRequest{
+		TaskListInfo: taskListInfo,
+		RangeID:      leaseResp.TaskListInfo.RangeID,
+	})
+	s.NoError(err)
+	count, err = s.TaskMgr.EstimateBacklogCount(request)
+	s.NoError(err)
+	s.InDelta(numTasks/2, count, float64(tolerance))
+
+	taskListInfo.AckLevel = tasks[numTasks-1].GetTaskId()
+	_, err = s.TaskMgr.UpdateTaskList(&p.UpdateTaskListRequest{
+		TaskListInfo: taskListInfo,
+		RangeID:      leaseResp.TaskListInfo.RangeID,
+	})
+	s.NoError(err)
+	count, err = s.TaskMgr.EstimateBacklogCount(request)
+	s.NoError(err)
+	s.Equal(int64(0), count)
+}
+
+// TestGetTaskListRangeID test
+func (s *MatchingPersistenceSuite) TestGetTaskListRangeID() {
+	namespaceID := primitives.UUID(uuid.NewRandom())
+	taskList := "get-task-list-range-id-" + uuid.New()
+	request := &p.GetTaskListRangeIDRequest{
+		NamespaceID: namespaceID,
+		TaskList:    taskList,
+		TaskType:    p.TaskListTypeActivity,
+	}
+
+	_, err := s.TaskMgr.GetTaskListRangeID(request)
+	s.Error(err)
+	_, ok := err.(*serviceerror.NotFound)
+	s.True(ok, "a task list which was never leased is not found")
+
+	leaseResp, err := s.TaskMgr.LeaseTaskList(&p.LeaseTaskListRequest{
+		NamespaceID: namespaceID,
+		TaskList:    taskList,
+		TaskType:    p.TaskListTypeActivity,
+	})
+	s.NoError(err)
+	rangeID, err := s.TaskMgr.GetTaskListRangeID(request)
+	s.NoError(err)
+	s.Equal(leaseResp.TaskListInfo.RangeID, rangeID)
+
+	// another host taking the task list over makes the range id of the first lease stale
+	newLeaseResp, err := s.TaskMgr.LeaseTaskList(&p.LeaseTaskListRequest{
+		NamespaceID: namespaceID,
+		TaskList:    taskList,
+		TaskType:    p.TaskListTypeActivity,
+	})
+	s.NoError(err)
+	rangeID, err = s.TaskMgr.GetTaskListRangeID(request)
+	s.NoError(err)
+	s.Equal(newLeaseResp.TaskListInfo.RangeID, rangeID)
+	s.NotEqual(leaseResp.TaskListInfo.RangeID, rangeID)
+}
+
+// TestGetTasksAndMetadata test
+func (s *MatchingPersistenceSuite) TestGetTasksAndMetadata() {
+	namespaceID := primitives.UUID(uuid.NewRandom())
+	taskList := "get-tasks-and-metadata-" + uuid.New()
+	request := &p.GetTasksAndMetadataRequest{
+		NamespaceID:  namespaceID,
+		TaskList:     taskList,
+		TaskType:     p.TaskListTypeActivity,
+		ReadLevel:    0,
+		MaxReadLevel: math.MaxInt64,
+		BatchSize:    10,
+	}
+
+	_, err := s.TaskMgr.GetTasksAndMetadata(request)
+	s.Error(err)
+	_, ok := err.(*serviceerror.NotFound)
+	s.True(ok, "a task list which was never leased is not found")
+
+	leaseResp, err := s.TaskMgr.LeaseTaskList(&p.LeaseTaskListRequest{
+		NamespaceID: namespaceID,
+		TaskList:    taskList,
+		TaskType:    p.TaskListTypeActivity,
+	})
+	s.NoError(err)
+	taskID, err := s.MatchingTaskIDAllocator.AllocateTaskID()
+	s.NoError(err)
+	_, err = s.TaskMgr.CreateTasks(&p.CreateTasksRequest{
+		TaskListInfo: leaseResp.TaskListInfo,
+		Tasks: []*persistenceblobs.AllocatedTaskInfo{{
+			Data: &persistenceblobs.TaskInfo{
+				NamespaceId: namespaceID,
+				WorkflowId:  "get-tasks-and-metadata-test",
+				RunId:       primitives.MustParseUUID(uuid.New()),
+				ScheduleId:  5,
+			},
+			TaskId: taskID,
+		}},
+	})
+	s.NoError(err)
+	_, err = s.TaskMgr.UpdateTaskList(&p.UpdateTaskListRequest{
+		RangeID: leaseResp.TaskListInfo.RangeID,
+		TaskListInfo: &persistenceblobs.TaskListInfo{
+			NamespaceId: namespaceID,
+			Name:        taskList,
+			TaskType:    p.TaskListTypeActivity,
+			AckLevel:    taskID - 1,
+			Kind:        p.TaskListKindNormal,
+		},
+	})
+	s.NoError(err)
+
+	// the metadata matches the current lease
+	resp, err := s.TaskMgr.GetTasksAndMetadata(request)
+	s.NoError(err)
+	s.Equal(1, len(resp.Tasks))
+	s.Equal(taskID, resp.Tasks[0].GetTaskId())
+	s.Equal(leaseResp.TaskListInfo.RangeID, resp.TaskListInfo.RangeID)
+	s.Equal(taskID-1, resp.TaskListInfo.Data.GetAckLevel())
+
+	// another host taking the task list over is observed along with the tasks
+	newLeaseResp, err := s.TaskMgr.LeaseTaskList(&p.LeaseTaskListRequest{
+		NamespaceID: namespaceID,
+		TaskList:    taskList,
+		TaskType:    p.TaskListTypeActivity,
+	})
+	s.NoError(err)
+	resp, err = s.TaskMgr.GetTasksAndMetadata(request)
+	s.NoError(err)
+	s.Equal(1, len(resp.Tasks))
+	s.Equal(newLeaseResp.TaskListInfo.RangeID, resp.TaskListInfo.RangeID)
+	s.NotEqual(leaseResp.TaskListInfo.RangeID, resp.TaskListInfo.RangeID)
+}
+
+// TestRenewLeaseAndUpdate test
+func (s *MatchingPersistenceSuite) TestRenewLeaseAndUpdate() {
+	namespaceID := primitives.UUID(uuid.NewRandom())
+	taskList := "renew-lease-and-update-" + uuid.New()
+	leaseResp, err := s.TaskMgr.LeaseTaskList(&p.LeaseTaskListRequest{
+		NamespaceID: namespaceID,
+		TaskList:    taskList,
+		TaskType:    p.TaskListTypeActivity,
+	})
+	s.NoError(err)
+	rangeID := leaseResp.TaskListInfo.RangeID
+
+	taskListInfo := &persistenceblobs.TaskListInfo{
+		NamespaceId: namespaceID,
+		Name:        taskList,
+		TaskType:    p.TaskListTypeActivity,
+		AckLevel:    42,
+		Kind:        p.TaskListKindNormal,
+	}
+	renewResp, err := s.TaskMgr.RenewLeaseAndUpdate(&p.RenewLeaseAndUpdateRequest{
+		RangeID:      rangeID,
+		TaskListInfo: taskListInfo,
+	})
+	s.NoError(err)
+	s.Equal(rangeID+1, renewResp.TaskListInfo.RangeID)
+	s.EqualValues(42, renewResp.TaskListInfo.Data.AckLevel)
+
+	// both the range id and the ack level are persisted
+	currentRangeID, err := s.TaskMgr.GetTaskListRangeID(&p.GetTaskListRangeIDRequest{
+		NamespaceID: namespaceID,
+		TaskList:    taskList,
+		TaskType:    p.TaskListTypeActivity,
+	})
+	s.NoError(err)
+	s.Equal(rangeID+1, currentRangeID)
+	leaseResp, err = s.TaskMgr.LeaseTaskList(&p.LeaseTaskListRequest{
+		NamespaceID: namespaceID,
+		TaskList:    taskList,
+		TaskType:    p.TaskListTypeActivity,
+		RangeID:     rangeID + 1,
+	})
+	s.NoError(err)
+	s.Equal(rangeID+2, leaseResp.TaskListInfo.RangeID)
+	s.EqualValues(42, leaseResp.TaskListInfo.Data.AckLevel)
+
+	// renewing with the stale range id neither renews the lease nor updates the ack level
+	taskListInfo.AckLevel = 100
+	_, err = s.TaskMgr.RenewLeaseAndUpdate(&p.RenewLeaseAndUpdateRequest{
+		RangeID:      rangeID,
+		TaskListInfo: taskListInfo,
+	})
+	s.Error(err)
+	_, ok := err.(*p.ConditionFailedError)
+	s.True(ok)
+
+	leaseResp, err = s.TaskMgr.LeaseTaskList(&p.LeaseTaskListRequest{
+		NamespaceID: namespaceID,
+		TaskList:    taskList,
+		TaskType:    p.TaskListTypeActivity,
+		RangeID:     rangeID + 2,
+	})
+	s.NoError(err)
+	s.Equal(rangeID+3, leaseResp.TaskListInfo.RangeID)
+	s.EqualValues(42, leaseResp.TaskListInfo.Data.AckLevel)
+}
+
 // TestLeaseAndUpdateTaskList test
 func (s *MatchingPersistenceSuite) TestLeaseAndUpdateTaskList() {
 	namespaceID := primitives.MustParseUUID("00136543-72ad-4615-b7e9-44bca9775b45")
@@ -516,3 +1082,12 @@ func (s *MatchingPersistenceSuite) TestListWithMultipleTaskList() {
 	s.Nil(resp.NextPageToken)
 	s.Equal(0, len(resp.Items))
 }
+
+func (a *fixedTaskIDAllocator) AllocateTaskID() (int64, error) {
+	if len(a.taskIDs) == 0 {
+		return 0, fmt.Errorf("no task ID left to allocate")
+	}
+	taskID := a.taskIDs[0]
+	a.taskIDs = a.taskIDs[1:]
+	return taskID, nil
+}
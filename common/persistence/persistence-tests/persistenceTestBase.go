@@ -58,6 +58,11 @@ type (
 		GenerateTransferTaskID() (int64, error)
 	}
 
+	// MatchingTaskIDAllocator allocates IDs for matching tasks created by helper methods
+	MatchingTaskIDAllocator interface {
+		AllocateTaskID() (int64, error)
+	}
+
 	// TestBaseOptions options to configure workflow test base.
 	TestBaseOptions struct {
 		SQLDBPluginName string
@@ -86,6 +91,7 @@ type (
 		NamespaceReplicationQueue p.NamespaceReplicationQueue
 		ShardInfo                 *persistenceblobs.ShardInfo
 		TaskIDGenerator           TransferTaskIDGenerator
+		MatchingTaskIDAllocator   MatchingTaskIDAllocator
 		ClusterMetadata           cluster.Metadata
 		ReadLevel                 int64
 		ReplicationReadLevel      int64
@@ -105,6 +111,11 @@ type (
 	TestTransferTaskIDGenerator struct {
 		seqNum int64
 	}
+
+	// TestSequenceTaskIDAllocator allocates matching task IDs from the sequence of a TransferTaskIDGenerator
+	TestSequenceTaskIDAllocator struct {
+		generator TransferTaskIDGenerator
+	}
 )
 
 const (
@@ -231,6 +242,7 @@ func (s *TestBase) Setup() {
 	}
 
 	s.TaskIDGenerator = &TestTransferTaskIDGenerator{}
+	s.MatchingTaskIDAllocator = NewTestSequenceTaskIDAllocator(s.TaskIDGenerator)
 	err = s.ShardMgr.CreateShard(&p.CreateShardRequest{ShardInfo: s.ShardInfo})
 	s.fatalOnError("CreateShard", err)
 
@@ -1247,7 +1259,10 @@ func (s *TestBase) CreateDecisionTask(namespaceID primitives.UUID, workflowExecu
 		return 0, err
 	}
 
-	taskID := s.GetNextSequenceNumber()
+	taskID, err := s.MatchingTaskIDAllocator.AllocateTaskID()
+	if err != nil {
+		return 0, err
+	}
 	tasks := []*persistenceblobs.AllocatedTaskInfo{
 		{
 			TaskId: taskID,
@@ -1292,7 +1307,10 @@ func (s *TestBase) CreateActivityTasks(namespaceID primitives.UUID, workflowExec
 
 	var taskIDs []int64
 	for activityScheduleID, taskList := range activities {
-		taskID := s.GetNextSequenceNumber()
+		taskID, err := s.MatchingTaskIDAllocator.AllocateTaskID()
+		if err != nil {
+			return nil, err
+		}
 		tasks := []*persistenceblobs.AllocatedTaskInfo{
 			{
 				Data: &persistenceblobs.TaskInfo{
@@ -1306,7 +1324,7 @@ func (s *TestBase) CreateActivityTasks(namespaceID primitives.UUID, workflowExec
 				TaskId: taskID,
 			},
 		}
-		_, err := s.TaskMgr.CreateTasks(&p.CreateTasksRequest{
+		_, err = s.TaskMgr.CreateTasks(&p.CreateTasksRequest{
 			TaskListInfo: taskLists[taskList],
 			Tasks:        tasks,
 		})
@@ -1449,6 +1467,16 @@ func (g *TestTransferTaskIDGenerator) GenerateTransferTaskID() (int64, error) {
 	return atomic.AddInt64(&g.seqNum, 1), nil
 }
 
+// NewTestSequenceTaskIDAllocator returns a matching task ID allocator backed by the given generator
+func NewTestSequenceTaskIDAllocator(generator TransferTaskIDGenerator) *TestSequenceTaskIDAllocator {
+	return &TestSequenceTaskIDAllocator{generator: generator}
+}
+
+// AllocateTaskID helper
+func (a *TestSequenceTaskIDAllocator) AllocateTaskID() (int64, error) {
+	return a.generator.GenerateTransferTaskID()
+}
+
 // Publish is a utility method to add messages to the queue
 func (s *TestBase) Publish(
 	message interface{},
@@ -1521,6 +1549,7 @@ func (s *TestBase) GetMessagesFromNamespaceDLQ(
 	lastMessageID int,
 	pageSize int,
 	pageToken []byte,
+	namespaceID string,
 ) ([]*replicationgenpb.ReplicationTask, []byte, error) {
 
 	return s.NamespaceReplicationQueue.GetMessagesFromDLQ(
@@ -1528,9 +1557,18 @@ func (s *TestBase) GetMessagesFromNamespaceDLQ(
 		lastMessageID,
 		pageSize,
 		pageToken,
+		namespaceID,
 	)
 }
 
+// GetMessageFromNamespaceDLQ is a utility method to get one message from the namespace DLQ
+func (s *TestBase) GetMessageFromNamespaceDLQ(
+	messageID int,
+) (*replicationgenpb.ReplicationTask, error) {
+
+	return s.NamespaceReplicationQueue.GetMessageFromDLQ(messageID)
+}
+
 // UpdateNamespaceDLQAckLevel updates namespace dlq ack level
 func (s *TestBase) UpdateNamespaceDLQAckLevel(
 	lastProcessedMessageID int,
@@ -1556,9 +1594,10 @@ func (s *TestBase) DeleteMessageFromNamespaceDLQ(
 func (s *TestBase) RangeDeleteMessagesFromNamespaceDLQ(
 	firstMessageID int,
 	lastMessageID int,
+	namespaceID string,
 ) error {
 
-	return s.NamespaceReplicationQueue.RangeDeleteMessagesFromDLQ(firstMessageID, lastMessageID)
+	return s.NamespaceReplicationQueue.RangeDeleteMessagesFromDLQ(firstMessageID, lastMessageID, namespaceID)
 }
 
 // GenerateTransferTaskIDs helper
@@ -22,12 +22,14 @@ package persistencetests
 
 import (
 	"fmt"
+	"math"
 	"os"
 	"sync"
 	"testing"
 
 	log "github.com/sirupsen/logrus"
 	"github.com/stretchr/testify/require"
+	"go.temporal.io/temporal-proto/serviceerror"
 
 	replicationgenpb "github.com/temporalio/temporal/.gen/proto/replication"
 )
@@ -172,10 +174,10 @@ func (s *QueuePersistenceSuite) TestNamespaceReplicationDLQ() {
 
 	wg.Wait()
 
-	result1, token, err := s.GetMessagesFromNamespaceDLQ(-1, numMessages, numMessages/2, nil)
+	result1, token, err := s.GetMessagesFromNamespaceDLQ(-1, numMessages, numMessages/2, nil, "")
 	s.Nil(err, "GetReplicationMessages failed.")
 	s.NotNil(token)
-	result2, token, err := s.GetMessagesFromNamespaceDLQ(-1, numMessages, numMessages, token)
+	result2, token, err := s.GetMessagesFromNamespaceDLQ(-1, numMessages, numMessages, token, "")
 	s.Nil(err, "GetReplicationMessages failed.")
 	s.Equal(len(token), 0)
 	s.Equal(len(result1)+len(result2), numMessages)
@@ -183,19 +185,96 @@ func (s *QueuePersistenceSuite) TestNamespaceReplicationDLQ() {
 	lastMessageID := result2[len(result2)-1].SourceTaskId
 	err = s.DeleteMessageFromNamespaceDLQ(int(lastMessageID))
 	s.NoError(err)
-	result3, token, err := s.GetMessagesFromNamespaceDLQ(-1, numMessages, numMessages, token)
+	result3, token, err := s.GetMessagesFromNamespaceDLQ(-1, numMessages, numMessages, token, "")
 	s.Nil(err, "GetReplicationMessages failed.")
 	s.Equal(len(token), 0)
 	s.Equal(len(result3), numMessages-1)
 
-	err = s.RangeDeleteMessagesFromNamespaceDLQ(-1, int(lastMessageID))
+	err = s.RangeDeleteMessagesFromNamespaceDLQ(-1, int(lastMessageID), "")
 	s.NoError(err)
-	result4, token, err := s.GetMessagesFromNamespaceDLQ(-1, numMessages, numMessages, token)
+	result4, token, err := s.GetMessagesFromNamespaceDLQ(-1, numMessages, numMessages, token, "")
 	s.Nil(err, "GetReplicationMessages failed.")
 	s.Equal(len(token), 0)
 	s.Equal(len(result4), 0)
 }
 
+// TestNamespaceDLQFilterByNamespace tests reading and purging the namespace DLQ for a single namespace
+func (s *QueuePersistenceSuite) TestNamespaceDLQFilterByNamespace() {
+	err := s.RangeDeleteMessagesFromNamespaceDLQ(-1, math.MaxInt32, "")
+	s.NoError(err)
+
+	namespaceIDs := []string{"namespace-1", "namespace-2"}
+	numMessagesPerNamespace := 5
+	for i := 0; i < numMessagesPerNamespace; i++ {
+		for _, namespaceID := range namespaceIDs {
+			err = s.PublishToNamespaceDLQ(&replicationgenpb.ReplicationTask{
+				TaskType: replicationgenpb.ReplicationTaskTypeNamespace,
+				Attributes: &replicationgenpb.ReplicationTask_NamespaceTaskAttributes{
+					NamespaceTaskAttributes: &replicationgenpb.NamespaceTaskAttributes{
+						Id: namespaceID,
+					},
+				},
+			})
+			s.NoError(err)
+		}
+	}
+
+	result, token, err := s.GetMessagesFromNamespaceDLQ(-1, math.MaxInt32, 100, nil, namespaceIDs[0])
+	s.NoError(err)
+	s.Empty(token)
+	s.Len(result, numMessagesPerNamespace)
+	for _, task := range result {
+		s.Equal(namespaceIDs[0], task.GetNamespaceTaskAttributes().GetId())
+	}
+
+	err = s.RangeDeleteMessagesFromNamespaceDLQ(-1, math.MaxInt32, namespaceIDs[0])
+	s.NoError(err)
+
+	result, _, err = s.GetMessagesFromNamespaceDLQ(-1, math.MaxInt32, 100, nil, namespaceIDs[0])
+	s.NoError(err)
+	s.Empty(result)
+	result, _, err = s.GetMessagesFromNamespaceDLQ(-1, math.MaxInt32, 100, nil, "")
+	s.NoError(err)
+	s.Len(result, numMessagesPerNamespace)
+	for _, task := range result {
+		s.Equal(namespaceIDs[1], task.GetNamespaceTaskAttributes().GetId())
+	}
+}
+
+// TestNamespaceDLQGetMessage tests reading a single message of the namespace DLQ by its ID
+func (s *QueuePersistenceSuite) TestNamespaceDLQGetMessage() {
+	err := s.RangeDeleteMessagesFromNamespaceDLQ(-1, math.MaxInt32, "")
+	s.NoError(err)
+
+	for i := 0; i < 3; i++ {
+		err = s.PublishToNamespaceDLQ(&replicationgenpb.ReplicationTask{
+			TaskType: replicationgenpb.ReplicationTaskTypeNamespace,
+			Attributes: &replicationgenpb.ReplicationTask_NamespaceTaskAttributes{
+				NamespaceTaskAttributes: &replicationgenpb.NamespaceTaskAttributes{
+					Id: fmt.Sprintf("message-%v", i),
+				},
+			},
+		})
+		s.NoError(err)
+	}
+	result, _, err := s.GetMessagesFromNamespaceDLQ(-1, math.MaxInt32, 100, nil, "")
+	s.NoError(err)
+	s.Len(result, 3)
+
+	messageID := int(result[1].GetSourceTaskId())
+	message, err := s.GetMessageFromNamespaceDLQ(messageID)
+	s.NoError(err)
+	s.Equal(result[1], message)
+
+	err = s.DeleteMessageFromNamespaceDLQ(messageID)
+	s.NoError(err)
+	_, err = s.GetMessageFromNamespaceDLQ(messageID)
+	s.IsType(&serviceerror.NotFound{}, err)
+	result, _, err = s.GetMessagesFromNamespaceDLQ(-1, math.MaxInt32, 100, nil, "")
+	s.NoError(err)
+	s.Len(result, 2)
+}
+
 // TestNamespaceDLQMetadataOperations tests queue metadata operations
 func (s *QueuePersistenceSuite) TestNamespaceDLQMetadataOperations() {
 	ackLevel, err := s.GetNamespaceDLQAckLevel()
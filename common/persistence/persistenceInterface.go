@@ -244,6 +244,9 @@ type (
 		ClientFeatureVersion               string
 		ClientImpl                         string
 		AutoResetPoints                    *serialization.DataBlob
+		CancelGracePeriodExpiryTime        time.Time
+		CancelGracePeriodCompletedEventID  int64
+		CancelGracePeriodDetails           []byte
 		// for retry
 		Attempt            int32
 		HasRetryPolicy     bool
@@ -258,6 +261,8 @@ type (
 		ExpirationSeconds  int32
 		Memo               map[string][]byte
 		SearchAttributes   map[string][]byte
+		MarkerChecksums    map[string][]byte
+		SignalControlIDs   map[string]*persistenceblobs.SignalControlInfo
 
 		// attributes which are not related to mutable state at all
 		HistorySize int64
@@ -795,12 +800,20 @@ func InternalWorkflowExecutionInfoToProto(executionInfo *InternalWorkflowExecuti
 		AutoResetPointsEncoding:                 executionInfo.AutoResetPoints.GetEncoding().String(),
 		SearchAttributes:                        executionInfo.SearchAttributes,
 		Memo:                                    executionInfo.Memo,
+		MarkerChecksums:                         executionInfo.MarkerChecksums,
+		SignalControlIds:                        executionInfo.SignalControlIDs,
+		CancelGracePeriodCompletedEventId:       executionInfo.CancelGracePeriodCompletedEventID,
+		CancelGracePeriodDetails:                executionInfo.CancelGracePeriodDetails,
 	}
 
 	if !executionInfo.ExpirationTime.IsZero() {
 		info.RetryExpirationTimeNanos = executionInfo.ExpirationTime.UnixNano()
 	}
 
+	if !executionInfo.CancelGracePeriodExpiryTime.IsZero() {
+		info.CancelGracePeriodExpiryTimeNanos = executionInfo.CancelGracePeriodExpiryTime.UnixNano()
+	}
+
 	completionEvent := executionInfo.CompletionEvent
 	if completionEvent != nil {
 		info.CompletionEvent = completionEvent.Data
@@ -883,12 +896,20 @@ func ProtoWorkflowExecutionToPartialInternalExecution(info *persistenceblobs.Wor
 		NonRetriableErrors:                 info.GetRetryNonRetryableErrors(),
 		SearchAttributes:                   info.GetSearchAttributes(),
 		Memo:                               info.GetMemo(),
+		MarkerChecksums:                    info.GetMarkerChecksums(),
+		SignalControlIDs:                   info.GetSignalControlIds(),
+		CancelGracePeriodCompletedEventID:  info.GetCancelGracePeriodCompletedEventId(),
+		CancelGracePeriodDetails:           info.GetCancelGracePeriodDetails(),
 	}
 
 	if info.GetRetryExpirationTimeNanos() != 0 {
 		executionInfo.ExpirationTime = time.Unix(0, info.GetRetryExpirationTimeNanos())
 	}
 
+	if info.GetCancelGracePeriodExpiryTimeNanos() != 0 {
+		executionInfo.CancelGracePeriodExpiryTime = time.Unix(0, info.GetCancelGracePeriodExpiryTimeNanos())
+	}
+
 	if info.ParentNamespaceId != nil {
 		executionInfo.ParentNamespaceID = primitives.UUID(info.ParentNamespaceId).String()
 		executionInfo.ParentWorkflowID = info.GetParentWorkflowId()
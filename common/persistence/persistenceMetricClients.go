@@ -21,6 +21,8 @@
 package persistence
 
 import (
+	"time"
+
 	"go.temporal.io/temporal-proto/serviceerror"
 
 	"github.com/temporalio/temporal/common/log"
@@ -583,6 +585,10 @@ func (p *taskPersistenceClient) GetName() string {
 	return p.persistence.GetName()
 }
 
+func (p *taskPersistenceClient) GetTimePrecision() time.Duration {
+	return p.persistence.GetTimePrecision()
+}
+
 func (p *taskPersistenceClient) CreateTasks(request *CreateTasksRequest) (*CreateTasksResponse, error) {
 	p.metricClient.IncCounter(metrics.PersistenceCreateTaskScope, metrics.PersistenceRequests)
 
@@ -611,6 +617,17 @@ func (p *taskPersistenceClient) GetTasks(request *GetTasksRequest) (*GetTasksRes
 	return response, err
 }
 
+func (p *taskPersistenceClient) GetTask(request *GetTaskRequest) (*GetTaskResponse, error) {
+	p.metricClient.IncCounter(metrics.PersistenceGetTaskScope, metrics.PersistenceRequests)
+	sw := p.metricClient.StartTimer(metrics.PersistenceGetTaskScope, metrics.PersistenceLatency)
+	response, err := p.persistence.GetTask(request)
+	sw.Stop()
+	if err != nil {
+		p.updateErrorMetric(metrics.PersistenceGetTaskScope, err)
+	}
+	return response, err
+}
+
 func (p *taskPersistenceClient) CompleteTask(request *CompleteTaskRequest) error {
 	p.metricClient.IncCounter(metrics.PersistenceCompleteTaskScope, metrics.PersistenceRequests)
 
@@ -636,6 +653,72 @@ func (p *taskPersistenceClient) CompleteTasksLessThan(request *CompleteTasksLess
 	return result, err
 }
 
+func (p *taskPersistenceClient) MoveTasks(request *MoveTasksRequest) (int, error) {
+	p.metricClient.IncCounter(metrics.PersistenceMoveTasksScope, metrics.PersistenceRequests)
+	sw := p.metricClient.StartTimer(metrics.PersistenceMoveTasksScope, metrics.PersistenceLatency)
+	result, err := p.persistence.MoveTasks(request)
+	sw.Stop()
+	if err != nil {
+		p.updateErrorMetric(metrics.PersistenceMoveTasksScope, err)
+	}
+	return result, err
+}
+
+func (p *taskPersistenceClient) PurgeExpiredTasks(request *PurgeExpiredTasksRequest) (int, error) {
+	p.metricClient.IncCounter(metrics.PersistencePurgeExpiredTasksScope, metrics.PersistenceRequests)
+	sw := p.metricClient.StartTimer(metrics.PersistencePurgeExpiredTasksScope, metrics.PersistenceLatency)
+	result, err := p.persistence.PurgeExpiredTasks(request)
+	sw.Stop()
+	if err != nil {
+		p.updateErrorMetric(metrics.PersistencePurgeExpiredTasksScope, err)
+	}
+	return result, err
+}
+
+func (p *taskPersistenceClient) EstimateBacklogCount(request *EstimateBacklogCountRequest) (int64, error) {
+	p.metricClient.IncCounter(metrics.PersistenceEstimateBacklogCountScope, metrics.PersistenceRequests)
+	sw := p.metricClient.StartTimer(metrics.PersistenceEstimateBacklogCountScope, metrics.PersistenceLatency)
+	result, err := p.persistence.EstimateBacklogCount(request)
+	sw.Stop()
+	if err != nil {
+		p.updateErrorMetric(metrics.PersistenceEstimateBacklogCountScope, err)
+	}
+	return result, err
+}
+
+func (p *taskPersistenceClient) GetTaskListRangeID(request *GetTaskListRangeIDRequest) (int64, error) {
+	p.metricClient.IncCounter(metrics.PersistenceGetTaskListRangeIDScope, metrics.PersistenceRequests)
+	sw := p.metricClient.StartTimer(metrics.PersistenceGetTaskListRangeIDScope, metrics.PersistenceLatency)
+	result, err := p.persistence.GetTaskListRangeID(request)
+	sw.Stop()
+	if err != nil {
+		p.updateErrorMetric(metrics.PersistenceGetTaskListRangeIDScope, err)
+	}
+	return result, err
+}
+
+func (p *taskPersistenceClient) RenewLeaseAndUpdate(request *RenewLeaseAndUpdateRequest) (*RenewLeaseAndUpdateResponse, error) {
+	p.metricClient.IncCounter(metrics.PersistenceRenewLeaseAndUpdateScope, metrics.PersistenceRequests)
+	sw := p.metricClient.StartTimer(metrics.PersistenceRenewLeaseAndUpdateScope, metrics.PersistenceLatency)
+	response, err := p.persistence.RenewLeaseAndUpdate(request)
+	sw.Stop()
+	if err != nil {
+		p.updateErrorMetric(metrics.PersistenceRenewLeaseAndUpdateScope, err)
+	}
+	return response, err
+}
+
+func (p *taskPersistenceClient) GetTasksAndMetadata(request *GetTasksAndMetadataRequest) (*GetTasksAndMetadataResponse, error) {
+	p.metricClient.IncCounter(metrics.PersistenceGetTasksAndMetadataScope, metrics.PersistenceRequests)
+	sw := p.metricClient.StartTimer(metrics.PersistenceGetTasksAndMetadataScope, metrics.PersistenceLatency)
+	response, err := p.persistence.GetTasksAndMetadata(request)
+	sw.Stop()
+	if err != nil {
+		p.updateErrorMetric(metrics.PersistenceGetTasksAndMetadataScope, err)
+	}
+	return response, err
+}
+
 func (p *taskPersistenceClient) LeaseTaskList(request *LeaseTaskListRequest) (*LeaseTaskListResponse, error) {
 	p.metricClient.IncCounter(metrics.PersistenceLeaseTaskListScope, metrics.PersistenceRequests)
 
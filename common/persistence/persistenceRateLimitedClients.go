@@ -21,6 +21,8 @@
 package persistence
 
 import (
+	"time"
+
 	"go.temporal.io/temporal-proto/serviceerror"
 
 	"github.com/temporalio/temporal/common/log"
@@ -418,6 +420,10 @@ func (p *taskRateLimitedPersistenceClient) GetName() string {
 	return p.persistence.GetName()
 }
 
+func (p *taskRateLimitedPersistenceClient) GetTimePrecision() time.Duration {
+	return p.persistence.GetTimePrecision()
+}
+
 func (p *taskRateLimitedPersistenceClient) CreateTasks(request *CreateTasksRequest) (*CreateTasksResponse, error) {
 	if ok := p.rateLimiter.Allow(); !ok {
 		return nil, ErrPersistenceLimitExceeded
@@ -436,6 +442,13 @@ func (p *taskRateLimitedPersistenceClient) GetTasks(request *GetTasksRequest) (*
 	return response, err
 }
 
+func (p *taskRateLimitedPersistenceClient) GetTask(request *GetTaskRequest) (*GetTaskResponse, error) {
+	if ok := p.rateLimiter.Allow(); !ok {
+		return nil, ErrPersistenceLimitExceeded
+	}
+	return p.persistence.GetTask(request)
+}
+
 func (p *taskRateLimitedPersistenceClient) CompleteTask(request *CompleteTaskRequest) error {
 	if ok := p.rateLimiter.Allow(); !ok {
 		return ErrPersistenceLimitExceeded
@@ -452,6 +465,48 @@ func (p *taskRateLimitedPersistenceClient) CompleteTasksLessThan(request *Comple
 	return p.persistence.CompleteTasksLessThan(request)
 }
 
+func (p *taskRateLimitedPersistenceClient) MoveTasks(request *MoveTasksRequest) (int, error) {
+	if ok := p.rateLimiter.Allow(); !ok {
+		return 0, ErrPersistenceLimitExceeded
+	}
+	return p.persistence.MoveTasks(request)
+}
+
+func (p *taskRateLimitedPersistenceClient) PurgeExpiredTasks(request *PurgeExpiredTasksRequest) (int, error) {
+	if ok := p.rateLimiter.Allow(); !ok {
+		return 0, ErrPersistenceLimitExceeded
+	}
+	return p.persistence.PurgeExpiredTasks(request)
+}
+
+func (p *taskRateLimitedPersistenceClient) EstimateBacklogCount(request *EstimateBacklogCountRequest) (int64, error) {
+	if ok := p.rateLimiter.Allow(); !ok {
+		return 0, ErrPersistenceLimitExceeded
+	}
+	return p.persistence.EstimateBacklogCount(request)
+}
+
+func (p *taskRateLimitedPersistenceClient) GetTaskListRangeID(request *GetTaskListRangeIDRequest) (int64, error) {
+	if ok := p.rateLimiter.Allow(); !ok {
+		return 0, ErrPersistenceLimitExceeded
+	}
+	return p.persistence.GetTaskListRangeID(request)
+}
+
+func (p *taskRateLimitedPersistenceClient) RenewLeaseAndUpdate(request *RenewLeaseAndUpdateRequest) (*RenewLeaseAndUpdateResponse, error) {
+	if ok := p.rateLimiter.Allow(); !ok {
+		return nil, ErrPersistenceLimitExceeded
+	}
+	return p.persistence.RenewLeaseAndUpdate(request)
+}
+
+func (p *taskRateLimitedPersistenceClient) GetTasksAndMetadata(request *GetTasksAndMetadataRequest) (*GetTasksAndMetadataResponse, error) {
+	if ok := p.rateLimiter.Allow(); !ok {
+		return nil, ErrPersistenceLimitExceeded
+	}
+	return p.persistence.GetTasksAndMetadata(request)
+}
+
 func (p *taskRateLimitedPersistenceClient) LeaseTaskList(request *LeaseTaskListRequest) (*LeaseTaskListResponse, error) {
 	if ok := p.rateLimiter.Allow(); !ok {
 		return nil, ErrPersistenceLimitExceeded
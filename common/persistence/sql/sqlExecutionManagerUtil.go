@@ -803,6 +803,11 @@ func createReplicationTasks(
 			return serviceerror.NewInternal(fmt.Sprintf("Unknown replication task: %v", task.GetType()))
 		}
 
+		visibilityTimestamp, err := types.TimestampProto(task.GetVisibilityTimestamp().UTC())
+		if err != nil {
+			return err
+		}
+
 		blob, err := serialization.ReplicationTaskInfoToBlob(&persistenceblobs.ReplicationTaskInfo{
 			TaskId:                  task.GetTaskID(),
 			NamespaceId:             namespaceID,
@@ -819,6 +824,7 @@ func createReplicationTasks(
 			BranchToken:             branchToken,
 			NewRunBranchToken:       newRunBranchToken,
 			ResetWorkflow:           resetWorkflow,
+			VisibilityTimestamp:     visibilityTimestamp,
 		})
 		if err != nil {
 			return err
@@ -885,6 +891,9 @@ func createTimerTasks(
 			case *p.WorkflowTimeoutTask:
 				// noop
 
+			case *p.CancellationGracePeriodTask:
+				// noop
+
 			case *p.DeleteHistoryEventTask:
 				// noop
 
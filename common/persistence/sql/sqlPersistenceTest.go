@@ -25,6 +25,7 @@ import (
 	"io/ioutil"
 	"os"
 	"strings"
+	"time"
 
 	log "github.com/sirupsen/logrus"
 
@@ -53,13 +54,14 @@ func NewTestCluster(pluginName, dbName, username, password, host string, port in
 	}
 	result.schemaDir = schemaDir
 	result.cfg = config.SQL{
-		User:            username,
-		Password:        password,
-		ConnectAddr:     fmt.Sprintf("%v:%v", host, port),
-		ConnectProtocol: "tcp",
-		PluginName:      pluginName,
-		DatabaseName:    dbName,
-		NumShards:       4,
+		User:              username,
+		Password:          password,
+		ConnectAddr:       fmt.Sprintf("%v:%v", host, port),
+		ConnectProtocol:   "tcp",
+		PluginName:        pluginName,
+		DatabaseName:      dbName,
+		NumShards:         4,
+		TaskTimePrecision: time.Microsecond,
 	}
 	return &result
 }
@@ -41,24 +41,41 @@ import (
 
 type sqlTaskManager struct {
 	sqlStore
-	nShards int
+	nShards       int
+	timePrecision time.Duration
 }
 
 var (
 	minUUID = "00000000-0000-0000-0000-000000000000"
 )
 
+const (
+	defaultTaskTimePrecision = time.Millisecond
+	finestTaskTimePrecision  = time.Microsecond
+)
+
 // newTaskPersistence creates a new instance of TaskManager
-func newTaskPersistence(db sqlplugin.DB, nShards int, log log.Logger) (persistence.TaskManager, error) {
+func newTaskPersistence(db sqlplugin.DB, nShards int, timePrecision time.Duration, log log.Logger) (persistence.TaskManager, error) {
+	switch {
+	case timePrecision <= 0:
+		timePrecision = defaultTaskTimePrecision
+	case timePrecision < finestTaskTimePrecision:
+		timePrecision = finestTaskTimePrecision
+	}
 	return &sqlTaskManager{
 		sqlStore: sqlStore{
 			db:     db,
 			logger: log,
 		},
-		nShards: nShards,
+		nShards:       nShards,
+		timePrecision: timePrecision,
 	}, nil
 }
 
+func (m *sqlTaskManager) GetTimePrecision() time.Duration {
+	return m.timePrecision
+}
+
 func (m *sqlTaskManager) LeaseTaskList(request *persistence.LeaseTaskListRequest) (*persistence.LeaseTaskListResponse, error) {
 	var rangeID int64
 	var ackLevel int64
@@ -322,6 +339,10 @@ func (m *sqlTaskManager) DeleteTaskList(request *persistence.DeleteTaskListReque
 func (m *sqlTaskManager) CreateTasks(request *persistence.CreateTasksRequest) (*persistence.CreateTasksResponse, error) {
 	tasksRows := make([]sqlplugin.TasksRow, len(request.Tasks))
 	for i, v := range request.Tasks {
+		v, err := persistence.TruncateTaskTimes(v, m.timePrecision)
+		if err != nil {
+			return nil, err
+		}
 		blob, err := serialization.TaskInfoToBlob(v)
 
 		if err != nil {
@@ -383,6 +404,32 @@ func (m *sqlTaskManager) GetTasks(request *persistence.GetTasksRequest) (*persis
 	return &persistence.GetTasksResponse{Tasks: tasks}, nil
 }
 
+func (m *sqlTaskManager) GetTask(request *persistence.GetTaskRequest) (*persistence.GetTaskResponse, error) {
+	// the task id range is exclusive of its lower bound, so this selects exactly the requested task id
+	minTaskID := request.TaskID - 1
+	pageSize := 1
+	rows, err := m.db.SelectFromTasks(&sqlplugin.TasksFilter{
+		NamespaceID:  request.NamespaceID,
+		TaskListName: request.TaskList,
+		TaskType:     int64(request.TaskType),
+		MinTaskID:    &minTaskID,
+		MaxTaskID:    &request.TaskID,
+		PageSize:     &pageSize,
+	})
+	if err == sql.ErrNoRows || (err == nil && len(rows) == 0) {
+		return nil, serviceerror.NewNotFound(fmt.Sprintf("Task not found. TaskList: %v, TaskType: %v, TaskID: %v", request.TaskList, request.TaskType, request.TaskID))
+	}
+	if err != nil {
+		return nil, serviceerror.NewInternal(fmt.Sprintf("GetTask operation failed. Failed to get rows. Error: %v", err))
+	}
+
+	info, err := serialization.TaskInfoFromBlob(rows[0].Data, rows[0].DataEncoding)
+	if err != nil {
+		return nil, err
+	}
+	return &persistence.GetTaskResponse{Task: info}, nil
+}
+
 func (m *sqlTaskManager) CompleteTask(request *persistence.CompleteTaskRequest) error {
 	taskID := request.TaskID
 	taskList := request.TaskList
@@ -415,6 +462,293 @@ func (m *sqlTaskManager) CompleteTasksLessThan(request *persistence.CompleteTask
 	return int(nRows), nil
 }
 
+func (m *sqlTaskManager) MoveTasks(request *persistence.MoveTasksRequest) (int, error) {
+	src := request.SourceTaskList
+	dst := request.DestinationTaskList.Data
+	var nMoved int
+	err := m.txExecute("MoveTasks", func(tx sqlplugin.Tx) error {
+		minTaskID := int64(-1)
+		limit := len(request.TaskIDs)
+		rows, err := tx.SelectFromTasks(&sqlplugin.TasksFilter{
+			NamespaceID:  src.NamespaceID,
+			TaskListName: src.Name,
+			TaskType:     int64(src.TaskType),
+			MinTaskID:    &minTaskID,
+			PageSize:     &limit,
+		})
+		if err != nil {
+			return err
+		}
+		if len(rows) == 0 {
+			return nil
+		}
+		tasksRows := make([]sqlplugin.TasksRow, len(rows))
+		for i, row := range rows {
+			tasksRows[i] = sqlplugin.TasksRow{
+				NamespaceID:  dst.GetNamespaceId(),
+				TaskListName: dst.Name,
+				TaskType:     int64(dst.TaskType),
+				TaskID:       request.TaskIDs[i],
+				Data:         row.Data,
+				DataEncoding: row.DataEncoding,
+			}
+		}
+		if _, err := tx.InsertIntoTasks(tasksRows); err != nil {
+			return err
+		}
+		maxTaskID := rows[len(rows)-1].TaskID
+		nRows := len(rows)
+		if _, err := tx.DeleteFromTasks(&sqlplugin.TasksFilter{
+			NamespaceID:          src.NamespaceID,
+			TaskListName:         src.Name,
+			TaskType:             int64(src.TaskType),
+			TaskIDLessThanEquals: &maxTaskID,
+			Limit:                &nRows,
+		}); err != nil {
+			return err
+		}
+		// Lock destination task list before committing.
+		if err := lockTaskList(tx,
+			m.shardID(dst.GetNamespaceId(), dst.Name),
+			dst.GetNamespaceId(),
+			dst.Name,
+			dst.TaskType,
+			request.DestinationTaskList.RangeID); err != nil {
+			return err
+		}
+		nMoved = len(rows)
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+	return nMoved, nil
+}
+
+func (m *sqlTaskManager) PurgeExpiredTasks(request *persistence.PurgeExpiredTasksRequest) (int, error) {
+	if request.BatchSize <= 0 {
+		return 0, serviceerror.NewInvalidArgument("PurgeExpiredTasks operation failed. BatchSize must be positive.")
+	}
+
+	// the expiry is only part of the task blob, so expired tasks are found by reading the tasks
+	// of every task list, which are listed from the task_lists table
+	now := time.Now()
+	purged := 0
+	var pageToken []byte
+	for {
+		resp, err := m.ListTaskList(&persistence.ListTaskListRequest{
+			PageSize:  request.BatchSize,
+			PageToken: pageToken,
+		})
+		if err != nil {
+			return purged, err
+		}
+		for _, item := range resp.Items {
+			n, err := m.purgeExpiredTasksOfTaskList(item.Data, request.BatchSize, now)
+			purged += n
+			if err != nil {
+				return purged, err
+			}
+		}
+		if len(resp.NextPageToken) == 0 {
+			return purged, nil
+		}
+		pageToken = resp.NextPageToken
+	}
+}
+
+func (m *sqlTaskManager) purgeExpiredTasksOfTaskList(
+	taskList *persistenceblobs.TaskListInfo,
+	batchSize int,
+	now time.Time,
+) (int, error) {
+	purged := 0
+	minTaskID := int64(-1)
+	for {
+		rows, err := m.db.SelectFromTasks(&sqlplugin.TasksFilter{
+			NamespaceID:  taskList.GetNamespaceId(),
+			TaskListName: taskList.GetName(),
+			TaskType:     int64(taskList.GetTaskType()),
+			MinTaskID:    &minTaskID,
+			PageSize:     &batchSize,
+		})
+		if err != nil {
+			return purged, serviceerror.NewInternal(fmt.Sprintf("PurgeExpiredTasks operation failed. Failed to get rows. Error: %v", err))
+		}
+		for _, row := range rows {
+			minTaskID = row.TaskID
+			info, err := serialization.TaskInfoFromBlob(row.Data, row.DataEncoding)
+			if err != nil {
+				return purged, err
+			}
+			if info.Data.GetExpiry() == nil {
+				continue
+			}
+			expiry, err := types.TimestampFromProto(info.Data.GetExpiry())
+			if err != nil || !expiry.Before(now) {
+				continue
+			}
+
+			taskID := row.TaskID
+			result, err := m.db.DeleteFromTasks(&sqlplugin.TasksFilter{
+				NamespaceID:  taskList.GetNamespaceId(),
+				TaskListName: taskList.GetName(),
+				TaskType:     int64(taskList.GetTaskType()),
+				TaskID:       &taskID,
+			})
+			if err != nil {
+				return purged, serviceerror.NewInternal(err.Error())
+			}
+			nRows, err := result.RowsAffected()
+			if err != nil {
+				return purged, serviceerror.NewInternal(fmt.Sprintf("rowsAffected returned error: %v", err))
+			}
+			purged += int(nRows)
+		}
+		if len(rows) < batchSize {
+			return purged, nil
+		}
+	}
+}
+
+func (m *sqlTaskManager) EstimateBacklogCount(request *persistence.EstimateBacklogCountRequest) (int64, error) {
+	namespaceID := request.NamespaceID
+	rows, err := m.db.SelectFromTaskLists(&sqlplugin.TaskListsFilter{
+		ShardID:     m.shardID(namespaceID, request.TaskList),
+		NamespaceID: &namespaceID,
+		Name:        &request.TaskList,
+		TaskType:    common.Int64Ptr(int64(request.TaskType))})
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return 0, nil
+		}
+		return 0, serviceerror.NewInternal(fmt.Sprintf("EstimateBacklogCount operation failed. Failed to get task list. Error: %v", err))
+	}
+	tlInfo, err := serialization.TaskListInfoFromBlob(rows[0].Data, rows[0].DataEncoding)
+	if err != nil {
+		return 0, err
+	}
+
+	maxTaskID, err := m.db.SelectMaxTaskIDFromTasks(&sqlplugin.TasksFilter{
+		NamespaceID:  namespaceID,
+		TaskListName: request.TaskList,
+		TaskType:     int64(request.TaskType),
+	})
+	if err != nil {
+		return 0, serviceerror.NewInternal(fmt.Sprintf("EstimateBacklogCount operation failed. Failed to get max task id. Error: %v", err))
+	}
+	return backlogCountEstimate(maxTaskID, tlInfo.GetAckLevel()), nil
+}
+
+func (m *sqlTaskManager) GetTaskListRangeID(request *persistence.GetTaskListRangeIDRequest) (int64, error) {
+	namespaceID := request.NamespaceID
+	rows, err := m.db.SelectFromTaskLists(&sqlplugin.TaskListsFilter{
+		ShardID:     m.shardID(namespaceID, request.TaskList),
+		NamespaceID: &namespaceID,
+		Name:        &request.TaskList,
+		TaskType:    common.Int64Ptr(int64(request.TaskType))})
+	if err == sql.ErrNoRows || (err == nil && len(rows) == 0) {
+		return 0, serviceerror.NewNotFound(fmt.Sprintf("Task list not found. TaskList: %v, TaskType: %v", request.TaskList, request.TaskType))
+	}
+	if err != nil {
+		return 0, serviceerror.NewInternal(fmt.Sprintf("GetTaskListRangeID operation failed. Failed to get task list. Error: %v", err))
+	}
+	return rows[0].RangeID, nil
+}
+
+func (m *sqlTaskManager) GetTasksAndMetadata(request *persistence.GetTasksAndMetadataRequest) (*persistence.GetTasksAndMetadataResponse, error) {
+	tasksResp, err := m.GetTasks(&persistence.GetTasksRequest{
+		NamespaceID:  request.NamespaceID,
+		TaskList:     request.TaskList,
+		TaskType:     request.TaskType,
+		ReadLevel:    request.ReadLevel,
+		MaxReadLevel: &request.MaxReadLevel,
+		BatchSize:    request.BatchSize,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// the task list is read after the tasks, so the range id returned was current when the tasks were read
+	namespaceID := request.NamespaceID
+	rows, err := m.db.SelectFromTaskLists(&sqlplugin.TaskListsFilter{
+		ShardID:     m.shardID(namespaceID, request.TaskList),
+		NamespaceID: &namespaceID,
+		Name:        &request.TaskList,
+		TaskType:    common.Int64Ptr(int64(request.TaskType))})
+	if err == sql.ErrNoRows || (err == nil && len(rows) == 0) {
+		return nil, serviceerror.NewNotFound(fmt.Sprintf("Task list not found. TaskList: %v, TaskType: %v", request.TaskList, request.TaskType))
+	}
+	if err != nil {
+		return nil, serviceerror.NewInternal(fmt.Sprintf("GetTasksAndMetadata operation failed. Failed to get task list. Error: %v", err))
+	}
+	tlInfo, err := serialization.TaskListInfoFromBlob(rows[0].Data, rows[0].DataEncoding)
+	if err != nil {
+		return nil, err
+	}
+
+	return &persistence.GetTasksAndMetadataResponse{
+		Tasks: tasksResp.Tasks,
+		TaskListInfo: &persistence.PersistedTaskListInfo{
+			Data:    tlInfo,
+			RangeID: rows[0].RangeID,
+		},
+	}, nil
+}
+
+func (m *sqlTaskManager) RenewLeaseAndUpdate(request *persistence.RenewLeaseAndUpdateRequest) (*persistence.RenewLeaseAndUpdateResponse, error) {
+	namespaceID := request.TaskListInfo.GetNamespaceId()
+	shardID := m.shardID(namespaceID, request.TaskListInfo.Name)
+
+	tl := *request.TaskListInfo
+	tl.LastUpdated = types.TimestampNow()
+	blob, err := serialization.TaskListInfoToBlob(&tl)
+	if err != nil {
+		return nil, err
+	}
+
+	var resp *persistence.RenewLeaseAndUpdateResponse
+	err = m.txExecute("RenewLeaseAndUpdate", func(tx sqlplugin.Tx) error {
+		err1 := lockTaskList(tx, shardID, namespaceID, tl.Name, tl.TaskType, request.RangeID)
+		if err1 != nil {
+			return err1
+		}
+		result, err1 := tx.UpdateTaskLists(&sqlplugin.TaskListsRow{
+			ShardID:      shardID,
+			NamespaceID:  namespaceID,
+			RangeID:      request.RangeID + 1,
+			Name:         tl.Name,
+			TaskType:     int64(tl.TaskType),
+			Data:         blob.Data,
+			DataEncoding: string(blob.Encoding),
+		})
+		if err1 != nil {
+			return err1
+		}
+		rowsAffected, err1 := result.RowsAffected()
+		if err1 != nil {
+			return err1
+		}
+		if rowsAffected != 1 {
+			return fmt.Errorf("%v rows were affected instead of 1", rowsAffected)
+		}
+		resp = &persistence.RenewLeaseAndUpdateResponse{TaskListInfo: &persistence.PersistedTaskListInfo{
+			Data:    &tl,
+			RangeID: request.RangeID + 1,
+		}}
+		return nil
+	})
+	return resp, err
+}
+
+// backlogCountEstimate returns the number of task ids above the ack level, tasks at or below it are acked
+func backlogCountEstimate(maxTaskID int64, ackLevel int64) int64 {
+	if maxTaskID <= ackLevel {
+		return 0
+	}
+	return maxTaskID - ackLevel
+}
+
 func (m *sqlTaskManager) shardID(namespaceID primitives.UUID, name string) int {
 	id := farm.Hash32(append(namespaceID, []byte("_"+name)...)) % uint32(m.nShards)
 	return int(id)
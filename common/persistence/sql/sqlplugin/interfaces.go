@@ -574,6 +574,9 @@ type (
 		//    - {namespaceID, tasklistName, taskType, taskIDLessThanEquals, limit }
 		//    - this will delete upto limit number of tasks less than or equal to the given task id
 		DeleteFromTasks(filter *TasksFilter) (sql.Result, error)
+		// SelectMaxTaskIDFromTasks returns the highest task id of a task list, or 0 when it has no tasks
+		// Required filter params - {namespaceID, tasklistName, taskType}
+		SelectMaxTaskIDFromTasks(filter *TasksFilter) (int64, error)
 
 		InsertIntoTaskLists(row *TaskListsRow) (sql.Result, error)
 		ReplaceIntoTaskLists(row *TaskListsRow) (sql.Result, error)
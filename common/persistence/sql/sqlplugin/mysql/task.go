@@ -79,6 +79,9 @@ task_type = :task_type
 	rangeDeleteTaskQry = `DELETE FROM tasks ` +
 		`WHERE namespace_id = ? AND task_list_name = ? AND task_type = ? AND task_id <= ? ` +
 		`ORDER BY namespace_id,task_list_name,task_type,task_id LIMIT ?`
+
+	maxTaskIDQry = `SELECT COALESCE(MAX(task_id), 0) FROM tasks ` +
+		`WHERE namespace_id = ? AND task_list_name = ? AND task_type = ?`
 )
 
 // InsertIntoTasks inserts one or more rows into tasks table
@@ -116,6 +119,13 @@ func (mdb *db) DeleteFromTasks(filter *sqlplugin.TasksFilter) (sql.Result, error
 	return mdb.conn.Exec(deleteTaskQry, filter.NamespaceID, filter.TaskListName, filter.TaskType, *filter.TaskID)
 }
 
+// SelectMaxTaskIDFromTasks returns the highest task id of a task list in tasks table, or 0 if it has no tasks
+func (mdb *db) SelectMaxTaskIDFromTasks(filter *sqlplugin.TasksFilter) (int64, error) {
+	var maxTaskID int64
+	err := mdb.conn.Get(&maxTaskID, maxTaskIDQry, filter.NamespaceID, filter.TaskListName, filter.TaskType)
+	return maxTaskID, err
+}
+
 // InsertIntoTaskLists inserts one or more rows into task_lists table
 func (mdb *db) InsertIntoTaskLists(row *sqlplugin.TaskListsRow) (sql.Result, error) {
 	return mdb.conn.NamedExec(createTaskListQry, row)
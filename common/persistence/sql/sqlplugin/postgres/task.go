@@ -84,6 +84,9 @@ task_type = :task_type
 		`WHERE namespace_id = $1 AND task_list_name = $2 AND task_type = $3 AND task_id IN (SELECT task_id FROM
 		 tasks WHERE namespace_id = $1 AND task_list_name = $2 AND task_type = $3 AND task_id <= $4 ` +
 		`ORDER BY namespace_id,task_list_name,task_type,task_id LIMIT $5 )`
+
+	maxTaskIDQry = `SELECT COALESCE(MAX(task_id), 0) FROM tasks ` +
+		`WHERE namespace_id = $1 AND task_list_name = $2 AND task_type = $3`
 )
 
 // InsertIntoTasks inserts one or more rows into tasks table
@@ -121,6 +124,13 @@ func (pdb *db) DeleteFromTasks(filter *sqlplugin.TasksFilter) (sql.Result, error
 	return pdb.conn.Exec(deleteTaskQry, filter.NamespaceID, filter.TaskListName, filter.TaskType, *filter.TaskID)
 }
 
+// SelectMaxTaskIDFromTasks returns the highest task id of a task list in tasks table, or 0 if it has no tasks
+func (pdb *db) SelectMaxTaskIDFromTasks(filter *sqlplugin.TasksFilter) (int64, error) {
+	var maxTaskID int64
+	err := pdb.conn.Get(&maxTaskID, maxTaskIDQry, filter.NamespaceID, filter.TaskListName, filter.TaskType)
+	return maxTaskID, err
+}
+
 // InsertIntoTaskLists inserts one or more rows into task_lists table
 func (pdb *db) InsertIntoTaskLists(row *sqlplugin.TaskListsRow) (sql.Result, error) {
 	return pdb.conn.NamedExec(createTaskListQry, row)
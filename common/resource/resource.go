@@ -93,6 +93,7 @@ type (
 		GetTaskManager() persistence.TaskManager
 		GetVisibilityManager() persistence.VisibilityManager
 		GetNamespaceReplicationQueue() persistence.NamespaceReplicationQueue
+		GetDecisionTaskDLQ() persistence.DecisionTaskDLQ
 		GetShardManager() persistence.ShardManager
 		GetHistoryManager() persistence.HistoryManager
 		GetExecutionManager(int) (persistence.ExecutionManager, error)
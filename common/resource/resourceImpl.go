@@ -556,6 +556,11 @@ func (h *Impl) GetNamespaceReplicationQueue() persistence.NamespaceReplicationQu
 	return h.persistenceBean.GetNamespaceReplicationQueue()
 }
 
+// GetDecisionTaskDLQ return the DLQ of decision tasks
+func (h *Impl) GetDecisionTaskDLQ() persistence.DecisionTaskDLQ {
+	return h.persistenceBean.GetDecisionTaskDLQ()
+}
+
 // GetShardManager return shard manager
 func (h *Impl) GetShardManager() persistence.ShardManager {
 	return h.persistenceBean.GetShardManager()
@@ -94,6 +94,7 @@ type (
 		TaskMgr                   *mocks.TaskManager
 		VisibilityMgr             *mocks.VisibilityManager
 		NamespaceReplicationQueue persistence.NamespaceReplicationQueue
+		DecisionTaskDLQ           *persistence.MockDecisionTaskDLQ
 		ShardMgr                  *mocks.ShardManager
 		HistoryMgr                *mocks.HistoryV2Manager
 		ExecutionMgr              *mocks.ExecutionManager
@@ -146,6 +147,7 @@ func NewTest(
 	namespaceReplicationQueue := persistence.NewMockNamespaceReplicationQueue(controller)
 	namespaceReplicationQueue.EXPECT().Start().AnyTimes()
 	namespaceReplicationQueue.EXPECT().Stop().AnyTimes()
+	decisionTaskDLQ := persistence.NewMockDecisionTaskDLQ(controller)
 	persistenceBean := persistenceClient.NewMockBean(controller)
 	persistenceBean.EXPECT().GetMetadataManager().Return(metadataMgr).AnyTimes()
 	persistenceBean.EXPECT().GetTaskManager().Return(taskMgr).AnyTimes()
@@ -154,6 +156,7 @@ func NewTest(
 	persistenceBean.EXPECT().GetShardManager().Return(shardMgr).AnyTimes()
 	persistenceBean.EXPECT().GetExecutionManager(gomock.Any()).Return(executionMgr, nil).AnyTimes()
 	persistenceBean.EXPECT().GetNamespaceReplicationQueue().Return(namespaceReplicationQueue).AnyTimes()
+	persistenceBean.EXPECT().GetDecisionTaskDLQ().Return(decisionTaskDLQ).AnyTimes()
 
 	membershipMonitor := membership.NewMockMonitor(controller)
 	frontendServiceResolver := membership.NewMockServiceResolver(controller)
@@ -204,6 +207,7 @@ func NewTest(
 		TaskMgr:                   taskMgr,
 		VisibilityMgr:             visibilityMgr,
 		NamespaceReplicationQueue: namespaceReplicationQueue,
+		DecisionTaskDLQ:           decisionTaskDLQ,
 		ShardMgr:                  shardMgr,
 		HistoryMgr:                historyMgr,
 		ExecutionMgr:              executionMgr,
@@ -392,6 +396,11 @@ func (s *Test) GetNamespaceReplicationQueue() persistence.NamespaceReplicationQu
 	return s.NamespaceReplicationQueue
 }
 
+// GetDecisionTaskDLQ for testing
+func (s *Test) GetDecisionTaskDLQ() persistence.DecisionTaskDLQ {
+	return s.DecisionTaskDLQ
+}
+
 // GetShardManager for testing
 func (s *Test) GetShardManager() persistence.ShardManager {
 	return s.ShardMgr
@@ -221,6 +221,9 @@ type (
 		NumShards int `yaml:"nShards"`
 		// TLS is the configuration for TLS connections
 		TLS *auth.TLS `yaml:"tls"`
+		// TaskTimePrecision is the precision the created time and expiry of tasks are stored at.
+		// The default value for this param is 1ms, the finest supported value is 1µs
+		TaskTimePrecision time.Duration `yaml:"taskTimePrecision"`
 	}
 
 	// CustomDatastoreConfig is the configuration for connecting to a custom datastore that is not supported by temporal core
@@ -246,6 +246,18 @@ func (s *configSuite) TestGetMapProperty() {
 	s.Equal("321", value()["testKey"])
 }
 
+func (s *configSuite) TestGetMapPropertyFnWithNamespaceFilter() {
+	key := testGetMapPropertyKey
+	namespace := "testNamespace"
+	value := s.cln.GetMapPropertyFnWithNamespaceFilter(key, nil)
+	s.Nil(value(namespace))
+	val := map[string]interface{}{
+		"testKey": "123",
+	}
+	s.client.SetValue(key, val)
+	s.Equal(val, value(namespace))
+}
+
 func (s *configSuite) TestUpdateConfig() {
 	key := testGetBoolPropertyKey
 	value := s.cln.GetBoolProperty(key, true)
@@ -61,6 +61,9 @@ var keys = map[Key]string{
 	EnableReadFromHistoryArchival:          "system.enableReadFromHistoryArchival",
 	VisibilityArchivalStatus:               "system.visibilityArchivalStatus",
 	EnableReadFromVisibilityArchival:       "system.enableReadFromVisibilityArchival",
+	ArchivalRedactedSearchAttributes:       "system.archivalRedactedSearchAttributes",
+	ArchivalSearchAttributesFieldMapping:   "system.archivalSearchAttributesFieldMapping",
+	ArchivalHistoryStatusFilter:            "system.archivalHistoryStatusFilter",
 	EnableNamespaceNotActiveAutoForwarding: "system.enableNamespaceNotActiveAutoForwarding",
 	TransactionSizeLimit:                   "system.transactionSizeLimit",
 	MinRetentionDays:                       "system.minRetentionDays",
@@ -73,12 +76,17 @@ var keys = map[Key]string{
 	// size limit
 	BlobSizeLimitError:     "limit.blobSize.error",
 	BlobSizeLimitWarn:      "limit.blobSize.warn",
+	DecisionBlobSizeBudget: "limit.decisionBlobSizeBudget",
+	ControlSizeLimitError:  "limit.controlSize.error",
 	HistorySizeLimitError:  "limit.historySize.error",
 	HistorySizeLimitWarn:   "limit.historySize.warn",
 	HistoryCountLimitError: "limit.historyCount.error",
 	HistoryCountLimitWarn:  "limit.historyCount.warn",
 	MaxIDLengthLimit:       "limit.maxIDLength",
 
+	BufferedEventsCountLimitError: "limit.bufferedEventsCount.error",
+	BufferedEventsCountLimitWarn:  "limit.bufferedEventsCount.warn",
+
 	// frontend settings
 	FrontendPersistenceMaxQPS:             "frontend.persistenceMaxQPS",
 	FrontendVisibilityMaxPageSize:         "frontend.visibilityMaxPageSize",
@@ -96,6 +104,7 @@ var keys = map[Key]string{
 	ValidSearchAttributes:                 "frontend.validSearchAttributes",
 	SearchAttributesNumberOfKeysLimit:     "frontend.searchAttributesNumberOfKeysLimit",
 	SearchAttributesSizeOfValueLimit:      "frontend.searchAttributesSizeOfValueLimit",
+	MaxSearchAttributeValueCount:          "history.maxSearchAttributeValueCount",
 	SearchAttributesTotalSizeLimit:        "frontend.searchAttributesTotalSizeLimit",
 	VisibilityArchivalQueryMaxPageSize:    "frontend.visibilityArchivalQueryMaxPageSize",
 	VisibilityArchivalQueryMaxRangeInDays: "frontend.visibilityArchivalQueryMaxRangeInDays",
@@ -107,6 +116,10 @@ var keys = map[Key]string{
 	MatchingMinTaskThrottlingBurstSize:      "matching.minTaskThrottlingBurstSize",
 	MatchingGetTasksBatchSize:               "matching.getTasksBatchSize",
 	MatchingLongPollExpirationInterval:      "matching.longPollExpirationInterval",
+	MatchingMinPollTimeout:                  "matching.minPollTimeout",
+	MatchingMaxBacklogForOffer:              "matching.maxBacklogForOffer",
+	MatchingMatchRatioWindow:                "matching.matchRatioWindow",
+	MatchingPollerStalenessWindow:           "matching.pollerStalenessWindow",
 	MatchingEnableSyncMatch:                 "matching.enableSyncMatch",
 	MatchingUpdateAckInterval:               "matching.updateAckInterval",
 	MatchingIdleTasklistCheckInterval:       "matching.idleTasklistCheckInterval",
@@ -121,6 +134,9 @@ var keys = map[Key]string{
 	MatchingForwarderMaxOutstandingTasks:    "matching.forwarderMaxOutstandingTasks",
 	MatchingForwarderMaxRatePerSecond:       "matching.forwarderMaxRatePerSecond",
 	MatchingForwarderMaxChildrenPerNode:     "matching.forwarderMaxChildrenPerNode",
+	MatchingForwarderBreakerThreshold:       "matching.forwarderBreakerThreshold",
+	MatchingForwarderBreakerCooldown:        "matching.forwarderBreakerCooldown",
+	MatchingEnableTaskInjection:             "matching.enableTaskInjection",
 
 	// history settings
 	HistoryRPS:                                            "history.rps",
@@ -141,6 +157,7 @@ var keys = map[Key]string{
 	StandbyTaskMissingEventsResendDelay:                   "history.standbyTaskMissingEventsResendDelay",
 	StandbyTaskMissingEventsDiscardDelay:                  "history.standbyTaskMissingEventsDiscardDelay",
 	TaskProcessRPS:                                        "history.taskProcessRPS",
+	QueueProcessorMaxShardWorkerCount:                     "history.queueProcessorMaxShardWorkerCount",
 	TimerTaskBatchSize:                                    "history.timerTaskBatchSize",
 	TimerTaskWorkerCount:                                  "history.timerTaskWorkerCount",
 	TimerTaskMaxRetryCount:                                "history.timerTaskMaxRetryCount",
@@ -162,6 +179,7 @@ var keys = map[Key]string{
 	TransferProcessorMaxPollRPS:                           "history.transferProcessorMaxPollRPS",
 	TransferTaskWorkerCount:                               "history.transferTaskWorkerCount",
 	TransferTaskMaxRetryCount:                             "history.transferTaskMaxRetryCount",
+	MaxDecisionTaskProcessingAttempts:                     "history.maxDecisionTaskProcessingAttempts",
 	TransferProcessorCompleteTransferFailureRetryCount:    "history.transferProcessorCompleteTransferFailureRetryCount",
 	TransferProcessorUpdateShardTaskCount:                 "history.transferProcessorUpdateShardTaskCount",
 	TransferProcessorMaxPollInterval:                      "history.transferProcessorMaxPollInterval",
@@ -190,12 +208,32 @@ var keys = map[Key]string{
 	EnableAdminProtection:                                 "history.enableAdminProtection",
 	AdminOperationToken:                                   "history.adminOperationToken",
 	EnableParentClosePolicy:                               "history.enableParentClosePolicy",
+	InheritChildRetryPolicy:                               "history.inheritChildRetryPolicy",
+	DefaultParentClosePolicy:                              "history.defaultParentClosePolicy",
 	NumArchiveSystemWorkflows:                             "history.numArchiveSystemWorkflows",
 	ArchiveRequestRPS:                                     "history.archiveRequestRPS",
 	EmitShardDiffLog:                                      "history.emitShardDiffLog",
 	HistoryThrottledLogRPS:                                "history.throttledLogRPS",
 	StickyTTL:                                             "history.stickyTTL",
 	DecisionHeartbeatTimeout:                              "history.decisionHeartbeatTimeout",
+	WorkflowCancellationGracePeriod:                       "history.workflowCancellationGracePeriod",
+	LogDecisionFailureMessage:                             "history.logDecisionFailureMessage",
+	LogDroppedDecisions:                                   "history.logDroppedDecisions",
+	EnableMarkerConsistencyCheck:                          "history.enableMarkerConsistencyCheck",
+	ValidateActivityTaskList:                              "history.validateActivityTaskList",
+	FailUnknownActivityTaskList:                           "history.failUnknownActivityTaskList",
+	ActivityTaskListsByType:                               "history.activityTaskListsByType",
+	DedupeSignalExternalDecisions:                         "history.dedupeSignalExternalDecisions",
+	DedupeSignalControlIDs:                                "history.dedupeSignalControlIDs",
+	MaximumSignalControlIDs:                               "history.maximumSignalControlIDs",
+	EnableStructuredFailureDetails:                        "history.enableStructuredFailureDetails",
+	FailureCategories:                                     "history.failureCategories",
+	MaxRetryCronBackoffInterval:                           "history.maxRetryCronBackoffInterval",
+	AllowZeroDurationTimers:                               "history.allowZeroDurationTimers",
+	MaxTimerDuration:                                      "history.maxTimerDuration",
+	ContinueAsNewRequiredSearchAttributes:                 "history.continueAsNewRequiredSearchAttributes",
+	MaxPendingActivitiesPerWorkflow:                       "history.maxPendingActivitiesPerWorkflow",
+	EnableNamespaceTagPropagation:                         "history.enableNamespaceTagPropagation",
 	ParentClosePolicyThreshold:                            "history.parentClosePolicyThreshold",
 	NumParentClosePolicySystemWorkflows:                   "history.numParentClosePolicySystemWorkflows",
 	ReplicationTaskFetcherParallelism:                     "history.ReplicationTaskFetcherParallelism",
@@ -286,6 +324,15 @@ const (
 	VisibilityArchivalStatus
 	// EnableReadFromVisibilityArchival is key for enabling reading visibility from archival store
 	EnableReadFromVisibilityArchival
+	// ArchivalRedactedSearchAttributes is the comma separated list of search attribute keys
+	// removed from visibility records before they are archived
+	ArchivalRedactedSearchAttributes
+	// ArchivalSearchAttributesFieldMapping maps search attribute keys to the keys they are
+	// renamed to in visibility records before they are archived
+	ArchivalSearchAttributesFieldMapping
+	// ArchivalHistoryStatusFilter is the comma separated list of workflow close statuses, e.g. "Failed,TimedOut",
+	// whose history is archived. Visibility is archived for all statuses. Empty archives the history of all statuses
+	ArchivalHistoryStatusFilter
 	// EnableNamespaceNotActiveAutoForwarding whether enabling DC auto forwarding to active cluster
 	// for signal / start / signal with start API if namespace is not active
 	EnableNamespaceNotActiveAutoForwarding
@@ -302,6 +349,12 @@ const (
 	BlobSizeLimitError
 	// BlobSizeLimitWarn is the per event blob size limit for warning
 	BlobSizeLimitWarn
+	// DecisionBlobSizeBudget is the limit of the total size of the blobs of all decisions completing
+	// a decision task, above which the decision task is failed. Zero disables the budget
+	DecisionBlobSizeBudget
+	// ControlSizeLimitError is the size limit of the control of signal external workflow decisions, above which
+	// the decision task is failed. Zero disables the limit
+	ControlSizeLimitError
 	// HistorySizeLimitError is the per workflow execution history size limit
 	HistorySizeLimitError
 	// HistorySizeLimitWarn is the per workflow execution history size limit for warning
@@ -310,6 +363,10 @@ const (
 	HistoryCountLimitError
 	// HistoryCountLimitWarn is the per workflow execution history event count limit for warning
 	HistoryCountLimitWarn
+	// BufferedEventsCountLimitError is the per workflow execution buffered event count limit, above which the workflow is terminated
+	BufferedEventsCountLimitError
+	// BufferedEventsCountLimitWarn is the per workflow execution buffered event count limit, above which the decision is failed to flush the buffer
+	BufferedEventsCountLimitWarn
 
 	// MaxIDLengthLimit is the length limit for various IDs, including: Namespace, TaskList, WorkflowID, ActivityID, TimerID,
 	// WorkflowType, ActivityType, SignalName, MarkerName, ErrorReason/FailureReason/CancelCause, Identity, RequestID
@@ -347,6 +404,9 @@ const (
 	SearchAttributesNumberOfKeysLimit
 	// SearchAttributesSizeOfValueLimit is the size limit of each value
 	SearchAttributesSizeOfValueLimit
+	// MaxSearchAttributeValueCount is the limit of number of values of a keyword list search attribute
+	// upserted by a decision
+	MaxSearchAttributeValueCount
 	// SearchAttributesTotalSizeLimit is the size limit of the whole map
 	SearchAttributesTotalSizeLimit
 	// VisibilityArchivalQueryMaxPageSize is the maximum page size for a visibility archival query
@@ -368,6 +428,19 @@ const (
 	MatchingGetTasksBatchSize
 	// MatchingLongPollExpirationInterval is the long poll expiration interval in the matching service
 	MatchingLongPollExpirationInterval
+	// MatchingMinPollTimeout is the minimum time a poll is held waiting for a task, even when the long poll
+	// interval is shorter. It never eats into the time reserved to return an empty task before the poller's
+	// own deadline. Zero disables the floor
+	MatchingMinPollTimeout
+	// MatchingMaxBacklogForOffer is the task list backlog size above which new tasks are shed instead of
+	// being accepted. Zero disables shedding
+	MatchingMaxBacklogForOffer
+	// MatchingMatchRatioWindow is the window over which the ratio of sync matches to backlog matches
+	// of a task list is computed
+	MatchingMatchRatioWindow
+	// MatchingPollerStalenessWindow is the time since its last poll after which a poller is evicted from
+	// the pollers reported by DescribeTaskList
+	MatchingPollerStalenessWindow
 	// MatchingEnableSyncMatch is to enable sync match
 	MatchingEnableSyncMatch
 	// MatchingUpdateAckInterval is the interval for update ack
@@ -396,6 +469,13 @@ const (
 	MatchingForwarderMaxRatePerSecond
 	// MatchingForwarderMaxChildrenPerNode is the max number of children per node in the task list partition tree
 	MatchingForwarderMaxChildrenPerNode
+	// MatchingForwarderBreakerThreshold is the number of consecutive failures to reach the parent partition
+	// after which the forwarder stops forwarding, zero disables the breaker
+	MatchingForwarderBreakerThreshold
+	// MatchingForwarderBreakerCooldown is how long the forwarder stops forwarding before probing the parent partition again
+	MatchingForwarderBreakerCooldown
+	// MatchingEnableTaskInjection allows the InjectTask API to write synthetic tasks into task lists, for development only
+	MatchingEnableTaskInjection
 
 	// key for history
 
@@ -435,6 +515,9 @@ const (
 	StandbyTaskMissingEventsDiscardDelay
 	// TaskProcessRPS is the task processing rate per second for each namespace
 	TaskProcessRPS
+	// QueueProcessorMaxShardWorkerCount is the max number of workers the concurrency override of a shard may
+	// raise its queue task processing to, 0 means the override is not bounded
+	QueueProcessorMaxShardWorkerCount
 	// TimerTaskBatchSize is batch size for timer processor to process tasks
 	TimerTaskBatchSize
 	// TimerTaskWorkerCount is number of task workers for timer processor
@@ -477,6 +560,9 @@ const (
 	TransferTaskWorkerCount
 	// TransferTaskMaxRetryCount is max times of retry for transferQueueProcessor
 	TransferTaskMaxRetryCount
+	// MaxDecisionTaskProcessingAttempts is the number of failed attempts after which a transfer decision task is
+	// moved to the decision task DLQ instead of being retried, 0 means it is retried forever
+	MaxDecisionTaskProcessingAttempts
 	// TransferProcessorCompleteTransferFailureRetryCount is times of retry for failure
 	TransferProcessorCompleteTransferFailureRetryCount
 	// TransferProcessorUpdateShardTaskCount is update shard count for transferQueueProcessor
@@ -527,7 +613,8 @@ const (
 	ShardSyncTimerJitterCoefficient
 	// DefaultEventEncoding is the encoding type for history events
 	DefaultEventEncoding
-	// NumArchiveSystemWorkflows is key for number of archive system workflows running in total
+	// NumArchiveSystemWorkflows is key for number of archive system workflows running in total,
+	// a namespace may override it to spread its archival signals over more or fewer workflows
 	NumArchiveSystemWorkflows
 	// ArchiveRequestRPS is the rate limit on the number of archive request per second
 	ArchiveRequestRPS
@@ -541,6 +628,12 @@ const (
 
 	// EnableParentClosePolicy whether to  ParentClosePolicy
 	EnableParentClosePolicy
+	// InheritChildRetryPolicy whether child workflows started without a retry policy inherit the retry policy of the parent
+	InheritChildRetryPolicy
+	// DefaultParentClosePolicy is the parent close policy of the child workflows started without one when
+	// EnableParentClosePolicy is on. Abandon being the zero value of the policy, a child decision opts into
+	// the default with the ParentClosePolicyUnset header field
+	DefaultParentClosePolicy
 	// ParentClosePolicyThreshold decides that parent close policy will be processed by sys workers(if enabled) if
 	// the number of children greater than or equal to this threshold
 	ParentClosePolicyThreshold
@@ -553,6 +646,48 @@ const (
 	StickyTTL
 	// DecisionHeartbeatTimeout for decision heartbeat
 	DecisionHeartbeatTimeout
+	// WorkflowCancellationGracePeriod is the delay between a cancel workflow decision and the workflow being canceled
+	WorkflowCancellationGracePeriod
+	// LogDecisionFailureMessage whether to include the failure message when logging a failed decision
+	LogDecisionFailureMessage
+	// LogDroppedDecisions whether to log the decisions of a batch dropped after decision processing stopped
+	LogDroppedDecisions
+	// EnableMarkerConsistencyCheck whether to fail decisions recording a marker ID again with different details
+	EnableMarkerConsistencyCheck
+	// ValidateActivityTaskList whether to check that the task list of a scheduled activity has activity pollers
+	ValidateActivityTaskList
+	// FailUnknownActivityTaskList whether to fail, rather than only warn on, decisions scheduling an activity on a
+	// task list without activity pollers, only used when ValidateActivityTaskList is enabled
+	FailUnknownActivityTaskList
+	// ActivityTaskListsByType maps activity type names to the task list their activities must be scheduled on
+	ActivityTaskListsByType
+	// DedupeSignalExternalDecisions whether identical signal external workflow decisions in one batch signal only once
+	DedupeSignalExternalDecisions
+	// DedupeSignalControlIDs whether signal external workflow decisions carrying a control already signaled by
+	// an earlier decision of the workflow, for example one re-emitted by a retried decision task, signal only once
+	DedupeSignalControlIDs
+	// MaximumSignalControlIDs is max number of signal controls recorded by single execution for dedupe,
+	// the control of the earliest signal is forgotten first
+	MaximumSignalControlIDs
+	// EnableStructuredFailureDetails whether the failure category carried by fail workflow decision details
+	// is validated and mirrored into the FailureCategory search attribute
+	EnableStructuredFailureDetails
+	// FailureCategories is the set of failure categories fail workflow decisions may carry, any category is allowed if empty
+	FailureCategories
+	// MaxRetryCronBackoffInterval caps the backoff before the next run of a retried or cron workflow, no cap if zero
+	MaxRetryCronBackoffInterval
+	// AllowZeroDurationTimers whether start timer decisions may start timers which fire immediately
+	AllowZeroDurationTimers
+	// MaxTimerDuration is the max duration of timers started by start timer decisions, no max if zero
+	MaxTimerDuration
+	// ContinueAsNewRequiredSearchAttributes is the comma separated list of search attribute keys
+	// which the new run of a continue as new decision must carry
+	ContinueAsNewRequiredSearchAttributes
+	// MaxPendingActivitiesPerWorkflow is the max number of pending activities of a workflow, no max if zero
+	MaxPendingActivitiesPerWorkflow
+	// EnableNamespaceTagPropagation whether the namespace tags, the namespace data entries prefixed by "tag.",
+	// are attached to the header of scheduled activities
+	EnableNamespaceTagPropagation
 
 	// key for worker
 
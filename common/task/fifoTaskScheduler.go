@@ -137,6 +137,12 @@ func (f *fifoTaskSchedulerImpl) TrySubmit(
 	}
 }
 
+func (f *fifoTaskSchedulerImpl) SetWorkerCount(
+	count int,
+) {
+	f.processor.SetWorkerCount(count)
+}
+
 func (f *fifoTaskSchedulerImpl) dispatcher() {
 	defer f.dispatcherWG.Done()
 
@@ -32,6 +32,7 @@ type (
 	Processor interface {
 		common.Daemon
 		Submit(task Task) error
+		SetWorkerCount(count int)
 	}
 
 	// Scheduler is the generic interface for scheduling tasks with priority
@@ -40,6 +41,7 @@ type (
 		common.Daemon
 		Submit(task PriorityTask) error
 		TrySubmit(task PriorityTask) (bool, error)
+		SetWorkerCount(count int)
 	}
 
 	// SchedulerType respresents the type of the task scheduler implementation
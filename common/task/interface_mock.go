@@ -55,6 +55,18 @@ func (m *MockProcessor) EXPECT() *MockProcessorMockRecorder {
 	return m.recorder
 }
 
+// SetWorkerCount mocks base method.
+func (m *MockProcessor) SetWorkerCount(count int) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "SetWorkerCount", count)
+}
+
+// SetWorkerCount indicates an expected call of SetWorkerCount.
+func (mr *MockProcessorMockRecorder) SetWorkerCount(count interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetWorkerCount", reflect.TypeOf((*MockProcessor)(nil).SetWorkerCount), count)
+}
+
 // Start mocks base method.
 func (m *MockProcessor) Start() {
 	m.ctrl.T.Helper()
@@ -116,6 +128,18 @@ func (m *MockScheduler) EXPECT() *MockSchedulerMockRecorder {
 	return m.recorder
 }
 
+// SetWorkerCount mocks base method.
+func (m *MockScheduler) SetWorkerCount(count int) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "SetWorkerCount", count)
+}
+
+// SetWorkerCount indicates an expected call of SetWorkerCount.
+func (mr *MockSchedulerMockRecorder) SetWorkerCount(count interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetWorkerCount", reflect.TypeOf((*MockScheduler)(nil).SetWorkerCount), count)
+}
+
 // Start mocks base method.
 func (m *MockScheduler) Start() {
 	m.ctrl.T.Helper()
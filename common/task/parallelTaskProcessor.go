@@ -41,6 +41,8 @@ type (
 	}
 
 	parallelTaskProcessorImpl struct {
+		sync.Mutex
+
 		status       int32
 		tasksCh      chan Task
 		shutdownCh   chan struct{}
@@ -48,6 +50,9 @@ type (
 		logger       log.Logger
 		metricsScope metrics.Scope
 		options      *ParallelTaskProcessorOptions
+
+		workerCount       int
+		workerShutdownChs []chan struct{}
 	}
 )
 
@@ -69,6 +74,7 @@ func NewParallelTaskProcessor(
 		logger:       logger,
 		metricsScope: metricsScope,
 		options:      options,
+		workerCount:  options.WorkerCount,
 	}
 }
 
@@ -77,10 +83,10 @@ func (p *parallelTaskProcessorImpl) Start() {
 		return
 	}
 
-	p.workerWG.Add(p.options.WorkerCount)
-	for i := 0; i < p.options.WorkerCount; i++ {
-		go p.taskWorker()
-	}
+	p.Lock()
+	p.resizeWorkersLocked()
+	p.Unlock()
+
 	p.logger.Info("Parallel task processor started.")
 }
 
@@ -89,7 +95,11 @@ func (p *parallelTaskProcessorImpl) Stop() {
 		return
 	}
 
+	// wait for any in progress resizing before waiting for the workers
+	p.Lock()
 	close(p.shutdownCh)
+	p.Unlock()
+
 	if success := common.AwaitWaitGroup(&p.workerWG, time.Minute); !success {
 		p.logger.Warn("Parallel task processor timedout on shutdown.")
 	}
@@ -109,13 +119,58 @@ func (p *parallelTaskProcessorImpl) Submit(task Task) error {
 	}
 }
 
-func (p *parallelTaskProcessorImpl) taskWorker() {
+func (p *parallelTaskProcessorImpl) SetWorkerCount(
+	count int,
+) {
+	if count < 1 {
+		count = 1
+	}
+
+	p.Lock()
+	defer p.Unlock()
+
+	p.workerCount = count
+	if atomic.LoadInt32(&p.status) != common.DaemonStatusStarted {
+		// workers will be started or have already been stopped
+		return
+	}
+	p.resizeWorkersLocked()
+}
+
+func (p *parallelTaskProcessorImpl) resizeWorkersLocked() {
+	for len(p.workerShutdownChs) < p.workerCount {
+		workerShutdownCh := make(chan struct{})
+		p.workerShutdownChs = append(p.workerShutdownChs, workerShutdownCh)
+		p.workerWG.Add(1)
+		go p.taskWorker(workerShutdownCh)
+	}
+
+	// removed workers will exit after finishing the task at hand
+	for len(p.workerShutdownChs) > p.workerCount {
+		lastIndex := len(p.workerShutdownChs) - 1
+		close(p.workerShutdownChs[lastIndex])
+		p.workerShutdownChs = p.workerShutdownChs[:lastIndex]
+	}
+}
+
+func (p *parallelTaskProcessorImpl) taskWorker(
+	workerShutdownCh <-chan struct{},
+) {
 	defer p.workerWG.Done()
 
 	for {
+		// a removed worker should not pick up new tasks
+		select {
+		case <-workerShutdownCh:
+			return
+		default:
+		}
+
 		select {
 		case <-p.shutdownCh:
 			return
+		case <-workerShutdownCh:
+			return
 		case task := <-p.tasksCh:
 			p.executeTask(task)
 		}
@@ -96,6 +96,24 @@ func (s *parallelTaskProcessorSuite) TestSubmit_Fail() {
 	s.Equal(ErrTaskProcessorClosed, err)
 }
 
+func (s *parallelTaskProcessorSuite) TestSetWorkerCount() {
+	s.processor.SetWorkerCount(3)
+	s.Empty(s.processor.workerShutdownChs)
+
+	s.processor.Start()
+	s.Len(s.processor.workerShutdownChs, 3)
+
+	s.processor.SetWorkerCount(5)
+	s.Len(s.processor.workerShutdownChs, 5)
+
+	s.processor.SetWorkerCount(0)
+	s.Len(s.processor.workerShutdownChs, 1)
+
+	s.processor.Stop()
+	s.processor.SetWorkerCount(5)
+	s.Len(s.processor.workerShutdownChs, 1)
+}
+
 func (s *parallelTaskProcessorSuite) TestTaskWorker() {
 	numTasks := 5
 
@@ -114,7 +132,7 @@ func (s *parallelTaskProcessorSuite) TestTaskWorker() {
 		close(done)
 	}()
 
-	s.processor.taskWorker()
+	s.processor.taskWorker(make(chan struct{}))
 	<-done
 }
 
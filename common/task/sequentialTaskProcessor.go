@@ -35,6 +35,8 @@ import (
 
 type (
 	sequentialTaskProcessorImpl struct {
+		sync.Mutex
+
 		status       int32
 		shutdownChan chan struct{}
 		waitGroup    sync.WaitGroup
@@ -44,6 +46,8 @@ type (
 		taskQueueFactory SequentialTaskQueueFactory
 		taskqueueChan    chan SequentialTaskQueue
 
+		coroutineShutdownChan []chan struct{}
+
 		metricsScope  int
 		metricsClient metrics.Client
 		logger        log.Logger
@@ -73,10 +77,10 @@ func (t *sequentialTaskProcessorImpl) Start() {
 		return
 	}
 
-	t.waitGroup.Add(t.coroutineSize)
-	for i := 0; i < t.coroutineSize; i++ {
-		go t.pollAndProcessTaskQueue()
-	}
+	t.Lock()
+	t.resizeCoroutinesLocked()
+	t.Unlock()
+
 	t.logger.Info("Task processor started.")
 }
 
@@ -85,7 +89,11 @@ func (t *sequentialTaskProcessorImpl) Stop() {
 		return
 	}
 
+	// wait for any in progress resizing before waiting for the coroutines
+	t.Lock()
 	close(t.shutdownChan)
+	t.Unlock()
+
 	if success := common.AwaitWaitGroup(&t.waitGroup, time.Minute); !success {
 		t.logger.Warn("Task processor timeout trying to stop.")
 	}
@@ -130,13 +138,46 @@ func (t *sequentialTaskProcessorImpl) Submit(task Task) error {
 
 }
 
-func (t *sequentialTaskProcessorImpl) pollAndProcessTaskQueue() {
+func (t *sequentialTaskProcessorImpl) SetWorkerCount(count int) {
+	if count < 1 {
+		count = 1
+	}
+
+	t.Lock()
+	defer t.Unlock()
+
+	t.coroutineSize = count
+	if atomic.LoadInt32(&t.status) != common.DaemonStatusStarted {
+		return
+	}
+	t.resizeCoroutinesLocked()
+}
+
+func (t *sequentialTaskProcessorImpl) resizeCoroutinesLocked() {
+	for len(t.coroutineShutdownChan) < t.coroutineSize {
+		shutdownChan := make(chan struct{})
+		t.coroutineShutdownChan = append(t.coroutineShutdownChan, shutdownChan)
+		t.waitGroup.Add(1)
+		go t.pollAndProcessTaskQueue(shutdownChan)
+	}
+
+	// a removed coroutine finishes the task queue at hand before exiting
+	for len(t.coroutineShutdownChan) > t.coroutineSize {
+		lastIndex := len(t.coroutineShutdownChan) - 1
+		close(t.coroutineShutdownChan[lastIndex])
+		t.coroutineShutdownChan = t.coroutineShutdownChan[:lastIndex]
+	}
+}
+
+func (t *sequentialTaskProcessorImpl) pollAndProcessTaskQueue(coroutineShutdownChan <-chan struct{}) {
 	defer t.waitGroup.Done()
 
 	for {
 		select {
 		case <-t.shutdownChan:
 			return
+		case <-coroutineShutdownChan:
+			return
 		case taskqueue := <-t.taskqueueChan:
 			metricsTimer := t.metricsClient.StartTimer(t.metricsScope, metrics.SequentialTaskQueueProcessingLatency)
 			t.processTaskQueue(taskqueue)
@@ -167,6 +167,12 @@ func (w *weightedRoundRobinTaskSchedulerImpl) TrySubmit(
 	}
 }
 
+func (w *weightedRoundRobinTaskSchedulerImpl) SetWorkerCount(
+	count int,
+) {
+	w.processor.SetWorkerCount(count)
+}
+
 func (w *weightedRoundRobinTaskSchedulerImpl) dispatcher() {
 	defer w.dispatcherWG.Done()
 
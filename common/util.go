@@ -88,6 +88,8 @@ const (
 	FailureReasonSizeExceedsLimit = "HISTORY_EXCEEDS_LIMIT"
 	// FailureReasonTransactionSizeExceedsLimit is the failureReason for when transaction cannot be committed because it exceeds size limit
 	FailureReasonTransactionSizeExceedsLimit = "TRANSACTION_SIZE_EXCEEDS_LIMIT"
+	// FailureReasonBufferedEventsCountExceedsLimit is reason to terminate workflow when buffered events count exceed limit
+	FailureReasonBufferedEventsCountExceedsLimit = "BUFFERED_EVENTS_EXCEEDS_LIMIT"
 )
 
 var (
@@ -23,20 +23,28 @@ package frontend
 import (
 	"context"
 	"errors"
+	"fmt"
+	"io"
 	"strconv"
 	"time"
 
+	"github.com/gogo/protobuf/types"
 	"github.com/olivere/elastic"
 	"github.com/pborman/uuid"
 	commonpb "go.temporal.io/temporal-proto/common"
 	eventpb "go.temporal.io/temporal-proto/event"
+	executionpb "go.temporal.io/temporal-proto/execution"
 	"go.temporal.io/temporal-proto/serviceerror"
+	tasklistpb "go.temporal.io/temporal-proto/tasklist"
 	versionpb "go.temporal.io/temporal-proto/version"
+	"go.temporal.io/temporal-proto/workflowservice"
 
 	"github.com/temporalio/temporal/.gen/proto/adminservice"
 	clustergenpb "github.com/temporalio/temporal/.gen/proto/cluster"
 	commongenpb "github.com/temporalio/temporal/.gen/proto/common"
 	"github.com/temporalio/temporal/.gen/proto/historyservice"
+	"github.com/temporalio/temporal/.gen/proto/matchingservice"
+	"github.com/temporalio/temporal/.gen/proto/persistenceblobs"
 	replicationgenpb "github.com/temporalio/temporal/.gen/proto/replication"
 	tokengenpb "github.com/temporalio/temporal/.gen/proto/token"
 	"github.com/temporalio/temporal/common"
@@ -48,6 +56,7 @@ import (
 	"github.com/temporalio/temporal/common/metrics"
 	"github.com/temporalio/temporal/common/namespace"
 	"github.com/temporalio/temporal/common/persistence"
+	"github.com/temporalio/temporal/common/primitives"
 	"github.com/temporalio/temporal/common/resource"
 	"github.com/temporalio/temporal/common/service/dynamicconfig"
 	"github.com/temporalio/temporal/service/history"
@@ -210,12 +219,27 @@ func (adh *AdminHandler) DescribeWorkflowExecution(ctx context.Context, request
 	if err != nil {
 		return &adminservice.DescribeWorkflowExecutionResponse{}, err
 	}
+
+	// pending children are best effort, the mutable state is still described without them
+	resp3, err := adh.GetHistoryClient().GetPendingChildren(ctx, &historyservice.GetPendingChildrenRequest{
+		NamespaceId: namespaceID,
+		Execution:   request.Execution,
+	})
+	if err != nil {
+		adh.GetLogger().Warn("Failed to get pending children of workflow execution",
+			tag.WorkflowNamespaceID(namespaceID),
+			tag.WorkflowID(request.Execution.GetWorkflowId()),
+			tag.WorkflowRunID(request.Execution.GetRunId()),
+			tag.Error(err))
+	}
 	return &adminservice.DescribeWorkflowExecutionResponse{
 		ShardId:                shardIDForOutput,
 		HistoryAddr:            historyAddr,
 		MutableStateInDatabase: resp2.MutableStateInDatabase,
 		MutableStateInCache:    resp2.MutableStateInCache,
-	}, err
+		PendingChildren:        resp3.GetPendingChildren(),
+		BufferedEventsCount:    resp2.GetBufferedEventsCount(),
+	}, nil
 }
 
 // RemoveTask returns information about the internal states of a history host
@@ -786,6 +810,9 @@ func (adh *AdminHandler) ReadDLQMessages(
 	var op func() error
 	switch request.GetType() {
 	case commongenpb.DLQTypeReplication:
+		if request.GetNamespaceId() != "" {
+			return nil, adh.error(errDLQNamespaceFilterNotSupported, scope)
+		}
 		resp, err := adh.GetHistoryClient().ReadDLQMessages(ctx, &historyservice.ReadDLQMessagesRequest{
 			Type:                  request.GetType(),
 			ShardId:               request.GetShardId(),
@@ -814,10 +841,13 @@ func (adh *AdminHandler) ReadDLQMessages(
 				tasks, token, err = adh.namespaceDLQHandler.Read(
 					int(request.GetInclusiveEndMessageId()),
 					int(request.GetMaximumPageSize()),
-					request.GetNextPageToken())
+					request.GetNextPageToken(),
+					request.GetNamespaceId())
 				return err
 			}
 		}
+	case commongenpb.DLQTypeDecisionTask:
+		return adh.readDecisionTaskDLQ(ctx, request, scope)
 	default:
 		return nil, adh.error(errDLQTypeIsNotSupported, scope)
 	}
@@ -832,6 +862,58 @@ func (adh *AdminHandler) ReadDLQMessages(
 	}, nil
 }
 
+func (adh *AdminHandler) readDecisionTaskDLQ(
+	ctx context.Context,
+	request *adminservice.ReadDLQMessagesRequest,
+	scope metrics.Scope,
+) (*adminservice.ReadDLQMessagesResponse, error) {
+
+	if request.GetNamespaceId() != "" {
+		return nil, adh.error(errDLQNamespaceFilterNotSupported, scope)
+	}
+
+	var decisionTasks []*persistenceblobs.TransferTaskInfo
+	var token []byte
+	op := func() error {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		default:
+			var err error
+			decisionTasks, _, token, err = adh.GetDecisionTaskDLQ().Read(
+				int(request.GetInclusiveEndMessageId()),
+				int(request.GetMaximumPageSize()),
+				request.GetNextPageToken())
+			return err
+		}
+	}
+	if err := backoff.Retry(op, adminServiceRetryPolicy, common.IsServiceTransientError); err != nil {
+		return nil, adh.error(err, scope)
+	}
+
+	resp := &adminservice.ReadDLQMessagesResponse{
+		Type:          commongenpb.DLQTypeDecisionTask,
+		NextPageToken: token,
+	}
+	for _, task := range decisionTasks {
+		var visibilityTime int64
+		if t, err := types.TimestampFromProto(task.GetVisibilityTimestamp()); err == nil {
+			visibilityTime = t.UnixNano()
+		}
+		resp.DecisionTasks = append(resp.DecisionTasks, &adminservice.DLQDecisionTask{
+			NamespaceId:    primitives.UUIDString(task.GetNamespaceId()),
+			WorkflowId:     task.GetWorkflowId(),
+			RunId:          primitives.UUIDString(task.GetRunId()),
+			TaskList:       task.GetTaskList(),
+			ScheduleId:     task.GetScheduleId(),
+			TaskId:         task.GetTaskId(),
+			Version:        task.GetVersion(),
+			VisibilityTime: visibilityTime,
+		})
+	}
+	return resp, nil
+}
+
 // PurgeDLQMessages purge messages from DLQ
 func (adh *AdminHandler) PurgeDLQMessages(
 	ctx context.Context,
@@ -853,6 +935,9 @@ func (adh *AdminHandler) PurgeDLQMessages(
 	var op func() error
 	switch request.GetType() {
 	case commongenpb.DLQTypeReplication:
+		if request.GetNamespaceId() != "" {
+			return nil, adh.error(errDLQNamespaceFilterNotSupported, scope)
+		}
 		resp, err := adh.GetHistoryClient().PurgeDLQMessages(ctx, &historyservice.PurgeDLQMessagesRequest{
 			Type:                  request.GetType(),
 			ShardId:               request.GetShardId(),
@@ -871,7 +956,7 @@ func (adh *AdminHandler) PurgeDLQMessages(
 			case <-ctx.Done():
 				return ctx.Err()
 			default:
-				return adh.namespaceDLQHandler.Purge(int(request.GetInclusiveEndMessageId()))
+				return adh.namespaceDLQHandler.Purge(int(request.GetInclusiveEndMessageId()), request.GetNamespaceId())
 			}
 		}
 	default:
@@ -907,6 +992,9 @@ func (adh *AdminHandler) MergeDLQMessages(
 	var op func() error
 	switch request.GetType() {
 	case commongenpb.DLQTypeReplication:
+		if request.GetNamespaceId() != "" {
+			return nil, adh.error(errDLQNamespaceFilterNotSupported, scope)
+		}
 		resp, err := adh.GetHistoryClient().MergeDLQMessages(ctx, &historyservice.MergeDLQMessagesRequest{
 			Type:                  request.GetType(),
 			ShardId:               request.GetShardId(),
@@ -934,10 +1022,25 @@ func (adh *AdminHandler) MergeDLQMessages(
 					int(request.GetInclusiveEndMessageId()),
 					int(request.GetMaximumPageSize()),
 					request.GetNextPageToken(),
+					request.GetNamespaceId(),
 				)
 				return err
 			}
 		}
+	case commongenpb.DLQTypeDecisionTask:
+		if request.GetNamespaceId() != "" {
+			return nil, adh.error(errDLQNamespaceFilterNotSupported, scope)
+		}
+		op = func() error {
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			default:
+				var err error
+				token, err = adh.mergeDecisionTaskDLQ(ctx, request)
+				return err
+			}
+		}
 	default:
 		return nil, adh.error(errDLQTypeIsNotSupported, scope)
 	}
@@ -951,6 +1054,89 @@ func (adh *AdminHandler) MergeDLQMessages(
 	}, nil
 }
 
+// mergeDecisionTaskDLQ redrives a page of the decision task DLQ by refreshing the tasks of each workflow,
+// which schedules its pending decision task again, and then deletes the page from the DLQ
+func (adh *AdminHandler) mergeDecisionTaskDLQ(
+	ctx context.Context,
+	request *adminservice.MergeDLQMessagesRequest,
+) ([]byte, error) {
+
+	decisionTasks, lastMessageID, token, err := adh.GetDecisionTaskDLQ().Read(
+		int(request.GetInclusiveEndMessageId()),
+		int(request.GetMaximumPageSize()),
+		request.GetNextPageToken())
+	if err != nil {
+		return nil, err
+	}
+	if len(decisionTasks) == 0 {
+		return token, nil
+	}
+
+	for _, task := range decisionTasks {
+		namespaceID := primitives.UUIDString(task.GetNamespaceId())
+		namespaceEntry, err := adh.GetNamespaceCache().GetNamespaceByID(namespaceID)
+		if err != nil {
+			return nil, err
+		}
+		if _, err := adh.GetHistoryClient().RefreshWorkflowTasks(ctx, &historyservice.RefreshWorkflowTasksRequest{
+			NamespaceId: namespaceID,
+			Request: &adminservice.RefreshWorkflowTasksRequest{
+				Namespace: namespaceEntry.GetInfo().Name,
+				Execution: &executionpb.WorkflowExecution{
+					WorkflowId: task.GetWorkflowId(),
+					RunId:      primitives.UUIDString(task.GetRunId()),
+				},
+			},
+		}); err != nil {
+			// the workflow is gone, there is no decision task left to redrive
+			if _, ok := err.(*serviceerror.NotFound); !ok {
+				return nil, err
+			}
+		}
+	}
+
+	if err := adh.GetDecisionTaskDLQ().RangeDelete(lastMessageID); err != nil {
+		return nil, err
+	}
+	return token, nil
+}
+
+// MergeOneDLQMessage merges a single message from the namespace DLQ
+func (adh *AdminHandler) MergeOneDLQMessage(
+	ctx context.Context,
+	request *adminservice.MergeOneDLQMessageRequest,
+) (_ *adminservice.MergeOneDLQMessageResponse, err error) {
+
+	defer log.CapturePanicGRPC(adh.GetLogger(), &err)
+	scope, sw := adh.startRequestProfile(metrics.AdminMergeOneDLQMessageScope)
+	defer sw.Stop()
+
+	if request == nil {
+		return nil, adh.error(errRequestNotSet, scope)
+	}
+	if request.GetType() != commongenpb.DLQTypeNamespace {
+		return nil, adh.error(errDLQTypeIsNotSupported, scope)
+	}
+	if request.GetMessageId() <= 0 {
+		return nil, adh.error(errInvalidDLQMessageID, scope)
+	}
+
+	op := func() error {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		default:
+			return adh.namespaceDLQHandler.MergeOne(int(request.GetMessageId()))
+		}
+	}
+	err = backoff.Retry(op, adminServiceRetryPolicy, common.IsServiceTransientError)
+	if err != nil {
+		return nil, adh.error(err, scope)
+	}
+
+	return &adminservice.MergeOneDLQMessageResponse{}, nil
+}
+
 // RefreshWorkflowTasks re-generates the workflow tasks
 func (adh *AdminHandler) RefreshWorkflowTasks(
 	ctx context.Context,
@@ -981,6 +1167,490 @@ func (adh *AdminHandler) RefreshWorkflowTasks(
 	return &adminservice.RefreshWorkflowTasksResponse{}, nil
 }
 
+// ResetReplicationAckLevel sets the replication ack level of a remote cluster on a shard
+func (adh *AdminHandler) ResetReplicationAckLevel(
+	ctx context.Context,
+	request *adminservice.ResetReplicationAckLevelRequest,
+) (_ *adminservice.ResetReplicationAckLevelResponse, err error) {
+	defer log.CapturePanicGRPC(adh.GetLogger(), &err)
+	scope, sw := adh.startRequestProfile(metrics.AdminResetReplicationAckLevelScope)
+	defer sw.Stop()
+
+	if request == nil {
+		return nil, adh.error(errRequestNotSet, scope)
+	}
+	if request.GetClusterName() == "" {
+		return nil, adh.error(errClusterNameNotSet, scope)
+	}
+
+	resp, err := adh.GetHistoryClient().ResetReplicationAckLevel(ctx, &historyservice.ResetReplicationAckLevelRequest{
+		ShardId:     request.GetShardId(),
+		ClusterName: request.GetClusterName(),
+	})
+	if err != nil {
+		return nil, adh.error(err, scope)
+	}
+	return &adminservice.ResetReplicationAckLevelResponse{
+		AckLevel: resp.GetAckLevel(),
+	}, nil
+}
+
+// RepairShardAckLevels recomputes the task queue ack levels of a shard from the tasks in persistence
+func (adh *AdminHandler) RepairShardAckLevels(
+	ctx context.Context,
+	request *adminservice.RepairShardAckLevelsRequest,
+) (_ *adminservice.RepairShardAckLevelsResponse, err error) {
+	defer log.CapturePanicGRPC(adh.GetLogger(), &err)
+	scope, sw := adh.startRequestProfile(metrics.AdminRepairShardAckLevelsScope)
+	defer sw.Stop()
+
+	if request == nil {
+		return nil, adh.error(errRequestNotSet, scope)
+	}
+
+	resp, err := adh.GetHistoryClient().RepairShardAckLevels(ctx, &historyservice.RepairShardAckLevelsRequest{
+		ShardId: request.GetShardId(),
+		Apply:   request.GetApply(),
+	})
+	if err != nil {
+		return nil, adh.error(err, scope)
+	}
+	return &adminservice.RepairShardAckLevelsResponse{
+		Queues: resp.GetQueues(),
+	}, nil
+}
+
+// ForceTerminateWorkflowExecution closes a workflow with a terminated event recording the operator's reason
+func (adh *AdminHandler) ForceTerminateWorkflowExecution(
+	ctx context.Context,
+	request *adminservice.ForceTerminateWorkflowExecutionRequest,
+) (_ *adminservice.ForceTerminateWorkflowExecutionResponse, err error) {
+	defer log.CapturePanicGRPC(adh.GetLogger(), &err)
+	scope, sw := adh.startRequestProfile(metrics.AdminForceTerminateWorkflowExecutionScope)
+	defer sw.Stop()
+
+	if request == nil {
+		return nil, adh.error(errRequestNotSet, scope)
+	}
+	if err := validateExecution(request.Execution); err != nil {
+		return nil, adh.error(err, scope)
+	}
+	if request.GetReason() == "" {
+		return nil, adh.error(errReasonNotSet, scope)
+	}
+	namespaceEntry, err := adh.GetNamespaceCache().GetNamespace(request.GetNamespace())
+	if err != nil {
+		return nil, adh.error(err, scope)
+	}
+
+	_, err = adh.GetHistoryClient().ForceTerminateWorkflowExecution(ctx, &historyservice.ForceTerminateWorkflowExecutionRequest{
+		NamespaceId: namespaceEntry.GetInfo().ID,
+		Request:     request,
+	})
+	if err != nil {
+		return nil, adh.error(err, scope)
+	}
+	return &adminservice.ForceTerminateWorkflowExecutionResponse{}, nil
+}
+
+// EstimateTaskListBacklog returns the approximate number of tasks persisted for a task list which are not acked yet
+func (adh *AdminHandler) EstimateTaskListBacklog(
+	ctx context.Context,
+	request *adminservice.EstimateTaskListBacklogRequest,
+) (_ *adminservice.EstimateTaskListBacklogResponse, err error) {
+	defer log.CapturePanicGRPC(adh.GetLogger(), &err)
+	scope, sw := adh.startRequestProfile(metrics.AdminEstimateTaskListBacklogScope)
+	defer sw.Stop()
+
+	if request == nil {
+		return nil, adh.error(errRequestNotSet, scope)
+	}
+	if request.GetTaskList() == "" {
+		return nil, adh.error(errTaskListNotSet, scope)
+	}
+	taskListType := request.GetTaskListType()
+	if taskListType != persistence.TaskListTypeDecision && taskListType != persistence.TaskListTypeActivity {
+		return nil, adh.error(errInvalidTaskListType, scope)
+	}
+	namespaceEntry, err := adh.GetNamespaceCache().GetNamespace(request.GetNamespace())
+	if err != nil {
+		return nil, adh.error(err, scope)
+	}
+
+	count, err := adh.GetTaskManager().EstimateBacklogCount(&persistence.EstimateBacklogCountRequest{
+		NamespaceID: primitives.MustParseUUID(namespaceEntry.GetInfo().ID),
+		TaskList:    request.GetTaskList(),
+		TaskType:    taskListType,
+	})
+	if err != nil {
+		return nil, adh.error(err, scope)
+	}
+	return &adminservice.EstimateTaskListBacklogResponse{BacklogCount: count}, nil
+}
+
+// ListOwnedTaskLists returns the task lists currently loaded, and so leased, by a matching host
+func (adh *AdminHandler) ListOwnedTaskLists(
+	ctx context.Context,
+	request *adminservice.ListOwnedTaskListsRequest,
+) (_ *adminservice.ListOwnedTaskListsResponse, err error) {
+	defer log.CapturePanicGRPC(adh.GetLogger(), &err)
+	scope, sw := adh.startRequestProfile(metrics.AdminListOwnedTaskListsScope)
+	defer sw.Stop()
+
+	if request == nil {
+		return nil, adh.error(errRequestNotSet, scope)
+	}
+	hostAddress := request.GetHostAddress()
+	if hostAddress == "" {
+		return nil, adh.error(errHostAddressNotSet, scope)
+	}
+
+	resolver, err := adh.GetMembershipMonitor().GetResolver(common.MatchingServiceName)
+	if err != nil {
+		return nil, adh.error(err, scope)
+	}
+	isMatchingHost := false
+	for _, host := range resolver.Members() {
+		if host.GetAddress() == hostAddress {
+			isMatchingHost = true
+			break
+		}
+	}
+	if !isMatchingHost {
+		return nil, adh.error(serviceerror.NewInvalidArgument(fmt.Sprintf("Host %v is not a matching host.", hostAddress)), scope)
+	}
+
+	resp, err := adh.GetMatchingClient().ListOwnedTaskLists(ctx, &matchingservice.ListOwnedTaskListsRequest{
+		HostAddress: hostAddress,
+	})
+	if err != nil {
+		return nil, adh.error(err, scope)
+	}
+	return &adminservice.ListOwnedTaskListsResponse{TaskLists: resp.GetTaskLists()}, nil
+}
+
+// DescribeTaskListStatus returns whether a task list is draining a backlog of persisted tasks, its backlog
+// read and ack levels and whether it forwards to its parent partition
+func (adh *AdminHandler) DescribeTaskListStatus(
+	ctx context.Context,
+	request *adminservice.DescribeTaskListStatusRequest,
+) (_ *adminservice.DescribeTaskListStatusResponse, err error) {
+	defer log.CapturePanicGRPC(adh.GetLogger(), &err)
+	scope, sw := adh.startRequestProfile(metrics.AdminDescribeTaskListStatusScope)
+	defer sw.Stop()
+
+	if request == nil {
+		return nil, adh.error(errRequestNotSet, scope)
+	}
+	if request.GetTaskList() == "" {
+		return nil, adh.error(errTaskListNotSet, scope)
+	}
+	taskListType := request.GetTaskListType()
+	if taskListType != persistence.TaskListTypeDecision && taskListType != persistence.TaskListTypeActivity {
+		return nil, adh.error(errInvalidTaskListType, scope)
+	}
+	namespaceID, err := adh.GetNamespaceCache().GetNamespaceID(request.GetNamespace())
+	if err != nil {
+		return nil, adh.error(err, scope)
+	}
+
+	resp, err := adh.GetMatchingClient().DescribeTaskList(ctx, &matchingservice.DescribeTaskListRequest{
+		NamespaceId: namespaceID,
+		DescRequest: &workflowservice.DescribeTaskListRequest{
+			Namespace:             request.GetNamespace(),
+			TaskList:              &tasklistpb.TaskList{Name: request.GetTaskList()},
+			TaskListType:          tasklistpb.TaskListType(taskListType),
+			IncludeTaskListStatus: true,
+		},
+	})
+	if err != nil {
+		return nil, adh.error(err, scope)
+	}
+	return &adminservice.DescribeTaskListStatusResponse{BacklogStatus: resp.GetBacklogStatus()}, nil
+}
+
+// InjectTask enqueues a synthetic task into the backlog of a task list without going through history,
+// it is meant for development only and is rejected by matching unless task injection is enabled
+func (adh *AdminHandler) InjectTask(
+	ctx context.Context,
+	request *adminservice.InjectTaskRequest,
+) (_ *adminservice.InjectTaskResponse, err error) {
+	defer log.CapturePanicGRPC(adh.GetLogger(), &err)
+	scope, sw := adh.startRequestProfile(metrics.AdminInjectTaskScope)
+	defer sw.Stop()
+
+	if request == nil {
+		return nil, adh.error(errRequestNotSet, scope)
+	}
+	if request.GetTaskList() == "" {
+		return nil, adh.error(errTaskListNotSet, scope)
+	}
+	taskListType := request.GetTaskListType()
+	if taskListType != persistence.TaskListTypeDecision && taskListType != persistence.TaskListTypeActivity {
+		return nil, adh.error(errInvalidTaskListType, scope)
+	}
+	namespaceID, err := adh.GetNamespaceCache().GetNamespaceID(request.GetNamespace())
+	if err != nil {
+		return nil, adh.error(err, scope)
+	}
+
+	resp, err := adh.GetMatchingClient().InjectTask(ctx, &matchingservice.InjectTaskRequest{
+		NamespaceId:                   namespaceID,
+		TaskList:                      &tasklistpb.TaskList{Name: request.GetTaskList()},
+		TaskListType:                  taskListType,
+		ScheduleToStartTimeoutSeconds: request.GetScheduleToStartTimeoutSeconds(),
+	})
+	if err != nil {
+		return nil, adh.error(err, scope)
+	}
+	return &adminservice.InjectTaskResponse{
+		Execution:  resp.GetExecution(),
+		ScheduleId: resp.GetScheduleId(),
+	}, nil
+}
+
+// CompleteTasksBelow completes all tasks of a task list with a task id at or below the given one. It goes through
+// the matching host owning the task list rather than the database, so the in-memory ack level of the host moves along
+func (adh *AdminHandler) CompleteTasksBelow(
+	ctx context.Context,
+	request *adminservice.CompleteTasksBelowRequest,
+) (_ *adminservice.CompleteTasksBelowResponse, err error) {
+	defer log.CapturePanicGRPC(adh.GetLogger(), &err)
+	scope, sw := adh.startRequestProfile(metrics.AdminCompleteTasksBelowScope)
+	defer sw.Stop()
+
+	if request == nil {
+		return nil, adh.error(errRequestNotSet, scope)
+	}
+	if request.GetTaskList() == "" {
+		return nil, adh.error(errTaskListNotSet, scope)
+	}
+	taskListType := request.GetTaskListType()
+	if taskListType != persistence.TaskListTypeDecision && taskListType != persistence.TaskListTypeActivity {
+		return nil, adh.error(errInvalidTaskListType, scope)
+	}
+	namespaceID, err := adh.GetNamespaceCache().GetNamespaceID(request.GetNamespace())
+	if err != nil {
+		return nil, adh.error(err, scope)
+	}
+
+	resp, err := adh.GetMatchingClient().CompleteTasksBelow(ctx, &matchingservice.CompleteTasksBelowRequest{
+		NamespaceId:  namespaceID,
+		TaskList:     &tasklistpb.TaskList{Name: request.GetTaskList()},
+		TaskListType: taskListType,
+		TaskId:       request.GetTaskId(),
+	})
+	if err != nil {
+		return nil, adh.error(err, scope)
+	}
+	return &adminservice.CompleteTasksBelowResponse{AckLevel: resp.GetAckLevel()}, nil
+}
+
+// ListWorkflowTimers returns the user timers of a workflow, flagging the ones not backed by their timer started event
+func (adh *AdminHandler) ListWorkflowTimers(
+	ctx context.Context,
+	request *adminservice.ListWorkflowTimersRequest,
+) (_ *adminservice.ListWorkflowTimersResponse, err error) {
+	defer log.CapturePanicGRPC(adh.GetLogger(), &err)
+	scope, sw := adh.startRequestProfile(metrics.AdminListWorkflowTimersScope)
+	defer sw.Stop()
+
+	if request == nil {
+		return nil, adh.error(errRequestNotSet, scope)
+	}
+	if err := validateExecution(request.Execution); err != nil {
+		return nil, adh.error(err, scope)
+	}
+	namespaceEntry, err := adh.GetNamespaceCache().GetNamespace(request.GetNamespace())
+	if err != nil {
+		return nil, adh.error(err, scope)
+	}
+
+	resp, err := adh.GetHistoryClient().ListWorkflowTimers(ctx, &historyservice.ListWorkflowTimersRequest{
+		NamespaceId: namespaceEntry.GetInfo().ID,
+		Execution:   request.Execution,
+	})
+	if err != nil {
+		return nil, adh.error(err, scope)
+	}
+	return &adminservice.ListWorkflowTimersResponse{
+		Timers: resp.GetTimers(),
+	}, nil
+}
+
+// CancelOrphanedTimer removes a user timer not backed by its timer started event from the mutable state of a workflow
+func (adh *AdminHandler) CancelOrphanedTimer(
+	ctx context.Context,
+	request *adminservice.CancelOrphanedTimerRequest,
+) (_ *adminservice.CancelOrphanedTimerResponse, err error) {
+	defer log.CapturePanicGRPC(adh.GetLogger(), &err)
+	scope, sw := adh.startRequestProfile(metrics.AdminCancelOrphanedTimerScope)
+	defer sw.Stop()
+
+	if request == nil {
+		return nil, adh.error(errRequestNotSet, scope)
+	}
+	if err := validateExecution(request.Execution); err != nil {
+		return nil, adh.error(err, scope)
+	}
+	if request.GetTimerId() == "" {
+		return nil, adh.error(errTimerIDNotSet, scope)
+	}
+	namespaceEntry, err := adh.GetNamespaceCache().GetNamespace(request.GetNamespace())
+	if err != nil {
+		return nil, adh.error(err, scope)
+	}
+
+	_, err = adh.GetHistoryClient().CancelOrphanedTimer(ctx, &historyservice.CancelOrphanedTimerRequest{
+		NamespaceId: namespaceEntry.GetInfo().ID,
+		Execution:   request.Execution,
+		TimerId:     request.GetTimerId(),
+	})
+	if err != nil {
+		return nil, adh.error(err, scope)
+	}
+	return &adminservice.CancelOrphanedTimerResponse{}, nil
+}
+
+// DLQReplicationTask puts a replication task received from a source cluster into DLQ instead of applying it
+func (adh *AdminHandler) DLQReplicationTask(
+	ctx context.Context,
+	request *adminservice.DLQReplicationTaskRequest,
+) (_ *adminservice.DLQReplicationTaskResponse, err error) {
+	defer log.CapturePanicGRPC(adh.GetLogger(), &err)
+	scope, sw := adh.startRequestProfile(metrics.AdminDLQReplicationTaskScope)
+	defer sw.Stop()
+
+	if request == nil {
+		return nil, adh.error(errRequestNotSet, scope)
+	}
+	if request.GetSourceCluster() == "" {
+		return nil, adh.error(errClusterNameNotSet, scope)
+	}
+
+	if _, err := adh.GetHistoryClient().DLQReplicationTask(ctx, &historyservice.DLQReplicationTaskRequest{
+		ShardId:       request.GetShardId(),
+		SourceCluster: request.GetSourceCluster(),
+		TaskId:        request.GetTaskId(),
+		Reason:        request.GetReason(),
+	}); err != nil {
+		return nil, adh.error(err, scope)
+	}
+	return &adminservice.DLQReplicationTaskResponse{}, nil
+}
+
+// RefreshNamespaceCache reloads a namespace from persistence into the namespace cache of every history
+// and matching host in the membership ring, as well as of this frontend host. Failures are reported
+// per host instead of failing the whole request
+func (adh *AdminHandler) RefreshNamespaceCache(
+	ctx context.Context,
+	request *adminservice.RefreshNamespaceCacheRequest,
+) (_ *adminservice.RefreshNamespaceCacheResponse, err error) {
+	defer log.CapturePanicGRPC(adh.GetLogger(), &err)
+	scope, sw := adh.startRequestProfile(metrics.AdminRefreshNamespaceCacheScope)
+	defer sw.Stop()
+
+	if request == nil {
+		return nil, adh.error(errRequestNotSet, scope)
+	}
+	namespace := request.GetNamespace()
+	if namespace == "" {
+		return nil, adh.error(errNamespaceNotSet, scope)
+	}
+
+	resp := &adminservice.RefreshNamespaceCacheResponse{}
+	resp.Results = append(resp.Results, newRefreshNamespaceCacheHostResult(
+		common.FrontendServiceName,
+		adh.GetHostInfo().GetAddress(),
+		adh.GetNamespaceCache().RefreshNamespace(namespace),
+	))
+
+	for _, role := range []string{common.HistoryServiceName, common.MatchingServiceName} {
+		resolver, err := adh.GetMembershipMonitor().GetResolver(role)
+		if err != nil {
+			return nil, adh.error(err, scope)
+		}
+		for _, host := range resolver.Members() {
+			var err error
+			switch role {
+			case common.HistoryServiceName:
+				_, err = adh.GetHistoryClient().RefreshNamespaceCache(ctx, &historyservice.RefreshNamespaceCacheRequest{
+					Namespace:   namespace,
+					HostAddress: host.GetAddress(),
+				})
+			case common.MatchingServiceName:
+				_, err = adh.GetMatchingClient().RefreshNamespaceCache(ctx, &matchingservice.RefreshNamespaceCacheRequest{
+					Namespace:   namespace,
+					HostAddress: host.GetAddress(),
+				})
+			}
+			resp.Results = append(resp.Results, newRefreshNamespaceCacheHostResult(role, host.GetAddress(), err))
+		}
+	}
+	return resp, nil
+}
+
+func newRefreshNamespaceCacheHostResult(
+	service string,
+	hostAddress string,
+	err error,
+) *adminservice.RefreshNamespaceCacheHostResult {
+	result := &adminservice.RefreshNamespaceCacheHostResult{
+		Service:     service,
+		HostAddress: hostAddress,
+	}
+	if err != nil {
+		result.Error = err.Error()
+	}
+	return result
+}
+
+// TailWorkflowExecutionHistory streams the history events of a workflow execution as they are written,
+// relaying them from the history host owning the workflow until the workflow closes or the caller goes away
+func (adh *AdminHandler) TailWorkflowExecutionHistory(
+	request *adminservice.TailWorkflowExecutionHistoryRequest,
+	stream adminservice.AdminService_TailWorkflowExecutionHistoryServer,
+) (err error) {
+	defer log.CapturePanicGRPC(adh.GetLogger(), &err)
+	scope, sw := adh.startRequestProfile(metrics.AdminTailWorkflowExecutionHistoryScope)
+	defer sw.Stop()
+
+	if request == nil {
+		return adh.error(errRequestNotSet, scope)
+	}
+	if request.GetNamespace() == "" {
+		return adh.error(errNamespaceNotSet, scope)
+	}
+	if err := validateExecution(request.Execution); err != nil {
+		return adh.error(err, scope)
+	}
+
+	namespaceID, err := adh.GetNamespaceCache().GetNamespaceID(request.GetNamespace())
+	if err != nil {
+		return adh.error(err, scope)
+	}
+
+	historyStream, err := adh.GetHistoryClient().TailWorkflowExecutionHistory(stream.Context(), &historyservice.TailWorkflowExecutionHistoryRequest{
+		NamespaceId: namespaceID,
+		Execution:   request.Execution,
+	})
+	if err != nil {
+		return adh.error(err, scope)
+	}
+	for {
+		resp, err := historyStream.Recv()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return adh.error(err, scope)
+		}
+		if err := stream.Send(&adminservice.TailWorkflowExecutionHistoryResponse{Events: resp.GetEvents()}); err != nil {
+			return adh.error(err, scope)
+		}
+	}
+}
+
 func (adh *AdminHandler) validateGetWorkflowExecutionRawHistoryV2Request(
 	request *adminservice.GetWorkflowExecutionRawHistoryV2Request,
 ) error {
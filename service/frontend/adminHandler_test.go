@@ -37,18 +37,26 @@ import (
 	commonpb "go.temporal.io/temporal-proto/common"
 	executionpb "go.temporal.io/temporal-proto/execution"
 	"go.temporal.io/temporal-proto/serviceerror"
+	tasklistpb "go.temporal.io/temporal-proto/tasklist"
+	"go.temporal.io/temporal-proto/workflowservice"
 
 	"github.com/temporalio/temporal/.gen/proto/adminservice"
+	commongenpb "github.com/temporalio/temporal/.gen/proto/common"
 	"github.com/temporalio/temporal/.gen/proto/historyservice"
 	"github.com/temporalio/temporal/.gen/proto/historyservicemock"
+	"github.com/temporalio/temporal/.gen/proto/matchingservice"
+	"github.com/temporalio/temporal/.gen/proto/persistenceblobs"
 	"github.com/temporalio/temporal/common"
 	"github.com/temporalio/temporal/common/cache"
 	"github.com/temporalio/temporal/common/definition"
 	"github.com/temporalio/temporal/common/elasticsearch"
 	esmock "github.com/temporalio/temporal/common/elasticsearch/mocks"
+	"github.com/temporalio/temporal/common/membership"
 	"github.com/temporalio/temporal/common/metrics"
 	"github.com/temporalio/temporal/common/mocks"
+	"github.com/temporalio/temporal/common/namespace"
 	"github.com/temporalio/temporal/common/persistence"
+	"github.com/temporalio/temporal/common/primitives"
 	"github.com/temporalio/temporal/common/resource"
 	"github.com/temporalio/temporal/common/service/config"
 	"github.com/temporalio/temporal/common/service/dynamicconfig"
@@ -226,6 +234,243 @@ func (s *adminHandlerSuite) Test_GetWorkflowExecutionRawHistoryV2_FailedOnNamesp
 	s.Error(err)
 }
 
+func (s *adminHandlerSuite) Test_RefreshNamespaceCache() {
+	ctx := context.Background()
+	historyHost1 := membership.NewHostInfo("history-1", nil)
+	historyHost2 := membership.NewHostInfo("history-2", nil)
+	matchingHost := membership.NewHostInfo("matching-1", nil)
+
+	s.mockNamespaceCache.EXPECT().RefreshNamespace(s.namespace).Return(nil).Times(1)
+	s.mockResource.MembershipMonitor.EXPECT().GetResolver(common.HistoryServiceName).Return(s.mockResource.HistoryServiceResolver, nil).Times(1)
+	s.mockResource.MembershipMonitor.EXPECT().GetResolver(common.MatchingServiceName).Return(s.mockResource.MatchingServiceResolver, nil).Times(1)
+	s.mockResource.HistoryServiceResolver.EXPECT().Members().Return([]*membership.HostInfo{historyHost1, historyHost2}).Times(1)
+	s.mockResource.MatchingServiceResolver.EXPECT().Members().Return([]*membership.HostInfo{matchingHost}).Times(1)
+	s.mockHistoryClient.EXPECT().RefreshNamespaceCache(gomock.Any(), &historyservice.RefreshNamespaceCacheRequest{
+		Namespace:   s.namespace,
+		HostAddress: historyHost1.GetAddress(),
+	}).Return(&historyservice.RefreshNamespaceCacheResponse{}, nil).Times(1)
+	s.mockHistoryClient.EXPECT().RefreshNamespaceCache(gomock.Any(), &historyservice.RefreshNamespaceCacheRequest{
+		Namespace:   s.namespace,
+		HostAddress: historyHost2.GetAddress(),
+	}).Return(nil, errors.New("some random error")).Times(1)
+	s.mockResource.MatchingClient.EXPECT().RefreshNamespaceCache(gomock.Any(), &matchingservice.RefreshNamespaceCacheRequest{
+		Namespace:   s.namespace,
+		HostAddress: matchingHost.GetAddress(),
+	}).Return(&matchingservice.RefreshNamespaceCacheResponse{}, nil).Times(1)
+
+	resp, err := s.handler.RefreshNamespaceCache(ctx, &adminservice.RefreshNamespaceCacheRequest{Namespace: s.namespace})
+	s.NoError(err)
+	s.Equal([]*adminservice.RefreshNamespaceCacheHostResult{
+		{Service: common.FrontendServiceName, HostAddress: s.mockResource.GetHostInfo().GetAddress()},
+		{Service: common.HistoryServiceName, HostAddress: historyHost1.GetAddress()},
+		{Service: common.HistoryServiceName, HostAddress: historyHost2.GetAddress(), Error: "some random error"},
+		{Service: common.MatchingServiceName, HostAddress: matchingHost.GetAddress()},
+	}, resp.GetResults())
+}
+
+func (s *adminHandlerSuite) Test_RefreshNamespaceCache_NamespaceNotSet() {
+	_, err := s.handler.RefreshNamespaceCache(context.Background(), &adminservice.RefreshNamespaceCacheRequest{})
+	s.Equal(errNamespaceNotSet, err)
+}
+
+func (s *adminHandlerSuite) Test_ListOwnedTaskLists() {
+	matchingHost := membership.NewHostInfo("matching-1", nil)
+	ownedTaskLists := []*adminservice.OwnedTaskList{
+		{NamespaceId: s.namespaceID, Namespace: s.namespace, Name: "some random task list", TaskListType: persistence.TaskListTypeDecision, Kind: tasklistpb.TaskListKindNormal, BacklogCountHint: 10},
+		{NamespaceId: s.namespaceID, Namespace: s.namespace, Name: "some random sticky task list", TaskListType: persistence.TaskListTypeDecision, Kind: tasklistpb.TaskListKindSticky},
+	}
+
+	s.mockResource.MembershipMonitor.EXPECT().GetResolver(common.MatchingServiceName).Return(s.mockResource.MatchingServiceResolver, nil).Times(1)
+	s.mockResource.MatchingServiceResolver.EXPECT().Members().Return([]*membership.HostInfo{matchingHost}).Times(1)
+	s.mockResource.MatchingClient.EXPECT().ListOwnedTaskLists(gomock.Any(), &matchingservice.ListOwnedTaskListsRequest{
+		HostAddress: matchingHost.GetAddress(),
+	}).Return(&matchingservice.ListOwnedTaskListsResponse{TaskLists: ownedTaskLists}, nil).Times(1)
+
+	resp, err := s.handler.ListOwnedTaskLists(context.Background(), &adminservice.ListOwnedTaskListsRequest{
+		HostAddress: matchingHost.GetAddress(),
+	})
+	s.NoError(err)
+	s.Equal(ownedTaskLists, resp.GetTaskLists())
+}
+
+func (s *adminHandlerSuite) Test_ListOwnedTaskLists_UnknownHost() {
+	s.mockResource.MembershipMonitor.EXPECT().GetResolver(common.MatchingServiceName).Return(s.mockResource.MatchingServiceResolver, nil).Times(1)
+	s.mockResource.MatchingServiceResolver.EXPECT().Members().Return([]*membership.HostInfo{membership.NewHostInfo("matching-1", nil)}).Times(1)
+
+	_, err := s.handler.ListOwnedTaskLists(context.Background(), &adminservice.ListOwnedTaskListsRequest{
+		HostAddress: "some random host",
+	})
+	s.IsType(&serviceerror.InvalidArgument{}, err)
+}
+
+func (s *adminHandlerSuite) Test_DescribeWorkflowExecution_PendingChildrenFailure() {
+	execution := &executionpb.WorkflowExecution{
+		WorkflowId: "some random workflow ID",
+		RunId:      uuid.New(),
+	}
+	s.mockResource.MembershipMonitor.EXPECT().Lookup(common.HistoryServiceName, gomock.Any()).Return(membership.NewHostInfo("history-1", nil), nil).Times(1)
+	s.mockNamespaceCache.EXPECT().GetNamespaceID(s.namespace).Return(s.namespaceID, nil).Times(1)
+	s.mockHistoryClient.EXPECT().DescribeMutableState(gomock.Any(), &historyservice.DescribeMutableStateRequest{
+		NamespaceId: s.namespaceID,
+		Execution:   execution,
+	}).Return(&historyservice.DescribeMutableStateResponse{
+		MutableStateInDatabase: "some random mutable state",
+		BufferedEventsCount:    2,
+	}, nil).Times(1)
+	s.mockHistoryClient.EXPECT().GetPendingChildren(gomock.Any(), &historyservice.GetPendingChildrenRequest{
+		NamespaceId: s.namespaceID,
+		Execution:   execution,
+	}).Return(nil, serviceerror.NewInternal("some random error")).Times(1)
+
+	resp, err := s.handler.DescribeWorkflowExecution(context.Background(), &adminservice.DescribeWorkflowExecutionRequest{
+		Namespace: s.namespace,
+		Execution: execution,
+	})
+	s.NoError(err)
+	s.Equal("history-1", resp.GetHistoryAddr())
+	s.Equal("some random mutable state", resp.GetMutableStateInDatabase())
+	s.Equal(int32(2), resp.GetBufferedEventsCount())
+	s.Empty(resp.GetPendingChildren())
+}
+
+func (s *adminHandlerSuite) Test_DescribeTaskListStatus() {
+	backlogStatus := &adminservice.TaskListBacklogStatus{BacklogMode: true, ReadLevel: 10, AckLevel: 5}
+	s.mockNamespaceCache.EXPECT().GetNamespaceID(s.namespace).Return(s.namespaceID, nil).Times(1)
+	s.mockResource.MatchingClient.EXPECT().DescribeTaskList(gomock.Any(), &matchingservice.DescribeTaskListRequest{
+		NamespaceId: s.namespaceID,
+		DescRequest: &workflowservice.DescribeTaskListRequest{
+			Namespace:             s.namespace,
+			TaskList:              &tasklistpb.TaskList{Name: "some random task list"},
+			TaskListType:          tasklistpb.TaskListTypeActivity,
+			IncludeTaskListStatus: true,
+		},
+	}).Return(&matchingservice.DescribeTaskListResponse{BacklogStatus: backlogStatus}, nil).Times(1)
+
+	resp, err := s.handler.DescribeTaskListStatus(context.Background(), &adminservice.DescribeTaskListStatusRequest{
+		Namespace:    s.namespace,
+		TaskList:     "some random task list",
+		TaskListType: persistence.TaskListTypeActivity,
+	})
+	s.NoError(err)
+	s.Equal(backlogStatus, resp.GetBacklogStatus())
+}
+
+func (s *adminHandlerSuite) Test_DescribeTaskListStatus_InvalidTaskListType() {
+	_, err := s.handler.DescribeTaskListStatus(context.Background(), &adminservice.DescribeTaskListStatusRequest{
+		Namespace:    s.namespace,
+		TaskList:     "some random task list",
+		TaskListType: 2,
+	})
+	s.Equal(errInvalidTaskListType, err)
+}
+
+func (s *adminHandlerSuite) Test_InjectTask() {
+	execution := &executionpb.WorkflowExecution{WorkflowId: "some random workflow ID", RunId: uuid.New()}
+	s.mockNamespaceCache.EXPECT().GetNamespaceID(s.namespace).Return(s.namespaceID, nil).Times(1)
+	s.mockResource.MatchingClient.EXPECT().InjectTask(gomock.Any(), &matchingservice.InjectTaskRequest{
+		NamespaceId:                   s.namespaceID,
+		TaskList:                      &tasklistpb.TaskList{Name: "some random task list"},
+		TaskListType:                  persistence.TaskListTypeDecision,
+		ScheduleToStartTimeoutSeconds: 60,
+	}).Return(&matchingservice.InjectTaskResponse{Execution: execution, ScheduleId: 5}, nil).Times(1)
+
+	resp, err := s.handler.InjectTask(context.Background(), &adminservice.InjectTaskRequest{
+		Namespace:                     s.namespace,
+		TaskList:                      "some random task list",
+		TaskListType:                  persistence.TaskListTypeDecision,
+		ScheduleToStartTimeoutSeconds: 60,
+	})
+	s.NoError(err)
+	s.Equal(execution, resp.GetExecution())
+	s.Equal(int64(5), resp.GetScheduleId())
+}
+
+func (s *adminHandlerSuite) Test_CompleteTasksBelow() {
+	s.mockNamespaceCache.EXPECT().GetNamespaceID(s.namespace).Return(s.namespaceID, nil).Times(1)
+	s.mockResource.MatchingClient.EXPECT().CompleteTasksBelow(gomock.Any(), &matchingservice.CompleteTasksBelowRequest{
+		NamespaceId:  s.namespaceID,
+		TaskList:     &tasklistpb.TaskList{Name: "some random task list"},
+		TaskListType: persistence.TaskListTypeActivity,
+		TaskId:       1234,
+	}).Return(&matchingservice.CompleteTasksBelowResponse{AckLevel: 1234}, nil).Times(1)
+
+	resp, err := s.handler.CompleteTasksBelow(context.Background(), &adminservice.CompleteTasksBelowRequest{
+		Namespace:    s.namespace,
+		TaskList:     "some random task list",
+		TaskListType: persistence.TaskListTypeActivity,
+		TaskId:       1234,
+	})
+	s.NoError(err)
+	s.Equal(int64(1234), resp.GetAckLevel())
+}
+
+func (s *adminHandlerSuite) Test_MergeDLQMessages_DecisionTask() {
+	namespaceID := uuid.New()
+	runningTask := &persistenceblobs.TransferTaskInfo{
+		NamespaceId: primitives.MustParseUUID(namespaceID),
+		WorkflowId:  "some random workflow ID",
+		RunId:       primitives.MustParseUUID(uuid.New()),
+	}
+	closedTask := &persistenceblobs.TransferTaskInfo{
+		NamespaceId: primitives.MustParseUUID(namespaceID),
+		WorkflowId:  "some other random workflow ID",
+		RunId:       primitives.MustParseUUID(uuid.New()),
+	}
+	namespaceEntry := cache.NewLocalNamespaceCacheEntryForTest(
+		&persistence.NamespaceInfo{ID: namespaceID, Name: s.namespace}, &persistence.NamespaceConfig{}, "", nil)
+	s.mockResource.DecisionTaskDLQ.EXPECT().Read(int(common.EndMessageID), 10, nil).
+		Return([]*persistenceblobs.TransferTaskInfo{runningTask, closedTask}, 7, nil, nil).Times(1)
+	s.mockNamespaceCache.EXPECT().GetNamespaceByID(namespaceID).Return(namespaceEntry, nil).Times(2)
+	s.mockHistoryClient.EXPECT().RefreshWorkflowTasks(gomock.Any(), &historyservice.RefreshWorkflowTasksRequest{
+		NamespaceId: namespaceID,
+		Request: &adminservice.RefreshWorkflowTasksRequest{
+			Namespace: s.namespace,
+			Execution: &executionpb.WorkflowExecution{
+				WorkflowId: runningTask.GetWorkflowId(),
+				RunId:      primitives.UUIDString(runningTask.GetRunId()),
+			},
+		},
+	}).Return(&historyservice.RefreshWorkflowTasksResponse{}, nil).Times(1)
+	s.mockHistoryClient.EXPECT().RefreshWorkflowTasks(gomock.Any(), &historyservice.RefreshWorkflowTasksRequest{
+		NamespaceId: namespaceID,
+		Request: &adminservice.RefreshWorkflowTasksRequest{
+			Namespace: s.namespace,
+			Execution: &executionpb.WorkflowExecution{
+				WorkflowId: closedTask.GetWorkflowId(),
+				RunId:      primitives.UUIDString(closedTask.GetRunId()),
+			},
+		},
+	}).Return(nil, serviceerror.NewNotFound("")).Times(1)
+	s.mockResource.DecisionTaskDLQ.EXPECT().RangeDelete(7).Return(nil).Times(1)
+
+	resp, err := s.handler.MergeDLQMessages(context.Background(), &adminservice.MergeDLQMessagesRequest{
+		Type:            commongenpb.DLQTypeDecisionTask,
+		MaximumPageSize: 10,
+	})
+	s.NoError(err)
+	s.Empty(resp.GetNextPageToken())
+}
+
+func (s *adminHandlerSuite) Test_MergeOneDLQMessage() {
+	mockDLQHandler := namespace.NewMockDLQMessageHandler(s.controller)
+	s.handler.namespaceDLQHandler = mockDLQHandler
+	mockDLQHandler.EXPECT().MergeOne(12).Return(nil).Times(1)
+
+	_, err := s.handler.MergeOneDLQMessage(context.Background(), &adminservice.MergeOneDLQMessageRequest{
+		Type:      commongenpb.DLQTypeNamespace,
+		MessageId: 12,
+	})
+	s.NoError(err)
+}
+
+func (s *adminHandlerSuite) Test_MergeOneDLQMessage_ReplicationDLQNotSupported() {
+	_, err := s.handler.MergeOneDLQMessage(context.Background(), &adminservice.MergeOneDLQMessageRequest{
+		Type:      commongenpb.DLQTypeReplication,
+		MessageId: 12,
+	})
+	s.Equal(errDLQTypeIsNotSupported, err)
+}
+
 func (s *adminHandlerSuite) Test_GetWorkflowExecutionRawHistoryV2() {
 	ctx := context.Background()
 	s.mockNamespaceCache.EXPECT().GetNamespaceID(s.namespace).Return(s.namespaceID, nil).AnyTimes()
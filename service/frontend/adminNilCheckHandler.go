@@ -185,6 +185,15 @@ func (adh *AdminNilCheckHandler) MergeDLQMessages(ctx context.Context, request *
 	return resp, err
 }
 
+// MergeOneDLQMessage merges a single message from the namespace DLQ
+func (adh *AdminNilCheckHandler) MergeOneDLQMessage(ctx context.Context, request *adminservice.MergeOneDLQMessageRequest) (*adminservice.MergeOneDLQMessageResponse, error) {
+	resp, err := adh.parentHandler.MergeOneDLQMessage(ctx, request)
+	if resp == nil && err == nil {
+		resp = &adminservice.MergeOneDLQMessageResponse{}
+	}
+	return resp, err
+}
+
 // RefreshWorkflowTasks refreshes all tasks of a workflow
 func (adh *AdminNilCheckHandler) RefreshWorkflowTasks(ctx context.Context, request *adminservice.RefreshWorkflowTasksRequest) (*adminservice.RefreshWorkflowTasksResponse, error) {
 	resp, err := adh.parentHandler.RefreshWorkflowTasks(ctx, request)
@@ -193,3 +202,116 @@ func (adh *AdminNilCheckHandler) RefreshWorkflowTasks(ctx context.Context, reque
 	}
 	return resp, err
 }
+
+// ResetReplicationAckLevel resets the replication ack level of a remote cluster
+func (adh *AdminNilCheckHandler) ResetReplicationAckLevel(ctx context.Context, request *adminservice.ResetReplicationAckLevelRequest) (*adminservice.ResetReplicationAckLevelResponse, error) {
+	resp, err := adh.parentHandler.ResetReplicationAckLevel(ctx, request)
+	if resp == nil && err == nil {
+		resp = &adminservice.ResetReplicationAckLevelResponse{}
+	}
+	return resp, err
+}
+
+// DLQReplicationTask puts a replication task into DLQ
+func (adh *AdminNilCheckHandler) DLQReplicationTask(ctx context.Context, request *adminservice.DLQReplicationTaskRequest) (*adminservice.DLQReplicationTaskResponse, error) {
+	resp, err := adh.parentHandler.DLQReplicationTask(ctx, request)
+	if resp == nil && err == nil {
+		resp = &adminservice.DLQReplicationTaskResponse{}
+	}
+	return resp, err
+}
+
+// RefreshNamespaceCache reloads a namespace into the namespace caches of the cluster
+func (adh *AdminNilCheckHandler) RefreshNamespaceCache(ctx context.Context, request *adminservice.RefreshNamespaceCacheRequest) (*adminservice.RefreshNamespaceCacheResponse, error) {
+	resp, err := adh.parentHandler.RefreshNamespaceCache(ctx, request)
+	if resp == nil && err == nil {
+		resp = &adminservice.RefreshNamespaceCacheResponse{}
+	}
+	return resp, err
+}
+
+// TailWorkflowExecutionHistory streams the history events of a workflow execution as they are written
+func (adh *AdminNilCheckHandler) TailWorkflowExecutionHistory(request *adminservice.TailWorkflowExecutionHistoryRequest, stream adminservice.AdminService_TailWorkflowExecutionHistoryServer) error {
+	return adh.parentHandler.TailWorkflowExecutionHistory(request, stream)
+}
+
+// RepairShardAckLevels recomputes the task queue ack levels of a shard
+func (adh *AdminNilCheckHandler) RepairShardAckLevels(ctx context.Context, request *adminservice.RepairShardAckLevelsRequest) (*adminservice.RepairShardAckLevelsResponse, error) {
+	resp, err := adh.parentHandler.RepairShardAckLevels(ctx, request)
+	if resp == nil && err == nil {
+		resp = &adminservice.RepairShardAckLevelsResponse{}
+	}
+	return resp, err
+}
+
+// ForceTerminateWorkflowExecution closes a workflow with a terminated event on behalf of an operator
+func (adh *AdminNilCheckHandler) ForceTerminateWorkflowExecution(ctx context.Context, request *adminservice.ForceTerminateWorkflowExecutionRequest) (*adminservice.ForceTerminateWorkflowExecutionResponse, error) {
+	resp, err := adh.parentHandler.ForceTerminateWorkflowExecution(ctx, request)
+	if resp == nil && err == nil {
+		resp = &adminservice.ForceTerminateWorkflowExecutionResponse{}
+	}
+	return resp, err
+}
+
+// ListOwnedTaskLists returns the task lists currently loaded by a matching host
+func (adh *AdminNilCheckHandler) ListOwnedTaskLists(ctx context.Context, request *adminservice.ListOwnedTaskListsRequest) (*adminservice.ListOwnedTaskListsResponse, error) {
+	resp, err := adh.parentHandler.ListOwnedTaskLists(ctx, request)
+	if resp == nil && err == nil {
+		resp = &adminservice.ListOwnedTaskListsResponse{}
+	}
+	return resp, err
+}
+
+// DescribeTaskListStatus returns whether a task list is draining a backlog of persisted tasks
+func (adh *AdminNilCheckHandler) DescribeTaskListStatus(ctx context.Context, request *adminservice.DescribeTaskListStatusRequest) (*adminservice.DescribeTaskListStatusResponse, error) {
+	resp, err := adh.parentHandler.DescribeTaskListStatus(ctx, request)
+	if resp == nil && err == nil {
+		resp = &adminservice.DescribeTaskListStatusResponse{}
+	}
+	return resp, err
+}
+
+// InjectTask enqueues a synthetic task into the backlog of a task list
+func (adh *AdminNilCheckHandler) InjectTask(ctx context.Context, request *adminservice.InjectTaskRequest) (*adminservice.InjectTaskResponse, error) {
+	resp, err := adh.parentHandler.InjectTask(ctx, request)
+	if resp == nil && err == nil {
+		resp = &adminservice.InjectTaskResponse{}
+	}
+	return resp, err
+}
+
+// CompleteTasksBelow completes all tasks of a task list with a task id at or below the given one
+func (adh *AdminNilCheckHandler) CompleteTasksBelow(ctx context.Context, request *adminservice.CompleteTasksBelowRequest) (*adminservice.CompleteTasksBelowResponse, error) {
+	resp, err := adh.parentHandler.CompleteTasksBelow(ctx, request)
+	if resp == nil && err == nil {
+		resp = &adminservice.CompleteTasksBelowResponse{}
+	}
+	return resp, err
+}
+
+// EstimateTaskListBacklog returns the approximate number of tasks of a task list which are not acked yet
+func (adh *AdminNilCheckHandler) EstimateTaskListBacklog(ctx context.Context, request *adminservice.EstimateTaskListBacklogRequest) (*adminservice.EstimateTaskListBacklogResponse, error) {
+	resp, err := adh.parentHandler.EstimateTaskListBacklog(ctx, request)
+	if resp == nil && err == nil {
+		resp = &adminservice.EstimateTaskListBacklogResponse{}
+	}
+	return resp, err
+}
+
+// ListWorkflowTimers returns the user timers of a workflow with their fire times
+func (adh *AdminNilCheckHandler) ListWorkflowTimers(ctx context.Context, request *adminservice.ListWorkflowTimersRequest) (*adminservice.ListWorkflowTimersResponse, error) {
+	resp, err := adh.parentHandler.ListWorkflowTimers(ctx, request)
+	if resp == nil && err == nil {
+		resp = &adminservice.ListWorkflowTimersResponse{}
+	}
+	return resp, err
+}
+
+// CancelOrphanedTimer removes an orphaned user timer from the mutable state of a workflow
+func (adh *AdminNilCheckHandler) CancelOrphanedTimer(ctx context.Context, request *adminservice.CancelOrphanedTimerRequest) (*adminservice.CancelOrphanedTimerResponse, error) {
+	resp, err := adh.parentHandler.CancelOrphanedTimer(ctx, request)
+	if resp == nil && err == nil {
+		resp = &adminservice.CancelOrphanedTimerResponse{}
+	}
+	return resp, err
+}
@@ -30,13 +30,16 @@ var (
 	errExecutionNotSet                                    = serviceerror.NewInvalidArgument("Execution is not set on request.")
 	errWorkflowIDNotSet                                   = serviceerror.NewInvalidArgument("WorkflowId is not set on request.")
 	errActivityIDNotSet                                   = serviceerror.NewInvalidArgument("ActivityId is not set on request.")
+	errTimerIDNotSet                                      = serviceerror.NewInvalidArgument("TimerId is not set on request.")
 	errSignalNameNotSet                                   = serviceerror.NewInvalidArgument("SignalName is not set on request.")
+	errReasonNotSet                                       = serviceerror.NewInvalidArgument("Reason is not set on request.")
 	errInvalidRunID                                       = serviceerror.NewInvalidArgument("Invalid RunId.")
 	errInvalidNextPageToken                               = serviceerror.NewInvalidArgument("Invalid NextPageToken.")
 	errNextPageTokenRunIDMismatch                         = serviceerror.NewInvalidArgument("RunId in the request does not match the NextPageToken.")
 	errQueryNotSet                                        = serviceerror.NewInvalidArgument("WorkflowQuery is not set on request.")
 	errQueryTypeNotSet                                    = serviceerror.NewInvalidArgument("QueryType is not set on request.")
 	errRequestNotSet                                      = serviceerror.NewInvalidArgument("Request is nil.")
+	errHostAddressNotSet                                  = serviceerror.NewInvalidArgument("HostAddress is not set on request.")
 	errRequestIDNotSet                                    = serviceerror.NewInvalidArgument("RequestId is not set on request.")
 	errWorkflowTypeNotSet                                 = serviceerror.NewInvalidArgument("WorkflowType is not set on request.")
 	errInvalidRetention                                   = serviceerror.NewInvalidArgument("RetentionDays is invalid.")
@@ -64,6 +67,7 @@ var (
 	errKeyIsReservedBySystem                              = serviceerror.NewInvalidArgument("Key [%s] is reserved by system.")
 	errKeyIsAlreadyWhitelisted                            = serviceerror.NewInvalidArgument("Key [%s] is already whitelist.")
 	errInvalidPageSize                                    = serviceerror.NewInvalidArgument("Invalid PageSize.")
+	errInvalidTaskListType                                = serviceerror.NewInvalidArgument("Invalid TaskListType.")
 	errInvalidPaginationToken                             = serviceerror.NewInvalidArgument("Invalid pagination token.")
 	errInvalidFirstNextEventCombination                   = serviceerror.NewInvalidArgument("Invalid FirstEventId and NextEventId combination.")
 	errInvalidStartEventCombination                       = serviceerror.NewInvalidArgument("Invalid StartEventId and StartEventVersion combination.")
@@ -72,6 +76,8 @@ var (
 	errInvalidEventQueryRange                             = serviceerror.NewInvalidArgument("Invalid event query range.")
 	errUnknownValueType                                   = serviceerror.NewInvalidArgument("Unknown value type, %v.")
 	errDLQTypeIsNotSupported                              = serviceerror.NewInvalidArgument("The DLQ type is not supported.")
+	errDLQNamespaceFilterNotSupported                     = serviceerror.NewInvalidArgument("Filtering by namespace is only supported for namespace DLQ.")
+	errInvalidDLQMessageID                                = serviceerror.NewInvalidArgument("DLQ message id must be positive.")
 
 	errFailedUpdateDynamicConfig = serviceerror.NewInternal("Failed to update dynamic config, err: %v.")
 	errFailedToCreateESIndex     = serviceerror.NewInternal("Failed to create ES index, err: %v.")
@@ -90,3 +90,13 @@ func deserializeHistoryToken(bytes []byte) (*tokengenpb.HistoryContinuation, err
 	err := token.Unmarshal(bytes)
 	return token, err
 }
+
+// DeserializeRawHistoryToken decodes a continuation token returned by GetWorkflowExecutionRawHistoryV2
+func DeserializeRawHistoryToken(bytes []byte) (*tokengenpb.RawHistoryContinuation, error) {
+	return deserializeRawHistoryToken(bytes)
+}
+
+// DeserializeHistoryToken decodes a continuation token returned by GetWorkflowExecutionHistory
+func DeserializeHistoryToken(bytes []byte) (*tokengenpb.HistoryContinuation, error) {
+	return deserializeHistoryToken(bytes)
+}
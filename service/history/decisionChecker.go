@@ -21,12 +21,19 @@
 package history
 
 import (
+	"encoding/json"
 	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
 	"strings"
+	"time"
 
+	"github.com/dgryski/go-farm"
 	"github.com/pborman/uuid"
 	commonpb "go.temporal.io/temporal-proto/common"
 	decisionpb "go.temporal.io/temporal-proto/decision"
+	eventpb "go.temporal.io/temporal-proto/event"
 	"go.temporal.io/temporal-proto/serviceerror"
 	tasklistpb "go.temporal.io/temporal-proto/tasklist"
 
@@ -38,18 +45,45 @@ import (
 	"github.com/temporalio/temporal/common/log/tag"
 	"github.com/temporalio/temporal/common/metrics"
 	"github.com/temporalio/temporal/common/persistence"
+	"github.com/temporalio/temporal/common/service/dynamicconfig"
 )
 
 type (
+	activityTaskListSelector struct {
+		Candidates []string `json:"candidates"`
+		Strategy   string   `json:"strategy"`
+		Key        string   `json:"key"`
+	}
+
 	decisionAttrValidator struct {
-		namespaceCache            cache.NamespaceCache
-		maxIDLengthLimit          int
-		searchAttributesValidator *validator.SearchAttributesValidator
+		namespaceCache                   cache.NamespaceCache
+		maxIDLengthLimit                 int
+		searchAttributesSizeOfValueLimit dynamicconfig.IntPropertyFnWithNamespaceFilter
+		maxSearchAttributeValueCount     dynamicconfig.IntPropertyFnWithNamespaceFilter
+		validSearchAttributes            dynamicconfig.MapPropertyFn
+		searchAttributesValidator        *validator.SearchAttributesValidator
+		blobSizeLimitError               dynamicconfig.IntPropertyFnWithNamespaceFilter
+		failureCategories                dynamicconfig.MapPropertyFnWithNamespaceFilter
+		allowZeroDurationTimers          dynamicconfig.BoolPropertyFnWithNamespaceFilter
+		maxTimerDuration                 dynamicconfig.DurationPropertyFnWithNamespaceFilter
+		requiredSearchAttributes         dynamicconfig.StringPropertyFnWithNamespaceFilter
+		logger                           log.Logger
+	}
+
+	// structuredFailureDetails is the optional structure of fail workflow decision details
+	structuredFailureDetails struct {
+		Category string `json:"category"`
 	}
 
 	workflowSizeChecker struct {
 		blobSizeLimitWarn  int
 		blobSizeLimitError int
+		// blobSizeBudget bounds the total size of the blobs checked for one decision batch, zero disables it.
+		// The checker is created for each batch, so the total starts from zero for every batch
+		blobSizeBudget int
+		blobSizeTotal  int
+		// controlSizeLimitError bounds the size of the control of signal external workflow decisions, zero disables it
+		controlSizeLimitError int
 
 		historySizeLimitWarn  int
 		historySizeLimitError int
@@ -67,16 +101,49 @@ type (
 
 const (
 	reservedTaskListPrefix = "/__temporal_sys/"
+
+	// activityPriorityHeaderKey is the activity header field carrying the activity priority,
+	// encoded as a decimal string, activities without a priority get activityPriorityDefault
+	activityPriorityHeaderKey = "Priority"
+	activityPriorityMin       = 1
+	activityPriorityMax       = 5
+	activityPriorityDefault   = 3
+
+	// activityTaskListSelectorHeaderKey is the activity header field carrying a JSON encoded
+	// activityTaskListSelector, used to pick the activity task list out of a set of candidates
+	activityTaskListSelectorHeaderKey = "TaskListSelector"
+	// activityTaskListSelectorStrategyHash selects the candidate by the hash of the selector key
+	activityTaskListSelectorStrategyHash = "hash"
+
+	// traceParentHeaderKey and traceStateHeaderKey are the header fields carrying the W3C trace context,
+	// they are matched case insensitively and propagated to child workflows under these exact keys
+	traceParentHeaderKey = "traceparent"
+	traceStateHeaderKey  = "tracestate"
+
+	// parentClosePolicyUnsetHeaderKey is the child workflow header field marking the parent close policy
+	// of the decision as unset, abandon being the zero value of the policy this is how a child decision
+	// without a policy is told apart from one explicitly asking for abandon
+	parentClosePolicyUnsetHeaderKey = "ParentClosePolicyUnset"
+
+	// namespaceTagDataKeyPrefix marks the namespace data entries which are namespace tags, a tag is attached
+	// to the header of scheduled activities under namespaceTagHeaderKeyPrefix followed by the tag name
+	namespaceTagDataKeyPrefix   = "tag."
+	namespaceTagHeaderKeyPrefix = "NamespaceTag."
 )
 
+var traceParentRegex = regexp.MustCompile(`^[0-9a-f]{2}-[0-9a-f]{32}-[0-9a-f]{16}-[0-9a-f]{2}$`)
+
 func newDecisionAttrValidator(
 	namespaceCache cache.NamespaceCache,
 	config *Config,
 	logger log.Logger,
 ) *decisionAttrValidator {
 	return &decisionAttrValidator{
-		namespaceCache:   namespaceCache,
-		maxIDLengthLimit: config.MaxIDLengthLimit(),
+		namespaceCache:                   namespaceCache,
+		maxIDLengthLimit:                 config.MaxIDLengthLimit(),
+		searchAttributesSizeOfValueLimit: config.SearchAttributesSizeOfValueLimit,
+		maxSearchAttributeValueCount:     config.MaxSearchAttributeValueCount,
+		validSearchAttributes:            config.ValidSearchAttributes,
 		searchAttributesValidator: validator.NewSearchAttributesValidator(
 			logger,
 			config.ValidSearchAttributes,
@@ -84,12 +151,20 @@ func newDecisionAttrValidator(
 			config.SearchAttributesSizeOfValueLimit,
 			config.SearchAttributesTotalSizeLimit,
 		),
+		blobSizeLimitError:       config.BlobSizeLimitError,
+		failureCategories:        config.FailureCategories,
+		allowZeroDurationTimers:  config.AllowZeroDurationTimers,
+		maxTimerDuration:         config.MaxTimerDuration,
+		requiredSearchAttributes: config.RequiredSearchAttributes,
+		logger:                   logger,
 	}
 }
 
 func newWorkflowSizeChecker(
 	blobSizeLimitWarn int,
 	blobSizeLimitError int,
+	blobSizeBudget int,
+	controlSizeLimitError int,
 	historySizeLimitWarn int,
 	historySizeLimitError int,
 	historyCountLimitWarn int,
@@ -103,6 +178,8 @@ func newWorkflowSizeChecker(
 	return &workflowSizeChecker{
 		blobSizeLimitWarn:      blobSizeLimitWarn,
 		blobSizeLimitError:     blobSizeLimitError,
+		blobSizeBudget:         blobSizeBudget,
+		controlSizeLimitError:  controlSizeLimitError,
 		historySizeLimitWarn:   historySizeLimitWarn,
 		historySizeLimitError:  historySizeLimitError,
 		historyCountLimitWarn:  historyCountLimitWarn,
@@ -132,6 +209,7 @@ func (c *workflowSizeChecker) failWorkflowIfBlobSizeExceedsLimit(
 		c.logger,
 	)
 	if err == nil {
+		c.blobSizeTotal += len(blob)
 		return false, nil
 	}
 
@@ -147,6 +225,45 @@ func (c *workflowSizeChecker) failWorkflowIfBlobSizeExceedsLimit(
 	return true, nil
 }
 
+func (c *workflowSizeChecker) failWorkflowIfMemoSizeExceedsLimit(
+	memo *commonpb.Memo,
+	message string,
+) (bool, error) {
+
+	failWorkflow, err := c.failWorkflowIfBlobSizeExceedsLimit(
+		convertSearchAttributesToByteArray(memo.GetFields()),
+		message,
+	)
+	if failWorkflow {
+		c.metricsClient.IncCounter(metrics.HistoryRespondDecisionTaskCompletedScope, metrics.MemoSizeExceededCounter)
+	}
+	return failWorkflow, err
+}
+
+// controlSizeExceedsLimit returns true if the control of a signal external workflow decision exceeds its own
+// size limit. Unlike the other blobs, the decision rather than the workflow is failed for an oversized control
+func (c *workflowSizeChecker) controlSizeExceedsLimit(
+	control []byte,
+) bool {
+
+	if c.controlSizeLimitError <= 0 || len(control) <= c.controlSizeLimitError {
+		return false
+	}
+	executionInfo := c.mutableState.GetExecutionInfo()
+	c.logger.Warn("Signal external workflow control size exceeds limit.",
+		tag.WorkflowNamespaceID(executionInfo.NamespaceID),
+		tag.WorkflowID(executionInfo.WorkflowID),
+		tag.WorkflowRunID(executionInfo.RunID),
+		tag.WorkflowSize(int64(len(control))))
+	return true
+}
+
+// blobSizeBudgetExceeded returns true if the blobs checked so far for the decision batch
+// together exceed the budget, even though each of them is within the per blob limit
+func (c *workflowSizeChecker) blobSizeBudgetExceeded() bool {
+	return c.blobSizeBudget > 0 && c.blobSizeTotal > c.blobSizeBudget
+}
+
 func (c *workflowSizeChecker) failWorkflowSizeExceedsLimit() (bool, error) {
 	historyCount := int(c.mutableState.GetNextEventID()) - 1
 	historySize := int(c.executionStats.HistorySize)
@@ -232,6 +349,10 @@ func (v *decisionAttrValidator) validateActivityScheduleAttributes(
 		return serviceerror.NewInvalidArgument("Namespace exceeds length limit.")
 	}
 
+	if _, err := getActivityPriority(attributes.GetHeader()); err != nil {
+		return err
+	}
+
 	// Only attempt to deduce and fill in unspecified timeouts only when all timeouts are non-negative.
 	if attributes.GetScheduleToCloseTimeoutSeconds() < 0 || attributes.GetScheduleToStartTimeoutSeconds() < 0 ||
 		attributes.GetStartToCloseTimeoutSeconds() < 0 || attributes.GetHeartbeatTimeoutSeconds() < 0 {
@@ -290,6 +411,7 @@ func (v *decisionAttrValidator) validateActivityScheduleAttributes(
 }
 
 func (v *decisionAttrValidator) validateTimerScheduleAttributes(
+	namespace string,
 	attributes *decisionpb.StartTimerDecisionAttributes,
 ) error {
 
@@ -302,8 +424,16 @@ func (v *decisionAttrValidator) validateTimerScheduleAttributes(
 	if len(attributes.GetTimerId()) > v.maxIDLengthLimit {
 		return serviceerror.NewInvalidArgument("TimerId exceeds length limit.")
 	}
-	if attributes.GetStartToFireTimeoutSeconds() <= 0 {
-		return serviceerror.NewInvalidArgument("A valid StartToFireTimeoutSeconds is not set on decision.")
+	if timeout := attributes.GetStartToFireTimeoutSeconds(); timeout < 0 {
+		return serviceerror.NewInvalidArgument(fmt.Sprintf("StartToFireTimeoutSeconds %v is negative.", timeout))
+	}
+	// a zero duration timer fires immediately, which is only intended when allowed for the namespace
+	if attributes.GetStartToFireTimeoutSeconds() == 0 && !v.allowZeroDurationTimers(namespace) {
+		return serviceerror.NewInvalidArgument("StartToFireTimeoutSeconds is zero, zero duration timers are not allowed for the namespace.")
+	}
+	maxTimerDuration := v.maxTimerDuration(namespace)
+	if timeout := attributes.GetStartToFireTimeoutSeconds(); maxTimerDuration > 0 && time.Duration(timeout)*time.Second > maxTimerDuration {
+		return serviceerror.NewInvalidArgument(fmt.Sprintf("StartToFireTimeoutSeconds %v exceeds the maximum timer duration of %v.", timeout, maxTimerDuration))
 	}
 	return nil
 }
@@ -353,6 +483,9 @@ func (v *decisionAttrValidator) validateRecordMarkerAttributes(
 	if len(attributes.GetMarkerName()) > v.maxIDLengthLimit {
 		return serviceerror.NewInvalidArgument("MarkerName exceeds length limit.")
 	}
+	if attributes.GetMarkerName() == cancellationGracePeriodMarkerName {
+		return serviceerror.NewInvalidArgument("MarkerName is reserved.")
+	}
 
 	return nil
 }
@@ -380,6 +513,39 @@ func (v *decisionAttrValidator) validateFailWorkflowExecutionAttributes(
 	return nil
 }
 
+// validateFailureCategory returns the failure category carried by structured fail workflow details,
+// details which are not a JSON object with a category are left unstructured
+func (v *decisionAttrValidator) validateFailureCategory(
+	namespace string,
+	details []byte,
+) (string, error) {
+
+	failureCategory := getFailureCategory(details)
+	if failureCategory == "" {
+		return "", nil
+	}
+
+	if len(failureCategory) > v.maxIDLengthLimit {
+		return "", serviceerror.NewInvalidArgument("Failure category exceeds length limit.")
+	}
+	if categories := v.failureCategories(namespace); len(categories) > 0 {
+		if _, ok := categories[failureCategory]; !ok {
+			return "", serviceerror.NewInvalidArgument(fmt.Sprintf("Failure category %v is not allowed.", failureCategory))
+		}
+	}
+	return failureCategory, nil
+}
+
+// getFailureCategory returns the category carried by structured fail workflow details,
+// or empty if the details are not a JSON object with a category
+func getFailureCategory(details []byte) string {
+	var structured structuredFailureDetails
+	if err := json.Unmarshal(details, &structured); err != nil {
+		return ""
+	}
+	return structured.Category
+}
+
 func (v *decisionAttrValidator) validateCancelWorkflowExecutionAttributes(
 	attributes *decisionpb.CancelWorkflowExecutionDecisionAttributes,
 ) error {
@@ -452,6 +618,7 @@ func (v *decisionAttrValidator) validateSignalExternalWorkflowExecutionAttribute
 		return serviceerror.NewInvalidArgument("WorkflowId exceeds length limit.")
 	}
 
+	// an empty run ID targets the latest run of the workflow, resolved when the signal is delivered
 	targetRunID := attributes.Execution.GetRunId()
 	if targetRunID != "" && uuid.Parse(targetRunID) == nil {
 		return serviceerror.NewInvalidArgument("Invalid RunId set on decision.")
@@ -476,13 +643,63 @@ func (v *decisionAttrValidator) validateUpsertWorkflowSearchAttributes(
 		return serviceerror.NewInvalidArgument("SearchAttributes is not set on decision.")
 	}
 
-	if len(attributes.GetSearchAttributes().GetIndexedFields()) == 0 {
+	fields := attributes.GetSearchAttributes().GetIndexedFields()
+	if len(fields) == 0 {
 		return serviceerror.NewInvalidArgument("IndexedFields is empty on decision.")
 	}
 
+	// a single oversized value is reported with its key, keys are sorted so that the same
+	// decision always fails with the same message
+	keys := make([]string, 0, len(fields))
+	for key := range fields {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	sizeOfValueLimit := v.searchAttributesSizeOfValueLimit(namespace)
+	for _, key := range keys {
+		if size := len(fields[key]); size > sizeOfValueLimit {
+			return serviceerror.NewInvalidArgument(fmt.Sprintf(
+				"SearchAttributes value of key %v is %v bytes, which exceeds the limit of %v bytes.", key, size, sizeOfValueLimit,
+			))
+		}
+	}
+	if err := v.validateSearchAttributeValueCounts(namespace, keys, fields); err != nil {
+		return err
+	}
+
 	return v.searchAttributesValidator.ValidateSearchAttributes(attributes.GetSearchAttributes(), namespace)
 }
 
+// validateSearchAttributeValueCounts checks that keyword attributes upserted as a list of values do not
+// have more values than the limit. Keys which are not valid search attributes are left to the search
+// attributes validator
+func (v *decisionAttrValidator) validateSearchAttributeValueCounts(
+	namespace string,
+	keys []string,
+	fields map[string][]byte,
+) error {
+
+	validSearchAttributes := v.validSearchAttributes()
+	maxValueCount := v.maxSearchAttributeValueCount(namespace)
+	for _, key := range keys {
+		valueType, ok := validSearchAttributes[key]
+		if !ok || common.ConvertIndexedValueTypeToProtoType(valueType, v.logger) != commonpb.IndexedValueTypeKeyword {
+			continue
+		}
+		var values []interface{}
+		if err := json.Unmarshal(fields[key], &values); err != nil {
+			// not a list of values
+			continue
+		}
+		if len(values) > maxValueCount {
+			return serviceerror.NewInvalidArgument(fmt.Sprintf(
+				"SearchAttributes value of key %v has %v values, which exceeds the limit of %v values.", key, len(values), maxValueCount,
+			))
+		}
+	}
+	return nil
+}
+
 func (v *decisionAttrValidator) validateContinueAsNewWorkflowExecutionAttributes(
 	attributes *decisionpb.ContinueAsNewWorkflowExecutionDecisionAttributes,
 	executionInfo *persistence.WorkflowExecutionInfo,
@@ -501,6 +718,12 @@ func (v *decisionAttrValidator) validateContinueAsNewWorkflowExecutionAttributes
 		return serviceerror.NewInvalidArgument("WorkflowType exceeds length limit.")
 	}
 
+	// Continued execution must be dispatched on a normal task list, sticky task list is bound to a worker
+	if attributes.TaskList.GetKind() == tasklistpb.TaskListKindSticky ||
+		(executionInfo.StickyTaskList != "" && attributes.TaskList.GetName() == executionInfo.StickyTaskList) {
+		return serviceerror.NewInvalidArgument("ContinueAsNew cannot use sticky task list.")
+	}
+
 	// Inherit Tasklist from previous execution if not provided on decision
 	taskList, err := v.validatedTaskList(attributes.TaskList, executionInfo.TaskList)
 	if err != nil {
@@ -530,6 +753,98 @@ func (v *decisionAttrValidator) validateContinueAsNewWorkflowExecutionAttributes
 	return v.searchAttributesValidator.ValidateSearchAttributes(attributes.GetSearchAttributes(), namespaceEntry.GetInfo().Name)
 }
 
+// validateContinueAsNewInputSize checks the input against the blob size limit the new run will be
+// subject to, the limit of the current decision is captured when the decision task completes and
+// may be stale after a dynamic config change
+func (v *decisionAttrValidator) validateContinueAsNewInputSize(
+	attributes *decisionpb.ContinueAsNewWorkflowExecutionDecisionAttributes,
+	namespace string,
+) error {
+
+	limit := v.blobSizeLimitError(namespace)
+	if size := len(attributes.GetInput()); size > limit {
+		return serviceerror.NewInvalidArgument(
+			fmt.Sprintf("ContinueAsNewWorkflowExecutionDecisionAttributes.Input is %v bytes, which exceeds the new run limit of %v bytes.", size, limit),
+		)
+	}
+	return nil
+}
+
+// validateContinueAsNewRequiredSearchAttributes checks the new run carries the search attributes
+// the namespace requires to persist across runs. A required key not set by the decision is
+// inherited from the current run, the decision is rejected if the current run lacks it too
+func (v *decisionAttrValidator) validateContinueAsNewRequiredSearchAttributes(
+	attributes *decisionpb.ContinueAsNewWorkflowExecutionDecisionAttributes,
+	executionInfo *persistence.WorkflowExecutionInfo,
+	namespace string,
+) error {
+
+	if v.requiredSearchAttributes == nil {
+		return nil
+	}
+	for _, key := range strings.Split(v.requiredSearchAttributes(namespace), ",") {
+		if key = strings.TrimSpace(key); key == "" {
+			continue
+		}
+		if _, ok := attributes.GetSearchAttributes().GetIndexedFields()[key]; ok {
+			continue
+		}
+		value, ok := executionInfo.SearchAttributes[key]
+		if !ok {
+			return serviceerror.NewInvalidArgument(
+				fmt.Sprintf("ContinueAsNewWorkflowExecutionDecisionAttributes.SearchAttributes is missing required key %v.", key),
+			)
+		}
+		if attributes.SearchAttributes == nil {
+			attributes.SearchAttributes = &commonpb.SearchAttributes{}
+		}
+		if attributes.SearchAttributes.IndexedFields == nil {
+			attributes.SearchAttributes.IndexedFields = make(map[string][]byte)
+		}
+		attributes.SearchAttributes.IndexedFields[key] = value
+	}
+	return nil
+}
+
+// validateContinueAsNewBuilder checks the state of the new run created by continue as new
+// before it is persisted, so that a malformed continue as new does not start a run which fails
+// as soon as it is scheduled
+func (v *decisionAttrValidator) validateContinueAsNewBuilder(
+	newStateBuilder mutableState,
+	namespace string,
+) error {
+
+	executionInfo := newStateBuilder.GetExecutionInfo()
+	if executionInfo.TaskList == "" {
+		return serviceerror.NewInvalidArgument("New run of ContinueAsNew has no task list.")
+	}
+	if len(executionInfo.TaskList) > v.maxIDLengthLimit {
+		return serviceerror.NewInvalidArgument("New run of ContinueAsNew has a task list exceeding length limit.")
+	}
+	if executionInfo.WorkflowTimeout <= 0 {
+		return serviceerror.NewInvalidArgument(
+			fmt.Sprintf("New run of ContinueAsNew has invalid workflow timeout %v.", executionInfo.WorkflowTimeout),
+		)
+	}
+	if executionInfo.DecisionStartToCloseTimeout <= 0 {
+		return serviceerror.NewInvalidArgument(
+			fmt.Sprintf("New run of ContinueAsNew has invalid decision timeout %v.", executionInfo.DecisionStartToCloseTimeout),
+		)
+	}
+
+	history := newStateBuilder.GetHistoryBuilder().history
+	if len(history) == 0 || history[0].GetEventType() != eventpb.EventTypeWorkflowExecutionStarted {
+		return serviceerror.NewInvalidArgument("New run of ContinueAsNew has no workflow execution started event.")
+	}
+	limit := v.blobSizeLimitError(namespace)
+	if size := len(history[0].GetWorkflowExecutionStartedEventAttributes().GetInput()); size > limit {
+		return serviceerror.NewInvalidArgument(
+			fmt.Sprintf("New run of ContinueAsNew has input of %v bytes, which exceeds the limit of %v bytes.", size, limit),
+		)
+	}
+	return nil
+}
+
 func (v *decisionAttrValidator) validateStartChildExecutionAttributes(
 	namespaceID string,
 	targetNamespaceID string,
@@ -670,3 +985,143 @@ func (v *decisionAttrValidator) createCrossNamespaceCallError(
 ) error {
 	return serviceerror.NewInvalidArgument(fmt.Sprintf("cannot make cross namespace call between %v and %v", namespaceEntry.GetInfo().Name, targetNamespaceEntry.GetInfo().Name))
 }
+
+func getActivityPriority(
+	header *commonpb.Header,
+) (int, error) {
+
+	value, ok := header.GetFields()[activityPriorityHeaderKey]
+	if !ok {
+		return activityPriorityDefault, nil
+	}
+
+	priority, err := strconv.Atoi(string(value))
+	if err != nil || priority < activityPriorityMin || priority > activityPriorityMax {
+		return 0, serviceerror.NewInvalidArgument(fmt.Sprintf(
+			"Activity priority %q is invalid, priority must be between %v and %v.",
+			value,
+			activityPriorityMin,
+			activityPriorityMax,
+		))
+	}
+	return priority, nil
+}
+
+// getTraceContext returns the validated trace context fields of the header keyed by their canonical names,
+// it returns an empty map if the header carries no trace context
+func getTraceContext(
+	header *commonpb.Header,
+) (map[string][]byte, error) {
+
+	traceContext := make(map[string][]byte)
+	for key, value := range header.GetFields() {
+		for _, traceKey := range []string{traceParentHeaderKey, traceStateHeaderKey} {
+			if !strings.EqualFold(key, traceKey) {
+				continue
+			}
+			if _, ok := traceContext[traceKey]; ok {
+				return nil, serviceerror.NewInvalidArgument(fmt.Sprintf("Trace context field %v is set more than once.", traceKey))
+			}
+			traceContext[traceKey] = value
+		}
+	}
+
+	if len(traceContext) == 0 {
+		return traceContext, nil
+	}
+	traceParent, ok := traceContext[traceParentHeaderKey]
+	if !ok {
+		return nil, serviceerror.NewInvalidArgument(fmt.Sprintf("Trace context field %v is set without %v.", traceStateHeaderKey, traceParentHeaderKey))
+	}
+	if !traceParentRegex.Match(traceParent) {
+		return nil, serviceerror.NewInvalidArgument(fmt.Sprintf("Trace context field %v %q is invalid.", traceParentHeaderKey, traceParent))
+	}
+	return traceContext, nil
+}
+
+// isParentClosePolicyUnset returns whether the child workflow header marks the parent close policy as unset
+func isParentClosePolicyUnset(
+	header *commonpb.Header,
+) bool {
+
+	_, ok := header.GetFields()[parentClosePolicyUnsetHeaderKey]
+	return ok
+}
+
+// withTraceContext returns a copy of the header whose trace context fields are replaced by the given ones
+func withTraceContext(
+	header *commonpb.Header,
+	traceContext map[string][]byte,
+) *commonpb.Header {
+
+	fields := make(map[string][]byte, len(header.GetFields()))
+	for key, value := range header.GetFields() {
+		if strings.EqualFold(key, traceParentHeaderKey) || strings.EqualFold(key, traceStateHeaderKey) {
+			continue
+		}
+		fields[key] = value
+	}
+	for key, value := range traceContext {
+		fields[key] = value
+	}
+	return &commonpb.Header{Fields: fields}
+}
+
+// getNamespaceTags returns the namespace tags found in the namespace data keyed by their header field
+func getNamespaceTags(
+	data map[string]string,
+) map[string][]byte {
+
+	tags := make(map[string][]byte)
+	for key, value := range data {
+		if name := strings.TrimPrefix(key, namespaceTagDataKeyPrefix); name != key && name != "" {
+			tags[namespaceTagHeaderKeyPrefix+name] = []byte(value)
+		}
+	}
+	return tags
+}
+
+// getHeaderSize returns the size of the header field names and values
+func getHeaderSize(
+	header *commonpb.Header,
+) int {
+
+	size := 0
+	for key, value := range header.GetFields() {
+		size += len(key) + len(value)
+	}
+	return size
+}
+
+// selectActivityTaskList returns the task list picked by the task list selector in the activity
+// header, the selection only depends on the selector so replaying the decision picks the same one
+func selectActivityTaskList(
+	header *commonpb.Header,
+) (string, bool, error) {
+
+	value, ok := header.GetFields()[activityTaskListSelectorHeaderKey]
+	if !ok {
+		return "", false, nil
+	}
+
+	var selector activityTaskListSelector
+	if err := json.Unmarshal(value, &selector); err != nil {
+		return "", false, serviceerror.NewInvalidArgument(fmt.Sprintf("Activity task list selector is invalid: %v.", err))
+	}
+	if len(selector.Candidates) == 0 {
+		return "", false, serviceerror.NewInvalidArgument("Activity task list selector has no candidate task lists.")
+	}
+	for _, candidate := range selector.Candidates {
+		if candidate == "" {
+			return "", false, serviceerror.NewInvalidArgument("Activity task list selector has an empty candidate task list.")
+		}
+	}
+
+	switch selector.Strategy {
+	case "", activityTaskListSelectorStrategyHash:
+		index := farm.Fingerprint32([]byte(selector.Key)) % uint32(len(selector.Candidates))
+		return selector.Candidates[index], true, nil
+	default:
+		return "", false, serviceerror.NewInvalidArgument(fmt.Sprintf("Activity task list selector strategy %q is not supported.", selector.Strategy))
+	}
+}
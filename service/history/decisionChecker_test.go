@@ -22,7 +22,9 @@ package history
 
 import (
 	"testing"
+	"time"
 
+	"github.com/dgryski/go-farm"
 	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/require"
 	"github.com/stretchr/testify/suite"
@@ -79,6 +81,8 @@ func (s *decisionAttrValidatorSuite) SetupTest() {
 		SearchAttributesNumberOfKeysLimit: dynamicconfig.GetIntPropertyFilteredByNamespace(100),
 		SearchAttributesSizeOfValueLimit:  dynamicconfig.GetIntPropertyFilteredByNamespace(2 * 1024),
 		SearchAttributesTotalSizeLimit:    dynamicconfig.GetIntPropertyFilteredByNamespace(40 * 1024),
+		MaxSearchAttributeValueCount:      dynamicconfig.GetIntPropertyFilteredByNamespace(3),
+		MaxTimerDuration:                  dynamicconfig.GetDurationPropertyFnFilteredByNamespace(0),
 	}
 	s.validator = newDecisionAttrValidator(
 		s.mockNamespaceCache,
@@ -136,6 +140,85 @@ func (s *decisionAttrValidatorSuite) TestValidateSignalExternalWorkflowExecution
 	s.NoError(err)
 }
 
+func (s *decisionAttrValidatorSuite) TestValidateSignalExternalWorkflowExecutionAttributes_LatestRun() {
+	attributes := &decisionpb.SignalExternalWorkflowExecutionDecisionAttributes{
+		Execution:  &executionpb.WorkflowExecution{WorkflowId: "workflow-id"},
+		SignalName: "my signal name",
+	}
+	err := s.validator.validateSignalExternalWorkflowExecutionAttributes(s.testNamespaceID, s.testNamespaceID, attributes)
+	s.NoError(err)
+}
+
+func (s *decisionAttrValidatorSuite) TestValidateTimerScheduleAttributes_Duration() {
+	namespace := "testNamespace"
+	attributes := &decisionpb.StartTimerDecisionAttributes{
+		TimerId:                   "timer-id",
+		StartToFireTimeoutSeconds: 10,
+	}
+	s.validator.allowZeroDurationTimers = dynamicconfig.GetBoolPropertyFnFilteredByNamespace(false)
+
+	// positive
+	s.NoError(s.validator.validateTimerScheduleAttributes(namespace, attributes))
+
+	// zero is rejected by default
+	attributes.StartToFireTimeoutSeconds = 0
+	err := s.validator.validateTimerScheduleAttributes(namespace, attributes)
+	s.EqualError(err, "StartToFireTimeoutSeconds is zero, zero duration timers are not allowed for the namespace.")
+
+	// negative is rejected by default
+	attributes.StartToFireTimeoutSeconds = -1
+	err = s.validator.validateTimerScheduleAttributes(namespace, attributes)
+	s.EqualError(err, "StartToFireTimeoutSeconds -1 is negative.")
+
+	s.validator.allowZeroDurationTimers = dynamicconfig.GetBoolPropertyFnFilteredByNamespace(true)
+
+	// zero is allowed with the flag
+	attributes.StartToFireTimeoutSeconds = 0
+	s.NoError(s.validator.validateTimerScheduleAttributes(namespace, attributes))
+
+	// negative is always rejected
+	attributes.StartToFireTimeoutSeconds = -1
+	err = s.validator.validateTimerScheduleAttributes(namespace, attributes)
+	s.EqualError(err, "StartToFireTimeoutSeconds -1 is negative.")
+}
+
+func (s *decisionAttrValidatorSuite) TestValidateTimerScheduleAttributes_MaxDuration() {
+	namespace := "testNamespace"
+	attributes := &decisionpb.StartTimerDecisionAttributes{
+		TimerId:                   "timer-id",
+		StartToFireTimeoutSeconds: int64((48 * time.Hour).Seconds()),
+	}
+
+	// no max by default
+	s.NoError(s.validator.validateTimerScheduleAttributes(namespace, attributes))
+
+	s.validator.maxTimerDuration = dynamicconfig.GetDurationPropertyFnFilteredByNamespace(24 * time.Hour)
+
+	// over the max
+	err := s.validator.validateTimerScheduleAttributes(namespace, attributes)
+	s.EqualError(err, "StartToFireTimeoutSeconds 172800 exceeds the maximum timer duration of 24h0m0s.")
+
+	// at the max
+	attributes.StartToFireTimeoutSeconds = int64((24 * time.Hour).Seconds())
+	s.NoError(s.validator.validateTimerScheduleAttributes(namespace, attributes))
+
+	// below the max
+	attributes.StartToFireTimeoutSeconds = 10
+	s.NoError(s.validator.validateTimerScheduleAttributes(namespace, attributes))
+}
+
+func (s *decisionAttrValidatorSuite) TestValidateRecordMarkerAttributes_ReservedName() {
+	err := s.validator.validateRecordMarkerAttributes(&decisionpb.RecordMarkerDecisionAttributes{
+		MarkerName: "some random marker name",
+	})
+	s.NoError(err)
+
+	err = s.validator.validateRecordMarkerAttributes(&decisionpb.RecordMarkerDecisionAttributes{
+		MarkerName: cancellationGracePeriodMarkerName,
+	})
+	s.IsType(&serviceerror.InvalidArgument{}, err)
+}
+
 func (s *decisionAttrValidatorSuite) TestValidateUpsertWorkflowSearchAttributes() {
 	namespace := "testNamespace"
 	var attributes *decisionpb.UpsertWorkflowSearchAttributesDecisionAttributes
@@ -156,6 +239,160 @@ func (s *decisionAttrValidatorSuite) TestValidateUpsertWorkflowSearchAttributes(
 	s.Nil(err)
 }
 
+func (s *decisionAttrValidatorSuite) TestValidateUpsertWorkflowSearchAttributes_OversizedValue() {
+	namespace := "testNamespace"
+	attributes := &decisionpb.UpsertWorkflowSearchAttributesDecisionAttributes{
+		SearchAttributes: &commonpb.SearchAttributes{
+			IndexedFields: map[string][]byte{
+				"CustomKeywordField": []byte(`"keyword"`),
+				"CustomStringField":  make([]byte, 2*1024+1),
+			},
+		},
+	}
+
+	// the whole map is far below the total size limit, the single value is not
+	err := s.validator.validateUpsertWorkflowSearchAttributes(namespace, attributes)
+	s.EqualError(err, "SearchAttributes value of key CustomStringField is 2049 bytes, which exceeds the limit of 2048 bytes.")
+
+	attributes.SearchAttributes.IndexedFields["CustomStringField"] = make([]byte, 2*1024)
+	err = s.validator.validateUpsertWorkflowSearchAttributes(namespace, attributes)
+	s.NoError(err)
+}
+
+func (s *decisionAttrValidatorSuite) TestValidateUpsertWorkflowSearchAttributes_ValueCount() {
+	namespace := "testNamespace"
+	attributes := &decisionpb.UpsertWorkflowSearchAttributesDecisionAttributes{
+		SearchAttributes: &commonpb.SearchAttributes{
+			IndexedFields: map[string][]byte{
+				"CustomKeywordField": []byte(`["a","b"]`),
+				// only keyword lists are limited
+				"CustomStringField": []byte(`["a","b","c","d"]`),
+			},
+		},
+	}
+
+	// under limit
+	err := s.validator.validateUpsertWorkflowSearchAttributes(namespace, attributes)
+	s.NoError(err)
+
+	// at limit
+	attributes.SearchAttributes.IndexedFields["CustomKeywordField"] = []byte(`["a","b","c"]`)
+	err = s.validator.validateUpsertWorkflowSearchAttributes(namespace, attributes)
+	s.NoError(err)
+
+	// over limit
+	attributes.SearchAttributes.IndexedFields["CustomKeywordField"] = []byte(`["a","b","c","d"]`)
+	err = s.validator.validateUpsertWorkflowSearchAttributes(namespace, attributes)
+	s.EqualError(err, "SearchAttributes value of key CustomKeywordField has 4 values, which exceeds the limit of 3 values.")
+}
+
+func (s *decisionAttrValidatorSuite) TestValidateUpsertWorkflowSearchAttributes_TotalSizeExceeded() {
+	namespace := "testNamespace"
+	config := &Config{
+		MaxIDLengthLimit:                  dynamicconfig.GetIntPropertyFn(1000),
+		ValidSearchAttributes:             dynamicconfig.GetMapPropertyFn(definition.GetDefaultIndexedKeys()),
+		SearchAttributesNumberOfKeysLimit: dynamicconfig.GetIntPropertyFilteredByNamespace(100),
+		SearchAttributesSizeOfValueLimit:  dynamicconfig.GetIntPropertyFilteredByNamespace(2 * 1024),
+		SearchAttributesTotalSizeLimit:    dynamicconfig.GetIntPropertyFilteredByNamespace(4 * 1024),
+		MaxSearchAttributeValueCount:      dynamicconfig.GetIntPropertyFilteredByNamespace(100),
+	}
+	validator := newDecisionAttrValidator(s.mockNamespaceCache, config, log.NewNoop())
+
+	// every value is within its own limit, the values together are not
+	attributes := &decisionpb.UpsertWorkflowSearchAttributesDecisionAttributes{
+		SearchAttributes: &commonpb.SearchAttributes{
+			IndexedFields: map[string][]byte{
+				"CustomKeywordField":  make([]byte, 1024),
+				"CustomStringField":   make([]byte, 1024),
+				"CustomIntField":      make([]byte, 1024),
+				"CustomBoolField":     make([]byte, 1024),
+				"CustomDoubleField":   make([]byte, 1024),
+				"CustomDatetimeField": make([]byte, 1024),
+			},
+		},
+	}
+	err := validator.validateUpsertWorkflowSearchAttributes(namespace, attributes)
+	s.Error(err)
+	s.Contains(err.Error(), "total size")
+	s.NotContains(err.Error(), "SearchAttributes value of key")
+}
+
+func (s *decisionAttrValidatorSuite) TestValidateContinueAsNewWorkflowExecutionAttributes_TaskList() {
+	namespaceEntry := cache.NewLocalNamespaceCacheEntryForTest(
+		&persistence.NamespaceInfo{Name: s.testNamespaceID},
+		nil,
+		cluster.TestCurrentClusterName,
+		nil,
+	)
+	s.mockNamespaceCache.EXPECT().GetNamespaceByID(s.testNamespaceID).Return(namespaceEntry, nil).AnyTimes()
+
+	executionInfo := &persistence.WorkflowExecutionInfo{
+		NamespaceID:      s.testNamespaceID,
+		WorkflowTypeName: "workflow-type",
+		TaskList:         "tl-1",
+		StickyTaskList:   "sticky-tl",
+	}
+
+	// unset task list is defaulted to the current execution's task list
+	attributes := &decisionpb.ContinueAsNewWorkflowExecutionDecisionAttributes{}
+	err := s.validator.validateContinueAsNewWorkflowExecutionAttributes(attributes, executionInfo)
+	s.NoError(err)
+	s.Equal("tl-1", attributes.GetTaskList().GetName())
+
+	attributes = &decisionpb.ContinueAsNewWorkflowExecutionDecisionAttributes{
+		TaskList: &tasklistpb.TaskList{Name: "tl-2", Kind: tasklistpb.TaskListKindNormal},
+	}
+	err = s.validator.validateContinueAsNewWorkflowExecutionAttributes(attributes, executionInfo)
+	s.NoError(err)
+	s.Equal("tl-2", attributes.GetTaskList().GetName())
+
+	attributes = &decisionpb.ContinueAsNewWorkflowExecutionDecisionAttributes{
+		TaskList: &tasklistpb.TaskList{Name: "tl-2", Kind: tasklistpb.TaskListKindSticky},
+	}
+	err = s.validator.validateContinueAsNewWorkflowExecutionAttributes(attributes, executionInfo)
+	s.IsType(&serviceerror.InvalidArgument{}, err)
+
+	attributes = &decisionpb.ContinueAsNewWorkflowExecutionDecisionAttributes{
+		TaskList: &tasklistpb.TaskList{Name: "sticky-tl", Kind: tasklistpb.TaskListKindNormal},
+	}
+	err = s.validator.validateContinueAsNewWorkflowExecutionAttributes(attributes, executionInfo)
+	s.IsType(&serviceerror.InvalidArgument{}, err)
+}
+
+func (s *decisionAttrValidatorSuite) TestValidateContinueAsNewRequiredSearchAttributes() {
+	s.validator.requiredSearchAttributes = dynamicconfig.GetStringPropertyFnFilteredByNamespace("Owner, ")
+	executionInfo := &persistence.WorkflowExecutionInfo{
+		NamespaceID: s.testNamespaceID,
+	}
+
+	// present in the new run
+	attributes := &decisionpb.ContinueAsNewWorkflowExecutionDecisionAttributes{
+		SearchAttributes: &commonpb.SearchAttributes{
+			IndexedFields: map[string][]byte{"Owner": []byte("new-owner")},
+		},
+	}
+	err := s.validator.validateContinueAsNewRequiredSearchAttributes(attributes, executionInfo, s.testNamespaceID)
+	s.NoError(err)
+	s.Equal([]byte("new-owner"), attributes.GetSearchAttributes().GetIndexedFields()["Owner"])
+
+	// absent from both the new and the current run
+	attributes = &decisionpb.ContinueAsNewWorkflowExecutionDecisionAttributes{
+		SearchAttributes: &commonpb.SearchAttributes{
+			IndexedFields: map[string][]byte{"CustomKeywordField": []byte("value")},
+		},
+	}
+	err = s.validator.validateContinueAsNewRequiredSearchAttributes(attributes, executionInfo, s.testNamespaceID)
+	s.IsType(&serviceerror.InvalidArgument{}, err)
+	s.Contains(err.Error(), "Owner")
+
+	// inherited from the current run
+	executionInfo.SearchAttributes = map[string][]byte{"Owner": []byte("current-owner")}
+	attributes = &decisionpb.ContinueAsNewWorkflowExecutionDecisionAttributes{}
+	err = s.validator.validateContinueAsNewRequiredSearchAttributes(attributes, executionInfo, s.testNamespaceID)
+	s.NoError(err)
+	s.Equal([]byte("current-owner"), attributes.GetSearchAttributes().GetIndexedFields()["Owner"])
+}
+
 func (s *decisionAttrValidatorSuite) TestValidateCrossNamespaceCall_LocalToLocal() {
 	namespaceEntry := cache.NewLocalNamespaceCacheEntryForTest(
 		&persistence.NamespaceInfo{Name: s.testNamespaceID},
@@ -543,3 +780,128 @@ func (s *decisionAttrValidatorSuite) TestValidateTaskListName() {
 		})
 	}
 }
+
+func (s *decisionAttrValidatorSuite) TestValidateActivityScheduleAttributes_Priority() {
+	attributes := func(header *commonpb.Header) *decisionpb.ScheduleActivityTaskDecisionAttributes {
+		return &decisionpb.ScheduleActivityTaskDecisionAttributes{
+			ActivityId:                    "some random activity ID",
+			ActivityType:                  &commonpb.ActivityType{Name: "some random activity type"},
+			TaskList:                      &tasklistpb.TaskList{Name: "some random task list"},
+			ScheduleToCloseTimeoutSeconds: 10,
+			Header:                        header,
+		}
+	}
+	priority := func(value string) *commonpb.Header {
+		return &commonpb.Header{Fields: map[string][]byte{activityPriorityHeaderKey: []byte(value)}}
+	}
+
+	testCases := []struct {
+		name        string
+		header      *commonpb.Header
+		priority    int
+		isOutputErr bool
+	}{
+		{"unset", nil, activityPriorityDefault, false},
+		{"unset with header", &commonpb.Header{}, activityPriorityDefault, false},
+		{"min", priority("1"), activityPriorityMin, false},
+		{"max", priority("5"), activityPriorityMax, false},
+		{"below min", priority("0"), 0, true},
+		{"above max", priority("6"), 0, true},
+		{"not a number", priority("high"), 0, true},
+	}
+
+	for _, tc := range testCases {
+		s.Run(tc.name, func() {
+			err := s.validator.validateActivityScheduleAttributes(
+				s.testNamespaceID,
+				s.testNamespaceID,
+				attributes(tc.header),
+				100,
+			)
+			output, priorityErr := getActivityPriority(tc.header)
+			if tc.isOutputErr {
+				s.IsType(&serviceerror.InvalidArgument{}, err)
+				s.Error(priorityErr)
+			} else {
+				s.NoError(err)
+				s.NoError(priorityErr)
+			}
+			s.Equal(tc.priority, output)
+		})
+	}
+}
+
+func (s *decisionAttrValidatorSuite) TestSelectActivityTaskList() {
+	selector := func(value string) *commonpb.Header {
+		return &commonpb.Header{Fields: map[string][]byte{activityTaskListSelectorHeaderKey: []byte(value)}}
+	}
+	candidates := []string{"tl-0", "tl-1", "tl-2"}
+	hashed := candidates[farm.Fingerprint32([]byte("customer-42"))%uint32(len(candidates))]
+
+	testCases := []struct {
+		name        string
+		header      *commonpb.Header
+		taskList    string
+		selected    bool
+		isOutputErr bool
+	}{
+		{"unset", nil, "", false, false},
+		{"hash", selector(`{"candidates":["tl-0","tl-1","tl-2"],"strategy":"hash","key":"customer-42"}`), hashed, true, false},
+		{"default strategy", selector(`{"candidates":["tl-0","tl-1","tl-2"],"key":"customer-42"}`), hashed, true, false},
+		{"single candidate", selector(`{"candidates":["tl-0"],"key":"customer-42"}`), "tl-0", true, false},
+		{"no candidates", selector(`{"candidates":[],"key":"customer-42"}`), "", false, true},
+		{"empty candidate", selector(`{"candidates":["tl-0",""],"key":"customer-42"}`), "", false, true},
+		{"unknown strategy", selector(`{"candidates":["tl-0"],"strategy":"random"}`), "", false, true},
+		{"malformed", selector(`tl-0,tl-1`), "", false, true},
+	}
+
+	for _, tc := range testCases {
+		s.Run(tc.name, func() {
+			// selecting again, as a replay of the decision would, picks the same task list
+			for i := 0; i < 2; i++ {
+				taskList, selected, err := selectActivityTaskList(tc.header)
+				if tc.isOutputErr {
+					s.IsType(&serviceerror.InvalidArgument{}, err)
+				} else {
+					s.NoError(err)
+				}
+				s.Equal(tc.taskList, taskList)
+				s.Equal(tc.selected, selected)
+			}
+		})
+	}
+}
+
+func (s *decisionAttrValidatorSuite) TestGetTraceContext() {
+	traceParent := []byte("00-0af7651916cd43dd8448eb211c80319c-b7ad6b7169203331-01")
+	header := func(fields map[string][]byte) *commonpb.Header {
+		return &commonpb.Header{Fields: fields}
+	}
+
+	testCases := []struct {
+		name         string
+		header       *commonpb.Header
+		traceContext map[string][]byte
+		isOutputErr  bool
+	}{
+		{"unset", nil, map[string][]byte{}, false},
+		{"no trace context", header(map[string][]byte{"some random key": nil}), map[string][]byte{}, false},
+		{"trace parent", header(map[string][]byte{traceParentHeaderKey: traceParent}), map[string][]byte{traceParentHeaderKey: traceParent}, false},
+		{"mixed case", header(map[string][]byte{"TraceParent": traceParent, "TraceState": []byte("a=b")}), map[string][]byte{traceParentHeaderKey: traceParent, traceStateHeaderKey: []byte("a=b")}, false},
+		{"duplicate", header(map[string][]byte{"TraceParent": traceParent, traceParentHeaderKey: traceParent}), nil, true},
+		{"trace state only", header(map[string][]byte{traceStateHeaderKey: []byte("a=b")}), nil, true},
+		{"malformed trace parent", header(map[string][]byte{traceParentHeaderKey: []byte("00-0af7651916cd43dd-01")}), nil, true},
+	}
+
+	for _, tc := range testCases {
+		s.Run(tc.name, func() {
+			traceContext, err := getTraceContext(tc.header)
+			if tc.isOutputErr {
+				s.IsType(&serviceerror.InvalidArgument{}, err)
+			} else {
+				s.NoError(err)
+			}
+			s.Equal(tc.traceContext, traceContext)
+		})
+	}
+}
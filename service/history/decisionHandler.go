@@ -74,6 +74,8 @@ type (
 		throttledLogger       log.Logger
 		decisionAttrValidator *decisionAttrValidator
 		versionChecker        headers.VersionChecker
+		// activityTaskListPollers caches the task list lookups validating scheduled activities
+		activityTaskListPollers cache.Cache
 	}
 )
 
@@ -97,7 +99,8 @@ func newDecisionHandler(historyEngine *historyEngineImpl) *decisionHandlerImpl {
 			historyEngine.config,
 			historyEngine.logger,
 		),
-		versionChecker: headers.NewVersionChecker(),
+		versionChecker:          headers.NewVersionChecker(),
+		activityTaskListPollers: newActivityTaskListPollersCache(),
 	}
 }
 
@@ -399,6 +402,8 @@ Update_History_Loop:
 			workflowSizeChecker := newWorkflowSizeChecker(
 				handler.config.BlobSizeLimitWarn(namespace),
 				handler.config.BlobSizeLimitError(namespace),
+				handler.config.DecisionBlobSizeBudget(namespace),
+				handler.config.ControlSizeLimitError(namespace),
 				handler.config.HistorySizeLimitWarn(namespace),
 				handler.config.HistorySizeLimitError(namespace),
 				handler.config.HistoryCountLimitWarn(namespace),
@@ -411,6 +416,7 @@ Update_History_Loop:
 			)
 
 			decisionTaskHandler := newDecisionTaskHandler(
+				ctx,
 				request.GetIdentity(),
 				completedEvent.GetEventId(),
 				namespaceEntry,
@@ -419,6 +425,8 @@ Update_History_Loop:
 				workflowSizeChecker,
 				handler.logger,
 				handler.namespaceCache,
+				handler.historyEngine.matchingClient,
+				handler.activityTaskListPollers,
 				handler.metricsClient,
 				handler.config,
 			)
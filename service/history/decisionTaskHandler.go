@@ -21,37 +21,93 @@
 package history
 
 import (
+	"bytes"
+	"context"
 	"fmt"
+	"runtime/debug"
+	"time"
 
+	"github.com/dgryski/go-farm"
 	"github.com/pborman/uuid"
 	commonpb "go.temporal.io/temporal-proto/common"
 	decisionpb "go.temporal.io/temporal-proto/decision"
 	eventpb "go.temporal.io/temporal-proto/event"
 	"go.temporal.io/temporal-proto/serviceerror"
+	tasklistpb "go.temporal.io/temporal-proto/tasklist"
+	"go.temporal.io/temporal-proto/workflowservice"
 
+	"github.com/temporalio/temporal/.gen/proto/matchingservice"
+	"github.com/temporalio/temporal/client/matching"
 	"github.com/temporalio/temporal/common"
 	"github.com/temporalio/temporal/common/backoff"
 	"github.com/temporalio/temporal/common/cache"
 	"github.com/temporalio/temporal/common/log"
 	"github.com/temporalio/temporal/common/log/tag"
 	"github.com/temporalio/temporal/common/metrics"
+	"github.com/temporalio/temporal/common/persistence"
 )
 
+const (
+	// duplicateActivityIDReplay and duplicateActivityIDBatch tag a duplicate activity ID by whether the
+	// conflicting activity was scheduled by a previous decision or by the same decision batch
+	duplicateActivityIDReplay = "replay"
+	duplicateActivityIDBatch  = "batch"
+
+	// stopCauseFailDecision and stopCauseLimitExceeded tag the decisions dropped from a batch by whether
+	// the decision task was failed or the workflow was closed for exceeding a size or count limit
+	stopCauseFailDecision  = "fail_decision"
+	stopCauseLimitExceeded = "limit_exceeded"
+
+	// activityTaskListLookupTimeout bounds the matching lookup used to validate the task list of a scheduled activity
+	activityTaskListLookupTimeout = time.Second
+	// activityTaskListPollersCacheTTL is how long the outcome of a task list lookup is reused across decision tasks,
+	// so that the lookup runs under the workflow lock at most once per task list within the TTL
+	activityTaskListPollersCacheTTL         = time.Minute
+	activityTaskListPollersCacheInitSize    = 128
+	activityTaskListPollersCacheInitMaxSize = 10000
+)
+
+// badAttributesCauses maps the decisions carrying blobs to the cause a decision task is failed with
+// when the decision pushes the total size of the blobs of the batch over the budget
+var badAttributesCauses = map[decisionpb.DecisionType]eventpb.DecisionTaskFailedCause{
+	decisionpb.DecisionTypeScheduleActivityTask:            eventpb.DecisionTaskFailedCauseBadScheduleActivityAttributes,
+	decisionpb.DecisionTypeCompleteWorkflowExecution:       eventpb.DecisionTaskFailedCauseBadCompleteWorkflowExecutionAttributes,
+	decisionpb.DecisionTypeFailWorkflowExecution:           eventpb.DecisionTaskFailedCauseBadFailWorkflowExecutionAttributes,
+	decisionpb.DecisionTypeCancelWorkflowExecution:         eventpb.DecisionTaskFailedCauseBadCancelWorkflowExecutionAttributes,
+	decisionpb.DecisionTypeRecordMarker:                    eventpb.DecisionTaskFailedCauseBadRecordMarkerAttributes,
+	decisionpb.DecisionTypeContinueAsNewWorkflowExecution:  eventpb.DecisionTaskFailedCauseBadContinueAsNewAttributes,
+	decisionpb.DecisionTypeStartChildWorkflowExecution:     eventpb.DecisionTaskFailedCauseBadStartChildExecutionAttributes,
+	decisionpb.DecisionTypeSignalExternalWorkflowExecution: eventpb.DecisionTaskFailedCauseBadSignalWorkflowExecutionAttributes,
+	decisionpb.DecisionTypeUpsertWorkflowSearchAttributes:  eventpb.DecisionTaskFailedCauseBadSearchAttributes,
+}
+
+// parentClosePolicies maps the values of the DefaultParentClosePolicy dynamic config to the policies
+var parentClosePolicies = map[string]commonpb.ParentClosePolicy{
+	"Abandon":       commonpb.ParentClosePolicyAbandon,
+	"Terminate":     commonpb.ParentClosePolicyTerminate,
+	"RequestCancel": commonpb.ParentClosePolicyRequestCancel,
+}
+
 type (
 	decisionAttrValidationFn func() error
 
 	decisionTaskHandlerImpl struct {
+		ctx                     context.Context
 		identity                string
 		decisionTaskCompletedID int64
 		namespaceEntry          *cache.NamespaceCacheEntry
 
 		// internal state
 		hasUnhandledEventsBeforeDecisions bool
+		decisionIndex                     int // index of the decision being handled
 		failDecisionInfo                  *failDecisionInfo
 		activityNotStartedCancelled       bool
 		continueAsNewBuilder              mutableState
 		stopProcessing                    bool // should stop processing any more decisions
 		mutableState                      mutableState
+		initiatedSignals                  map[signalExternalKey]string  // request IDs of the signals initiated by the batch, for dedupe
+		initiatedChildWorkflows           map[childWorkflowKey]struct{} // child workflows initiated by the batch
+		activityTaskListPollers           cache.Cache                   // activity task lists looked up, shared across batches
 
 		// validation
 		attrValidator    *decisionAttrValidator
@@ -59,6 +115,7 @@ type (
 
 		logger         log.Logger
 		namespaceCache cache.NamespaceCache
+		matchingClient matching.Client
 		metricsClient  metrics.Client
 		config         *Config
 	}
@@ -67,9 +124,31 @@ type (
 		cause   eventpb.DecisionTaskFailedCause
 		message string
 	}
+
+	// activityTaskListKey identifies the activity task lists validated by the schedule activity decisions
+	activityTaskListKey struct {
+		namespaceID string
+		taskList    string
+	}
+
+	// signalExternalKey identifies the signals sent by the signal external workflow decisions of a batch
+	signalExternalKey struct {
+		namespaceID string
+		workflowID  string
+		runID       string
+		signalName  string
+		inputHash   uint64
+	}
+
+	// childWorkflowKey identifies the child workflows started by the start child workflow decisions of a batch
+	childWorkflowKey struct {
+		namespaceID string
+		workflowID  string
+	}
 )
 
 func newDecisionTaskHandler(
+	ctx context.Context,
 	identity string,
 	decisionTaskCompletedID int64,
 	namespaceEntry *cache.NamespaceCacheEntry,
@@ -78,11 +157,14 @@ func newDecisionTaskHandler(
 	sizeLimitChecker *workflowSizeChecker,
 	logger log.Logger,
 	namespaceCache cache.NamespaceCache,
+	matchingClient matching.Client,
+	activityTaskListPollers cache.Cache,
 	metricsClient metrics.Client,
 	config *Config,
 ) *decisionTaskHandlerImpl {
 
 	return &decisionTaskHandlerImpl{
+		ctx:                     ctx,
 		identity:                identity,
 		decisionTaskCompletedID: decisionTaskCompletedID,
 		namespaceEntry:          namespaceEntry,
@@ -94,6 +176,9 @@ func newDecisionTaskHandler(
 		continueAsNewBuilder:              nil,
 		stopProcessing:                    false,
 		mutableState:                      mutableState,
+		initiatedSignals:                  make(map[signalExternalKey]string),
+		initiatedChildWorkflows:           make(map[childWorkflowKey]struct{}),
+		activityTaskListPollers:           activityTaskListPollers,
 
 		// validation
 		attrValidator:    attrValidator,
@@ -101,6 +186,7 @@ func newDecisionTaskHandler(
 
 		logger:         logger,
 		namespaceCache: namespaceCache,
+		matchingClient: matchingClient,
 		metricsClient:  metricsClient,
 		config:         config,
 	}
@@ -117,10 +203,25 @@ func (handler *decisionTaskHandlerImpl) handleDecisions(
 		return err
 	}
 
-	for _, decision := range decisions {
+	// buffered events count check
+	if err := handler.checkBufferedEventsCount(); err != nil || handler.stopProcessing {
+		if err == nil {
+			handler.emitDroppedDecisions(decisions)
+		}
+		return err
+	}
+
+	for index, decision := range decisions {
 
+		handler.decisionIndex = index
 		err = handler.handleDecision(decision)
+		if err == nil && !handler.stopProcessing {
+			err = handler.failDecisionIfBlobSizeBudgetExceeded(decision)
+		}
 		if err != nil || handler.stopProcessing {
+			if err == nil {
+				handler.emitDroppedDecisions(decisions[index+1:])
+			}
 			return err
 		}
 	}
@@ -129,7 +230,110 @@ func (handler *decisionTaskHandlerImpl) handleDecisions(
 	return nil
 }
 
-func (handler *decisionTaskHandlerImpl) handleDecision(decision *decisionpb.Decision) error {
+// failDecisionIfBlobSizeBudgetExceeded fails the decision task once the blobs of the batch processed
+// so far together exceed the blob size budget, the events of the batch are discarded with the failure
+func (handler *decisionTaskHandlerImpl) failDecisionIfBlobSizeBudgetExceeded(
+	decision *decisionpb.Decision,
+) error {
+
+	if !handler.sizeLimitChecker.blobSizeBudgetExceeded() {
+		return nil
+	}
+	cause, ok := badAttributesCauses[decision.GetDecisionType()]
+	if !ok {
+		cause = eventpb.DecisionTaskFailedCauseUnhandledDecision
+	}
+	return handler.handlerFailDecision(
+		cause,
+		fmt.Sprintf("Total size of the decision blobs exceeds the budget of %v bytes.", handler.sizeLimitChecker.blobSizeBudget),
+	)
+}
+
+// emitDroppedDecisions counts the decisions of the batch left unprocessed once processing was stopped
+// and, when enabled for the namespace, logs their types
+func (handler *decisionTaskHandlerImpl) emitDroppedDecisions(dropped []*decisionpb.Decision) {
+	if len(dropped) == 0 {
+		return
+	}
+
+	cause := stopCauseLimitExceeded
+	if handler.failDecisionInfo != nil {
+		cause = stopCauseFailDecision
+	}
+	handler.metricsClient.Scope(
+		metrics.HistoryRespondDecisionTaskCompletedScope,
+		metrics.StopCauseTag(cause),
+	).AddCounter(metrics.DroppedDecisionsCounter, int64(len(dropped)))
+
+	namespace := handler.namespaceEntry.GetInfo().Name
+	if !handler.config.LogDroppedDecisions(namespace) {
+		return
+	}
+	decisionTypes := make([]string, 0, len(dropped))
+	for _, decision := range dropped {
+		decisionTypes = append(decisionTypes, decision.GetDecisionType().String())
+	}
+	executionInfo := handler.mutableState.GetExecutionInfo()
+	handler.logger.Info("Decisions dropped after decision processing stopped",
+		tag.WorkflowNamespace(namespace),
+		tag.WorkflowID(executionInfo.WorkflowID),
+		tag.WorkflowRunID(executionInfo.RunID),
+		tag.WorkflowDecisionIndex(handler.decisionIndex),
+		tag.Counter(len(dropped)),
+		tag.WorkflowDecisionTypes(decisionTypes),
+	)
+}
+
+func (handler *decisionTaskHandlerImpl) checkBufferedEventsCount() error {
+
+	namespace := handler.namespaceEntry.GetInfo().Name
+	bufferedEventsCount := handler.mutableState.GetBufferedEventsCount()
+	handler.metricsClient.UpdateGauge(
+		metrics.HistoryRespondDecisionTaskCompletedScope,
+		metrics.BufferedEventsCountGauge,
+		float64(bufferedEventsCount),
+	)
+
+	if bufferedEventsCount > handler.config.BufferedEventsCountLimitError(namespace) {
+		executionInfo := handler.mutableState.GetExecutionInfo()
+		handler.logger.Error("buffered events count exceeds error limit.",
+			tag.WorkflowNamespaceID(executionInfo.NamespaceID),
+			tag.WorkflowID(executionInfo.WorkflowID),
+			tag.WorkflowRunID(executionInfo.RunID),
+			tag.WorkflowEventCount(bufferedEventsCount))
+
+		if _, err := handler.mutableState.AddWorkflowExecutionTerminatedEvent(
+			handler.decisionTaskCompletedID,
+			common.FailureReasonBufferedEventsCountExceedsLimit,
+			[]byte("Workflow buffered events count exceeds limit."),
+			identityHistoryService,
+		); err != nil {
+			return err
+		}
+		handler.stopProcessing = true
+		return nil
+	}
+
+	// failing the decision flushes the buffered events and schedules a new decision to handle them
+	limit := handler.config.BufferedEventsCountLimitWarn(namespace)
+	if bufferedEventsCount > limit {
+		return handler.handlerFailDecision(
+			eventpb.DecisionTaskFailedCauseUnhandledDecision,
+			fmt.Sprintf("Buffered events count %v exceeds limit %v.", bufferedEventsCount, limit),
+		)
+	}
+	return nil
+}
+
+func (handler *decisionTaskHandlerImpl) handleDecision(decision *decisionpb.Decision) (retError error) {
+	// a panic in a single decision fails the decision task instead of taking down the processing,
+	// the mutable state is reloaded when the decision task fails so partial updates are discarded
+	defer func() {
+		if panicObj := recover(); panicObj != nil {
+			retError = handler.handleDecisionPanic(decision, panicObj)
+		}
+	}()
+
 	switch decision.GetDecisionType() {
 	case decisionpb.DecisionTypeScheduleActivityTask:
 		return handler.handleDecisionScheduleActivity(decision.GetScheduleActivityTaskDecisionAttributes())
@@ -175,6 +379,31 @@ func (handler *decisionTaskHandlerImpl) handleDecision(decision *decisionpb.Deci
 	}
 }
 
+func (handler *decisionTaskHandlerImpl) handleDecisionPanic(
+	decision *decisionpb.Decision,
+	panicObj interface{},
+) error {
+
+	handler.metricsClient.IncCounter(
+		metrics.HistoryRespondDecisionTaskCompletedScope,
+		metrics.DecisionPanicCounter,
+	)
+	executionInfo := handler.mutableState.GetExecutionInfo()
+	handler.logger.Error(
+		"Panic is captured while handling decision",
+		tag.WorkflowNamespaceID(executionInfo.NamespaceID),
+		tag.WorkflowID(executionInfo.WorkflowID),
+		tag.WorkflowRunID(executionInfo.RunID),
+		tag.WorkflowDecisionType(int64(decision.GetDecisionType())),
+		tag.SysStackTrace(string(debug.Stack())),
+		tag.Value(panicObj),
+	)
+	return handler.handlerFailDecision(
+		eventpb.DecisionTaskFailedCauseUnhandledDecision,
+		fmt.Sprintf("Panic while handling decision %v: %v.", decision.GetDecisionType(), panicObj),
+	)
+}
+
 func (handler *decisionTaskHandlerImpl) handleDecisionScheduleActivity(
 	attr *decisionpb.ScheduleActivityTaskDecisionAttributes,
 ) error {
@@ -195,6 +424,15 @@ func (handler *decisionTaskHandlerImpl) handleDecisionScheduleActivity(
 		targetNamespaceID = targetNamespaceEntry.GetInfo().ID
 	}
 
+	if err := handler.validateDecisionAttr(
+		func() error {
+			return handler.resolveActivityTaskList(attr)
+		},
+		eventpb.DecisionTaskFailedCauseBadScheduleActivityAttributes,
+	); err != nil || handler.stopProcessing {
+		return err
+	}
+
 	if err := handler.validateDecisionAttr(
 		func() error {
 			return handler.attrValidator.validateActivityScheduleAttributes(
@@ -209,6 +447,26 @@ func (handler *decisionTaskHandlerImpl) handleDecisionScheduleActivity(
 		return err
 	}
 
+	if err := handler.validateDecisionAttr(
+		func() error {
+			return handler.validateActivityTaskListForType(attr)
+		},
+		eventpb.DecisionTaskFailedCauseBadScheduleActivityAttributes,
+	); err != nil || handler.stopProcessing {
+		return err
+	}
+
+	if handler.config.EnableNamespaceTagPropagation(handler.namespaceEntry.GetInfo().Name) {
+		if err := handler.validateDecisionAttr(
+			func() error {
+				return handler.attachNamespaceTags(attr)
+			},
+			eventpb.DecisionTaskFailedCauseBadScheduleActivityAttributes,
+		); err != nil || handler.stopProcessing {
+			return err
+		}
+	}
+
 	failWorkflow, err := handler.sizeLimitChecker.failWorkflowIfBlobSizeExceedsLimit(
 		attr.Input,
 		"ScheduleActivityTaskDecisionAttributes.Input exceeds size limit.",
@@ -218,19 +476,178 @@ func (handler *decisionTaskHandlerImpl) handleDecisionScheduleActivity(
 		return err
 	}
 
+	namespace := handler.namespaceEntry.GetInfo().Name
+	if handler.config.ValidateActivityTaskList(namespace) &&
+		!handler.hasActivityPollers(targetNamespaceID, attr.TaskList.GetName()) {
+		handler.metricsClient.IncCounter(
+			metrics.HistoryRespondDecisionTaskCompletedScope,
+			metrics.UnknownActivityTaskListCounter,
+		)
+		handler.logger.Warn(
+			"Activity scheduled on task list without activity pollers",
+			tag.WorkflowNamespaceID(targetNamespaceID),
+			tag.WorkflowTaskListName(attr.TaskList.GetName()),
+			tag.WorkflowActivityID(attr.GetActivityId()),
+		)
+		if handler.config.FailUnknownActivityTaskList(namespace) {
+			return handler.handlerFailDecision(
+				eventpb.DecisionTaskFailedCauseBadScheduleActivityAttributes,
+				fmt.Sprintf("TaskList %v has no activity pollers.", attr.TaskList.GetName()),
+			)
+		}
+	}
+
+	if maxPendingActivities := handler.config.MaxPendingActivitiesPerWorkflow(namespace); maxPendingActivities > 0 &&
+		len(handler.mutableState.GetPendingActivityInfos()) >= maxPendingActivities {
+		handler.metricsClient.IncCounter(
+			metrics.HistoryRespondDecisionTaskCompletedScope,
+			metrics.PendingActivitiesLimitExceededCounter,
+		)
+		return handler.handlerFailDecision(
+			eventpb.DecisionTaskFailedCauseBadScheduleActivityAttributes,
+			fmt.Sprintf("Workflow already has %v pending activities, which is the maximum allowed.", maxPendingActivities),
+		)
+	}
+
 	_, _, err = handler.mutableState.AddActivityTaskScheduledEvent(handler.decisionTaskCompletedID, attr)
 	switch err.(type) {
 	case nil:
 		return nil
 	case *serviceerror.InvalidArgument:
+		message := fmt.Sprintf("ActivityId %v is already in use.", attr.GetActivityId())
+		if ai, ok := handler.mutableState.GetActivityByActivityID(attr.GetActivityId()); ok {
+			// an activity scheduled before this decision task completed was already in history,
+			// otherwise it was scheduled by an earlier decision of the same batch
+			duplicateType := duplicateActivityIDReplay
+			if ai.ScheduleID > handler.decisionTaskCompletedID {
+				duplicateType = duplicateActivityIDBatch
+			}
+			handler.metricsClient.Scope(
+				metrics.HistoryRespondDecisionTaskCompletedScope,
+				metrics.DuplicateTypeTag(duplicateType),
+			).IncCounter(metrics.ScheduleActivityDuplicateIDCounter)
+			message = fmt.Sprintf("ActivityId %v is already scheduled by event %v.", attr.GetActivityId(), ai.ScheduleID)
+		}
 		return handler.handlerFailDecision(
-			eventpb.DecisionTaskFailedCauseScheduleActivityDuplicateId, "",
+			eventpb.DecisionTaskFailedCauseScheduleActivityDuplicateId, message,
 		)
 	default:
 		return err
 	}
 }
 
+// attachNamespaceTags adds the tags of the workflow namespace to the activity header for downstream metering,
+// the tags take precedence over header fields of the same name set by the decision
+func (handler *decisionTaskHandlerImpl) attachNamespaceTags(
+	attr *decisionpb.ScheduleActivityTaskDecisionAttributes,
+) error {
+
+	tags := getNamespaceTags(handler.namespaceEntry.GetInfo().Data)
+	if len(tags) == 0 {
+		return nil
+	}
+
+	fields := make(map[string][]byte, len(attr.GetHeader().GetFields())+len(tags))
+	for key, value := range attr.GetHeader().GetFields() {
+		fields[key] = value
+	}
+	for key, value := range tags {
+		fields[key] = value
+	}
+	header := &commonpb.Header{Fields: fields}
+
+	if size := getHeaderSize(header); size > handler.sizeLimitChecker.blobSizeLimitError {
+		return serviceerror.NewInvalidArgument(fmt.Sprintf(
+			"ScheduleActivityTaskDecisionAttributes.Header size %v with namespace tags exceeds size limit %v.",
+			size,
+			handler.sizeLimitChecker.blobSizeLimitError,
+		))
+	}
+	attr.Header = header
+	return nil
+}
+
+// resolveActivityTaskList replaces the activity task list by the one picked by the task list selector,
+// the picked task list is recorded in the activity scheduled event, so it is fixed from then on
+func (handler *decisionTaskHandlerImpl) resolveActivityTaskList(
+	attr *decisionpb.ScheduleActivityTaskDecisionAttributes,
+) error {
+
+	taskListName, ok, err := selectActivityTaskList(attr.GetHeader())
+	if err != nil || !ok {
+		return err
+	}
+	if attr.TaskList == nil {
+		attr.TaskList = &tasklistpb.TaskList{}
+	}
+	attr.TaskList.Name = taskListName
+	return nil
+}
+
+// validateActivityTaskListForType checks that the activity is scheduled on the task list registered
+// for its type in the namespace config, activity types without a registered task list may use any
+func (handler *decisionTaskHandlerImpl) validateActivityTaskListForType(
+	attr *decisionpb.ScheduleActivityTaskDecisionAttributes,
+) error {
+
+	activityType := attr.ActivityType.GetName()
+	namespace := handler.namespaceEntry.GetInfo().Name
+	requiredTaskList, ok := handler.config.ActivityTaskListsByType(namespace)[activityType].(string)
+	if !ok || requiredTaskList == "" {
+		return nil
+	}
+	if attr.TaskList.GetName() != requiredTaskList {
+		return serviceerror.NewInvalidArgument(fmt.Sprintf(
+			"ActivityType %v must be scheduled on TaskList %v, but was scheduled on TaskList %v.",
+			activityType,
+			requiredTaskList,
+			attr.TaskList.GetName(),
+		))
+	}
+	return nil
+}
+
+// newActivityTaskListPollersCache creates the cache of task list lookups shared by the decision tasks of a shard
+func newActivityTaskListPollersCache() cache.Cache {
+	return cache.New(activityTaskListPollersCacheInitMaxSize, &cache.Options{
+		InitialCapacity: activityTaskListPollersCacheInitSize,
+		TTL:             activityTaskListPollersCacheTTL,
+		Pin:             false,
+	})
+}
+
+// hasActivityPollers returns whether matching has seen the task list serving activity tasks, lookup failures
+// are treated as a known task list so that validation never blocks on matching. The outcome is cached for
+// activityTaskListPollersCacheTTL, each lookup is bounded by activityTaskListLookupTimeout.
+func (handler *decisionTaskHandlerImpl) hasActivityPollers(
+	namespaceID string,
+	taskList string,
+) bool {
+
+	key := activityTaskListKey{namespaceID: namespaceID, taskList: taskList}
+	if hasPollers, ok := handler.activityTaskListPollers.Get(key).(bool); ok {
+		return hasPollers
+	}
+
+	ctx, cancel := context.WithTimeout(handler.ctx, activityTaskListLookupTimeout)
+	defer cancel()
+	resp, err := handler.matchingClient.DescribeTaskList(ctx, &matchingservice.DescribeTaskListRequest{
+		NamespaceId: namespaceID,
+		DescRequest: &workflowservice.DescribeTaskListRequest{
+			TaskList:     &tasklistpb.TaskList{Name: taskList},
+			TaskListType: tasklistpb.TaskListTypeActivity,
+		},
+	})
+	hasPollers := true
+	if err != nil {
+		handler.logger.Warn("Failed to describe activity task list", tag.WorkflowTaskListName(taskList), tag.Error(err))
+	} else {
+		hasPollers = len(resp.GetPollers()) > 0
+	}
+	handler.activityTaskListPollers.Put(key, hasPollers)
+	return hasPollers
+}
+
 func (handler *decisionTaskHandlerImpl) handleDecisionRequestCancelActivity(
 	attr *decisionpb.RequestCancelActivityTaskDecisionAttributes,
 ) error {
@@ -296,7 +713,7 @@ func (handler *decisionTaskHandlerImpl) handleDecisionStartTimer(
 
 	if err := handler.validateDecisionAttr(
 		func() error {
-			return handler.attrValidator.validateTimerScheduleAttributes(attr)
+			return handler.attrValidator.validateTimerScheduleAttributes(handler.namespaceEntry.GetInfo().Name, attr)
 		},
 		eventpb.DecisionTaskFailedCauseBadStartTimerAttributes,
 	); err != nil || handler.stopProcessing {
@@ -381,6 +798,7 @@ func (handler *decisionTaskHandlerImpl) handleDecisionCompleteWorkflow(
 		return err
 	}
 	startAttributes := startEvent.GetWorkflowExecutionStartedEventAttributes()
+	handler.emitRetryCronContinueAsNewCounter(commonpb.ContinueAsNewInitiatorCronSchedule)
 	return handler.retryCronContinueAsNew(
 		startAttributes,
 		int32(cronBackoff.Seconds()),
@@ -413,6 +831,19 @@ func (handler *decisionTaskHandlerImpl) handleDecisionFailWorkflow(
 		return err
 	}
 
+	// the category is mirrored into the close visibility record from the failure event, see getCloseSearchAttributes
+	if namespace := handler.namespaceEntry.GetInfo().Name; handler.config.EnableStructuredFailureDetails(namespace) {
+		if err := handler.validateDecisionAttr(
+			func() error {
+				_, err := handler.attrValidator.validateFailureCategory(namespace, attr.GetDetails())
+				return err
+			},
+			eventpb.DecisionTaskFailedCauseBadFailWorkflowExecutionAttributes,
+		); err != nil || handler.stopProcessing {
+			return err
+		}
+	}
+
 	failWorkflow, err := handler.sizeLimitChecker.failWorkflowIfBlobSizeExceedsLimit(
 		attr.Details,
 		"FailWorkflowExecutionDecisionAttributes.Details exceeds size limit.",
@@ -464,6 +895,7 @@ func (handler *decisionTaskHandlerImpl) handleDecisionFailWorkflow(
 		return err
 	}
 	startAttributes := startEvent.GetWorkflowExecutionStartedEventAttributes()
+	handler.emitRetryCronContinueAsNewCounter(continueAsNewInitiator)
 	return handler.retryCronContinueAsNew(
 		startAttributes,
 		int32(backoffInterval.Seconds()),
@@ -474,6 +906,18 @@ func (handler *decisionTaskHandlerImpl) handleDecisionFailWorkflow(
 	)
 }
 
+// emitRetryCronContinueAsNewCounter records whether a workflow continues as new because of its retry policy
+// or its cron schedule, so that retry storms can be told apart from cron activity
+func (handler *decisionTaskHandlerImpl) emitRetryCronContinueAsNewCounter(
+	initiator commonpb.ContinueAsNewInitiator,
+) {
+
+	handler.metricsClient.Scope(
+		metrics.HistoryRespondDecisionTaskCompletedScope,
+		metrics.ContinueAsNewInitiatorTag(initiator.String()),
+	).IncCounter(metrics.RetryCronContinueAsNewCounter)
+}
+
 func (handler *decisionTaskHandlerImpl) handleDecisionCancelTimer(
 	attr *decisionpb.CancelTimerDecisionAttributes,
 ) error {
@@ -536,6 +980,15 @@ func (handler *decisionTaskHandlerImpl) handleDecisionCancelWorkflow(
 		return err
 	}
 
+	failWorkflow, err := handler.sizeLimitChecker.failWorkflowIfBlobSizeExceedsLimit(
+		attr.Details,
+		"CancelWorkflowExecutionDecisionAttributes.Details exceeds size limit.",
+	)
+	if err != nil || failWorkflow {
+		handler.stopProcessing = true
+		return err
+	}
+
 	// If the decision has more than one completion event than just pick the first one
 	if !handler.mutableState.IsWorkflowExecutionRunning() {
 		handler.metricsClient.IncCounter(
@@ -550,7 +1003,15 @@ func (handler *decisionTaskHandlerImpl) handleDecisionCancelWorkflow(
 		return nil
 	}
 
-	_, err := handler.mutableState.AddWorkflowExecutionCanceledEvent(handler.decisionTaskCompletedID, attr)
+	// with a grace period configured, the first cancel decision only starts the grace period,
+	// the workflow is canceled once the grace period is over or when the workflow issues another cancel decision
+	gracePeriod := handler.config.WorkflowCancellationGracePeriod(handler.namespaceEntry.GetInfo().Name)
+	if handler.mutableState.GetExecutionInfo().CancelGracePeriodCompletedEventID == 0 && gracePeriod > 0 {
+		_, err := handler.mutableState.AddCancellationGracePeriod(handler.decisionTaskCompletedID, attr, gracePeriod)
+		return err
+	}
+
+	_, err = handler.mutableState.AddWorkflowExecutionCanceledEvent(handler.decisionTaskCompletedID, attr)
 	return err
 }
 
@@ -621,6 +1082,18 @@ func (handler *decisionTaskHandlerImpl) handleDecisionRecordMarker(
 		return err
 	}
 
+	if handler.config.EnableMarkerConsistencyCheck(handler.namespaceEntry.GetInfo().Name) {
+		if key, ok := getMarkerChecksumKey(attr.GetMarkerName(), attr.GetHeader()); ok {
+			checksum, recorded := handler.mutableState.GetExecutionInfo().MarkerChecksums[key]
+			if recorded && !bytes.Equal(checksum, getMarkerDetailsChecksum(attr.GetDetails())) {
+				return handler.handlerFailDecision(
+					eventpb.DecisionTaskFailedCauseBadRecordMarkerAttributes,
+					fmt.Sprintf("Marker %v was already recorded with different details.", key),
+				)
+			}
+		}
+	}
+
 	_, err = handler.mutableState.AddRecordMarkerEvent(handler.decisionTaskCompletedID, attr)
 	return err
 }
@@ -661,6 +1134,42 @@ func (handler *decisionTaskHandlerImpl) handleDecisionContinueAsNewWorkflow(
 		return err
 	}
 
+	// the new run may be subject to a stricter limit than this decision, fail the decision
+	// rather than starting a run which cannot make progress
+	if err := handler.validateDecisionAttr(
+		func() error {
+			return handler.attrValidator.validateContinueAsNewInputSize(
+				attr,
+				handler.namespaceEntry.GetInfo().Name,
+			)
+		},
+		eventpb.DecisionTaskFailedCauseBadContinueAsNewAttributes,
+	); err != nil || handler.stopProcessing {
+		return err
+	}
+
+	if err := handler.validateDecisionAttr(
+		func() error {
+			return handler.attrValidator.validateContinueAsNewRequiredSearchAttributes(
+				attr,
+				executionInfo,
+				handler.namespaceEntry.GetInfo().Name,
+			)
+		},
+		eventpb.DecisionTaskFailedCauseBadContinueAsNewAttributes,
+	); err != nil || handler.stopProcessing {
+		return err
+	}
+
+	failWorkflow, err = handler.sizeLimitChecker.failWorkflowIfMemoSizeExceedsLimit(
+		attr.GetMemo(),
+		"ContinueAsNewWorkflowExecutionDecisionAttributes.Memo exceeds size limit.",
+	)
+	if err != nil || failWorkflow {
+		handler.stopProcessing = true
+		return err
+	}
+
 	// If the decision has more than one completion event than just pick the first one
 	if !handler.mutableState.IsWorkflowExecutionRunning() {
 		handler.metricsClient.IncCounter(
@@ -696,8 +1205,7 @@ func (handler *decisionTaskHandlerImpl) handleDecisionContinueAsNewWorkflow(
 		return err
 	}
 
-	handler.continueAsNewBuilder = newStateBuilder
-	return nil
+	return handler.setContinueAsNewBuilder(newStateBuilder)
 }
 
 func (handler *decisionTaskHandlerImpl) handleDecisionStartChildWorkflow(
@@ -720,6 +1228,13 @@ func (handler *decisionTaskHandlerImpl) handleDecisionStartChildWorkflow(
 		targetNamespaceID = targetNamespaceEntry.GetInfo().ID
 	}
 
+	if attr.RetryPolicy == nil &&
+		executionInfo.HasRetryPolicy &&
+		handler.config.InheritChildRetryPolicy(handler.namespaceEntry.GetInfo().Name) {
+		// child does not specify a retry policy, use the one of the parent
+		attr.RetryPolicy = retryPolicyFromExecutionInfo(executionInfo)
+	}
+
 	if err := handler.validateDecisionAttr(
 		func() error {
 			return handler.attrValidator.validateStartChildExecutionAttributes(
@@ -734,6 +1249,30 @@ func (handler *decisionTaskHandlerImpl) handleDecisionStartChildWorkflow(
 		return err
 	}
 
+	// the second start of the same child would only fail once the child is started, fail the batch early instead
+	childKey := childWorkflowKey{
+		namespaceID: targetNamespaceID,
+		workflowID:  attr.GetWorkflowId(),
+	}
+	if _, ok := handler.initiatedChildWorkflows[childKey]; ok {
+		return handler.handlerFailDecision(
+			eventpb.DecisionTaskFailedCauseBadStartChildExecutionAttributes,
+			fmt.Sprintf("Child workflow %v is already started by another decision of the batch.", attr.GetWorkflowId()),
+		)
+	}
+
+	var traceContext map[string][]byte
+	if err := handler.validateDecisionAttr(
+		func() error {
+			var err error
+			traceContext, err = getTraceContext(attr.GetHeader())
+			return err
+		},
+		eventpb.DecisionTaskFailedCauseBadStartChildExecutionAttributes,
+	); err != nil || handler.stopProcessing {
+		return err
+	}
+
 	failWorkflow, err := handler.sizeLimitChecker.failWorkflowIfBlobSizeExceedsLimit(
 		attr.Input,
 		"StartChildWorkflowExecutionDecisionAttributes.Input exceeds size limit.",
@@ -743,16 +1282,62 @@ func (handler *decisionTaskHandlerImpl) handleDecisionStartChildWorkflow(
 		return err
 	}
 
+	failWorkflow, err = handler.sizeLimitChecker.failWorkflowIfMemoSizeExceedsLimit(
+		attr.GetMemo(),
+		"StartChildWorkflowExecutionDecisionAttributes.Memo exceeds size limit.",
+	)
+	if err != nil || failWorkflow {
+		handler.stopProcessing = true
+		return err
+	}
+
 	enabled := handler.config.EnableParentClosePolicy(handler.namespaceEntry.GetInfo().Name)
 	if !enabled {
 		attr.ParentClosePolicy = commonpb.ParentClosePolicyAbandon
+	} else if attr.GetParentClosePolicy() == commonpb.ParentClosePolicyAbandon && isParentClosePolicyUnset(attr.GetHeader()) {
+		// an explicit policy, abandon included, always overrides the namespace default
+		attr.ParentClosePolicy = handler.defaultParentClosePolicy()
+	}
+
+	// the initiated event header is the one the child is started with, so the trace context
+	// recorded under its canonical keys links the child trace to the parent one
+	if len(traceContext) > 0 {
+		attr.Header = withTraceContext(attr.Header, traceContext)
+		handler.metricsClient.IncCounter(
+			metrics.HistoryRespondDecisionTaskCompletedScope,
+			metrics.ChildWorkflowWithTraceContextCounter,
+		)
+	} else {
+		handler.metricsClient.IncCounter(
+			metrics.HistoryRespondDecisionTaskCompletedScope,
+			metrics.ChildWorkflowWithoutTraceContextCounter,
+		)
 	}
 
 	requestID := uuid.New()
 	_, _, err = handler.mutableState.AddStartChildWorkflowExecutionInitiatedEvent(
 		handler.decisionTaskCompletedID, requestID, attr,
 	)
-	return err
+	if err != nil {
+		return err
+	}
+	handler.initiatedChildWorkflows[childKey] = struct{}{}
+	return nil
+}
+
+// defaultParentClosePolicy returns the namespace default parent close policy, an invalid configured
+// value is logged and abandon is used instead.
+func (handler *decisionTaskHandlerImpl) defaultParentClosePolicy() commonpb.ParentClosePolicy {
+	namespace := handler.namespaceEntry.GetInfo().Name
+	value := handler.config.DefaultParentClosePolicy(namespace)
+	policy, ok := parentClosePolicies[value]
+	if !ok {
+		handler.logger.Error("Invalid default parent close policy.",
+			tag.WorkflowNamespace(namespace),
+			tag.Value(value))
+		return commonpb.ParentClosePolicyAbandon
+	}
+	return policy
 }
 
 func (handler *decisionTaskHandlerImpl) handleDecisionSignalExternalWorkflow(
@@ -796,12 +1381,53 @@ func (handler *decisionTaskHandlerImpl) handleDecisionSignalExternalWorkflow(
 		handler.stopProcessing = true
 		return err
 	}
+	if handler.sizeLimitChecker.controlSizeExceedsLimit(attr.GetControl()) {
+		return handler.handlerFailDecision(
+			eventpb.DecisionTaskFailedCauseBadSignalWorkflowExecutionAttributes,
+			"SignalExternalWorkflowExecutionDecisionAttributes.Control exceeds size limit.",
+		)
+	}
 
+	signalKey := signalExternalKey{
+		namespaceID: targetNamespaceID,
+		workflowID:  attr.Execution.GetWorkflowId(),
+		runID:       attr.Execution.GetRunId(),
+		signalName:  attr.GetSignalName(),
+		inputHash:   farm.Fingerprint64(attr.GetInput()),
+	}
 	signalRequestID := uuid.New() // for deduplicate
+	dedupe := handler.config.DedupeSignalExternalDecisions(handler.namespaceEntry.GetInfo().Name)
+	if requestID, ok := handler.initiatedSignals[signalKey]; ok && dedupe {
+		// the same signal was already initiated by this batch, the decision still records its initiated
+		// event but reuses the request ID, so the target workflow only accepts the signal once
+		handler.metricsClient.IncCounter(
+			metrics.HistoryRespondDecisionTaskCompletedScope,
+			metrics.DedupedSignalExternalDecisionsCounter,
+		)
+		signalRequestID = requestID
+	}
+	if handler.config.DedupeSignalControlIDs(handler.namespaceEntry.GetInfo().Name) {
+		if key, ok := getSignalControlKey(attr.GetControl()); ok {
+			if info, signaled := executionInfo.SignalControlIDs[key]; signaled {
+				// an earlier decision, like the one of a previous attempt of the decision task, already signaled with
+				// the control, the signal is initiated again under the persisted request ID so the target drops it
+				handler.metricsClient.IncCounter(
+					metrics.HistoryRespondDecisionTaskCompletedScope,
+					metrics.DedupedSignalExternalDecisionsCounter,
+				)
+				signalRequestID = info.GetRequestId()
+			}
+		}
+	}
+
 	_, _, err = handler.mutableState.AddSignalExternalWorkflowExecutionInitiatedEvent(
 		handler.decisionTaskCompletedID, signalRequestID, attr,
 	)
-	return err
+	if err != nil {
+		return err
+	}
+	handler.initiatedSignals[signalKey] = signalRequestID
+	return nil
 }
 
 func (handler *decisionTaskHandlerImpl) handleDecisionUpsertWorkflowSearchAttributes(
@@ -870,6 +1496,19 @@ func (handler *decisionTaskHandlerImpl) retryCronContinueAsNew(
 	lastCompletionResult []byte,
 ) error {
 
+	namespace := handler.namespaceEntry.GetInfo().Name
+	if maxBackoff := int32(handler.config.MaxRetryCronBackoffInterval(namespace).Seconds()); maxBackoff > 0 && backoffInterval > maxBackoff {
+		executionInfo := handler.mutableState.GetExecutionInfo()
+		handler.logger.Info(
+			"Capping retry or cron backoff interval to the namespace maximum.",
+			tag.WorkflowNamespace(namespace),
+			tag.WorkflowID(executionInfo.WorkflowID),
+			tag.WorkflowRunID(executionInfo.RunID),
+			tag.Value(backoffInterval),
+		)
+		backoffInterval = maxBackoff
+	}
+
 	continueAsNewAttributes := &decisionpb.ContinueAsNewWorkflowExecutionDecisionAttributes{
 		WorkflowType:                        attr.WorkflowType,
 		TaskList:                            attr.TaskList,
@@ -898,6 +1537,27 @@ func (handler *decisionTaskHandlerImpl) retryCronContinueAsNew(
 		return err
 	}
 
+	return handler.setContinueAsNewBuilder(newStateBuilder)
+}
+
+func (handler *decisionTaskHandlerImpl) setContinueAsNewBuilder(
+	newStateBuilder mutableState,
+) error {
+
+	// the continue as new event is already added to the current run at this point, failing the
+	// decision discards it along with the other updates accumulated by this decision task
+	if err := handler.validateDecisionAttr(
+		func() error {
+			return handler.attrValidator.validateContinueAsNewBuilder(
+				newStateBuilder,
+				handler.namespaceEntry.GetInfo().Name,
+			)
+		},
+		eventpb.DecisionTaskFailedCauseBadContinueAsNewAttributes,
+	); err != nil || handler.stopProcessing {
+		return err
+	}
+
 	handler.continueAsNewBuilder = newStateBuilder
 	return nil
 }
@@ -926,5 +1586,34 @@ func (handler *decisionTaskHandlerImpl) handlerFailDecision(
 		message: failMessage,
 	}
 	handler.stopProcessing = true
+
+	executionInfo := handler.mutableState.GetExecutionInfo()
+	namespace := handler.namespaceEntry.GetInfo().Name
+	tags := []tag.Tag{
+		tag.WorkflowNamespace(namespace),
+		tag.WorkflowID(executionInfo.WorkflowID),
+		tag.WorkflowRunID(executionInfo.RunID),
+		tag.WorkflowDecisionIndex(handler.decisionIndex),
+		tag.WorkflowDecisionFailCause(int64(failedCause)),
+	}
+	// failure message may embed decision attributes, only log it when explicitly enabled
+	if handler.config.LogDecisionFailureMessage(namespace) {
+		tags = append(tags, tag.WorkflowDecisionFailMessage(failMessage))
+	}
+	handler.logger.Warn("Decision task failed", tags...)
 	return nil
 }
+
+func retryPolicyFromExecutionInfo(
+	executionInfo *persistence.WorkflowExecutionInfo,
+) *commonpb.RetryPolicy {
+
+	return &commonpb.RetryPolicy{
+		InitialIntervalInSeconds:    executionInfo.InitialInterval,
+		BackoffCoefficient:          executionInfo.BackoffCoefficient,
+		MaximumIntervalInSeconds:    executionInfo.MaximumInterval,
+		MaximumAttempts:             executionInfo.MaximumAttempts,
+		NonRetriableErrorReasons:    executionInfo.NonRetriableErrors,
+		ExpirationIntervalInSeconds: executionInfo.ExpirationSeconds,
+	}
+}
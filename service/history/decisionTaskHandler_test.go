@@ -0,0 +1,1532 @@
+// The MIT License (MIT)
+//
+// Copyright (c) 2020 Temporal Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package history
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/dgryski/go-farm"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+	"github.com/uber-go/tally"
+	commonpb "go.temporal.io/temporal-proto/common"
+	decisionpb "go.temporal.io/temporal-proto/decision"
+	eventpb "go.temporal.io/temporal-proto/event"
+	executionpb "go.temporal.io/temporal-proto/execution"
+	"go.temporal.io/temporal-proto/serviceerror"
+	tasklistpb "go.temporal.io/temporal-proto/tasklist"
+	"go.temporal.io/temporal-proto/workflowservice"
+
+	"github.com/temporalio/temporal/.gen/proto/matchingservice"
+	"github.com/temporalio/temporal/.gen/proto/matchingservicemock"
+	"github.com/temporalio/temporal/.gen/proto/persistenceblobs"
+	"github.com/temporalio/temporal/common"
+	"github.com/temporalio/temporal/common/backoff"
+	"github.com/temporalio/temporal/common/cache"
+	"github.com/temporalio/temporal/common/cluster"
+	"github.com/temporalio/temporal/common/definition"
+	"github.com/temporalio/temporal/common/log"
+	"github.com/temporalio/temporal/common/log/tag"
+	"github.com/temporalio/temporal/common/metrics"
+	"github.com/temporalio/temporal/common/persistence"
+	"github.com/temporalio/temporal/common/service/dynamicconfig"
+)
+
+type (
+	decisionTaskHandlerSuite struct {
+		suite.Suite
+		*require.Assertions
+
+		controller         *gomock.Controller
+		mockMutableState   *MockmutableState
+		mockMatchingClient *matchingservicemock.MockMatchingServiceClient
+		mockLogger         *log.MockLogger
+		metricsScope       tally.TestScope
+		executionInfo      *persistence.WorkflowExecutionInfo
+
+		config  *Config
+		handler *decisionTaskHandlerImpl
+	}
+)
+
+func TestDecisionTaskHandlerSuite(t *testing.T) {
+	s := new(decisionTaskHandlerSuite)
+	suite.Run(t, s)
+}
+
+func (s *decisionTaskHandlerSuite) SetupTest() {
+	s.Assertions = require.New(s.T())
+
+	s.controller = gomock.NewController(s.T())
+	s.mockMutableState = NewMockmutableState(s.controller)
+	s.mockMutableState.EXPECT().HasBufferedEvents().Return(false).AnyTimes()
+	s.executionInfo = &persistence.WorkflowExecutionInfo{
+		NamespaceID: testNamespaceID,
+		WorkflowID:  testWorkflowID,
+		RunID:       testRunID,
+	}
+	s.mockMutableState.EXPECT().GetExecutionInfo().Return(s.executionInfo).AnyTimes()
+	s.mockMatchingClient = matchingservicemock.NewMockMatchingServiceClient(s.controller)
+	s.mockLogger = &log.MockLogger{}
+
+	s.config = NewDynamicConfigForTest()
+	s.metricsScope = tally.NewTestScope("test", nil)
+	s.handler = s.newDecisionTaskHandler()
+}
+
+func (s *decisionTaskHandlerSuite) TearDownTest() {
+	s.controller.Finish()
+	s.mockLogger.AssertExpectations(s.T())
+}
+
+func (s *decisionTaskHandlerSuite) newDecisionTaskHandler() *decisionTaskHandlerImpl {
+	metricsClient := metrics.NewClient(s.metricsScope, metrics.History)
+	return newDecisionTaskHandler(
+		context.Background(),
+		"some random identity",
+		int64(4),
+		testLocalNamespaceEntry,
+		s.mockMutableState,
+		newDecisionAttrValidator(nil, s.config, s.mockLogger),
+		newWorkflowSizeChecker(
+			s.config.BlobSizeLimitWarn(testNamespace),
+			s.config.BlobSizeLimitError(testNamespace),
+			s.config.DecisionBlobSizeBudget(testNamespace),
+			s.config.ControlSizeLimitError(testNamespace),
+			s.config.HistorySizeLimitWarn(testNamespace),
+			s.config.HistorySizeLimitError(testNamespace),
+			s.config.HistoryCountLimitWarn(testNamespace),
+			s.config.HistoryCountLimitError(testNamespace),
+			int64(4),
+			s.mockMutableState,
+			&persistence.ExecutionStats{},
+			metricsClient,
+			s.mockLogger,
+		),
+		s.mockLogger,
+		nil,
+		s.mockMatchingClient,
+		newActivityTaskListPollersCache(),
+		metricsClient,
+		s.config,
+	)
+}
+
+func (s *decisionTaskHandlerSuite) TestHandlerFailDecision_LogsCorrelationTags() {
+	failMessage := "some random fail message"
+	s.mockLogger.On("Warn", "Decision task failed", mock.Anything).Run(func(args mock.Arguments) {
+		tags := args.Get(1).([]tag.Tag)
+		s.Contains(tags, tag.WorkflowNamespace(testNamespace))
+		s.Contains(tags, tag.WorkflowID(testWorkflowID))
+		s.Contains(tags, tag.WorkflowRunID(testRunID))
+		s.Contains(tags, tag.WorkflowDecisionIndex(2))
+		s.Contains(tags, tag.WorkflowDecisionFailCause(int64(eventpb.DecisionTaskFailedCauseBadStartTimerAttributes)))
+		s.NotContains(tags, tag.WorkflowDecisionFailMessage(failMessage))
+	}).Once()
+
+	s.handler.decisionIndex = 2
+	err := s.handler.handlerFailDecision(eventpb.DecisionTaskFailedCauseBadStartTimerAttributes, failMessage)
+	s.NoError(err)
+	s.True(s.handler.stopProcessing)
+	s.Equal(eventpb.DecisionTaskFailedCauseBadStartTimerAttributes, s.handler.failDecisionInfo.cause)
+	s.Equal(failMessage, s.handler.failDecisionInfo.message)
+}
+
+func (s *decisionTaskHandlerSuite) TestHandlerFailDecision_LogsMessageWhenEnabled() {
+	failMessage := "some random fail message"
+	s.config.LogDecisionFailureMessage = dynamicconfig.GetBoolPropertyFnFilteredByNamespace(true)
+	s.mockLogger.On("Warn", "Decision task failed", mock.Anything).Run(func(args mock.Arguments) {
+		tags := args.Get(1).([]tag.Tag)
+		s.Contains(tags, tag.WorkflowDecisionFailMessage(failMessage))
+	}).Once()
+
+	err := s.handler.handlerFailDecision(eventpb.DecisionTaskFailedCauseBadStartTimerAttributes, failMessage)
+	s.NoError(err)
+}
+
+func (s *decisionTaskHandlerSuite) TestHandleDecisionRecordMarker_ReplayedMarkerMatches() {
+	s.config.EnableMarkerConsistencyCheck = dynamicconfig.GetBoolPropertyFnFilteredByNamespace(true)
+	attr := &decisionpb.RecordMarkerDecisionAttributes{
+		MarkerName: "some random marker name",
+		Details:    []byte("some random marker details"),
+		Header:     &commonpb.Header{Fields: map[string][]byte{markerIDHeaderKey: []byte("1")}},
+	}
+	s.executionInfo.MarkerChecksums = map[string][]byte{
+		"some random marker name/1": getMarkerDetailsChecksum(attr.Details),
+	}
+	s.mockMutableState.EXPECT().AddRecordMarkerEvent(int64(4), attr).Return(&eventpb.HistoryEvent{}, nil).Times(1)
+
+	err := s.handler.handleDecisionRecordMarker(attr)
+	s.NoError(err)
+	s.False(s.handler.stopProcessing)
+	s.Nil(s.handler.failDecisionInfo)
+}
+
+func (s *decisionTaskHandlerSuite) TestHandleDecisionRecordMarker_ReplayedMarkerMismatches() {
+	s.config.EnableMarkerConsistencyCheck = dynamicconfig.GetBoolPropertyFnFilteredByNamespace(true)
+	attr := &decisionpb.RecordMarkerDecisionAttributes{
+		MarkerName: "some random marker name",
+		Details:    []byte("some random marker details"),
+		Header:     &commonpb.Header{Fields: map[string][]byte{markerIDHeaderKey: []byte("1")}},
+	}
+	s.executionInfo.MarkerChecksums = map[string][]byte{
+		"some random marker name/1": getMarkerDetailsChecksum([]byte("some other marker details")),
+	}
+	s.mockLogger.On("Warn", "Decision task failed", mock.Anything).Once()
+
+	err := s.handler.handleDecisionRecordMarker(attr)
+	s.NoError(err)
+	s.True(s.handler.stopProcessing)
+	s.Equal(eventpb.DecisionTaskFailedCauseBadRecordMarkerAttributes, s.handler.failDecisionInfo.cause)
+	s.Equal("Marker some random marker name/1 was already recorded with different details.", s.handler.failDecisionInfo.message)
+}
+
+func (s *decisionTaskHandlerSuite) TestHandleDecisionRecordMarker_ConsistencyCheckDisabled() {
+	attr := &decisionpb.RecordMarkerDecisionAttributes{
+		MarkerName: "some random marker name",
+		Details:    []byte("some random marker details"),
+		Header:     &commonpb.Header{Fields: map[string][]byte{markerIDHeaderKey: []byte("1")}},
+	}
+	s.executionInfo.MarkerChecksums = map[string][]byte{
+		"some random marker name/1": getMarkerDetailsChecksum([]byte("some other marker details")),
+	}
+	s.mockMutableState.EXPECT().AddRecordMarkerEvent(int64(4), attr).Return(&eventpb.HistoryEvent{}, nil).Times(1)
+
+	err := s.handler.handleDecisionRecordMarker(attr)
+	s.NoError(err)
+	s.False(s.handler.stopProcessing)
+}
+
+func (s *decisionTaskHandlerSuite) TestHandleDecisions_BufferedEventsCountBelowLimit() {
+	s.testHandleDecisionsBufferedEventsCount(s.config.BufferedEventsCountLimitWarn(testNamespace) - 1)
+	s.False(s.handler.stopProcessing)
+	s.Nil(s.handler.failDecisionInfo)
+}
+
+func (s *decisionTaskHandlerSuite) TestHandleDecisions_BufferedEventsCountAtLimit() {
+	s.testHandleDecisionsBufferedEventsCount(s.config.BufferedEventsCountLimitWarn(testNamespace))
+	s.False(s.handler.stopProcessing)
+	s.Nil(s.handler.failDecisionInfo)
+}
+
+func (s *decisionTaskHandlerSuite) TestHandleDecisions_BufferedEventsCountAboveLimit() {
+	s.mockLogger.On("Warn", "Decision task failed", mock.Anything).Once()
+
+	s.testHandleDecisionsBufferedEventsCount(s.config.BufferedEventsCountLimitWarn(testNamespace) + 1)
+	s.True(s.handler.stopProcessing)
+	s.Equal(eventpb.DecisionTaskFailedCauseUnhandledDecision, s.handler.failDecisionInfo.cause)
+}
+
+func (s *decisionTaskHandlerSuite) TestHandleDecisions_BufferedEventsCountAboveErrorLimit() {
+	s.mockLogger.On("Error", "buffered events count exceeds error limit.", mock.Anything).Once()
+	s.mockMutableState.EXPECT().AddWorkflowExecutionTerminatedEvent(
+		int64(4),
+		common.FailureReasonBufferedEventsCountExceedsLimit,
+		gomock.Any(),
+		identityHistoryService,
+	).Return(&eventpb.HistoryEvent{}, nil).Times(1)
+
+	s.testHandleDecisionsBufferedEventsCount(s.config.BufferedEventsCountLimitError(testNamespace) + 1)
+	s.True(s.handler.stopProcessing)
+	s.Nil(s.handler.failDecisionInfo)
+}
+
+func (s *decisionTaskHandlerSuite) TestHandleDecisions_DroppedDecisionsLimitExceeded() {
+	s.mockMutableState.EXPECT().GetNextEventID().Return(int64(5)).AnyTimes()
+	s.mockMutableState.EXPECT().GetBufferedEventsCount().Return(0).Times(1)
+	s.expectMemoSizeExceedsLimit("CancelWorkflowExecutionDecisionAttributes.Details exceeds size limit.")
+	decisions := []*decisionpb.Decision{
+		{
+			DecisionType: decisionpb.DecisionTypeCancelWorkflowExecution,
+			Attributes: &decisionpb.Decision_CancelWorkflowExecutionDecisionAttributes{
+				CancelWorkflowExecutionDecisionAttributes: &decisionpb.CancelWorkflowExecutionDecisionAttributes{
+					Details: make([]byte, 100),
+				},
+			},
+		},
+		{DecisionType: decisionpb.DecisionTypeStartTimer},
+		{DecisionType: decisionpb.DecisionTypeStartTimer},
+	}
+
+	err := s.handler.handleDecisions(nil, decisions)
+	s.NoError(err)
+	s.True(s.handler.stopProcessing)
+	s.Nil(s.handler.failDecisionInfo)
+
+	counters := s.metricsScope.Snapshot().Counters()
+	counter, ok := counters["test.dropped_decisions+operation=RespondDecisionTaskCompleted,stopCause=limit_exceeded"]
+	s.True(ok)
+	s.Equal(int64(2), counter.Value())
+}
+
+func (s *decisionTaskHandlerSuite) TestHandleDecisions_DroppedDecisionsFailDecision() {
+	s.mockLogger.On("Warn", "Decision task failed", mock.Anything).Once()
+	s.mockMutableState.EXPECT().GetNextEventID().Return(int64(5)).AnyTimes()
+	s.mockMutableState.EXPECT().GetBufferedEventsCount().Return(s.config.BufferedEventsCountLimitWarn(testNamespace) + 1).Times(1)
+	decisions := []*decisionpb.Decision{
+		{DecisionType: decisionpb.DecisionTypeStartTimer},
+		{DecisionType: decisionpb.DecisionTypeStartTimer},
+	}
+
+	err := s.handler.handleDecisions(nil, decisions)
+	s.NoError(err)
+	s.True(s.handler.stopProcessing)
+
+	counters := s.metricsScope.Snapshot().Counters()
+	counter, ok := counters["test.dropped_decisions+operation=RespondDecisionTaskCompleted,stopCause=fail_decision"]
+	s.True(ok)
+	s.Equal(int64(2), counter.Value())
+}
+
+func (s *decisionTaskHandlerSuite) TestHandleDecisions_DroppedDecisionsLogged() {
+	s.config.LogDroppedDecisions = dynamicconfig.GetBoolPropertyFnFilteredByNamespace(true)
+	s.mockMutableState.EXPECT().GetNextEventID().Return(int64(5)).AnyTimes()
+	s.mockMutableState.EXPECT().GetBufferedEventsCount().Return(0).Times(1)
+	s.expectMemoSizeExceedsLimit("CancelWorkflowExecutionDecisionAttributes.Details exceeds size limit.")
+	s.mockLogger.On("Info", "Decisions dropped after decision processing stopped", mock.Anything).Run(func(args mock.Arguments) {
+		tags := args.Get(1).([]tag.Tag)
+		s.Contains(tags, tag.WorkflowID(testWorkflowID))
+		s.Contains(tags, tag.WorkflowDecisionIndex(0))
+		s.Contains(tags, tag.Counter(2))
+		s.Contains(tags, tag.WorkflowDecisionTypes([]string{
+			decisionpb.DecisionTypeStartTimer.String(),
+			decisionpb.DecisionTypeRecordMarker.String(),
+		}))
+	}).Once()
+	decisions := []*decisionpb.Decision{
+		{
+			DecisionType: decisionpb.DecisionTypeCancelWorkflowExecution,
+			Attributes: &decisionpb.Decision_CancelWorkflowExecutionDecisionAttributes{
+				CancelWorkflowExecutionDecisionAttributes: &decisionpb.CancelWorkflowExecutionDecisionAttributes{
+					Details: make([]byte, 100),
+				},
+			},
+		},
+		{DecisionType: decisionpb.DecisionTypeStartTimer},
+		{DecisionType: decisionpb.DecisionTypeRecordMarker},
+	}
+
+	err := s.handler.handleDecisions(nil, decisions)
+	s.NoError(err)
+	s.True(s.handler.stopProcessing)
+
+	counters := s.metricsScope.Snapshot().Counters()
+	counter, ok := counters["test.dropped_decisions+operation=RespondDecisionTaskCompleted,stopCause=limit_exceeded"]
+	s.True(ok)
+	s.Equal(int64(2), counter.Value())
+}
+
+func (s *decisionTaskHandlerSuite) TestHandleDecisions_BlobSizeBudgetExceeded() {
+	s.handler.sizeLimitChecker.blobSizeBudget = 100
+	s.mockLogger.On("Warn", "Decision task failed", mock.Anything).Once()
+	s.mockMutableState.EXPECT().GetNextEventID().Return(int64(5)).AnyTimes()
+	s.mockMutableState.EXPECT().GetBufferedEventsCount().Return(0).Times(1)
+	// each marker is well within the blob size limit, the third one brings the total over the budget
+	s.mockMutableState.EXPECT().AddRecordMarkerEvent(int64(4), gomock.Any()).Return(&eventpb.HistoryEvent{}, nil).Times(3)
+	var decisions []*decisionpb.Decision
+	for i := 0; i < 4; i++ {
+		decisions = append(decisions, &decisionpb.Decision{
+			DecisionType: decisionpb.DecisionTypeRecordMarker,
+			Attributes: &decisionpb.Decision_RecordMarkerDecisionAttributes{RecordMarkerDecisionAttributes: &decisionpb.RecordMarkerDecisionAttributes{
+				MarkerName: fmt.Sprintf("marker-%v", i),
+				Details:    make([]byte, 40),
+			}},
+		})
+	}
+
+	err := s.handler.handleDecisions(nil, decisions)
+	s.NoError(err)
+	s.True(s.handler.stopProcessing)
+	s.NotNil(s.handler.failDecisionInfo)
+	s.Equal(eventpb.DecisionTaskFailedCauseBadRecordMarkerAttributes, s.handler.failDecisionInfo.cause)
+
+	counters := s.metricsScope.Snapshot().Counters()
+	counter, ok := counters["test.dropped_decisions+operation=RespondDecisionTaskCompleted,stopCause=fail_decision"]
+	s.True(ok)
+	s.Equal(int64(1), counter.Value())
+}
+
+func (s *decisionTaskHandlerSuite) testHandleDecisionsBufferedEventsCount(bufferedEventsCount int) {
+	s.mockMutableState.EXPECT().GetNextEventID().Return(int64(5)).AnyTimes()
+	s.mockMutableState.EXPECT().GetBufferedEventsCount().Return(bufferedEventsCount).Times(1)
+
+	err := s.handler.handleDecisions(nil, nil)
+	s.NoError(err)
+
+	gauges := s.metricsScope.Snapshot().Gauges()
+	gauge, ok := gauges["test.buffered_events_count+operation=RespondDecisionTaskCompleted"]
+	s.True(ok)
+	s.Equal(float64(bufferedEventsCount), gauge.Value())
+}
+
+func (s *decisionTaskHandlerSuite) TestHandleDecisionScheduleActivity_InvalidPriority() {
+	s.executionInfo.WorkflowTimeout = 100
+	attr := &decisionpb.ScheduleActivityTaskDecisionAttributes{
+		ActivityId:                    "some random activity ID",
+		ActivityType:                  &commonpb.ActivityType{Name: "some random activity type"},
+		TaskList:                      &tasklistpb.TaskList{Name: "some random task list"},
+		ScheduleToCloseTimeoutSeconds: 10,
+		Header:                        &commonpb.Header{Fields: map[string][]byte{activityPriorityHeaderKey: []byte("100")}},
+	}
+	s.mockLogger.On("Warn", "Decision task failed", mock.Anything).Once()
+
+	err := s.handler.handleDecisionScheduleActivity(attr)
+	s.NoError(err)
+	s.True(s.handler.stopProcessing)
+	s.Equal(eventpb.DecisionTaskFailedCauseBadScheduleActivityAttributes, s.handler.failDecisionInfo.cause)
+}
+
+func (s *decisionTaskHandlerSuite) TestHandleDecisionStartTimer_Duration() {
+	s.handler.attrValidator.maxTimerDuration = dynamicconfig.GetDurationPropertyFnFilteredByNamespace(time.Hour)
+
+	for _, timeout := range []int64{0, -1, int64((2 * time.Hour).Seconds())} {
+		s.handler.stopProcessing = false
+		s.handler.failDecisionInfo = nil
+		attr := &decisionpb.StartTimerDecisionAttributes{
+			TimerId:                   "some random timer ID",
+			StartToFireTimeoutSeconds: timeout,
+		}
+		s.mockLogger.On("Warn", "Decision task failed", mock.Anything).Once()
+
+		err := s.handler.handleDecisionStartTimer(attr)
+		s.NoError(err)
+		s.True(s.handler.stopProcessing)
+		s.Equal(eventpb.DecisionTaskFailedCauseBadStartTimerAttributes, s.handler.failDecisionInfo.cause)
+	}
+
+	s.handler.stopProcessing = false
+	s.handler.failDecisionInfo = nil
+	attr := &decisionpb.StartTimerDecisionAttributes{
+		TimerId:                   "some random timer ID",
+		StartToFireTimeoutSeconds: 10,
+	}
+	s.mockMutableState.EXPECT().AddTimerStartedEvent(int64(4), attr).Return(&eventpb.HistoryEvent{}, &persistenceblobs.TimerInfo{}, nil).Times(1)
+
+	err := s.handler.handleDecisionStartTimer(attr)
+	s.NoError(err)
+	s.False(s.handler.stopProcessing)
+}
+
+func (s *decisionTaskHandlerSuite) TestHandleDecisionScheduleActivity_Priority() {
+	s.executionInfo.WorkflowTimeout = 100
+	attr := &decisionpb.ScheduleActivityTaskDecisionAttributes{
+		ActivityId:                    "some random activity ID",
+		ActivityType:                  &commonpb.ActivityType{Name: "some random activity type"},
+		TaskList:                      &tasklistpb.TaskList{Name: "some random task list"},
+		ScheduleToCloseTimeoutSeconds: 10,
+		Header:                        &commonpb.Header{Fields: map[string][]byte{activityPriorityHeaderKey: []byte("1")}},
+	}
+	s.mockMutableState.EXPECT().AddActivityTaskScheduledEvent(int64(4), attr).Return(&eventpb.HistoryEvent{}, &persistence.ActivityInfo{}, nil).Times(1)
+
+	err := s.handler.handleDecisionScheduleActivity(attr)
+	s.NoError(err)
+	s.False(s.handler.stopProcessing)
+}
+
+func (s *decisionTaskHandlerSuite) TestHandleDecisionScheduleActivity_TaskListSelector() {
+	selector := []byte(`{"candidates":["tl-0","tl-1","tl-2"],"strategy":"hash","key":"customer-42"}`)
+	expected := []string{"tl-0", "tl-1", "tl-2"}[farm.Fingerprint32([]byte("customer-42"))%3]
+
+	// the same decision handled again, as on a replay, lands on the same task list
+	for i := 0; i < 2; i++ {
+		attr := s.newScheduleActivityAttributes()
+		attr.Header = &commonpb.Header{Fields: map[string][]byte{activityTaskListSelectorHeaderKey: selector}}
+		s.mockMutableState.EXPECT().AddActivityTaskScheduledEvent(int64(4), attr).Return(&eventpb.HistoryEvent{}, &persistence.ActivityInfo{}, nil).Times(1)
+
+		err := s.handler.handleDecisionScheduleActivity(attr)
+		s.NoError(err)
+		s.False(s.handler.stopProcessing)
+		s.Equal(expected, attr.TaskList.GetName())
+	}
+}
+
+func (s *decisionTaskHandlerSuite) TestHandleDecisionScheduleActivity_TaskListSelectorNoCandidates() {
+	attr := s.newScheduleActivityAttributes()
+	attr.Header = &commonpb.Header{Fields: map[string][]byte{activityTaskListSelectorHeaderKey: []byte(`{"candidates":[],"key":"customer-42"}`)}}
+	s.mockLogger.On("Warn", "Decision task failed", mock.Anything).Once()
+
+	err := s.handler.handleDecisionScheduleActivity(attr)
+	s.NoError(err)
+	s.True(s.handler.stopProcessing)
+	s.Equal(eventpb.DecisionTaskFailedCauseBadScheduleActivityAttributes, s.handler.failDecisionInfo.cause)
+}
+
+func (s *decisionTaskHandlerSuite) TestHandleDecisionScheduleActivity_UnknownTaskList() {
+	s.config.ValidateActivityTaskList = dynamicconfig.GetBoolPropertyFnFilteredByNamespace(true)
+	attr := s.newScheduleActivityAttributes()
+	s.mockMatchingClient.EXPECT().DescribeTaskList(gomock.Any(), gomock.Any()).Return(&matchingservice.DescribeTaskListResponse{}, nil).Times(1)
+	s.mockLogger.On("Warn", "Activity scheduled on task list without activity pollers", mock.Anything).Once()
+	s.mockMutableState.EXPECT().AddActivityTaskScheduledEvent(int64(4), attr).Return(&eventpb.HistoryEvent{}, &persistence.ActivityInfo{}, nil).Times(1)
+
+	err := s.handler.handleDecisionScheduleActivity(attr)
+	s.NoError(err)
+	s.False(s.handler.stopProcessing)
+	s.assertUnknownActivityTaskListCounter(true)
+}
+
+func (s *decisionTaskHandlerSuite) TestHandleDecisionScheduleActivity_UnknownTaskListFail() {
+	s.config.ValidateActivityTaskList = dynamicconfig.GetBoolPropertyFnFilteredByNamespace(true)
+	s.config.FailUnknownActivityTaskList = dynamicconfig.GetBoolPropertyFnFilteredByNamespace(true)
+	attr := s.newScheduleActivityAttributes()
+	s.mockMatchingClient.EXPECT().DescribeTaskList(gomock.Any(), gomock.Any()).Return(&matchingservice.DescribeTaskListResponse{}, nil).Times(1)
+	s.mockLogger.On("Warn", "Activity scheduled on task list without activity pollers", mock.Anything).Once()
+	s.mockLogger.On("Warn", "Decision task failed", mock.Anything).Once()
+
+	err := s.handler.handleDecisionScheduleActivity(attr)
+	s.NoError(err)
+	s.True(s.handler.stopProcessing)
+	s.Equal(eventpb.DecisionTaskFailedCauseBadScheduleActivityAttributes, s.handler.failDecisionInfo.cause)
+	s.assertUnknownActivityTaskListCounter(true)
+}
+
+func (s *decisionTaskHandlerSuite) TestHandleDecisionScheduleActivity_KnownTaskList() {
+	s.config.ValidateActivityTaskList = dynamicconfig.GetBoolPropertyFnFilteredByNamespace(true)
+	attr := s.newScheduleActivityAttributes()
+	s.mockMatchingClient.EXPECT().DescribeTaskList(gomock.Any(), &matchingservice.DescribeTaskListRequest{
+		NamespaceId: testNamespaceID,
+		DescRequest: &workflowservice.DescribeTaskListRequest{
+			TaskList:     attr.TaskList,
+			TaskListType: tasklistpb.TaskListTypeActivity,
+		},
+	}).Return(&matchingservice.DescribeTaskListResponse{
+		Pollers: []*tasklistpb.PollerInfo{{Identity: "some random identity"}},
+	}, nil).Times(1)
+	s.mockMutableState.EXPECT().AddActivityTaskScheduledEvent(int64(4), attr).Return(&eventpb.HistoryEvent{}, &persistence.ActivityInfo{}, nil).Times(1)
+
+	err := s.handler.handleDecisionScheduleActivity(attr)
+	s.NoError(err)
+	s.False(s.handler.stopProcessing)
+	s.assertUnknownActivityTaskListCounter(false)
+}
+
+func (s *decisionTaskHandlerSuite) TestHandleDecisionScheduleActivity_TaskListLookupCached() {
+	s.config.ValidateActivityTaskList = dynamicconfig.GetBoolPropertyFnFilteredByNamespace(true)
+	attr := s.newScheduleActivityAttributes()
+	s.mockMatchingClient.EXPECT().DescribeTaskList(gomock.Any(), gomock.Any()).Return(&matchingservice.DescribeTaskListResponse{
+		Pollers: []*tasklistpb.PollerInfo{{Identity: "some random identity"}},
+	}, nil).Times(1)
+	s.mockMutableState.EXPECT().AddActivityTaskScheduledEvent(int64(4), attr).Return(&eventpb.HistoryEvent{}, &persistence.ActivityInfo{}, nil).Times(3)
+
+	s.NoError(s.handler.handleDecisionScheduleActivity(attr))
+	s.NoError(s.handler.handleDecisionScheduleActivity(attr))
+	s.False(s.handler.stopProcessing)
+
+	// the handler of a later decision task reuses the lookup
+	nextHandler := s.newDecisionTaskHandler()
+	nextHandler.activityTaskListPollers = s.handler.activityTaskListPollers
+	s.NoError(nextHandler.handleDecisionScheduleActivity(attr))
+	s.False(nextHandler.stopProcessing)
+}
+
+func (s *decisionTaskHandlerSuite) TestHandleDecisionScheduleActivity_RegisteredTaskListMatches() {
+	s.config.ActivityTaskListsByType = dynamicconfig.GetMapPropertyFnFilteredByNamespace(map[string]interface{}{
+		"some random activity type": "some random task list",
+	})
+	attr := s.newScheduleActivityAttributes()
+	s.mockMutableState.EXPECT().AddActivityTaskScheduledEvent(int64(4), attr).Return(&eventpb.HistoryEvent{}, &persistence.ActivityInfo{}, nil).Times(1)
+
+	err := s.handler.handleDecisionScheduleActivity(attr)
+	s.NoError(err)
+	s.False(s.handler.stopProcessing)
+	s.Nil(s.handler.failDecisionInfo)
+}
+
+func (s *decisionTaskHandlerSuite) TestHandleDecisionScheduleActivity_RegisteredTaskListMismatch() {
+	s.config.ActivityTaskListsByType = dynamicconfig.GetMapPropertyFnFilteredByNamespace(map[string]interface{}{
+		"some random activity type": "some other task list",
+	})
+	attr := s.newScheduleActivityAttributes()
+	s.mockLogger.On("Warn", "Decision task failed", mock.Anything).Once()
+
+	err := s.handler.handleDecisionScheduleActivity(attr)
+	s.NoError(err)
+	s.True(s.handler.stopProcessing)
+	s.Equal(eventpb.DecisionTaskFailedCauseBadScheduleActivityAttributes, s.handler.failDecisionInfo.cause)
+	s.Contains(s.handler.failDecisionInfo.message, "some other task list")
+	s.Contains(s.handler.failDecisionInfo.message, "some random task list")
+}
+
+func (s *decisionTaskHandlerSuite) TestHandleDecisionScheduleActivity_UnregisteredActivityType() {
+	s.config.ActivityTaskListsByType = dynamicconfig.GetMapPropertyFnFilteredByNamespace(map[string]interface{}{
+		"some other activity type": "some other task list",
+	})
+	attr := s.newScheduleActivityAttributes()
+	s.mockMutableState.EXPECT().AddActivityTaskScheduledEvent(int64(4), attr).Return(&eventpb.HistoryEvent{}, &persistence.ActivityInfo{}, nil).Times(1)
+
+	err := s.handler.handleDecisionScheduleActivity(attr)
+	s.NoError(err)
+	s.False(s.handler.stopProcessing)
+	s.Nil(s.handler.failDecisionInfo)
+}
+
+func (s *decisionTaskHandlerSuite) TestHandleDecisionScheduleActivity_BelowMaxPendingActivities() {
+	s.config.MaxPendingActivitiesPerWorkflow = dynamicconfig.GetIntPropertyFilteredByNamespace(2)
+	attr := s.newScheduleActivityAttributes()
+	s.mockMutableState.EXPECT().GetPendingActivityInfos().Return(map[int64]*persistence.ActivityInfo{
+		5: {ScheduleID: 5},
+	}).Times(1)
+	s.mockMutableState.EXPECT().AddActivityTaskScheduledEvent(int64(4), attr).Return(&eventpb.HistoryEvent{}, &persistence.ActivityInfo{}, nil).Times(1)
+
+	err := s.handler.handleDecisionScheduleActivity(attr)
+	s.NoError(err)
+	s.False(s.handler.stopProcessing)
+	s.Nil(s.handler.failDecisionInfo)
+	s.assertPendingActivitiesLimitExceededCounter(false)
+}
+
+func (s *decisionTaskHandlerSuite) TestHandleDecisionScheduleActivity_AtMaxPendingActivities() {
+	s.config.MaxPendingActivitiesPerWorkflow = dynamicconfig.GetIntPropertyFilteredByNamespace(2)
+	attr := s.newScheduleActivityAttributes()
+	s.mockMutableState.EXPECT().GetPendingActivityInfos().Return(map[int64]*persistence.ActivityInfo{
+		5: {ScheduleID: 5},
+		6: {ScheduleID: 6},
+	}).Times(1)
+	s.mockLogger.On("Warn", "Decision task failed", mock.Anything).Once()
+
+	err := s.handler.handleDecisionScheduleActivity(attr)
+	s.NoError(err)
+	s.True(s.handler.stopProcessing)
+	s.Equal(eventpb.DecisionTaskFailedCauseBadScheduleActivityAttributes, s.handler.failDecisionInfo.cause)
+	s.Equal("Workflow already has 2 pending activities, which is the maximum allowed.", s.handler.failDecisionInfo.message)
+	s.assertPendingActivitiesLimitExceededCounter(true)
+}
+
+func (s *decisionTaskHandlerSuite) TestHandleDecisionScheduleActivity_NoMaxPendingActivities() {
+	attr := s.newScheduleActivityAttributes()
+	s.mockMutableState.EXPECT().AddActivityTaskScheduledEvent(int64(4), attr).Return(&eventpb.HistoryEvent{}, &persistence.ActivityInfo{}, nil).Times(1)
+
+	err := s.handler.handleDecisionScheduleActivity(attr)
+	s.NoError(err)
+	s.False(s.handler.stopProcessing)
+	s.assertPendingActivitiesLimitExceededCounter(false)
+}
+
+func (s *decisionTaskHandlerSuite) assertPendingActivitiesLimitExceededCounter(expected bool) {
+	counters := s.metricsScope.Snapshot().Counters()
+	_, ok := counters["test.pending_activities_limit_exceeded+operation=RespondDecisionTaskCompleted"]
+	s.Equal(expected, ok)
+}
+
+func (s *decisionTaskHandlerSuite) TestHandleDecisionScheduleActivity_NamespaceTags() {
+	s.config.EnableNamespaceTagPropagation = dynamicconfig.GetBoolPropertyFnFilteredByNamespace(true)
+	s.setNamespaceData(map[string]string{
+		"tag.team":        "payments",
+		"tag.cost-center": "42",
+		"some random key": "some random value",
+	})
+	attr := s.newScheduleActivityAttributes()
+	attr.Header = &commonpb.Header{Fields: map[string][]byte{
+		"some random header": []byte("some random value"),
+		"NamespaceTag.team":  []byte("spoofed"),
+	}}
+	s.mockMutableState.EXPECT().AddActivityTaskScheduledEvent(int64(4), attr).Return(&eventpb.HistoryEvent{}, &persistence.ActivityInfo{}, nil).Times(1)
+
+	err := s.handler.handleDecisionScheduleActivity(attr)
+	s.NoError(err)
+	s.False(s.handler.stopProcessing)
+	s.Equal(map[string][]byte{
+		"some random header":       []byte("some random value"),
+		"NamespaceTag.team":        []byte("payments"),
+		"NamespaceTag.cost-center": []byte("42"),
+	}, attr.Header.Fields)
+}
+
+func (s *decisionTaskHandlerSuite) TestHandleDecisionScheduleActivity_NamespaceTagsSizeExceedsLimit() {
+	s.config.EnableNamespaceTagPropagation = dynamicconfig.GetBoolPropertyFnFilteredByNamespace(true)
+	s.setNamespaceData(map[string]string{"tag.team": "payments"})
+	s.handler.sizeLimitChecker.blobSizeLimitError = 20
+	attr := s.newScheduleActivityAttributes()
+	s.mockLogger.On("Warn", "Decision task failed", mock.Anything).Once()
+
+	err := s.handler.handleDecisionScheduleActivity(attr)
+	s.NoError(err)
+	s.True(s.handler.stopProcessing)
+	s.Equal(eventpb.DecisionTaskFailedCauseBadScheduleActivityAttributes, s.handler.failDecisionInfo.cause)
+	s.Nil(attr.Header)
+}
+
+func (s *decisionTaskHandlerSuite) TestHandleDecisionScheduleActivity_NamespaceTagsDisabled() {
+	s.setNamespaceData(map[string]string{"tag.team": "payments"})
+	attr := s.newScheduleActivityAttributes()
+	s.mockMutableState.EXPECT().AddActivityTaskScheduledEvent(int64(4), attr).Return(&eventpb.HistoryEvent{}, &persistence.ActivityInfo{}, nil).Times(1)
+
+	err := s.handler.handleDecisionScheduleActivity(attr)
+	s.NoError(err)
+	s.False(s.handler.stopProcessing)
+	s.Nil(attr.Header)
+}
+
+func (s *decisionTaskHandlerSuite) setNamespaceData(data map[string]string) {
+	s.handler.namespaceEntry = cache.NewLocalNamespaceCacheEntryForTest(
+		&persistence.NamespaceInfo{ID: testNamespaceID, Name: testNamespace, Data: data},
+		&persistence.NamespaceConfig{Retention: 1},
+		cluster.TestCurrentClusterName,
+		nil,
+	)
+}
+
+func (s *decisionTaskHandlerSuite) newScheduleActivityAttributes() *decisionpb.ScheduleActivityTaskDecisionAttributes {
+	s.executionInfo.WorkflowTimeout = 100
+	return &decisionpb.ScheduleActivityTaskDecisionAttributes{
+		ActivityId:                    "some random activity ID",
+		ActivityType:                  &commonpb.ActivityType{Name: "some random activity type"},
+		TaskList:                      &tasklistpb.TaskList{Name: "some random task list"},
+		ScheduleToCloseTimeoutSeconds: 10,
+	}
+}
+
+func (s *decisionTaskHandlerSuite) assertUnknownActivityTaskListCounter(expected bool) {
+	counters := s.metricsScope.Snapshot().Counters()
+	_, ok := counters["test.unknown_activity_task_list+operation=RespondDecisionTaskCompleted"]
+	s.Equal(expected, ok)
+}
+
+func (s *decisionTaskHandlerSuite) TestHandleDecisionScheduleActivity_DuplicateIDInBatch() {
+	s.testHandleDecisionScheduleActivityDuplicateID(int64(5), "batch")
+}
+
+func (s *decisionTaskHandlerSuite) TestHandleDecisionScheduleActivity_DuplicateIDReplay() {
+	s.testHandleDecisionScheduleActivityDuplicateID(int64(2), "replay")
+}
+
+func (s *decisionTaskHandlerSuite) testHandleDecisionScheduleActivityDuplicateID(scheduleID int64, duplicateType string) {
+	s.executionInfo.WorkflowTimeout = 100
+	attr := &decisionpb.ScheduleActivityTaskDecisionAttributes{
+		ActivityId:                    "some random activity ID",
+		ActivityType:                  &commonpb.ActivityType{Name: "some random activity type"},
+		TaskList:                      &tasklistpb.TaskList{Name: "some random task list"},
+		ScheduleToCloseTimeoutSeconds: 10,
+	}
+	s.mockMutableState.EXPECT().AddActivityTaskScheduledEvent(int64(4), attr).Return(nil, nil, serviceerror.NewInvalidArgument("some random error")).Times(1)
+	s.mockMutableState.EXPECT().GetActivityByActivityID(attr.ActivityId).Return(&persistence.ActivityInfo{ScheduleID: scheduleID}, true).Times(1)
+	s.mockLogger.On("Warn", "Decision task failed", mock.Anything).Once()
+
+	err := s.handler.handleDecisionScheduleActivity(attr)
+	s.NoError(err)
+	s.True(s.handler.stopProcessing)
+	s.Equal(eventpb.DecisionTaskFailedCauseScheduleActivityDuplicateId, s.handler.failDecisionInfo.cause)
+	s.Equal(fmt.Sprintf("ActivityId some random activity ID is already scheduled by event %v.", scheduleID), s.handler.failDecisionInfo.message)
+
+	counters := s.metricsScope.Snapshot().Counters()
+	counter, ok := counters["test.schedule_activity_duplicate_id+duplicateType="+duplicateType+",operation=RespondDecisionTaskCompleted"]
+	s.True(ok)
+	s.Equal(int64(1), counter.Value())
+}
+
+func (s *decisionTaskHandlerSuite) TestHandleDecision_PanicFailsDecision() {
+	attr := &decisionpb.StartTimerDecisionAttributes{
+		TimerId:                   "some random timer ID",
+		StartToFireTimeoutSeconds: 10,
+	}
+	s.mockMutableState.EXPECT().AddTimerStartedEvent(int64(4), attr).DoAndReturn(
+		func(_ int64, _ *decisionpb.StartTimerDecisionAttributes) (*eventpb.HistoryEvent, *persistenceblobs.TimerInfo, error) {
+			panic("some random panic")
+		},
+	).Times(1)
+	s.mockLogger.On("Error", "Panic is captured while handling decision", mock.Anything).Once()
+	s.mockLogger.On("Warn", "Decision task failed", mock.Anything).Once()
+
+	err := s.handler.handleDecision(&decisionpb.Decision{
+		DecisionType: decisionpb.DecisionTypeStartTimer,
+		Attributes:   &decisionpb.Decision_StartTimerDecisionAttributes{StartTimerDecisionAttributes: attr},
+	})
+	s.NoError(err)
+	s.True(s.handler.stopProcessing)
+	s.Equal(eventpb.DecisionTaskFailedCauseUnhandledDecision, s.handler.failDecisionInfo.cause)
+	s.Equal(fmt.Sprintf("Panic while handling decision %v: some random panic.", decisionpb.DecisionTypeStartTimer), s.handler.failDecisionInfo.message)
+
+	counter, ok := s.metricsScope.Snapshot().Counters()["test.decision_panic+operation=RespondDecisionTaskCompleted"]
+	s.True(ok)
+	s.Equal(int64(1), counter.Value())
+}
+
+func (s *decisionTaskHandlerSuite) TestHandleDecisionContinueAsNewWorkflow_MemoSizeExceedsLimit() {
+	s.executionInfo.WorkflowTypeName = "some random workflow type"
+	s.executionInfo.TaskList = "some random task list"
+	mockNamespaceCache := cache.NewMockNamespaceCache(s.controller)
+	mockNamespaceCache.EXPECT().GetNamespaceByID(testNamespaceID).Return(testLocalNamespaceEntry, nil).AnyTimes()
+	s.handler.attrValidator = newDecisionAttrValidator(mockNamespaceCache, s.config, s.mockLogger)
+	attr := &decisionpb.ContinueAsNewWorkflowExecutionDecisionAttributes{
+		Memo: &commonpb.Memo{Fields: map[string][]byte{"some random memo key": make([]byte, 100)}},
+	}
+	message := "ContinueAsNewWorkflowExecutionDecisionAttributes.Memo exceeds size limit."
+	s.expectMemoSizeExceedsLimit(message)
+
+	err := s.handler.handleDecisionContinueAsNewWorkflow(attr)
+	s.NoError(err)
+	s.True(s.handler.stopProcessing)
+	s.Nil(s.handler.continueAsNewBuilder)
+	s.assertMemoSizeExceededCounter()
+}
+
+func (s *decisionTaskHandlerSuite) TestHandleDecisionContinueAsNewWorkflow_InputExceedsNewRunLimit() {
+	s.executionInfo.WorkflowTypeName = "some random workflow type"
+	s.executionInfo.TaskList = "some random task list"
+	mockNamespaceCache := cache.NewMockNamespaceCache(s.controller)
+	mockNamespaceCache.EXPECT().GetNamespaceByID(testNamespaceID).Return(testLocalNamespaceEntry, nil).AnyTimes()
+	// the limit was lowered after the decision task completed, the input is within the limit of
+	// the current decision but not within the limit of the new run
+	s.config.BlobSizeLimitError = dynamicconfig.GetIntPropertyFilteredByNamespace(10)
+	s.handler.attrValidator = newDecisionAttrValidator(mockNamespaceCache, s.config, s.mockLogger)
+	attr := &decisionpb.ContinueAsNewWorkflowExecutionDecisionAttributes{
+		Input: make([]byte, 100),
+	}
+	s.mockLogger.On("Warn", "Decision task failed", mock.Anything).Once()
+
+	err := s.handler.handleDecisionContinueAsNewWorkflow(attr)
+	s.NoError(err)
+	s.True(s.handler.stopProcessing)
+	s.Nil(s.handler.continueAsNewBuilder)
+	s.Equal(eventpb.DecisionTaskFailedCauseBadContinueAsNewAttributes, s.handler.failDecisionInfo.cause)
+	s.Equal("ContinueAsNewWorkflowExecutionDecisionAttributes.Input is 100 bytes, which exceeds the new run limit of 10 bytes.", s.handler.failDecisionInfo.message)
+}
+
+func (s *decisionTaskHandlerSuite) TestHandleDecisionStartChildWorkflow_MemoSizeExceedsLimit() {
+	attr := &decisionpb.StartChildWorkflowExecutionDecisionAttributes{
+		WorkflowId:   "some random child workflow ID",
+		WorkflowType: &commonpb.WorkflowType{Name: "some random workflow type"},
+		TaskList:     &tasklistpb.TaskList{Name: "some random task list"},
+		Memo:         &commonpb.Memo{Fields: map[string][]byte{"some random memo key": make([]byte, 100)}},
+	}
+	message := "StartChildWorkflowExecutionDecisionAttributes.Memo exceeds size limit."
+	s.expectMemoSizeExceedsLimit(message)
+
+	err := s.handler.handleDecisionStartChildWorkflow(attr)
+	s.NoError(err)
+	s.True(s.handler.stopProcessing)
+	s.assertMemoSizeExceededCounter()
+}
+
+func (s *decisionTaskHandlerSuite) TestHandleDecisionStartChildWorkflow_ExplicitRetryPolicy() {
+	s.config.InheritChildRetryPolicy = dynamicconfig.GetBoolPropertyFnFilteredByNamespace(true)
+	s.setParentRetryPolicy()
+	retryPolicy := &commonpb.RetryPolicy{
+		InitialIntervalInSeconds: 2,
+		BackoffCoefficient:       1.5,
+		MaximumAttempts:          3,
+	}
+	attr := s.newStartChildWorkflowAttributes()
+	attr.RetryPolicy = retryPolicy
+	s.mockMutableState.EXPECT().AddStartChildWorkflowExecutionInitiatedEvent(int64(4), gomock.Any(), attr).Return(&eventpb.HistoryEvent{}, &persistence.ChildExecutionInfo{}, nil).Times(1)
+
+	err := s.handler.handleDecisionStartChildWorkflow(attr)
+	s.NoError(err)
+	s.False(s.handler.stopProcessing)
+	s.Equal(retryPolicy, attr.RetryPolicy)
+}
+
+func (s *decisionTaskHandlerSuite) TestHandleDecisionStartChildWorkflow_InheritRetryPolicy() {
+	s.config.InheritChildRetryPolicy = dynamicconfig.GetBoolPropertyFnFilteredByNamespace(true)
+	s.setParentRetryPolicy()
+	attr := s.newStartChildWorkflowAttributes()
+	s.mockMutableState.EXPECT().AddStartChildWorkflowExecutionInitiatedEvent(int64(4), gomock.Any(), attr).Return(&eventpb.HistoryEvent{}, &persistence.ChildExecutionInfo{}, nil).Times(1)
+
+	err := s.handler.handleDecisionStartChildWorkflow(attr)
+	s.NoError(err)
+	s.False(s.handler.stopProcessing)
+	s.Equal(&commonpb.RetryPolicy{
+		InitialIntervalInSeconds:    1,
+		BackoffCoefficient:          2,
+		MaximumIntervalInSeconds:    10,
+		MaximumAttempts:             5,
+		NonRetriableErrorReasons:    []string{"some random error reason"},
+		ExpirationIntervalInSeconds: 100,
+	}, attr.RetryPolicy)
+}
+
+func (s *decisionTaskHandlerSuite) TestHandleDecisionStartChildWorkflow_InheritInvalidRetryPolicy() {
+	s.config.InheritChildRetryPolicy = dynamicconfig.GetBoolPropertyFnFilteredByNamespace(true)
+	s.setParentRetryPolicy()
+	s.executionInfo.BackoffCoefficient = 0.5
+	attr := s.newStartChildWorkflowAttributes()
+	s.mockLogger.On("Warn", "Decision task failed", mock.Anything).Once()
+
+	err := s.handler.handleDecisionStartChildWorkflow(attr)
+	s.NoError(err)
+	s.True(s.handler.stopProcessing)
+	s.Equal(eventpb.DecisionTaskFailedCauseBadStartChildExecutionAttributes, s.handler.failDecisionInfo.cause)
+}
+
+func (s *decisionTaskHandlerSuite) TestHandleDecisionStartChildWorkflow_InheritRetryPolicyDisabled() {
+	s.setParentRetryPolicy()
+	attr := s.newStartChildWorkflowAttributes()
+	s.mockMutableState.EXPECT().AddStartChildWorkflowExecutionInitiatedEvent(int64(4), gomock.Any(), attr).Return(&eventpb.HistoryEvent{}, &persistence.ChildExecutionInfo{}, nil).Times(1)
+
+	err := s.handler.handleDecisionStartChildWorkflow(attr)
+	s.NoError(err)
+	s.False(s.handler.stopProcessing)
+	s.Nil(attr.RetryPolicy)
+}
+
+func (s *decisionTaskHandlerSuite) TestHandleDecisionStartChildWorkflow_DefaultParentClosePolicy() {
+	for value, policy := range map[string]commonpb.ParentClosePolicy{
+		"Abandon":       commonpb.ParentClosePolicyAbandon,
+		"Terminate":     commonpb.ParentClosePolicyTerminate,
+		"RequestCancel": commonpb.ParentClosePolicyRequestCancel,
+	} {
+		s.config.DefaultParentClosePolicy = dynamicconfig.GetStringPropertyFnFilteredByNamespace(value)
+		handler := s.newDecisionTaskHandler()
+		attr := s.newStartChildWorkflowAttributes()
+		attr.Header = s.newParentClosePolicyUnsetHeader()
+		s.mockMutableState.EXPECT().AddStartChildWorkflowExecutionInitiatedEvent(int64(4), gomock.Any(), attr).Return(&eventpb.HistoryEvent{}, &persistence.ChildExecutionInfo{}, nil).Times(1)
+
+		err := handler.handleDecisionStartChildWorkflow(attr)
+		s.NoError(err)
+		s.False(handler.stopProcessing)
+		s.Equal(policy, attr.ParentClosePolicy, value)
+	}
+}
+
+func (s *decisionTaskHandlerSuite) TestHandleDecisionStartChildWorkflow_ExplicitAbandonParentClosePolicy() {
+	for _, value := range []string{"Terminate", "RequestCancel"} {
+		s.config.DefaultParentClosePolicy = dynamicconfig.GetStringPropertyFnFilteredByNamespace(value)
+		handler := s.newDecisionTaskHandler()
+		// without the unset marker abandon is an explicit choice, which the default must not override
+		attr := s.newStartChildWorkflowAttributes()
+		attr.ParentClosePolicy = commonpb.ParentClosePolicyAbandon
+		s.mockMutableState.EXPECT().AddStartChildWorkflowExecutionInitiatedEvent(int64(4), gomock.Any(), attr).Return(&eventpb.HistoryEvent{}, &persistence.ChildExecutionInfo{}, nil).Times(1)
+
+		err := handler.handleDecisionStartChildWorkflow(attr)
+		s.NoError(err)
+		s.False(handler.stopProcessing)
+		s.Equal(commonpb.ParentClosePolicyAbandon, attr.ParentClosePolicy, value)
+	}
+}
+
+func (s *decisionTaskHandlerSuite) TestHandleDecisionStartChildWorkflow_ExplicitParentClosePolicy() {
+	s.config.DefaultParentClosePolicy = dynamicconfig.GetStringPropertyFnFilteredByNamespace("Terminate")
+	attr := s.newStartChildWorkflowAttributes()
+	attr.Header = s.newParentClosePolicyUnsetHeader()
+	attr.ParentClosePolicy = commonpb.ParentClosePolicyRequestCancel
+	s.mockMutableState.EXPECT().AddStartChildWorkflowExecutionInitiatedEvent(int64(4), gomock.Any(), attr).Return(&eventpb.HistoryEvent{}, &persistence.ChildExecutionInfo{}, nil).Times(1)
+
+	err := s.handler.handleDecisionStartChildWorkflow(attr)
+	s.NoError(err)
+	s.False(s.handler.stopProcessing)
+	s.Equal(commonpb.ParentClosePolicyRequestCancel, attr.ParentClosePolicy)
+}
+
+func (s *decisionTaskHandlerSuite) TestHandleDecisionStartChildWorkflow_InvalidDefaultParentClosePolicy() {
+	s.config.DefaultParentClosePolicy = dynamicconfig.GetStringPropertyFnFilteredByNamespace("some random policy")
+	attr := s.newStartChildWorkflowAttributes()
+	attr.Header = s.newParentClosePolicyUnsetHeader()
+	s.mockLogger.On("Error", "Invalid default parent close policy.", mock.Anything).Once()
+	s.mockMutableState.EXPECT().AddStartChildWorkflowExecutionInitiatedEvent(int64(4), gomock.Any(), attr).Return(&eventpb.HistoryEvent{}, &persistence.ChildExecutionInfo{}, nil).Times(1)
+
+	err := s.handler.handleDecisionStartChildWorkflow(attr)
+	s.NoError(err)
+	s.False(s.handler.stopProcessing)
+	s.Equal(commonpb.ParentClosePolicyAbandon, attr.ParentClosePolicy)
+}
+
+func (s *decisionTaskHandlerSuite) TestHandleDecisionStartChildWorkflow_DefaultParentClosePolicyDisabled() {
+	s.config.EnableParentClosePolicy = dynamicconfig.GetBoolPropertyFnFilteredByNamespace(false)
+	s.config.DefaultParentClosePolicy = dynamicconfig.GetStringPropertyFnFilteredByNamespace("Terminate")
+	attr := s.newStartChildWorkflowAttributes()
+	attr.Header = s.newParentClosePolicyUnsetHeader()
+	attr.ParentClosePolicy = commonpb.ParentClosePolicyRequestCancel
+	s.mockMutableState.EXPECT().AddStartChildWorkflowExecutionInitiatedEvent(int64(4), gomock.Any(), attr).Return(&eventpb.HistoryEvent{}, &persistence.ChildExecutionInfo{}, nil).Times(1)
+
+	err := s.handler.handleDecisionStartChildWorkflow(attr)
+	s.NoError(err)
+	s.False(s.handler.stopProcessing)
+	s.Equal(commonpb.ParentClosePolicyAbandon, attr.ParentClosePolicy)
+}
+
+func (s *decisionTaskHandlerSuite) TestHandleDecisionStartChildWorkflow_TraceContext() {
+	traceParent := []byte("00-0af7651916cd43dd8448eb211c80319c-b7ad6b7169203331-01")
+	attr := s.newStartChildWorkflowAttributes()
+	attr.Header = &commonpb.Header{Fields: map[string][]byte{
+		"Traceparent":       traceParent,
+		traceStateHeaderKey: []byte("congo=t61rcWkgMzE"),
+		"some random key":   []byte("some random value"),
+	}}
+	s.mockMutableState.EXPECT().AddStartChildWorkflowExecutionInitiatedEvent(int64(4), gomock.Any(), attr).Return(&eventpb.HistoryEvent{}, &persistence.ChildExecutionInfo{}, nil).Times(1)
+
+	err := s.handler.handleDecisionStartChildWorkflow(attr)
+	s.NoError(err)
+	s.False(s.handler.stopProcessing)
+	s.Equal(map[string][]byte{
+		traceParentHeaderKey: traceParent,
+		traceStateHeaderKey:  []byte("congo=t61rcWkgMzE"),
+		"some random key":    []byte("some random value"),
+	}, attr.Header.Fields)
+	s.assertChildWorkflowTraceContextCounter(true)
+}
+
+func (s *decisionTaskHandlerSuite) TestHandleDecisionStartChildWorkflow_NoTraceContext() {
+	attr := s.newStartChildWorkflowAttributes()
+	s.mockMutableState.EXPECT().AddStartChildWorkflowExecutionInitiatedEvent(int64(4), gomock.Any(), attr).Return(&eventpb.HistoryEvent{}, &persistence.ChildExecutionInfo{}, nil).Times(1)
+
+	err := s.handler.handleDecisionStartChildWorkflow(attr)
+	s.NoError(err)
+	s.False(s.handler.stopProcessing)
+	s.Nil(attr.Header)
+	s.assertChildWorkflowTraceContextCounter(false)
+}
+
+func (s *decisionTaskHandlerSuite) TestHandleDecisionStartChildWorkflow_InvalidTraceContext() {
+	attr := s.newStartChildWorkflowAttributes()
+	attr.Header = &commonpb.Header{Fields: map[string][]byte{
+		traceParentHeaderKey: []byte("some random trace parent"),
+	}}
+	s.mockLogger.On("Warn", "Decision task failed", mock.Anything).Once()
+
+	err := s.handler.handleDecisionStartChildWorkflow(attr)
+	s.NoError(err)
+	s.True(s.handler.stopProcessing)
+	s.Equal(eventpb.DecisionTaskFailedCauseBadStartChildExecutionAttributes, s.handler.failDecisionInfo.cause)
+}
+
+func (s *decisionTaskHandlerSuite) TestHandleDecisions_DuplicateChildWorkflowIDInBatch() {
+	s.mockMutableState.EXPECT().GetNextEventID().Return(int64(5)).AnyTimes()
+	s.mockMutableState.EXPECT().GetBufferedEventsCount().Return(0).Times(1)
+	newDecision := func() *decisionpb.Decision {
+		return &decisionpb.Decision{
+			DecisionType: decisionpb.DecisionTypeStartChildWorkflowExecution,
+			Attributes: &decisionpb.Decision_StartChildWorkflowExecutionDecisionAttributes{
+				StartChildWorkflowExecutionDecisionAttributes: s.newStartChildWorkflowAttributes(),
+			},
+		}
+	}
+	decisions := []*decisionpb.Decision{newDecision(), newDecision()}
+	s.mockMutableState.EXPECT().AddStartChildWorkflowExecutionInitiatedEvent(
+		int64(4), gomock.Any(), decisions[0].GetStartChildWorkflowExecutionDecisionAttributes(),
+	).Return(&eventpb.HistoryEvent{}, &persistence.ChildExecutionInfo{}, nil).Times(1)
+	s.mockLogger.On("Warn", "Decision task failed", mock.Anything).Once()
+
+	err := s.handler.handleDecisions(nil, decisions)
+	s.NoError(err)
+	s.True(s.handler.stopProcessing)
+	s.Equal(eventpb.DecisionTaskFailedCauseBadStartChildExecutionAttributes, s.handler.failDecisionInfo.cause)
+	s.Equal("Child workflow some random child workflow ID is already started by another decision of the batch.", s.handler.failDecisionInfo.message)
+}
+
+func (s *decisionTaskHandlerSuite) assertChildWorkflowTraceContextCounter(withTraceContext bool) {
+	counters := s.metricsScope.Snapshot().Counters()
+	_, with := counters["test.child_workflow_with_trace_context+operation=RespondDecisionTaskCompleted"]
+	_, without := counters["test.child_workflow_without_trace_context+operation=RespondDecisionTaskCompleted"]
+	s.Equal(withTraceContext, with)
+	s.Equal(!withTraceContext, without)
+}
+
+func (s *decisionTaskHandlerSuite) TestHandleDecisionSignalExternalWorkflow_ExplicitRun() {
+	s.testHandleDecisionSignalExternalWorkflow(testRunID)
+}
+
+func (s *decisionTaskHandlerSuite) TestHandleDecisionSignalExternalWorkflow_LatestRun() {
+	s.testHandleDecisionSignalExternalWorkflow("")
+}
+
+func (s *decisionTaskHandlerSuite) testHandleDecisionSignalExternalWorkflow(runID string) {
+	attr := &decisionpb.SignalExternalWorkflowExecutionDecisionAttributes{
+		Execution: &executionpb.WorkflowExecution{
+			WorkflowId: "some random target workflow ID",
+			RunId:      runID,
+		},
+		SignalName: "some random signal name",
+	}
+	s.mockMutableState.EXPECT().AddSignalExternalWorkflowExecutionInitiatedEvent(int64(4), gomock.Any(), attr).Return(&eventpb.HistoryEvent{}, nil, nil).Times(1)
+
+	err := s.handler.handleDecisionSignalExternalWorkflow(attr)
+	s.NoError(err)
+	s.False(s.handler.stopProcessing)
+}
+
+func (s *decisionTaskHandlerSuite) TestHandleDecisions_DedupeSignalExternalWorkflow() {
+	s.config.DedupeSignalExternalDecisions = dynamicconfig.GetBoolPropertyFnFilteredByNamespace(true)
+	s.mockMutableState.EXPECT().GetNextEventID().Return(int64(5)).AnyTimes()
+	s.mockMutableState.EXPECT().GetBufferedEventsCount().Return(0).Times(1)
+	newDecision := func(input []byte) *decisionpb.Decision {
+		return &decisionpb.Decision{
+			DecisionType: decisionpb.DecisionTypeSignalExternalWorkflowExecution,
+			Attributes: &decisionpb.Decision_SignalExternalWorkflowExecutionDecisionAttributes{
+				SignalExternalWorkflowExecutionDecisionAttributes: &decisionpb.SignalExternalWorkflowExecutionDecisionAttributes{
+					Execution: &executionpb.WorkflowExecution{
+						WorkflowId: "some random target workflow ID",
+						RunId:      testRunID,
+					},
+					SignalName: "some random signal name",
+					Input:      input,
+				},
+			},
+		}
+	}
+	decisions := []*decisionpb.Decision{
+		newDecision([]byte("some random input")),
+		newDecision([]byte("some random input")),
+		newDecision([]byte("some other random input")),
+	}
+	var requestIDs []string
+	recordRequestID := func(_ int64, requestID string, _ *decisionpb.SignalExternalWorkflowExecutionDecisionAttributes) (*eventpb.HistoryEvent, *persistenceblobs.SignalInfo, error) {
+		requestIDs = append(requestIDs, requestID)
+		return &eventpb.HistoryEvent{}, nil, nil
+	}
+	// the duplicate still records its initiated event
+	s.mockMutableState.EXPECT().AddSignalExternalWorkflowExecutionInitiatedEvent(
+		int64(4), gomock.Any(), decisions[0].GetSignalExternalWorkflowExecutionDecisionAttributes(),
+	).DoAndReturn(recordRequestID).Times(2)
+	s.mockMutableState.EXPECT().AddSignalExternalWorkflowExecutionInitiatedEvent(
+		int64(4), gomock.Any(), decisions[2].GetSignalExternalWorkflowExecutionDecisionAttributes(),
+	).DoAndReturn(recordRequestID).Times(1)
+
+	err := s.handler.handleDecisions(nil, decisions)
+	s.NoError(err)
+	s.False(s.handler.stopProcessing)
+	s.Len(requestIDs, 3)
+	s.Equal(requestIDs[0], requestIDs[1])
+	s.NotEqual(requestIDs[0], requestIDs[2])
+
+	counters := s.metricsScope.Snapshot().Counters()
+	counter, ok := counters["test.deduped_signal_external_decisions+operation=RespondDecisionTaskCompleted"]
+	s.True(ok)
+	s.Equal(int64(1), counter.Value())
+}
+
+func (s *decisionTaskHandlerSuite) TestHandleDecisionSignalExternalWorkflow_DedupeControlAcrossAttempts() {
+	s.config.DedupeSignalControlIDs = dynamicconfig.GetBoolPropertyFnFilteredByNamespace(true)
+	attr := &decisionpb.SignalExternalWorkflowExecutionDecisionAttributes{
+		Execution: &executionpb.WorkflowExecution{
+			WorkflowId: "some random target workflow ID",
+			RunId:      testRunID,
+		},
+		SignalName: "some random signal name",
+		Control:    []byte("some random control ID"),
+	}
+	var requestIDs []string
+	s.mockMutableState.EXPECT().AddSignalExternalWorkflowExecutionInitiatedEvent(int64(4), gomock.Any(), attr).DoAndReturn(
+		func(_ int64, requestID string, attr *decisionpb.SignalExternalWorkflowExecutionDecisionAttributes) (*eventpb.HistoryEvent, *persistenceblobs.SignalInfo, error) {
+			// the initiated event records the control in mutable state
+			key, ok := getSignalControlKey(attr.GetControl())
+			s.True(ok)
+			s.executionInfo.SignalControlIDs = map[string]*persistenceblobs.SignalControlInfo{
+				key: {InitiatedId: 5, RequestId: requestID},
+			}
+			requestIDs = append(requestIDs, requestID)
+			return &eventpb.HistoryEvent{}, nil, nil
+		},
+	).Times(2)
+
+	err := s.handler.handleDecisionSignalExternalWorkflow(attr)
+	s.NoError(err)
+	s.False(s.handler.stopProcessing)
+
+	// the retried decision task re-emits the decision with the same control
+	retryHandler := s.newDecisionTaskHandler()
+	err = retryHandler.handleDecisionSignalExternalWorkflow(attr)
+	s.NoError(err)
+	s.False(retryHandler.stopProcessing)
+	s.Len(requestIDs, 2)
+	s.Equal(requestIDs[0], requestIDs[1])
+
+	counters := s.metricsScope.Snapshot().Counters()
+	counter, ok := counters["test.deduped_signal_external_decisions+operation=RespondDecisionTaskCompleted"]
+	s.True(ok)
+	s.Equal(int64(1), counter.Value())
+}
+
+func (s *decisionTaskHandlerSuite) TestHandleDecisionSignalExternalWorkflow_ControlSizeExceedsLimit() {
+	s.handler.sizeLimitChecker.controlSizeLimitError = 10
+	attr := &decisionpb.SignalExternalWorkflowExecutionDecisionAttributes{
+		Execution: &executionpb.WorkflowExecution{
+			WorkflowId: "some random target workflow ID",
+			RunId:      testRunID,
+		},
+		SignalName: "some random signal name",
+		Input:      []byte("input"),
+		Control:    make([]byte, 11),
+	}
+	s.mockLogger.On("Warn", "Signal external workflow control size exceeds limit.", mock.Anything).Once()
+	s.mockLogger.On("Warn", "Decision task failed", mock.Anything).Once()
+
+	err := s.handler.handleDecisionSignalExternalWorkflow(attr)
+	s.NoError(err)
+	s.True(s.handler.stopProcessing)
+	s.Equal(eventpb.DecisionTaskFailedCauseBadSignalWorkflowExecutionAttributes, s.handler.failDecisionInfo.cause)
+	s.Equal("SignalExternalWorkflowExecutionDecisionAttributes.Control exceeds size limit.", s.handler.failDecisionInfo.message)
+}
+
+func (s *decisionTaskHandlerSuite) TestHandleDecisionSignalExternalWorkflow_ControlSizeWithinLimit() {
+	s.handler.sizeLimitChecker.controlSizeLimitError = 10
+	attr := &decisionpb.SignalExternalWorkflowExecutionDecisionAttributes{
+		Execution: &executionpb.WorkflowExecution{
+			WorkflowId: "some random target workflow ID",
+			RunId:      testRunID,
+		},
+		SignalName: "some random signal name",
+		Control:    make([]byte, 10),
+	}
+	s.mockMutableState.EXPECT().AddSignalExternalWorkflowExecutionInitiatedEvent(int64(4), gomock.Any(), attr).
+		Return(&eventpb.HistoryEvent{}, nil, nil).Times(1)
+
+	err := s.handler.handleDecisionSignalExternalWorkflow(attr)
+	s.NoError(err)
+	s.False(s.handler.stopProcessing)
+}
+
+func (s *decisionTaskHandlerSuite) TestHandleDecisionCancelWorkflow_Details() {
+	attr := &decisionpb.CancelWorkflowExecutionDecisionAttributes{
+		Details: []byte("some random cancellation reason"),
+	}
+	s.mockMutableState.EXPECT().IsWorkflowExecutionRunning().Return(true).Times(1)
+	s.mockMutableState.EXPECT().AddWorkflowExecutionCanceledEvent(int64(4), attr).Return(&eventpb.HistoryEvent{}, nil).Times(1)
+
+	err := s.handler.handleDecisionCancelWorkflow(attr)
+	s.NoError(err)
+	s.False(s.handler.stopProcessing)
+}
+
+func (s *decisionTaskHandlerSuite) TestHandleDecisionCancelWorkflow_DetailsSizeExceedsLimit() {
+	attr := &decisionpb.CancelWorkflowExecutionDecisionAttributes{
+		Details: make([]byte, 100),
+	}
+	s.expectMemoSizeExceedsLimit("CancelWorkflowExecutionDecisionAttributes.Details exceeds size limit.")
+
+	err := s.handler.handleDecisionCancelWorkflow(attr)
+	s.NoError(err)
+	s.True(s.handler.stopProcessing)
+}
+
+func (s *decisionTaskHandlerSuite) TestHandleDecisions_BatchActivityCancel() {
+	s.mockMutableState.EXPECT().GetNextEventID().Return(int64(5)).AnyTimes()
+	s.mockMutableState.EXPECT().GetBufferedEventsCount().Return(0).Times(1)
+	newDecision := func(activityID string) *decisionpb.Decision {
+		return &decisionpb.Decision{
+			DecisionType: decisionpb.DecisionTypeRequestCancelActivityTask,
+			Attributes: &decisionpb.Decision_RequestCancelActivityTaskDecisionAttributes{
+				RequestCancelActivityTaskDecisionAttributes: &decisionpb.RequestCancelActivityTaskDecisionAttributes{
+					ActivityId: activityID,
+				},
+			},
+		}
+	}
+	decisions := []*decisionpb.Decision{
+		newDecision("started activity"),
+		newDecision("not started activity"),
+		newDecision("unknown activity"),
+		newDecision("started activity"),
+	}
+	startedEvent := &eventpb.HistoryEvent{EventId: 5}
+	notStartedEvent := &eventpb.HistoryEvent{EventId: 6}
+	s.mockMutableState.EXPECT().AddActivityTaskCancelRequestedEvent(int64(4), "started activity", "some random identity").
+		Return(startedEvent, &persistence.ActivityInfo{ScheduleID: 2, StartedID: 3}, nil).Times(1)
+	s.mockMutableState.EXPECT().AddActivityTaskCancelRequestedEvent(int64(4), "not started activity", "some random identity").
+		Return(notStartedEvent, &persistence.ActivityInfo{ScheduleID: 1, StartedID: common.EmptyEventID}, nil).Times(1)
+	s.mockMutableState.EXPECT().AddActivityTaskCanceledEvent(
+		int64(1), common.EmptyEventID, int64(6), []byte(activityCancellationMsgActivityNotStarted), "some random identity",
+	).Return(&eventpb.HistoryEvent{}, nil).Times(1)
+	s.mockMutableState.EXPECT().AddActivityTaskCancelRequestedEvent(int64(4), "unknown activity", "some random identity").
+		Return(nil, nil, serviceerror.NewInvalidArgument("some random error")).Times(1)
+	s.mockMutableState.EXPECT().AddRequestCancelActivityTaskFailedEvent(int64(4), "unknown activity", activityCancellationMsgActivityIDUnknown).
+		Return(&eventpb.HistoryEvent{}, nil).Times(1)
+	// the activity is already cancel requested by the first decision of the batch
+	s.mockMutableState.EXPECT().AddActivityTaskCancelRequestedEvent(int64(4), "started activity", "some random identity").
+		Return(nil, nil, serviceerror.NewInvalidArgument("some random error")).Times(1)
+	s.mockMutableState.EXPECT().AddRequestCancelActivityTaskFailedEvent(int64(4), "started activity", activityCancellationMsgActivityIDUnknown).
+		Return(&eventpb.HistoryEvent{}, nil).Times(1)
+
+	err := s.handler.handleDecisions(nil, decisions)
+	s.NoError(err)
+	s.False(s.handler.stopProcessing)
+	s.True(s.handler.activityNotStartedCancelled)
+}
+
+func (s *decisionTaskHandlerSuite) setParentRetryPolicy() {
+	s.executionInfo.HasRetryPolicy = true
+	s.executionInfo.InitialInterval = 1
+	s.executionInfo.BackoffCoefficient = 2
+	s.executionInfo.MaximumInterval = 10
+	s.executionInfo.MaximumAttempts = 5
+	s.executionInfo.NonRetriableErrors = []string{"some random error reason"}
+	s.executionInfo.ExpirationSeconds = 100
+}
+
+func (s *decisionTaskHandlerSuite) newStartChildWorkflowAttributes() *decisionpb.StartChildWorkflowExecutionDecisionAttributes {
+	return &decisionpb.StartChildWorkflowExecutionDecisionAttributes{
+		WorkflowId:   "some random child workflow ID",
+		WorkflowType: &commonpb.WorkflowType{Name: "some random workflow type"},
+		TaskList:     &tasklistpb.TaskList{Name: "some random task list"},
+	}
+}
+
+func (s *decisionTaskHandlerSuite) newParentClosePolicyUnsetHeader() *commonpb.Header {
+	return &commonpb.Header{Fields: map[string][]byte{parentClosePolicyUnsetHeaderKey: []byte("true")}}
+}
+
+func (s *decisionTaskHandlerSuite) expectMemoSizeExceedsLimit(message string) {
+	s.handler.sizeLimitChecker.blobSizeLimitWarn = 10
+	s.handler.sizeLimitChecker.blobSizeLimitError = 50
+	s.mockLogger.On("Warn", "Blob size exceeds limit.", mock.Anything).Once()
+	s.mockMutableState.EXPECT().AddFailWorkflowEvent(int64(4), &decisionpb.FailWorkflowExecutionDecisionAttributes{
+		Reason:  common.FailureReasonDecisionBlobSizeExceedsLimit,
+		Details: []byte(message),
+	}).Return(&eventpb.HistoryEvent{}, nil).Times(1)
+}
+
+func (s *decisionTaskHandlerSuite) assertMemoSizeExceededCounter() {
+	counters := s.metricsScope.Snapshot().Counters()
+	counter, ok := counters["test.memo_size_exceeded+operation=RespondDecisionTaskCompleted"]
+	s.True(ok)
+	s.Equal(int64(1), counter.Value())
+}
+
+func (s *decisionTaskHandlerSuite) TestHandleDecisionUpsertWorkflowSearchAttributes_OversizedValue() {
+	mockNamespaceCache := cache.NewMockNamespaceCache(s.controller)
+	mockNamespaceCache.EXPECT().GetNamespaceByID(testNamespaceID).Return(testLocalNamespaceEntry, nil).Times(1)
+	s.handler.namespaceCache = mockNamespaceCache
+	s.mockLogger.On("Warn", "Decision task failed", mock.Anything).Once()
+
+	attr := &decisionpb.UpsertWorkflowSearchAttributesDecisionAttributes{
+		SearchAttributes: &commonpb.SearchAttributes{
+			IndexedFields: map[string][]byte{
+				"CustomKeywordField": []byte(`"keyword"`),
+				"CustomStringField":  make([]byte, s.config.SearchAttributesSizeOfValueLimit(testNamespace)+1),
+			},
+		},
+	}
+	err := s.handler.handleDecisionUpsertWorkflowSearchAttributes(attr)
+	s.NoError(err)
+	s.True(s.handler.stopProcessing)
+	s.Equal(eventpb.DecisionTaskFailedCauseBadSearchAttributes, s.handler.failDecisionInfo.cause)
+	s.Contains(s.handler.failDecisionInfo.message, "CustomStringField")
+	s.Contains(s.handler.failDecisionInfo.message, fmt.Sprintf("%v bytes", s.config.SearchAttributesSizeOfValueLimit(testNamespace)+1))
+}
+
+func (s *decisionTaskHandlerSuite) TestSetContinueAsNewBuilder_Valid() {
+	newStateBuilder := s.newContinueAsNewBuilder(&persistence.WorkflowExecutionInfo{
+		TaskList:                    "some random task list",
+		WorkflowTimeout:             100,
+		DecisionStartToCloseTimeout: 10,
+	}, []byte("some random input"))
+
+	err := s.handler.setContinueAsNewBuilder(newStateBuilder)
+	s.NoError(err)
+	s.Nil(s.handler.failDecisionInfo)
+	s.False(s.handler.stopProcessing)
+	s.Equal(newStateBuilder, s.handler.continueAsNewBuilder)
+}
+
+func (s *decisionTaskHandlerSuite) TestSetContinueAsNewBuilder_Corrupt() {
+	newStateBuilder := s.newContinueAsNewBuilder(&persistence.WorkflowExecutionInfo{
+		TaskList:                    "some random task list",
+		WorkflowTimeout:             0,
+		DecisionStartToCloseTimeout: 10,
+	}, []byte("some random input"))
+	s.mockLogger.On("Warn", "Decision task failed", mock.Anything).Once()
+
+	err := s.handler.setContinueAsNewBuilder(newStateBuilder)
+	s.NoError(err)
+	s.True(s.handler.stopProcessing)
+	s.Equal(eventpb.DecisionTaskFailedCauseBadContinueAsNewAttributes, s.handler.failDecisionInfo.cause)
+	s.Equal("New run of ContinueAsNew has invalid workflow timeout 0.", s.handler.failDecisionInfo.message)
+	s.Nil(s.handler.continueAsNewBuilder)
+}
+
+func (s *decisionTaskHandlerSuite) newContinueAsNewBuilder(
+	executionInfo *persistence.WorkflowExecutionInfo,
+	input []byte,
+) *MockmutableState {
+
+	newStateBuilder := NewMockmutableState(s.controller)
+	newStateBuilder.EXPECT().GetExecutionInfo().Return(executionInfo).AnyTimes()
+	newStateBuilder.EXPECT().GetHistoryBuilder().Return(&historyBuilder{
+		history: []*eventpb.HistoryEvent{
+			{
+				EventId:   common.FirstEventID,
+				EventType: eventpb.EventTypeWorkflowExecutionStarted,
+				Attributes: &eventpb.HistoryEvent_WorkflowExecutionStartedEventAttributes{WorkflowExecutionStartedEventAttributes: &eventpb.WorkflowExecutionStartedEventAttributes{
+					Input: input,
+				}},
+			},
+		},
+	}).AnyTimes()
+	return newStateBuilder
+}
+
+func (s *decisionTaskHandlerSuite) TestHandleDecisionFailWorkflow_StructuredFailureCategory() {
+	s.config.EnableStructuredFailureDetails = dynamicconfig.GetBoolPropertyFnFilteredByNamespace(true)
+	s.handler.attrValidator.failureCategories = dynamicconfig.GetMapPropertyFnFilteredByNamespace(map[string]interface{}{
+		"Dependency": true,
+	})
+	details := []byte(`{"category":"Dependency","message":"some random message"}`)
+	attr := &decisionpb.FailWorkflowExecutionDecisionAttributes{
+		Reason:  "some random reason",
+		Details: details,
+	}
+
+	s.mockMutableState.EXPECT().IsWorkflowExecutionRunning().Return(true).Times(1)
+	s.mockMutableState.EXPECT().AddUpsertWorkflowSearchAttributesEvent(gomock.Any(), gomock.Any()).Times(0)
+	s.mockMutableState.EXPECT().GetRetryBackoffDuration(attr.GetReason()).Return(backoff.NoBackoff).Times(1)
+	s.mockMutableState.EXPECT().GetCronBackoffDuration().Return(backoff.NoBackoff, nil).Times(1)
+	s.mockMutableState.EXPECT().AddFailWorkflowEvent(int64(4), attr).Return(&eventpb.HistoryEvent{}, nil).Times(1)
+
+	err := s.handler.handleDecisionFailWorkflow(attr)
+	s.NoError(err)
+	s.False(s.handler.stopProcessing)
+	s.Equal(details, attr.GetDetails())
+	// the category is mirrored from the failure event when the close visibility record is written
+	s.NotContains(s.executionInfo.SearchAttributes, definition.FailureCategory)
+}
+
+func (s *decisionTaskHandlerSuite) TestHandleDecisionFailWorkflow_UnknownFailureCategory() {
+	s.config.EnableStructuredFailureDetails = dynamicconfig.GetBoolPropertyFnFilteredByNamespace(true)
+	s.handler.attrValidator.failureCategories = dynamicconfig.GetMapPropertyFnFilteredByNamespace(map[string]interface{}{
+		"Dependency": true,
+	})
+	s.mockLogger.On("Warn", "Decision task failed", mock.Anything).Once()
+	attr := &decisionpb.FailWorkflowExecutionDecisionAttributes{
+		Reason:  "some random reason",
+		Details: []byte(`{"category":"some random category"}`),
+	}
+
+	s.mockMutableState.EXPECT().AddUpsertWorkflowSearchAttributesEvent(gomock.Any(), gomock.Any()).Times(0)
+	s.mockMutableState.EXPECT().AddFailWorkflowEvent(gomock.Any(), gomock.Any()).Times(0)
+
+	err := s.handler.handleDecisionFailWorkflow(attr)
+	s.NoError(err)
+	s.True(s.handler.stopProcessing)
+	s.Equal(eventpb.DecisionTaskFailedCauseBadFailWorkflowExecutionAttributes, s.handler.failDecisionInfo.cause)
+	s.Equal("Failure category some random category is not allowed.", s.handler.failDecisionInfo.message)
+}
+
+func (s *decisionTaskHandlerSuite) TestHandleDecisionFailWorkflow_RetryInitiatorCounter() {
+	attr := &decisionpb.FailWorkflowExecutionDecisionAttributes{Reason: "some random reason"}
+	s.mockMutableState.EXPECT().IsWorkflowExecutionRunning().Return(true).Times(1)
+	s.mockMutableState.EXPECT().GetRetryBackoffDuration(attr.GetReason()).Return(10 * time.Second).Times(1)
+	s.mockMutableState.EXPECT().GetCronBackoffDuration().Times(0)
+	s.expectRetryCronStartEvent()
+	s.expectRetryCronContinueAsNew(10)
+
+	err := s.handler.handleDecisionFailWorkflow(attr)
+	s.NoError(err)
+	s.assertRetryCronContinueAsNewCounter(commonpb.ContinueAsNewInitiatorRetryPolicy)
+}
+
+func (s *decisionTaskHandlerSuite) TestHandleDecisionFailWorkflow_CronInitiatorCounter() {
+	attr := &decisionpb.FailWorkflowExecutionDecisionAttributes{Reason: "some random reason"}
+	s.mockMutableState.EXPECT().IsWorkflowExecutionRunning().Return(true).Times(1)
+	s.mockMutableState.EXPECT().GetRetryBackoffDuration(attr.GetReason()).Return(backoff.NoBackoff).Times(1)
+	s.mockMutableState.EXPECT().GetCronBackoffDuration().Return(20*time.Second, nil).Times(1)
+	s.expectRetryCronStartEvent()
+	s.expectRetryCronContinueAsNew(20)
+
+	err := s.handler.handleDecisionFailWorkflow(attr)
+	s.NoError(err)
+	s.assertRetryCronContinueAsNewCounter(commonpb.ContinueAsNewInitiatorCronSchedule)
+}
+
+func (s *decisionTaskHandlerSuite) TestHandleDecisionCompleteWorkflow_CronInitiatorCounter() {
+	attr := &decisionpb.CompleteWorkflowExecutionDecisionAttributes{Result: []byte("some random result")}
+	s.mockMutableState.EXPECT().IsWorkflowExecutionRunning().Return(true).Times(1)
+	s.mockMutableState.EXPECT().GetCronBackoffDuration().Return(20*time.Second, nil).Times(1)
+	s.expectRetryCronStartEvent()
+	s.expectRetryCronContinueAsNew(20)
+
+	err := s.handler.handleDecisionCompleteWorkflow(attr)
+	s.NoError(err)
+	s.assertRetryCronContinueAsNewCounter(commonpb.ContinueAsNewInitiatorCronSchedule)
+}
+
+func (s *decisionTaskHandlerSuite) expectRetryCronStartEvent() {
+	s.mockMutableState.EXPECT().GetStartEvent().Return(&eventpb.HistoryEvent{
+		EventId:   common.FirstEventID,
+		EventType: eventpb.EventTypeWorkflowExecutionStarted,
+		Attributes: &eventpb.HistoryEvent_WorkflowExecutionStartedEventAttributes{
+			WorkflowExecutionStartedEventAttributes: s.newRetryCronStartedAttributes(),
+		},
+	}, nil).Times(1)
+}
+
+func (s *decisionTaskHandlerSuite) assertRetryCronContinueAsNewCounter(initiator commonpb.ContinueAsNewInitiator) {
+	counters := s.metricsScope.Snapshot().Counters()
+	for _, candidate := range []commonpb.ContinueAsNewInitiator{
+		commonpb.ContinueAsNewInitiatorRetryPolicy,
+		commonpb.ContinueAsNewInitiatorCronSchedule,
+	} {
+		counter, ok := counters["test.retry_cron_continue_as_new+continueAsNewInitiator="+candidate.String()+",operation=RespondDecisionTaskCompleted"]
+		s.Equal(candidate == initiator, ok)
+		if ok {
+			s.Equal(int64(1), counter.Value())
+		}
+	}
+}
+
+func (s *decisionTaskHandlerSuite) TestRetryCronContinueAsNew_BackoffIntervalCapped() {
+	s.config.MaxRetryCronBackoffInterval = dynamicconfig.GetDurationPropertyFnFilteredByNamespace(time.Hour)
+	s.mockLogger.On("Info", "Capping retry or cron backoff interval to the namespace maximum.", mock.Anything).Once()
+
+	s.expectRetryCronContinueAsNew(int32(time.Hour.Seconds()))
+	err := s.handler.retryCronContinueAsNew(
+		s.newRetryCronStartedAttributes(),
+		int32((48 * time.Hour).Seconds()),
+		commonpb.ContinueAsNewInitiatorCronSchedule,
+		"",
+		nil,
+		nil,
+	)
+	s.NoError(err)
+	s.NotNil(s.handler.continueAsNewBuilder)
+}
+
+func (s *decisionTaskHandlerSuite) TestRetryCronContinueAsNew_BackoffIntervalBelowCap() {
+	s.config.MaxRetryCronBackoffInterval = dynamicconfig.GetDurationPropertyFnFilteredByNamespace(time.Hour)
+
+	s.expectRetryCronContinueAsNew(int32(time.Minute.Seconds()))
+	err := s.handler.retryCronContinueAsNew(
+		s.newRetryCronStartedAttributes(),
+		int32(time.Minute.Seconds()),
+		commonpb.ContinueAsNewInitiatorRetryPolicy,
+		"some random reason",
+		nil,
+		nil,
+	)
+	s.NoError(err)
+	s.NotNil(s.handler.continueAsNewBuilder)
+}
+
+func (s *decisionTaskHandlerSuite) newRetryCronStartedAttributes() *eventpb.WorkflowExecutionStartedEventAttributes {
+	return &eventpb.WorkflowExecutionStartedEventAttributes{
+		WorkflowType:                        &commonpb.WorkflowType{Name: "some random workflow type"},
+		TaskList:                            &tasklistpb.TaskList{Name: "some random task list"},
+		ExecutionStartToCloseTimeoutSeconds: 100,
+		TaskStartToCloseTimeoutSeconds:      10,
+	}
+}
+
+func (s *decisionTaskHandlerSuite) expectRetryCronContinueAsNew(expectedBackoffInterval int32) {
+	newStateBuilder := s.newContinueAsNewBuilder(&persistence.WorkflowExecutionInfo{
+		TaskList:                    "some random task list",
+		WorkflowTimeout:             100,
+		DecisionStartToCloseTimeout: 10,
+	}, nil)
+	s.mockMutableState.EXPECT().AddContinueAsNewEvent(int64(4), int64(4), "", gomock.Any()).DoAndReturn(
+		func(_ int64, _ int64, _ string, attr *decisionpb.ContinueAsNewWorkflowExecutionDecisionAttributes) (*eventpb.HistoryEvent, mutableState, error) {
+			s.Equal(expectedBackoffInterval, attr.GetBackoffStartIntervalInSeconds())
+			return &eventpb.HistoryEvent{}, newStateBuilder, nil
+		}).Times(1)
+}
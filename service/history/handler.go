@@ -26,12 +26,15 @@ import (
 	"sync"
 	"sync/atomic"
 
+	"github.com/gogo/protobuf/types"
 	"github.com/pborman/uuid"
 	eventpb "go.temporal.io/temporal-proto/event"
 	executionpb "go.temporal.io/temporal-proto/execution"
 	"go.temporal.io/temporal-proto/serviceerror"
 	healthpb "google.golang.org/grpc/health/grpc_health_v1"
 
+	"github.com/temporalio/temporal/.gen/proto/adminservice"
+	executiongenpb "github.com/temporalio/temporal/.gen/proto/execution"
 	"github.com/temporalio/temporal/.gen/proto/historyservice"
 	namespacegenpb "github.com/temporalio/temporal/.gen/proto/namespace"
 	replicationgenpb "github.com/temporalio/temporal/.gen/proto/replication"
@@ -666,6 +669,12 @@ func (h *Handler) DescribeMutableState(ctx context.Context, request *historyserv
 	if err2 != nil {
 		return nil, h.error(err2, scope, namespaceID, workflowID)
 	}
+
+	bufferedEventsCount, err3 := engine.GetBufferedEventCount(ctx, namespaceID, *workflowExecution)
+	if err3 != nil {
+		return nil, h.error(err3, scope, namespaceID, workflowID)
+	}
+	resp.BufferedEventsCount = int32(bufferedEventsCount)
 	return resp, nil
 }
 
@@ -1593,6 +1602,273 @@ func (h *Handler) RefreshWorkflowTasks(ctx context.Context, request *historyserv
 	return &historyservice.RefreshWorkflowTasksResponse{}, nil
 }
 
+func (h *Handler) ResetReplicationAckLevel(ctx context.Context, request *historyservice.ResetReplicationAckLevelRequest) (_ *historyservice.ResetReplicationAckLevelResponse, retError error) {
+	defer log.CapturePanicGRPC(h.GetLogger(), &retError)
+
+	h.startWG.Wait()
+
+	scope := metrics.HistoryResetReplicationAckLevelScope
+	h.GetMetricsClient().IncCounter(scope, metrics.ServiceRequests)
+	sw := h.GetMetricsClient().StartTimer(scope, metrics.ServiceLatency)
+	defer sw.Stop()
+
+	engine, err := h.controller.getEngineForShard(int(request.GetShardId()))
+	if err != nil {
+		err = h.error(err, scope, "", "")
+		return nil, err
+	}
+
+	resp, err := engine.ResetReplicationAckLevel(ctx, request)
+	if err != nil {
+		err = h.error(err, scope, "", "")
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+// RepairShardAckLevels recomputes the task queue ack levels of a shard from persistence
+func (h *Handler) RepairShardAckLevels(ctx context.Context, request *historyservice.RepairShardAckLevelsRequest) (_ *historyservice.RepairShardAckLevelsResponse, retError error) {
+	defer log.CapturePanicGRPC(h.GetLogger(), &retError)
+
+	h.startWG.Wait()
+
+	scope := metrics.HistoryRepairShardAckLevelsScope
+	h.GetMetricsClient().IncCounter(scope, metrics.ServiceRequests)
+	sw := h.GetMetricsClient().StartTimer(scope, metrics.ServiceLatency)
+	defer sw.Stop()
+
+	shardID := int(request.GetShardId())
+	engine, err := h.controller.getEngineForShard(shardID)
+	if err != nil {
+		err = h.error(err, scope, "", "")
+		return nil, err
+	}
+
+	resp, err := engine.RepairShardAckLevels(ctx, request)
+	if err != nil {
+		err = h.error(err, scope, "", "")
+		return nil, err
+	}
+
+	if request.GetApply() {
+		// the queue processors keep their ack levels in memory and would overwrite the repaired
+		// ones on their next update, so close the shard and let them start over from persistence
+		h.controller.removeEngineForShard(shardID)
+	}
+	return resp, nil
+}
+
+// ForceTerminateWorkflowExecution closes a workflow with a terminated event on behalf of an operator
+func (h *Handler) ForceTerminateWorkflowExecution(ctx context.Context, request *historyservice.ForceTerminateWorkflowExecutionRequest) (_ *historyservice.ForceTerminateWorkflowExecutionResponse, retError error) {
+	defer log.CapturePanicGRPC(h.GetLogger(), &retError)
+
+	h.startWG.Wait()
+
+	scope := metrics.HistoryForceTerminateWorkflowExecutionScope
+	h.GetMetricsClient().IncCounter(scope, metrics.ServiceRequests)
+	sw := h.GetMetricsClient().StartTimer(scope, metrics.ServiceLatency)
+	defer sw.Stop()
+
+	namespaceID := request.GetNamespaceId()
+	if namespaceID == "" {
+		return nil, h.error(errNamespaceNotSet, scope, namespaceID, "")
+	}
+
+	terminateRequest := request.GetRequest()
+	execution := terminateRequest.GetExecution()
+	workflowID := execution.GetWorkflowId()
+	engine, err := h.controller.GetEngine(workflowID)
+	if err != nil {
+		return nil, h.error(err, scope, namespaceID, workflowID)
+	}
+
+	err = engine.TerminateWorkflow(
+		ctx,
+		namespaceID,
+		executionpb.WorkflowExecution{
+			WorkflowId: execution.GetWorkflowId(),
+			RunId:      execution.GetRunId(),
+		},
+		terminateRequest.GetReason(),
+		terminateRequest.GetDetails(),
+		terminateRequest.GetIdentity(),
+	)
+	if err != nil {
+		return nil, h.error(err, scope, namespaceID, workflowID)
+	}
+	return &historyservice.ForceTerminateWorkflowExecutionResponse{}, nil
+}
+
+// DLQReplicationTask puts a replication task received from a source cluster into DLQ instead of applying it
+func (h *Handler) DLQReplicationTask(ctx context.Context, request *historyservice.DLQReplicationTaskRequest) (_ *historyservice.DLQReplicationTaskResponse, retError error) {
+	defer log.CapturePanicGRPC(h.GetLogger(), &retError)
+
+	h.startWG.Wait()
+
+	scope := metrics.HistoryDLQReplicationTaskScope
+	h.GetMetricsClient().IncCounter(scope, metrics.ServiceRequests)
+	sw := h.GetMetricsClient().StartTimer(scope, metrics.ServiceLatency)
+	defer sw.Stop()
+
+	engine, err := h.controller.getEngineForShard(int(request.GetShardId()))
+	if err != nil {
+		err = h.error(err, scope, "", "")
+		return nil, err
+	}
+
+	if err := engine.DLQReplicationTask(ctx, request); err != nil {
+		err = h.error(err, scope, "", "")
+		return nil, err
+	}
+
+	return &historyservice.DLQReplicationTaskResponse{}, nil
+}
+
+// RefreshNamespaceCache reloads a namespace from persistence into the namespace cache of this host
+func (h *Handler) RefreshNamespaceCache(ctx context.Context, request *historyservice.RefreshNamespaceCacheRequest) (_ *historyservice.RefreshNamespaceCacheResponse, retError error) {
+	defer log.CapturePanicGRPC(h.GetLogger(), &retError)
+
+	h.startWG.Wait()
+
+	scope := metrics.HistoryRefreshNamespaceCacheScope
+	h.GetMetricsClient().IncCounter(scope, metrics.ServiceRequests)
+	sw := h.GetMetricsClient().StartTimer(scope, metrics.ServiceLatency)
+	defer sw.Stop()
+
+	if request.GetNamespace() == "" {
+		return nil, h.error(errNamespaceNotSet, scope, "", "")
+	}
+
+	if err := h.GetNamespaceCache().RefreshNamespace(request.GetNamespace()); err != nil {
+		return nil, h.error(err, scope, "", "")
+	}
+	return &historyservice.RefreshNamespaceCacheResponse{}, nil
+}
+
+// GetPendingChildren returns the initiated but not yet closed child workflows of an execution
+func (h *Handler) GetPendingChildren(ctx context.Context, request *historyservice.GetPendingChildrenRequest) (_ *historyservice.GetPendingChildrenResponse, retError error) {
+	defer log.CapturePanicGRPC(h.GetLogger(), &retError)
+	h.startWG.Wait()
+
+	scope := metrics.HistoryGetPendingChildrenScope
+	h.GetMetricsClient().IncCounter(scope, metrics.ServiceRequests)
+	sw := h.GetMetricsClient().StartTimer(scope, metrics.ServiceLatency)
+	defer sw.Stop()
+
+	namespaceID := request.GetNamespaceId()
+	if namespaceID == "" {
+		return nil, h.error(errNamespaceNotSet, scope, namespaceID, "")
+	}
+
+	workflowExecution := request.Execution
+	workflowID := workflowExecution.GetWorkflowId()
+	engine, err1 := h.controller.GetEngine(workflowID)
+	if err1 != nil {
+		return nil, h.error(err1, scope, namespaceID, workflowID)
+	}
+
+	pendingChildren, err2 := engine.GetPendingChildren(ctx, namespaceID, *workflowExecution)
+	if err2 != nil {
+		return nil, h.error(err2, scope, namespaceID, workflowID)
+	}
+
+	resp := &historyservice.GetPendingChildrenResponse{}
+	for _, childInfo := range pendingChildren {
+		resp.PendingChildren = append(resp.PendingChildren, toPendingChildExecutionInfo(childInfo))
+	}
+	return resp, nil
+}
+
+func toPendingChildExecutionInfo(childInfo *persistence.ChildExecutionInfo) *executiongenpb.PendingChildExecutionInfo {
+	return &executiongenpb.PendingChildExecutionInfo{
+		Namespace:         childInfo.Namespace,
+		WorkflowId:        childInfo.StartedWorkflowID,
+		RunId:             childInfo.StartedRunID,
+		WorkflowTypeName:  childInfo.WorkflowTypeName,
+		InitiatedId:       childInfo.InitiatedID,
+		InitiatedTime:     childInfo.InitiatedEvent.GetTimestamp(),
+		StartedId:         childInfo.StartedID,
+		ParentClosePolicy: childInfo.ParentClosePolicy,
+	}
+}
+
+// ListWorkflowTimers returns the user timers held by the mutable state of an execution
+func (h *Handler) ListWorkflowTimers(ctx context.Context, request *historyservice.ListWorkflowTimersRequest) (_ *historyservice.ListWorkflowTimersResponse, retError error) {
+	defer log.CapturePanicGRPC(h.GetLogger(), &retError)
+	h.startWG.Wait()
+
+	scope := metrics.HistoryListWorkflowTimersScope
+	h.GetMetricsClient().IncCounter(scope, metrics.ServiceRequests)
+	sw := h.GetMetricsClient().StartTimer(scope, metrics.ServiceLatency)
+	defer sw.Stop()
+
+	namespaceID := request.GetNamespaceId()
+	if namespaceID == "" {
+		return nil, h.error(errNamespaceNotSet, scope, namespaceID, "")
+	}
+
+	workflowExecution := request.Execution
+	workflowID := workflowExecution.GetWorkflowId()
+	engine, err1 := h.controller.GetEngine(workflowID)
+	if err1 != nil {
+		return nil, h.error(err1, scope, namespaceID, workflowID)
+	}
+
+	timers, err2 := engine.ListTimers(ctx, namespaceID, *workflowExecution)
+	if err2 != nil {
+		return nil, h.error(err2, scope, namespaceID, workflowID)
+	}
+
+	resp := &historyservice.ListWorkflowTimersResponse{}
+	for _, timer := range timers {
+		resp.Timers = append(resp.Timers, toWorkflowTimerInfo(timer))
+	}
+	return resp, nil
+}
+
+func toWorkflowTimerInfo(timer *TimerInfo) *adminservice.WorkflowTimerInfo {
+	var expiryTime int64
+	if expiry, err := types.TimestampFromProto(timer.ExpiryTime); err == nil {
+		expiryTime = expiry.UnixNano()
+	}
+	return &adminservice.WorkflowTimerInfo{
+		TimerId:        timer.TimerId,
+		StartedEventId: timer.StartedId,
+		ExpiryTime:     expiryTime,
+		Version:        timer.Version,
+		Orphaned:       timer.Orphaned,
+	}
+}
+
+// CancelOrphanedTimer removes a user timer which is not backed by its timer started event from an execution
+func (h *Handler) CancelOrphanedTimer(ctx context.Context, request *historyservice.CancelOrphanedTimerRequest) (_ *historyservice.CancelOrphanedTimerResponse, retError error) {
+	defer log.CapturePanicGRPC(h.GetLogger(), &retError)
+	h.startWG.Wait()
+
+	scope := metrics.HistoryCancelOrphanedTimerScope
+	h.GetMetricsClient().IncCounter(scope, metrics.ServiceRequests)
+	sw := h.GetMetricsClient().StartTimer(scope, metrics.ServiceLatency)
+	defer sw.Stop()
+
+	namespaceID := request.GetNamespaceId()
+	if namespaceID == "" {
+		return nil, h.error(errNamespaceNotSet, scope, namespaceID, "")
+	}
+
+	workflowExecution := request.Execution
+	workflowID := workflowExecution.GetWorkflowId()
+	engine, err1 := h.controller.GetEngine(workflowID)
+	if err1 != nil {
+		return nil, h.error(err1, scope, namespaceID, workflowID)
+	}
+
+	if err2 := engine.CancelOrphanedTimer(ctx, namespaceID, *workflowExecution, request.GetTimerId()); err2 != nil {
+		return nil, h.error(err2, scope, namespaceID, workflowID)
+	}
+	return &historyservice.CancelOrphanedTimerResponse{}, nil
+}
+
 // convertError is a helper method to convert ShardOwnershipLostError from persistence layer returned by various
 // HistoryEngine API calls to ShardOwnershipLost error return by HistoryService for client to be redirected to the
 // correct shard.
@@ -1710,3 +1986,33 @@ func validateTaskToken(taskToken *tokengenpb.Task) error {
 	}
 	return nil
 }
+
+// TailWorkflowExecutionHistory streams the history events of a workflow execution as they are written
+func (h *Handler) TailWorkflowExecutionHistory(request *historyservice.TailWorkflowExecutionHistoryRequest, stream historyservice.HistoryService_TailWorkflowExecutionHistoryServer) (retError error) {
+	defer log.CapturePanicGRPC(h.GetLogger(), &retError)
+	h.startWG.Wait()
+
+	scope := metrics.HistoryTailWorkflowExecutionHistoryScope
+	h.GetMetricsClient().IncCounter(scope, metrics.ServiceRequests)
+	sw := h.GetMetricsClient().StartTimer(scope, metrics.ServiceLatency)
+	defer sw.Stop()
+
+	namespaceID := request.GetNamespaceId()
+	if namespaceID == "" {
+		return h.error(errNamespaceNotSet, scope, namespaceID, "")
+	}
+
+	workflowID := request.Execution.GetWorkflowId()
+	engine, err1 := h.controller.GetEngine(workflowID)
+	if err1 != nil {
+		return h.error(err1, scope, namespaceID, workflowID)
+	}
+
+	err2 := engine.TailWorkflowExecutionHistory(stream.Context(), request, func(events []*eventpb.HistoryEvent) error {
+		return stream.Send(&historyservice.TailWorkflowExecutionHistoryResponse{Events: events})
+	})
+	if err2 != nil {
+		return h.error(err2, scope, namespaceID, workflowID)
+	}
+	return nil
+}
@@ -28,6 +28,7 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"sort"
 	"time"
 
 	"github.com/gogo/protobuf/types"
@@ -41,9 +42,11 @@ import (
 	"go.temporal.io/temporal-proto/workflowservice"
 	sdkclient "go.temporal.io/temporal/client"
 
+	"github.com/temporalio/temporal/.gen/proto/adminservice"
 	executiongenpb "github.com/temporalio/temporal/.gen/proto/execution"
 	"github.com/temporalio/temporal/.gen/proto/historyservice"
 	"github.com/temporalio/temporal/.gen/proto/matchingservice"
+	"github.com/temporalio/temporal/.gen/proto/persistenceblobs"
 	replicationgenpb "github.com/temporalio/temporal/.gen/proto/replication"
 	"github.com/temporalio/temporal/client/history"
 	"github.com/temporalio/temporal/client/matching"
@@ -71,6 +74,7 @@ const (
 	timerCancellationMsgTimerIDUnknown        = "TIMER_ID_UNKNOWN"
 	queryFirstDecisionTaskWaitTime            = time.Second
 	queryFirstDecisionTaskCheckInterval       = 200 * time.Millisecond
+	archivalClientCloseTimeout                = 10 * time.Second
 )
 
 type (
@@ -97,6 +101,7 @@ type (
 		SignalWithStartWorkflowExecution(ctx context.Context, request *historyservice.SignalWithStartWorkflowExecutionRequest) (*historyservice.SignalWithStartWorkflowExecutionResponse, error)
 		RemoveSignalMutableState(ctx context.Context, request *historyservice.RemoveSignalMutableStateRequest) error
 		TerminateWorkflowExecution(ctx context.Context, request *historyservice.TerminateWorkflowExecutionRequest) error
+		TerminateWorkflow(ctx context.Context, namespaceID string, execution executionpb.WorkflowExecution, reason string, details []byte, identity string) error
 		ResetWorkflowExecution(ctx context.Context, request *historyservice.ResetWorkflowExecutionRequest) (*historyservice.ResetWorkflowExecutionResponse, error)
 		ScheduleDecisionTask(ctx context.Context, request *historyservice.ScheduleDecisionTaskRequest) error
 		RecordChildExecutionCompleted(ctx context.Context, request *historyservice.RecordChildExecutionCompletedRequest) error
@@ -113,6 +118,15 @@ type (
 		PurgeDLQMessages(ctx context.Context, messagesRequest *historyservice.PurgeDLQMessagesRequest) error
 		MergeDLQMessages(ctx context.Context, messagesRequest *historyservice.MergeDLQMessagesRequest) (*historyservice.MergeDLQMessagesResponse, error)
 		RefreshWorkflowTasks(ctx context.Context, namespaceUUID string, execution executionpb.WorkflowExecution) error
+		ResetReplicationAckLevel(ctx context.Context, request *historyservice.ResetReplicationAckLevelRequest) (*historyservice.ResetReplicationAckLevelResponse, error)
+		RepairShardAckLevels(ctx context.Context, request *historyservice.RepairShardAckLevelsRequest) (*historyservice.RepairShardAckLevelsResponse, error)
+		GetPendingChildren(ctx context.Context, namespaceID string, execution executionpb.WorkflowExecution) ([]*persistence.ChildExecutionInfo, error)
+		GetBufferedEventCount(ctx context.Context, namespaceID string, execution executionpb.WorkflowExecution) (int, error)
+		ListTimers(ctx context.Context, namespaceID string, execution executionpb.WorkflowExecution) ([]*TimerInfo, error)
+		DryRunReplay(ctx context.Context, namespaceID string, execution executionpb.WorkflowExecution) (*WorkflowStateSummary, error)
+		CancelOrphanedTimer(ctx context.Context, namespaceID string, execution executionpb.WorkflowExecution, timerID string) error
+		DLQReplicationTask(ctx context.Context, request *historyservice.DLQReplicationTaskRequest) error
+		TailWorkflowExecutionHistory(ctx context.Context, request *historyservice.TailWorkflowExecutionHistoryRequest, send func([]*eventpb.HistoryEvent) error) error
 
 		NotifyNewHistoryEvent(event *historyEventNotification)
 		NotifyNewTransferTasks(tasks []persistence.Task)
@@ -153,6 +167,45 @@ type (
 		versionChecker            headers.VersionChecker
 		replicationDLQHandler     replicationDLQHandler
 	}
+
+	// TimerInfo is a user timer held by the mutable state of a workflow. Orphaned is set when the timer is not
+	// backed by the timer started event it refers to, so the workflow has no way to cancel it.
+	TimerInfo struct {
+		*persistenceblobs.TimerInfo
+		Orphaned bool
+	}
+
+	// WorkflowStateSummary is the part of the state of a workflow which mutable state derives from history.
+	// Pending activities, timers and children are ordered by the ID of the event they were created by.
+	WorkflowStateSummary struct {
+		NextEventID       int64
+		PendingActivities []ActivitySummary
+		PendingTimers     []TimerSummary
+		PendingChildren   []ChildSummary
+		SearchAttributes  map[string][]byte
+	}
+
+	// ActivitySummary is a pending activity of a WorkflowStateSummary
+	ActivitySummary struct {
+		ScheduleID int64
+		StartedID  int64
+		ActivityID string
+	}
+
+	// TimerSummary is a pending user timer of a WorkflowStateSummary
+	TimerSummary struct {
+		StartedID  int64
+		TimerID    string
+		ExpiryTime *types.Timestamp
+	}
+
+	// ChildSummary is a pending child workflow of a WorkflowStateSummary
+	ChildSummary struct {
+		InitiatedID      int64
+		StartedID        int64
+		Namespace        string
+		WorkflowTypeName string
+	}
 )
 
 var _ Engine = (*historyEngineImpl)(nil)
@@ -246,6 +299,11 @@ func NewEngineWithShardContext(
 			shard.GetConfig().NumArchiveSystemWorkflows,
 			shard.GetConfig().ArchiveRequestRPS,
 			shard.GetService().GetArchiverProvider(),
+			archiver.NewChainedSearchAttributesTransformProvider(
+				archiver.NewRedactSearchAttributesTransformProvider(shard.GetConfig().ArchivalRedactedSearchAttributes),
+				archiver.NewRenameSearchAttributesTransformProvider(shard.GetConfig().ArchivalSearchAttributesFieldMapping),
+			),
+			shard.GetConfig().ArchivalHistoryStatusFilter,
 		),
 		publicClient:      publicClient,
 		matchingClient:    matching,
@@ -382,6 +440,13 @@ func (e *historyEngineImpl) Stop() {
 		replicationTaskProcessor.Stop()
 	}
 
+	// give in-flight inline archivals a chance to complete
+	ctx, cancel := context.WithTimeout(context.Background(), archivalClientCloseTimeout)
+	defer cancel()
+	if err := e.archivalClient.Close(ctx); err != nil {
+		e.logger.Warn("Timed out waiting for in-flight archivals to complete.", tag.Error(err))
+	}
+
 	// unset the failover callback
 	e.shard.GetNamespaceCache().UnregisterNamespaceChangeCallback(e.shard.GetShardID())
 }
@@ -2160,6 +2225,46 @@ func (e *historyEngineImpl) TerminateWorkflowExecution(
 		})
 }
 
+// TerminateWorkflow closes a workflow with a terminated event on behalf of an operator. As for any closed workflow,
+// the close execution transfer task then applies the parent close policy of each pending child.
+func (e *historyEngineImpl) TerminateWorkflow(
+	ctx context.Context,
+	namespaceUUID string,
+	execution executionpb.WorkflowExecution,
+	reason string,
+	details []byte,
+	identity string,
+) error {
+
+	if reason == "" {
+		return serviceerror.NewInvalidArgument("Terminate reason is not set.")
+	}
+	namespaceEntry, err := e.getActiveNamespaceEntry(namespaceUUID)
+	if err != nil {
+		return err
+	}
+	namespaceID := namespaceEntry.GetInfo().ID
+
+	return e.updateWorkflow(
+		ctx,
+		namespaceID,
+		execution,
+		func(context workflowExecutionContext, mutableState mutableState) (*updateWorkflowAction, error) {
+			if !mutableState.IsWorkflowExecutionRunning() {
+				return nil, ErrWorkflowCompleted
+			}
+
+			eventBatchFirstEventID := mutableState.GetNextEventID()
+			return updateWorkflowWithoutDecision, terminateWorkflow(
+				mutableState,
+				eventBatchFirstEventID,
+				reason,
+				details,
+				identity,
+			)
+		})
+}
+
 // RecordChildExecutionCompleted records the completion of child execution into parent execution history
 func (e *historyEngineImpl) RecordChildExecutionCompleted(
 	ctx context.Context,
@@ -2832,6 +2937,7 @@ func (e *historyEngineImpl) GetReplicationMessages(
 		ctx,
 		pollingCluster,
 		lastReadMessageID,
+		0,
 	)
 	if err != nil {
 		e.logger.Error("Failed to retrieve replication messages.", tag.Error(err))
@@ -3053,6 +3159,616 @@ func (e *historyEngineImpl) MergeDLQMessages(
 	}, nil
 }
 
+func (e *historyEngineImpl) ResetReplicationAckLevel(
+	ctx context.Context,
+	request *historyservice.ResetReplicationAckLevelRequest,
+) (*historyservice.ResetReplicationAckLevelResponse, error) {
+
+	clusterName := request.GetClusterName()
+	if clusterName == e.currentClusterName {
+		return nil, serviceerror.NewInvalidArgument("Cannot reset replication ack level of the current cluster.")
+	}
+	if _, ok := e.clusterMetadata.GetAllClusterInfo()[clusterName]; !ok {
+		return nil, serviceerror.NewInvalidArgument(fmt.Sprintf("Unknown cluster name: %v.", clusterName))
+	}
+
+	// everything up to the max read level has been generated already, so moving the remote
+	// cluster's ack level there makes all existing replication tasks eligible for cleanup
+	ackLevel := e.shard.GetTransferMaxReadLevel()
+	if err := e.shard.UpdateClusterReplicationLevel(clusterName, ackLevel); err != nil {
+		return nil, err
+	}
+	return &historyservice.ResetReplicationAckLevelResponse{
+		AckLevel: ackLevel,
+	}, nil
+}
+
+// queueTaskRange is the range of task levels found in a task queue of the shard
+type queueTaskRange struct {
+	minLevel int64
+	maxLevel int64
+	count    int64
+}
+
+func (r *queueTaskRange) add(level int64) {
+	if r.count == 0 || level < r.minLevel {
+		r.minLevel = level
+	}
+	if r.count == 0 || level > r.maxLevel {
+		r.maxLevel = level
+	}
+	r.count++
+}
+
+func (r *queueTaskRange) toAckLevel(queue queueType, ackLevel int64, repairedAckLevel int64) *adminservice.ShardQueueAckLevel {
+	return &adminservice.ShardQueueAckLevel{
+		QueueType:        queue.String(),
+		AckLevel:         ackLevel,
+		RepairedAckLevel: repairedAckLevel,
+		MinTaskLevel:     r.minLevel,
+		MaxTaskLevel:     r.maxLevel,
+		TaskCount:        r.count,
+	}
+}
+
+func (e *historyEngineImpl) RepairShardAckLevels(
+	ctx context.Context,
+	request *historyservice.RepairShardAckLevelsRequest,
+) (*historyservice.RepairShardAckLevelsResponse, error) {
+
+	transferAckLevel, err := e.repairTransferAckLevel(ctx)
+	if err != nil {
+		return nil, err
+	}
+	timerAckLevel, err := e.repairTimerAckLevel(ctx)
+	if err != nil {
+		return nil, err
+	}
+	replicationAckLevel, err := e.repairReplicationAckLevel(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if request.GetApply() {
+		if err := e.shard.RepairAckLevels(
+			transferAckLevel.GetRepairedAckLevel(),
+			time.Unix(0, timerAckLevel.GetRepairedAckLevel()),
+			replicationAckLevel.GetRepairedAckLevel(),
+		); err != nil {
+			return nil, err
+		}
+	}
+	return &historyservice.RepairShardAckLevelsResponse{
+		Queues: []*adminservice.ShardQueueAckLevel{transferAckLevel, timerAckLevel, replicationAckLevel},
+	}, nil
+}
+
+func (e *historyEngineImpl) repairTransferAckLevel(
+	ctx context.Context,
+) (*adminservice.ShardQueueAckLevel, error) {
+
+	maxReadLevel := e.shard.GetTransferMaxReadLevel()
+	var taskRange queueTaskRange
+	request := &persistence.GetTransferTasksRequest{
+		ReadLevel:    0,
+		MaxReadLevel: maxReadLevel,
+		BatchSize:    e.config.TransferTaskBatchSize(),
+	}
+	for {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		response, err := e.executionManager.GetTransferTasks(request)
+		if err != nil {
+			return nil, err
+		}
+		for _, task := range response.Tasks {
+			taskRange.add(task.GetTaskId())
+		}
+		if len(response.NextPageToken) == 0 {
+			break
+		}
+		request.NextPageToken = response.NextPageToken
+	}
+
+	// the ack level is exclusive, so it sits right below the oldest pending task,
+	// or at the max read level when everything generated so far has been processed
+	repairedAckLevel := maxReadLevel
+	if taskRange.count > 0 {
+		repairedAckLevel = taskRange.minLevel - 1
+	}
+	ackLevel := e.shard.GetTransferAckLevel()
+	return taskRange.toAckLevel(transferQueueType, ackLevel, repairedAckLevel), nil
+}
+
+func (e *historyEngineImpl) repairTimerAckLevel(
+	ctx context.Context,
+) (*adminservice.ShardQueueAckLevel, error) {
+
+	var taskRange queueTaskRange
+	request := &persistence.GetTimerIndexTasksRequest{
+		MinTimestamp: time.Unix(0, 0),
+		MaxTimestamp: maximumTime,
+		BatchSize:    e.config.TimerTaskBatchSize(),
+	}
+	for {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		response, err := e.executionManager.GetTimerIndexTasks(request)
+		if err != nil {
+			return nil, err
+		}
+		for _, timer := range response.Timers {
+			visibilityTime, err := types.TimestampFromProto(timer.GetVisibilityTimestamp())
+			if err != nil {
+				return nil, err
+			}
+			taskRange.add(visibilityTime.UnixNano())
+		}
+		if len(response.NextPageToken) == 0 {
+			break
+		}
+		request.NextPageToken = response.NextPageToken
+	}
+
+	// the timer ack level is inclusive, so it sits at the oldest pending timer; without any
+	// pending timer it must not be ahead of the current time, or new timers would be skipped
+	ackLevel := e.shard.GetTimerAckLevel()
+	repairedAckLevel := ackLevel
+	if now := e.shard.GetTimeSource().Now(); repairedAckLevel.After(now) {
+		repairedAckLevel = now
+	}
+	if taskRange.count > 0 {
+		repairedAckLevel = time.Unix(0, taskRange.minLevel)
+	}
+	return taskRange.toAckLevel(timerQueueType, ackLevel.UnixNano(), repairedAckLevel.UnixNano()), nil
+}
+
+func (e *historyEngineImpl) repairReplicationAckLevel(
+	ctx context.Context,
+) (*adminservice.ShardQueueAckLevel, error) {
+
+	maxReadLevel := e.shard.GetTransferMaxReadLevel()
+	var taskRange queueTaskRange
+	request := &persistence.GetReplicationTasksRequest{
+		ReadLevel:    0,
+		MaxReadLevel: maxReadLevel,
+		BatchSize:    e.config.ReplicatorTaskBatchSize(),
+	}
+	for {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		response, err := e.executionManager.GetReplicationTasks(request)
+		if err != nil {
+			return nil, err
+		}
+		for _, task := range response.Tasks {
+			taskRange.add(task.GetTaskId())
+		}
+		if len(response.NextPageToken) == 0 {
+			break
+		}
+		request.NextPageToken = response.NextPageToken
+	}
+
+	repairedAckLevel := maxReadLevel
+	if taskRange.count > 0 {
+		repairedAckLevel = taskRange.minLevel - 1
+	}
+	ackLevel := e.shard.GetReplicatorAckLevel()
+	return taskRange.toAckLevel(replicationQueueType, ackLevel, repairedAckLevel), nil
+}
+
+func (e *historyEngineImpl) GetPendingChildren(
+	ctx context.Context,
+	namespaceUUID string,
+	execution executionpb.WorkflowExecution,
+) (_ []*persistence.ChildExecutionInfo, retError error) {
+
+	namespaceID, err := validateNamespaceUUID(namespaceUUID)
+	if err != nil {
+		return nil, err
+	}
+
+	context, release, err := e.historyCache.getOrCreateWorkflowExecution(ctx, namespaceID, execution)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { release(retError) }()
+
+	mutableState, err := context.loadWorkflowExecution()
+	if err != nil {
+		return nil, err
+	}
+
+	pendingChildren := make([]*persistence.ChildExecutionInfo, 0, len(mutableState.GetPendingChildExecutionInfos()))
+	for _, childInfo := range mutableState.GetPendingChildExecutionInfos() {
+		// copy so that loading the initiated event does not modify the cached mutable state
+		pendingChild := *childInfo
+		if pendingChild.InitiatedEvent == nil {
+			if pendingChild.InitiatedEvent, err = mutableState.GetChildExecutionInitiatedEvent(childInfo.InitiatedID); err != nil {
+				return nil, err
+			}
+		}
+		pendingChildren = append(pendingChildren, &pendingChild)
+	}
+	sort.Slice(pendingChildren, func(i, j int) bool {
+		return pendingChildren[i].InitiatedID < pendingChildren[j].InitiatedID
+	})
+	return pendingChildren, nil
+}
+
+// ListTimers returns the user timers held by the mutable state of an execution, ordered by fire time
+func (e *historyEngineImpl) ListTimers(
+	ctx context.Context,
+	namespaceUUID string,
+	execution executionpb.WorkflowExecution,
+) (_ []*TimerInfo, retError error) {
+
+	namespaceID, err := validateNamespaceUUID(namespaceUUID)
+	if err != nil {
+		return nil, err
+	}
+
+	context, release, err := e.historyCache.getOrCreateWorkflowExecution(ctx, namespaceID, execution)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { release(retError) }()
+
+	mutableState, err := context.loadWorkflowExecution()
+	if err != nil {
+		return nil, err
+	}
+
+	timers := make([]*TimerInfo, 0, len(mutableState.GetPendingTimerInfos()))
+	for _, timerInfo := range mutableState.GetPendingTimerInfos() {
+		timers = append(timers, &TimerInfo{
+			TimerInfo: timerInfo,
+			Orphaned:  isOrphanedTimer(mutableState, timerInfo),
+		})
+	}
+	sort.Slice(timers, func(i, j int) bool {
+		if cmp := timers[i].ExpiryTime.Compare(timers[j].ExpiryTime); cmp != 0 {
+			return cmp < 0
+		}
+		return timers[i].TimerId < timers[j].TimerId
+	})
+	return timers, nil
+}
+
+// DryRunReplay replays the history of an execution into a new mutable state held in memory and returns the
+// summary of the replayed state. Nothing is written, so comparing the summary with the one of the persisted
+// mutable state verifies that mutable state matches history. The workflow lock is only held while the branch
+// to replay is read, so replaying a long history does not block the execution.
+func (e *historyEngineImpl) DryRunReplay(
+	ctx context.Context,
+	namespaceUUID string,
+	execution executionpb.WorkflowExecution,
+) (*WorkflowStateSummary, error) {
+
+	namespaceID, err := validateNamespaceUUID(namespaceUUID)
+	if err != nil {
+		return nil, err
+	}
+	namespaceEntry, err := e.shard.GetNamespaceCache().GetNamespaceByID(namespaceID)
+	if err != nil {
+		return nil, err
+	}
+
+	var branchToken []byte
+	var nextEventID int64
+	var hasVersionHistories, hasReplicationState bool
+	if err := func() (retError error) {
+		context, release, err := e.historyCache.getOrCreateWorkflowExecution(ctx, namespaceID, execution)
+		if err != nil {
+			return err
+		}
+		defer func() { release(retError) }()
+
+		mutableState, err := context.loadWorkflowExecution()
+		if err != nil {
+			return err
+		}
+		branchToken, err = mutableState.GetCurrentBranchToken()
+		if err != nil {
+			return err
+		}
+		nextEventID = mutableState.GetNextEventID()
+		execution.RunId = mutableState.GetExecutionInfo().RunID
+		hasVersionHistories = mutableState.GetVersionHistories() != nil
+		hasReplicationState = mutableState.GetReplicationState() != nil
+		return nil
+	}(); err != nil {
+		return nil, err
+	}
+
+	// the replayed mutable state is of the same kind as the persisted one
+	var replayedMutableState *mutableStateBuilder
+	switch {
+	case hasVersionHistories:
+		replayedMutableState = newMutableStateBuilderWithVersionHistories(e.shard, e.shard.GetEventsCache(), e.logger, namespaceEntry)
+	case hasReplicationState:
+		replayedMutableState = newMutableStateBuilderWithReplicationState(e.shard, e.shard.GetEventsCache(), e.logger, namespaceEntry)
+	default:
+		replayedMutableState = newMutableStateBuilder(e.shard, e.shard.GetEventsCache(), e.logger, namespaceEntry)
+	}
+	stateBuilder := newStateBuilder(
+		e.shard,
+		e.logger,
+		replayedMutableState,
+		func(mutableState mutableState) mutableStateTaskGenerator {
+			return newMutableStateTaskGenerator(e.shard.GetNamespaceCache(), e.logger, mutableState)
+		},
+	)
+
+	requestID := uuid.New()
+	var token []byte
+	for {
+		_, historyBatches, nextToken, _, err := PaginateHistory(
+			e.historyV2Mgr,
+			true,
+			branchToken,
+			common.FirstEventID,
+			nextEventID,
+			token,
+			nDCDefaultPageSize,
+			common.IntPtr(e.shard.GetShardID()),
+		)
+		if err != nil {
+			return nil, err
+		}
+		for _, history := range historyBatches {
+			if _, err := stateBuilder.applyEvents(
+				namespaceID,
+				requestID,
+				execution,
+				history.Events,
+				nil, // the new run of a continued as new workflow is not replayed
+				hasVersionHistories,
+			); err != nil {
+				return nil, err
+			}
+		}
+		if len(nextToken) == 0 {
+			break
+		}
+		token = nextToken
+	}
+
+	if replayedMutableState.GetNextEventID() != nextEventID {
+		return nil, serviceerror.NewInternal(fmt.Sprintf(
+			"Replayed history up to next event ID %v, mutable state next event ID is %v.",
+			replayedMutableState.GetNextEventID(),
+			nextEventID,
+		))
+	}
+	return newWorkflowStateSummary(replayedMutableState), nil
+}
+
+func newWorkflowStateSummary(
+	mutableState mutableState,
+) *WorkflowStateSummary {
+
+	summary := &WorkflowStateSummary{
+		NextEventID:      mutableState.GetNextEventID(),
+		SearchAttributes: mutableState.GetExecutionInfo().SearchAttributes,
+	}
+	for _, ai := range mutableState.GetPendingActivityInfos() {
+		summary.PendingActivities = append(summary.PendingActivities, ActivitySummary{
+			ScheduleID: ai.ScheduleID,
+			StartedID:  ai.StartedID,
+			ActivityID: ai.ActivityID,
+		})
+	}
+	sort.Slice(summary.PendingActivities, func(i, j int) bool {
+		return summary.PendingActivities[i].ScheduleID < summary.PendingActivities[j].ScheduleID
+	})
+	for _, ti := range mutableState.GetPendingTimerInfos() {
+		summary.PendingTimers = append(summary.PendingTimers, TimerSummary{
+			StartedID:  ti.StartedId,
+			TimerID:    ti.TimerId,
+			ExpiryTime: ti.ExpiryTime,
+		})
+	}
+	sort.Slice(summary.PendingTimers, func(i, j int) bool {
+		return summary.PendingTimers[i].StartedID < summary.PendingTimers[j].StartedID
+	})
+	for _, ci := range mutableState.GetPendingChildExecutionInfos() {
+		summary.PendingChildren = append(summary.PendingChildren, ChildSummary{
+			InitiatedID:      ci.InitiatedID,
+			StartedID:        ci.StartedID,
+			Namespace:        ci.Namespace,
+			WorkflowTypeName: ci.WorkflowTypeName,
+		})
+	}
+	sort.Slice(summary.PendingChildren, func(i, j int) bool {
+		return summary.PendingChildren[i].InitiatedID < summary.PendingChildren[j].InitiatedID
+	})
+	return summary
+}
+
+// CancelOrphanedTimer removes a user timer from the mutable state of an execution. Only orphaned timers can be
+// removed this way, timers backed by their started event have to be canceled by the workflow itself.
+func (e *historyEngineImpl) CancelOrphanedTimer(
+	ctx context.Context,
+	namespaceUUID string,
+	execution executionpb.WorkflowExecution,
+	timerID string,
+) error {
+
+	namespaceEntry, err := e.getActiveNamespaceEntry(namespaceUUID)
+	if err != nil {
+		return err
+	}
+	namespaceID := namespaceEntry.GetInfo().ID
+
+	return e.updateWorkflow(
+		ctx,
+		namespaceID,
+		execution,
+		func(context workflowExecutionContext, mutableState mutableState) (*updateWorkflowAction, error) {
+			if !mutableState.IsWorkflowExecutionRunning() {
+				return nil, ErrWorkflowCompleted
+			}
+
+			timerInfo, ok := mutableState.GetUserTimerInfo(timerID)
+			if !ok {
+				return nil, serviceerror.NewNotFound(fmt.Sprintf("Timer %v not found.", timerID))
+			}
+			if !isOrphanedTimer(mutableState, timerInfo) {
+				return nil, serviceerror.NewInvalidArgument(fmt.Sprintf("Timer %v is not orphaned.", timerID))
+			}
+			return updateWorkflowWithoutDecision, mutableState.DeleteUserTimer(timerID)
+		})
+}
+
+// isOrphanedTimer returns true if the timer started event the timer info refers to cannot be in history, or is
+// tracked for another timer
+func isOrphanedTimer(
+	mutableState mutableState,
+	timerInfo *persistenceblobs.TimerInfo,
+) bool {
+
+	startedID := timerInfo.GetStartedId()
+	if startedID <= common.FirstEventID || startedID >= mutableState.GetNextEventID() {
+		return true
+	}
+	backingTimer, ok := mutableState.GetUserTimerInfoByEventID(startedID)
+	return !ok || backingTimer.GetTimerId() != timerInfo.GetTimerId()
+}
+
+// TailWorkflowExecutionHistory sends the events of a workflow execution as they are written, starting after the
+// events already in history, until the workflow closes or the context is done
+func (e *historyEngineImpl) TailWorkflowExecutionHistory(
+	ctx context.Context,
+	request *historyservice.TailWorkflowExecutionHistoryRequest,
+	send func([]*eventpb.HistoryEvent) error,
+) error {
+
+	namespaceID, err := validateNamespaceUUID(request.GetNamespaceId())
+	if err != nil {
+		return err
+	}
+	execution := executionpb.WorkflowExecution{
+		WorkflowId: request.Execution.GetWorkflowId(),
+		RunId:      request.Execution.GetRunId(),
+	}
+	response, err := e.getMutableState(ctx, namespaceID, execution)
+	if err != nil {
+		return err
+	}
+	// tail the current run when no run ID is given
+	execution.RunId = response.Execution.RunId
+
+	identifier := definition.NewWorkflowIdentifier(namespaceID, execution.GetWorkflowId(), execution.GetRunId())
+	subscriberID, channel, err := e.historyEventNotifier.WatchHistoryEvent(identifier)
+	if err != nil {
+		return err
+	}
+	defer e.historyEventNotifier.UnwatchHistoryEvent(identifier, subscriberID) //nolint:errcheck
+
+	// check again in case events were written before the subscription
+	response, err = e.getMutableState(ctx, namespaceID, execution)
+	if err != nil {
+		return err
+	}
+	if !response.GetIsWorkflowRunning() {
+		return nil
+	}
+
+	nextEventID := response.GetNextEventId()
+	for {
+		select {
+		case event := <-channel:
+			if event.nextEventID > nextEventID {
+				events, err := e.readHistoryEvents(event.currentBranchToken, nextEventID, event.nextEventID)
+				if err != nil {
+					return err
+				}
+				if err := send(events); err != nil {
+					return err
+				}
+				nextEventID = event.nextEventID
+			}
+			if event.workflowState == persistence.WorkflowStateCompleted {
+				return nil
+			}
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+func (e *historyEngineImpl) readHistoryEvents(
+	branchToken []byte,
+	firstEventID int64,
+	nextEventID int64,
+) ([]*eventpb.HistoryEvent, error) {
+
+	var historyEvents []*eventpb.HistoryEvent
+	var token []byte
+	for {
+		events, _, nextToken, _, err := PaginateHistory(
+			e.historyV2Mgr,
+			false,
+			branchToken,
+			firstEventID,
+			nextEventID,
+			token,
+			defaultHistoryPageSize,
+			common.IntPtr(e.shard.GetShardID()),
+		)
+		if err != nil {
+			return nil, err
+		}
+		historyEvents = append(historyEvents, events...)
+		if len(nextToken) == 0 {
+			return historyEvents, nil
+		}
+		token = nextToken
+	}
+}
+
+func (e *historyEngineImpl) GetBufferedEventCount(
+	ctx context.Context,
+	namespaceUUID string,
+	execution executionpb.WorkflowExecution,
+) (_ int, retError error) {
+
+	namespaceID, err := validateNamespaceUUID(namespaceUUID)
+	if err != nil {
+		return 0, err
+	}
+
+	context, release, err := e.historyCache.getOrCreateWorkflowExecution(ctx, namespaceID, execution)
+	if err != nil {
+		return 0, err
+	}
+	defer func() { release(retError) }()
+
+	mutableState, err := context.loadWorkflowExecution()
+	if err != nil {
+		return 0, err
+	}
+	return mutableState.GetBufferedEventsCount(), nil
+}
+
+func (e *historyEngineImpl) DLQReplicationTask(
+	ctx context.Context,
+	request *historyservice.DLQReplicationTaskRequest,
+) error {
+
+	for _, replicationTaskProcessor := range e.replicationTaskProcessors {
+		if replicationTaskProcessor.getSourceCluster() == request.GetSourceCluster() {
+			return replicationTaskProcessor.dlqReplicationTask(ctx, request.GetTaskId(), request.GetReason())
+		}
+	}
+	return serviceerror.NewInvalidArgument(fmt.Sprintf("No replication task processor for source cluster %v.", request.GetSourceCluster()))
+}
+
 func (e *historyEngineImpl) RefreshWorkflowTasks(
 	ctx context.Context,
 	namespaceUUID string,
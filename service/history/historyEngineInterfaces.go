@@ -22,6 +22,8 @@ package history
 
 import (
 	"context"
+	"fmt"
+	"time"
 
 	"github.com/gogo/protobuf/types"
 
@@ -51,6 +53,7 @@ type (
 			ctx context.Context,
 			pollingCluster string,
 			lastReadTaskID int64,
+			minVersion int64,
 		) (*replicationgenpb.ReplicationMessages, error)
 		getTask(
 			ctx context.Context,
@@ -91,6 +94,7 @@ type (
 	queueTaskProcessor interface {
 		common.Daemon
 		StopShardProcessor(int)
+		SetShardConcurrency(int, int)
 		Submit(queueTask) error
 		TrySubmit(queueTask) (bool, error)
 	}
@@ -128,6 +132,7 @@ type (
 		common.Daemon
 		NotifyNewHistoryEvent(event *historyEventNotification)
 		WatchHistoryEvent(identifier definition.WorkflowIdentifier) (string, chan *historyEventNotification, error)
+		WatchHistoryEventWithTTL(identifier definition.WorkflowIdentifier, ttl time.Duration) (string, chan *historyEventNotification, error)
 		UnwatchHistoryEvent(identifier definition.WorkflowIdentifier, subscriberID string) error
 	}
 
@@ -139,3 +144,16 @@ const (
 	timerQueueType
 	replicationQueueType
 )
+
+func (q queueType) String() string {
+	switch q {
+	case transferQueueType:
+		return "transfer"
+	case timerQueueType:
+		return "timer"
+	case replicationQueueType:
+		return "replication"
+	default:
+		return fmt.Sprintf("unknown(%d)", int(q))
+	}
+}
@@ -391,6 +391,20 @@ func (mr *MockEngineMockRecorder) ScheduleDecisionTask(ctx, request interface{})
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ScheduleDecisionTask", reflect.TypeOf((*MockEngine)(nil).ScheduleDecisionTask), ctx, request)
 }
 
+// TerminateWorkflow mocks base method.
+func (m *MockEngine) TerminateWorkflow(ctx context.Context, namespaceID string, execution execution.WorkflowExecution, reason string, details []byte, identity string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TerminateWorkflow", ctx, namespaceID, execution, reason, details, identity)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// TerminateWorkflow indicates an expected call of TerminateWorkflow.
+func (mr *MockEngineMockRecorder) TerminateWorkflow(ctx, namespaceID, execution, reason, details, identity interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TerminateWorkflow", reflect.TypeOf((*MockEngine)(nil).TerminateWorkflow), ctx, namespaceID, execution, reason, details, identity)
+}
+
 // RecordChildExecutionCompleted mocks base method.
 func (m *MockEngine) RecordChildExecutionCompleted(ctx context.Context, request *historyservice.RecordChildExecutionCompletedRequest) error {
 	m.ctrl.T.Helper()
@@ -592,6 +606,138 @@ func (mr *MockEngineMockRecorder) RefreshWorkflowTasks(ctx, namespaceUUID, execu
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RefreshWorkflowTasks", reflect.TypeOf((*MockEngine)(nil).RefreshWorkflowTasks), ctx, namespaceUUID, execution)
 }
 
+// ResetReplicationAckLevel mocks base method.
+func (m *MockEngine) ResetReplicationAckLevel(ctx context.Context, request *historyservice.ResetReplicationAckLevelRequest) (*historyservice.ResetReplicationAckLevelResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ResetReplicationAckLevel", ctx, request)
+	ret0, _ := ret[0].(*historyservice.ResetReplicationAckLevelResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ResetReplicationAckLevel indicates an expected call of ResetReplicationAckLevel.
+func (mr *MockEngineMockRecorder) ResetReplicationAckLevel(ctx, request interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResetReplicationAckLevel", reflect.TypeOf((*MockEngine)(nil).ResetReplicationAckLevel), ctx, request)
+}
+
+// RepairShardAckLevels mocks base method.
+func (m *MockEngine) RepairShardAckLevels(ctx context.Context, request *historyservice.RepairShardAckLevelsRequest) (*historyservice.RepairShardAckLevelsResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RepairShardAckLevels", ctx, request)
+	ret0, _ := ret[0].(*historyservice.RepairShardAckLevelsResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RepairShardAckLevels indicates an expected call of RepairShardAckLevels.
+func (mr *MockEngineMockRecorder) RepairShardAckLevels(ctx, request interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RepairShardAckLevels", reflect.TypeOf((*MockEngine)(nil).RepairShardAckLevels), ctx, request)
+}
+
+// GetPendingChildren mocks base method.
+func (m *MockEngine) GetPendingChildren(ctx context.Context, namespaceID string, execution execution.WorkflowExecution) ([]*persistence.ChildExecutionInfo, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPendingChildren", ctx, namespaceID, execution)
+	ret0, _ := ret[0].([]*persistence.ChildExecutionInfo)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPendingChildren indicates an expected call of GetPendingChildren.
+func (mr *MockEngineMockRecorder) GetPendingChildren(ctx, namespaceID, execution interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPendingChildren", reflect.TypeOf((*MockEngine)(nil).GetPendingChildren), ctx, namespaceID, execution)
+}
+
+// GetBufferedEventCount mocks base method.
+func (m *MockEngine) GetBufferedEventCount(ctx context.Context, namespaceID string, execution execution.WorkflowExecution) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBufferedEventCount", ctx, namespaceID, execution)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBufferedEventCount indicates an expected call of GetBufferedEventCount.
+func (mr *MockEngineMockRecorder) GetBufferedEventCount(ctx, namespaceID, execution interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBufferedEventCount", reflect.TypeOf((*MockEngine)(nil).GetBufferedEventCount), ctx, namespaceID, execution)
+}
+
+// ListTimers mocks base method.
+func (m *MockEngine) ListTimers(ctx context.Context, namespaceID string, execution execution.WorkflowExecution) ([]*TimerInfo, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListTimers", ctx, namespaceID, execution)
+	ret0, _ := ret[0].([]*TimerInfo)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListTimers indicates an expected call of ListTimers.
+func (mr *MockEngineMockRecorder) ListTimers(ctx, namespaceID, execution interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListTimers", reflect.TypeOf((*MockEngine)(nil).ListTimers), ctx, namespaceID, execution)
+}
+
+// DryRunReplay mocks base method.
+func (m *MockEngine) DryRunReplay(ctx context.Context, namespaceID string, execution execution.WorkflowExecution) (*WorkflowStateSummary, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DryRunReplay", ctx, namespaceID, execution)
+	ret0, _ := ret[0].(*WorkflowStateSummary)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DryRunReplay indicates an expected call of DryRunReplay.
+func (mr *MockEngineMockRecorder) DryRunReplay(ctx, namespaceID, execution interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DryRunReplay", reflect.TypeOf((*MockEngine)(nil).DryRunReplay), ctx, namespaceID, execution)
+}
+
+// CancelOrphanedTimer mocks base method.
+func (m *MockEngine) CancelOrphanedTimer(ctx context.Context, namespaceID string, execution execution.WorkflowExecution, timerID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CancelOrphanedTimer", ctx, namespaceID, execution, timerID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CancelOrphanedTimer indicates an expected call of CancelOrphanedTimer.
+func (mr *MockEngineMockRecorder) CancelOrphanedTimer(ctx, namespaceID, execution, timerID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CancelOrphanedTimer", reflect.TypeOf((*MockEngine)(nil).CancelOrphanedTimer), ctx, namespaceID, execution, timerID)
+}
+
+// DLQReplicationTask mocks base method.
+func (m *MockEngine) DLQReplicationTask(ctx context.Context, request *historyservice.DLQReplicationTaskRequest) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DLQReplicationTask", ctx, request)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DLQReplicationTask indicates an expected call of DLQReplicationTask.
+func (mr *MockEngineMockRecorder) DLQReplicationTask(ctx, request interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DLQReplicationTask", reflect.TypeOf((*MockEngine)(nil).DLQReplicationTask), ctx, request)
+}
+
+// TailWorkflowExecutionHistory mocks base method.
+func (m *MockEngine) TailWorkflowExecutionHistory(ctx context.Context, request *historyservice.TailWorkflowExecutionHistoryRequest, send func([]*event.HistoryEvent) error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TailWorkflowExecutionHistory", ctx, request, send)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// TailWorkflowExecutionHistory indicates an expected call of TailWorkflowExecutionHistory.
+func (mr *MockEngineMockRecorder) TailWorkflowExecutionHistory(ctx, request, send interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TailWorkflowExecutionHistory", reflect.TypeOf((*MockEngine)(nil).TailWorkflowExecutionHistory), ctx, request, send)
+}
+
 // NotifyNewHistoryEvent mocks base method.
 func (m *MockEngine) NotifyNewHistoryEvent(event *historyEventNotification) {
 	m.ctrl.T.Helper()
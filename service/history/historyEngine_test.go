@@ -28,6 +28,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/gogo/protobuf/types"
 	"github.com/golang/mock/gomock"
 	"github.com/pborman/uuid"
 	"github.com/stretchr/testify/mock"
@@ -43,6 +44,7 @@ import (
 	tasklistpb "go.temporal.io/temporal-proto/tasklist"
 	"go.temporal.io/temporal-proto/workflowservice"
 
+	"github.com/temporalio/temporal/.gen/proto/adminservice"
 	executiongenpb "github.com/temporalio/temporal/.gen/proto/execution"
 	"github.com/temporalio/temporal/.gen/proto/historyservice"
 	"github.com/temporalio/temporal/.gen/proto/historyservicemock"
@@ -55,6 +57,7 @@ import (
 	"github.com/temporalio/temporal/common/cache"
 	"github.com/temporalio/temporal/common/clock"
 	"github.com/temporalio/temporal/common/cluster"
+	"github.com/temporalio/temporal/common/definition"
 	"github.com/temporalio/temporal/common/headers"
 	"github.com/temporalio/temporal/common/log"
 	"github.com/temporalio/temporal/common/log/loggerimpl"
@@ -493,6 +496,158 @@ func (s *engineSuite) TestGetMutableStateLongPollTimeout() {
 	s.Equal(int64(4), response.GetNextEventId())
 }
 
+func (s *engineSuite) TestTailWorkflowExecutionHistory() {
+	execution := executionpb.WorkflowExecution{
+		WorkflowId: "test-tail-workflow-execution-history",
+		RunId:      testRunID,
+	}
+	tasklist := "testTaskList"
+	identity := "testIdentity"
+
+	msBuilder := newMutableStateBuilderWithEventV2(
+		s.mockHistoryEngine.shard,
+		s.eventsCache,
+		loggerimpl.NewDevelopmentForTest(s.Suite),
+		execution.GetRunId())
+	addWorkflowExecutionStartedEvent(msBuilder, execution, "wType", tasklist, []byte("input"), 100, 200, identity)
+	di := addDecisionTaskScheduledEvent(msBuilder)
+	addDecisionTaskStartedEvent(msBuilder, di.ScheduleID, tasklist, identity)
+	ms := createMutableState(msBuilder)
+	gweResponse := &persistence.GetWorkflowExecutionResponse{State: ms}
+	// right now the next event ID is 4
+	s.mockExecutionMgr.On("GetWorkflowExecution", mock.Anything).Return(gweResponse, nil).Once()
+
+	branchToken := []byte{1}
+	notifier := &testTailHistoryEventNotifier{
+		historyEventNotifier: s.mockHistoryEngine.historyEventNotifier,
+		channel:              make(chan *historyEventNotification, 2),
+	}
+	notifier.channel <- newHistoryEventNotification(testNamespaceID, &execution, int64(4), int64(6), int64(3), branchToken,
+		persistence.WorkflowStateRunning, executionpb.WorkflowExecutionStatusRunning)
+	notifier.channel <- newHistoryEventNotification(testNamespaceID, &execution, int64(6), int64(7), int64(3), branchToken,
+		persistence.WorkflowStateCompleted, executionpb.WorkflowExecutionStatusCompleted)
+	s.mockHistoryEngine.historyEventNotifier = notifier
+
+	s.mockHistoryV2Mgr.On("ReadHistoryBranch", mock.MatchedBy(func(request *persistence.ReadHistoryBranchRequest) bool {
+		return request.MinEventID == 4 && request.MaxEventID == 6
+	})).Return(&persistence.ReadHistoryBranchResponse{
+		HistoryEvents: []*eventpb.HistoryEvent{{EventId: 4}, {EventId: 5}},
+	}, nil).Once()
+	s.mockHistoryV2Mgr.On("ReadHistoryBranch", mock.MatchedBy(func(request *persistence.ReadHistoryBranchRequest) bool {
+		return request.MinEventID == 6 && request.MaxEventID == 7
+	})).Return(&persistence.ReadHistoryBranchResponse{
+		HistoryEvents: []*eventpb.HistoryEvent{{EventId: 6}},
+	}, nil).Once()
+
+	var eventIDs []int64
+	err := s.mockHistoryEngine.TailWorkflowExecutionHistory(context.Background(), &historyservice.TailWorkflowExecutionHistoryRequest{
+		NamespaceId: testNamespaceID,
+		Execution:   &execution,
+	}, func(events []*eventpb.HistoryEvent) error {
+		for _, event := range events {
+			eventIDs = append(eventIDs, event.GetEventId())
+		}
+		return nil
+	})
+	s.NoError(err)
+	s.Equal([]int64{4, 5, 6}, eventIDs)
+	s.True(notifier.unwatched)
+}
+
+func (s *engineSuite) prepareRepairShardAckLevels() time.Time {
+	timerAckLevel, err := types.TimestampProto(time.Unix(0, 0).Add(time.Hour))
+	s.NoError(err)
+	s.mockShard.transferMaxReadLevel = 200
+	s.mockShard.shardInfo.TransferAckLevel = 150
+	s.mockShard.shardInfo.ReplicationAckLevel = 5
+	s.mockShard.shardInfo.TimerAckLevel = timerAckLevel
+	s.mockShard.shardInfo.ClusterTransferAckLevel = map[string]int64{cluster.TestCurrentClusterName: 150}
+	s.mockShard.shardInfo.ClusterTimerAckLevel = map[string]*types.Timestamp{cluster.TestCurrentClusterName: timerAckLevel}
+
+	// transfer tasks come in two pages
+	s.mockExecutionMgr.On("GetTransferTasks", mock.MatchedBy(func(request *persistence.GetTransferTasksRequest) bool {
+		return request.MaxReadLevel == 200 && len(request.NextPageToken) == 0
+	})).Return(&persistence.GetTransferTasksResponse{
+		Tasks:         []*persistenceblobs.TransferTaskInfo{{TaskId: 101}, {TaskId: 120}},
+		NextPageToken: []byte{1},
+	}, nil).Once()
+	s.mockExecutionMgr.On("GetTransferTasks", mock.MatchedBy(func(request *persistence.GetTransferTasksRequest) bool {
+		return len(request.NextPageToken) != 0
+	})).Return(&persistence.GetTransferTasksResponse{
+		Tasks: []*persistenceblobs.TransferTaskInfo{{TaskId: 180}},
+	}, nil).Once()
+
+	oldestTimer := time.Unix(0, 0).Add(time.Minute)
+	oldestTimerProto, err := types.TimestampProto(oldestTimer)
+	s.NoError(err)
+	newestTimerProto, err := types.TimestampProto(oldestTimer.Add(time.Hour))
+	s.NoError(err)
+	s.mockExecutionMgr.On("GetTimerIndexTasks", mock.Anything).Return(&persistence.GetTimerIndexTasksResponse{
+		Timers: []*persistenceblobs.TimerTaskInfo{{VisibilityTimestamp: oldestTimerProto}, {VisibilityTimestamp: newestTimerProto}},
+	}, nil).Once()
+
+	// no replication tasks are pending
+	s.mockExecutionMgr.On("GetReplicationTasks", mock.Anything).Return(&persistence.GetReplicationTasksResponse{}, nil).Once()
+	return oldestTimer
+}
+
+func (s *engineSuite) TestRepairShardAckLevels_DryRun() {
+	oldestTimer := s.prepareRepairShardAckLevels()
+
+	resp, err := s.mockHistoryEngine.RepairShardAckLevels(context.Background(), &historyservice.RepairShardAckLevelsRequest{
+		ShardId: 0,
+	})
+	s.NoError(err)
+	s.Equal([]*adminservice.ShardQueueAckLevel{
+		{
+			QueueType:        transferQueueType.String(),
+			AckLevel:         150,
+			RepairedAckLevel: 100,
+			MinTaskLevel:     101,
+			MaxTaskLevel:     180,
+			TaskCount:        3,
+		},
+		{
+			QueueType:        timerQueueType.String(),
+			AckLevel:         time.Unix(0, 0).Add(time.Hour).UnixNano(),
+			RepairedAckLevel: oldestTimer.UnixNano(),
+			MinTaskLevel:     oldestTimer.UnixNano(),
+			MaxTaskLevel:     oldestTimer.Add(time.Hour).UnixNano(),
+			TaskCount:        2,
+		},
+		{
+			QueueType:        replicationQueueType.String(),
+			AckLevel:         5,
+			RepairedAckLevel: 200,
+		},
+	}, resp.GetQueues())
+
+	// nothing is written without apply
+	s.Equal(int64(150), s.mockShard.GetTransferAckLevel())
+	s.Equal(int64(5), s.mockShard.GetReplicatorAckLevel())
+	s.mockShardManager.AssertNotCalled(s.T(), "UpdateShard", mock.Anything)
+}
+
+func (s *engineSuite) TestRepairShardAckLevels_Apply() {
+	oldestTimer := s.prepareRepairShardAckLevels()
+	// the repaired levels are persisted at once, even though the shard info was just updated
+	s.mockShard.lastUpdated = time.Now()
+	s.mockShardManager.On("UpdateShard", mock.MatchedBy(func(request *persistence.UpdateShardRequest) bool {
+		return request.ShardInfo.GetTransferAckLevel() == 100 && request.ShardInfo.GetReplicationAckLevel() == 200
+	})).Return(nil).Once()
+
+	_, err := s.mockHistoryEngine.RepairShardAckLevels(context.Background(), &historyservice.RepairShardAckLevelsRequest{
+		ShardId: 0,
+		Apply:   true,
+	})
+	s.NoError(err)
+	s.Equal(int64(100), s.mockShard.GetTransferAckLevel())
+	s.Equal(int64(100), s.mockShard.GetTransferClusterAckLevel(cluster.TestCurrentClusterName))
+	s.True(oldestTimer.Equal(s.mockShard.GetTimerAckLevel()))
+	s.True(oldestTimer.Equal(s.mockShard.GetTimerClusterAckLevel(cluster.TestCurrentClusterName)))
+	s.Equal(int64(200), s.mockShard.GetReplicatorAckLevel())
+}
+
 func (s *engineSuite) TestQueryWorkflow_RejectBasedOnNotEnabled() {
 	s.mockHistoryEngine.config.EnableConsistentQueryByNamespace = dynamicconfig.GetBoolPropertyFnFilteredByNamespace(false)
 	request := &historyservice.QueryWorkflowRequest{
@@ -1944,6 +2099,60 @@ func (s *engineSuite) TestRespondDecisionTaskCompletedCompleteWorkflowSuccess()
 	s.False(executionBuilder.HasPendingDecision())
 }
 
+func (s *engineSuite) TestRespondDecisionTaskCompletedCancelWorkflowWithGracePeriod() {
+
+	we := executionpb.WorkflowExecution{
+		WorkflowId: "wId",
+		RunId:      testRunID,
+	}
+	tl := "testTaskList"
+	tt := &tokengenpb.Task{
+		WorkflowId: we.WorkflowId,
+		RunId:      primitives.MustParseUUID(we.RunId),
+		ScheduleId: 2,
+	}
+	taskToken, _ := tt.Marshal()
+	identity := "testIdentity"
+	s.mockHistoryEngine.config.WorkflowCancellationGracePeriod = dynamicconfig.GetDurationPropertyFnFilteredByNamespace(time.Minute)
+
+	msBuilder := newMutableStateBuilderWithEventV2(s.mockHistoryEngine.shard, s.eventsCache,
+		loggerimpl.NewDevelopmentForTest(s.Suite), we.GetRunId())
+	addWorkflowExecutionStartedEvent(msBuilder, we, "wType", tl, []byte("input"), 100, 200, identity)
+	di := addDecisionTaskScheduledEvent(msBuilder)
+	addDecisionTaskStartedEvent(msBuilder, di.ScheduleID, tl, identity)
+
+	decisions := []*decisionpb.Decision{{
+		DecisionType: decisionpb.DecisionTypeCancelWorkflowExecution,
+		Attributes: &decisionpb.Decision_CancelWorkflowExecutionDecisionAttributes{CancelWorkflowExecutionDecisionAttributes: &decisionpb.CancelWorkflowExecutionDecisionAttributes{
+			Details: []byte("details"),
+		}},
+	}}
+
+	ms := createMutableState(msBuilder)
+	gwmsResponse := &persistence.GetWorkflowExecutionResponse{State: ms}
+
+	s.mockExecutionMgr.On("GetWorkflowExecution", mock.Anything).Return(gwmsResponse, nil).Once()
+	s.mockHistoryV2Mgr.On("AppendHistoryNodes", mock.Anything).Return(&persistence.AppendHistoryNodesResponse{Size: 0}, nil).Once()
+	s.mockExecutionMgr.On("UpdateWorkflowExecution", mock.Anything).Return(&persistence.UpdateWorkflowExecutionResponse{MutableStateUpdateSessionStats: &persistence.MutableStateUpdateSessionStats{}}, nil).Once()
+
+	_, err := s.mockHistoryEngine.RespondDecisionTaskCompleted(context.Background(), &historyservice.RespondDecisionTaskCompletedRequest{
+		NamespaceId: testNamespaceID,
+		CompleteRequest: &workflowservice.RespondDecisionTaskCompletedRequest{
+			TaskToken: taskToken,
+			Decisions: decisions,
+			Identity:  identity,
+		},
+	})
+	s.Nil(err, s.printHistory(msBuilder))
+	executionBuilder := s.getBuilder(testNamespaceID, we)
+	s.Equal(int64(6), executionBuilder.GetExecutionInfo().NextEventID)
+	s.Equal(persistence.WorkflowStateRunning, executionBuilder.GetExecutionInfo().State)
+	s.Equal(int64(4), executionBuilder.GetExecutionInfo().CancelGracePeriodCompletedEventID)
+	s.Equal([]byte("details"), executionBuilder.GetExecutionInfo().CancelGracePeriodDetails)
+	s.False(executionBuilder.GetExecutionInfo().CancelGracePeriodExpiryTime.IsZero())
+	s.False(executionBuilder.HasPendingDecision())
+}
+
 func (s *engineSuite) TestRespondDecisionTaskCompletedFailWorkflowSuccess() {
 
 	we := executionpb.WorkflowExecution{
@@ -2240,6 +2449,329 @@ func (s *engineSuite) TestRespondDecisionTaskCompletedStartChildWorkflowWithTerm
 	s.EqualError(err, "RunID is not valid UUID.")
 }*/
 
+func (s *engineSuite) TestGetPendingChildren() {
+
+	we := executionpb.WorkflowExecution{
+		WorkflowId: "wId",
+		RunId:      testRunID,
+	}
+	tl := "testTaskList"
+	identity := "testIdentity"
+
+	msBuilder := newMutableStateBuilderWithEventV2(s.mockHistoryEngine.shard, s.eventsCache,
+		loggerimpl.NewDevelopmentForTest(s.Suite), we.GetRunId())
+	addWorkflowExecutionStartedEvent(msBuilder, we, "wType", tl, []byte("input"), 100, 200, identity)
+	di := addDecisionTaskScheduledEvent(msBuilder)
+	event := addDecisionTaskStartedEvent(msBuilder, di.ScheduleID, tl, identity)
+	event = addDecisionTaskCompletedEvent(msBuilder, di.ScheduleID, event.GetEventId(), nil, identity)
+
+	closePolicies := map[string]commonpb.ParentClosePolicy{
+		"child-abandon":        commonpb.ParentClosePolicyAbandon,
+		"child-terminate":      commonpb.ParentClosePolicyTerminate,
+		"child-request-cancel": commonpb.ParentClosePolicyRequestCancel,
+	}
+	for _, childWorkflowID := range []string{"child-abandon", "child-terminate", "child-request-cancel"} {
+		_, _, err := msBuilder.AddStartChildWorkflowExecutionInitiatedEvent(event.GetEventId(), uuid.New(),
+			&decisionpb.StartChildWorkflowExecutionDecisionAttributes{
+				Namespace:                           testChildNamespace,
+				WorkflowId:                          childWorkflowID,
+				WorkflowType:                        &commonpb.WorkflowType{Name: "child-wType"},
+				TaskList:                            &tasklistpb.TaskList{Name: tl},
+				ExecutionStartToCloseTimeoutSeconds: 100,
+				TaskStartToCloseTimeoutSeconds:      10,
+				ParentClosePolicy:                   closePolicies[childWorkflowID],
+			})
+		s.NoError(err)
+	}
+
+	ms := createMutableState(msBuilder)
+	gwmsResponse := &persistence.GetWorkflowExecutionResponse{State: ms}
+	s.mockExecutionMgr.On("GetWorkflowExecution", mock.Anything).Return(gwmsResponse, nil).Once()
+
+	pendingChildren, err := s.mockHistoryEngine.GetPendingChildren(context.Background(), testNamespaceID, we)
+	s.NoError(err)
+	s.Len(pendingChildren, len(closePolicies))
+	for i, childInfo := range pendingChildren {
+		if i > 0 {
+			s.True(pendingChildren[i-1].InitiatedID < childInfo.InitiatedID)
+		}
+		s.Equal(testChildNamespace, childInfo.Namespace)
+		s.Equal("child-wType", childInfo.WorkflowTypeName)
+		s.NotNil(childInfo.InitiatedEvent)
+		s.Equal(childInfo.InitiatedID, childInfo.InitiatedEvent.GetEventId())
+		s.Equal(closePolicies[childInfo.InitiatedEvent.GetStartChildWorkflowExecutionInitiatedEventAttributes().GetWorkflowId()], childInfo.ParentClosePolicy)
+	}
+}
+
+func (s *engineSuite) TestDryRunReplay() {
+
+	we := executionpb.WorkflowExecution{
+		WorkflowId: "wId",
+		RunId:      testRunID,
+	}
+	tl := "testTaskList"
+	identity := "testIdentity"
+
+	msBuilder := newMutableStateBuilderWithEventV2(s.mockHistoryEngine.shard, s.eventsCache,
+		loggerimpl.NewDevelopmentForTest(s.Suite), we.GetRunId())
+	addWorkflowExecutionStartedEvent(msBuilder, we, "wType", tl, []byte("input"), 100, 200, identity)
+	di := addDecisionTaskScheduledEvent(msBuilder)
+	event := addDecisionTaskStartedEvent(msBuilder, di.ScheduleID, tl, identity)
+	event = addDecisionTaskCompletedEvent(msBuilder, di.ScheduleID, event.GetEventId(), nil, identity)
+	addActivityTaskScheduledEvent(msBuilder, event.GetEventId(), "activity1", "activity_type1", tl, []byte("input1"), 100, 10, 5)
+	addTimerStartedEvent(msBuilder, event.GetEventId(), "timer1", 50)
+	addStartChildWorkflowExecutionInitiatedEvent(msBuilder, event.GetEventId(), uuid.New(), testNamespace, "child-wId",
+		"child-wType", tl, nil, 100, 10)
+	history := msBuilder.GetHistoryBuilder().history
+
+	ms := createMutableState(msBuilder)
+	gwmsResponse := &persistence.GetWorkflowExecutionResponse{State: ms}
+	s.mockExecutionMgr.On("GetWorkflowExecution", mock.Anything).Return(gwmsResponse, nil).Once()
+	// history is read in the batches it was written in, without holding the workflow lock
+	s.mockHistoryV2Mgr.On("ReadHistoryBranchByBatch", mock.Anything).Run(func(args mock.Arguments) {
+		lockCtx, cancel := context.WithTimeout(context.Background(), time.Second)
+		defer cancel()
+		_, release, err := s.mockHistoryEngine.historyCache.getOrCreateWorkflowExecution(lockCtx, testNamespaceID, we)
+		s.NoError(err)
+		release(nil)
+	}).Return(&persistence.ReadHistoryBranchByBatchResponse{
+		History: []*eventpb.History{
+			{Events: history[0:2]},
+			{Events: history[2:3]},
+			{Events: history[3:]},
+		},
+	}, nil).Once()
+
+	summary, err := s.mockHistoryEngine.DryRunReplay(context.Background(), testNamespaceID, we)
+	s.NoError(err)
+	s.Len(summary.PendingActivities, 1)
+	s.Len(summary.PendingTimers, 1)
+	s.Len(summary.PendingChildren, 1)
+
+	// the persisted mutable state is cached by the dry run, which did not write anything
+	weContext, release, err := s.mockHistoryEngine.historyCache.getOrCreateWorkflowExecutionForBackground(testNamespaceID, we)
+	s.NoError(err)
+	defer release(nil)
+	persistedMutableState, err := weContext.loadWorkflowExecution()
+	s.NoError(err)
+	s.Equal(newWorkflowStateSummary(persistedMutableState), summary)
+}
+
+func (s *engineSuite) TestResetReplicationAckLevel() {
+	clusterMetadata := cluster.NewMockMetadata(s.controller)
+	clusterMetadata.EXPECT().GetAllClusterInfo().Return(cluster.TestAllClusterInfo).AnyTimes()
+	s.mockHistoryEngine.clusterMetadata = clusterMetadata
+	s.mockShard.shardInfo.ClusterReplicationLevel = map[string]int64{cluster.TestAlternativeClusterName: 10}
+	s.mockShard.transferMaxReadLevel = 100
+	s.mockShardManager.On("UpdateShard", mock.Anything).Return(nil).Once()
+
+	resp, err := s.mockHistoryEngine.ResetReplicationAckLevel(context.Background(), &historyservice.ResetReplicationAckLevelRequest{
+		ShardId:     0,
+		ClusterName: cluster.TestAlternativeClusterName,
+	})
+	s.NoError(err)
+	s.Equal(int64(100), resp.GetAckLevel())
+	s.Equal(int64(100), s.mockShard.GetClusterReplicationLevel(cluster.TestAlternativeClusterName))
+}
+
+func (s *engineSuite) TestResetReplicationAckLevel_CurrentCluster() {
+	_, err := s.mockHistoryEngine.ResetReplicationAckLevel(context.Background(), &historyservice.ResetReplicationAckLevelRequest{
+		ShardId:     0,
+		ClusterName: cluster.TestCurrentClusterName,
+	})
+	s.IsType(&serviceerror.InvalidArgument{}, err)
+}
+
+func (s *engineSuite) TestResetReplicationAckLevel_UnknownCluster() {
+	// the suite runs a single cluster, so the alternative cluster is not known
+	_, err := s.mockHistoryEngine.ResetReplicationAckLevel(context.Background(), &historyservice.ResetReplicationAckLevelRequest{
+		ShardId:     0,
+		ClusterName: cluster.TestAlternativeClusterName,
+	})
+	s.IsType(&serviceerror.InvalidArgument{}, err)
+}
+
+func (s *engineSuite) TestListTimers_CancelOrphanedTimer() {
+
+	we := executionpb.WorkflowExecution{
+		WorkflowId: "wId",
+		RunId:      testRunID,
+	}
+	tl := "testTaskList"
+	identity := "testIdentity"
+
+	msBuilder := newMutableStateBuilderWithEventV2(s.mockHistoryEngine.shard, s.eventsCache,
+		loggerimpl.NewDevelopmentForTest(s.Suite), we.GetRunId())
+	addWorkflowExecutionStartedEvent(msBuilder, we, "wType", tl, []byte("input"), 100, 200, identity)
+	di := addDecisionTaskScheduledEvent(msBuilder)
+	event := addDecisionTaskStartedEvent(msBuilder, di.ScheduleID, tl, identity)
+	event = addDecisionTaskCompletedEvent(msBuilder, di.ScheduleID, event.GetEventId(), nil, identity)
+	longTimerEvent, _ := addTimerStartedEvent(msBuilder, event.GetEventId(), "timer-long", 50)
+	shortTimerEvent, _ := addTimerStartedEvent(msBuilder, event.GetEventId(), "timer-short", 10)
+
+	ms := createMutableState(msBuilder)
+	// the orphaned timer refers to a timer started event which was never written to history
+	orphanExpiryTime, err := types.TimestampProto(time.Unix(0, longTimerEvent.GetTimestamp()).Add(time.Hour))
+	s.NoError(err)
+	ms.TimerInfos["timer-orphan"] = &persistenceblobs.TimerInfo{
+		Version:    longTimerEvent.GetVersion(),
+		TimerId:    "timer-orphan",
+		ExpiryTime: orphanExpiryTime,
+		StartedId:  ms.ExecutionInfo.NextEventID + 10,
+	}
+	gwmsResponse := &persistence.GetWorkflowExecutionResponse{State: ms}
+	s.mockExecutionMgr.On("GetWorkflowExecution", mock.Anything).Return(gwmsResponse, nil).Once()
+
+	timers, err := s.mockHistoryEngine.ListTimers(context.Background(), testNamespaceID, we)
+	s.NoError(err)
+	s.Len(timers, 3)
+	expectedTimers := []struct {
+		timerID    string
+		startedID  int64
+		expiryTime time.Time
+		orphaned   bool
+	}{
+		{"timer-short", shortTimerEvent.GetEventId(), time.Unix(0, shortTimerEvent.GetTimestamp()).Add(10 * time.Second), false},
+		{"timer-long", longTimerEvent.GetEventId(), time.Unix(0, longTimerEvent.GetTimestamp()).Add(50 * time.Second), false},
+		{"timer-orphan", ms.ExecutionInfo.NextEventID + 10, time.Unix(0, longTimerEvent.GetTimestamp()).Add(time.Hour), true},
+	}
+	for i, expected := range expectedTimers {
+		s.Equal(expected.timerID, timers[i].TimerId)
+		s.Equal(expected.startedID, timers[i].StartedId)
+		expiryTime, err := types.TimestampFromProto(timers[i].ExpiryTime)
+		s.NoError(err)
+		s.True(expected.expiryTime.Equal(expiryTime))
+		s.Equal(expected.orphaned, timers[i].Orphaned)
+	}
+
+	s.mockExecutionMgr.On("UpdateWorkflowExecution", mock.MatchedBy(func(request *persistence.UpdateWorkflowExecutionRequest) bool {
+		deleteTimerInfos := request.UpdateWorkflowMutation.DeleteTimerInfos
+		return len(deleteTimerInfos) == 1 && deleteTimerInfos[0] == "timer-orphan"
+	})).Return(&persistence.UpdateWorkflowExecutionResponse{MutableStateUpdateSessionStats: &persistence.MutableStateUpdateSessionStats{}}, nil).Once()
+
+	err = s.mockHistoryEngine.CancelOrphanedTimer(context.Background(), testNamespaceID, we, "timer-orphan")
+	s.NoError(err)
+
+	executionBuilder := s.getBuilder(testNamespaceID, we)
+	s.Len(executionBuilder.GetPendingTimerInfos(), 2)
+	_, ok := executionBuilder.GetUserTimerInfo("timer-orphan")
+	s.False(ok)
+
+	err = s.mockHistoryEngine.CancelOrphanedTimer(context.Background(), testNamespaceID, we, "timer-short")
+	s.IsType(&serviceerror.InvalidArgument{}, err)
+}
+
+func (s *engineSuite) TestTerminateWorkflow() {
+
+	we := executionpb.WorkflowExecution{
+		WorkflowId: "wId",
+		RunId:      testRunID,
+	}
+	tl := "testTaskList"
+	identity := "testIdentity"
+
+	msBuilder := newMutableStateBuilderWithEventV2(s.mockHistoryEngine.shard, s.eventsCache,
+		loggerimpl.NewDevelopmentForTest(s.Suite), we.GetRunId())
+	addWorkflowExecutionStartedEvent(msBuilder, we, "wType", tl, []byte("input"), 100, 200, identity)
+	di := addDecisionTaskScheduledEvent(msBuilder)
+	event := addDecisionTaskStartedEvent(msBuilder, di.ScheduleID, tl, identity)
+	event = addDecisionTaskCompletedEvent(msBuilder, di.ScheduleID, event.GetEventId(), nil, identity)
+
+	closePolicies := map[string]commonpb.ParentClosePolicy{
+		"child-abandon":   commonpb.ParentClosePolicyAbandon,
+		"child-terminate": commonpb.ParentClosePolicyTerminate,
+	}
+	for childWorkflowID, closePolicy := range closePolicies {
+		_, _, err := msBuilder.AddStartChildWorkflowExecutionInitiatedEvent(event.GetEventId(), uuid.New(),
+			&decisionpb.StartChildWorkflowExecutionDecisionAttributes{
+				Namespace:                           testChildNamespace,
+				WorkflowId:                          childWorkflowID,
+				WorkflowType:                        &commonpb.WorkflowType{Name: "child-wType"},
+				TaskList:                            &tasklistpb.TaskList{Name: tl},
+				ExecutionStartToCloseTimeoutSeconds: 100,
+				TaskStartToCloseTimeoutSeconds:      10,
+				ParentClosePolicy:                   closePolicy,
+			})
+		s.NoError(err)
+	}
+
+	ms := createMutableState(msBuilder)
+	gwmsResponse := &persistence.GetWorkflowExecutionResponse{State: ms}
+	s.mockExecutionMgr.On("GetWorkflowExecution", mock.Anything).Return(gwmsResponse, nil).Once()
+	s.mockHistoryV2Mgr.On("AppendHistoryNodes", mock.MatchedBy(func(request *persistence.AppendHistoryNodesRequest) bool {
+		lastEvent := request.Events[len(request.Events)-1]
+		return lastEvent.GetEventType() == eventpb.EventTypeWorkflowExecutionTerminated &&
+			lastEvent.GetWorkflowExecutionTerminatedEventAttributes().GetReason() == "stuck workflow" &&
+			lastEvent.GetWorkflowExecutionTerminatedEventAttributes().GetIdentity() == "operator"
+	})).Return(&persistence.AppendHistoryNodesResponse{Size: 0}, nil).Once()
+	s.mockExecutionMgr.On("UpdateWorkflowExecution", mock.MatchedBy(func(request *persistence.UpdateWorkflowExecutionRequest) bool {
+		// pending children are left to the close execution task, which applies their parent close policy
+		for _, task := range request.UpdateWorkflowMutation.TransferTasks {
+			if _, ok := task.(*persistence.CloseExecutionTask); ok {
+				return true
+			}
+		}
+		return false
+	})).Return(&persistence.UpdateWorkflowExecutionResponse{MutableStateUpdateSessionStats: &persistence.MutableStateUpdateSessionStats{}}, nil).Once()
+
+	err := s.mockHistoryEngine.TerminateWorkflow(context.Background(), testNamespaceID, we, "stuck workflow", []byte("details"), "operator")
+	s.NoError(err)
+
+	executionBuilder := s.getBuilder(testNamespaceID, we)
+	s.False(executionBuilder.IsWorkflowExecutionRunning())
+	s.Equal(executionpb.WorkflowExecutionStatusTerminated, executionBuilder.GetExecutionInfo().Status)
+	s.Len(executionBuilder.GetPendingChildExecutionInfos(), len(closePolicies))
+	for _, childInfo := range executionBuilder.GetPendingChildExecutionInfos() {
+		s.Equal(closePolicies[childInfo.StartedWorkflowID], childInfo.ParentClosePolicy)
+	}
+}
+
+func (s *engineSuite) TestTerminateWorkflow_ReasonNotSet() {
+	we := executionpb.WorkflowExecution{
+		WorkflowId: "wId",
+		RunId:      testRunID,
+	}
+
+	err := s.mockHistoryEngine.TerminateWorkflow(context.Background(), testNamespaceID, we, "", nil, "operator")
+	s.IsType(&serviceerror.InvalidArgument{}, err)
+}
+
+func (s *engineSuite) TestGetBufferedEventCount() {
+
+	we := executionpb.WorkflowExecution{
+		WorkflowId: "wId",
+		RunId:      testRunID,
+	}
+	tl := "testTaskList"
+	identity := "testIdentity"
+
+	msBuilder := newMutableStateBuilderWithEventV2(s.mockHistoryEngine.shard, s.eventsCache,
+		loggerimpl.NewDevelopmentForTest(s.Suite), we.GetRunId())
+	addWorkflowExecutionStartedEvent(msBuilder, we, "wType", tl, []byte("input"), 100, 100, identity)
+	di := addDecisionTaskScheduledEvent(msBuilder)
+	decisionStartedEvent := addDecisionTaskStartedEvent(msBuilder, di.ScheduleID, tl, identity)
+	decisionCompletedEvent := addDecisionTaskCompletedEvent(msBuilder, di.ScheduleID,
+		decisionStartedEvent.EventId, nil, identity)
+	addTimerStartedEvent(msBuilder, decisionCompletedEvent.EventId, "t1", 10)
+	addTimerStartedEvent(msBuilder, decisionCompletedEvent.EventId, "t2", 10)
+	di2 := addDecisionTaskScheduledEvent(msBuilder)
+	addDecisionTaskStartedEvent(msBuilder, di2.ScheduleID, tl, identity)
+	// timers firing while a decision is in flight are buffered
+	addTimerFiredEvent(msBuilder, "t1")
+	addTimerFiredEvent(msBuilder, "t2")
+	_, _, err := msBuilder.CloseTransactionAsMutation(time.Now(), transactionPolicyActive)
+	s.Nil(err)
+
+	ms := createMutableState(msBuilder)
+	gwmsResponse := &persistence.GetWorkflowExecutionResponse{State: ms}
+	s.Len(gwmsResponse.State.BufferedEvents, 2)
+	s.mockExecutionMgr.On("GetWorkflowExecution", mock.Anything).Return(gwmsResponse, nil).Once()
+
+	count, err := s.mockHistoryEngine.GetBufferedEventCount(context.Background(), testNamespaceID, we)
+	s.NoError(err)
+	s.Equal(2, count)
+}
+
 func (s *engineSuite) TestRespondDecisionTaskCompletedSignalExternalWorkflowFailed_UnKnownNamespace() {
 
 	we := executionpb.WorkflowExecution{
@@ -5376,3 +5908,24 @@ func copyReplicationState(source *persistence.ReplicationState) *persistence.Rep
 		LastReplicationInfo: lastReplicationInfo,
 	}
 }
+
+// testTailHistoryEventNotifier hands out a channel fed by the test and records the unwatch of the subscription
+type testTailHistoryEventNotifier struct {
+	historyEventNotifier
+	channel   chan *historyEventNotification
+	unwatched bool
+}
+
+func (n *testTailHistoryEventNotifier) WatchHistoryEvent(
+	identifier definition.WorkflowIdentifier,
+) (string, chan *historyEventNotification, error) {
+	return "some random subscriber ID", n.channel, nil
+}
+
+func (n *testTailHistoryEventNotifier) UnwatchHistoryEvent(
+	identifier definition.WorkflowIdentifier,
+	subscriberID string,
+) error {
+	n.unwatched = true
+	return nil
+}
@@ -21,6 +21,7 @@
 package history
 
 import (
+	"math"
 	"sync/atomic"
 	"time"
 
@@ -37,6 +38,9 @@ import (
 
 const (
 	eventsChanSize = 1000
+
+	// historyEventSubscriptionNoTTL is the TTL of subscriptions which never expire
+	historyEventSubscriptionNoTTL = time.Duration(math.MaxInt64)
 )
 
 type (
@@ -51,6 +55,19 @@ type (
 		workflowStatus         executionpb.WorkflowExecutionStatus
 	}
 
+	// historyEventSubscriber is a subscription to the history events of a workflow, a subscription
+	// not read from within its TTL is idle and is unwatched by the notifier
+	historyEventSubscriber struct {
+		channel chan *historyEventNotification
+		ttl     time.Duration
+		// lastActive is when the subscription was created or last seen read from
+		lastActive time.Time
+		// unread is whether a notification was delivered to the channel and not yet seen read
+		unread bool
+		// expiryTimer checks whether the subscription is idle, nil for subscriptions without TTL
+		expiryTimer *time.Timer
+	}
+
 	historyEventNotifierImpl struct {
 		timeSource clock.TimeSource
 		metrics    metrics.Client
@@ -63,7 +80,7 @@ type (
 		// function which calculate the shard ID from given workflow ID
 		workflowIDToShardID func(string) int
 
-		// concurrent map with key workflowIdentifier, value map[string]chan *historyEventNotification.
+		// concurrent map with key workflowIdentifier, value map[string]*historyEventSubscriber.
 		// the reason for the second map being non thread safe:
 		// 1. expected number of subscriber per workflow is low, i.e. < 5
 		// 2. update to this map is already guarded by GetAndDo API provided by ConcurrentTxMap
@@ -128,20 +145,33 @@ func newHistoryEventNotifier(
 func (notifier *historyEventNotifierImpl) WatchHistoryEvent(
 	identifier definition.WorkflowIdentifier) (string, chan *historyEventNotification, error) {
 
+	return notifier.WatchHistoryEventWithTTL(identifier, historyEventSubscriptionNoTTL)
+}
+
+// WatchHistoryEventWithTTL watches the history events of the workflow like WatchHistoryEvent, except that
+// the subscription is unwatched by the notifier once it has not been read from within the TTL
+func (notifier *historyEventNotifierImpl) WatchHistoryEventWithTTL(
+	identifier definition.WorkflowIdentifier, ttl time.Duration) (string, chan *historyEventNotification, error) {
+
 	channel := make(chan *historyEventNotification, 1)
 	subscriberID := uuid.New()
-	subscribers := map[string]chan *historyEventNotification{
-		subscriberID: channel,
+	subscriber := &historyEventSubscriber{
+		channel:    channel,
+		ttl:        ttl,
+		lastActive: notifier.timeSource.Now(),
+	}
+	subscribers := map[string]*historyEventSubscriber{
+		subscriberID: subscriber,
 	}
 
 	_, _, err := notifier.eventsPubsubs.PutOrDo(identifier, subscribers, func(key interface{}, value interface{}) error {
-		subscribers := value.(map[string]chan *historyEventNotification)
+		subscribers := value.(map[string]*historyEventSubscriber)
 
 		if _, ok := subscribers[subscriberID]; ok {
 			// UUID collision
 			return serviceerror.NewInternal("Unable to watch on workflow execution.")
 		}
-		subscribers[subscriberID] = channel
+		subscribers[subscriberID] = subscriber
 		return nil
 	})
 
@@ -149,6 +179,16 @@ func (notifier *historyEventNotifierImpl) WatchHistoryEvent(
 		return "", nil, err
 	}
 
+	if ttl != historyEventSubscriptionNoTTL {
+		// the timer is set while holding the subscribers lock, so the expiry check always sees it
+		notifier.eventsPubsubs.GetAndDo(identifier, func(key interface{}, value interface{}) error { //nolint:errcheck
+			subscriber.expiryTimer = time.AfterFunc(ttl, func() {
+				notifier.expireIdleSubscription(identifier, subscriberID)
+			})
+			return nil
+		})
+	}
+
 	return subscriberID, channel, nil
 }
 
@@ -157,12 +197,15 @@ func (notifier *historyEventNotifierImpl) UnwatchHistoryEvent(
 
 	success := true
 	notifier.eventsPubsubs.RemoveIf(identifier, func(key interface{}, value interface{}) bool {
-		subscribers := value.(map[string]chan *historyEventNotification)
+		subscribers := value.(map[string]*historyEventSubscriber)
 
-		if _, ok := subscribers[subscriberID]; !ok {
+		if subscriber, ok := subscribers[subscriberID]; !ok {
 			// cannot find the subscribe ID, which means there is a bug
 			success = false
 		} else {
+			if subscriber.expiryTimer != nil {
+				subscriber.expiryTimer.Stop()
+			}
 			delete(subscribers, subscriberID)
 		}
 
@@ -177,17 +220,61 @@ func (notifier *historyEventNotifierImpl) UnwatchHistoryEvent(
 	return nil
 }
 
+// expireIdleSubscription unwatches the subscription if it is idle,
+// otherwise checks it again once its TTL since it was last active elapses
+func (notifier *historyEventNotifierImpl) expireIdleSubscription(
+	identifier definition.WorkflowIdentifier, subscriberID string) {
+
+	expired := false
+	notifier.eventsPubsubs.RemoveIf(identifier, func(key interface{}, value interface{}) bool {
+		subscribers := value.(map[string]*historyEventSubscriber)
+
+		subscriber, ok := subscribers[subscriberID]
+		if !ok {
+			// already unwatched
+			return len(subscribers) == 0
+		}
+
+		now := notifier.timeSource.Now()
+		subscriber.observeRead(now)
+		if idle := now.Sub(subscriber.lastActive); idle < subscriber.ttl {
+			subscriber.expiryTimer.Reset(subscriber.ttl - idle)
+			return false
+		}
+
+		expired = true
+		delete(subscribers, subscriberID)
+		return len(subscribers) == 0
+	})
+
+	if expired {
+		notifier.metrics.IncCounter(metrics.HistoryEventNotificationScope,
+			metrics.HistoryEventNotificationIdleExpiredCount)
+	}
+}
+
+// observeRead records the subscription as active if the last notification delivered to it was read
+func (subscriber *historyEventSubscriber) observeRead(now time.Time) {
+	if subscriber.unread && len(subscriber.channel) == 0 {
+		subscriber.unread = false
+		subscriber.lastActive = now
+	}
+}
+
 func (notifier *historyEventNotifierImpl) dispatchHistoryEventNotification(event *historyEventNotification) {
 	identifier := event.id
 
 	timer := notifier.metrics.StartTimer(metrics.HistoryEventNotificationScope, metrics.HistoryEventNotificationFanoutLatency)
 	defer timer.Stop()
 	notifier.eventsPubsubs.GetAndDo(identifier, func(key interface{}, value interface{}) error { //nolint:errcheck
-		subscribers := value.(map[string]chan *historyEventNotification)
+		subscribers := value.(map[string]*historyEventSubscriber)
 
-		for _, channel := range subscribers {
+		now := notifier.timeSource.Now()
+		for _, subscriber := range subscribers {
+			subscriber.observeRead(now)
 			select {
-			case channel <- event:
+			case subscriber.channel <- event:
+				subscriber.unread = true
 			default:
 				// in case the channel is already filled with message
 				// this should NOT happen, unless there is a bug or high load
@@ -152,3 +152,36 @@ func (s *historyEventNotifierSuite) TestMultipleSubscriberWatchingEvents() {
 	s.historyEventNotifier.NotifyNewHistoryEvent(historyEvent)
 	waitGroup.Wait()
 }
+
+func (s *historyEventNotifierSuite) TestIdleSubscriberExpires() {
+	metricsScope := tally.NewTestScope("test", nil)
+	s.historyEventNotifier.metrics = metrics.NewClient(metricsScope, metrics.History)
+	identifier := definition.NewWorkflowIdentifier("namespace ID", "workflow ID", "run ID")
+
+	subscriberID, _, err := s.historyEventNotifier.WatchHistoryEventWithTTL(identifier, 100*time.Millisecond)
+	s.Nil(err)
+	_, ok := s.historyEventNotifier.eventsPubsubs.Get(identifier)
+	s.True(ok)
+
+	s.Eventually(func() bool {
+		_, ok := s.historyEventNotifier.eventsPubsubs.Get(identifier)
+		return !ok
+	}, 2*time.Second, 10*time.Millisecond)
+
+	counter, ok := metricsScope.Snapshot().Counters()["test.history_event_notification_idle_expired_count+operation=HistoryEventNotification"]
+	s.True(ok)
+	s.Equal(int64(1), counter.Value())
+	s.Error(s.historyEventNotifier.UnwatchHistoryEvent(identifier, subscriberID))
+}
+
+func (s *historyEventNotifierSuite) TestSubscriberWithoutTTLDoesNotExpire() {
+	identifier := definition.NewWorkflowIdentifier("namespace ID", "workflow ID", "run ID")
+
+	subscriberID, _, err := s.historyEventNotifier.WatchHistoryEvent(identifier)
+	s.Nil(err)
+
+	time.Sleep(200 * time.Millisecond)
+	_, ok := s.historyEventNotifier.eventsPubsubs.Get(identifier)
+	s.True(ok)
+	s.Nil(s.historyEventNotifier.UnwatchHistoryEvent(identifier, subscriberID))
+}
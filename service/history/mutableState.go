@@ -104,6 +104,7 @@ type (
 		AddUpsertWorkflowSearchAttributesEvent(int64, *decisionpb.UpsertWorkflowSearchAttributesDecisionAttributes) (*eventpb.HistoryEvent, error)
 		AddWorkflowExecutionCancelRequestedEvent(string, *historyservice.RequestCancelWorkflowExecutionRequest) (*eventpb.HistoryEvent, error)
 		AddWorkflowExecutionCanceledEvent(int64, *decisionpb.CancelWorkflowExecutionDecisionAttributes) (*eventpb.HistoryEvent, error)
+		AddCancellationGracePeriod(int64, *decisionpb.CancelWorkflowExecutionDecisionAttributes, time.Duration) (*eventpb.HistoryEvent, error)
 		AddWorkflowExecutionSignaled(signalName string, input []byte, identity string) (*eventpb.HistoryEvent, error)
 		AddWorkflowExecutionStartedEvent(executionpb.WorkflowExecution, *historyservice.StartWorkflowExecutionRequest) (*eventpb.HistoryEvent, error)
 		AddWorkflowExecutionTerminatedEvent(firstEventID int64, reason string, details []byte, identity string) (*eventpb.HistoryEvent, error)
@@ -116,11 +117,13 @@ type (
 		CreateTransientDecisionEvents(di *decisionInfo, identity string) (*eventpb.HistoryEvent, *eventpb.HistoryEvent)
 		DeleteDecision()
 		DeleteSignalRequested(requestID string)
+		DeleteUserTimer(string) error
 		FailDecision(bool)
 		FlushBufferedEvents() error
 		GetActivityByActivityID(string) (*persistence.ActivityInfo, bool)
 		GetActivityInfo(int64) (*persistence.ActivityInfo, bool)
 		GetActivityScheduledEvent(int64) (*eventpb.HistoryEvent, error)
+		GetBufferedEventsCount() int
 		GetChildExecutionInfo(int64) (*persistence.ChildExecutionInfo, bool)
 		GetChildExecutionInitiatedEvent(int64) (*eventpb.HistoryEvent, error)
 		GetCompletionEvent() (*eventpb.HistoryEvent, error)
@@ -188,6 +191,7 @@ type (
 		ReplicateDecisionTaskTimedOutEvent(eventpb.TimeoutType) error
 		ReplicateExternalWorkflowExecutionCancelRequested(*eventpb.HistoryEvent) error
 		ReplicateExternalWorkflowExecutionSignaled(*eventpb.HistoryEvent) error
+		ReplicateMarkerRecordedEvent(*eventpb.HistoryEvent) error
 		ReplicateRequestCancelExternalWorkflowExecutionFailedEvent(*eventpb.HistoryEvent) error
 		ReplicateRequestCancelExternalWorkflowExecutionInitiatedEvent(int64, *eventpb.HistoryEvent, string) (*persistenceblobs.RequestCancelInfo, error)
 		ReplicateSignalExternalWorkflowExecutionFailedEvent(*eventpb.HistoryEvent) error
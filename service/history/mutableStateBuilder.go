@@ -23,6 +23,7 @@ package history
 import (
 	"encoding/json"
 	"fmt"
+	"math"
 	"math/rand"
 	"time"
 
@@ -1497,6 +1498,16 @@ func (e *mutableStateBuilder) GetInFlightDecision() (*decisionInfo, bool) {
 	return e.decisionTaskManager.GetInFlightDecision()
 }
 
+func (e *mutableStateBuilder) GetBufferedEventsCount() int {
+	count := len(e.bufferedEvents) + len(e.updateBufferedEvents)
+	for _, event := range e.hBuilder.history {
+		if event.GetEventId() == common.BufferedEventID {
+			count++
+		}
+	}
+	return count
+}
+
 func (e *mutableStateBuilder) HasBufferedEvents() bool {
 	if len(e.bufferedEvents) > 0 || len(e.updateBufferedEvents) > 0 {
 		return true
@@ -2702,6 +2713,34 @@ func (e *mutableStateBuilder) AddWorkflowExecutionCanceledEvent(
 	return event, nil
 }
 
+// AddCancellationGracePeriod defers a cancel workflow decision, the workflow is canceled with the
+// original decision attributes once the grace period is over
+func (e *mutableStateBuilder) AddCancellationGracePeriod(
+	decisionTaskCompletedEventID int64,
+	attributes *decisionpb.CancelWorkflowExecutionDecisionAttributes,
+	gracePeriod time.Duration,
+) (*eventpb.HistoryEvent, error) {
+
+	opTag := tag.WorkflowActionWorkflowCanceled
+	if err := e.checkMutability(opTag); err != nil {
+		return nil, err
+	}
+
+	// the pending cancellation is recorded in history, so it is replicated and rebuilt with the marker
+	expiryTime := e.timeSource.Now().Add(gracePeriod)
+	event := e.hBuilder.AddMarkerRecordedEvent(
+		decisionTaskCompletedEventID,
+		newCancellationGracePeriodMarker(attributes.GetDetails(), expiryTime),
+	)
+	if err := e.ReplicateMarkerRecordedEvent(event); err != nil {
+		return nil, err
+	}
+	if err := e.taskGenerator.generateCancellationGracePeriodTasks(expiryTime); err != nil {
+		return nil, err
+	}
+	return event, nil
+}
+
 func (e *mutableStateBuilder) ReplicateWorkflowExecutionCanceledEvent(
 	firstEventID int64,
 	event *eventpb.HistoryEvent,
@@ -2889,9 +2928,43 @@ func (e *mutableStateBuilder) ReplicateSignalExternalWorkflowExecutionInitiatedE
 
 	e.pendingSignalInfoIDs[initiatedEventID] = si
 	e.updateSignalInfos[si] = struct{}{}
+
+	// the control outlives the pending signal, so a decision signaling with it again can be detected
+	if key, ok := getSignalControlKey(attributes.Control); ok {
+		e.recordSignalControl(key, initiatedEventID, signalRequestID)
+	}
 	return si, nil
 }
 
+func (e *mutableStateBuilder) recordSignalControl(
+	key string,
+	initiatedEventID int64,
+	signalRequestID string,
+) {
+
+	if e.executionInfo.SignalControlIDs == nil {
+		e.executionInfo.SignalControlIDs = make(map[string]*persistenceblobs.SignalControlInfo)
+	}
+	e.executionInfo.SignalControlIDs[key] = &persistenceblobs.SignalControlInfo{
+		InitiatedId: initiatedEventID,
+		RequestId:   signalRequestID,
+	}
+
+	// forget the controls of the earliest signals, a decision re-emitting those is long past
+	maxSignalControlIDs := e.config.MaximumSignalControlIDs(e.GetNamespaceEntry().GetInfo().Name)
+	for maxSignalControlIDs > 0 && len(e.executionInfo.SignalControlIDs) > maxSignalControlIDs {
+		earliestKey := ""
+		earliestInitiatedID := int64(math.MaxInt64)
+		for key, info := range e.executionInfo.SignalControlIDs {
+			if info.GetInitiatedId() < earliestInitiatedID {
+				earliestKey = key
+				earliestInitiatedID = info.GetInitiatedId()
+			}
+		}
+		delete(e.executionInfo.SignalControlIDs, earliestKey)
+	}
+}
+
 func (e *mutableStateBuilder) AddUpsertWorkflowSearchAttributesEvent(
 	decisionCompletedEventID int64,
 	request *decisionpb.UpsertWorkflowSearchAttributesDecisionAttributes,
@@ -3189,7 +3262,39 @@ func (e *mutableStateBuilder) AddRecordMarkerEvent(
 		return nil, err
 	}
 
-	return e.hBuilder.AddMarkerRecordedEvent(decisionCompletedEventID, attributes), nil
+	event := e.hBuilder.AddMarkerRecordedEvent(decisionCompletedEventID, attributes)
+	if err := e.ReplicateMarkerRecordedEvent(event); err != nil {
+		return nil, err
+	}
+	return event, nil
+}
+
+func (e *mutableStateBuilder) ReplicateMarkerRecordedEvent(
+	event *eventpb.HistoryEvent,
+) error {
+
+	attributes := event.GetMarkerRecordedEventAttributes()
+	if attributes.GetMarkerName() == cancellationGracePeriodMarkerName {
+		expiryTime, err := getCancellationGracePeriodExpiry(attributes)
+		if err != nil {
+			return err
+		}
+		e.executionInfo.CancelGracePeriodExpiryTime = expiryTime
+		e.executionInfo.CancelGracePeriodCompletedEventID = attributes.GetDecisionTaskCompletedEventId()
+		e.executionInfo.CancelGracePeriodDetails = attributes.GetDetails()
+		return nil
+	}
+
+	key, ok := getMarkerChecksumKey(attributes.GetMarkerName(), attributes.GetHeader())
+	if !ok {
+		return nil
+	}
+
+	if e.executionInfo.MarkerChecksums == nil {
+		e.executionInfo.MarkerChecksums = make(map[string][]byte)
+	}
+	e.executionInfo.MarkerChecksums[key] = getMarkerDetailsChecksum(attributes.GetDetails())
+	return nil
 }
 
 func (e *mutableStateBuilder) AddWorkflowExecutionTerminatedEvent(
@@ -34,6 +34,7 @@ import (
 	decisionpb "go.temporal.io/temporal-proto/decision"
 	eventpb "go.temporal.io/temporal-proto/event"
 	executionpb "go.temporal.io/temporal-proto/execution"
+	"go.temporal.io/temporal-proto/serviceerror"
 	tasklistpb "go.temporal.io/temporal-proto/tasklist"
 
 	"github.com/temporalio/temporal/.gen/proto/persistenceblobs"
@@ -536,6 +537,56 @@ func (s *mutableStateSuite) TestMergeMapOfByteArray() {
 	s.Equal(2, len(resultMap))
 }
 
+func (s *mutableStateSuite) TestRecordSignalControl() {
+	s.mockShard.config.MaximumSignalControlIDs = func(namespace string) int { return 2 }
+
+	s.msBuilder.recordSignalControl("control 1", 5, "request 1")
+	s.msBuilder.recordSignalControl("control 2", 7, "request 2")
+	s.msBuilder.recordSignalControl("control 3", 9, "request 3")
+
+	// the control of the earliest signal is forgotten
+	s.Equal(map[string]*persistenceblobs.SignalControlInfo{
+		"control 2": {InitiatedId: 7, RequestId: "request 2"},
+		"control 3": {InitiatedId: 9, RequestId: "request 3"},
+	}, s.msBuilder.GetExecutionInfo().SignalControlIDs)
+
+	// signaling again with a recorded control keeps its request ID current
+	s.msBuilder.recordSignalControl("control 2", 11, "request 2")
+	s.msBuilder.recordSignalControl("control 4", 13, "request 4")
+	s.Equal(map[string]*persistenceblobs.SignalControlInfo{
+		"control 2": {InitiatedId: 11, RequestId: "request 2"},
+		"control 4": {InitiatedId: 13, RequestId: "request 4"},
+	}, s.msBuilder.GetExecutionInfo().SignalControlIDs)
+}
+
+func (s *mutableStateSuite) TestReplicateMarkerRecordedEvent_CancellationGracePeriod() {
+	expiryTime := time.Unix(0, time.Now().Add(time.Minute).UnixNano())
+	details := []byte("some random cancellation details")
+	marker := newCancellationGracePeriodMarker(details, expiryTime)
+	event := &eventpb.HistoryEvent{
+		EventId:   5,
+		EventType: eventpb.EventTypeMarkerRecorded,
+		Attributes: &eventpb.HistoryEvent_MarkerRecordedEventAttributes{MarkerRecordedEventAttributes: &eventpb.MarkerRecordedEventAttributes{
+			MarkerName:                   marker.GetMarkerName(),
+			Details:                      marker.GetDetails(),
+			DecisionTaskCompletedEventId: 4,
+			Header:                       marker.GetHeader(),
+		}},
+	}
+
+	err := s.msBuilder.ReplicateMarkerRecordedEvent(event)
+	s.NoError(err)
+	executionInfo := s.msBuilder.GetExecutionInfo()
+	s.True(expiryTime.Equal(executionInfo.CancelGracePeriodExpiryTime))
+	s.Equal(int64(4), executionInfo.CancelGracePeriodCompletedEventID)
+	s.Equal(details, executionInfo.CancelGracePeriodDetails)
+	s.Empty(executionInfo.MarkerChecksums)
+
+	event.GetMarkerRecordedEventAttributes().Header = nil
+	err = s.msBuilder.ReplicateMarkerRecordedEvent(event)
+	s.IsType(&serviceerror.Internal{}, err)
+}
+
 func (s *mutableStateSuite) TestEventReapplied() {
 	runID := uuid.New()
 	eventID := int64(1)
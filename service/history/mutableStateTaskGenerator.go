@@ -86,6 +86,9 @@ type (
 		generateWorkflowResetTasks(
 			now time.Time,
 		) error
+		generateCancellationGracePeriodTasks(
+			expiryTime time.Time,
+		) error
 
 		// these 2 APIs should only be called when mutable state transaction is being closed
 		generateActivityTimerTasks(
@@ -495,6 +498,21 @@ func (r *mutableStateTaskGeneratorImpl) generateWorkflowResetTasks(
 	return nil
 }
 
+func (r *mutableStateTaskGeneratorImpl) generateCancellationGracePeriodTasks(
+	expiryTime time.Time,
+) error {
+
+	currentVersion := r.mutableState.GetCurrentVersion()
+
+	r.mutableState.AddTimerTasks(&persistence.CancellationGracePeriodTask{
+		// TaskID is set by shard
+		VisibilityTimestamp: expiryTime,
+		Version:             currentVersion,
+	})
+
+	return nil
+}
+
 func (r *mutableStateTaskGeneratorImpl) generateActivityTimerTasks(
 	now time.Time,
 ) error {
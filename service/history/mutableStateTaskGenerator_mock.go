@@ -239,6 +239,20 @@ func (mr *MockmutableStateTaskGeneratorMockRecorder) generateWorkflowResetTasks(
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "generateWorkflowResetTasks", reflect.TypeOf((*MockmutableStateTaskGenerator)(nil).generateWorkflowResetTasks), now)
 }
 
+// generateCancellationGracePeriodTasks mocks base method.
+func (m *MockmutableStateTaskGenerator) generateCancellationGracePeriodTasks(expiryTime time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "generateCancellationGracePeriodTasks", expiryTime)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// generateCancellationGracePeriodTasks indicates an expected call of generateCancellationGracePeriodTasks.
+func (mr *MockmutableStateTaskGeneratorMockRecorder) generateCancellationGracePeriodTasks(expiryTime interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "generateCancellationGracePeriodTasks", reflect.TypeOf((*MockmutableStateTaskGenerator)(nil).generateCancellationGracePeriodTasks), expiryTime)
+}
+
 // generateActivityTimerTasks mocks base method.
 func (m *MockmutableStateTaskGenerator) generateActivityTimerTasks(now time.Time) error {
 	m.ctrl.T.Helper()
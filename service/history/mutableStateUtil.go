@@ -21,6 +21,17 @@
 package history
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"strconv"
+	"time"
+
+	commonpb "go.temporal.io/temporal-proto/common"
+	decisionpb "go.temporal.io/temporal-proto/decision"
+	eventpb "go.temporal.io/temporal-proto/event"
+	"go.temporal.io/temporal-proto/serviceerror"
+
 	"github.com/temporalio/temporal/.gen/proto/persistenceblobs"
 	"github.com/temporalio/temporal/common/persistence"
 )
@@ -36,6 +47,78 @@ func (policy transactionPolicy) ptr() *transactionPolicy {
 	return &policy
 }
 
+// markerIDHeaderKey is the marker header field carrying the logical marker ID,
+// markers recorded with the same name and ID must carry the same details
+const markerIDHeaderKey = "MarkerId"
+
+const (
+	// cancellationGracePeriodMarkerName is the reserved marker recording a cancel workflow decision deferred
+	// by the cancellation grace period, the marker details are the details of the cancel decision
+	cancellationGracePeriodMarkerName = "temporal-internal-cancellation-grace-period"
+	// cancellationGracePeriodExpiryHeaderKey is the cancellation grace period marker header field carrying
+	// the time the grace period is over, in unix nanoseconds
+	cancellationGracePeriodExpiryHeaderKey = "ExpiryTime"
+)
+
+func newCancellationGracePeriodMarker(
+	details []byte,
+	expiryTime time.Time,
+) *decisionpb.RecordMarkerDecisionAttributes {
+
+	return &decisionpb.RecordMarkerDecisionAttributes{
+		MarkerName: cancellationGracePeriodMarkerName,
+		Details:    details,
+		Header: &commonpb.Header{Fields: map[string][]byte{
+			cancellationGracePeriodExpiryHeaderKey: []byte(strconv.FormatInt(expiryTime.UnixNano(), 10)),
+		}},
+	}
+}
+
+func getCancellationGracePeriodExpiry(
+	attributes *eventpb.MarkerRecordedEventAttributes,
+) (time.Time, error) {
+
+	value := attributes.GetHeader().GetFields()[cancellationGracePeriodExpiryHeaderKey]
+	expiryTimeNanos, err := strconv.ParseInt(string(value), 10, 64)
+	if err != nil {
+		return time.Time{}, serviceerror.NewInternal(fmt.Sprintf("Invalid cancellation grace period expiry time %q.", value))
+	}
+	return time.Unix(0, expiryTimeNanos), nil
+}
+
+func getMarkerChecksumKey(
+	markerName string,
+	header *commonpb.Header,
+) (string, bool) {
+
+	markerID, ok := header.GetFields()[markerIDHeaderKey]
+	if !ok || len(markerID) == 0 {
+		return "", false
+	}
+	return markerName + "/" + string(markerID), true
+}
+
+func getMarkerDetailsChecksum(
+	details []byte,
+) []byte {
+
+	checksum := sha256.Sum256(details)
+	return checksum[:]
+}
+
+// getSignalControlKey returns the key the control of a signal external workflow decision is recorded
+// under, the control itself can be a large blob
+func getSignalControlKey(
+	control []byte,
+) (string, bool) {
+
+	if len(control) == 0 {
+		return "", false
+	}
+	checksum := sha256.Sum256(control)
+	return hex.EncodeToString(checksum[:]), true
+}
+
 // NOTE: do not use make(type, len(input))
 // since this will assume initial length being len(inputs)
 // always use make(type, 0, len(input))
@@ -700,6 +700,21 @@ func (mr *MockmutableStateMockRecorder) AddWorkflowExecutionCancelRequestedEvent
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddWorkflowExecutionCancelRequestedEvent", reflect.TypeOf((*MockmutableState)(nil).AddWorkflowExecutionCancelRequestedEvent), arg0, arg1)
 }
 
+// AddCancellationGracePeriod mocks base method.
+func (m *MockmutableState) AddCancellationGracePeriod(arg0 int64, arg1 *decision.CancelWorkflowExecutionDecisionAttributes, arg2 time.Duration) (*event.HistoryEvent, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddCancellationGracePeriod", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*event.HistoryEvent)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddCancellationGracePeriod indicates an expected call of AddCancellationGracePeriod.
+func (mr *MockmutableStateMockRecorder) AddCancellationGracePeriod(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddCancellationGracePeriod", reflect.TypeOf((*MockmutableState)(nil).AddCancellationGracePeriod), arg0, arg1, arg2)
+}
+
 // AddWorkflowExecutionCanceledEvent mocks base method.
 func (m *MockmutableState) AddWorkflowExecutionCanceledEvent(arg0 int64, arg1 *decision.CancelWorkflowExecutionDecisionAttributes) (*event.HistoryEvent, error) {
 	m.ctrl.T.Helper()
@@ -882,6 +897,20 @@ func (mr *MockmutableStateMockRecorder) DeleteSignalRequested(requestID interfac
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteSignalRequested", reflect.TypeOf((*MockmutableState)(nil).DeleteSignalRequested), requestID)
 }
 
+// DeleteUserTimer mocks base method.
+func (m *MockmutableState) DeleteUserTimer(arg0 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteUserTimer", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteUserTimer indicates an expected call of DeleteUserTimer.
+func (mr *MockmutableStateMockRecorder) DeleteUserTimer(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteUserTimer", reflect.TypeOf((*MockmutableState)(nil).DeleteUserTimer), arg0)
+}
+
 // FailDecision mocks base method.
 func (m *MockmutableState) FailDecision(arg0 bool) {
 	m.ctrl.T.Helper()
@@ -953,6 +982,20 @@ func (mr *MockmutableStateMockRecorder) GetActivityScheduledEvent(arg0 interface
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetActivityScheduledEvent", reflect.TypeOf((*MockmutableState)(nil).GetActivityScheduledEvent), arg0)
 }
 
+// GetBufferedEventsCount mocks base method.
+func (m *MockmutableState) GetBufferedEventsCount() int {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBufferedEventsCount")
+	ret0, _ := ret[0].(int)
+	return ret0
+}
+
+// GetBufferedEventsCount indicates an expected call of GetBufferedEventsCount.
+func (mr *MockmutableStateMockRecorder) GetBufferedEventsCount() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBufferedEventsCount", reflect.TypeOf((*MockmutableState)(nil).GetBufferedEventsCount))
+}
+
 // GetChildExecutionInfo mocks base method.
 func (m *MockmutableState) GetChildExecutionInfo(arg0 int64) (*persistence.ChildExecutionInfo, bool) {
 	m.ctrl.T.Helper()
@@ -1907,6 +1950,20 @@ func (mr *MockmutableStateMockRecorder) ReplicateExternalWorkflowExecutionSignal
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReplicateExternalWorkflowExecutionSignaled", reflect.TypeOf((*MockmutableState)(nil).ReplicateExternalWorkflowExecutionSignaled), arg0)
 }
 
+// ReplicateMarkerRecordedEvent mocks base method.
+func (m *MockmutableState) ReplicateMarkerRecordedEvent(arg0 *event.HistoryEvent) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReplicateMarkerRecordedEvent", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ReplicateMarkerRecordedEvent indicates an expected call of ReplicateMarkerRecordedEvent.
+func (mr *MockmutableStateMockRecorder) ReplicateMarkerRecordedEvent(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReplicateMarkerRecordedEvent", reflect.TypeOf((*MockmutableState)(nil).ReplicateMarkerRecordedEvent), arg0)
+}
+
 // ReplicateRequestCancelExternalWorkflowExecutionFailedEvent mocks base method.
 func (m *MockmutableState) ReplicateRequestCancelExternalWorkflowExecutionFailedEvent(arg0 *event.HistoryEvent) error {
 	m.ctrl.T.Helper()
@@ -352,3 +352,71 @@ func (h *NilCheckHandler) RefreshWorkflowTasks(ctx context.Context, request *his
 	}
 	return resp, err
 }
+
+func (h *NilCheckHandler) ResetReplicationAckLevel(ctx context.Context, request *historyservice.ResetReplicationAckLevelRequest) (*historyservice.ResetReplicationAckLevelResponse, error) {
+	resp, err := h.parentHandler.ResetReplicationAckLevel(ctx, request)
+	if resp == nil && err == nil {
+		resp = &historyservice.ResetReplicationAckLevelResponse{}
+	}
+	return resp, err
+}
+
+func (h *NilCheckHandler) GetPendingChildren(ctx context.Context, request *historyservice.GetPendingChildrenRequest) (*historyservice.GetPendingChildrenResponse, error) {
+	resp, err := h.parentHandler.GetPendingChildren(ctx, request)
+	if resp == nil && err == nil {
+		resp = &historyservice.GetPendingChildrenResponse{}
+	}
+	return resp, err
+}
+
+func (h *NilCheckHandler) DLQReplicationTask(ctx context.Context, request *historyservice.DLQReplicationTaskRequest) (*historyservice.DLQReplicationTaskResponse, error) {
+	resp, err := h.parentHandler.DLQReplicationTask(ctx, request)
+	if resp == nil && err == nil {
+		resp = &historyservice.DLQReplicationTaskResponse{}
+	}
+	return resp, err
+}
+
+func (h *NilCheckHandler) RefreshNamespaceCache(ctx context.Context, request *historyservice.RefreshNamespaceCacheRequest) (*historyservice.RefreshNamespaceCacheResponse, error) {
+	resp, err := h.parentHandler.RefreshNamespaceCache(ctx, request)
+	if resp == nil && err == nil {
+		resp = &historyservice.RefreshNamespaceCacheResponse{}
+	}
+	return resp, err
+}
+
+func (h *NilCheckHandler) TailWorkflowExecutionHistory(request *historyservice.TailWorkflowExecutionHistoryRequest, stream historyservice.HistoryService_TailWorkflowExecutionHistoryServer) error {
+	return h.parentHandler.TailWorkflowExecutionHistory(request, stream)
+}
+
+func (h *NilCheckHandler) RepairShardAckLevels(ctx context.Context, request *historyservice.RepairShardAckLevelsRequest) (*historyservice.RepairShardAckLevelsResponse, error) {
+	resp, err := h.parentHandler.RepairShardAckLevels(ctx, request)
+	if resp == nil && err == nil {
+		resp = &historyservice.RepairShardAckLevelsResponse{}
+	}
+	return resp, err
+}
+
+func (h *NilCheckHandler) ForceTerminateWorkflowExecution(ctx context.Context, request *historyservice.ForceTerminateWorkflowExecutionRequest) (*historyservice.ForceTerminateWorkflowExecutionResponse, error) {
+	resp, err := h.parentHandler.ForceTerminateWorkflowExecution(ctx, request)
+	if resp == nil && err == nil {
+		resp = &historyservice.ForceTerminateWorkflowExecutionResponse{}
+	}
+	return resp, err
+}
+
+func (h *NilCheckHandler) ListWorkflowTimers(ctx context.Context, request *historyservice.ListWorkflowTimersRequest) (*historyservice.ListWorkflowTimersResponse, error) {
+	resp, err := h.parentHandler.ListWorkflowTimers(ctx, request)
+	if resp == nil && err == nil {
+		resp = &historyservice.ListWorkflowTimersResponse{}
+	}
+	return resp, err
+}
+
+func (h *NilCheckHandler) CancelOrphanedTimer(ctx context.Context, request *historyservice.CancelOrphanedTimerRequest) (*historyservice.CancelOrphanedTimerResponse, error) {
+	resp, err := h.parentHandler.CancelOrphanedTimer(ctx, request)
+	if resp == nil && err == nil {
+		resp = &historyservice.CancelOrphanedTimerResponse{}
+	}
+	return resp, err
+}
@@ -71,7 +71,9 @@ type (
 	transferQueueTask struct {
 		*queueTaskBase
 
-		ackMgr queueAckMgr
+		ackMgr                  queueAckMgr
+		decisionTaskDLQ         persistence.DecisionTaskDLQ
+		maxDecisionTaskAttempts dynamicconfig.IntPropertyFn
 	}
 )
 
@@ -111,6 +113,8 @@ func newTransferQueueTask(
 	timeSource clock.TimeSource,
 	maxRetryCount dynamicconfig.IntPropertyFn,
 	ackMgr queueAckMgr,
+	decisionTaskDLQ persistence.DecisionTaskDLQ,
+	maxDecisionTaskAttempts dynamicconfig.IntPropertyFn,
 ) queueTask {
 	return &transferQueueTask{
 		queueTaskBase: newQueueTaskBase(
@@ -123,7 +127,9 @@ func newTransferQueueTask(
 			timeSource,
 			maxRetryCount,
 		),
-		ackMgr: ackMgr,
+		ackMgr:                  ackMgr,
+		decisionTaskDLQ:         decisionTaskDLQ,
+		maxDecisionTaskAttempts: maxDecisionTaskAttempts,
 	}
 }
 
@@ -176,6 +182,17 @@ func (t *transferQueueTask) GetQueueType() queueType {
 	return transferQueueType
 }
 
+func (t *transferQueueTask) HandleErr(
+	err error,
+) error {
+
+	err = t.queueTaskBase.HandleErr(err)
+	if err != nil && isPoisonDecisionTask(t.queueTaskInfo, t.attempt, t.maxDecisionTaskAttempts(), err) {
+		return moveDecisionTaskToDLQ(t.decisionTaskDLQ, t.scope, t.logger, t.queueTaskInfo, t.attempt, err)
+	}
+	return err
+}
+
 func (t *queueTaskBase) Execute() error {
 	// TODO: after mergering active and standby queue,
 	// the task should be smart enough to tell if it should be
@@ -24,6 +24,7 @@ import (
 	"errors"
 	"fmt"
 	"math/rand"
+	"sync"
 	"testing"
 	"time"
 
@@ -36,6 +37,7 @@ import (
 	"github.com/temporalio/temporal/common/log"
 	"github.com/temporalio/temporal/common/log/loggerimpl"
 	"github.com/temporalio/temporal/common/metrics"
+	"github.com/temporalio/temporal/common/service/dynamicconfig"
 	"github.com/temporalio/temporal/common/task"
 )
 
@@ -159,6 +161,98 @@ func (s *queueTaskProcessorSuite) TestStopShardProcessor() {
 	s.Empty(s.processor.schedulers)
 }
 
+func (s *queueTaskProcessorSuite) TestStopShardProcessor_ResetShardConcurrency() {
+	shardID := 0
+
+	s.processor.SetShardConcurrency(shardID, 5)
+	s.Equal(5, s.processor.shardWorkerCounts[shardID])
+
+	s.processor.StopShardProcessor(shardID)
+	s.Empty(s.processor.shardWorkerCounts)
+}
+
+func (s *queueTaskProcessorSuite) TestSetShardConcurrency_Bounded() {
+	shardID := 0
+
+	mockScheduler := task.NewMockScheduler(s.controller)
+	maxShardWorkerCount := s.processor.options.maxShardWorkerCount()
+	mockScheduler.EXPECT().SetWorkerCount(maxShardWorkerCount).Times(1)
+	mockScheduler.EXPECT().SetWorkerCount(1).Times(1)
+	s.processor.schedulers[shardID] = mockScheduler
+
+	s.processor.SetShardConcurrency(shardID, maxShardWorkerCount+100)
+	s.Equal(maxShardWorkerCount, s.processor.shardWorkerCounts[shardID])
+
+	s.processor.SetShardConcurrency(shardID, 0)
+	s.Equal(1, s.processor.shardWorkerCounts[shardID])
+}
+
+func (s *queueTaskProcessorSuite) TestSetShardConcurrency() {
+	shardID := 0
+
+	var lock sync.Mutex
+	running := 0
+	maxRunning := 0
+	releaseCh := make(chan struct{})
+	var doneWG sync.WaitGroup
+	submitTasks := func(numTasks int) {
+		for i := 0; i != numTasks; i++ {
+			mockTask := NewMockqueueTask(s.controller)
+			mockTask.EXPECT().GetShardID().Return(shardID).Times(1)
+			mockTask.EXPECT().Execute().DoAndReturn(func() error {
+				lock.Lock()
+				running++
+				if running > maxRunning {
+					maxRunning = running
+				}
+				lock.Unlock()
+
+				<-releaseCh
+
+				lock.Lock()
+				running--
+				lock.Unlock()
+				return nil
+			}).Times(1)
+			mockTask.EXPECT().Ack().Do(func() { doneWG.Done() }).Times(1)
+			s.mockPriorityAssigner.EXPECT().Assign(newMockQueueTaskMatcher(mockTask)).Return(nil).Times(1)
+
+			doneWG.Add(1)
+			s.NoError(s.processor.Submit(mockTask))
+		}
+	}
+	getRunning := func() int {
+		lock.Lock()
+		defer lock.Unlock()
+		return running
+	}
+
+	s.processor.options.fifoSchedulerOptions.WorkerCount = 1
+	s.processor.Start()
+	defer s.processor.Stop()
+
+	// raise the concurrency and expect all tasks to be processed in parallel
+	s.processor.SetShardConcurrency(shardID, 5)
+	submitTasks(5)
+	s.Eventually(func() bool { return getRunning() == 5 }, time.Second, 10*time.Millisecond)
+	for i := 0; i != 5; i++ {
+		releaseCh <- struct{}{}
+	}
+	doneWG.Wait()
+
+	// lower the concurrency and expect no more than that number of tasks to be processed in parallel
+	maxRunning = 0
+	s.processor.SetShardConcurrency(shardID, 2)
+	submitTasks(5)
+	s.Eventually(func() bool { return getRunning() == 2 }, time.Second, 10*time.Millisecond)
+	time.Sleep(100 * time.Millisecond)
+	for i := 0; i != 5; i++ {
+		releaseCh <- struct{}{}
+	}
+	doneWG.Wait()
+	s.Equal(2, maxRunning)
+}
+
 func (s *queueTaskProcessorSuite) TestStop() {
 	for i := 0; i != 10; i++ {
 		shardID := rand.Int()
@@ -239,6 +333,8 @@ func (s *queueTaskProcessorSuite) TestNewQueueTaskProcessor_SchedulerOptionNotSp
 }
 
 func (s *queueTaskProcessorSuite) newTestQueueTaskProcessor() *queueTaskProcessorImpl {
+	config := NewDynamicConfigForTest()
+	config.QueueProcessorMaxShardWorkerCount = dynamicconfig.GetIntPropertyFn(20)
 	processor, err := newQueueTaskProcessor(
 		s.mockPriorityAssigner,
 		&queueTaskProcessorOptions{
@@ -248,6 +344,7 @@ func (s *queueTaskProcessorSuite) newTestQueueTaskProcessor() *queueTaskProcesso
 				WorkerCount: 10,
 				RetryPolicy: backoff.NewExponentialRetryPolicy(time.Millisecond),
 			},
+			maxShardWorkerCount: config.QueueProcessorMaxShardWorkerCount,
 		},
 		s.logger,
 		s.metricsClient,
@@ -28,6 +28,7 @@ import (
 	"github.com/temporalio/temporal/common"
 	"github.com/temporalio/temporal/common/log"
 	"github.com/temporalio/temporal/common/metrics"
+	"github.com/temporalio/temporal/common/service/dynamicconfig"
 	"github.com/temporalio/temporal/common/task"
 )
 
@@ -36,13 +37,15 @@ type (
 		schedulerType        task.SchedulerType
 		fifoSchedulerOptions *task.FIFOTaskSchedulerOptions
 		wRRSchedulerOptions  *task.WeightedRoundRobinTaskSchedulerOptions
+		maxShardWorkerCount  dynamicconfig.IntPropertyFn
 	}
 
 	queueTaskProcessorImpl struct {
 		sync.RWMutex
 
-		priorityAssigner taskPriorityAssigner
-		schedulers       map[int]task.Scheduler
+		priorityAssigner  taskPriorityAssigner
+		schedulers        map[int]task.Scheduler
+		shardWorkerCounts map[int]int
 
 		status        int32
 		options       *queueTaskProcessorOptions
@@ -79,12 +82,13 @@ func newQueueTaskProcessor(
 	}
 
 	return &queueTaskProcessorImpl{
-		priorityAssigner: priorityAssigner,
-		schedulers:       make(map[int]task.Scheduler),
-		status:           common.DaemonStatusInitialized,
-		options:          options,
-		logger:           logger,
-		metricsClient:    metricsClient,
+		priorityAssigner:  priorityAssigner,
+		schedulers:        make(map[int]task.Scheduler),
+		shardWorkerCounts: make(map[int]int),
+		status:            common.DaemonStatusInitialized,
+		options:           options,
+		logger:            logger,
+		metricsClient:     metricsClient,
 	}, nil
 }
 
@@ -108,6 +112,7 @@ func (p *queueTaskProcessorImpl) Stop() {
 		delete(p.schedulers, shardID)
 		scheduler.Stop()
 	}
+	p.shardWorkerCounts = make(map[int]int)
 
 	p.logger.Info("Queue task processor stopped.")
 }
@@ -116,6 +121,7 @@ func (p *queueTaskProcessorImpl) StopShardProcessor(
 	shardID int,
 ) {
 	p.Lock()
+	delete(p.shardWorkerCounts, shardID)
 	scheduler, ok := p.schedulers[shardID]
 	if !ok {
 		p.Unlock()
@@ -129,6 +135,29 @@ func (p *queueTaskProcessorImpl) StopShardProcessor(
 	scheduler.Stop()
 }
 
+// SetShardConcurrency overrides the number of workers processing tasks for the shard,
+// the override is bounded by the max shard worker count and is reset when the shard processor is stopped
+func (p *queueTaskProcessorImpl) SetShardConcurrency(
+	shardID int,
+	n int,
+) {
+	if n < 1 {
+		n = 1
+	}
+	if maxShardWorkerCount := p.options.maxShardWorkerCount(); maxShardWorkerCount > 0 && n > maxShardWorkerCount {
+		n = maxShardWorkerCount
+	}
+
+	p.Lock()
+	p.shardWorkerCounts[shardID] = n
+	scheduler, ok := p.schedulers[shardID]
+	p.Unlock()
+
+	if ok {
+		scheduler.SetWorkerCount(n)
+	}
+}
+
 func (p *queueTaskProcessorImpl) Submit(
 	task queueTask,
 ) error {
@@ -204,6 +233,9 @@ func (p *queueTaskProcessorImpl) getOrCreateTaskScheduler(
 		return nil, err
 	}
 
+	if workerCount, ok := p.shardWorkerCounts[shardID]; ok {
+		scheduler.SetWorkerCount(workerCount)
+	}
 	p.schedulers[shardID] = scheduler
 	p.Unlock()
 
@@ -31,6 +31,7 @@ import (
 	"github.com/uber-go/tally"
 	"go.temporal.io/temporal-proto/serviceerror"
 
+	"github.com/temporalio/temporal/.gen/proto/persistenceblobs"
 	"github.com/temporalio/temporal/common/cache"
 	"github.com/temporalio/temporal/common/clock"
 	"github.com/temporalio/temporal/common/log"
@@ -172,6 +173,36 @@ func (s *queueTaskSuite) TestHandleErr_UnknownErr() {
 	s.Equal(err, queueTaskBase.HandleErr(err))
 }
 
+func (s *queueTaskSuite) TestHandleErr_PoisonDecisionTask() {
+	transferTask := &persistenceblobs.TransferTaskInfo{
+		TaskId:   12345,
+		TaskType: persistence.TransferTaskTypeDecisionTask,
+	}
+	mockDecisionTaskDLQ := persistence.NewMockDecisionTaskDLQ(s.controller)
+	queueTask := newTransferQueueTask(
+		s.sharID,
+		transferTask,
+		s.scope,
+		s.logger,
+		func(task queueTaskInfo) (bool, error) { return true, nil },
+		s.mockQueueTaskExecutor,
+		s.timeSource,
+		s.maxRetryCount,
+		nil,
+		mockDecisionTaskDLQ,
+		dynamicconfig.GetIntPropertyFn(2),
+	)
+
+	// transient errors are retried past the max attempts
+	for i := 0; i < 3; i++ {
+		err := serviceerror.NewUnavailable("some random error")
+		s.Equal(err, queueTask.HandleErr(err))
+	}
+
+	mockDecisionTaskDLQ.EXPECT().Put(transferTask).Return(nil).Times(1)
+	s.NoError(queueTask.HandleErr(errors.New("some random error")))
+}
+
 func (s *queueTaskSuite) TestTaskState() {
 	queueTaskBase := s.newTestQueueTaskBase(func(task queueTaskInfo) (bool, error) {
 		return true, nil
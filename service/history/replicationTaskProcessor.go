@@ -27,6 +27,7 @@ import (
 	"fmt"
 	"math"
 	"strconv"
+	"sync"
 	"sync/atomic"
 	"time"
 
@@ -81,11 +82,22 @@ type (
 		requestChan   chan<- *request
 		syncShardChan chan *replicationgenpb.SyncShardStatus
 		done          chan struct{}
+
+		dlqRequestsLock sync.Mutex
+		dlqRequests     map[int64]*dlqRequest
 	}
 
 	// ReplicationTaskProcessor is responsible for processing replication tasks for a shard.
 	ReplicationTaskProcessor interface {
 		common.Daemon
+		getSourceCluster() string
+		dlqReplicationTask(ctx context.Context, taskID int64, reason string) error
+	}
+
+	// dlqRequest is a pending operator request to move a replication task to DLQ
+	dlqRequest struct {
+		reason   string
+		respChan chan error
 	}
 
 	request struct {
@@ -132,6 +144,7 @@ func NewReplicationTaskProcessor(
 		done:                    make(chan struct{}),
 		lastProcessedMessageID:  emptyMessageID,
 		lastRetrievedMessageID:  emptyMessageID,
+		dlqRequests:             make(map[int64]*dlqRequest),
 	}
 }
 
@@ -157,6 +170,63 @@ func (p *ReplicationTaskProcessorImpl) Stop() {
 	close(p.done)
 }
 
+func (p *ReplicationTaskProcessorImpl) getSourceCluster() string {
+	return p.sourceCluster
+}
+
+// dlqReplicationTask moves the replication task with the given source task ID to DLQ instead of applying it,
+// so that a task failing repeatedly does not block the queue. The call blocks until the task is fetched
+// again and put into DLQ, after which the ack level advances past it as usual.
+func (p *ReplicationTaskProcessorImpl) dlqReplicationTask(
+	ctx context.Context,
+	taskID int64,
+	reason string,
+) error {
+
+	if taskID <= 0 {
+		return serviceerror.NewInvalidArgument("Replication task ID must be positive.")
+	}
+
+	request := &dlqRequest{
+		reason:   reason,
+		respChan: make(chan error, 1),
+	}
+	p.dlqRequestsLock.Lock()
+	if _, ok := p.dlqRequests[taskID]; ok {
+		p.dlqRequestsLock.Unlock()
+		return serviceerror.NewInvalidArgument(fmt.Sprintf("Replication task %v is already requested to be put into DLQ.", taskID))
+	}
+	p.dlqRequests[taskID] = request
+	p.dlqRequestsLock.Unlock()
+
+	p.logger.Info("Replication task requested to be put into DLQ.", tag.TaskID(taskID), tag.DetailInfo(reason))
+
+	select {
+	case err := <-request.respChan:
+		return err
+	case <-ctx.Done():
+		p.removeDLQRequest(taskID)
+		return ctx.Err()
+	case <-p.done:
+		p.removeDLQRequest(taskID)
+		return serviceerror.NewUnavailable("Replication task processor is shutting down.")
+	}
+}
+
+func (p *ReplicationTaskProcessorImpl) getDLQRequest(taskID int64) *dlqRequest {
+	p.dlqRequestsLock.Lock()
+	defer p.dlqRequestsLock.Unlock()
+
+	return p.dlqRequests[taskID]
+}
+
+func (p *ReplicationTaskProcessorImpl) removeDLQRequest(taskID int64) {
+	p.dlqRequestsLock.Lock()
+	defer p.dlqRequestsLock.Unlock()
+
+	delete(p.dlqRequests, taskID)
+}
+
 func (p *ReplicationTaskProcessorImpl) processorLoop() {
 	p.lastProcessedMessageID = p.shard.GetClusterReplicationLevel(p.sourceCluster)
 
@@ -344,11 +414,23 @@ func (p *ReplicationTaskProcessorImpl) handleSyncShardStatus(
 }
 
 func (p *ReplicationTaskProcessorImpl) processSingleTask(replicationTask *replicationgenpb.ReplicationTask) error {
+	taskID := replicationTask.GetSourceTaskId()
+	if request := p.getDLQRequest(taskID); request != nil {
+		return p.handleDLQRequest(replicationTask, request)
+	}
+
 	err := backoff.Retry(func() error {
 		return p.processTaskOnce(replicationTask)
-	}, p.taskRetryPolicy, isTransientRetryableError)
+	}, p.taskRetryPolicy, func(err error) bool {
+		// stop retrying once the task is requested to be put into DLQ
+		return p.getDLQRequest(taskID) == nil && isTransientRetryableError(err)
+	})
 
 	if err != nil {
+		if request := p.getDLQRequest(taskID); request != nil {
+			return p.handleDLQRequest(replicationTask, request)
+		}
+
 		p.logger.Error(
 			"Failed to apply replication task after retry. Putting task into DLQ.",
 			tag.TaskID(replicationTask.GetSourceTaskId()),
@@ -361,6 +443,23 @@ func (p *ReplicationTaskProcessorImpl) processSingleTask(replicationTask *replic
 	return nil
 }
 
+func (p *ReplicationTaskProcessorImpl) handleDLQRequest(
+	replicationTask *replicationgenpb.ReplicationTask,
+	request *dlqRequest,
+) error {
+
+	p.logger.Warn(
+		"Putting replication task into DLQ as requested.",
+		tag.TaskID(replicationTask.GetSourceTaskId()),
+		tag.DetailInfo(request.reason),
+	)
+
+	err := p.putReplicationTaskToDLQ(replicationTask)
+	p.removeDLQRequest(replicationTask.GetSourceTaskId())
+	request.respChan <- err
+	return err
+}
+
 func (p *ReplicationTaskProcessorImpl) processTaskOnce(replicationTask *replicationgenpb.ReplicationTask) error {
 	scope, err := p.replicationTaskExecutor.execute(
 		p.sourceCluster,
@@ -28,6 +28,7 @@
 package history
 
 import (
+	context "context"
 	gomock "github.com/golang/mock/gomock"
 	reflect "reflect"
 )
@@ -78,3 +79,31 @@ func (mr *MockReplicationTaskProcessorMockRecorder) Stop() *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Stop", reflect.TypeOf((*MockReplicationTaskProcessor)(nil).Stop))
 }
+
+// dlqReplicationTask mocks base method.
+func (m *MockReplicationTaskProcessor) dlqReplicationTask(ctx context.Context, taskID int64, reason string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "dlqReplicationTask", ctx, taskID, reason)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// dlqReplicationTask indicates an expected call of dlqReplicationTask.
+func (mr *MockReplicationTaskProcessorMockRecorder) dlqReplicationTask(ctx, taskID, reason interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "dlqReplicationTask", reflect.TypeOf((*MockReplicationTaskProcessor)(nil).dlqReplicationTask), ctx, taskID, reason)
+}
+
+// getSourceCluster mocks base method.
+func (m *MockReplicationTaskProcessor) getSourceCluster() string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "getSourceCluster")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// getSourceCluster indicates an expected call of getSourceCluster.
+func (mr *MockReplicationTaskProcessorMockRecorder) getSourceCluster() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "getSourceCluster", reflect.TypeOf((*MockReplicationTaskProcessor)(nil).getSourceCluster))
+}
@@ -21,16 +21,19 @@
 package history
 
 import (
+	"context"
 	"testing"
 	"time"
 
 	"github.com/golang/mock/gomock"
 	"github.com/pborman/uuid"
+	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 	"github.com/stretchr/testify/suite"
 	"github.com/uber-go/tally"
 	commonpb "go.temporal.io/temporal-proto/common"
 	eventpb "go.temporal.io/temporal-proto/event"
+	"go.temporal.io/temporal-proto/serviceerror"
 
 	"github.com/temporalio/temporal/.gen/proto/adminservicemock"
 	"github.com/temporalio/temporal/.gen/proto/historyservice"
@@ -252,3 +255,117 @@ func (s *replicationTaskProcessorSuite) TestPutReplicationTaskToDLQ_HistoryV2Rep
 	err = s.replicationTaskProcessor.putReplicationTaskToDLQ(task)
 	s.NoError(err)
 }
+
+func (s *replicationTaskProcessorSuite) TestCleanupAckedReplicationTasks_AfterResetReplicationAckLevel() {
+	shard := s.mockShard.(*shardContextImpl)
+	shard.shardInfo.ClusterReplicationLevel = map[string]int64{cluster.TestAlternativeClusterName: 10}
+	shard.transferMaxReadLevel = 100
+	engine := &historyEngineImpl{
+		currentClusterName: cluster.TestCurrentClusterName,
+		shard:              s.mockShard,
+		clusterMetadata:    s.clusterMetadata,
+	}
+	s.clusterMetadata.EXPECT().GetAllClusterInfo().Return(cluster.TestAllClusterInfo).AnyTimes()
+	s.mockResource.ShardMgr.On("UpdateShard", mock.Anything).Return(nil)
+
+	resp, err := engine.ResetReplicationAckLevel(context.Background(), &historyservice.ResetReplicationAckLevelRequest{
+		ShardId:     0,
+		ClusterName: cluster.TestAlternativeClusterName,
+	})
+	s.NoError(err)
+	s.Equal(int64(100), resp.GetAckLevel())
+	s.Equal(int64(100), s.mockShard.GetClusterReplicationLevel(cluster.TestAlternativeClusterName))
+
+	s.executionManager.On("RangeCompleteReplicationTask", &persistence.RangeCompleteReplicationTaskRequest{
+		InclusiveEndTaskID: 100,
+	}).Return(nil).Once()
+	s.NoError(s.replicationTaskProcessor.cleanupAckedReplicationTasks())
+}
+
+func (s *replicationTaskProcessorSuite) TestDLQReplicationTask() {
+	s.replicationTaskProcessor.syncShardChan = make(chan *replicationgenpb.SyncShardStatus, 1)
+	failingTask := s.newSyncActivityReplicationTask(10)
+	nextTask := s.newSyncActivityReplicationTask(11)
+
+	s.replicationTaskExecutor.EXPECT().execute("standby", failingTask, false).
+		Return(metrics.SyncActivityTaskScope, serviceerror.NewInternal("poison task")).AnyTimes()
+	s.replicationTaskExecutor.EXPECT().execute("standby", nextTask, false).
+		Return(metrics.SyncActivityTaskScope, nil).Times(1)
+	s.executionManager.On("PutReplicationTaskToDLQ", mock.MatchedBy(func(request *persistence.PutReplicationTaskToDLQRequest) bool {
+		return request.TaskInfo.GetTaskId() == 10
+	})).Return(nil).Once()
+
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- s.replicationTaskProcessor.dlqReplicationTask(context.Background(), 10, "poison task")
+	}()
+	s.Eventually(func() bool {
+		return s.replicationTaskProcessor.getDLQRequest(10) != nil
+	}, time.Second, 10*time.Millisecond)
+
+	s.replicationTaskProcessor.processResponse(&replicationgenpb.ReplicationMessages{
+		ReplicationTasks:       []*replicationgenpb.ReplicationTask{failingTask, nextTask},
+		LastRetrievedMessageId: 11,
+	})
+
+	s.NoError(<-errCh)
+	s.Nil(s.replicationTaskProcessor.getDLQRequest(10))
+	s.Equal(int64(11), s.replicationTaskProcessor.lastProcessedMessageID)
+	s.executionManager.AssertExpectations(s.T())
+}
+
+func (s *replicationTaskProcessorSuite) TestDLQReplicationTask_StopsRetry() {
+	task := s.newSyncActivityReplicationTask(10)
+	request := &dlqRequest{
+		reason:   "poison task",
+		respChan: make(chan error, 1),
+	}
+
+	s.replicationTaskExecutor.EXPECT().execute("standby", task, false).DoAndReturn(
+		func(_ string, _ *replicationgenpb.ReplicationTask, _ bool) (int, error) {
+			// the request arrives while the task is being retried
+			s.replicationTaskProcessor.dlqRequests[10] = request
+			return metrics.SyncActivityTaskScope, serviceerror.NewInternal("poison task")
+		},
+	).Times(1)
+	s.executionManager.On("PutReplicationTaskToDLQ", mock.Anything).Return(nil).Once()
+
+	s.NoError(s.replicationTaskProcessor.processSingleTask(task))
+	s.NoError(<-request.respChan)
+	s.Nil(s.replicationTaskProcessor.getDLQRequest(10))
+}
+
+func (s *replicationTaskProcessorSuite) TestDLQReplicationTask_Canceled() {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	err := s.replicationTaskProcessor.dlqReplicationTask(ctx, 10, "poison task")
+	s.Equal(context.DeadlineExceeded, err)
+	s.Nil(s.replicationTaskProcessor.getDLQRequest(10))
+}
+
+func (s *replicationTaskProcessorSuite) TestDLQReplicationTask_UnknownSourceCluster() {
+	engine := &historyEngineImpl{
+		currentClusterName:        cluster.TestCurrentClusterName,
+		shard:                     s.mockShard,
+		replicationTaskProcessors: []ReplicationTaskProcessor{s.replicationTaskProcessor},
+	}
+
+	err := engine.DLQReplicationTask(context.Background(), &historyservice.DLQReplicationTaskRequest{
+		SourceCluster: "unknown",
+		TaskId:        10,
+	})
+	s.IsType(&serviceerror.InvalidArgument{}, err)
+}
+
+func (s *replicationTaskProcessorSuite) newSyncActivityReplicationTask(taskID int64) *replicationgenpb.ReplicationTask {
+	return &replicationgenpb.ReplicationTask{
+		TaskType:     replicationgenpb.ReplicationTaskTypeSyncActivity,
+		SourceTaskId: taskID,
+		Attributes: &replicationgenpb.ReplicationTask_SyncActivityTaskAttributes{SyncActivityTaskAttributes: &replicationgenpb.SyncActivityTaskAttributes{
+			NamespaceId: uuid.New(),
+			WorkflowId:  uuid.New(),
+			RunId:       uuid.New(),
+		}},
+	}
+}
@@ -25,6 +25,7 @@ import (
 	"errors"
 	"time"
 
+	"github.com/gogo/protobuf/types"
 	commonpb "go.temporal.io/temporal-proto/common"
 	eventpb "go.temporal.io/temporal-proto/event"
 	executionpb "go.temporal.io/temporal-proto/execution"
@@ -434,10 +435,13 @@ func convertLastReplicationInfo(info map[string]*replicationgenpb.ReplicationInf
 // TODO: when kafka deprecation is finished, delete all logic above
 //  and move logic below to dedicated replicationTaskAckMgr
 
+// getTasks returns the replication tasks after lastReadTaskID for the polling cluster.
+// Tasks with a version below minVersion are skipped, a zero minVersion returns tasks of all versions.
 func (p *replicatorQueueProcessorImpl) getTasks(
 	ctx context.Context,
 	pollingCluster string,
 	lastReadTaskID int64,
+	minVersion int64,
 ) (*replicationgenpb.ReplicationMessages, error) {
 
 	if lastReadTaskID == emptyMessageID {
@@ -452,6 +456,11 @@ func (p *replicatorQueueProcessorImpl) getTasks(
 	var replicationTasks []*replicationgenpb.ReplicationTask
 	readLevel := lastReadTaskID
 	for _, taskInfo := range taskInfoList {
+		if minVersion > 0 && taskInfo.GetVersion() < minVersion {
+			readLevel = taskInfo.GetTaskId()
+			continue
+		}
+
 		var replicationTask *replicationgenpb.ReplicationTask
 		op := func() error {
 			var err error
@@ -478,6 +487,17 @@ func (p *replicatorQueueProcessorImpl) getTasks(
 		time.Duration(p.shard.GetTransferMaxReadLevel()-readLevel),
 	)
 
+	// the oldest task read is the oldest task not replicated to the polling cluster yet
+	var replicationLag time.Duration
+	if len(taskInfoList) > 0 {
+		replicationLag = p.getTaskReplicationLag(taskInfoList[0])
+	}
+	p.metricsClient.RecordTimer(
+		metrics.ReplicatorQueueProcessorScope,
+		metrics.ReplicationTasksLagTime,
+		replicationLag,
+	)
+
 	p.metricsClient.RecordTimer(
 		metrics.ReplicatorQueueProcessorScope,
 		metrics.ReplicationTasksFetched,
@@ -504,6 +524,38 @@ func (p *replicatorQueueProcessorImpl) getTasks(
 	}, nil
 }
 
+// getReplicationLag returns the age of the oldest replication task above the replication level of the cluster,
+// zero when the cluster is caught up
+func (p *replicatorQueueProcessorImpl) getReplicationLag(
+	ctx context.Context,
+	cluster string,
+) (time.Duration, error) {
+
+	taskInfoList, _, err := p.readTasksWithBatchSize(p.shard.GetClusterReplicationLevel(cluster), 1)
+	if err != nil {
+		return 0, err
+	}
+	if len(taskInfoList) == 0 {
+		return 0, nil
+	}
+	return p.getTaskReplicationLag(taskInfoList[0]), nil
+}
+
+func (p *replicatorQueueProcessorImpl) getTaskReplicationLag(
+	taskInfo queueTaskInfo,
+) time.Duration {
+
+	visibilityTime, err := types.TimestampFromProto(taskInfo.GetVisibilityTimestamp())
+	if err != nil || visibilityTime.Unix() <= 0 {
+		// tasks written before the visibility timestamp was recorded have no known age
+		return 0
+	}
+	if lag := p.shard.GetTimeSource().Now().Sub(visibilityTime); lag > 0 {
+		return lag
+	}
+	return 0
+}
+
 func (p *replicatorQueueProcessorImpl) getTask(
 	ctx context.Context,
 	taskInfo *replicationgenpb.ReplicationTaskInfo,
@@ -81,9 +81,9 @@ func (mr *MockReplicatorQueueProcessorMockRecorder) Stop() *gomock.Call {
 }
 
 // getTasks mocks base method
-func (m *MockReplicatorQueueProcessor) getTasks(arg0 context.Context, arg1 string, arg2 int64) (*replicationgenpb.ReplicationMessages, error) {
+func (m *MockReplicatorQueueProcessor) getTasks(arg0 context.Context, arg1 string, arg2 int64, arg3 int64) (*replicationgenpb.ReplicationMessages, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "getTasks", arg0, arg1, arg2)
+	ret := m.ctrl.Call(m, "getTasks", arg0, arg1, arg2, arg3)
 	ret0, _ := ret[0].(*replicationgenpb.ReplicationMessages)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
@@ -99,9 +99,9 @@ func (m *MockReplicatorQueueProcessor) getTask(arg0 context.Context, arg1 *repli
 }
 
 // getTasks indicates an expected call of getTasks
-func (mr *MockReplicatorQueueProcessorMockRecorder) getTasks(arg0 interface{}, arg1 interface{}, arg2 interface{}) *gomock.Call {
+func (mr *MockReplicatorQueueProcessorMockRecorder) getTasks(arg0 interface{}, arg1 interface{}, arg2 interface{}, arg3 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "getTasks", reflect.TypeOf((*MockReplicatorQueueProcessor)(nil).getTasks), arg0, arg1, arg2)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "getTasks", reflect.TypeOf((*MockReplicatorQueueProcessor)(nil).getTasks), arg0, arg1, arg2, arg3)
 }
 
 // notifyNewTask mocks base method
@@ -21,11 +21,14 @@
 package history
 
 import (
+	"context"
 	"testing"
 	"time"
 
+	"github.com/gogo/protobuf/types"
 	"github.com/golang/mock/gomock"
 	"github.com/pborman/uuid"
+	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 	"github.com/stretchr/testify/suite"
 	eventpb "go.temporal.io/temporal-proto/event"
@@ -36,6 +39,7 @@ import (
 	replicationgenpb "github.com/temporalio/temporal/.gen/proto/replication"
 	"github.com/temporalio/temporal/common"
 	"github.com/temporalio/temporal/common/cache"
+	"github.com/temporalio/temporal/common/clock"
 	"github.com/temporalio/temporal/common/cluster"
 	"github.com/temporalio/temporal/common/log"
 	"github.com/temporalio/temporal/common/mocks"
@@ -523,3 +527,133 @@ func (s *replicatorQueueProcessorSuite) TestPaginateHistoryWithShardID() {
 	s.Equal(1, size)
 	s.NoError(err)
 }
+
+func (s *replicatorQueueProcessorSuite) TestGetReplicationLag() {
+	now := time.Now()
+	s.mockShard.resource.TimeSource = clock.NewEventTimeSource().Update(now)
+	s.mockShard.shardInfo.ClusterReplicationLevel = map[string]int64{cluster.TestAlternativeClusterName: 10}
+
+	visibilityTimestamp, err := types.TimestampProto(now.Add(-5 * time.Minute))
+	s.NoError(err)
+	s.mockExecutionMgr.On("GetReplicationTasks", &persistence.GetReplicationTasksRequest{
+		ReadLevel:    10,
+		MaxReadLevel: s.mockShard.GetTransferMaxReadLevel(),
+		BatchSize:    1,
+	}).Return(&persistence.GetReplicationTasksResponse{
+		Tasks: []*persistenceblobs.ReplicationTaskInfo{{
+			TaskType:            persistence.ReplicationTaskTypeHistory,
+			TaskId:              11,
+			VisibilityTimestamp: visibilityTimestamp,
+		}},
+	}, nil).Once()
+
+	lag, err := s.replicatorQueueProcessor.getReplicationLag(context.Background(), cluster.TestAlternativeClusterName)
+	s.NoError(err)
+	s.Equal(5*time.Minute, lag)
+}
+
+func (s *replicatorQueueProcessorSuite) TestGetReplicationLag_NoTasks() {
+	s.mockShard.shardInfo.ClusterReplicationLevel = map[string]int64{cluster.TestAlternativeClusterName: 10}
+
+	s.mockExecutionMgr.On("GetReplicationTasks", &persistence.GetReplicationTasksRequest{
+		ReadLevel:    10,
+		MaxReadLevel: s.mockShard.GetTransferMaxReadLevel(),
+		BatchSize:    1,
+	}).Return(&persistence.GetReplicationTasksResponse{}, nil).Once()
+
+	lag, err := s.replicatorQueueProcessor.getReplicationLag(context.Background(), cluster.TestAlternativeClusterName)
+	s.NoError(err)
+	s.Equal(time.Duration(0), lag)
+}
+
+func (s *replicatorQueueProcessorSuite) TestGetReplicationLag_LegacyTask() {
+	s.mockShard.shardInfo.ClusterReplicationLevel = map[string]int64{cluster.TestAlternativeClusterName: 10}
+
+	s.mockExecutionMgr.On("GetReplicationTasks", &persistence.GetReplicationTasksRequest{
+		ReadLevel:    10,
+		MaxReadLevel: s.mockShard.GetTransferMaxReadLevel(),
+		BatchSize:    1,
+	}).Return(&persistence.GetReplicationTasksResponse{
+		Tasks: []*persistenceblobs.ReplicationTaskInfo{{
+			TaskType: persistence.ReplicationTaskTypeHistory,
+			TaskId:   11,
+		}},
+	}, nil).Once()
+
+	lag, err := s.replicatorQueueProcessor.getReplicationLag(context.Background(), cluster.TestAlternativeClusterName)
+	s.NoError(err)
+	s.Equal(time.Duration(0), lag)
+}
+
+func (s *replicatorQueueProcessorSuite) TestGetTasks_MinVersion() {
+	namespace := "some random namespace name"
+	namespaceID := testNamespaceID
+	workflowID := "some random workflow ID"
+	runID := uuid.New()
+	minVersion := int64(20)
+	s.mockShard.shardInfo.ClusterReplicationLevel = map[string]int64{cluster.TestAlternativeClusterName: 10}
+	s.mockShard.resource.ShardMgr.On("UpdateShard", mock.Anything).Return(nil)
+
+	// tasks 11, 12 and 13 sync activities 101, 102 and 103 at versions 10, 20 and 30
+	var tasks []*persistenceblobs.ReplicationTaskInfo
+	for i, version := range []int64{10, 20, 30} {
+		tasks = append(tasks, &persistenceblobs.ReplicationTaskInfo{
+			TaskType:    persistence.ReplicationTaskTypeSyncActivity,
+			TaskId:      int64(11 + i),
+			Version:     version,
+			NamespaceId: primitives.MustParseUUID(namespaceID),
+			WorkflowId:  workflowID,
+			RunId:       primitives.MustParseUUID(runID),
+			ScheduledId: int64(101 + i),
+		})
+	}
+	s.mockExecutionMgr.On("GetReplicationTasks", &persistence.GetReplicationTasksRequest{
+		ReadLevel:    10,
+		MaxReadLevel: s.mockShard.GetTransferMaxReadLevel(),
+		BatchSize:    s.replicatorQueueProcessor.fetchTasksBatchSize,
+	}).Return(&persistence.GetReplicationTasksResponse{Tasks: tasks}, nil).Once()
+
+	weContext, release, _ := s.replicatorQueueProcessor.historyCache.getOrCreateWorkflowExecutionForBackground(
+		namespaceID,
+		executionpb.WorkflowExecution{
+			WorkflowId: workflowID,
+			RunId:      runID,
+		},
+	)
+	weContext.(*workflowExecutionContextImpl).mutableState = s.mockMutableState
+	release(nil)
+
+	s.mockNamespaceCache.EXPECT().GetNamespaceByID(namespaceID).Return(cache.NewGlobalNamespaceCacheEntryForTest(
+		&persistence.NamespaceInfo{ID: namespaceID, Name: namespace},
+		&persistence.NamespaceConfig{Retention: 1},
+		&persistence.NamespaceReplicationConfig{
+			ActiveClusterName: cluster.TestCurrentClusterName,
+			Clusters: []*persistence.ClusterReplicationConfig{
+				{ClusterName: cluster.TestCurrentClusterName},
+				{ClusterName: cluster.TestAlternativeClusterName},
+			},
+		},
+		minVersion,
+		nil,
+	), nil).AnyTimes()
+	s.mockMutableState.EXPECT().StartTransaction(gomock.Any()).Return(false, nil).Times(2)
+	s.mockMutableState.EXPECT().IsWorkflowExecutionRunning().Return(true).AnyTimes()
+	s.mockMutableState.EXPECT().GetVersionHistories().Return(nil).AnyTimes()
+	// the task below the min version is skipped without loading its activity
+	for _, scheduleID := range []int64{102, 103} {
+		s.mockMutableState.EXPECT().GetActivityInfo(scheduleID).Return(&persistence.ActivityInfo{
+			ScheduleID: scheduleID,
+			StartedID:  common.EmptyEventID,
+		}, true).Times(1)
+	}
+
+	messages, err := s.replicatorQueueProcessor.getTasks(context.Background(), cluster.TestAlternativeClusterName, 10, minVersion)
+	s.NoError(err)
+	s.False(messages.HasMore)
+	s.Equal(int64(13), messages.LastRetrievedMessageId)
+	s.Len(messages.ReplicationTasks, 2)
+	s.Equal(int64(102), messages.ReplicationTasks[0].GetSyncActivityTaskAttributes().GetScheduledId())
+	s.Equal(int64(12), messages.ReplicationTasks[0].GetSourceTaskId())
+	s.Equal(int64(103), messages.ReplicationTasks[1].GetSyncActivityTaskAttributes().GetScheduledId())
+	s.Equal(int64(13), messages.ReplicationTasks[1].GetSourceTaskId())
+}
@@ -83,7 +83,8 @@ type Config struct {
 	StandbyTaskMissingEventsDiscardDelay dynamicconfig.DurationPropertyFn
 
 	// Task process settings
-	TaskProcessRPS dynamicconfig.IntPropertyFnWithNamespaceFilter
+	TaskProcessRPS                    dynamicconfig.IntPropertyFnWithNamespaceFilter
+	QueueProcessorMaxShardWorkerCount dynamicconfig.IntPropertyFn
 
 	// TimerQueueProcessor settings
 	TimerTaskBatchSize                               dynamicconfig.IntPropertyFn
@@ -106,6 +107,7 @@ type Config struct {
 	TransferTaskBatchSize                               dynamicconfig.IntPropertyFn
 	TransferTaskWorkerCount                             dynamicconfig.IntPropertyFn
 	TransferTaskMaxRetryCount                           dynamicconfig.IntPropertyFn
+	MaxDecisionTaskProcessingAttempts                   dynamicconfig.IntPropertyFn
 	TransferProcessorCompleteTransferFailureRetryCount  dynamicconfig.IntPropertyFn
 	TransferProcessorFailoverMaxPollRPS                 dynamicconfig.IntPropertyFn
 	TransferProcessorMaxPollRPS                         dynamicconfig.IntPropertyFn
@@ -149,6 +151,11 @@ type Config struct {
 	EventEncodingType dynamicconfig.StringPropertyFnWithNamespaceFilter
 	// whether or not using ParentClosePolicy
 	EnableParentClosePolicy dynamicconfig.BoolPropertyFnWithNamespaceFilter
+	// parent close policy of the child workflows whose header marks the policy as unset, when parent close
+	// policies are enabled
+	DefaultParentClosePolicy dynamicconfig.StringPropertyFnWithNamespaceFilter
+	// whether or not child workflows without a retry policy inherit the retry policy of the parent
+	InheritChildRetryPolicy dynamicconfig.BoolPropertyFnWithNamespaceFilter
 	// whether or not enable system workers for processing parent close policy task
 	EnableParentClosePolicyWorker dynamicconfig.BoolPropertyFn
 	// parent close policy will be processed by sys workers(if enabled) if
@@ -158,22 +165,32 @@ type Config struct {
 	NumParentClosePolicySystemWorkflows dynamicconfig.IntPropertyFn
 
 	// Archival settings
-	NumArchiveSystemWorkflows dynamicconfig.IntPropertyFn
-	ArchiveRequestRPS         dynamicconfig.IntPropertyFn
+	NumArchiveSystemWorkflows            dynamicconfig.IntPropertyFnWithNamespaceFilter
+	ArchiveRequestRPS                    dynamicconfig.IntPropertyFn
+	ArchivalRedactedSearchAttributes     dynamicconfig.StringPropertyFnWithNamespaceFilter
+	ArchivalSearchAttributesFieldMapping dynamicconfig.MapPropertyFnWithNamespaceFilter
+	ArchivalHistoryStatusFilter          dynamicconfig.StringPropertyFnWithNamespaceFilter
 
 	// Size limit related settings
 	BlobSizeLimitError     dynamicconfig.IntPropertyFnWithNamespaceFilter
 	BlobSizeLimitWarn      dynamicconfig.IntPropertyFnWithNamespaceFilter
+	DecisionBlobSizeBudget dynamicconfig.IntPropertyFnWithNamespaceFilter
+	ControlSizeLimitError  dynamicconfig.IntPropertyFnWithNamespaceFilter
 	HistorySizeLimitError  dynamicconfig.IntPropertyFnWithNamespaceFilter
 	HistorySizeLimitWarn   dynamicconfig.IntPropertyFnWithNamespaceFilter
 	HistoryCountLimitError dynamicconfig.IntPropertyFnWithNamespaceFilter
 	HistoryCountLimitWarn  dynamicconfig.IntPropertyFnWithNamespaceFilter
 
+	// Buffered events count limit related settings
+	BufferedEventsCountLimitError dynamicconfig.IntPropertyFnWithNamespaceFilter
+	BufferedEventsCountLimitWarn  dynamicconfig.IntPropertyFnWithNamespaceFilter
+
 	// ValidSearchAttributes is legal indexed keys that can be used in list APIs
 	ValidSearchAttributes             dynamicconfig.MapPropertyFn
 	SearchAttributesNumberOfKeysLimit dynamicconfig.IntPropertyFnWithNamespaceFilter
 	SearchAttributesSizeOfValueLimit  dynamicconfig.IntPropertyFnWithNamespaceFilter
 	SearchAttributesTotalSizeLimit    dynamicconfig.IntPropertyFnWithNamespaceFilter
+	MaxSearchAttributeValueCount      dynamicconfig.IntPropertyFnWithNamespaceFilter
 
 	// Decision settings
 	// StickyTTL is to expire a sticky tasklist if no update more than this duration
@@ -182,6 +199,42 @@ type Config struct {
 	// DecisionHeartbeatTimeout is to timeout behavior of: RespondDecisionTaskComplete with ForceCreateNewDecisionTask == true without any decisions
 	// So that decision will be scheduled to another worker(by clear stickyness)
 	DecisionHeartbeatTimeout dynamicconfig.DurationPropertyFnWithNamespaceFilter
+	// WorkflowCancellationGracePeriod defers a cancel workflow decision, 0 cancels the workflow immediately
+	WorkflowCancellationGracePeriod dynamicconfig.DurationPropertyFnWithNamespaceFilter
+	// LogDecisionFailureMessage whether to include the failure message when logging a failed decision
+	LogDecisionFailureMessage dynamicconfig.BoolPropertyFnWithNamespaceFilter
+	// LogDroppedDecisions whether to log the decisions of a batch dropped after decision processing stopped
+	LogDroppedDecisions dynamicconfig.BoolPropertyFnWithNamespaceFilter
+	// EnableMarkerConsistencyCheck whether to fail decisions recording a marker ID again with different details
+	EnableMarkerConsistencyCheck dynamicconfig.BoolPropertyFnWithNamespaceFilter
+	// ValidateActivityTaskList whether to check that the task list of a scheduled activity has activity pollers
+	ValidateActivityTaskList dynamicconfig.BoolPropertyFnWithNamespaceFilter
+	// FailUnknownActivityTaskList whether to fail, rather than only warn on, decisions scheduling an activity on a task list without activity pollers
+	FailUnknownActivityTaskList dynamicconfig.BoolPropertyFnWithNamespaceFilter
+	// ActivityTaskListsByType maps activity type names to the task list their activities must be scheduled on
+	ActivityTaskListsByType dynamicconfig.MapPropertyFnWithNamespaceFilter
+	// DedupeSignalExternalDecisions whether identical signal external workflow decisions in one batch signal only once
+	DedupeSignalExternalDecisions dynamicconfig.BoolPropertyFnWithNamespaceFilter
+	// DedupeSignalControlIDs whether signal external workflow decisions carrying an already signaled control signal only once
+	DedupeSignalControlIDs dynamicconfig.BoolPropertyFnWithNamespaceFilter
+	// MaximumSignalControlIDs is max number of signal controls recorded by single execution for dedupe, 0 for no limit
+	MaximumSignalControlIDs dynamicconfig.IntPropertyFnWithNamespaceFilter
+	// EnableStructuredFailureDetails whether the failure category in fail workflow details is mirrored into a search attribute
+	EnableStructuredFailureDetails dynamicconfig.BoolPropertyFnWithNamespaceFilter
+	// FailureCategories is the set of failure categories fail workflow decisions may carry, any category is allowed if empty
+	FailureCategories dynamicconfig.MapPropertyFnWithNamespaceFilter
+	// MaxRetryCronBackoffInterval caps the backoff before the next run of a retried or cron workflow, no cap if zero
+	MaxRetryCronBackoffInterval dynamicconfig.DurationPropertyFnWithNamespaceFilter
+	// AllowZeroDurationTimers whether start timer decisions may start timers which fire immediately
+	AllowZeroDurationTimers dynamicconfig.BoolPropertyFnWithNamespaceFilter
+	// MaxTimerDuration caps the duration of timers started by start timer decisions, no cap if zero
+	MaxTimerDuration dynamicconfig.DurationPropertyFnWithNamespaceFilter
+	// RequiredSearchAttributes is the comma separated list of search attribute keys the new run of continue as new must carry
+	RequiredSearchAttributes dynamicconfig.StringPropertyFnWithNamespaceFilter
+	// MaxPendingActivitiesPerWorkflow caps the number of pending activities of a workflow, no cap if zero
+	MaxPendingActivitiesPerWorkflow dynamicconfig.IntPropertyFnWithNamespaceFilter
+	// EnableNamespaceTagPropagation whether the namespace tags are attached to the header of scheduled activities
+	EnableNamespaceTagPropagation dynamicconfig.BoolPropertyFnWithNamespaceFilter
 	// MaxDecisionStartToCloseSeconds is the StartToCloseSeconds for decision
 	MaxDecisionStartToCloseSeconds dynamicconfig.IntPropertyFnWithNamespaceFilter
 
@@ -240,6 +293,7 @@ func NewConfig(dc *dynamicconfig.Collection, numberOfShards int, storeType strin
 		StandbyTaskMissingEventsResendDelay:                   dc.GetDurationProperty(dynamicconfig.StandbyTaskMissingEventsResendDelay, 15*time.Minute),
 		StandbyTaskMissingEventsDiscardDelay:                  dc.GetDurationProperty(dynamicconfig.StandbyTaskMissingEventsDiscardDelay, 25*time.Minute),
 		TaskProcessRPS:                                        dc.GetIntPropertyFilteredByNamespace(dynamicconfig.TaskProcessRPS, 1000),
+		QueueProcessorMaxShardWorkerCount:                     dc.GetIntProperty(dynamicconfig.QueueProcessorMaxShardWorkerCount, 100),
 		TimerTaskBatchSize:                                    dc.GetIntProperty(dynamicconfig.TimerTaskBatchSize, 100),
 		TimerTaskWorkerCount:                                  dc.GetIntProperty(dynamicconfig.TimerTaskWorkerCount, 10),
 		TimerTaskMaxRetryCount:                                dc.GetIntProperty(dynamicconfig.TimerTaskMaxRetryCount, 100),
@@ -260,6 +314,7 @@ func NewConfig(dc *dynamicconfig.Collection, numberOfShards int, storeType strin
 		TransferProcessorMaxPollRPS:                           dc.GetIntProperty(dynamicconfig.TransferProcessorMaxPollRPS, 20),
 		TransferTaskWorkerCount:                               dc.GetIntProperty(dynamicconfig.TransferTaskWorkerCount, 10),
 		TransferTaskMaxRetryCount:                             dc.GetIntProperty(dynamicconfig.TransferTaskMaxRetryCount, 100),
+		MaxDecisionTaskProcessingAttempts:                     dc.GetIntProperty(dynamicconfig.MaxDecisionTaskProcessingAttempts, 0),
 		TransferProcessorCompleteTransferFailureRetryCount:    dc.GetIntProperty(dynamicconfig.TransferProcessorCompleteTransferFailureRetryCount, 10),
 		TransferProcessorMaxPollInterval:                      dc.GetDurationProperty(dynamicconfig.TransferProcessorMaxPollInterval, 1*time.Minute),
 		TransferProcessorMaxPollIntervalJitterCoefficient:     dc.GetFloat64Property(dynamicconfig.TransferProcessorMaxPollIntervalJitterCoefficient, 0.15),
@@ -288,20 +343,30 @@ func NewConfig(dc *dynamicconfig.Collection, numberOfShards int, storeType strin
 		LongPollExpirationInterval:          dc.GetDurationPropertyFilteredByNamespace(dynamicconfig.HistoryLongPollExpirationInterval, time.Second*20),
 		EventEncodingType:                   dc.GetStringPropertyFnWithNamespaceFilter(dynamicconfig.DefaultEventEncoding, string(common.EncodingTypeProto3)),
 		EnableParentClosePolicy:             dc.GetBoolPropertyFnWithNamespaceFilter(dynamicconfig.EnableParentClosePolicy, true),
+		InheritChildRetryPolicy:             dc.GetBoolPropertyFnWithNamespaceFilter(dynamicconfig.InheritChildRetryPolicy, false),
+		DefaultParentClosePolicy:            dc.GetStringPropertyFnWithNamespaceFilter(dynamicconfig.DefaultParentClosePolicy, "Abandon"),
 		NumParentClosePolicySystemWorkflows: dc.GetIntProperty(dynamicconfig.NumParentClosePolicySystemWorkflows, 10),
 		EnableParentClosePolicyWorker:       dc.GetBoolProperty(dynamicconfig.EnableParentClosePolicyWorker, true),
 		ParentClosePolicyThreshold:          dc.GetIntPropertyFilteredByNamespace(dynamicconfig.ParentClosePolicyThreshold, 10),
 
-		NumArchiveSystemWorkflows: dc.GetIntProperty(dynamicconfig.NumArchiveSystemWorkflows, 1000),
-		ArchiveRequestRPS:         dc.GetIntProperty(dynamicconfig.ArchiveRequestRPS, 300), // should be much smaller than frontend RPS
+		NumArchiveSystemWorkflows:            dc.GetIntPropertyFilteredByNamespace(dynamicconfig.NumArchiveSystemWorkflows, 1000),
+		ArchiveRequestRPS:                    dc.GetIntProperty(dynamicconfig.ArchiveRequestRPS, 300), // should be much smaller than frontend RPS
+		ArchivalRedactedSearchAttributes:     dc.GetStringPropertyFnWithNamespaceFilter(dynamicconfig.ArchivalRedactedSearchAttributes, ""),
+		ArchivalSearchAttributesFieldMapping: dc.GetMapPropertyFnWithNamespaceFilter(dynamicconfig.ArchivalSearchAttributesFieldMapping, nil),
+		ArchivalHistoryStatusFilter:          dc.GetStringPropertyFnWithNamespaceFilter(dynamicconfig.ArchivalHistoryStatusFilter, ""),
 
 		BlobSizeLimitError:     dc.GetIntPropertyFilteredByNamespace(dynamicconfig.BlobSizeLimitError, 2*1024*1024),
 		BlobSizeLimitWarn:      dc.GetIntPropertyFilteredByNamespace(dynamicconfig.BlobSizeLimitWarn, 512*1024),
+		DecisionBlobSizeBudget: dc.GetIntPropertyFilteredByNamespace(dynamicconfig.DecisionBlobSizeBudget, 0),
+		ControlSizeLimitError:  dc.GetIntPropertyFilteredByNamespace(dynamicconfig.ControlSizeLimitError, 0),
 		HistorySizeLimitError:  dc.GetIntPropertyFilteredByNamespace(dynamicconfig.HistorySizeLimitError, 200*1024*1024),
 		HistorySizeLimitWarn:   dc.GetIntPropertyFilteredByNamespace(dynamicconfig.HistorySizeLimitWarn, 50*1024*1024),
 		HistoryCountLimitError: dc.GetIntPropertyFilteredByNamespace(dynamicconfig.HistoryCountLimitError, 200*1024),
 		HistoryCountLimitWarn:  dc.GetIntPropertyFilteredByNamespace(dynamicconfig.HistoryCountLimitWarn, 50*1024),
 
+		BufferedEventsCountLimitError: dc.GetIntPropertyFilteredByNamespace(dynamicconfig.BufferedEventsCountLimitError, 10*1024),
+		BufferedEventsCountLimitWarn:  dc.GetIntPropertyFilteredByNamespace(dynamicconfig.BufferedEventsCountLimitWarn, 1024),
+
 		ThrottledLogRPS:   dc.GetIntProperty(dynamicconfig.HistoryThrottledLogRPS, 4),
 		EnableStickyQuery: dc.GetBoolPropertyFnWithNamespaceFilter(dynamicconfig.EnableStickyQuery, true),
 
@@ -309,8 +374,27 @@ func NewConfig(dc *dynamicconfig.Collection, numberOfShards int, storeType strin
 		SearchAttributesNumberOfKeysLimit: dc.GetIntPropertyFilteredByNamespace(dynamicconfig.SearchAttributesNumberOfKeysLimit, 100),
 		SearchAttributesSizeOfValueLimit:  dc.GetIntPropertyFilteredByNamespace(dynamicconfig.SearchAttributesSizeOfValueLimit, 2*1024),
 		SearchAttributesTotalSizeLimit:    dc.GetIntPropertyFilteredByNamespace(dynamicconfig.SearchAttributesTotalSizeLimit, 40*1024),
+		MaxSearchAttributeValueCount:      dc.GetIntPropertyFilteredByNamespace(dynamicconfig.MaxSearchAttributeValueCount, 100),
 		StickyTTL:                         dc.GetDurationPropertyFilteredByNamespace(dynamicconfig.StickyTTL, time.Hour*24*365),
 		DecisionHeartbeatTimeout:          dc.GetDurationPropertyFilteredByNamespace(dynamicconfig.DecisionHeartbeatTimeout, time.Minute*30),
+		WorkflowCancellationGracePeriod:   dc.GetDurationPropertyFilteredByNamespace(dynamicconfig.WorkflowCancellationGracePeriod, 0),
+		LogDecisionFailureMessage:         dc.GetBoolPropertyFnWithNamespaceFilter(dynamicconfig.LogDecisionFailureMessage, false),
+		LogDroppedDecisions:               dc.GetBoolPropertyFnWithNamespaceFilter(dynamicconfig.LogDroppedDecisions, false),
+		EnableMarkerConsistencyCheck:      dc.GetBoolPropertyFnWithNamespaceFilter(dynamicconfig.EnableMarkerConsistencyCheck, false),
+		ValidateActivityTaskList:          dc.GetBoolPropertyFnWithNamespaceFilter(dynamicconfig.ValidateActivityTaskList, false),
+		FailUnknownActivityTaskList:       dc.GetBoolPropertyFnWithNamespaceFilter(dynamicconfig.FailUnknownActivityTaskList, false),
+		ActivityTaskListsByType:           dc.GetMapPropertyFnWithNamespaceFilter(dynamicconfig.ActivityTaskListsByType, nil),
+		DedupeSignalExternalDecisions:     dc.GetBoolPropertyFnWithNamespaceFilter(dynamicconfig.DedupeSignalExternalDecisions, false),
+		DedupeSignalControlIDs:            dc.GetBoolPropertyFnWithNamespaceFilter(dynamicconfig.DedupeSignalControlIDs, false),
+		MaximumSignalControlIDs:           dc.GetIntPropertyFilteredByNamespace(dynamicconfig.MaximumSignalControlIDs, 1000),
+		EnableStructuredFailureDetails:    dc.GetBoolPropertyFnWithNamespaceFilter(dynamicconfig.EnableStructuredFailureDetails, false),
+		FailureCategories:                 dc.GetMapPropertyFnWithNamespaceFilter(dynamicconfig.FailureCategories, nil),
+		MaxRetryCronBackoffInterval:       dc.GetDurationPropertyFilteredByNamespace(dynamicconfig.MaxRetryCronBackoffInterval, 0),
+		AllowZeroDurationTimers:           dc.GetBoolPropertyFnWithNamespaceFilter(dynamicconfig.AllowZeroDurationTimers, false),
+		MaxTimerDuration:                  dc.GetDurationPropertyFilteredByNamespace(dynamicconfig.MaxTimerDuration, 0),
+		RequiredSearchAttributes:          dc.GetStringPropertyFnWithNamespaceFilter(dynamicconfig.ContinueAsNewRequiredSearchAttributes, ""),
+		MaxPendingActivitiesPerWorkflow:   dc.GetIntPropertyFilteredByNamespace(dynamicconfig.MaxPendingActivitiesPerWorkflow, 0),
+		EnableNamespaceTagPropagation:     dc.GetBoolPropertyFnWithNamespaceFilter(dynamicconfig.EnableNamespaceTagPropagation, false),
 
 		ReplicationTaskFetcherParallelism:                dc.GetIntProperty(dynamicconfig.ReplicationTaskFetcherParallelism, 1),
 		ReplicationTaskFetcherAggregationInterval:        dc.GetDurationProperty(dynamicconfig.ReplicationTaskFetcherAggregationInterval, 2*time.Second),
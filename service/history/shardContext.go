@@ -92,6 +92,8 @@ type (
 		GetTimerClusterAckLevel(cluster string) time.Time
 		UpdateTimerClusterAckLevel(cluster string, ackLevel time.Time) error
 
+		RepairAckLevels(transferAckLevel int64, timerAckLevel time.Time, replicationAckLevel int64) error
+
 		UpdateTransferFailoverLevel(failoverID string, level persistence.TransferFailoverLevel) error
 		DeleteTransferFailoverLevel(failoverID string) error
 		GetAllTransferFailoverLevels() map[string]persistence.TransferFailoverLevel
@@ -360,6 +362,37 @@ func (s *shardContextImpl) UpdateTimerClusterAckLevel(cluster string, ackLevel t
 	return s.updateShardInfoLocked()
 }
 
+// RepairAckLevels overwrites the transfer, timer and replication ack levels of the shard,
+// including the per cluster ones, and persists them right away
+func (s *shardContextImpl) RepairAckLevels(
+	transferAckLevel int64,
+	timerAckLevel time.Time,
+	replicationAckLevel int64,
+) error {
+
+	s.Lock()
+	defer s.Unlock()
+
+	pTime, err := types.TimestampProto(timerAckLevel)
+	if err != nil {
+		return err
+	}
+
+	s.shardInfo.TransferAckLevel = transferAckLevel
+	for cluster := range s.shardInfo.ClusterTransferAckLevel {
+		s.shardInfo.ClusterTransferAckLevel[cluster] = transferAckLevel
+	}
+	s.shardInfo.TimerAckLevel = pTime
+	for cluster := range s.shardInfo.ClusterTimerAckLevel {
+		s.shardInfo.ClusterTimerAckLevel[cluster] = pTime
+	}
+	s.shardInfo.ReplicationAckLevel = replicationAckLevel
+	s.shardInfo.StolenSinceRenew = 0
+	// skip the update throttling, the repaired levels must not be lost when the shard is closed
+	s.lastUpdated = time.Time{}
+	return s.updateShardInfoLocked()
+}
+
 func (s *shardContextImpl) UpdateTransferFailoverLevel(failoverID string, level persistence.TransferFailoverLevel) error {
 	s.Lock()
 	defer s.Unlock()
@@ -1058,6 +1091,13 @@ func (s *shardContextImpl) allocateTaskIDsLocked(
 		transferMaxReadLevel); err != nil {
 		return err
 	}
+	// replication tasks are stamped with their creation time, so the replication lag can be reported as a duration
+	now := s.GetTimeSource().Now()
+	for _, task := range replicationTasks {
+		if task.GetVisibilityTimestamp().IsZero() {
+			task.SetVisibilityTimestamp(now)
+		}
+	}
 	if err := s.allocateTransferIDsLocked(
 		replicationTasks,
 		transferMaxReadLevel); err != nil {
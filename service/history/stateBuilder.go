@@ -507,7 +507,19 @@ func (b *stateBuilderImpl) applyEvents(
 			}
 
 		case eventpb.EventTypeMarkerRecorded:
-			// No mutable state action is needed
+			if err := b.mutableState.ReplicateMarkerRecordedEvent(
+				event,
+			); err != nil {
+				return nil, err
+			}
+
+			if event.GetMarkerRecordedEventAttributes().GetMarkerName() == cancellationGracePeriodMarkerName {
+				if err := taskGenerator.generateCancellationGracePeriodTasks(
+					b.mutableState.GetExecutionInfo().CancelGracePeriodExpiryTime,
+				); err != nil {
+					return nil, err
+				}
+			}
 
 		case eventpb.EventTypeWorkflowExecutionSignaled:
 			if err := b.mutableState.ReplicateWorkflowExecutionSignaled(
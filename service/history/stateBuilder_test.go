@@ -661,6 +661,7 @@ func (s *stateBuilderSuite) TestApplyEvents_EventTypeMarkerRecorded() {
 		Attributes: &eventpb.HistoryEvent_MarkerRecordedEventAttributes{MarkerRecordedEventAttributes: &eventpb.MarkerRecordedEventAttributes{}},
 	}
 	s.mockUpdateVersion(event)
+	s.mockMutableState.EXPECT().ReplicateMarkerRecordedEvent(event).Return(nil).Times(1)
 	s.mockMutableState.EXPECT().GetExecutionInfo().Return(&persistence.WorkflowExecutionInfo{}).AnyTimes()
 	s.mockMutableState.EXPECT().ClearStickyness().Times(1)
 
@@ -668,6 +669,44 @@ func (s *stateBuilderSuite) TestApplyEvents_EventTypeMarkerRecorded() {
 	s.Nil(err)
 }
 
+func (s *stateBuilderSuite) TestApplyEvents_EventTypeMarkerRecorded_CancellationGracePeriod() {
+	version := int64(1)
+	requestID := uuid.New()
+
+	execution := executionpb.WorkflowExecution{
+		WorkflowId: "some random workflow ID",
+		RunId:      testRunID,
+	}
+
+	now := time.Now()
+	expiryTime := now.Add(time.Minute)
+	marker := newCancellationGracePeriodMarker([]byte("some random cancellation details"), expiryTime)
+	evenType := eventpb.EventTypeMarkerRecorded
+	event := &eventpb.HistoryEvent{
+		Version:   version,
+		EventId:   130,
+		Timestamp: now.UnixNano(),
+		EventType: evenType,
+		Attributes: &eventpb.HistoryEvent_MarkerRecordedEventAttributes{MarkerRecordedEventAttributes: &eventpb.MarkerRecordedEventAttributes{
+			MarkerName:                   marker.GetMarkerName(),
+			Details:                      marker.GetDetails(),
+			DecisionTaskCompletedEventId: 129,
+			Header:                       marker.GetHeader(),
+		}},
+	}
+	s.mockUpdateVersion(event)
+	s.mockMutableState.EXPECT().ReplicateMarkerRecordedEvent(event).Return(nil).Times(1)
+	s.mockMutableState.EXPECT().GetExecutionInfo().Return(&persistence.WorkflowExecutionInfo{
+		CancelGracePeriodExpiryTime:       expiryTime,
+		CancelGracePeriodCompletedEventID: 129,
+	}).AnyTimes()
+	s.mockTaskGenerator.EXPECT().generateCancellationGracePeriodTasks(expiryTime).Return(nil).Times(1)
+	s.mockMutableState.EXPECT().ClearStickyness().Times(1)
+
+	_, err := s.stateBuilder.applyEvents(testNamespaceID, requestID, execution, s.toHistory(event), nil, false)
+	s.Nil(err)
+}
+
 // decision operations
 
 func (s *stateBuilderSuite) TestApplyEvents_EventTypeDecisionTaskScheduled() {
@@ -27,6 +27,7 @@ import (
 	"github.com/gogo/protobuf/types"
 	"go.temporal.io/temporal-proto/serviceerror"
 
+	"github.com/temporalio/temporal/.gen/proto/persistenceblobs"
 	"github.com/temporalio/temporal/common"
 	"github.com/temporalio/temporal/common/backoff"
 	"github.com/temporalio/temporal/common/cache"
@@ -205,6 +206,9 @@ FilterLoop:
 		err := t.handleTaskError(scope, task, notificationChan, err)
 		if err != nil {
 			task.attempt++
+			if isPoisonDecisionTask(task.task, task.attempt, t.config.MaxDecisionTaskProcessingAttempts(), err) {
+				return moveDecisionTaskToDLQ(t.shard.GetService().GetDecisionTaskDLQ(), scope, task.logger, task.task, task.attempt, err)
+			}
 			if task.attempt >= t.config.TimerTaskMaxRetryCount() {
 				scope.RecordTimer(metrics.TaskAttemptTimer, time.Duration(task.attempt))
 				task.logger.Error("Critical error processing task, retrying.",
@@ -308,6 +312,58 @@ func (t *taskProcessor) handleTaskError(
 	return err
 }
 
+// isPoisonDecisionTask returns true if a transfer decision task failed to be processed more times than allowed.
+// Transient errors never make a task poison, as they are retried until they go away.
+func isPoisonDecisionTask(
+	task queueTaskInfo,
+	attempt int,
+	maxAttempts int,
+	err error,
+) bool {
+
+	if maxAttempts <= 0 || attempt < maxAttempts {
+		return false
+	}
+	transferTask, ok := task.(*persistenceblobs.TransferTaskInfo)
+	if !ok || transferTask.GetTaskType() != persistence.TransferTaskTypeDecisionTask {
+		return false
+	}
+	if err == ErrTaskRetry || common.IsPersistenceTransientError(err) {
+		return false
+	}
+	switch err.(type) {
+	case *serviceerror.NamespaceNotActive,
+		*serviceerror.Unavailable,
+		*serviceerror.ResourceExhausted:
+		return false
+	}
+	return true
+}
+
+// moveDecisionTaskToDLQ puts a poison decision task into the decision task DLQ so it can be acked. The task
+// keeps being retried if it cannot be put into the DLQ. Tasks in the DLQ are redriven with the admin DLQ merge
+// command, which regenerates the pending tasks of their workflows.
+func moveDecisionTaskToDLQ(
+	dlq persistence.DecisionTaskDLQ,
+	scope metrics.Scope,
+	logger log.Logger,
+	task queueTaskInfo,
+	attempt int,
+	taskErr error,
+) error {
+
+	transferTask := task.(*persistenceblobs.TransferTaskInfo)
+	if err := dlq.Put(transferTask); err != nil {
+		logger.Error("Failed to move decision task to DLQ.", tag.Error(err))
+		return taskErr
+	}
+
+	scope.IncCounter(metrics.TaskMovedToDLQ)
+	logger.Error("Decision task keeps failing, moved to DLQ.",
+		tag.Error(taskErr), tag.Attempt(int32(attempt)), tag.OperationCritical, tag.TaskType(transferTask.GetTaskType()))
+	return nil
+}
+
 func (t *taskProcessor) ackTaskOnce(
 	scope metrics.Scope,
 	task *taskInfo,
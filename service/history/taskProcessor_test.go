@@ -37,6 +37,7 @@ import (
 	"github.com/temporalio/temporal/common/log"
 	"github.com/temporalio/temporal/common/metrics"
 	"github.com/temporalio/temporal/common/persistence"
+	"github.com/temporalio/temporal/common/service/dynamicconfig"
 )
 
 type (
@@ -189,6 +190,74 @@ func (s *taskProcessorSuite) TestProcessTaskAndAck_NamespaceTrue_ProcessErrNoErr
 	)
 }
 
+func (s *taskProcessorSuite) TestProcessTaskAndAck_DecisionTaskMovedToDLQ() {
+	s.mockShard.config.MaxDecisionTaskProcessingAttempts = dynamicconfig.GetIntPropertyFn(3)
+	err := errors.New("some random err")
+	transferTask := &persistenceblobs.TransferTaskInfo{
+		TaskId:              12345,
+		TaskType:            persistence.TransferTaskTypeDecisionTask,
+		VisibilityTimestamp: types.TimestampNow(),
+	}
+	task := newTaskInfo(s.mockProcessor, transferTask, s.logger)
+	var taskFilter taskFilter = func(task queueTaskInfo) (bool, error) {
+		return true, nil
+	}
+	s.mockProcessor.On("getTaskFilter").Return(taskFilter).Once()
+	s.mockProcessor.On("process", task).Return(s.scopeIdx, err).Times(3)
+	s.mockProcessor.On("complete", task).Once()
+	s.mockShard.resource.NamespaceCache.EXPECT().GetNamespaceName(gomock.Any()).Return(testNamespace, nil).Times(3)
+	s.mockShard.resource.DecisionTaskDLQ.EXPECT().Put(transferTask).Return(nil).Times(1)
+	s.taskProcessor.processTaskAndAck(
+		s.notificationChan,
+		task,
+	)
+	s.Equal(3, task.attempt)
+}
+
+func (s *taskProcessorSuite) TestProcessTaskAndAck_NonDecisionTaskNotMovedToDLQ() {
+	s.mockShard.config.MaxDecisionTaskProcessingAttempts = dynamicconfig.GetIntPropertyFn(1)
+	err := errors.New("some random err")
+	transferTask := &persistenceblobs.TransferTaskInfo{
+		TaskId:              12345,
+		TaskType:            persistence.TransferTaskTypeActivityTask,
+		VisibilityTimestamp: types.TimestampNow(),
+	}
+	task := newTaskInfo(s.mockProcessor, transferTask, s.logger)
+	var taskFilter taskFilter = func(task queueTaskInfo) (bool, error) {
+		return true, nil
+	}
+	s.mockProcessor.On("getTaskFilter").Return(taskFilter).Once()
+	s.mockProcessor.On("process", task).Return(s.scopeIdx, err).Times(2)
+	s.mockProcessor.On("process", task).Return(s.scopeIdx, nil).Once()
+	s.mockProcessor.On("complete", task).Once()
+	s.mockShard.resource.NamespaceCache.EXPECT().GetNamespaceName(gomock.Any()).Return(testNamespace, nil).Times(3)
+	s.taskProcessor.processTaskAndAck(
+		s.notificationChan,
+		task,
+	)
+	s.Equal(2, task.attempt)
+}
+
+func (s *taskProcessorSuite) TestIsPoisonDecisionTask() {
+	decisionTask := &persistenceblobs.TransferTaskInfo{TaskType: persistence.TransferTaskTypeDecisionTask}
+	activityTask := &persistenceblobs.TransferTaskInfo{TaskType: persistence.TransferTaskTypeActivityTask}
+	err := errors.New("some random err")
+
+	s.True(isPoisonDecisionTask(decisionTask, 3, 3, err))
+	s.False(isPoisonDecisionTask(decisionTask, 2, 3, err))
+	s.False(isPoisonDecisionTask(decisionTask, 3, 0, err))
+	s.False(isPoisonDecisionTask(activityTask, 3, 3, err))
+	for _, transientErr := range []error{
+		ErrTaskRetry,
+		serviceerror.NewNamespaceNotActive("", "", ""),
+		serviceerror.NewUnavailable(""),
+		serviceerror.NewResourceExhausted(""),
+		serviceerror.NewInternal(""),
+	} {
+		s.False(isPoisonDecisionTask(decisionTask, 3, 3, transientErr))
+	}
+}
+
 func (s *taskProcessorSuite) TestHandleTaskError_EntityNotExists() {
 	err := serviceerror.NewNotFound("")
 
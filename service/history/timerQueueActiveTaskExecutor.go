@@ -97,6 +97,8 @@ func (t *timerQueueActiveTaskExecutor) execute(
 		return t.executeActivityRetryTimerTask(timerTask)
 	case persistence.TaskTypeWorkflowBackoffTimer:
 		return t.executeWorkflowBackoffTimerTask(timerTask)
+	case persistence.TaskTypeCancellationGracePeriod:
+		return t.executeCancellationGracePeriodTask(timerTask)
 	case persistence.TaskTypeDeleteHistoryEvent:
 		return t.executeDeleteHistoryEventTask(timerTask)
 	default:
@@ -361,6 +363,36 @@ func (t *timerQueueActiveTaskExecutor) executeWorkflowBackoffTimerTask(
 	return t.updateWorkflowExecution(weContext, mutableState, true)
 }
 
+func (t *timerQueueActiveTaskExecutor) executeCancellationGracePeriodTask(
+	task *persistenceblobs.TimerTaskInfo,
+) (retError error) {
+
+	weContext, release, err := t.cache.getOrCreateWorkflowExecutionForBackground(
+		t.getNamespaceIDAndWorkflowExecution(task),
+	)
+	if err != nil {
+		return err
+	}
+	defer func() { release(retError) }()
+
+	mutableState, err := loadMutableStateForTimerTask(weContext, task, t.metricsClient, t.logger)
+	if err != nil {
+		return err
+	}
+	if mutableState == nil || !mutableState.IsWorkflowExecutionRunning() {
+		return nil
+	}
+	if mutableState.GetExecutionInfo().CancelGracePeriodCompletedEventID == 0 {
+		return nil
+	}
+
+	// grace period of a cancel workflow decision is over, cancel the workflow
+	if err := cancelWorkflowAfterGracePeriod(mutableState, mutableState.GetNextEventID()); err != nil {
+		return err
+	}
+	return t.updateWorkflowExecution(weContext, mutableState, false)
+}
+
 func (t *timerQueueActiveTaskExecutor) executeActivityRetryTimerTask(
 	task *persistenceblobs.TimerTaskInfo,
 ) (retError error) {
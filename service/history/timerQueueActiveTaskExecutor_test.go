@@ -21,6 +21,7 @@
 package history
 
 import (
+	"bytes"
 	"testing"
 	"time"
 
@@ -32,6 +33,7 @@ import (
 	"github.com/stretchr/testify/suite"
 	"github.com/uber-go/tally"
 	commonpb "go.temporal.io/temporal-proto/common"
+	decisionpb "go.temporal.io/temporal-proto/decision"
 	eventpb "go.temporal.io/temporal-proto/event"
 	executionpb "go.temporal.io/temporal-proto/execution"
 	tasklistpb "go.temporal.io/temporal-proto/tasklist"
@@ -245,6 +247,84 @@ func (s *timerQueueActiveTaskExecutorSuite) TestProcessUserTimerTimeout_Fire() {
 	s.False(ok)
 }
 
+func (s *timerQueueActiveTaskExecutorSuite) TestProcessCancellationGracePeriod() {
+
+	execution := executionpb.WorkflowExecution{
+		WorkflowId: "some random workflow ID",
+		RunId:      uuid.New(),
+	}
+	workflowType := "some random workflow type"
+	taskListName := "some random task list"
+
+	mutableState := newMutableStateBuilderWithReplicationStateWithEventV2(
+		s.mockShard,
+		s.mockShard.GetEventsCache(),
+		s.logger,
+		s.version,
+		execution.GetRunId(),
+	)
+	_, err := mutableState.AddWorkflowExecutionStartedEvent(
+		execution,
+		&historyservice.StartWorkflowExecutionRequest{
+			NamespaceId: s.namespaceID,
+			StartRequest: &workflowservice.StartWorkflowExecutionRequest{
+				WorkflowType:                        &commonpb.WorkflowType{Name: workflowType},
+				TaskList:                            &tasklistpb.TaskList{Name: taskListName},
+				ExecutionStartToCloseTimeoutSeconds: 2,
+				TaskStartToCloseTimeoutSeconds:      1,
+			},
+		},
+	)
+	s.Nil(err)
+
+	di := addDecisionTaskScheduledEvent(mutableState)
+	event := addDecisionTaskStartedEvent(mutableState, di.ScheduleID, taskListName, uuid.New())
+	di.StartedID = event.GetEventId()
+	event = addDecisionTaskCompletedEvent(mutableState, di.ScheduleID, di.StartedID, nil, "some random identity")
+
+	gracePeriod := 2 * time.Second
+	details := []byte("some random cancellation details")
+	decisionCompletedEventID := event.GetEventId()
+	mutableState.insertTimerTasks = nil
+	event, err = mutableState.AddCancellationGracePeriod(
+		decisionCompletedEventID,
+		&decisionpb.CancelWorkflowExecutionDecisionAttributes{Details: details},
+		gracePeriod,
+	)
+	s.NoError(err)
+	task := mutableState.insertTimerTasks[0]
+	protoTaskTime, err := types.TimestampProto(task.(*persistence.CancellationGracePeriodTask).GetVisibilityTimestamp())
+	s.NoError(err)
+	timerTask := &persistenceblobs.TimerTaskInfo{
+		Version:             s.version,
+		NamespaceId:         primitives.MustParseUUID(s.namespaceID),
+		WorkflowId:          execution.GetWorkflowId(),
+		RunId:               primitives.MustParseUUID(execution.GetRunId()),
+		TaskId:              int64(100),
+		TaskType:            persistence.TaskTypeCancellationGracePeriod,
+		VisibilityTimestamp: protoTaskTime,
+	}
+
+	persistenceMutableState := s.createPersistenceMutableState(mutableState, event.GetEventId(), event.GetVersion())
+	s.mockExecutionMgr.On("GetWorkflowExecution", mock.Anything).Return(&persistence.GetWorkflowExecutionResponse{State: persistenceMutableState}, nil)
+	s.mockHistoryV2Mgr.On("AppendHistoryNodes", mock.MatchedBy(func(request *persistence.AppendHistoryNodesRequest) bool {
+		canceledEvent := request.Events[len(request.Events)-1]
+		attributes := canceledEvent.GetWorkflowExecutionCanceledEventAttributes()
+		return canceledEvent.GetEventType() == eventpb.EventTypeWorkflowExecutionCanceled &&
+			attributes.GetDecisionTaskCompletedEventId() == decisionCompletedEventID &&
+			bytes.Equal(details, attributes.GetDetails())
+	})).Return(&persistence.AppendHistoryNodesResponse{Size: 0}, nil).Once()
+	s.mockExecutionMgr.On("UpdateWorkflowExecution", mock.Anything).Return(&persistence.UpdateWorkflowExecutionResponse{MutableStateUpdateSessionStats: &persistence.MutableStateUpdateSessionStats{}}, nil).Once()
+
+	s.timeSource.Update(s.now.Add(2 * gracePeriod))
+	err = s.timerQueueActiveTaskExecutor.execute(timerTask, true)
+	s.NoError(err)
+
+	state, status := s.getMutableStateFromCache(s.namespaceID, execution.GetWorkflowId(), execution.GetRunId()).GetWorkflowStateStatus()
+	s.Equal(persistence.WorkflowStateCompleted, state)
+	s.EqualValues(executionpb.WorkflowExecutionStatusCanceled, status)
+}
+
 func (s *timerQueueActiveTaskExecutorSuite) TestProcessUserTimerTimeout_Noop() {
 
 	execution := executionpb.WorkflowExecution{
@@ -371,6 +371,8 @@ func (t *timerQueueProcessorBase) getTimerTaskType(
 		return "ActivityRetryTimerTask"
 	case persistence.TaskTypeWorkflowBackoffTimer:
 		return "WorkflowBackoffTimerTask"
+	case persistence.TaskTypeCancellationGracePeriod:
+		return "CancellationGracePeriod"
 	}
 	return "UnKnown"
 }
@@ -415,6 +417,11 @@ func (t *timerQueueProcessorBase) getTimerTaskMetricScope(
 			return metrics.TimerActiveTaskWorkflowBackoffTimerScope
 		}
 		return metrics.TimerStandbyTaskWorkflowBackoffTimerScope
+	case persistence.TaskTypeCancellationGracePeriod:
+		if isActive {
+			return metrics.TimerActiveTaskCancellationGracePeriodScope
+		}
+		return metrics.TimerStandbyTaskCancellationGracePeriodScope
 	default:
 		if isActive {
 			return metrics.TimerActiveQueueProcessorScope
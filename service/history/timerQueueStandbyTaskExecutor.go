@@ -105,6 +105,8 @@ func (t *timerQueueStandbyTaskExecutor) execute(
 		return nil
 	case persistence.TaskTypeWorkflowBackoffTimer:
 		return t.executeWorkflowBackoffTimerTask(timerTask)
+	case persistence.TaskTypeCancellationGracePeriod:
+		return t.executeCancellationGracePeriodTask(timerTask)
 	case persistence.TaskTypeDeleteHistoryEvent:
 		return t.executeDeleteHistoryEventTask(timerTask)
 	default:
@@ -334,6 +336,40 @@ func (t *timerQueueStandbyTaskExecutor) executeWorkflowBackoffTimerTask(
 	)
 }
 
+func (t *timerQueueStandbyTaskExecutor) executeCancellationGracePeriodTask(
+	timerTask *persistenceblobs.TimerTaskInfo,
+) error {
+
+	actionFn := func(context workflowExecutionContext, mutableState mutableState) (interface{}, error) {
+
+		if mutableState.GetExecutionInfo().CancelGracePeriodCompletedEventID == 0 {
+			return nil, nil
+		}
+
+		// Note: do not need to verify task version here
+		// the cancellation grace period marker is recorded at most once per run and is only
+		// followed by the canceled event, which closes the workflow
+
+		// active cluster will add the canceled event once the grace period is over,
+		// standby cluster should just call ack manager to retry this task
+		// since we are still waiting for the canceled event to be replicated from active side.
+		return getHistoryResendInfo(mutableState)
+	}
+
+	return t.processTimer(
+		timerTask,
+		actionFn,
+		getStandbyPostActionFn(
+			timerTask,
+			t.getCurrentTime,
+			t.config.StandbyTaskMissingEventsResendDelay(),
+			t.config.StandbyTaskMissingEventsDiscardDelay(),
+			t.fetchHistoryFromRemote,
+			standbyTimerTaskPostActionTaskDiscarded,
+		),
+	)
+}
+
 func (t *timerQueueStandbyTaskExecutor) executeWorkflowTimeoutTask(
 	timerTask *persistenceblobs.TimerTaskInfo,
 ) error {
@@ -266,8 +266,11 @@ func (t *transferQueueActiveTaskExecutor) processCloseExecution(
 	workflowStartTimestamp := startEvent.GetTimestamp()
 	workflowExecutionTimestamp := getWorkflowExecutionTimestamp(mutableState, startEvent)
 	visibilityMemo := getWorkflowMemo(executionInfo.Memo)
-	searchAttr := executionInfo.SearchAttributes
 	namespace := mutableState.GetNamespaceEntry().GetInfo().Name
+	searchAttr, err := t.getCloseSearchAttributes(namespace, executionInfo.SearchAttributes, completionEvent)
+	if err != nil {
+		return err
+	}
 	children := mutableState.GetPendingChildExecutionInfos()
 
 	// release the context lock since we no longer need mutable state builder and
@@ -458,6 +461,8 @@ func (t *transferQueueActiveTaskExecutor) processSignalExecution(
 		)
 	}
 
+	// without a target run ID the signal goes to the current run of the target workflow,
+	// and fails as any other non-retryable error when that run is already closed
 	if err = t.signalExternalExecutionWithRetry(
 		task,
 		targetNamespace,
@@ -51,6 +51,7 @@ import (
 	"github.com/temporalio/temporal/common/cache"
 	"github.com/temporalio/temporal/common/clock"
 	"github.com/temporalio/temporal/common/cluster"
+	"github.com/temporalio/temporal/common/definition"
 	"github.com/temporalio/temporal/common/log"
 	"github.com/temporalio/temporal/common/metrics"
 	"github.com/temporalio/temporal/common/mocks"
@@ -723,6 +724,62 @@ func (s *transferQueueActiveTaskExecutorSuite) TestProcessCloseExecution_NoParen
 	s.Nil(err)
 }
 
+func (s *transferQueueActiveTaskExecutorSuite) TestProcessCloseExecution_FailureCategory() {
+	s.transferQueueActiveTaskExecutor.config.EnableStructuredFailureDetails = dc.GetBoolPropertyFnFilteredByNamespace(true)
+
+	execution := executionpb.WorkflowExecution{
+		WorkflowId: "some random workflow ID",
+		RunId:      uuid.New(),
+	}
+	workflowType := "some random workflow type"
+	taskListName := "some random task list"
+
+	mutableState := newMutableStateBuilderWithReplicationStateWithEventV2(s.mockShard, s.mockShard.GetEventsCache(), s.logger, s.version, execution.GetRunId())
+	_, err := mutableState.AddWorkflowExecutionStartedEvent(
+		execution,
+		&historyservice.StartWorkflowExecutionRequest{
+			NamespaceId: s.namespaceID,
+			StartRequest: &workflowservice.StartWorkflowExecutionRequest{
+				WorkflowType:                        &commonpb.WorkflowType{Name: workflowType},
+				TaskList:                            &tasklistpb.TaskList{Name: taskListName},
+				ExecutionStartToCloseTimeoutSeconds: 2,
+				TaskStartToCloseTimeoutSeconds:      1,
+			},
+		},
+	)
+	s.Nil(err)
+
+	di := addDecisionTaskScheduledEvent(mutableState)
+	event := addDecisionTaskStartedEvent(mutableState, di.ScheduleID, taskListName, uuid.New())
+	di.StartedID = event.GetEventId()
+	event = addDecisionTaskCompletedEvent(mutableState, di.ScheduleID, di.StartedID, nil, "some random identity")
+
+	taskID := int64(59)
+	event = addFailWorkflowEvent(mutableState, event.GetEventId(), "some random reason", []byte(`{"category":"Dependency"}`))
+
+	transferTask := &persistenceblobs.TransferTaskInfo{
+		Version:     s.version,
+		NamespaceId: s.GetNamespaceIDBytes(),
+		WorkflowId:  execution.GetWorkflowId(),
+		RunId:       primitives.MustParseUUID(execution.GetRunId()),
+		TaskId:      taskID,
+		TaskList:    taskListName,
+		TaskType:    persistence.TransferTaskTypeCloseExecution,
+		ScheduleId:  event.GetEventId(),
+	}
+
+	persistenceMutableState := s.createPersistenceMutableState(mutableState, event.GetEventId(), event.GetVersion())
+	s.mockExecutionMgr.On("GetWorkflowExecution", mock.Anything).Return(&persistence.GetWorkflowExecutionResponse{State: persistenceMutableState}, nil)
+	s.mockVisibilityMgr.On("RecordWorkflowExecutionClosed", mock.MatchedBy(func(request *persistence.RecordWorkflowExecutionClosedRequest) bool {
+		return string(request.SearchAttributes[definition.FailureCategory]) == `"Dependency"`
+	})).Return(nil).Once()
+	s.mockArchivalMetadata.On("GetVisibilityConfig").Return(archiver.NewArchivalConfig("enabled", dc.GetStringPropertyFn("enabled"), dc.GetBoolPropertyFn(true), "disabled", "random URI"))
+	s.mockArchivalClient.On("Archive", mock.Anything, mock.Anything).Return(nil, nil).Once()
+
+	err = s.transferQueueActiveTaskExecutor.execute(transferTask, true)
+	s.Nil(err)
+}
+
 func (s *transferQueueActiveTaskExecutorSuite) TestProcessCloseExecution_NoParent_HasFewChildren() {
 
 	execution := executionpb.WorkflowExecution{
@@ -1363,6 +1420,92 @@ func (s *transferQueueActiveTaskExecutorSuite) TestProcessSignalExecution_Failur
 	s.Nil(err)
 }
 
+func (s *transferQueueActiveTaskExecutorSuite) TestProcessSignalExecution_LatestRun() {
+	transferTask, si := s.prepareProcessSignalExecutionLatestRun()
+	s.mockHistoryClient.EXPECT().SignalWorkflowExecution(gomock.Any(), s.createSignalWorkflowExecutionRequest(s.targetNamespace, transferTask, si)).Return(nil, nil).Times(1)
+	s.mockHistoryV2Mgr.On("AppendHistoryNodes", mock.MatchedBy(func(request *p.AppendHistoryNodesRequest) bool {
+		return len(request.Events) > 0 && request.Events[0].GetEventType() == eventpb.EventTypeExternalWorkflowExecutionSignaled
+	})).Return(&p.AppendHistoryNodesResponse{Size: 0}, nil).Once()
+	s.mockExecutionMgr.On("UpdateWorkflowExecution", mock.Anything).Return(&p.UpdateWorkflowExecutionResponse{MutableStateUpdateSessionStats: &p.MutableStateUpdateSessionStats{}}, nil).Once()
+	s.mockHistoryClient.EXPECT().RemoveSignalMutableState(gomock.Any(), &historyservice.RemoveSignalMutableStateRequest{
+		NamespaceId: primitives.UUID(transferTask.GetTargetNamespaceId()).String(),
+		WorkflowExecution: &executionpb.WorkflowExecution{
+			WorkflowId: transferTask.GetTargetWorkflowId(),
+			RunId:      "",
+		},
+		RequestId: si.GetRequestId(),
+	}).Return(nil, nil).Times(1)
+
+	err := s.transferQueueActiveTaskExecutor.execute(transferTask, true)
+	s.Nil(err)
+}
+
+func (s *transferQueueActiveTaskExecutorSuite) TestProcessSignalExecution_LatestRunNotOpen() {
+	transferTask, si := s.prepareProcessSignalExecutionLatestRun()
+	s.mockHistoryClient.EXPECT().SignalWorkflowExecution(gomock.Any(), s.createSignalWorkflowExecutionRequest(s.targetNamespace, transferTask, si)).Return(nil, ErrWorkflowCompleted).Times(1)
+	s.mockHistoryV2Mgr.On("AppendHistoryNodes", mock.MatchedBy(func(request *p.AppendHistoryNodesRequest) bool {
+		return len(request.Events) > 0 && request.Events[0].GetEventType() == eventpb.EventTypeSignalExternalWorkflowExecutionFailed
+	})).Return(&p.AppendHistoryNodesResponse{Size: 0}, nil).Once()
+	s.mockExecutionMgr.On("UpdateWorkflowExecution", mock.Anything).Return(&p.UpdateWorkflowExecutionResponse{MutableStateUpdateSessionStats: &p.MutableStateUpdateSessionStats{}}, nil).Once()
+
+	err := s.transferQueueActiveTaskExecutor.execute(transferTask, true)
+	s.Nil(err)
+}
+
+// prepareProcessSignalExecutionLatestRun sets up a workflow with a pending signal to the latest run of an external workflow
+func (s *transferQueueActiveTaskExecutorSuite) prepareProcessSignalExecutionLatestRun() (*persistenceblobs.TransferTaskInfo, *persistenceblobs.SignalInfo) {
+	execution := executionpb.WorkflowExecution{
+		WorkflowId: "some random workflow ID",
+		RunId:      uuid.New(),
+	}
+	workflowType := "some random workflow type"
+	taskListName := "some random task list"
+	targetWorkflowID := "some random target workflow ID"
+
+	mutableState := newMutableStateBuilderWithReplicationStateWithEventV2(s.mockShard, s.mockShard.GetEventsCache(), s.logger, s.version, execution.GetRunId())
+	_, err := mutableState.AddWorkflowExecutionStartedEvent(
+		execution,
+		&historyservice.StartWorkflowExecutionRequest{
+			NamespaceId: s.namespaceID,
+			StartRequest: &workflowservice.StartWorkflowExecutionRequest{
+				WorkflowType:                        &commonpb.WorkflowType{Name: workflowType},
+				TaskList:                            &tasklistpb.TaskList{Name: taskListName},
+				ExecutionStartToCloseTimeoutSeconds: 2,
+				TaskStartToCloseTimeoutSeconds:      1,
+			},
+		},
+	)
+	s.Nil(err)
+
+	di := addDecisionTaskScheduledEvent(mutableState)
+	event := addDecisionTaskStartedEvent(mutableState, di.ScheduleID, taskListName, uuid.New())
+	di.StartedID = event.GetEventId()
+	event = addDecisionTaskCompletedEvent(mutableState, di.ScheduleID, di.StartedID, nil, "some random identity")
+
+	event, si := addRequestSignalInitiatedEvent(mutableState, event.GetEventId(), uuid.New(),
+		testTargetNamespace, targetWorkflowID, "", "some random signal name", []byte("some random signal input"), nil)
+	s.Equal("", event.GetSignalExternalWorkflowExecutionInitiatedEventAttributes().GetWorkflowExecution().GetRunId())
+
+	transferTask := &persistenceblobs.TransferTaskInfo{
+		Version:           s.version,
+		NamespaceId:       s.GetNamespaceIDBytes(),
+		WorkflowId:        execution.GetWorkflowId(),
+		RunId:             primitives.MustParseUUID(execution.GetRunId()),
+		TargetNamespaceId: primitives.MustParseUUID(s.targetNamespaceID),
+		TargetWorkflowId:  targetWorkflowID,
+		TargetRunId:       primitives.MustParseUUID(""),
+		TaskId:            int64(59),
+		TaskList:          taskListName,
+		TaskType:          persistence.TransferTaskTypeSignalExecution,
+		ScheduleId:        event.GetEventId(),
+	}
+
+	persistenceMutableState := s.createPersistenceMutableState(mutableState, event.GetEventId(), event.GetVersion())
+	s.mockExecutionMgr.On("GetWorkflowExecution", mock.Anything).Return(&persistence.GetWorkflowExecutionResponse{State: persistenceMutableState}, nil)
+	s.mockClusterMetadata.EXPECT().ClusterNameForFailoverVersion(s.version).Return(cluster.TestCurrentClusterName).AnyTimes()
+	return transferTask, si
+}
+
 func (s *transferQueueActiveTaskExecutorSuite) TestProcessSignalExecution_Duplication() {
 
 	execution := executionpb.WorkflowExecution{
@@ -229,7 +229,14 @@ func (t *transferQueueStandbyTaskExecutor) processCloseExecution(
 		workflowStartTimestamp := startEvent.GetTimestamp()
 		workflowExecutionTimestamp := getWorkflowExecutionTimestamp(mutableState, startEvent)
 		visibilityMemo := getWorkflowMemo(executionInfo.Memo)
-		searchAttr := executionInfo.SearchAttributes
+		searchAttr, err := t.getCloseSearchAttributes(
+			mutableState.GetNamespaceEntry().GetInfo().Name,
+			executionInfo.SearchAttributes,
+			completionEvent,
+		)
+		if err != nil {
+			return nil, err
+		}
 
 		lastWriteVersion, err := mutableState.GetLastWriteVersion()
 		if err != nil {
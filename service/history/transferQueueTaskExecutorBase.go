@@ -22,6 +22,7 @@ package history
 
 import (
 	"context"
+	"encoding/json"
 	"time"
 
 	commonpb "go.temporal.io/temporal-proto/common"
@@ -36,6 +37,7 @@ import (
 	"github.com/temporalio/temporal/.gen/proto/persistenceblobs"
 	"github.com/temporalio/temporal/client/matching"
 	"github.com/temporalio/temporal/common"
+	"github.com/temporalio/temporal/common/definition"
 	"github.com/temporalio/temporal/common/log"
 	"github.com/temporalio/temporal/common/log/tag"
 	"github.com/temporalio/temporal/common/metrics"
@@ -372,6 +374,43 @@ func copySearchAttributes(
 	return result
 }
 
+// getCloseSearchAttributes returns the search attributes of the close visibility record. With structured failure
+// details enabled, the category of the failure which closed the workflow is mirrored into the FailureCategory
+// search attribute. It is derived from the completion event, which standby clusters replicate as well, so
+// that no history event has to be written for it.
+func (t *transferQueueTaskExecutorBase) getCloseSearchAttributes(
+	namespace string,
+	searchAttributes map[string][]byte,
+	completionEvent *eventpb.HistoryEvent,
+) (map[string][]byte, error) {
+
+	if !t.config.EnableStructuredFailureDetails(namespace) {
+		return searchAttributes, nil
+	}
+
+	var details []byte
+	switch completionEvent.GetEventType() {
+	case eventpb.EventTypeWorkflowExecutionFailed:
+		details = completionEvent.GetWorkflowExecutionFailedEventAttributes().GetDetails()
+	case eventpb.EventTypeWorkflowExecutionContinuedAsNew:
+		// a workflow retried or run by its cron schedule after failing continues as new with the failure
+		details = completionEvent.GetWorkflowExecutionContinuedAsNewEventAttributes().GetFailureDetails()
+	}
+	failureCategory := getFailureCategory(details)
+	if failureCategory == "" {
+		return searchAttributes, nil
+	}
+
+	value, err := json.Marshal(failureCategory)
+	if err != nil {
+		return nil, err
+	}
+	return mergeMapOfByteArray(
+		copySearchAttributes(searchAttributes),
+		map[string][]byte{definition.FailureCategory: value},
+	), nil
+}
+
 func isWorkflowNotExistError(err error) bool {
 	_, ok := err.(*serviceerror.NotFound)
 	return ok
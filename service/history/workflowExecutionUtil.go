@@ -190,6 +190,36 @@ func timeoutWorkflow(
 	return err
 }
 
+func cancelWorkflowAfterGracePeriod(
+	mutableState mutableState,
+	eventBatchFirstEventID int64,
+) error {
+
+	if decision, ok := mutableState.GetInFlightDecision(); ok {
+		if err := failDecision(
+			mutableState,
+			decision,
+			eventpb.DecisionTaskFailedCauseForceCloseDecision,
+		); err != nil {
+			return err
+		}
+	}
+
+	// the canceled event belongs to the decision which requested the cancellation,
+	// but it is written in the batch of this grace period task
+	executionInfo := mutableState.GetExecutionInfo()
+	if _, err := mutableState.AddWorkflowExecutionCanceledEvent(
+		executionInfo.CancelGracePeriodCompletedEventID,
+		&decisionpb.CancelWorkflowExecutionDecisionAttributes{
+			Details: executionInfo.CancelGracePeriodDetails,
+		},
+	); err != nil {
+		return err
+	}
+	executionInfo.CompletionEventBatchID = eventBatchFirstEventID
+	return nil
+}
+
 func terminateWorkflow(
 	mutableState mutableState,
 	eventBatchFirstEventID int64,
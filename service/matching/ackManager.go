@@ -112,6 +112,39 @@ func (m *ackManager) completeTask(taskID int64) (ackLevel int64) {
 	return m.ackLevel
 }
 
+// completeTasksBelow acks all tasks with a task id at or below the given one, delivered or not, and moves
+// the ack level and the read level past them. Returns the new ack level.
+func (m *ackManager) completeTasksBelow(taskID int64) int64 {
+	m.Lock()
+	defer m.Unlock()
+	for id, acked := range m.outstandingTasks {
+		if id > taskID {
+			continue
+		}
+		if !acked {
+			m.backlogCounter.Dec()
+		}
+		delete(m.outstandingTasks, id)
+	}
+	if taskID > m.ackLevel {
+		m.ackLevel = taskID
+	}
+	if taskID > m.readLevel {
+		m.readLevel = taskID
+	}
+	// tasks above the completed ones which were already acked can now move the ack level
+	for current := m.ackLevel + 1; current <= m.readLevel; current++ {
+		if acked, ok := m.outstandingTasks[current]; ok {
+			if !acked {
+				break
+			}
+			m.ackLevel = current
+			delete(m.outstandingTasks, current)
+		}
+	}
+	return m.ackLevel
+}
+
 func (m *ackManager) getBacklogCountHint() int64 {
 	return m.backlogCounter.Load()
 }
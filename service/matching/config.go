@@ -47,16 +47,32 @@ type (
 		ForwarderMaxOutstandingTasks dynamicconfig.IntPropertyFnWithTaskListInfoFilters
 		ForwarderMaxRatePerSecond    dynamicconfig.IntPropertyFnWithTaskListInfoFilters
 		ForwarderMaxChildrenPerNode  dynamicconfig.IntPropertyFnWithTaskListInfoFilters
+		ForwarderBreakerThreshold    dynamicconfig.IntPropertyFnWithTaskListInfoFilters
+		ForwarderBreakerCooldown     dynamicconfig.DurationPropertyFnWithTaskListInfoFilters
+
+		// PartitionRouter computes the task list partition names and the tree formed by them
+		PartitionRouter PartitionRouter
 
 		// Time to hold a poll request before returning an empty response if there are no tasks
 		LongPollExpirationInterval dynamicconfig.DurationPropertyFnWithTaskListInfoFilters
+		// Minimum time to hold a poll request, even if the poller's deadline is shorter
+		MinPollTimeout             dynamicconfig.DurationPropertyFnWithTaskListInfoFilters
 		MinTaskThrottlingBurstSize dynamicconfig.IntPropertyFnWithTaskListInfoFilters
 		MaxTaskDeleteBatchSize     dynamicconfig.IntPropertyFnWithTaskListInfoFilters
+		// Backlog size above which new tasks are shed, zero disables shedding
+		MatcherMaxBacklogForOffer dynamicconfig.IntPropertyFnWithTaskListInfoFilters
+		// Window over which the sync match to backlog match ratio is computed
+		MatchRatioWindow dynamicconfig.DurationPropertyFnWithTaskListInfoFilters
+		// Time since its last poll after which a poller is no longer reported by DescribeTaskList
+		PollerStalenessWindow dynamicconfig.DurationPropertyFnWithTaskListInfoFilters
 
 		// taskWriter configuration
 		OutstandingTaskAppendsThreshold dynamicconfig.IntPropertyFnWithTaskListInfoFilters
 		MaxTaskBatchSize                dynamicconfig.IntPropertyFnWithTaskListInfoFilters
 
+		// Allows the InjectTask API to write synthetic tasks into task lists, for development only
+		EnableTaskInjection dynamicconfig.BoolPropertyFn
+
 		ThrottledLogRPS dynamicconfig.IntPropertyFn
 	}
 
@@ -65,6 +81,9 @@ type (
 		ForwarderMaxOutstandingTasks func() int
 		ForwarderMaxRatePerSecond    func() int
 		ForwarderMaxChildrenPerNode  func() int
+		ForwarderBreakerThreshold    func() int
+		ForwarderBreakerCooldown     func() time.Duration
+		PartitionRouter              PartitionRouter
 	}
 
 	taskListConfig struct {
@@ -72,6 +91,8 @@ type (
 		EnableSyncMatch func() bool
 		// Time to hold a poll request before returning an empty response if there are no tasks
 		LongPollExpirationInterval func() time.Duration
+		// Minimum time to hold a poll request, even if the poller's deadline is shorter
+		MinPollTimeout             func() time.Duration
 		RangeSize                  int64
 		GetTasksBatchSize          func() int
 		UpdateAckInterval          func() time.Duration
@@ -79,6 +100,9 @@ type (
 		MaxTasklistIdleTime        func() time.Duration
 		MinTaskThrottlingBurstSize func() int
 		MaxTaskDeleteBatchSize     func() int
+		MatcherMaxBacklogForOffer  func() int
+		MatchRatioWindow           func() time.Duration
+		PollerStalenessWindow      func() time.Duration
 		// taskWriter configuration
 		OutstandingTaskAppendsThreshold func() int
 		MaxTaskBatchSize                func() int
@@ -99,8 +123,12 @@ func NewConfig(dc *dynamicconfig.Collection) *Config {
 		IdleTasklistCheckInterval:       dc.GetDurationPropertyFilteredByTaskListInfo(dynamicconfig.MatchingIdleTasklistCheckInterval, 5*time.Minute),
 		MaxTasklistIdleTime:             dc.GetDurationPropertyFilteredByTaskListInfo(dynamicconfig.MaxTasklistIdleTime, 5*time.Minute),
 		LongPollExpirationInterval:      dc.GetDurationPropertyFilteredByTaskListInfo(dynamicconfig.MatchingLongPollExpirationInterval, time.Minute),
+		MinPollTimeout:                  dc.GetDurationPropertyFilteredByTaskListInfo(dynamicconfig.MatchingMinPollTimeout, 0),
 		MinTaskThrottlingBurstSize:      dc.GetIntPropertyFilteredByTaskListInfo(dynamicconfig.MatchingMinTaskThrottlingBurstSize, 1),
 		MaxTaskDeleteBatchSize:          dc.GetIntPropertyFilteredByTaskListInfo(dynamicconfig.MatchingMaxTaskDeleteBatchSize, 100),
+		MatcherMaxBacklogForOffer:       dc.GetIntPropertyFilteredByTaskListInfo(dynamicconfig.MatchingMaxBacklogForOffer, 0),
+		MatchRatioWindow:                dc.GetDurationPropertyFilteredByTaskListInfo(dynamicconfig.MatchingMatchRatioWindow, time.Minute),
+		PollerStalenessWindow:           dc.GetDurationPropertyFilteredByTaskListInfo(dynamicconfig.MatchingPollerStalenessWindow, pollerHistoryTTL),
 		OutstandingTaskAppendsThreshold: dc.GetIntPropertyFilteredByTaskListInfo(dynamicconfig.MatchingOutstandingTaskAppendsThreshold, 250),
 		MaxTaskBatchSize:                dc.GetIntPropertyFilteredByTaskListInfo(dynamicconfig.MatchingMaxTaskBatchSize, 100),
 		ThrottledLogRPS:                 dc.GetIntProperty(dynamicconfig.MatchingThrottledLogRPS, 20),
@@ -110,6 +138,10 @@ func NewConfig(dc *dynamicconfig.Collection) *Config {
 		ForwarderMaxOutstandingTasks:    dc.GetIntPropertyFilteredByTaskListInfo(dynamicconfig.MatchingForwarderMaxOutstandingTasks, 1),
 		ForwarderMaxRatePerSecond:       dc.GetIntPropertyFilteredByTaskListInfo(dynamicconfig.MatchingForwarderMaxRatePerSecond, 10),
 		ForwarderMaxChildrenPerNode:     dc.GetIntPropertyFilteredByTaskListInfo(dynamicconfig.MatchingForwarderMaxChildrenPerNode, 20),
+		ForwarderBreakerThreshold:       dc.GetIntPropertyFilteredByTaskListInfo(dynamicconfig.MatchingForwarderBreakerThreshold, 0),
+		ForwarderBreakerCooldown:        dc.GetDurationPropertyFilteredByTaskListInfo(dynamicconfig.MatchingForwarderBreakerCooldown, 10*time.Second),
+		EnableTaskInjection:             dc.GetBoolProperty(dynamicconfig.MatchingEnableTaskInjection, false),
+		PartitionRouter:                 NewDefaultPartitionRouter(),
 	}
 }
 
@@ -122,6 +154,7 @@ func newTaskListConfig(id *taskListID, config *Config, namespaceCache cache.Name
 	namespace := namespaceEntry.GetInfo().Name
 	taskListName := id.name
 	taskType := id.taskType
+	partitionRouter := config.PartitionRouter
 	return &taskListConfig{
 		RangeSize: config.RangeSize,
 		GetTasksBatchSize: func() int {
@@ -145,9 +178,21 @@ func newTaskListConfig(id *taskListID, config *Config, namespaceCache cache.Name
 		LongPollExpirationInterval: func() time.Duration {
 			return config.LongPollExpirationInterval(namespace, taskListName, taskType)
 		},
+		MinPollTimeout: func() time.Duration {
+			return config.MinPollTimeout(namespace, taskListName, taskType)
+		},
 		MaxTaskDeleteBatchSize: func() int {
 			return config.MaxTaskDeleteBatchSize(namespace, taskListName, taskType)
 		},
+		MatcherMaxBacklogForOffer: func() int {
+			return config.MatcherMaxBacklogForOffer(namespace, taskListName, taskType)
+		},
+		MatchRatioWindow: func() time.Duration {
+			return config.MatchRatioWindow(namespace, taskListName, taskType)
+		},
+		PollerStalenessWindow: func() time.Duration {
+			return config.PollerStalenessWindow(namespace, taskListName, taskType)
+		},
 		OutstandingTaskAppendsThreshold: func() int {
 			return config.OutstandingTaskAppendsThreshold(namespace, taskListName, taskType)
 		},
@@ -155,10 +200,10 @@ func newTaskListConfig(id *taskListID, config *Config, namespaceCache cache.Name
 			return config.MaxTaskBatchSize(namespace, taskListName, taskType)
 		},
 		NumWritePartitions: func() int {
-			return common.MaxInt(1, config.NumTasklistWritePartitions(namespace, taskListName, taskType))
+			return numPartitions(partitionRouter, id.baseName, config.NumTasklistWritePartitions(namespace, taskListName, taskType))
 		},
 		NumReadPartitions: func() int {
-			return common.MaxInt(1, config.NumTasklistReadPartitions(namespace, taskListName, taskType))
+			return numPartitions(partitionRouter, id.baseName, config.NumTasklistReadPartitions(namespace, taskListName, taskType))
 		},
 		forwarderConfig: forwarderConfig{
 			ForwarderMaxOutstandingPolls: func() int {
@@ -173,6 +218,13 @@ func newTaskListConfig(id *taskListID, config *Config, namespaceCache cache.Name
 			ForwarderMaxChildrenPerNode: func() int {
 				return common.MaxInt(1, config.ForwarderMaxChildrenPerNode(namespace, taskListName, taskType))
 			},
+			ForwarderBreakerThreshold: func() int {
+				return config.ForwarderBreakerThreshold(namespace, taskListName, taskType)
+			},
+			ForwarderBreakerCooldown: func() time.Duration {
+				return config.ForwarderBreakerCooldown(namespace, taskListName, taskType)
+			},
+			PartitionRouter: partitionRouter,
 		},
 	}, nil
 }
@@ -24,6 +24,7 @@ import (
 	"context"
 	"errors"
 	"math"
+	"sync"
 	"sync/atomic"
 	"time"
 
@@ -34,6 +35,7 @@ import (
 
 	"github.com/temporalio/temporal/.gen/proto/matchingservice"
 	"github.com/temporalio/temporal/client/matching"
+	"github.com/temporalio/temporal/common/clock"
 	"github.com/temporalio/temporal/common/metrics"
 	"github.com/temporalio/temporal/common/persistence"
 	"github.com/temporalio/temporal/common/primitives"
@@ -67,6 +69,11 @@ type (
 		// adjusts rate based on ServiceBusy errors from API calls
 		limiter *quotas.DynamicRateLimiter
 
+		// breaker stops forwarding for a cooldown period after
+		// consecutive failures to reach the parent partition
+		breaker    forwarderBreaker
+		timeSource clock.TimeSource
+
 		// cached metric scopes for API calls
 		//nolint
 		scope struct {
@@ -82,13 +89,28 @@ type (
 	ForwarderReqToken struct {
 		ch chan *ForwarderReqToken
 	}
+	// forwarderBreaker is the circuit breaker state of a forwarder
+	forwarderBreaker struct {
+		sync.Mutex
+		state               int
+		consecutiveFailures int
+		openedAt            time.Time
+	}
+)
+
+// forwarder circuit breaker states, also emitted as the breaker state gauge
+const (
+	breakerClosed = iota
+	breakerOpen
+	breakerHalfOpen
 )
 
 var (
-	errNoParent            = errors.New("cannot find parent task list for forwarding")
-	errTaskListKind        = errors.New("forwarding is not supported on sticky task list")
-	errInvalidTaskListType = errors.New("unrecognized task list type")
-	errForwarderSlowDown   = errors.New("limit exceeded")
+	errNoParent             = errors.New("cannot find parent task list for forwarding")
+	errTaskListKind         = errors.New("forwarding is not supported on sticky task list")
+	errInvalidTaskListType  = errors.New("unrecognized task list type")
+	errForwarderSlowDown    = errors.New("limit exceeded")
+	errForwarderBreakerOpen = errors.New("forwarding is paused after consecutive failures to reach parent task list")
 )
 
 // noopForwarderTokenC refers to a token channel that blocks forever
@@ -103,6 +125,7 @@ var noopForwarderTokenC <-chan *ForwarderReqToken = make(chan *ForwarderReqToken
 //  - errNoParent: If this task list doesn't have a parent to forward to
 //  - errTaskListKind: If the task list is a sticky task list. Sticky task lists are never partitioned
 //  - errForwarderSlowDown: When the rate limit is exceeded
+//  - errForwarderBreakerOpen: When forwarding is paused after consecutive failures to reach the parent
 //  - errInvalidTaskType: If the task list type is invalid
 func newForwarder(
 	cfg *forwarderConfig,
@@ -120,6 +143,7 @@ func newForwarder(
 		outstandingTasksLimit: int32(cfg.ForwarderMaxOutstandingTasks()),
 		outstandingPollsLimit: int32(cfg.ForwarderMaxOutstandingPolls()),
 		limiter:               quotas.NewDynamicRateLimiter(rpsFunc),
+		timeSource:            clock.NewRealTimeSource(),
 		scopeFunc:             scopeFunc,
 	}
 	fwdr.addReqToken.Store(newForwarderReqToken(cfg.ForwarderMaxOutstandingTasks()))
@@ -133,7 +157,7 @@ func (fwdr *Forwarder) ForwardTask(ctx context.Context, task *internalTask) erro
 		return errTaskListKind
 	}
 
-	name := fwdr.taskListID.Parent(fwdr.cfg.ForwarderMaxChildrenPerNode())
+	name := fwdr.parentName()
 	if name == "" {
 		return errNoParent
 	}
@@ -142,11 +166,16 @@ func (fwdr *Forwarder) ForwardTask(ctx context.Context, task *internalTask) erro
 		return errForwarderSlowDown
 	}
 
+	if !fwdr.allowForward() {
+		return errForwarderBreakerOpen
+	}
+
 	var err error
 
 	// todo: Vet recomputing ScheduleToStart and rechecking expiry here
 	expiryGo, err := types.TimestampFromProto(task.event.Data.Expiry)
 	if err != nil {
+		fwdr.recordForwardResult(context.Canceled)
 		return err
 	}
 
@@ -186,9 +215,11 @@ func (fwdr *Forwarder) ForwardTask(ctx context.Context, task *internalTask) erro
 			ForwardedFrom:                 fwdr.taskListID.name,
 		})
 	default:
+		fwdr.recordForwardResult(context.Canceled)
 		return errInvalidTaskListType
 	}
 
+	fwdr.recordForwardResult(err)
 	return fwdr.handleErr(err)
 }
 
@@ -202,11 +233,15 @@ func (fwdr *Forwarder) ForwardQueryTask(
 		return nil, errTaskListKind
 	}
 
-	name := fwdr.taskListID.Parent(fwdr.cfg.ForwarderMaxChildrenPerNode())
+	name := fwdr.parentName()
 	if name == "" {
 		return nil, errNoParent
 	}
 
+	if !fwdr.allowForward() {
+		return nil, errForwarderBreakerOpen
+	}
+
 	resp, err := fwdr.client.QueryWorkflow(ctx, &matchingservice.QueryWorkflowRequest{
 		NamespaceId: task.query.request.GetNamespaceId(),
 		TaskList: &tasklistpb.TaskList{
@@ -217,6 +252,7 @@ func (fwdr *Forwarder) ForwardQueryTask(
 		ForwardedFrom: fwdr.taskListID.name,
 	})
 
+	fwdr.recordForwardResult(err)
 	return resp, fwdr.handleErr(err)
 }
 
@@ -226,11 +262,15 @@ func (fwdr *Forwarder) ForwardPoll(ctx context.Context) (*internalTask, error) {
 		return nil, errTaskListKind
 	}
 
-	name := fwdr.taskListID.Parent(fwdr.cfg.ForwarderMaxChildrenPerNode())
+	name := fwdr.parentName()
 	if name == "" {
 		return nil, errNoParent
 	}
 
+	if !fwdr.allowForward() {
+		return nil, errForwarderBreakerOpen
+	}
+
 	pollerID, _ := ctx.Value(pollerIDKey).(string)
 	identity, _ := ctx.Value(identityKey).(string)
 
@@ -248,6 +288,7 @@ func (fwdr *Forwarder) ForwardPoll(ctx context.Context) (*internalTask, error) {
 			},
 			ForwardedFrom: fwdr.taskListID.name,
 		})
+		fwdr.recordForwardResult(err)
 		if err != nil {
 			return nil, fwdr.handleErr(err)
 		}
@@ -265,12 +306,14 @@ func (fwdr *Forwarder) ForwardPoll(ctx context.Context) (*internalTask, error) {
 			},
 			ForwardedFrom: fwdr.taskListID.name,
 		})
+		fwdr.recordForwardResult(err)
 		if err != nil {
 			return nil, fwdr.handleErr(err)
 		}
 		return newInternalStartedTask(&startedTaskInfo{activityTaskInfo: resp}), nil
 	}
 
+	fwdr.recordForwardResult(context.Canceled)
 	return nil, errInvalidTaskListType
 }
 
@@ -299,6 +342,95 @@ func (fwdr *Forwarder) refreshTokenC(value *atomic.Value, curr *int32, maxLimit
 	}
 }
 
+// parentName returns the name of the parent task list partition, empty string if this is the root
+func (fwdr *Forwarder) parentName() string {
+	return parentPartitionName(
+		fwdr.cfg.PartitionRouter,
+		fwdr.taskListID.qualifiedTaskListName,
+		fwdr.cfg.ForwarderMaxChildrenPerNode(),
+	)
+}
+
+// isActive returns whether there is a parent partition to forward to and the breaker does not pause forwarding
+func (fwdr *Forwarder) isActive() bool {
+	if fwdr.taskListKind == tasklistpb.TaskListKindSticky || fwdr.parentName() == "" {
+		return false
+	}
+	fwdr.breaker.Lock()
+	defer fwdr.breaker.Unlock()
+	return fwdr.breaker.state != breakerOpen
+}
+
+// allowForward returns whether the breaker lets a forwarded call through. Once the cooldown of an open
+// breaker elapses, a single call is let through to probe the parent partition. Every allowed call must
+// be followed by a call to recordForwardResult
+func (fwdr *Forwarder) allowForward() bool {
+	if fwdr.cfg.ForwarderBreakerThreshold() <= 0 {
+		return true
+	}
+
+	fwdr.breaker.Lock()
+	defer fwdr.breaker.Unlock()
+	switch fwdr.breaker.state {
+	case breakerOpen:
+		if fwdr.timeSource.Now().Sub(fwdr.breaker.openedAt) < fwdr.cfg.ForwarderBreakerCooldown() {
+			return false
+		}
+		fwdr.setBreakerState(breakerHalfOpen)
+		return true
+	case breakerHalfOpen:
+		// a probe is already in flight
+		return false
+	default:
+		return true
+	}
+}
+
+// recordForwardResult updates the breaker with the outcome of a forwarded call. Only unavailable errors count
+// as failures to reach the parent, context errors say nothing about the parent and leave the breaker as is,
+// and every other outcome means the parent responded
+func (fwdr *Forwarder) recordForwardResult(err error) {
+	threshold := fwdr.cfg.ForwarderBreakerThreshold()
+	if threshold <= 0 {
+		return
+	}
+
+	fwdr.breaker.Lock()
+	defer fwdr.breaker.Unlock()
+	switch err.(type) {
+	case *serviceerror.Unavailable:
+		fwdr.breaker.consecutiveFailures++
+		if fwdr.breaker.state == breakerHalfOpen || fwdr.breaker.consecutiveFailures >= threshold {
+			fwdr.breaker.openedAt = fwdr.timeSource.Now()
+			fwdr.setBreakerState(breakerOpen)
+		}
+	case *serviceerror.DeadlineExceeded:
+		fwdr.releaseProbe()
+	default:
+		if err == context.Canceled || err == context.DeadlineExceeded {
+			fwdr.releaseProbe()
+			return
+		}
+		fwdr.breaker.consecutiveFailures = 0
+		fwdr.setBreakerState(breakerClosed)
+	}
+}
+
+// releaseProbe lets the next call probe the parent again when a probe ended without an answer from the parent
+func (fwdr *Forwarder) releaseProbe() {
+	if fwdr.breaker.state == breakerHalfOpen {
+		fwdr.setBreakerState(breakerOpen)
+	}
+}
+
+func (fwdr *Forwarder) setBreakerState(state int) {
+	if fwdr.breaker.state == state {
+		return
+	}
+	fwdr.breaker.state = state
+	fwdr.scopeFunc().UpdateGauge(metrics.ForwarderBreakerStateGauge, float64(state))
+}
+
 func (fwdr *Forwarder) handleErr(err error) error {
 	if _, ok := err.(*serviceerror.ResourceExhausted); ok {
 		return errForwarderSlowDown
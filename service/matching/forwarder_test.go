@@ -31,6 +31,7 @@ import (
 	"github.com/golang/mock/gomock"
 	"github.com/pborman/uuid"
 	"github.com/stretchr/testify/suite"
+	"go.temporal.io/temporal-proto/serviceerror"
 	tasklistpb "go.temporal.io/temporal-proto/tasklist"
 
 	commongenpb "github.com/temporalio/temporal/.gen/proto/common"
@@ -38,6 +39,7 @@ import (
 	"github.com/temporalio/temporal/.gen/proto/matchingservicemock"
 	"github.com/temporalio/temporal/.gen/proto/persistenceblobs"
 	"github.com/temporalio/temporal/common"
+	"github.com/temporalio/temporal/common/clock"
 	"github.com/temporalio/temporal/common/metrics"
 	"github.com/temporalio/temporal/common/persistence"
 	"github.com/temporalio/temporal/common/primitives"
@@ -65,6 +67,9 @@ func (t *ForwarderTestSuite) SetupTest() {
 		ForwarderMaxRatePerSecond:    func() int { return 2 },
 		ForwarderMaxChildrenPerNode:  func() int { return 20 },
 		ForwarderMaxOutstandingTasks: func() int { return 1 },
+		ForwarderBreakerThreshold:    func() int { return 0 },
+		ForwarderBreakerCooldown:     func() time.Duration { return time.Minute },
+		PartitionRouter:              NewDefaultPartitionRouter(),
 	}
 	t.taskList = newTestTaskListID("fwdr", "tl0", persistence.TaskListTypeDecision)
 	scope := func() metrics.Scope { return metrics.NoopScope(metrics.Matching) }
@@ -193,6 +198,62 @@ func (t *ForwarderTestSuite) TestForwardQueryTaskRateNotEnforced() {
 	t.NoError(err) // no rateliming should be enforced for query task
 }
 
+func (t *ForwarderTestSuite) TestForwarderBreaker() {
+	t.cfg.ForwarderBreakerThreshold = func() int { return 2 }
+	timeSource := clock.NewEventTimeSource().Update(time.Now())
+	t.fwdr.timeSource = timeSource
+	t.usingTasklistPartition(persistence.TaskListTypeDecision)
+	task := newInternalQueryTask("id1", &matchingservice.QueryWorkflowRequest{})
+	unavailable := serviceerror.NewUnavailable("parent unavailable")
+
+	// context errors do not count as failures to reach the parent
+	t.client.EXPECT().QueryWorkflow(gomock.Any(), gomock.Any()).Return(nil, context.DeadlineExceeded).Times(1)
+	t.client.EXPECT().QueryWorkflow(gomock.Any(), gomock.Any()).Return(nil, unavailable).Times(2)
+	_, err := t.fwdr.ForwardQueryTask(context.Background(), task)
+	t.Equal(context.DeadlineExceeded, err)
+	for i := 0; i < 2; i++ {
+		_, err = t.fwdr.ForwardQueryTask(context.Background(), task)
+		t.Equal(unavailable, err)
+	}
+
+	// breaker is open, no call reaches the parent until the cooldown elapses
+	_, err = t.fwdr.ForwardQueryTask(context.Background(), task)
+	t.Equal(errForwarderBreakerOpen, err)
+	t.Equal(errForwarderBreakerOpen, t.fwdr.ForwardTask(context.Background(), newInternalTask(&persistenceblobs.AllocatedTaskInfo{}, nil, commongenpb.TaskSourceHistory, "", false)))
+
+	// a failed probe opens the breaker again
+	timeSource.Update(timeSource.Now().Add(time.Minute))
+	t.client.EXPECT().QueryWorkflow(gomock.Any(), gomock.Any()).Return(nil, unavailable).Times(1)
+	_, err = t.fwdr.ForwardQueryTask(context.Background(), task)
+	t.Equal(unavailable, err)
+	_, err = t.fwdr.ForwardQueryTask(context.Background(), task)
+	t.Equal(errForwarderBreakerOpen, err)
+
+	// a successful probe closes the breaker
+	timeSource.Update(timeSource.Now().Add(time.Minute))
+	resp := &matchingservice.QueryWorkflowResponse{}
+	t.client.EXPECT().QueryWorkflow(gomock.Any(), gomock.Any()).Return(resp, nil).Times(2)
+	for i := 0; i < 2; i++ {
+		gotResp, err := t.fwdr.ForwardQueryTask(context.Background(), task)
+		t.NoError(err)
+		t.Equal(resp, gotResp)
+	}
+}
+
+func (t *ForwarderTestSuite) TestIsActive() {
+	t.False(t.fwdr.isActive())
+
+	t.usingTasklistPartition(persistence.TaskListTypeDecision)
+	t.True(t.fwdr.isActive())
+
+	t.fwdr.breaker.state = breakerOpen
+	t.False(t.fwdr.isActive())
+
+	t.fwdr.breaker.state = breakerClosed
+	t.fwdr.taskListKind = tasklistpb.TaskListKindSticky
+	t.False(t.fwdr.isActive())
+}
+
 func (t *ForwarderTestSuite) TestForwardPollError() {
 	_, err := t.fwdr.ForwardPoll(context.Background())
 	t.Equal(errNoParent, err)
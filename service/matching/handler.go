@@ -54,6 +54,7 @@ var (
 	_ matchingservice.MatchingServiceServer = (*Handler)(nil)
 
 	errMatchingHostThrottle = serviceerror.NewResourceExhausted("Matching host RPS exceeded.")
+	errNamespaceNotSet      = serviceerror.NewInvalidArgument("Namespace not set on request.")
 )
 
 // NewHandler creates a gRPC handler for the matchingservice
@@ -299,6 +300,64 @@ func (h *Handler) ListTaskListPartitions(ctx context.Context, request *matchings
 	return response, h.handleErr(err, scope)
 }
 
+// RefreshNamespaceCache reloads a namespace from persistence into the namespace cache of this host
+func (h *Handler) RefreshNamespaceCache(ctx context.Context, request *matchingservice.RefreshNamespaceCacheRequest) (_ *matchingservice.RefreshNamespaceCacheResponse, retError error) {
+	defer log.CapturePanicGRPC(h.GetLogger(), &retError)
+	scope := metrics.MatchingRefreshNamespaceCacheScope
+	sw := h.startRequestProfile("RefreshNamespaceCache", scope)
+	defer sw.Stop()
+
+	if request.GetNamespace() == "" {
+		return nil, h.handleErr(errNamespaceNotSet, scope)
+	}
+
+	if err := h.GetNamespaceCache().RefreshNamespace(request.GetNamespace()); err != nil {
+		return nil, h.handleErr(err, scope)
+	}
+	return &matchingservice.RefreshNamespaceCacheResponse{}, nil
+}
+
+// ListOwnedTaskLists returns the task lists currently loaded by this host
+func (h *Handler) ListOwnedTaskLists(ctx context.Context, request *matchingservice.ListOwnedTaskListsRequest) (_ *matchingservice.ListOwnedTaskListsResponse, retError error) {
+	defer log.CapturePanicGRPC(h.GetLogger(), &retError)
+	scope := metrics.MatchingListOwnedTaskListsScope
+	sw := h.startRequestProfile("ListOwnedTaskLists", scope)
+	defer sw.Stop()
+
+	response, err := h.engine.ListOwnedTaskLists(ctx)
+	return response, h.handleErr(err, scope)
+}
+
+// InjectTask writes a synthetic task into the backlog of a task list
+func (h *Handler) InjectTask(ctx context.Context, request *matchingservice.InjectTaskRequest) (_ *matchingservice.InjectTaskResponse, retError error) {
+	defer log.CapturePanicGRPC(h.GetLogger(), &retError)
+	scope := metrics.MatchingInjectTaskScope
+	sw := h.startRequestProfile("InjectTask", scope)
+	defer sw.Stop()
+
+	if ok := h.rateLimiter.Allow(); !ok {
+		return nil, h.handleErr(errMatchingHostThrottle, scope)
+	}
+
+	response, err := h.engine.InjectTask(ctx, request)
+	return response, h.handleErr(err, scope)
+}
+
+// CompleteTasksBelow completes all tasks of a task list with a task id at or below the given one
+func (h *Handler) CompleteTasksBelow(ctx context.Context, request *matchingservice.CompleteTasksBelowRequest) (_ *matchingservice.CompleteTasksBelowResponse, retError error) {
+	defer log.CapturePanicGRPC(h.GetLogger(), &retError)
+	scope := metrics.MatchingCompleteTasksBelowScope
+	sw := h.startRequestProfile("CompleteTasksBelow", scope)
+	defer sw.Stop()
+
+	if ok := h.rateLimiter.Allow(); !ok {
+		return nil, h.handleErr(errMatchingHostThrottle, scope)
+	}
+
+	response, err := h.engine.CompleteTasksBelow(ctx, request)
+	return response, h.handleErr(err, scope)
+}
+
 func (h *Handler) handleErr(err error, scope int) error {
 
 	if err == nil {
@@ -23,8 +23,10 @@ package matching
 import (
 	"context"
 	"errors"
+	"sync"
 	"time"
 
+	"go.temporal.io/temporal-proto/serviceerror"
 	"golang.org/x/time/rate"
 
 	commongenpb "github.com/temporalio/temporal/.gen/proto/common"
@@ -44,12 +46,31 @@ type TaskMatcher struct {
 	// are interested in queryTasks but not others. Example is when namespace is
 	// not active in a cluster
 	queryTaskC chan *internalTask
+	// closed when the task list is being unloaded to wake up blocked pollers
+	unloadC    chan struct{}
+	unloadOnce sync.Once
 	// ratelimiter that limits the rate at which tasks can be dispatched to consumers
 	limiter *quotas.RateLimiter
 
-	fwdr          *Forwarder
-	scope         func() metrics.Scope // namespace metric scope
-	numPartitions func() int           // number of task list partitions
+	fwdr           *Forwarder
+	scope          func() metrics.Scope // namespace metric scope
+	taskListScope  func() metrics.Scope // task list tagged metric scope
+	numPartitions  func() int           // number of task list partitions
+	minPollTimeout func() time.Duration // minimum time to hold a poll
+	maxBacklog     func() int           // backlog size above which new tasks are shed
+	backlogCount   func() int64         // current task list backlog size
+	matchRatio     *matchRatioWindow    // sync vs backlog matches in the current window
+}
+
+// matchRatioWindow counts the sync matches and the backlog matches of
+// a task list within a fixed window of time. The counts are reset once
+// the window has elapsed
+type matchRatioWindow struct {
+	sync.Mutex
+	window         func() time.Duration
+	windowStart    time.Time
+	syncMatches    int64
+	backlogMatches int64
 }
 
 const (
@@ -57,21 +78,39 @@ const (
 	_defaultTaskDispatchRPSTTL = 60 * time.Second
 )
 
-var errTasklistThrottled = errors.New("cannot add to tasklist, limit exceeded")
+var (
+	errTasklistThrottled   = errors.New("cannot add to tasklist, limit exceeded")
+	errMatchingBacklogFull = serviceerror.NewResourceExhausted("Task list backlog exceeds limit.")
+	errTaskListUnloaded    = errors.New("task list is being unloaded")
+)
 
 // newTaskMatcher returns an task matcher instance. The returned instance can be
 // used by task producers and consumers to find a match. Both sync matches and non-sync
-// matches should use this implementation
-func newTaskMatcher(config *taskListConfig, fwdr *Forwarder, scopeFunc func() metrics.Scope) *TaskMatcher {
+// matches should use this implementation. backlogCount reports the current
+// backlog of the task list and is used to shed new tasks when it is too large.
+// taskListScope is used to emit local vs forwarded match counts per task list
+func newTaskMatcher(
+	config *taskListConfig,
+	fwdr *Forwarder,
+	scopeFunc func() metrics.Scope,
+	taskListScope func() metrics.Scope,
+	backlogCount func() int64,
+) *TaskMatcher {
 	dPtr := _defaultTaskDispatchRPS
 	limiter := quotas.NewRateLimiter(&dPtr, _defaultTaskDispatchRPSTTL, config.MinTaskThrottlingBurstSize())
 	return &TaskMatcher{
-		limiter:       limiter,
-		scope:         scopeFunc,
-		fwdr:          fwdr,
-		taskC:         make(chan *internalTask),
-		queryTaskC:    make(chan *internalTask),
-		numPartitions: config.NumReadPartitions,
+		limiter:        limiter,
+		scope:          scopeFunc,
+		taskListScope:  taskListScope,
+		fwdr:           fwdr,
+		taskC:          make(chan *internalTask),
+		queryTaskC:     make(chan *internalTask),
+		unloadC:        make(chan struct{}),
+		numPartitions:  config.NumReadPartitions,
+		minPollTimeout: config.MinPollTimeout,
+		maxBacklog:     config.MatcherMaxBacklogForOffer,
+		backlogCount:   backlogCount,
+		matchRatio:     &matchRatioWindow{window: config.MatchRatioWindow, windowStart: time.Now()},
 	}
 }
 
@@ -101,10 +140,15 @@ func newTaskMatcher(config *taskListConfig, fwdr *Forwarder, scopeFunc func() me
 // correct context timeout.
 //
 // returns error when:
+//  - backlog exceeds the configured limit (does not apply to backlog tasks)
 //  - ratelimit is exceeded (does not apply to query task)
 //  - context deadline is exceeded
 //  - task is matched and consumer returns error in response channel
 func (tm *TaskMatcher) Offer(ctx context.Context, task *internalTask) (bool, error) {
+	if tm.shouldShed(task) {
+		return false, errMatchingBacklogFull
+	}
+
 	var err error
 	var rsv *rate.Reservation
 	if !task.isForwarded() {
@@ -117,6 +161,7 @@ func (tm *TaskMatcher) Offer(ctx context.Context, task *internalTask) (bool, err
 
 	select {
 	case tm.taskC <- task: // poller picked up the task
+		tm.taskListScope().IncCounter(metrics.LocalMatchCounter)
 		if task.responseC != nil {
 			// if there is a response channel, block until resp is received
 			// and return error if the response contains error
@@ -132,9 +177,11 @@ func (tm *TaskMatcher) Offer(ctx context.Context, task *internalTask) (bool, err
 			if err := tm.fwdr.ForwardTask(ctx, task); err == nil {
 				// task was remotely sync matched on the parent partition
 				token.release()
+				tm.taskListScope().IncCounter(metrics.ForwardedMatchCounter)
 				return true, nil
 			}
 			token.release()
+			tm.taskListScope().IncCounter(metrics.ForwardFailureCounter)
 		default:
 			if !tm.isForwardingAllowed() && // we are the root partition and forwarding is not possible
 				task.source == commongenpb.TaskSourceDbBacklog && // task was from backlog (stored in db)
@@ -157,6 +204,7 @@ func (tm *TaskMatcher) Offer(ctx context.Context, task *internalTask) (bool, err
 func (tm *TaskMatcher) offerOrTimeout(ctx context.Context, task *internalTask) (bool, error) {
 	select {
 	case tm.taskC <- task: // poller picked up the task
+		tm.taskListScope().IncCounter(metrics.LocalMatchCounter)
 		if task.responseC != nil {
 			select {
 			case err := <-task.responseC:
@@ -166,6 +214,8 @@ func (tm *TaskMatcher) offerOrTimeout(ctx context.Context, task *internalTask) (
 			}
 		}
 		return false, nil
+	case <-tm.unloadC:
+		return false, nil
 	case <-ctx.Done():
 		return false, nil
 	}
@@ -195,13 +245,15 @@ func (tm *TaskMatcher) OfferQuery(ctx context.Context, task *internalTask) (*mat
 			if err == nil {
 				return resp, nil
 			}
-			if err == errForwarderSlowDown {
-				// if we are rate limited, try only local match for the
-				// remainder of the context timeout left
+			if err == errForwarderSlowDown || err == errForwarderBreakerOpen {
+				// if we are rate limited or the parent is unreachable, try only
+				// local match for the remainder of the context timeout left
 				fwdrTokenC = noopForwarderTokenC
 				continue
 			}
 			return nil, err
+		case <-tm.unloadC:
+			return nil, errTaskListUnloaded
 		case <-ctx.Done():
 			return nil, ctx.Err()
 		}
@@ -209,9 +261,14 @@ func (tm *TaskMatcher) OfferQuery(ctx context.Context, task *internalTask) (*mat
 }
 
 // MustOffer blocks until a consumer is found to handle this task
-// Returns error only when context is canceled or the ratelimit is set to zero (allow nothing)
+// Returns error only when context is canceled, the ratelimit is set to zero (allow nothing)
+// or the task list is being unloaded
 // The passed in context MUST NOT have a deadline associated with it
 func (tm *TaskMatcher) MustOffer(ctx context.Context, task *internalTask) error {
+	if tm.shouldShed(task) {
+		return errMatchingBacklogFull
+	}
+
 	if _, err := tm.ratelimit(ctx); err != nil {
 		return err
 	}
@@ -220,6 +277,7 @@ func (tm *TaskMatcher) MustOffer(ctx context.Context, task *internalTask) error
 	// doesn't succeed, try both local match and remote match
 	select {
 	case tm.taskC <- task:
+		tm.taskListScope().IncCounter(metrics.LocalMatchCounter)
 		return nil
 	case <-ctx.Done():
 		return ctx.Err()
@@ -230,20 +288,26 @@ forLoop:
 	for {
 		select {
 		case tm.taskC <- task:
+			tm.taskListScope().IncCounter(metrics.LocalMatchCounter)
 			return nil
 		case token := <-tm.fwdrAddReqTokenC():
 			childCtx, cancel := context.WithDeadline(ctx, time.Now().Add(time.Second*2))
 			err := tm.fwdr.ForwardTask(childCtx, task)
 			token.release()
 			if err != nil {
+				tm.taskListScope().IncCounter(metrics.ForwardFailureCounter)
 				// forwarder returns error only when the call is rate limited. To
 				// avoid a busy loop on such rate limiting events, we only attempt to make
 				// the next forwarded call after this childCtx expires. Till then, we block
 				// hoping for a local poller match
 				select {
 				case tm.taskC <- task:
+					tm.taskListScope().IncCounter(metrics.LocalMatchCounter)
 					return nil
 				case <-childCtx.Done():
+				case <-tm.unloadC:
+					cancel()
+					return errTaskListUnloaded
 				case <-ctx.Done():
 					return ctx.Err()
 				}
@@ -251,11 +315,14 @@ forLoop:
 				continue forLoop
 			}
 			cancel()
+			tm.taskListScope().IncCounter(metrics.ForwardedMatchCounter)
 			// at this point, we forwarded the task to a parent partition which
 			// in turn dispatched the task to a poller. Make sure we delete the
 			// task from the database
 			task.finish(nil)
 			return nil
+		case <-tm.unloadC:
+			return errTaskListUnloaded
 		case <-ctx.Done():
 			return ctx.Err()
 		}
@@ -264,12 +331,23 @@ forLoop:
 
 // Poll blocks until a task is found or context deadline is exceeded
 // On success, the returned task could be a query task or a regular task
-// Returns ErrNoTasks when context deadline is exceeded
+// Returns ErrNoTasks when context deadline is exceeded and errTaskListUnloaded
+// when the task list is being unloaded
+//
+// When the context deadline is shorter than the configured minimum poll
+// timeout, the poll is held for the minimum timeout instead, but never past
+// the poll deadline attached to the context. Cancelling the context still
+// aborts the poll immediately.
 func (tm *TaskMatcher) Poll(ctx context.Context) (*internalTask, error) {
+	ctx, cancel := tm.withMinPollTimeout(ctx)
+	defer cancel()
 	// try local match first without blocking until context timeout
 	if task, err := tm.pollNonBlocking(ctx, tm.taskC, tm.queryTaskC); err == nil {
 		return task, nil
 	}
+	if tm.isUnloaded() {
+		return nil, errTaskListUnloaded
+	}
 	// there is no local poller available to pickup this task. Now block waiting
 	// either for a local poller or a forwarding token to be available. When a
 	// forwarding token becomes available, send this poll to a parent partition
@@ -277,18 +355,41 @@ func (tm *TaskMatcher) Poll(ctx context.Context) (*internalTask, error) {
 }
 
 // PollForQuery blocks until a *query* task is found or context deadline is exceeded
-// Returns ErrNoTasks when context deadline is exceeded
+// Returns ErrNoTasks when context deadline is exceeded and errTaskListUnloaded
+// when the task list is being unloaded
 func (tm *TaskMatcher) PollForQuery(ctx context.Context) (*internalTask, error) {
 	// try local match first without blocking until context timeout
 	if task, err := tm.pollNonBlocking(ctx, nil, tm.queryTaskC); err == nil {
 		return task, nil
 	}
+	if tm.isUnloaded() {
+		return nil, errTaskListUnloaded
+	}
 	// there is no local poller available to pickup this task. Now block waiting
 	// either for a local poller or a forwarding token to be available. When a
 	// forwarding token becomes available, send this poll to a parent partition
 	return tm.pollOrForward(ctx, nil, tm.queryTaskC)
 }
 
+// Unload wakes up all blocked pollers with errTaskListUnloaded and fails any
+// later poll fast, so that pollers re-poll and get routed to the new owner of
+// the task list. Blocked offers stop waiting for a local poller; tasks that
+// are not matched stay in (or get written to) the db and are re-read later
+func (tm *TaskMatcher) Unload() {
+	tm.unloadOnce.Do(func() {
+		close(tm.unloadC)
+	})
+}
+
+func (tm *TaskMatcher) isUnloaded() bool {
+	select {
+	case <-tm.unloadC:
+		return true
+	default:
+		return false
+	}
+}
+
 // UpdateRatelimit updates the task dispatch rate
 func (tm *TaskMatcher) UpdateRatelimit(rps *float64) {
 	if rps == nil {
@@ -316,14 +417,17 @@ func (tm *TaskMatcher) pollOrForward(
 	select {
 	case task := <-taskC:
 		if task.responseC != nil {
-			tm.scope().IncCounter(metrics.PollSuccessWithSyncCounter)
+			tm.taskScope(task).IncCounter(metrics.PollSuccessWithSyncCounter)
 		}
-		tm.scope().IncCounter(metrics.PollSuccessCounter)
+		tm.taskScope(task).IncCounter(metrics.PollSuccessCounter)
+		tm.recordMatch(task)
 		return task, nil
 	case task := <-queryTaskC:
-		tm.scope().IncCounter(metrics.PollSuccessWithSyncCounter)
-		tm.scope().IncCounter(metrics.PollSuccessCounter)
+		tm.taskScope(task).IncCounter(metrics.PollSuccessWithSyncCounter)
+		tm.taskScope(task).IncCounter(metrics.PollSuccessCounter)
 		return task, nil
+	case <-tm.unloadC:
+		return nil, errTaskListUnloaded
 	case <-ctx.Done():
 		tm.scope().IncCounter(metrics.PollTimeoutCounter)
 		return nil, ErrNoTasks
@@ -345,14 +449,17 @@ func (tm *TaskMatcher) poll(
 	select {
 	case task := <-taskC:
 		if task.responseC != nil {
-			tm.scope().IncCounter(metrics.PollSuccessWithSyncCounter)
+			tm.taskScope(task).IncCounter(metrics.PollSuccessWithSyncCounter)
 		}
-		tm.scope().IncCounter(metrics.PollSuccessCounter)
+		tm.taskScope(task).IncCounter(metrics.PollSuccessCounter)
+		tm.recordMatch(task)
 		return task, nil
 	case task := <-queryTaskC:
-		tm.scope().IncCounter(metrics.PollSuccessWithSyncCounter)
-		tm.scope().IncCounter(metrics.PollSuccessCounter)
+		tm.taskScope(task).IncCounter(metrics.PollSuccessWithSyncCounter)
+		tm.taskScope(task).IncCounter(metrics.PollSuccessCounter)
 		return task, nil
+	case <-tm.unloadC:
+		return nil, errTaskListUnloaded
 	case <-ctx.Done():
 		tm.scope().IncCounter(metrics.PollTimeoutCounter)
 		return nil, ErrNoTasks
@@ -367,19 +474,73 @@ func (tm *TaskMatcher) pollNonBlocking(
 	select {
 	case task := <-taskC:
 		if task.responseC != nil {
-			tm.scope().IncCounter(metrics.PollSuccessWithSyncCounter)
+			tm.taskScope(task).IncCounter(metrics.PollSuccessWithSyncCounter)
 		}
-		tm.scope().IncCounter(metrics.PollSuccessCounter)
+		tm.taskScope(task).IncCounter(metrics.PollSuccessCounter)
+		tm.recordMatch(task)
 		return task, nil
 	case task := <-queryTaskC:
-		tm.scope().IncCounter(metrics.PollSuccessWithSyncCounter)
-		tm.scope().IncCounter(metrics.PollSuccessCounter)
+		tm.taskScope(task).IncCounter(metrics.PollSuccessWithSyncCounter)
+		tm.taskScope(task).IncCounter(metrics.PollSuccessCounter)
 		return task, nil
 	default:
 		return nil, ErrNoTasks
 	}
 }
 
+// withMinPollTimeout returns a context whose deadline is at least the
+// configured minimum poll timeout away, capped by the poll deadline found
+// in the context. Values of the parent context are preserved and
+// cancellation of the parent is still honored.
+func (tm *TaskMatcher) withMinPollTimeout(ctx context.Context) (context.Context, context.CancelFunc) {
+	noop := func() {}
+	if tm.minPollTimeout == nil {
+		return ctx, noop
+	}
+	floor := tm.minPollTimeout()
+	if floor <= 0 {
+		return ctx, noop
+	}
+	deadline, ok := ctx.Deadline()
+	if !ok {
+		return ctx, noop
+	}
+	floorDeadline := time.Now().Add(floor)
+	if pollDeadline, ok := ctx.Value(pollDeadlineKey).(time.Time); ok && pollDeadline.Before(floorDeadline) {
+		floorDeadline = pollDeadline
+	}
+	if !floorDeadline.After(deadline) {
+		return ctx, noop
+	}
+
+	floorCtx, cancel := context.WithDeadline(valueOnlyContext{ctx}, floorDeadline)
+	go func() {
+		select {
+		case <-ctx.Done():
+			if ctx.Err() == context.Canceled {
+				cancel()
+			}
+		case <-floorCtx.Done():
+		}
+	}()
+	return floorCtx, cancel
+}
+
+// shouldShed returns true when the task list backlog exceeds the configured
+// limit and the task would add to it. Tasks loaded from the db backlog are
+// never shed, since rejecting them would not make the backlog any smaller
+func (tm *TaskMatcher) shouldShed(task *internalTask) bool {
+	if tm.maxBacklog == nil || tm.backlogCount == nil || task.source == commongenpb.TaskSourceDbBacklog {
+		return false
+	}
+	maxBacklog := tm.maxBacklog()
+	if maxBacklog <= 0 || tm.backlogCount() <= int64(maxBacklog) {
+		return false
+	}
+	tm.scope().IncCounter(metrics.BacklogShedCounter)
+	return true
+}
+
 func (tm *TaskMatcher) fwdrPollReqTokenC() <-chan *ForwarderReqToken {
 	if tm.fwdr == nil {
 		return noopForwarderTokenC
@@ -387,6 +548,12 @@ func (tm *TaskMatcher) fwdrPollReqTokenC() <-chan *ForwarderReqToken {
 	return tm.fwdr.PollReqTokenC()
 }
 
+// isForwardingActive returns whether tasks and polls which are not matched locally can be forwarded
+// to the parent partition
+func (tm *TaskMatcher) isForwardingActive() bool {
+	return tm.fwdr != nil && tm.fwdr.isActive()
+}
+
 func (tm *TaskMatcher) fwdrAddReqTokenC() <-chan *ForwarderReqToken {
 	if tm.fwdr == nil {
 		return noopForwarderTokenC
@@ -422,6 +589,62 @@ func (tm *TaskMatcher) ratelimit(ctx context.Context) (*rate.Reservation, error)
 	return rsv, nil
 }
 
+// recordMatch counts a matched task towards the sync match ratio of the
+// current window and emits the updated ratio. A low ratio means pollers
+// are mostly draining the backlog, i.e. the task list is falling behind
+func (tm *TaskMatcher) recordMatch(task *internalTask) {
+	if tm.matchRatio == nil {
+		return
+	}
+	r := tm.matchRatio
+	r.Lock()
+	now := time.Now()
+	if window := r.window(); window > 0 && now.Sub(r.windowStart) >= window {
+		r.windowStart = now
+		r.syncMatches = 0
+		r.backlogMatches = 0
+	}
+	switch task.source {
+	case commongenpb.TaskSourceHistory:
+		r.syncMatches++
+	case commongenpb.TaskSourceDbBacklog:
+		r.backlogMatches++
+	default:
+		r.Unlock()
+		return
+	}
+	ratio := float64(r.syncMatches) / float64(r.syncMatches+r.backlogMatches)
+	r.Unlock()
+	tm.taskListScope().UpdateGauge(metrics.SyncMatchRatioGauge, ratio)
+}
+
+// taskScope returns the metric scope for a matched task, tagged
+// with the child partition name when the task was forwarded
+func (tm *TaskMatcher) taskScope(task *internalTask) metrics.Scope {
+	if task.isForwarded() {
+		return tm.scope().Tagged(metrics.ForwardedFromTag(task.forwardedFrom))
+	}
+	return tm.scope()
+}
+
 func (tm *TaskMatcher) isForwardingAllowed() bool {
 	return tm.fwdr != nil
 }
+
+// valueOnlyContext exposes the values of the wrapped context
+// but detaches it from the wrapped context's deadline and cancellation
+type valueOnlyContext struct {
+	context.Context
+}
+
+func (valueOnlyContext) Deadline() (deadline time.Time, ok bool) {
+	return
+}
+
+func (valueOnlyContext) Done() <-chan struct{} {
+	return nil
+}
+
+func (valueOnlyContext) Err() error {
+	return nil
+}
@@ -29,6 +29,7 @@ import (
 	"github.com/golang/mock/gomock"
 	"github.com/pborman/uuid"
 	"github.com/stretchr/testify/suite"
+	"github.com/uber-go/tally"
 	querypb "go.temporal.io/temporal-proto/query"
 	tasklistpb "go.temporal.io/temporal-proto/tasklist"
 	"go.uber.org/atomic"
@@ -44,6 +45,19 @@ import (
 	"github.com/temporalio/temporal/common/service/dynamicconfig"
 )
 
+// testPartitionRouter forms a two level tree where every partition
+// forwards to partition 1, which in turn forwards to the root
+type testPartitionRouter struct {
+	PartitionRouter
+}
+
+func (r *testPartitionRouter) ParentPartition(baseName string, partition int, degree int) int {
+	if partition == 1 {
+		return 0
+	}
+	return 1
+}
+
 type MatcherTestSuite struct {
 	suite.Suite
 	controller  *gomock.Controller
@@ -71,16 +85,17 @@ func (t *MatcherTestSuite) SetupTest() {
 		ForwarderMaxOutstandingTasks: func() int { return 1 },
 		ForwarderMaxRatePerSecond:    func() int { return 2 },
 		ForwarderMaxChildrenPerNode:  func() int { return 20 },
+		PartitionRouter:              NewDefaultPartitionRouter(),
 	}
 	t.cfg = tlCfg
 	scope := func() metrics.Scope { return metrics.NoopScope(metrics.Matching) }
 	t.fwdr = newForwarder(&t.cfg.forwarderConfig, t.taskList, tasklistpb.TaskListKindNormal, t.client, scope)
-	t.matcher = newTaskMatcher(tlCfg, t.fwdr, func() metrics.Scope { return metrics.NoopScope(metrics.Matching) })
+	t.matcher = newTaskMatcher(tlCfg, t.fwdr, scope, scope, nil)
 
 	rootTaskList := newTestTaskListID(t.taskList.namespaceID, t.taskList.Parent(20), persistence.TaskListTypeDecision)
 	rootTasklistCfg, err := newTaskListConfig(rootTaskList, cfg, t.newNamespaceCache())
 	t.NoError(err)
-	t.rootMatcher = newTaskMatcher(rootTasklistCfg, nil, func() metrics.Scope { return metrics.NoopScope(metrics.Matching) })
+	t.rootMatcher = newTaskMatcher(rootTasklistCfg, nil, scope, scope, nil)
 }
 
 func (t *MatcherTestSuite) TearDownTest() {
@@ -114,6 +129,161 @@ func (t *MatcherTestSuite) TestLocalSyncMatch() {
 	t.True(syncMatch)
 }
 
+func (t *MatcherTestSuite) TestSyncMatchForwardedFromTag() {
+	scope := tally.NewTestScope("test", nil)
+	metricsClient := metrics.NewClient(scope, metrics.Matching)
+	t.rootMatcher.scope = func() metrics.Scope { return metricsClient.Scope(metrics.MatchingTaskListMgrScope) }
+
+	offer := func(forwardedFrom string) {
+		pollStarted := make(chan struct{})
+		go func() {
+			ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+			close(pollStarted)
+			task, err := t.rootMatcher.Poll(ctx)
+			cancel()
+			if err == nil {
+				task.finish(nil)
+			}
+		}()
+
+		<-pollStarted
+		time.Sleep(10 * time.Millisecond)
+		task := newInternalTask(randomTaskInfo(), nil, commongenpb.TaskSourceHistory, forwardedFrom, true)
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+		syncMatch, err := t.rootMatcher.Offer(ctx, task)
+		cancel()
+		t.NoError(err)
+		t.True(syncMatch)
+	}
+
+	offer(t.taskList.name)
+	counters := scope.Snapshot().Counters()
+	forwardedCtr := counters["test.poll_success+forwardedFrom="+t.taskList.name+",operation=TaskListMgr"]
+	t.NotNil(forwardedCtr)
+	t.EqualValues(1, forwardedCtr.Value())
+	t.Nil(counters["test.poll_success+operation=TaskListMgr"])
+
+	offer("")
+	counters = scope.Snapshot().Counters()
+	localCtr := counters["test.poll_success+operation=TaskListMgr"]
+	t.NotNil(localCtr)
+	t.EqualValues(1, localCtr.Value())
+	t.EqualValues(1, counters["test.poll_success+forwardedFrom="+t.taskList.name+",operation=TaskListMgr"].Value())
+}
+
+func (t *MatcherTestSuite) TestSyncMatchTaskListCounters() {
+	scope := tally.NewTestScope("test", nil)
+	metricsClient := metrics.NewClient(scope, metrics.Matching)
+	t.matcher.taskListScope = func() metrics.Scope {
+		return metricsClient.Scope(metrics.MatchingTaskListMgrScope).Tagged(
+			metrics.TaskListTag(t.taskList.GetRoot()),
+			metrics.TaskListTypeTag("decision"),
+		)
+	}
+	counter := func(name string) int64 {
+		key := "test." + name + "+operation=TaskListMgr,tasklist=" + t.taskList.GetRoot() + ",tasklistType=decision"
+		if ctr, ok := scope.Snapshot().Counters()[key]; ok {
+			return ctr.Value()
+		}
+		return 0
+	}
+
+	t.client.EXPECT().AddDecisionTask(gomock.Any(), gomock.Any()).Return(&matchingservice.AddDecisionTaskResponse{}, nil).Times(1)
+	t.client.EXPECT().AddDecisionTask(gomock.Any(), gomock.Any()).Return(&matchingservice.AddDecisionTaskResponse{}, errMatchingHostThrottle).Times(1)
+
+	// no local poller, task is forwarded and matched on the parent
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	syncMatch, err := t.matcher.Offer(ctx, newInternalTask(randomTaskInfo(), nil, commongenpb.TaskSourceHistory, "", true))
+	cancel()
+	t.NoError(err)
+	t.True(syncMatch)
+	t.EqualValues(1, counter("forwarded_matches"))
+	t.EqualValues(0, counter("local_matches"))
+	t.EqualValues(0, counter("forward_failures"))
+
+	// no local poller, forwarding fails
+	ctx, cancel = context.WithTimeout(context.Background(), time.Second)
+	syncMatch, err = t.matcher.Offer(ctx, newInternalTask(randomTaskInfo(), nil, commongenpb.TaskSourceHistory, "", true))
+	cancel()
+	t.NoError(err)
+	t.False(syncMatch)
+	t.EqualValues(1, counter("forwarded_matches"))
+	t.EqualValues(0, counter("local_matches"))
+	t.EqualValues(1, counter("forward_failures"))
+
+	// local poller available, task is matched locally
+	pollStarted := make(chan struct{})
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+		close(pollStarted)
+		task, err := t.matcher.Poll(ctx)
+		cancel()
+		if err == nil {
+			task.finish(nil)
+		}
+	}()
+	<-pollStarted
+	time.Sleep(10 * time.Millisecond)
+	ctx, cancel = context.WithTimeout(context.Background(), time.Second)
+	syncMatch, err = t.matcher.Offer(ctx, newInternalTask(randomTaskInfo(), nil, commongenpb.TaskSourceHistory, "", true))
+	cancel()
+	t.NoError(err)
+	t.True(syncMatch)
+	t.EqualValues(1, counter("forwarded_matches"))
+	t.EqualValues(1, counter("local_matches"))
+	t.EqualValues(1, counter("forward_failures"))
+}
+
+func (t *MatcherTestSuite) TestSyncMatchRatioGauge() {
+	scope := tally.NewTestScope("test", nil)
+	metricsClient := metrics.NewClient(scope, metrics.Matching)
+	t.rootMatcher.taskListScope = func() metrics.Scope { return metricsClient.Scope(metrics.MatchingTaskListMgrScope) }
+
+	match := func(source commongenpb.TaskSource) {
+		pollStarted := make(chan struct{})
+		go func() {
+			ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+			close(pollStarted)
+			task, err := t.rootMatcher.Poll(ctx)
+			cancel()
+			if err == nil {
+				task.finish(nil)
+			}
+		}()
+
+		<-pollStarted
+		time.Sleep(10 * time.Millisecond)
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+		defer cancel()
+		if source == commongenpb.TaskSourceDbBacklog {
+			task := newInternalTask(randomTaskInfo(), func(*persistenceblobs.AllocatedTaskInfo, error) {}, source, "", false)
+			t.NoError(t.rootMatcher.MustOffer(ctx, task))
+			return
+		}
+		syncMatch, err := t.rootMatcher.Offer(ctx, newInternalTask(randomTaskInfo(), nil, source, "", true))
+		t.NoError(err)
+		t.True(syncMatch)
+	}
+	ratio := func() float64 {
+		gauge, ok := scope.Snapshot().Gauges()["test.sync_match_ratio+operation=TaskListMgr"]
+		t.True(ok)
+		return gauge.Value()
+	}
+
+	for i := 0; i < 3; i++ {
+		match(commongenpb.TaskSourceHistory)
+	}
+	t.InDelta(1.0, ratio(), 0.001)
+
+	for i := 0; i < 3; i++ {
+		match(commongenpb.TaskSourceDbBacklog)
+	}
+	t.InDelta(0.5, ratio(), 0.001)
+
+	match(commongenpb.TaskSourceDbBacklog)
+	t.True(ratio() > 0.4 && ratio() < 0.5)
+}
+
 func (t *MatcherTestSuite) TestRemoteSyncMatch() {
 	t.testRemoteSyncMatch(commongenpb.TaskSourceHistory)
 }
@@ -205,6 +375,35 @@ func (t *MatcherTestSuite) TestSyncMatchFailure() {
 	t.False(syncMatch)
 }
 
+func (t *MatcherTestSuite) TestOfferRoutedByPartitionRouter() {
+	cfg := NewConfig(dynamicconfig.NewNopCollection())
+	cfg.PartitionRouter = &testPartitionRouter{PartitionRouter: NewDefaultPartitionRouter()}
+	taskList := newTestTaskListID(uuid.New(), taskListPartitionPrefix+"tl0/5", persistence.TaskListTypeDecision)
+	tlCfg, err := newTaskListConfig(taskList, cfg, t.newNamespaceCache())
+	t.NoError(err)
+	scope := func() metrics.Scope { return metrics.NoopScope(metrics.Matching) }
+	fwdr := newForwarder(&tlCfg.forwarderConfig, taskList, tasklistpb.TaskListKindNormal, t.client, scope)
+	matcher := newTaskMatcher(tlCfg, fwdr, scope, scope, nil)
+
+	var req *matchingservice.AddDecisionTaskRequest
+	t.client.EXPECT().AddDecisionTask(gomock.Any(), gomock.Any()).Do(
+		func(arg0 context.Context, arg1 *matchingservice.AddDecisionTaskRequest) {
+			req = arg1
+		},
+	).Return(&matchingservice.AddDecisionTaskResponse{}, nil).Times(1)
+
+	task := newInternalTask(randomTaskInfo(), nil, commongenpb.TaskSourceHistory, "", true)
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	syncMatch, err := matcher.Offer(ctx, task)
+	cancel()
+	t.NoError(err)
+	t.True(syncMatch)
+	t.NotNil(req)
+	t.Equal(taskList.name, req.GetForwardedFrom())
+	t.Equal(taskListPartitionPrefix+"tl0/1", req.GetTaskList().GetName())
+	t.NotEqual(taskList.Parent(20), req.GetTaskList().GetName())
+}
+
 func (t *MatcherTestSuite) TestQueryLocalSyncMatch() {
 	// force disable remote forwarding
 	<-t.fwdr.AddReqTokenC()
@@ -434,6 +633,155 @@ func (t *MatcherTestSuite) TestRemotePoll() {
 	t.True(task.isStarted())
 }
 
+func (t *MatcherTestSuite) TestOfferShedWhenBacklogFull() {
+	// force disable remote forwarding
+	<-t.fwdr.AddReqTokenC()
+	<-t.fwdr.PollReqTokenC()
+
+	backlog := atomic.NewInt64(0)
+	t.matcher.maxBacklog = func() int { return 10 }
+	t.matcher.backlogCount = backlog.Load
+
+	// fill the backlog up to the threshold, offers are still accepted
+	backlog.Store(10)
+	task := newInternalTask(randomTaskInfo(), nil, commongenpb.TaskSourceHistory, "", true)
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	matched, err := t.matcher.Offer(ctx, task)
+	cancel()
+	t.NoError(err)
+	t.False(matched)
+
+	// any further task sheds load
+	backlog.Inc()
+	ctx, cancel = context.WithTimeout(context.Background(), time.Second)
+	matched, err = t.matcher.Offer(ctx, task)
+	t.Equal(errMatchingBacklogFull, err)
+	t.False(matched)
+	t.Equal(errMatchingBacklogFull, t.matcher.MustOffer(ctx, task))
+	cancel()
+
+	// tasks loaded from the backlog are never shed
+	backlogTask := newInternalTask(randomTaskInfo(), nil, commongenpb.TaskSourceDbBacklog, "", false)
+	ctx, cancel = context.WithTimeout(context.Background(), 100*time.Millisecond)
+	t.Equal(context.DeadlineExceeded, t.matcher.MustOffer(ctx, backlogTask))
+	cancel()
+}
+
+func (t *MatcherTestSuite) TestPollUnload() {
+	// force disable remote forwarding
+	<-t.fwdr.AddReqTokenC()
+	<-t.fwdr.PollReqTokenC()
+
+	pollErrC := make(chan error, 1)
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+		_, err := t.matcher.Poll(ctx)
+		pollErrC <- err
+	}()
+
+	time.Sleep(10 * time.Millisecond)
+	t.matcher.Unload()
+	select {
+	case err := <-pollErrC:
+		t.Equal(errTaskListUnloaded, err)
+	case <-time.After(time.Second):
+		t.Fail("poll was not woken up by unload")
+	}
+
+	// subsequent polls and offers do not block
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	_, err := t.matcher.Poll(ctx)
+	t.Equal(errTaskListUnloaded, err)
+	_, err = t.matcher.PollForQuery(ctx)
+	t.Equal(errTaskListUnloaded, err)
+	t.Equal(errTaskListUnloaded, t.matcher.MustOffer(ctx, newInternalTask(randomTaskInfo(), nil, commongenpb.TaskSourceDbBacklog, "", false)))
+	matched, err := t.matcher.Offer(ctx, newInternalTask(randomTaskInfo(), nil, commongenpb.TaskSourceHistory, "", true))
+	t.NoError(err)
+	t.False(matched)
+}
+
+func (t *MatcherTestSuite) TestMustOfferUnload() {
+	// force disable remote forwarding
+	<-t.fwdr.AddReqTokenC()
+	<-t.fwdr.PollReqTokenC()
+
+	taskCompleted := false
+	completionFunc := func(*persistenceblobs.AllocatedTaskInfo, error) {
+		taskCompleted = true
+	}
+	offerErrC := make(chan error, 1)
+	go func() {
+		task := newInternalTask(randomTaskInfo(), completionFunc, commongenpb.TaskSourceDbBacklog, "", false)
+		offerErrC <- t.matcher.MustOffer(context.Background(), task)
+	}()
+
+	time.Sleep(10 * time.Millisecond)
+	t.matcher.Unload()
+	select {
+	case err := <-offerErrC:
+		t.Equal(errTaskListUnloaded, err)
+	case <-time.After(time.Second):
+		t.Fail("offer was not woken up by unload")
+	}
+	// the task is left in the db backlog for the next owner
+	t.False(taskCompleted)
+}
+
+func (t *MatcherTestSuite) TestPollMinTimeoutFloor() {
+	// force disable remote forwarding
+	<-t.fwdr.AddReqTokenC()
+	<-t.fwdr.PollReqTokenC()
+
+	t.matcher.minPollTimeout = func() time.Duration { return 200 * time.Millisecond }
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	start := time.Now()
+	task, err := t.matcher.Poll(ctx)
+	t.Equal(ErrNoTasks, err)
+	t.Nil(task)
+	t.True(time.Since(start) >= 200*time.Millisecond)
+}
+
+func (t *MatcherTestSuite) TestPollMinTimeoutFloorCancelled() {
+	// force disable remote forwarding
+	<-t.fwdr.AddReqTokenC()
+	<-t.fwdr.PollReqTokenC()
+
+	t.matcher.minPollTimeout = func() time.Duration { return 5 * time.Second }
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	go func() {
+		time.Sleep(50 * time.Millisecond)
+		cancel()
+	}()
+	start := time.Now()
+	task, err := t.matcher.Poll(ctx)
+	t.Equal(ErrNoTasks, err)
+	t.Nil(task)
+	t.True(time.Since(start) < 500*time.Millisecond)
+}
+
+func (t *MatcherTestSuite) TestPollMinTimeoutFloorCappedByPollDeadline() {
+	// force disable remote forwarding
+	<-t.fwdr.AddReqTokenC()
+	<-t.fwdr.PollReqTokenC()
+
+	t.matcher.minPollTimeout = func() time.Duration { return 5 * time.Second }
+
+	start := time.Now()
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	ctx = context.WithValue(ctx, pollDeadlineKey, start.Add(200*time.Millisecond))
+	task, err := t.matcher.Poll(ctx)
+	t.Equal(ErrNoTasks, err)
+	t.Nil(task)
+	t.True(time.Since(start) >= 200*time.Millisecond)
+	t.True(time.Since(start) < time.Second)
+}
+
 func (t *MatcherTestSuite) TestRemotePollForQuery() {
 	pollToken := <-t.fwdr.PollReqTokenC()
 
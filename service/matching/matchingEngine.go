@@ -26,16 +26,20 @@ import (
 	"errors"
 	"fmt"
 	"math"
+	"math/rand"
+	"sort"
 	"sync"
 	"time"
 
 	"github.com/gogo/protobuf/types"
 	"github.com/pborman/uuid"
+	executionpb "go.temporal.io/temporal-proto/execution"
 	querypb "go.temporal.io/temporal-proto/query"
 	"go.temporal.io/temporal-proto/serviceerror"
 	tasklistpb "go.temporal.io/temporal-proto/tasklist"
 	"go.temporal.io/temporal-proto/workflowservice"
 
+	"github.com/temporalio/temporal/.gen/proto/adminservice"
 	"github.com/temporalio/temporal/.gen/proto/historyservice"
 	"github.com/temporalio/temporal/.gen/proto/matchingservice"
 	"github.com/temporalio/temporal/.gen/proto/persistenceblobs"
@@ -58,8 +62,9 @@ import (
 // TODO: Switch implementation from lock/channel based to a partitioned agent
 // to simplify code and reduce possibility of synchronization errors.
 type (
-	pollerIDCtxKey string
-	identityCtxKey string
+	pollerIDCtxKey     string
+	identityCtxKey     string
+	pollDeadlineCtxKey string
 
 	// lockableQueryTaskMap maps query TaskID (which is a UUID generated in QueryWorkflow() call) to a channel
 	// that QueryWorkflow() will block on. The channel is unblocked either by worker sending response through
@@ -99,8 +104,13 @@ var (
 	ErrNoTasks    = errors.New("No tasks")
 	errPumpClosed = errors.New("Task list pump closed its channel")
 
-	pollerIDKey pollerIDCtxKey = "pollerID"
-	identityKey identityCtxKey = "identity"
+	errTaskInjectionDisabled     = serviceerror.NewInvalidArgument("Task injection is disabled on this cluster.")
+	errInvalidInjectTaskListType = serviceerror.NewInvalidArgument("Invalid task list type.")
+	errInvalidCompleteTaskID     = serviceerror.NewInvalidArgument("Invalid task id.")
+
+	pollerIDKey     pollerIDCtxKey     = "pollerID"
+	identityKey     identityCtxKey     = "identity"
+	pollDeadlineKey pollDeadlineCtxKey = "pollDeadline"
 )
 
 var _ Engine = (*matchingEngineImpl)(nil) // Asserts that interface is indeed implemented
@@ -329,7 +339,7 @@ pollLoop:
 		task, err := e.getTask(pollerCtx, taskList, nil, taskListKind)
 		if err != nil {
 			// TODO: Is empty poll the best reply for errPumpClosed?
-			if err == ErrNoTasks || err == errPumpClosed {
+			if err == ErrNoTasks || err == errPumpClosed || err == errTaskListUnloaded {
 				return emptyPollForDecisionTaskResponse, nil
 			}
 			return nil, err
@@ -425,7 +435,7 @@ pollLoop:
 		task, err := e.getTask(pollerCtx, taskList, maxDispatch, taskListKind)
 		if err != nil {
 			// TODO: Is empty poll the best reply for errPumpClosed?
-			if err == ErrNoTasks || err == errPumpClosed {
+			if err == ErrNoTasks || err == errPumpClosed || err == errTaskListUnloaded {
 				return emptyPollForActivityTaskResponse, nil
 			}
 			return nil, err
@@ -583,6 +593,131 @@ func (e *matchingEngineImpl) ListTaskListPartitions(ctx context.Context, request
 	return &resp, nil
 }
 
+// ListOwnedTaskLists returns the task lists loaded by this host, along with their kind and backlog
+func (e *matchingEngineImpl) ListOwnedTaskLists(ctx context.Context) (*matchingservice.ListOwnedTaskListsResponse, error) {
+	e.taskListsLock.RLock()
+	ids := make([]taskListID, 0, len(e.taskLists))
+	managers := make([]taskListManager, 0, len(e.taskLists))
+	for id, tlMgr := range e.taskLists {
+		ids = append(ids, id)
+		managers = append(managers, tlMgr)
+	}
+	e.taskListsLock.RUnlock()
+
+	// describe the task lists outside of the lock, as for String()
+	taskLists := make([]*adminservice.OwnedTaskList, 0, len(ids))
+	for i, id := range ids {
+		namespace, err := e.namespaceCache.GetNamespaceName(id.namespaceID)
+		if err != nil {
+			// the task list is still reported, only without its namespace name
+			e.logger.Warn("Failed to resolve namespace name of owned task list", tag.WorkflowNamespaceID(id.namespaceID), tag.Error(err))
+		}
+		taskLists = append(taskLists, &adminservice.OwnedTaskList{
+			NamespaceId:      id.namespaceID,
+			Namespace:        namespace,
+			Name:             id.name,
+			TaskListType:     id.taskType,
+			Kind:             managers[i].GetTaskListKind(),
+			BacklogCountHint: managers[i].DescribeTaskList(true).GetTaskListStatus().GetBacklogCountHint(),
+		})
+	}
+	sort.Slice(taskLists, func(i, j int) bool {
+		if taskLists[i].GetNamespaceId() != taskLists[j].GetNamespaceId() {
+			return taskLists[i].GetNamespaceId() < taskLists[j].GetNamespaceId()
+		}
+		if taskLists[i].GetName() != taskLists[j].GetName() {
+			return taskLists[i].GetName() < taskLists[j].GetName()
+		}
+		return taskLists[i].GetTaskListType() < taskLists[j].GetTaskListType()
+	})
+	return &matchingservice.ListOwnedTaskListsResponse{TaskLists: taskLists}, nil
+}
+
+// InjectTask writes a synthetic task into the backlog of a task list, without a sync match attempt.
+// The task is not backed by a workflow, so it is only meant to exercise the matcher in development.
+func (e *matchingEngineImpl) InjectTask(ctx context.Context, request *matchingservice.InjectTaskRequest) (*matchingservice.InjectTaskResponse, error) {
+	if !e.config.EnableTaskInjection() {
+		return nil, errTaskInjectionDisabled
+	}
+
+	taskListType := request.GetTaskListType()
+	if taskListType != persistence.TaskListTypeDecision && taskListType != persistence.TaskListTypeActivity {
+		return nil, errInvalidInjectTaskListType
+	}
+	namespaceID := request.GetNamespaceId()
+	taskList, err := newTaskListID(namespaceID, request.TaskList.GetName(), taskListType)
+	if err != nil {
+		return nil, err
+	}
+	tlMgr, err := e.getTaskListManager(taskList, request.TaskList.GetKind())
+	if err != nil {
+		return nil, err
+	}
+
+	execution := &executionpb.WorkflowExecution{
+		WorkflowId: uuid.New(),
+		RunId:      uuid.New(),
+	}
+	now := types.TimestampNow()
+	expiry := types.TimestampNow()
+	expiry.Seconds += int64(request.GetScheduleToStartTimeoutSeconds())
+	taskInfo := &persistenceblobs.TaskInfo{
+		NamespaceId: primitives.MustParseUUID(namespaceID),
+		RunId:       primitives.MustParseUUID(execution.GetRunId()),
+		WorkflowId:  execution.GetWorkflowId(),
+		ScheduleId:  rand.Int63(),
+		Expiry:      expiry,
+		CreatedTime: now,
+	}
+	if err := tlMgr.InjectTask(execution, taskInfo); err != nil {
+		return nil, err
+	}
+
+	e.logger.Info("Injected synthetic task",
+		tag.WorkflowNamespaceID(namespaceID),
+		tag.WorkflowTaskListName(taskList.name),
+		tag.WorkflowID(execution.GetWorkflowId()),
+		tag.WorkflowRunID(execution.GetRunId()),
+		tag.WorkflowScheduleID(taskInfo.GetScheduleId()))
+	return &matchingservice.InjectTaskResponse{
+		Execution:  execution,
+		ScheduleId: taskInfo.GetScheduleId(),
+	}, nil
+}
+
+// CompleteTasksBelow completes all tasks of a task list with a task id at or below the given one, through the
+// task list manager so the tasks it already read are dropped and its ack level moves past them
+func (e *matchingEngineImpl) CompleteTasksBelow(ctx context.Context, request *matchingservice.CompleteTasksBelowRequest) (*matchingservice.CompleteTasksBelowResponse, error) {
+	taskListType := request.GetTaskListType()
+	if taskListType != persistence.TaskListTypeDecision && taskListType != persistence.TaskListTypeActivity {
+		return nil, errInvalidInjectTaskListType
+	}
+	if request.GetTaskId() < 0 {
+		return nil, errInvalidCompleteTaskID
+	}
+	namespaceID := request.GetNamespaceId()
+	taskList, err := newTaskListID(namespaceID, request.TaskList.GetName(), taskListType)
+	if err != nil {
+		return nil, err
+	}
+	tlMgr, err := e.getTaskListManager(taskList, request.TaskList.GetKind())
+	if err != nil {
+		return nil, err
+	}
+
+	ackLevel, err := tlMgr.CompleteTasksBelow(request.GetTaskId())
+	if err != nil {
+		return nil, err
+	}
+
+	e.logger.Info("Completed tasks below task id",
+		tag.WorkflowNamespaceID(namespaceID),
+		tag.WorkflowTaskListName(taskList.name),
+		tag.TaskID(request.GetTaskId()),
+		tag.AckLevel(ackLevel))
+	return &matchingservice.CompleteTasksBelowResponse{AckLevel: ackLevel}, nil
+}
+
 func (e *matchingEngineImpl) listTaskListPartitions(request *matchingservice.ListTaskListPartitionsRequest, taskListType int32) ([]*tasklistpb.TaskListPartitionMetadata, error) {
 	partitions, err := e.getAllPartitions(
 		request.GetNamespace(),
@@ -639,7 +774,7 @@ func (e *matchingEngineImpl) getAllPartitions(
 	}
 
 	for i := 1; i < n; i++ {
-		partitionKeys = append(partitionKeys, fmt.Sprintf("%v%v/%v", taskListPartitionPrefix, rootPartition, i))
+		partitionKeys = append(partitionKeys, taskListID.mkName(i))
 	}
 
 	return partitionKeys, nil
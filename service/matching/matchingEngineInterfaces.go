@@ -39,5 +39,8 @@ type (
 		CancelOutstandingPoll(ctx context.Context, request *matchingservice.CancelOutstandingPollRequest) error
 		DescribeTaskList(ctx context.Context, request *matchingservice.DescribeTaskListRequest) (*matchingservice.DescribeTaskListResponse, error)
 		ListTaskListPartitions(ctx context.Context, request *matchingservice.ListTaskListPartitionsRequest) (*matchingservice.ListTaskListPartitionsResponse, error)
+		ListOwnedTaskLists(ctx context.Context) (*matchingservice.ListOwnedTaskListsResponse, error)
+		InjectTask(ctx context.Context, request *matchingservice.InjectTaskRequest) (*matchingservice.InjectTaskResponse, error)
+		CompleteTasksBelow(ctx context.Context, request *matchingservice.CompleteTasksBelowRequest) (*matchingservice.CompleteTasksBelowResponse, error)
 	}
 )
@@ -45,6 +45,7 @@ import (
 	tasklistpb "go.temporal.io/temporal-proto/tasklist"
 	"go.temporal.io/temporal-proto/workflowservice"
 
+	commongenpb "github.com/temporalio/temporal/.gen/proto/common"
 	"github.com/temporalio/temporal/.gen/proto/historyservice"
 	"github.com/temporalio/temporal/.gen/proto/historyservicemock"
 	"github.com/temporalio/temporal/.gen/proto/matchingservice"
@@ -1633,6 +1634,97 @@ func (s *matchingEngineSuite) TestTaskExpiryAndCompletion() {
 	}
 }
 
+func (s *matchingEngineSuite) TestInjectTask() {
+	s.matchingEngine.config.EnableTaskInjection = dynamicconfig.GetBoolPropertyFn(true)
+
+	namespaceID := uuid.New()
+	tl := "inject-task-tl"
+	tlID := newTestTaskListID(namespaceID, tl, persistence.TaskListTypeActivity)
+
+	resp, err := s.matchingEngine.InjectTask(context.Background(), &matchingservice.InjectTaskRequest{
+		NamespaceId:                   namespaceID,
+		TaskList:                      &tasklistpb.TaskList{Name: tl},
+		TaskListType:                  persistence.TaskListTypeActivity,
+		ScheduleToStartTimeoutSeconds: 60,
+	})
+	s.NoError(err)
+	s.NotEmpty(resp.GetExecution().GetWorkflowId())
+	s.NotEmpty(resp.GetExecution().GetRunId())
+	s.Equal(1, s.taskManager.getTaskCount(tlID))
+
+	// the injected task is written to the backlog and then delivered to a poller by the matcher
+	tlMgr, err := s.matchingEngine.getTaskListManager(tlID, tasklistpb.TaskListKindNormal)
+	s.NoError(err)
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	task, err := tlMgr.GetTask(ctx, nil)
+	s.NoError(err)
+	s.Equal(commongenpb.TaskSourceDbBacklog, task.source)
+	s.Equal(resp.GetExecution(), task.workflowExecution())
+	s.Equal(resp.GetScheduleId(), task.event.Data.GetScheduleId())
+	task.finish(nil)
+}
+
+func (s *matchingEngineSuite) TestInjectTask_Disabled() {
+	namespaceID := uuid.New()
+	tl := "inject-task-disabled-tl"
+
+	_, err := s.matchingEngine.InjectTask(context.Background(), &matchingservice.InjectTaskRequest{
+		NamespaceId:  namespaceID,
+		TaskList:     &tasklistpb.TaskList{Name: tl},
+		TaskListType: persistence.TaskListTypeDecision,
+	})
+	s.Equal(errTaskInjectionDisabled, err)
+	s.Equal(0, s.taskManager.getTaskCount(newTestTaskListID(namespaceID, tl, persistence.TaskListTypeDecision)))
+}
+
+func (s *matchingEngineSuite) TestCompleteTasksBelow() {
+	s.matchingEngine.config.EnableTaskInjection = dynamicconfig.GetBoolPropertyFn(true)
+
+	namespaceID := uuid.New()
+	tl := "complete-tasks-below-tl"
+	tlID := newTestTaskListID(namespaceID, tl, persistence.TaskListTypeActivity)
+	for i := 0; i < 3; i++ {
+		_, err := s.matchingEngine.InjectTask(context.Background(), &matchingservice.InjectTaskRequest{
+			NamespaceId:                   namespaceID,
+			TaskList:                      &tasklistpb.TaskList{Name: tl},
+			TaskListType:                  persistence.TaskListTypeActivity,
+			ScheduleToStartTimeoutSeconds: 60,
+		})
+		s.NoError(err)
+	}
+	s.Equal(3, s.taskManager.getTaskCount(tlID))
+
+	tlMgr, err := s.matchingEngine.getTaskListManager(tlID, tasklistpb.TaskListKindNormal)
+	s.NoError(err)
+	mgr := tlMgr.(*taskListManagerImpl)
+	maxTaskID := mgr.taskWriter.GetMaxReadLevel()
+
+	resp, err := s.matchingEngine.CompleteTasksBelow(context.Background(), &matchingservice.CompleteTasksBelowRequest{
+		NamespaceId:  namespaceID,
+		TaskList:     &tasklistpb.TaskList{Name: tl},
+		TaskListType: persistence.TaskListTypeActivity,
+		TaskId:       maxTaskID - 1,
+	})
+	s.NoError(err)
+	s.Equal(maxTaskID-1, resp.GetAckLevel())
+	s.Equal(maxTaskID-1, mgr.taskAckManager.getAckLevel())
+	s.Equal(maxTaskID-1, s.taskManager.getTaskListManager(tlID).ackLevel)
+	s.Equal(1, s.taskManager.getTaskCount(tlID))
+
+	// the bound is capped at the highest task id written, later tasks must not be skipped
+	resp, err = s.matchingEngine.CompleteTasksBelow(context.Background(), &matchingservice.CompleteTasksBelowRequest{
+		NamespaceId:  namespaceID,
+		TaskList:     &tasklistpb.TaskList{Name: tl},
+		TaskListType: persistence.TaskListTypeActivity,
+		TaskId:       maxTaskID + 100,
+	})
+	s.NoError(err)
+	s.Equal(maxTaskID, resp.GetAckLevel())
+	s.Equal(maxTaskID, mgr.taskAckManager.getAckLevel())
+	s.Equal(0, s.taskManager.getTaskCount(tlID))
+}
+
 func (s *matchingEngineSuite) setupRecordActivityTaskStartedMock(tlName string) {
 	activityTypeName := "activity1"
 	activityID := "activityId1"
@@ -1715,6 +1807,10 @@ func (m *testTaskManager) GetName() string {
 	return "test"
 }
 
+func (m *testTaskManager) GetTimePrecision() time.Duration {
+	return time.Nanosecond
+}
+
 func (m *testTaskManager) Close() {
 	return
 }
@@ -1835,6 +1931,30 @@ func (m *testTaskManager) CompleteTasksLessThan(request *persistence.CompleteTas
 	return persistence.UnknownNumRowsAffected, nil
 }
 
+func (m *testTaskManager) MoveTasks(request *persistence.MoveTasksRequest) (int, error) {
+	return 0, fmt.Errorf("unsupported operation")
+}
+
+func (m *testTaskManager) PurgeExpiredTasks(request *persistence.PurgeExpiredTasksRequest) (int, error) {
+	return 0, fmt.Errorf("unsupported operation")
+}
+
+func (m *testTaskManager) EstimateBacklogCount(request *persistence.EstimateBacklogCountRequest) (int64, error) {
+	return 0, fmt.Errorf("unsupported operation")
+}
+
+func (m *testTaskManager) GetTaskListRangeID(request *persistence.GetTaskListRangeIDRequest) (int64, error) {
+	return 0, fmt.Errorf("unsupported operation")
+}
+
+func (m *testTaskManager) RenewLeaseAndUpdate(request *persistence.RenewLeaseAndUpdateRequest) (*persistence.RenewLeaseAndUpdateResponse, error) {
+	return nil, fmt.Errorf("unsupported operation")
+}
+
+func (m *testTaskManager) GetTasksAndMetadata(request *persistence.GetTasksAndMetadataRequest) (*persistence.GetTasksAndMetadataResponse, error) {
+	return nil, fmt.Errorf("unsupported operation")
+}
+
 func (m *testTaskManager) ListTaskList(
 	request *persistence.ListTaskListRequest) (*persistence.ListTaskListResponse, error) {
 	return nil, fmt.Errorf("unsupported operation")
@@ -1922,6 +2042,18 @@ func (m *testTaskManager) GetTasks(request *persistence.GetTasksRequest) (*persi
 	}, nil
 }
 
+// GetTask provides a mock function with given fields: request
+func (m *testTaskManager) GetTask(request *persistence.GetTaskRequest) (*persistence.GetTaskResponse, error) {
+	tlm := m.getTaskListManager(newTestTaskListID(primitives.UUIDString(request.NamespaceID), request.TaskList, request.TaskType))
+	tlm.Lock()
+	defer tlm.Unlock()
+	task, ok := tlm.tasks.Get(request.TaskID)
+	if !ok {
+		return nil, serviceerror.NewNotFound(fmt.Sprintf("Task not found. TaskID: %v", request.TaskID))
+	}
+	return &persistence.GetTaskResponse{Task: task.(*persistenceblobs.AllocatedTaskInfo)}, nil
+}
+
 // getTaskCount returns number of tasks in a task list
 func (m *testTaskManager) getTaskCount(taskList *taskListID) int {
 	tlm := m.getTaskListManager(taskList)
@@ -120,3 +120,35 @@ func (h *NilCheckHandler) ListTaskListPartitions(ctx context.Context, request *m
 	}
 	return resp, err
 }
+
+func (h *NilCheckHandler) RefreshNamespaceCache(ctx context.Context, request *matchingservice.RefreshNamespaceCacheRequest) (*matchingservice.RefreshNamespaceCacheResponse, error) {
+	resp, err := h.parentHandler.RefreshNamespaceCache(ctx, request)
+	if resp == nil && err == nil {
+		resp = &matchingservice.RefreshNamespaceCacheResponse{}
+	}
+	return resp, err
+}
+
+func (h *NilCheckHandler) ListOwnedTaskLists(ctx context.Context, request *matchingservice.ListOwnedTaskListsRequest) (*matchingservice.ListOwnedTaskListsResponse, error) {
+	resp, err := h.parentHandler.ListOwnedTaskLists(ctx, request)
+	if resp == nil && err == nil {
+		resp = &matchingservice.ListOwnedTaskListsResponse{}
+	}
+	return resp, err
+}
+
+func (h *NilCheckHandler) InjectTask(ctx context.Context, request *matchingservice.InjectTaskRequest) (*matchingservice.InjectTaskResponse, error) {
+	resp, err := h.parentHandler.InjectTask(ctx, request)
+	if resp == nil && err == nil {
+		resp = &matchingservice.InjectTaskResponse{}
+	}
+	return resp, err
+}
+
+func (h *NilCheckHandler) CompleteTasksBelow(ctx context.Context, request *matchingservice.CompleteTasksBelowRequest) (*matchingservice.CompleteTasksBelowResponse, error) {
+	resp, err := h.parentHandler.CompleteTasksBelow(ctx, request)
+	if resp == nil && err == nil {
+		resp = &matchingservice.CompleteTasksBelowResponse{}
+	}
+	return resp, err
+}
@@ -0,0 +1,101 @@
+// The MIT License (MIT)
+//
+// Copyright (c) 2020 Temporal Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package matching
+
+type (
+	// PartitionRouter decides the tree formed by the partitions of a task list and their number.
+	//
+	// The names of the partitions are not up to the router: the root partition is named after the
+	// task list and the others /__temporal_sys/[original-name]/[partitionID]. Matching parses the
+	// partition out of these names, and the matching client load balancer of the callers names the
+	// partitions it spreads requests over the same way, with the configured number of partitions.
+	PartitionRouter interface {
+		// ParentPartition returns the partition the given non-root partition of a task list forwards to,
+		// degree is the configured number of children at each level of the tree, negative for no parent.
+		// The parent must be lower than the partition, so the partitions form a tree rooted at partition 0
+		ParentPartition(baseName string, partition int, degree int) int
+		// NumPartitions returns the number of partitions given the configured value, which bounds it
+		// as callers never send requests to partitions beyond the configured ones
+		NumPartitions(baseName string, configured int) int
+	}
+
+	defaultPartitionRouter struct{}
+)
+
+var _ PartitionRouter = (*defaultPartitionRouter)(nil)
+
+// NewDefaultPartitionRouter returns a PartitionRouter which forms a N-ary tree out of the configured partitions
+func NewDefaultPartitionRouter() PartitionRouter {
+	return &defaultPartitionRouter{}
+}
+
+func (r *defaultPartitionRouter) ParentPartition(
+	baseName string,
+	partition int,
+	degree int,
+) int {
+	if degree == 0 {
+		return -1
+	}
+	return (partition+degree-1)/degree - 1
+}
+
+func (r *defaultPartitionRouter) NumPartitions(
+	baseName string,
+	configured int,
+) int {
+	return configured
+}
+
+// parentPartitionName returns the name of the parent of a task list partition chosen by the router,
+// empty string for the root partition or when the router does not pick a lower partition
+func parentPartitionName(
+	router PartitionRouter,
+	tn qualifiedTaskListName,
+	degree int,
+) string {
+	if tn.IsRoot() {
+		return ""
+	}
+	parent := router.ParentPartition(tn.baseName, tn.partition, degree)
+	if parent < 0 || parent >= tn.partition {
+		return ""
+	}
+	return tn.mkName(parent)
+}
+
+// numPartitions returns the number of partitions chosen by the router, between 1 and the configured value
+func numPartitions(
+	router PartitionRouter,
+	baseName string,
+	configured int,
+) int {
+	n := router.NumPartitions(baseName, configured)
+	if n > configured {
+		n = configured
+	}
+	if n < 1 {
+		n = 1
+	}
+	return n
+}
@@ -0,0 +1,76 @@
+// Copyright (c) 2019 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package matching
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// fixedPartitionRouter picks the same parent and number of partitions for every task list
+type fixedPartitionRouter struct {
+	parent        int
+	numPartitions int
+}
+
+func (r *fixedPartitionRouter) ParentPartition(baseName string, partition int, degree int) int {
+	return r.parent
+}
+
+func (r *fixedPartitionRouter) NumPartitions(baseName string, configured int) int {
+	return r.numPartitions
+}
+
+func TestDefaultPartitionRouterParentName(t *testing.T) {
+	router := NewDefaultPartitionRouter()
+	for _, degree := range []int{0, 1, 2, 3, 20} {
+		for partition := 0; partition < 50; partition++ {
+			tn := qualifiedTaskListName{baseName: "list0", partition: partition}
+			tn.name = tn.mkName(partition)
+			require.Equal(t, tn.Parent(degree), parentPartitionName(router, tn, degree))
+		}
+	}
+}
+
+func TestParentPartitionNameOutsideTree(t *testing.T) {
+	tn, err := newTaskListName("/__temporal_sys/list0/5")
+	require.NoError(t, err)
+
+	require.Equal(t, "list0", parentPartitionName(&fixedPartitionRouter{parent: 0}, tn, 20))
+	require.Equal(t, "/__temporal_sys/list0/4", parentPartitionName(&fixedPartitionRouter{parent: 4}, tn, 20))
+	// a parent which is not lower than the partition could form a cycle
+	require.Equal(t, "", parentPartitionName(&fixedPartitionRouter{parent: 5}, tn, 20))
+	require.Equal(t, "", parentPartitionName(&fixedPartitionRouter{parent: 6}, tn, 20))
+	require.Equal(t, "", parentPartitionName(&fixedPartitionRouter{parent: -1}, tn, 20))
+
+	root, err := newTaskListName("list0")
+	require.NoError(t, err)
+	require.Equal(t, "", parentPartitionName(&fixedPartitionRouter{parent: 0}, root, 20))
+}
+
+func TestNumPartitionsBoundedByConfig(t *testing.T) {
+	require.Equal(t, 4, numPartitions(NewDefaultPartitionRouter(), "list0", 4))
+	require.Equal(t, 1, numPartitions(NewDefaultPartitionRouter(), "list0", 0))
+	require.Equal(t, 2, numPartitions(&fixedPartitionRouter{numPartitions: 2}, "list0", 4))
+	require.Equal(t, 4, numPartitions(&fixedPartitionRouter{numPartitions: 8}, "list0", 4))
+	require.Equal(t, 1, numPartitions(&fixedPartitionRouter{numPartitions: 0}, "list0", 4))
+}
@@ -26,6 +26,8 @@ import (
 	tasklistpb "go.temporal.io/temporal-proto/tasklist"
 
 	"github.com/temporalio/temporal/common/cache"
+	"github.com/temporalio/temporal/common/clock"
+	"github.com/temporalio/temporal/common/metrics"
 )
 
 const (
@@ -38,7 +40,8 @@ type (
 	pollerIdentity string
 
 	pollerInfo struct {
-		ratePerSecond float64
+		ratePerSecond  float64
+		lastAccessTime time.Time
 	}
 )
 
@@ -46,9 +49,13 @@ type pollerHistory struct {
 	// poller ID -> pollerInfo
 	// pollers map[pollerID]pollerInfo
 	history cache.Cache
+	// stalenessWindow is the time since its last poll after which a poller is evicted
+	stalenessWindow func() time.Duration
+	scope           func() metrics.Scope
+	timeSource      clock.TimeSource
 }
 
-func newPollerHistory() *pollerHistory {
+func newPollerHistory(stalenessWindow func() time.Duration, scope func() metrics.Scope) *pollerHistory {
 	opts := &cache.Options{
 		InitialCapacity: pollerHistoryInitSize,
 		TTL:             pollerHistoryTTL,
@@ -56,7 +63,10 @@ func newPollerHistory() *pollerHistory {
 	}
 
 	return &pollerHistory{
-		history: cache.New(pollerHistoryInitMaxSize, opts),
+		history:         cache.New(pollerHistoryInitMaxSize, opts),
+		stalenessWindow: stalenessWindow,
+		scope:           scope,
+		timeSource:      clock.NewRealTimeSource(),
 	}
 }
 
@@ -65,26 +75,40 @@ func (pollers *pollerHistory) updatePollerInfo(id pollerIdentity, ratePerSecond
 	if ratePerSecond != nil {
 		rps = *ratePerSecond
 	}
-	pollers.history.Put(id, &pollerInfo{ratePerSecond: rps})
+	pollers.history.Put(id, &pollerInfo{ratePerSecond: rps, lastAccessTime: pollers.timeSource.Now()})
 }
 
+// getAllPollerInfo returns the pollers which polled within the staleness window and evicts the
+// others. Pollers are also dropped by the cache once they haven't polled for pollerHistoryTTL
 func (pollers *pollerHistory) getAllPollerInfo() []*tasklistpb.PollerInfo {
 	var result []*tasklistpb.PollerInfo
+	var stale []pollerIdentity
 
+	staleBefore := pollers.timeSource.Now().Add(-pollers.stalenessWindow())
 	ite := pollers.history.Iterator()
-	defer ite.Close()
 	for ite.HasNext() {
 		entry := ite.Next()
 		key := entry.Key().(pollerIdentity)
 		value := entry.Value().(*pollerInfo)
+		if value.lastAccessTime.Before(staleBefore) {
+			stale = append(stale, key)
+			continue
+		}
 		// TODO add IP, T1396795
-		lastAccessTime := entry.CreateTime()
 		result = append(result, &tasklistpb.PollerInfo{
 			Identity:       string(key),
-			LastAccessTime: lastAccessTime.UnixNano(),
+			LastAccessTime: value.lastAccessTime.UnixNano(),
 			RatePerSecond:  value.ratePerSecond,
 		})
 	}
+	// the iterator holds the cache lock, so stale pollers are deleted once it is closed
+	ite.Close()
 
+	for _, key := range stale {
+		pollers.history.Delete(key)
+	}
+	if len(stale) > 0 {
+		pollers.scope().AddCounter(metrics.EvictedPollersCounter, int64(len(stale)))
+	}
 	return result
 }
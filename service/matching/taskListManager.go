@@ -32,6 +32,7 @@ import (
 	executionpb "go.temporal.io/temporal-proto/execution"
 	tasklistpb "go.temporal.io/temporal-proto/tasklist"
 
+	"github.com/temporalio/temporal/.gen/proto/adminservice"
 	commongenpb "github.com/temporalio/temporal/.gen/proto/common"
 	"github.com/temporalio/temporal/.gen/proto/matchingservice"
 
@@ -84,6 +85,12 @@ type (
 		GetAllPollerInfo() []*tasklistpb.PollerInfo
 		// DescribeTaskList returns information about the target task list
 		DescribeTaskList(includeTaskListStatus bool) *matchingservice.DescribeTaskListResponse
+		// InjectTask writes a task straight to the backlog of the task list, skipping the sync match
+		InjectTask(execution *executionpb.WorkflowExecution, taskInfo *persistenceblobs.TaskInfo) error
+		// CompleteTasksBelow completes all tasks with a task id at or below the given one, delivered or not,
+		// and returns the new ack level. A task already offered to the matcher may still be delivered once.
+		CompleteTasksBelow(taskID int64) (int64, error)
+		GetTaskListKind() tasklistpb.TaskListKind
 		String() string
 	}
 
@@ -123,6 +130,8 @@ type (
 const (
 	// maxSyncMatchWaitTime is the max amount of time that we are willing to wait for a sync match to happen
 	maxSyncMatchWaitTime = 200 * time.Millisecond
+	// stickyTaskListMetricName is the task list tag value shared by all sticky task lists
+	stickyTaskListMetricName = "__sticky__"
 )
 
 var _ taskListManager = (*taskListManagerImpl)(nil)
@@ -154,20 +163,20 @@ func newTaskListManager(
 		taskAckManager:      newAckManager(e.logger),
 		taskGC:              newTaskGC(db, taskListConfig),
 		config:              taskListConfig,
-		pollerHistory:       newPollerHistory(),
 		outstandingPollsMap: make(map[string]context.CancelFunc),
 		taskListKind:        int(taskListKind),
 	}
 	tlMgr.namespaceValue.Store("")
 	tlMgr.namespaceScopeValue.Store(e.metricsClient.Scope(metrics.MatchingTaskListMgrScope, metrics.NamespaceUnknownTag()))
 	tlMgr.tryInitNamespaceAndScope()
+	tlMgr.pollerHistory = newPollerHistory(taskListConfig.PollerStalenessWindow, tlMgr.taskListScope)
 	tlMgr.taskWriter = newTaskWriter(tlMgr)
 	tlMgr.taskReader = newTaskReader(tlMgr)
 	var fwdr *Forwarder
 	if tlMgr.isFowardingAllowed(taskList, taskListKind) {
 		fwdr = newForwarder(&taskListConfig.forwarderConfig, taskList, taskListKind, e.matchingClient, tlMgr.namespaceScope)
 	}
-	tlMgr.matcher = newTaskMatcher(taskListConfig, fwdr, tlMgr.namespaceScope)
+	tlMgr.matcher = newTaskMatcher(taskListConfig, fwdr, tlMgr.namespaceScope, tlMgr.taskListScope, tlMgr.taskAckManager.getBacklogCountHint)
 	tlMgr.startWG.Add(1)
 	return tlMgr, nil
 }
@@ -197,6 +206,8 @@ func (c *taskListManagerImpl) Stop() {
 		return
 	}
 	close(c.shutdownCh)
+	// wake up blocked pollers so that they re-poll the new owner of this task list
+	c.matcher.Unload()
 	c.taskWriter.Stop()
 	c.taskReader.Stop()
 	c.engine.removeTaskListManager(c.taskListID)
@@ -228,6 +239,10 @@ func (c *taskListManagerImpl) AddTask(ctx context.Context, params addTaskParams)
 		if syncMatch {
 			return &persistence.CreateTasksResponse{}, err
 		}
+		if err == errMatchingBacklogFull {
+			// backlog is too large, let the caller throttle instead of growing it further
+			return nil, err
+		}
 
 		if params.forwardedFrom != "" {
 			// forwarded from child partition - only do sync match
@@ -284,7 +299,7 @@ func (c *taskListManagerImpl) getTask(ctx context.Context, maxDispatchPerSecond
 	// reached, instead of emptyTask, context timeout error is returned to the frontend by the rpc stack,
 	// which counts against our SLO. By shortening the timeout by a very small amount, the emptyTask can be
 	// returned to the handler before a context timeout error is generated.
-	childCtx, cancel := c.newChildContext(ctx, c.config.LongPollExpirationInterval(), returnEmptyTaskTimeBudget)
+	childCtx, cancel := c.newPollContext(ctx)
 	defer cancel()
 
 	pollerID, ok := ctx.Value(pollerIDKey).(string)
@@ -342,7 +357,7 @@ func (c *taskListManagerImpl) CancelPoller(pollerID string) {
 
 // DescribeTaskList returns information about the target tasklist, right now this API returns the
 // pollers which polled this tasklist in last few minutes and status of tasklist's ackManager
-// (readLevel, ackLevel, backlogCountHint and taskIDBlock).
+// (readLevel, ackLevel, backlogCountHint and taskIDBlock) along with whether it is draining a backlog.
 func (c *taskListManagerImpl) DescribeTaskList(includeTaskListStatus bool) *matchingservice.DescribeTaskListResponse {
 	response := &matchingservice.DescribeTaskListResponse{Pollers: c.GetAllPollerInfo()}
 	if !includeTaskListStatus {
@@ -360,10 +375,46 @@ func (c *taskListManagerImpl) DescribeTaskList(includeTaskListStatus bool) *matc
 			EndId:   taskIDBlock.end,
 		},
 	}
+	response.BacklogStatus = &adminservice.TaskListBacklogStatus{
+		// tasks read from persistence and not acked yet are being dispatched from the backlog
+		BacklogMode:      response.TaskListStatus.GetBacklogCountHint() > 0,
+		ReadLevel:        response.TaskListStatus.GetReadLevel(),
+		AckLevel:         response.TaskListStatus.GetAckLevel(),
+		ForwardingActive: c.matcher.isForwardingActive(),
+	}
 
 	return response
 }
 
+func (c *taskListManagerImpl) InjectTask(execution *executionpb.WorkflowExecution, taskInfo *persistenceblobs.TaskInfo) error {
+	c.startWG.Wait()
+	_, err := c.executeWithRetry(func() (interface{}, error) {
+		return c.taskWriter.appendTask(execution, taskInfo)
+	})
+	if err == nil {
+		c.taskReader.Signal()
+	}
+	return err
+}
+
+func (c *taskListManagerImpl) CompleteTasksBelow(taskID int64) (int64, error) {
+	c.startWG.Wait()
+	// task ids above the ones written so far may still be allocated to new tasks, which must not be skipped
+	if maxReadLevel := c.taskWriter.GetMaxReadLevel(); taskID > maxReadLevel {
+		taskID = maxReadLevel
+	}
+	ackLevel := c.taskAckManager.completeTasksBelow(taskID)
+	if err := c.db.UpdateState(ackLevel); err != nil {
+		return 0, err
+	}
+	c.taskGC.RunNow(ackLevel)
+	return ackLevel, nil
+}
+
+func (c *taskListManagerImpl) GetTaskListKind() tasklistpb.TaskListKind {
+	return tasklistpb.TaskListKind(c.taskListKind)
+}
+
 func (c *taskListManagerImpl) String() string {
 	buf := new(bytes.Buffer)
 	if c.taskListID.taskType == persistence.TaskListTypeActivity {
@@ -469,6 +520,9 @@ func (c *taskListManagerImpl) executeWithRetry(
 		if _, ok := err.(*persistence.ConditionFailedError); ok {
 			return false
 		}
+		if err == errMatchingBacklogFull {
+			return false
+		}
 		return common.IsPersistenceTransientError(err)
 	})
 
@@ -495,6 +549,18 @@ func (c *taskListManagerImpl) trySyncMatch(ctx context.Context, params addTaskPa
 	return matched, err
 }
 
+// newPollContext creates the child context a poll waits for a task on.
+// The poll gives up returnEmptyTaskTimeBudget before the poller's deadline,
+// which is also attached as the poll deadline the matcher's minimum poll
+// timeout is capped by, so the empty task always makes it back in time.
+func (c *taskListManagerImpl) newPollContext(parent context.Context) (context.Context, context.CancelFunc) {
+	childCtx, cancel := c.newChildContext(parent, c.config.LongPollExpirationInterval(), returnEmptyTaskTimeBudget)
+	if deadline, ok := parent.Deadline(); ok {
+		childCtx = context.WithValue(childCtx, pollDeadlineKey, deadline.Add(-returnEmptyTaskTimeBudget))
+	}
+	return childCtx, cancel
+}
+
 // newChildContext creates a child context with desired timeout.
 // if tailroom is non-zero, then child context timeout will be
 // the minOf(parentCtx.Deadline()-tailroom, timeout). Use this
@@ -535,6 +601,21 @@ func (c *taskListManagerImpl) namespaceScope() metrics.Scope {
 	return c.namespaceScopeValue.Load().(metrics.Scope)
 }
 
+// taskListScope returns the namespace scope tagged with the name and type of
+// this task list. To bound the tag cardinality, all partitions of a task list
+// are tagged with the root name and all sticky task lists share a single name
+func (c *taskListManagerImpl) taskListScope() metrics.Scope {
+	name := c.taskListID.GetRoot()
+	if c.taskListKind == int(tasklistpb.TaskListKindSticky) {
+		name = stickyTaskListMetricName
+	}
+	taskListType := "decision"
+	if c.taskListID.taskType == persistence.TaskListTypeActivity {
+		taskListType = "activity"
+	}
+	return c.namespaceScope().Tagged(metrics.TaskListTag(name), metrics.TaskListTypeTag(taskListType))
+}
+
 func (c *taskListManagerImpl) namespace() string {
 	name := c.namespaceValue.Load().(string)
 	if len(name) > 0 {
@@ -30,13 +30,16 @@ import (
 	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"github.com/uber-go/tally"
 	tasklistpb "go.temporal.io/temporal-proto/tasklist"
 
 	"github.com/temporalio/temporal/.gen/proto/persistenceblobs"
 
 	"github.com/temporalio/temporal/common/cache"
+	"github.com/temporalio/temporal/common/clock"
 	"github.com/temporalio/temporal/common/log/loggerimpl"
 	"github.com/temporalio/temporal/common/log/tag"
+	"github.com/temporalio/temporal/common/metrics"
 	"github.com/temporalio/temporal/common/persistence"
 	"github.com/temporalio/temporal/common/primitives/timestamp"
 	"github.com/temporalio/temporal/common/service/dynamicconfig"
@@ -181,6 +184,45 @@ func TestIsTaskAddedRecently(t *testing.T) {
 	require.False(t, tlm.taskReader.isTaskAddedRecently(time.Time{}))
 }
 
+func TestNewPollContext(t *testing.T) {
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+
+	tlm := createTestTaskListManager(controller)
+	tlm.config.MinPollTimeout = func() time.Duration { return 5 * time.Second }
+
+	// the empty task budget is reserved however long the minimum poll timeout is
+	parent, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+	parentDeadline, _ := parent.Deadline()
+	ctx, childCancel := tlm.newPollContext(parent)
+	defer childCancel()
+	deadline, ok := ctx.Deadline()
+	require.True(t, ok)
+	require.False(t, deadline.After(parentDeadline.Add(-returnEmptyTaskTimeBudget)))
+	require.Equal(t, parentDeadline.Add(-returnEmptyTaskTimeBudget), ctx.Value(pollDeadlineKey))
+
+	// cancelling the poller aborts the poll
+	cancel()
+	select {
+	case <-ctx.Done():
+	case <-time.After(time.Second):
+		t.Fatal("poll context was not cancelled with its parent")
+	}
+}
+
+func TestNewPollContext_NoDeadline(t *testing.T) {
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+
+	tlm := createTestTaskListManager(controller)
+	ctx, cancel := tlm.newPollContext(context.Background())
+	defer cancel()
+	_, ok := ctx.Deadline()
+	require.True(t, ok)
+	require.Nil(t, ctx.Value(pollDeadlineKey))
+}
+
 func TestDescribeTaskList(t *testing.T) {
 	controller := gomock.NewController(t)
 	defer controller.Finish()
@@ -241,6 +283,66 @@ func TestDescribeTaskList(t *testing.T) {
 	require.Zero(t, taskListStatus.GetBacklogCountHint())
 }
 
+func TestDescribeTaskList_BacklogStatus(t *testing.T) {
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+
+	tlm := createTestTaskListManager(controller)
+	tlm.db.rangeID = int64(1)
+	tlm.taskAckManager.setAckLevel(0)
+
+	backlogStatus := tlm.DescribeTaskList(true).GetBacklogStatus()
+	require.NotNil(t, backlogStatus)
+	require.False(t, backlogStatus.GetBacklogMode())
+	require.Zero(t, backlogStatus.GetReadLevel())
+	require.Zero(t, backlogStatus.GetAckLevel())
+	require.False(t, backlogStatus.GetForwardingActive())
+
+	// seed a backlog of tasks read from persistence and not acked yet
+	for taskID := int64(1); taskID <= 3; taskID++ {
+		tlm.taskAckManager.addTask(taskID)
+	}
+	tlm.taskAckManager.completeTask(1)
+
+	backlogStatus = tlm.DescribeTaskList(true).GetBacklogStatus()
+	require.True(t, backlogStatus.GetBacklogMode())
+	require.Equal(t, int64(3), backlogStatus.GetReadLevel())
+	require.Equal(t, int64(1), backlogStatus.GetAckLevel())
+
+	require.Nil(t, tlm.DescribeTaskList(false).GetBacklogStatus())
+}
+
+func TestDescribeTaskList_EvictsStalePollers(t *testing.T) {
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+
+	cfg := NewConfig(dynamicconfig.NewNopCollection())
+	cfg.PollerStalenessWindow = dynamicconfig.GetDurationPropertyFnFilteredByTaskListInfo(time.Minute)
+	tlm := createTestTaskListManagerWithConfig(controller, cfg)
+	scope := tally.NewTestScope("test", nil)
+	metricsClient := metrics.NewClient(scope, metrics.Matching)
+	tlm.pollerHistory.scope = func() metrics.Scope { return metricsClient.Scope(metrics.MatchingTaskListMgrScope) }
+	timeSource := clock.NewEventTimeSource().Update(time.Now())
+	tlm.pollerHistory.timeSource = timeSource
+
+	tlm.pollerHistory.updatePollerInfo(pollerIdentity("stale-poller"), nil)
+	timeSource.Update(timeSource.Now().Add(30 * time.Second))
+	tlm.pollerHistory.updatePollerInfo(pollerIdentity("active-poller"), nil)
+	require.Equal(t, 2, len(tlm.DescribeTaskList(false).GetPollers()))
+
+	timeSource.Update(timeSource.Now().Add(45 * time.Second))
+	pollers := tlm.DescribeTaskList(false).GetPollers()
+	require.Equal(t, 1, len(pollers))
+	require.Equal(t, "active-poller", pollers[0].GetIdentity())
+	require.Equal(t, timeSource.Now().Add(-45*time.Second).UnixNano(), pollers[0].GetLastAccessTime())
+
+	// the evicted poller is not counted again
+	require.Equal(t, 1, len(tlm.DescribeTaskList(false).GetPollers()))
+	counter, ok := scope.Snapshot().Counters()["test.evicted_pollers+operation=TaskListMgr"]
+	require.True(t, ok)
+	require.Equal(t, int64(1), counter.Value())
+}
+
 func tlMgrStartWithoutNotifyEvent(tlm *taskListManagerImpl) {
 	// mimic tlm.Start() but avoid calling notifyEvent
 	tlm.startWG.Done()
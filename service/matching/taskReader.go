@@ -93,6 +93,10 @@ dispatchLoop:
 			if !ok { // Task list getTasks pump is shutdown
 				break dispatchLoop
 			}
+			if taskInfo.GetTaskId() <= tr.tlMgr.taskAckManager.getAckLevel() {
+				// completed by CompleteTasksBelow while it was buffered
+				continue
+			}
 			task := newInternalTask(taskInfo, tr.tlMgr.completeTask, commongenpb.TaskSourceDbBacklog, "", false)
 			for {
 				err := tr.tlMgr.DispatchTask(tr.cancelCtx, task)
@@ -103,6 +107,10 @@ dispatchLoop:
 					tr.tlMgr.logger.Info("Tasklist manager context is cancelled, shutting down")
 					break dispatchLoop
 				}
+				if err == errTaskListUnloaded {
+					// the task is not acked and will be read again by the next owner of the task list
+					break dispatchLoop
+				}
 				// this should never happen unless there is a bug - don't drop the task
 				tr.scope().IncCounter(metrics.BufferThrottleCounter)
 				tr.logger().Error("taskReader: unexpected error dispatching task", tag.Error(err))
@@ -83,6 +83,8 @@ func uploadHistoryActivity(ctx context.Context, request ArchiveRequest) (err err
 		BranchToken:          request.BranchToken,
 		NextEventID:          request.NextEventID,
 		CloseFailoverVersion: request.CloseFailoverVersion,
+		FromEventID:          request.FromEventID,
+		ToEventID:            request.ToEventID,
 	}, carchiver.GetHeartbeatArchiveOption(), carchiver.GetNonRetriableErrorOption(errUploadNonRetriable))
 	if err == nil {
 		return nil
@@ -159,7 +161,7 @@ func archiveVisibilityActivity(ctx context.Context, request ArchiveRequest) (err
 		Status:             request.Status,
 		HistoryLength:      request.HistoryLength,
 		Memo:               request.Memo,
-		SearchAttributes:   convertSearchAttributesToString(request.SearchAttributes),
+		SearchAttributes:   transformSearchAttributes(container.SearchAttributesTransformProvider, request.Namespace, request.SearchAttributes),
 		HistoryArchivalURI: request.URI,
 	}, carchiver.GetNonRetriableErrorOption(errArchiveVisibilityNonRetriable))
 	if err == nil {
@@ -25,6 +25,8 @@ import (
 	"errors"
 	"fmt"
 	"math/rand"
+	"strings"
+	"sync"
 	"time"
 
 	commonpb "go.temporal.io/temporal-proto/common"
@@ -67,6 +69,9 @@ type (
 		NextEventID          int64
 		CloseFailoverVersion int64
 		URI                  string // should be historyURI, but keep the existing name for backward compatibility
+		// optional inclusive bounds of the history range to archive, zero archives the full history
+		FromEventID int64
+		ToEventID   int64
 
 		// visibility archival
 		WorkflowTypeName   string
@@ -83,18 +88,35 @@ type (
 		Targets []ArchivalTarget
 	}
 
+	// SearchAttributesTransform is applied to the search attributes of a visibility record before it is archived
+	SearchAttributesTransform func(searchAttr map[string]string) map[string]string
+
+	// SearchAttributesTransformProvider returns the SearchAttributesTransform to apply for a namespace
+	SearchAttributesTransformProvider func(namespace string) SearchAttributesTransform
+
 	// Client is used to archive workflow histories
 	Client interface {
 		Archive(context.Context, *ClientRequest) (*ClientResponse, error)
+		// Close stops accepting new archive requests and waits for the in-flight ones to finish
+		Close(context.Context) error
 	}
 
 	client struct {
 		metricsScope     metrics.Scope
 		logger           log.Logger
 		temporalClient   sdkclient.Client
-		numWorkflows     dynamicconfig.IntPropertyFn
+		numWorkflows     dynamicconfig.IntPropertyFnWithNamespaceFilter
 		rateLimiter      quotas.Limiter
 		archiverProvider provider.ArchiverProvider
+
+		searchAttributesTransformProvider SearchAttributesTransformProvider
+		// historyStatusFilter returns the comma separated list of workflow statuses whose history is archived
+		historyStatusFilter dynamicconfig.StringPropertyFnWithNamespaceFilter
+
+		// inflightWG tracks in-flight Archive calls, which wait for their inline archival goroutines
+		inflightWG sync.WaitGroup
+		closeLock  sync.Mutex
+		closed     bool
 	}
 
 	// ArchivalTarget is either history or visibility
@@ -105,6 +127,15 @@ const (
 	signalTimeout = 300 * time.Millisecond
 
 	tooManyRequestsErrMsg = "too many requests to archival workflow"
+
+	archivalTargetHistoryTagValue    = "history"
+	archivalTargetVisibilityTagValue = "visibility"
+	archivalOutcomeSuccessTagValue   = "success"
+	archivalOutcomeFailureTagValue   = "failure"
+)
+
+var (
+	errClientClosed = errors.New("archiver client is closed")
 )
 
 const (
@@ -114,14 +145,19 @@ const (
 	ArchiveTargetVisibility
 )
 
+// inlineArchivalTargets are the targets which can be archived inline, in the order their results are collected
+var inlineArchivalTargets = []ArchivalTarget{ArchiveTargetHistory, ArchiveTargetVisibility}
+
 // NewClient creates a new Client
 func NewClient(
 	metricsClient metrics.Client,
 	logger log.Logger,
 	publicClient sdkclient.Client,
-	numWorkflows dynamicconfig.IntPropertyFn,
+	numWorkflows dynamicconfig.IntPropertyFnWithNamespaceFilter,
 	requestRPS dynamicconfig.IntPropertyFn,
 	archiverProvider provider.ArchiverProvider,
+	searchAttributesTransformProvider SearchAttributesTransformProvider,
+	historyStatusFilter dynamicconfig.StringPropertyFnWithNamespaceFilter,
 ) Client {
 	return &client{
 		metricsScope:   metricsClient.Scope(metrics.ArchiverClientScope),
@@ -133,12 +169,24 @@ func NewClient(
 				return float64(requestRPS())
 			},
 		),
-		archiverProvider: archiverProvider,
+		archiverProvider:                  archiverProvider,
+		searchAttributesTransformProvider: searchAttributesTransformProvider,
+		historyStatusFilter:               historyStatusFilter,
 	}
 }
 
 // Archive starts an archival task
 func (c *client) Archive(ctx context.Context, request *ClientRequest) (*ClientResponse, error) {
+	c.closeLock.Lock()
+	if c.closed {
+		c.closeLock.Unlock()
+		return nil, errClientClosed
+	}
+	c.inflightWG.Add(1)
+	c.closeLock.Unlock()
+	defer c.inflightWG.Done()
+
+	c.filterTargetsByStatus(request.ArchiveRequest)
 	for _, target := range request.ArchiveRequest.Targets {
 		switch target {
 		case ArchiveTargetHistory:
@@ -155,10 +203,13 @@ func (c *client) Archive(ctx context.Context, request *ClientRequest) (*ClientRe
 		HistoryArchivedInline: false,
 	}
 	if request.AttemptArchiveInline {
-		results := []chan error{}
+		results := make(map[ArchivalTarget]chan error)
 		for _, target := range request.ArchiveRequest.Targets {
-			ch := make(chan error)
-			results = append(results, ch)
+			if _, ok := results[target]; ok {
+				continue
+			}
+			ch := make(chan error, 1)
+			results[target] = ch
 			switch target {
 			case ArchiveTargetHistory:
 				go c.archiveHistoryInline(ctx, request, logger, ch)
@@ -169,11 +220,19 @@ func (c *client) Archive(ctx context.Context, request *ClientRequest) (*ClientRe
 			}
 		}
 
+		// collect the results in a fixed target order, so the targets re-queued for the
+		// archival workflow do not depend on the order in the request
 		targets := []ArchivalTarget{}
-		for i, target := range request.ArchiveRequest.Targets {
-			if <-results[i] != nil {
+		for _, target := range inlineArchivalTargets {
+			ch, ok := results[target]
+			if !ok {
+				continue
+			}
+			if err := <-ch; err != nil {
 				targets = append(targets, target)
-			} else if target == ArchiveTargetHistory {
+				continue
+			}
+			if target == ArchiveTargetHistory {
 				resp.HistoryArchivedInline = true
 			}
 		}
@@ -187,6 +246,60 @@ func (c *client) Archive(ctx context.Context, request *ClientRequest) (*ClientRe
 	return resp, nil
 }
 
+// Close stops accepting new Archive calls and waits for the in-flight ones, including
+// their inline archivals, to finish. Returns the context error if they do not finish
+// before the context is done
+func (c *client) Close(ctx context.Context) error {
+	c.closeLock.Lock()
+	c.closed = true
+	c.closeLock.Unlock()
+
+	doneC := make(chan struct{})
+	go func() {
+		c.inflightWG.Wait()
+		close(doneC)
+	}()
+	select {
+	case <-doneC:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// filterTargetsByStatus removes the history target when the status of the workflow is not one of the
+// statuses whose history is archived for the namespace. The visibility target is always kept
+func (c *client) filterTargetsByStatus(request *ArchiveRequest) {
+	if c.historyStatusFilter == nil || archiveHistoryOfStatus(c.historyStatusFilter(request.Namespace), request.Status) {
+		return
+	}
+
+	targets := []ArchivalTarget{}
+	for _, target := range request.Targets {
+		if target != ArchiveTargetHistory {
+			targets = append(targets, target)
+		}
+	}
+	request.Targets = targets
+}
+
+// archiveHistoryOfStatus returns whether status is in the comma separated list of statuses,
+// matched case insensitively with or without the enum type prefix. An empty list contains all statuses
+func archiveHistoryOfStatus(statuses string, status executionpb.WorkflowExecutionStatus) bool {
+	name := strings.TrimPrefix(status.String(), "WorkflowExecutionStatus")
+	archiveAll := true
+	for _, s := range strings.Split(statuses, ",") {
+		if s = strings.TrimSpace(s); s == "" {
+			continue
+		}
+		if strings.EqualFold(strings.TrimPrefix(s, "WorkflowExecutionStatus"), name) {
+			return true
+		}
+		archiveAll = false
+	}
+	return archiveAll
+}
+
 func (c *client) archiveHistoryInline(ctx context.Context, request *ClientRequest, logger log.Logger, errCh chan error) {
 	logger = tagLoggerWithHistoryRequest(logger, request.ArchiveRequest)
 	var err error
@@ -208,6 +321,7 @@ func (c *client) archiveHistoryInline(ctx context.Context, request *ClientReques
 		return
 	}
 
+	startTime := time.Now()
 	err = historyArchiver.Archive(ctx, URI, &carchiver.ArchiveHistoryRequest{
 		ShardID:              request.ArchiveRequest.ShardID,
 		NamespaceID:          request.ArchiveRequest.NamespaceID,
@@ -217,7 +331,10 @@ func (c *client) archiveHistoryInline(ctx context.Context, request *ClientReques
 		BranchToken:          request.ArchiveRequest.BranchToken,
 		NextEventID:          request.ArchiveRequest.NextEventID,
 		CloseFailoverVersion: request.ArchiveRequest.CloseFailoverVersion,
+		FromEventID:          request.ArchiveRequest.FromEventID,
+		ToEventID:            request.ArchiveRequest.ToEventID,
 	})
+	c.recordInlineArchiveLatency(archivalTargetHistoryTagValue, time.Since(startTime), err)
 }
 
 func (c *client) archiveVisibilityInline(ctx context.Context, request *ClientRequest, logger log.Logger, errCh chan error) {
@@ -242,6 +359,7 @@ func (c *client) archiveVisibilityInline(ctx context.Context, request *ClientReq
 		return
 	}
 
+	startTime := time.Now()
 	err = visibilityArchiver.Archive(ctx, URI, &archiverproto.ArchiveVisibilityRequest{
 		NamespaceId:        request.ArchiveRequest.NamespaceID,
 		Namespace:          request.ArchiveRequest.Namespace,
@@ -254,9 +372,22 @@ func (c *client) archiveVisibilityInline(ctx context.Context, request *ClientReq
 		Status:             request.ArchiveRequest.Status,
 		HistoryLength:      request.ArchiveRequest.HistoryLength,
 		Memo:               request.ArchiveRequest.Memo,
-		SearchAttributes:   convertSearchAttributesToString(request.ArchiveRequest.SearchAttributes),
+		SearchAttributes:   transformSearchAttributes(c.searchAttributesTransformProvider, request.ArchiveRequest.Namespace, request.ArchiveRequest.SearchAttributes),
 		HistoryArchivalURI: request.ArchiveRequest.URI,
 	})
+	c.recordInlineArchiveLatency(archivalTargetVisibilityTagValue, time.Since(startTime), err)
+}
+
+// recordInlineArchiveLatency records the latency of a single provider Archive call, tagged by target and outcome
+func (c *client) recordInlineArchiveLatency(target string, latency time.Duration, err error) {
+	outcome := archivalOutcomeSuccessTagValue
+	if err != nil {
+		outcome = archivalOutcomeFailureTagValue
+	}
+	c.metricsScope.Tagged(
+		metrics.ArchivalTargetTag(target),
+		metrics.ArchivalOutcomeTag(outcome),
+	).RecordTimer(metrics.ArchiverClientInlineArchiveLatency, latency)
 }
 
 func (c *client) sendArchiveSignal(ctx context.Context, request *ArchiveRequest, taggedLogger log.Logger) error {
@@ -267,7 +398,8 @@ func (c *client) sendArchiveSignal(ctx context.Context, request *ArchiveRequest,
 		return errors.New(tooManyRequestsErrMsg)
 	}
 
-	workflowID := fmt.Sprintf("%v-%v", workflowIDPrefix, rand.Intn(c.numWorkflows()))
+	// signals are spread over the archival workflows configured for the namespace
+	workflowID := fmt.Sprintf("%v-%v", workflowIDPrefix, rand.Intn(c.numWorkflows(request.Namespace)))
 	workflowOptions := sdkclient.StartWorkflowOptions{
 		ID:                              workflowID,
 		TaskList:                        decisionTaskList,
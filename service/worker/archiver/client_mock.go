@@ -55,3 +55,17 @@ func (_m *ClientMock) Archive(_a0 context.Context, _a1 *ClientRequest) (*ClientR
 
 	return r0, r1
 }
+
+// Close provides a mock function with given fields: _a0
+func (_m *ClientMock) Close(_a0 context.Context) error {
+	ret := _m.Called(_a0)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context) error); ok {
+		r0 = rf(_a0)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
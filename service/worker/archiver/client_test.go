@@ -24,12 +24,16 @@ import (
 	"context"
 	"errors"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 	"github.com/stretchr/testify/suite"
+	"github.com/uber-go/tally"
+	executionpb "go.temporal.io/temporal-proto/execution"
 	"go.temporal.io/temporal/mocks"
 
+	archiverproto "github.com/temporalio/temporal/.gen/proto/archiver"
 	carchiver "github.com/temporalio/temporal/common/archiver"
 	"github.com/temporalio/temporal/common/archiver/provider"
 	"github.com/temporalio/temporal/common/log"
@@ -64,13 +68,17 @@ func (s *clientSuite) SetupTest() {
 	s.metricsScope = &mmocks.Scope{}
 	s.temporalClient = &mocks.Client{}
 	s.metricsClient.On("Scope", metrics.ArchiverClientScope, mock.Anything).Return(s.metricsScope).Once()
+	s.metricsScope.On("Tagged", mock.Anything, mock.Anything).Return(s.metricsScope).Maybe()
+	s.metricsScope.On("RecordTimer", metrics.ArchiverClientInlineArchiveLatency, mock.Anything).Maybe()
 	s.client = NewClient(
 		s.metricsClient,
 		log.NewNoop(),
 		nil,
-		dynamicconfig.GetIntPropertyFn(1000),
+		dynamicconfig.GetIntPropertyFilteredByNamespace(1000),
 		dynamicconfig.GetIntPropertyFn(1000),
 		s.archiverProvider,
+		nil,
+		nil,
 	).(*client)
 	s.client.temporalClient = s.temporalClient
 }
@@ -165,6 +173,135 @@ func (s *clientSuite) TestArchiveHistoryInlineSuccess() {
 	s.True(resp.HistoryArchivedInline)
 }
 
+func (s *clientSuite) TestArchiveHistoryInline_EventRange() {
+	s.archiverProvider.On("GetHistoryArchiver", mock.Anything, mock.Anything).Return(s.historyArchiver, nil).Once()
+	s.historyArchiver.On("Archive", mock.Anything, mock.Anything, mock.MatchedBy(func(request *carchiver.ArchiveHistoryRequest) bool {
+		return request.FromEventID == 10 && request.ToEventID == 20
+	})).Return(nil).Once()
+	s.metricsScope.On("IncCounter", metrics.ArchiverClientHistoryRequestCount).Once()
+	s.metricsScope.On("IncCounter", metrics.ArchiverClientHistoryInlineArchiveAttemptCount).Once()
+
+	resp, err := s.client.Archive(context.Background(), &ClientRequest{
+		ArchiveRequest: &ArchiveRequest{
+			URI:         "test:///history/archival",
+			Targets:     []ArchivalTarget{ArchiveTargetHistory},
+			FromEventID: 10,
+			ToEventID:   20,
+		},
+		AttemptArchiveInline: true,
+	})
+	s.NoError(err)
+	s.NotNil(resp)
+	s.True(resp.HistoryArchivedInline)
+}
+
+func (s *clientSuite) TestArchiveInline_RecordsLatency() {
+	archiveDelay := 50 * time.Millisecond
+	scope := tally.NewTestScope("test", nil)
+	s.client.metricsScope = metrics.NewClient(scope, metrics.Worker).Scope(metrics.ArchiverClientScope)
+	s.archiverProvider.On("GetHistoryArchiver", mock.Anything, mock.Anything).Return(s.historyArchiver, nil).Once()
+	s.historyArchiver.On("Archive", mock.Anything, mock.Anything, mock.Anything).Run(func(mock.Arguments) {
+		time.Sleep(archiveDelay)
+	}).Return(nil).Once()
+	s.archiverProvider.On("GetVisibilityArchiver", mock.Anything, mock.Anything).Return(s.visibilityArchiver, nil).Once()
+	s.visibilityArchiver.On("Archive", mock.Anything, mock.Anything, mock.Anything).Run(func(mock.Arguments) {
+		time.Sleep(archiveDelay)
+	}).Return(errors.New("some random error")).Once()
+	s.temporalClient.On("SignalWithStartWorkflow", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil, nil)
+
+	resp, err := s.client.Archive(context.Background(), &ClientRequest{
+		ArchiveRequest: &ArchiveRequest{
+			URI:           "test:///history/archival",
+			VisibilityURI: "test:///visibility/archival",
+			Targets:       []ArchivalTarget{ArchiveTargetHistory, ArchiveTargetVisibility},
+		},
+		AttemptArchiveInline: true,
+	})
+	s.NoError(err)
+	s.True(resp.HistoryArchivedInline)
+
+	latencies := map[string][]time.Duration{}
+	for _, timer := range scope.Snapshot().Timers() {
+		if timer.Name() != "test.archiver_client_inline_archive_latency" {
+			continue
+		}
+		key := timer.Tags()["archivalTarget"] + "/" + timer.Tags()["archivalOutcome"]
+		latencies[key] = append(latencies[key], timer.Values()...)
+	}
+	s.Len(latencies, 2)
+	for _, key := range []string{"history/success", "visibility/failure"} {
+		s.Len(latencies[key], 1)
+		s.True(latencies[key][0] >= archiveDelay)
+		s.True(latencies[key][0] < archiveDelay+time.Second)
+	}
+}
+
+func (s *clientSuite) TestClose_WaitsForInlineArchival() {
+	startedC := make(chan struct{})
+	releaseC := make(chan struct{})
+	s.archiverProvider.On("GetHistoryArchiver", mock.Anything, mock.Anything).Return(s.historyArchiver, nil).Once()
+	s.historyArchiver.On("Archive", mock.Anything, mock.Anything, mock.Anything).Run(func(mock.Arguments) {
+		close(startedC)
+		<-releaseC
+	}).Return(nil).Once()
+	s.metricsScope.On("IncCounter", metrics.ArchiverClientHistoryRequestCount).Once()
+	s.metricsScope.On("IncCounter", metrics.ArchiverClientHistoryInlineArchiveAttemptCount).Once()
+
+	archiveDoneC := make(chan struct{})
+	go func() {
+		defer close(archiveDoneC)
+		resp, err := s.client.Archive(context.Background(), &ClientRequest{
+			ArchiveRequest: &ArchiveRequest{
+				URI:     "test:///history/archival",
+				Targets: []ArchivalTarget{ArchiveTargetHistory},
+			},
+			AttemptArchiveInline: true,
+		})
+		s.NoError(err)
+		s.True(resp.HistoryArchivedInline)
+	}()
+	<-startedC
+
+	closeErrC := make(chan error, 1)
+	go func() {
+		closeErrC <- s.client.Close(context.Background())
+	}()
+	select {
+	case <-closeErrC:
+		s.Fail("close returned before the inline archival completed")
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	// no new archival is accepted while closing
+	_, err := s.client.Archive(context.Background(), &ClientRequest{
+		ArchiveRequest: &ArchiveRequest{Targets: []ArchivalTarget{ArchiveTargetHistory}},
+	})
+	s.Equal(errClientClosed, err)
+
+	close(releaseC)
+	select {
+	case err := <-closeErrC:
+		s.NoError(err)
+	case <-time.After(time.Second):
+		s.Fail("close did not return after the inline archival completed")
+	}
+	<-archiveDoneC
+}
+
+func (s *clientSuite) TestClose_Timeout() {
+	releaseC := make(chan struct{})
+	defer close(releaseC)
+	s.client.inflightWG.Add(1)
+	go func() {
+		<-releaseC
+		s.client.inflightWG.Done()
+	}()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	s.Equal(context.DeadlineExceeded, s.client.Close(ctx))
+}
+
 func (s *clientSuite) TestArchiveHistoryInlineFail_SendSignalSuccess() {
 	s.archiverProvider.On("GetHistoryArchiver", mock.Anything, mock.Anything).Return(s.historyArchiver, nil).Once()
 	s.historyArchiver.On("Archive", mock.Anything, mock.Anything, mock.Anything).Return(errors.New("some random error")).Once()
@@ -267,6 +404,34 @@ func (s *clientSuite) TestArchiveInline_VisibilityFail_HistorySuccess() {
 	s.True(resp.HistoryArchivedInline)
 }
 
+func (s *clientSuite) TestArchiveInline_VisibilityFail_HistorySuccess_VisibilityListedFirst() {
+	s.archiverProvider.On("GetHistoryArchiver", mock.Anything, mock.Anything).Return(s.historyArchiver, nil).Once()
+	s.archiverProvider.On("GetVisibilityArchiver", mock.Anything, mock.Anything).Return(s.visibilityArchiver, nil).Once()
+	s.historyArchiver.On("Archive", mock.Anything, mock.Anything, mock.Anything).Return(nil).Once()
+	s.visibilityArchiver.On("Archive", mock.Anything, mock.Anything, mock.Anything).Return(errors.New("some random error")).Once()
+	s.metricsScope.On("IncCounter", metrics.ArchiverClientHistoryRequestCount).Once()
+	s.metricsScope.On("IncCounter", metrics.ArchiverClientHistoryInlineArchiveAttemptCount).Once()
+	s.metricsScope.On("IncCounter", metrics.ArchiverClientVisibilityRequestCount).Once()
+	s.metricsScope.On("IncCounter", metrics.ArchiverClientVisibilityInlineArchiveAttemptCount).Once()
+	s.metricsScope.On("IncCounter", metrics.ArchiverClientVisibilityInlineArchiveFailureCount).Once()
+	s.metricsScope.On("IncCounter", metrics.ArchiverClientSendSignalCount).Once()
+	s.temporalClient.On("SignalWithStartWorkflow", mock.Anything, mock.Anything, mock.Anything, mock.MatchedBy(func(v ArchiveRequest) bool {
+		return len(v.Targets) == 1 && v.Targets[0] == ArchiveTargetVisibility
+	}), mock.Anything, mock.Anything, mock.Anything).Return(nil, nil).Once()
+
+	resp, err := s.client.Archive(context.Background(), &ClientRequest{
+		ArchiveRequest: &ArchiveRequest{
+			URI:           "test:///history/archival",
+			VisibilityURI: "test:///visibility/archival",
+			Targets:       []ArchivalTarget{ArchiveTargetVisibility, ArchiveTargetHistory},
+		},
+		AttemptArchiveInline: true,
+	})
+	s.NoError(err)
+	s.NotNil(resp)
+	s.True(resp.HistoryArchivedInline)
+}
+
 func (s *clientSuite) TestArchiveInline_VisibilityFail_HistoryFail() {
 	s.archiverProvider.On("GetHistoryArchiver", mock.Anything, mock.Anything).Return(s.historyArchiver, nil).Once()
 	s.archiverProvider.On("GetVisibilityArchiver", mock.Anything, mock.Anything).Return(s.visibilityArchiver, nil).Once()
@@ -319,6 +484,33 @@ func (s *clientSuite) TestArchiveInline_VisibilitySuccess_HistorySuccess() {
 	s.True(resp.HistoryArchivedInline)
 }
 
+func (s *clientSuite) TestArchiveSendSignal_NamespaceWorkflowCount() {
+	s.client.numWorkflows = func(namespace string) int {
+		if namespace == "high-volume-namespace" {
+			return 100
+		}
+		return 1
+	}
+	workflowIDs := map[string]map[string]struct{}{}
+	s.temporalClient.On("SignalWithStartWorkflow", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		request := args.Get(3).(ArchiveRequest)
+		if workflowIDs[request.Namespace] == nil {
+			workflowIDs[request.Namespace] = map[string]struct{}{}
+		}
+		workflowIDs[request.Namespace][args.String(1)] = struct{}{}
+	}).Return(nil, nil)
+	s.metricsScope.On("IncCounter", metrics.ArchiverClientSendSignalCount)
+
+	for i := 0; i < 50; i++ {
+		for _, namespace := range []string{"high-volume-namespace", "low-volume-namespace"} {
+			err := s.client.sendArchiveSignal(context.Background(), &ArchiveRequest{Namespace: namespace}, log.NewNoop())
+			s.NoError(err)
+		}
+	}
+	s.Len(workflowIDs["low-volume-namespace"], 1)
+	s.True(len(workflowIDs["high-volume-namespace"]) > 1)
+}
+
 func (s *clientSuite) TestArchiveSendSignal_Success() {
 	s.temporalClient.On("SignalWithStartWorkflow", mock.Anything, mock.Anything, mock.Anything, mock.MatchedBy(func(v ArchiveRequest) bool {
 		return len(v.Targets) == 2
@@ -351,3 +543,116 @@ func (s *clientSuite) TestArchiveUnknownTarget() {
 	s.NotNil(resp)
 	s.False(resp.HistoryArchivedInline)
 }
+
+func (s *clientSuite) TestArchiveVisibilityInline_RenameSearchAttributes() {
+	s.client.searchAttributesTransformProvider = NewRenameSearchAttributesTransformProvider(
+		dynamicconfig.GetMapPropertyFnFilteredByNamespace(map[string]interface{}{"CustomKeywordField": "custom_CustomKeywordField"}),
+	)
+	s.archiverProvider.On("GetVisibilityArchiver", mock.Anything, mock.Anything).Return(s.visibilityArchiver, nil).Once()
+	s.visibilityArchiver.On("Archive", mock.Anything, mock.Anything, mock.MatchedBy(func(request *archiverproto.ArchiveVisibilityRequest) bool {
+		_, ok := request.SearchAttributes["CustomKeywordField"]
+		return !ok &&
+			request.SearchAttributes["custom_CustomKeywordField"] == "keyword" &&
+			request.SearchAttributes["CustomIntField"] == "1"
+	})).Return(nil).Once()
+	s.metricsScope.On("IncCounter", metrics.ArchiverClientVisibilityRequestCount).Once()
+	s.metricsScope.On("IncCounter", metrics.ArchiverClientVisibilityInlineArchiveAttemptCount).Once()
+
+	resp, err := s.client.Archive(context.Background(), &ClientRequest{
+		ArchiveRequest: &ArchiveRequest{
+			Namespace:     "some-namespace",
+			VisibilityURI: "test:///visibility/archival",
+			SearchAttributes: map[string][]byte{
+				"CustomKeywordField": []byte("keyword"),
+				"CustomIntField":     []byte("1"),
+			},
+			Targets: []ArchivalTarget{ArchiveTargetVisibility},
+		},
+		AttemptArchiveInline: true,
+	})
+	s.NoError(err)
+	s.NotNil(resp)
+}
+
+func (s *clientSuite) TestArchiveVisibilityInline_RedactSearchAttributes() {
+	s.client.searchAttributesTransformProvider = NewRedactSearchAttributesTransformProvider(
+		dynamicconfig.GetStringPropertyFnFilteredByNamespace("CustomKeywordField"),
+	)
+	s.archiverProvider.On("GetVisibilityArchiver", mock.Anything, mock.Anything).Return(s.visibilityArchiver, nil).Once()
+	s.visibilityArchiver.On("Archive", mock.Anything, mock.Anything, mock.MatchedBy(func(request *archiverproto.ArchiveVisibilityRequest) bool {
+		_, ok := request.SearchAttributes["CustomKeywordField"]
+		return !ok && request.SearchAttributes["CustomIntField"] == "1"
+	})).Return(nil).Once()
+	s.metricsScope.On("IncCounter", metrics.ArchiverClientVisibilityRequestCount).Once()
+	s.metricsScope.On("IncCounter", metrics.ArchiverClientVisibilityInlineArchiveAttemptCount).Once()
+
+	resp, err := s.client.Archive(context.Background(), &ClientRequest{
+		ArchiveRequest: &ArchiveRequest{
+			Namespace:     "some-namespace",
+			VisibilityURI: "test:///visibility/archival",
+			SearchAttributes: map[string][]byte{
+				"CustomKeywordField": []byte("sensitive"),
+				"CustomIntField":     []byte("1"),
+			},
+			Targets: []ArchivalTarget{ArchiveTargetVisibility},
+		},
+		AttemptArchiveInline: true,
+	})
+	s.NoError(err)
+	s.NotNil(resp)
+}
+
+func (s *clientSuite) TestArchiveInline_StatusFilter_HistoryPruned() {
+	s.client.historyStatusFilter = dynamicconfig.GetStringPropertyFnFilteredByNamespace("Failed,TimedOut")
+	s.archiverProvider.On("GetVisibilityArchiver", mock.Anything, mock.Anything).Return(s.visibilityArchiver, nil).Once()
+	s.visibilityArchiver.On("Archive", mock.Anything, mock.Anything, mock.Anything).Return(nil).Once()
+	s.metricsScope.On("IncCounter", metrics.ArchiverClientVisibilityRequestCount).Once()
+	s.metricsScope.On("IncCounter", metrics.ArchiverClientVisibilityInlineArchiveAttemptCount).Once()
+
+	resp, err := s.client.Archive(context.Background(), &ClientRequest{
+		ArchiveRequest: &ArchiveRequest{
+			Namespace:     "some-namespace",
+			URI:           "test:///history/archival",
+			VisibilityURI: "test:///visibility/archival",
+			Status:        executionpb.WorkflowExecutionStatusCompleted,
+			Targets:       []ArchivalTarget{ArchiveTargetHistory, ArchiveTargetVisibility},
+		},
+		AttemptArchiveInline: true,
+	})
+	s.NoError(err)
+	s.NotNil(resp)
+	s.False(resp.HistoryArchivedInline)
+}
+
+func (s *clientSuite) TestArchiveInline_StatusFilter_HistoryKept() {
+	s.client.historyStatusFilter = dynamicconfig.GetStringPropertyFnFilteredByNamespace("Failed,TimedOut")
+	s.archiverProvider.On("GetHistoryArchiver", mock.Anything, mock.Anything).Return(s.historyArchiver, nil).Once()
+	s.archiverProvider.On("GetVisibilityArchiver", mock.Anything, mock.Anything).Return(s.visibilityArchiver, nil).Once()
+	s.historyArchiver.On("Archive", mock.Anything, mock.Anything, mock.Anything).Return(nil).Once()
+	s.visibilityArchiver.On("Archive", mock.Anything, mock.Anything, mock.Anything).Return(nil).Once()
+	s.metricsScope.On("IncCounter", metrics.ArchiverClientHistoryRequestCount).Once()
+	s.metricsScope.On("IncCounter", metrics.ArchiverClientHistoryInlineArchiveAttemptCount).Once()
+	s.metricsScope.On("IncCounter", metrics.ArchiverClientVisibilityRequestCount).Once()
+	s.metricsScope.On("IncCounter", metrics.ArchiverClientVisibilityInlineArchiveAttemptCount).Once()
+
+	resp, err := s.client.Archive(context.Background(), &ClientRequest{
+		ArchiveRequest: &ArchiveRequest{
+			Namespace:     "some-namespace",
+			URI:           "test:///history/archival",
+			VisibilityURI: "test:///visibility/archival",
+			Status:        executionpb.WorkflowExecutionStatusFailed,
+			Targets:       []ArchivalTarget{ArchiveTargetHistory, ArchiveTargetVisibility},
+		},
+		AttemptArchiveInline: true,
+	})
+	s.NoError(err)
+	s.NotNil(resp)
+	s.True(resp.HistoryArchivedInline)
+}
+
+func (s *clientSuite) TestArchiveHistoryOfStatus() {
+	s.True(archiveHistoryOfStatus("", executionpb.WorkflowExecutionStatusCompleted))
+	s.True(archiveHistoryOfStatus(" , ", executionpb.WorkflowExecutionStatusCompleted))
+	s.True(archiveHistoryOfStatus("failed, timedout", executionpb.WorkflowExecutionStatusTimedOut))
+	s.False(archiveHistoryOfStatus("Failed,TimedOut", executionpb.WorkflowExecutionStatusCompleted))
+}
@@ -60,6 +60,8 @@ type (
 		NamespaceCache   cache.NamespaceCache
 		Config           *Config
 		ArchiverProvider provider.ArchiverProvider
+
+		SearchAttributesTransformProvider SearchAttributesTransformProvider
 	}
 
 	// Config for ClientWorker
@@ -67,6 +69,8 @@ type (
 		ArchiverConcurrency           dynamicconfig.IntPropertyFn
 		ArchivalsPerIteration         dynamicconfig.IntPropertyFn
 		TimeLimitPerArchivalIteration dynamicconfig.DurationPropertyFn
+		RedactedSearchAttributes      dynamicconfig.StringPropertyFnWithNamespaceFilter
+		SearchAttributesFieldMapping  dynamicconfig.MapPropertyFnWithNamespaceFilter
 	}
 
 	contextKey int
@@ -23,6 +23,7 @@ package archiver
 import (
 	"bytes"
 	"encoding/gob"
+	"strings"
 	"time"
 
 	"github.com/dgryski/go-farm"
@@ -30,6 +31,7 @@ import (
 
 	"github.com/temporalio/temporal/common/log"
 	"github.com/temporalio/temporal/common/log/tag"
+	"github.com/temporalio/temporal/common/service/dynamicconfig"
 )
 
 // MaxArchivalIterationTimeout returns the max allowed timeout for a single iteration of archival workflow
@@ -99,3 +101,109 @@ func convertSearchAttributesToString(searchAttr map[string][]byte) map[string]st
 	}
 	return searchAttrStr
 }
+
+// IdentitySearchAttributesTransform returns the search attributes unchanged
+func IdentitySearchAttributesTransform(searchAttr map[string]string) map[string]string {
+	return searchAttr
+}
+
+// NewRedactSearchAttributesTransform returns a SearchAttributesTransform which removes the given keys
+func NewRedactSearchAttributesTransform(keys []string) SearchAttributesTransform {
+	if len(keys) == 0 {
+		return IdentitySearchAttributesTransform
+	}
+
+	return func(searchAttr map[string]string) map[string]string {
+		result := make(map[string]string, len(searchAttr))
+		for k, v := range searchAttr {
+			result[k] = v
+		}
+		for _, key := range keys {
+			delete(result, key)
+		}
+		return result
+	}
+}
+
+// NewRedactSearchAttributesTransformProvider returns a SearchAttributesTransformProvider which removes
+// the comma separated search attribute keys configured for each namespace
+func NewRedactSearchAttributesTransformProvider(
+	redactedKeys dynamicconfig.StringPropertyFnWithNamespaceFilter,
+) SearchAttributesTransformProvider {
+	return func(namespace string) SearchAttributesTransform {
+		var keys []string
+		for _, key := range strings.Split(redactedKeys(namespace), ",") {
+			if key = strings.TrimSpace(key); key != "" {
+				keys = append(keys, key)
+			}
+		}
+		return NewRedactSearchAttributesTransform(keys)
+	}
+}
+
+// NewRenameSearchAttributesTransform returns a SearchAttributesTransform which renames the search
+// attribute keys found in mapping to the mapped keys, leaving the other keys unchanged
+func NewRenameSearchAttributesTransform(mapping map[string]string) SearchAttributesTransform {
+	if len(mapping) == 0 {
+		return IdentitySearchAttributesTransform
+	}
+
+	return func(searchAttr map[string]string) map[string]string {
+		result := make(map[string]string, len(searchAttr))
+		for k, v := range searchAttr {
+			if renamed, ok := mapping[k]; ok {
+				k = renamed
+			}
+			result[k] = v
+		}
+		return result
+	}
+}
+
+// NewRenameSearchAttributesTransformProvider returns a SearchAttributesTransformProvider which renames
+// search attribute keys using the key to new key mapping configured for each namespace.
+// Mapped values which are not non-empty strings are ignored
+func NewRenameSearchAttributesTransformProvider(
+	fieldMapping dynamicconfig.MapPropertyFnWithNamespaceFilter,
+) SearchAttributesTransformProvider {
+	return func(namespace string) SearchAttributesTransform {
+		mapping := make(map[string]string)
+		for key, value := range fieldMapping(namespace) {
+			if renamed, ok := value.(string); ok && renamed != "" {
+				mapping[key] = renamed
+			}
+		}
+		return NewRenameSearchAttributesTransform(mapping)
+	}
+}
+
+// NewChainedSearchAttributesTransformProvider returns a SearchAttributesTransformProvider which applies
+// the transforms of the given providers in order
+func NewChainedSearchAttributesTransformProvider(
+	providers ...SearchAttributesTransformProvider,
+) SearchAttributesTransformProvider {
+	return func(namespace string) SearchAttributesTransform {
+		transforms := make([]SearchAttributesTransform, 0, len(providers))
+		for _, provider := range providers {
+			transforms = append(transforms, provider(namespace))
+		}
+		return func(searchAttr map[string]string) map[string]string {
+			for _, transform := range transforms {
+				searchAttr = transform(searchAttr)
+			}
+			return searchAttr
+		}
+	}
+}
+
+func transformSearchAttributes(
+	transformProvider SearchAttributesTransformProvider,
+	namespace string,
+	searchAttr map[string][]byte,
+) map[string]string {
+	searchAttrStr := convertSearchAttributesToString(searchAttr)
+	if transformProvider == nil {
+		return searchAttrStr
+	}
+	return transformProvider(namespace)(searchAttrStr)
+}
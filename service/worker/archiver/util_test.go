@@ -25,6 +25,8 @@ import (
 
 	"github.com/stretchr/testify/require"
 	"github.com/stretchr/testify/suite"
+
+	"github.com/temporalio/temporal/common/service/dynamicconfig"
 )
 
 type UtilSuite struct {
@@ -87,3 +89,54 @@ func (s *UtilSuite) TestHashesEqual() {
 		s.Equal(tc.equal, hashesEqual(tc.a, tc.b))
 	}
 }
+
+func (s *UtilSuite) TestTransformSearchAttributes_Redact() {
+	redactedKeys := dynamicconfig.GetStringPropertyFnFilteredByNamespace(" CustomKeywordField, CustomStringField ,")
+	searchAttr := map[string][]byte{
+		"CustomKeywordField": []byte("sensitive"),
+		"CustomStringField":  []byte("sensitive"),
+		"CustomIntField":     []byte("1"),
+	}
+
+	result := transformSearchAttributes(NewRedactSearchAttributesTransformProvider(redactedKeys), "some-namespace", searchAttr)
+	s.Equal(map[string]string{"CustomIntField": "1"}, result)
+	s.Len(searchAttr, 3)
+}
+
+func (s *UtilSuite) TestTransformSearchAttributes_RedactAndRename() {
+	redactedKeys := dynamicconfig.GetStringPropertyFnFilteredByNamespace("CustomStringField")
+	fieldMapping := dynamicconfig.GetMapPropertyFnFilteredByNamespace(map[string]interface{}{
+		"CustomKeywordField": "custom_CustomKeywordField",
+		"CustomStringField":  "custom_CustomStringField",
+		"CustomBoolField":    "",
+	})
+	searchAttr := map[string][]byte{
+		"CustomKeywordField": []byte("keyword"),
+		"CustomStringField":  []byte("sensitive"),
+		"CustomBoolField":    []byte("true"),
+	}
+
+	transformProvider := NewChainedSearchAttributesTransformProvider(
+		NewRedactSearchAttributesTransformProvider(redactedKeys),
+		NewRenameSearchAttributesTransformProvider(fieldMapping),
+	)
+	s.Equal(map[string]string{
+		"custom_CustomKeywordField": "keyword",
+		"CustomBoolField":           "true",
+	}, transformSearchAttributes(transformProvider, "some-namespace", searchAttr))
+}
+
+func (s *UtilSuite) TestTransformSearchAttributes_Identity() {
+	searchAttr := map[string][]byte{
+		"CustomKeywordField": []byte("value"),
+		"CustomIntField":     []byte("1"),
+	}
+	expected := map[string]string{
+		"CustomKeywordField": "value",
+		"CustomIntField":     "1",
+	}
+
+	redactedKeys := dynamicconfig.GetStringPropertyFnFilteredByNamespace("")
+	s.Equal(expected, transformSearchAttributes(NewRedactSearchAttributesTransformProvider(redactedKeys), "some-namespace", searchAttr))
+	s.Equal(expected, transformSearchAttributes(nil, "some-namespace", searchAttr))
+}
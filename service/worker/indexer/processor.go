@@ -170,7 +170,7 @@ func (p *indexProcessor) messageProcessLoop(workerWG *sync.WaitGroup) {
 func (p *indexProcessor) process(kafkaMsg messaging.Message) error {
 	logger := p.logger.WithTags(tag.KafkaPartition(kafkaMsg.Partition()), tag.KafkaOffset(kafkaMsg.Offset()), tag.AttemptStart(time.Now()))
 
-	indexMsg, err := p.deserialize(kafkaMsg.Value())
+	indexMsg, err := p.deserialize(kafkaMsg)
 	if err != nil {
 		logger.Error("Failed to deserialize index messages.", tag.Error(err))
 		p.metricsClient.IncCounter(metrics.IndexProcessorScope, metrics.IndexProcessorCorruptedData)
@@ -180,9 +180,9 @@ func (p *indexProcessor) process(kafkaMsg messaging.Message) error {
 	return p.addMessageToES(indexMsg, kafkaMsg, logger)
 }
 
-func (p *indexProcessor) deserialize(payload []byte) (*indexergenpb.Message, error) {
+func (p *indexProcessor) deserialize(kafkaMsg messaging.Message) (*indexergenpb.Message, error) {
 	var msg indexergenpb.Message
-	if err := msg.Unmarshal(payload); err != nil {
+	if err := messaging.DeserializeMessage(kafkaMsg, &msg); err != nil {
 		return nil, err
 	}
 	return &msg, nil
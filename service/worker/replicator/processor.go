@@ -276,7 +276,7 @@ func (p *replicationTaskProcessor) nackMsg(msg messaging.Message, err error, log
 
 func (p *replicationTaskProcessor) decodeAndValidateMsg(msg messaging.Message, logger log.Logger) (*replicationgenpb.ReplicationTask, error) {
 	var replicationTask replicationgenpb.ReplicationTask
-	err := replicationTask.Unmarshal(msg.Value())
+	err := messaging.DeserializeMessage(msg, &replicationTask)
 	if err != nil {
 		// return InvalidArgument so processWithRetry can nack the message
 		return nil, ErrDeserializeReplicationTask
@@ -121,6 +121,8 @@ func NewConfig(params *resource.BootstrapParams) *Config {
 			ArchiverConcurrency:           dc.GetIntProperty(dynamicconfig.WorkerArchiverConcurrency, 50),
 			ArchivalsPerIteration:         dc.GetIntProperty(dynamicconfig.WorkerArchivalsPerIteration, 1000),
 			TimeLimitPerArchivalIteration: dc.GetDurationProperty(dynamicconfig.WorkerTimeLimitPerArchivalIteration, archiver.MaxArchivalIterationTimeout()),
+			RedactedSearchAttributes:      dc.GetStringPropertyFnWithNamespaceFilter(dynamicconfig.ArchivalRedactedSearchAttributes, ""),
+			SearchAttributesFieldMapping:  dc.GetMapPropertyFnWithNamespaceFilter(dynamicconfig.ArchivalSearchAttributesFieldMapping, nil),
 		},
 		ScannerCfg: &scanner.Config{
 			PersistenceMaxQPS:        dc.GetIntProperty(dynamicconfig.ScannerPersistenceMaxQPS, 100),
@@ -286,6 +288,11 @@ func (s *Service) startArchiver() {
 		NamespaceCache:   s.GetNamespaceCache(),
 		Config:           s.config.ArchiverConfig,
 		ArchiverProvider: s.GetArchiverProvider(),
+
+		SearchAttributesTransformProvider: archiver.NewChainedSearchAttributesTransformProvider(
+			archiver.NewRedactSearchAttributesTransformProvider(s.config.ArchiverConfig.RedactedSearchAttributes),
+			archiver.NewRenameSearchAttributesTransformProvider(s.config.ArchiverConfig.SearchAttributesFieldMapping),
+		),
 	}
 	clientWorker := archiver.NewClientWorker(bc)
 	if err := clientWorker.Start(); err != nil {
@@ -42,6 +42,19 @@ func newAdminWorkflowCommands() []cli.Command {
 					Name:  FlagOutputFilenameWithAlias,
 					Usage: "output file",
 				},
+				cli.BoolFlag{
+					Name:  FlagReverse,
+					Usage: "read the events of the branch from newest to oldest, bounded by --" + FlagMaxEvents,
+				},
+				cli.IntFlag{
+					Name:  FlagMaxEvents,
+					Value: 100,
+					Usage: "max number of events to read in reverse",
+				},
+				cli.Int64Flag{
+					Name:  FlagMaxEventID,
+					Usage: "event id to read back from in reverse. Optional, default to the last event",
+				},
 
 				// for persistence connection
 				// TODO need to support other database: https://github.com/uber/cadence/issues/2777
@@ -110,6 +123,15 @@ func newAdminWorkflowCommands() []cli.Command {
 					Name:  FlagRunIDWithAlias,
 					Usage: "RunId",
 				},
+				cli.BoolFlag{
+					Name:  FlagShowBranchesWithAlias,
+					Usage: "Print the version history branch tree",
+				},
+				cli.StringFlag{
+					Name:  FlagFormat,
+					Usage: "Format of the version history branch tree (text or json)",
+					Value: formatText,
+				},
 			},
 			Action: func(c *cli.Context) {
 				AdminDescribeWorkflow(c)
@@ -133,6 +155,127 @@ func newAdminWorkflowCommands() []cli.Command {
 				AdminRefreshWorkflowTasks(c)
 			},
 		},
+		{
+			Name:    "force-terminate",
+			Aliases: []string{"ft"},
+			Usage:   "Close a stuck workflow with a terminated event, keeping its state and history unlike delete",
+			Flags: []cli.Flag{
+				cli.StringFlag{
+					Name:  FlagWorkflowIDWithAlias,
+					Usage: "WorkflowId",
+				},
+				cli.StringFlag{
+					Name:  FlagRunIDWithAlias,
+					Usage: "RunId",
+				},
+				cli.StringFlag{
+					Name:  FlagReasonWithAlias,
+					Usage: "The reason recorded on the terminated event",
+				},
+				cli.StringFlag{
+					Name:  FlagDetail,
+					Usage: "Optional details recorded on the terminated event",
+				},
+			},
+			Action: func(c *cli.Context) {
+				AdminForceTerminateWorkflow(c)
+			},
+		},
+		{
+			Name:    "timers",
+			Aliases: []string{"tm"},
+			Usage:   "List the user timers of a workflow with their fire times, flagging the orphaned ones",
+			Flags: []cli.Flag{
+				cli.StringFlag{
+					Name:  FlagWorkflowIDWithAlias,
+					Usage: "WorkflowId",
+				},
+				cli.StringFlag{
+					Name:  FlagRunIDWithAlias,
+					Usage: "RunId",
+				},
+			},
+			Action: func(c *cli.Context) {
+				AdminListWorkflowTimers(c)
+			},
+		},
+		{
+			Name:    "cancel-orphaned-timer",
+			Aliases: []string{"cot"},
+			Usage:   "Remove a user timer which is not backed by its timer started event from a workflow",
+			Flags: []cli.Flag{
+				cli.StringFlag{
+					Name:  FlagWorkflowIDWithAlias,
+					Usage: "WorkflowId",
+				},
+				cli.StringFlag{
+					Name:  FlagRunIDWithAlias,
+					Usage: "RunId",
+				},
+				cli.StringFlag{
+					Name:  FlagTimerIDWithAlias,
+					Usage: "TimerId of the orphaned timer, as listed by the timers command",
+				},
+			},
+			Action: func(c *cli.Context) {
+				AdminCancelOrphanedTimer(c)
+			},
+		},
+		{
+			Name:  "tail",
+			Usage: "Print the history events of a workflow execution as they are written, until it closes or is interrupted",
+			Flags: []cli.Flag{
+				cli.StringFlag{
+					Name:  FlagWorkflowIDWithAlias,
+					Usage: "WorkflowId",
+				},
+				cli.StringFlag{
+					Name:  FlagRunIDWithAlias,
+					Usage: "RunId",
+				},
+				cli.BoolFlag{
+					Name:  FlagShowDetailWithAlias,
+					Usage: "Show event details",
+				},
+				cli.IntFlag{
+					Name:  FlagMaxFieldLengthWithAlias,
+					Usage: "Maximum length for each attribute field",
+				},
+			},
+			Action: func(c *cli.Context) {
+				AdminTailWorkflow(c)
+			},
+		},
+		{
+			Name:    "verify-archival",
+			Aliases: []string{"va"},
+			Usage:   "Read the archived history of a closed workflow run back from the archival URI and compare it with its history",
+			Flags: []cli.Flag{
+				cli.StringFlag{
+					Name:  FlagWorkflowIDWithAlias,
+					Usage: "WorkflowId",
+				},
+				cli.StringFlag{
+					Name:  FlagRunIDWithAlias,
+					Usage: "RunId",
+				},
+				cli.StringFlag{
+					Name:  FlagHistoryArchivalURIWithAlias,
+					Usage: "URI the workflow history was archived to, e.g. file:///tmp/temporal_archival/development",
+				},
+				cli.StringFlag{
+					Name:  FlagNamespaceID,
+					Usage: "NamespaceId, looked up from the namespace if not provided",
+				},
+				cli.StringFlag{
+					Name:  FlagInputFileWithAlias,
+					Usage: "Optional JSON file with the expected history, compared against instead of the live history",
+				},
+			},
+			Action: func(c *cli.Context) {
+				AdminVerifyArchival(c)
+			},
+		},
 		{
 			Name:    "delete",
 			Aliases: []string{"del"},
@@ -150,6 +293,10 @@ func newAdminWorkflowCommands() []cli.Command {
 					Name:  FlagSkipErrorModeWithAlias,
 					Usage: "skip errors when deleting history",
 				},
+				cli.BoolFlag{
+					Name:  FlagForce,
+					Usage: "delete the workflow even if it is still open, which can corrupt in-flight processing on its shard",
+				},
 
 				// for persistence connection
 				// TODO need to support other database: https://github.com/uber/cadence/issues/2777
@@ -240,6 +387,60 @@ func newAdminShardManagementCommands() []cli.Command {
 				AdminRemoveTask(c)
 			},
 		},
+		{
+			Name:    "resetReplicationAck",
+			Aliases: []string{"rra"},
+			Usage:   "reset the replication ack level of a remote cluster on a shard, so that its replication tasks can be cleaned up",
+			Flags: []cli.Flag{
+				cli.IntFlag{
+					Name:  FlagShardID,
+					Usage: "ShardId for the temporal cluster to manage",
+				},
+				cli.StringFlag{
+					Name:  FlagCluster,
+					Usage: "Name of the remote cluster whose replication ack level should be reset",
+				},
+			},
+			Action: func(c *cli.Context) {
+				AdminResetReplicationAck(c)
+			},
+		},
+		{
+			Name:    "repair-ack-levels",
+			Aliases: []string{"rpal"},
+			Usage:   "recompute the transfer, timer and replication ack levels of a shard from the tasks in persistence",
+			Flags: []cli.Flag{
+				cli.IntFlag{
+					Name:  FlagShardID,
+					Usage: "ShardId for the temporal cluster to manage",
+				},
+				cli.BoolFlag{
+					Name:  FlagApply,
+					Usage: "Write the repaired ack levels and close the shard, instead of only printing them",
+				},
+				cli.BoolFlag{
+					Name:  FlagYes,
+					Usage: "Optional flag to disable confirmation prompt for --apply",
+				},
+			},
+			Action: func(c *cli.Context) {
+				AdminRepairShardAckLevels(c)
+			},
+		},
+		{
+			Name:    "queue-metrics",
+			Aliases: []string{"qm"},
+			Usage:   "print a point in time snapshot of the task queue metrics of a shard in Prometheus text format",
+			Flags: []cli.Flag{
+				cli.IntFlag{
+					Name:  FlagShardID,
+					Usage: "ShardId for the temporal cluster to manage",
+				},
+			},
+			Action: func(c *cli.Context) {
+				AdminShardQueueMetrics(c)
+			},
+		},
 	}
 }
 
@@ -321,6 +522,23 @@ func newAdminNamespaceCommands() []cli.Command {
 				newNamespaceCLI(c, true).DescribeNamespace(c)
 			},
 		},
+		{
+			Name:    "list-all",
+			Aliases: []string{"la"},
+			Usage:   "Describe all workflow namespaces in persistence as a JSON array",
+			Flags:   adminListAllNamespacesFlags,
+			Action: func(c *cli.Context) {
+				newNamespaceCLI(c, true).ListAllNamespaces(c)
+			},
+		},
+		{
+			Name:    "refresh-cache",
+			Aliases: []string{"rc"},
+			Usage:   "Reload namespace from persistence into the namespace cache of all hosts",
+			Action: func(c *cli.Context) {
+				AdminRefreshNamespaceCache(c)
+			},
+		},
 		{
 			Name:    "getnamespaceidorname",
 			Aliases: []string{"getdn"},
@@ -545,6 +763,10 @@ clusters:
 					Name:  FlagMaxEventID,
 					Usage: "MaxEventId Optional, default to all events",
 				},
+				cli.StringFlag{
+					Name:  FlagCheckpointFile,
+					Usage: "Optional file to record the last rereplicated event in, an existing checkpoint is resumed from",
+				},
 				cli.StringFlag{
 					Name:  FlagWorkflowIDWithAlias,
 					Usage: "WorkflowId",
@@ -763,6 +985,65 @@ func newAdminTaskListCommands() []cli.Command {
 				AdminDescribeTaskList(c)
 			},
 		},
+		{
+			Name:    "list-owned",
+			Aliases: []string{"lo"},
+			Usage:   "List the task lists currently owned by a matching host",
+			Flags: []cli.Flag{
+				cli.StringFlag{
+					Name:  FlagHost,
+					Usage: "Address of the matching host",
+				},
+			},
+			Action: func(c *cli.Context) {
+				AdminListOwnedTaskLists(c)
+			},
+		},
+		{
+			Name:  "inject-task",
+			Usage: "Enqueue a synthetic task, not backed by any workflow, into the backlog of a tasklist (dev only)",
+			Flags: []cli.Flag{
+				cli.StringFlag{
+					Name:  FlagTaskListWithAlias,
+					Usage: "TaskList name",
+				},
+				cli.StringFlag{
+					Name:  FlagTaskListTypeWithAlias,
+					Value: "decision",
+					Usage: "Optional TaskList type [decision|activity]",
+				},
+				cli.IntFlag{
+					Name:  FlagScheduleToStartTimeout,
+					Value: 60,
+					Usage: "Optional schedule to start timeout of the task in seconds",
+				},
+			},
+			Action: func(c *cli.Context) {
+				AdminInjectTask(c)
+			},
+		},
+		{
+			Name:  "complete-below",
+			Usage: "Complete all tasks of a tasklist with a task id at or below the given one, through the owning matching host",
+			Flags: []cli.Flag{
+				cli.StringFlag{
+					Name:  FlagTaskListWithAlias,
+					Usage: "TaskList name",
+				},
+				cli.StringFlag{
+					Name:  FlagTaskListTypeWithAlias,
+					Value: "decision",
+					Usage: "Optional TaskList type [decision|activity]",
+				},
+				cli.Int64Flag{
+					Name:  FlagTaskID,
+					Usage: "Task id at or below which all tasks are completed",
+				},
+			},
+			Action: func(c *cli.Context) {
+				AdminCompleteTasksBelow(c)
+			},
+		},
 	}
 }
 
@@ -811,12 +1092,16 @@ func newAdminDLQCommands() []cli.Command {
 			Flags: []cli.Flag{
 				cli.StringFlag{
 					Name:  FlagDLQTypeWithAlias,
-					Usage: "Type of DLQ to manage. (Options: namespace, history)",
+					Usage: "Type of DLQ to manage. (Options: namespace, history, decision)",
 				},
 				cli.IntFlag{
 					Name:  FlagShardIDWithAlias,
 					Usage: "ShardId",
 				},
+				cli.StringFlag{
+					Name:  FlagNamespaceID,
+					Usage: "Only apply to the messages of this namespace id (namespace DLQ only)",
+				},
 				cli.IntFlag{
 					Name:  FlagMaxMessageCountWithAlias,
 					Usage: "Max message size to fetch",
@@ -847,10 +1132,28 @@ func newAdminDLQCommands() []cli.Command {
 					Name:  FlagShardIDWithAlias,
 					Usage: "ShardId",
 				},
+				cli.StringFlag{
+					Name:  FlagNamespaceID,
+					Usage: "Only apply to the messages of this namespace id (namespace DLQ only)",
+				},
 				cli.IntFlag{
 					Name:  FlagLastMessageID,
 					Usage: "The upper boundary of the read message",
 				},
+				cli.IntFlag{
+					Name:  FlagChunkSize,
+					Usage: "Number of messages to purge per request",
+					Value: defaultPageSize,
+				},
+				cli.BoolFlag{
+					Name:  FlagResume,
+					Usage: "Resume an interrupted purge from the state file",
+				},
+				cli.StringFlag{
+					Name:  FlagStateFile,
+					Usage: "File to persist the purge progress to",
+					Value: defaultDLQPurgeStateFile,
+				},
 			},
 			Action: func(c *cli.Context) {
 				AdminPurgeDLQMessages(c)
@@ -869,6 +1172,10 @@ func newAdminDLQCommands() []cli.Command {
 					Name:  FlagShardIDWithAlias,
 					Usage: "ShardId",
 				},
+				cli.StringFlag{
+					Name:  FlagNamespaceID,
+					Usage: "Only apply to the messages of this namespace id (namespace DLQ only)",
+				},
 				cli.IntFlag{
 					Name:  FlagLastMessageID,
 					Usage: "The upper boundary of the read message",
@@ -878,5 +1185,107 @@ func newAdminDLQCommands() []cli.Command {
 				AdminMergeDLQMessages(c)
 			},
 		},
+		{
+			Name:  "merge-one",
+			Usage: "Merge a single DLQ message and leave the other messages in the DLQ",
+			Flags: []cli.Flag{
+				cli.StringFlag{
+					Name:  FlagDLQTypeWithAlias,
+					Usage: "Type of DLQ to manage. (Options: namespace)",
+				},
+				cli.Int64Flag{
+					Name:  FlagMessageID,
+					Usage: "Id of the message to merge",
+				},
+			},
+			Action: func(c *cli.Context) {
+				AdminMergeOneDLQMessage(c)
+			},
+		},
+		{
+			Name:    "diff",
+			Aliases: []string{"d"},
+			Usage:   "Compare DLQ messages of the current cluster with the DLQ of a remote cluster",
+			Flags: []cli.Flag{
+				cli.StringFlag{
+					Name:  FlagDLQTypeWithAlias,
+					Usage: "Type of DLQ to manage. (Options: namespace, history)",
+				},
+				cli.IntFlag{
+					Name:  FlagShardIDWithAlias,
+					Usage: "ShardId",
+				},
+				cli.StringFlag{
+					Name:  FlagRemoteAddressWithAlias,
+					Usage: "host:port of the frontend of the remote cluster",
+				},
+				cli.IntFlag{
+					Name:  FlagLastMessageID,
+					Usage: "The upper boundary of the read message",
+				},
+				cli.IntFlag{
+					Name:  FlagMaxMessages,
+					Usage: "Max number of messages to read from each DLQ",
+					Value: defaultDLQDiffMaxMessages,
+				},
+				cli.StringFlag{
+					Name:  FlagFormat,
+					Usage: "Format of the diff report (text or json)",
+					Value: formatText,
+				},
+			},
+			Action: func(c *cli.Context) {
+				AdminDiffDLQMessages(c)
+			},
+		},
+		{
+			Name:    "skip",
+			Aliases: []string{"s"},
+			Usage:   "Put a replication task that keeps failing into DLQ, so that the replication queue of the shard can proceed",
+			Flags: []cli.Flag{
+				cli.IntFlag{
+					Name:  FlagShardIDWithAlias,
+					Usage: "ShardId",
+				},
+				cli.StringFlag{
+					Name:  FlagCluster,
+					Usage: "Name of the source cluster of the replication task",
+				},
+				cli.Int64Flag{
+					Name:  FlagRemoveTaskID,
+					Usage: "Source task id of the replication task",
+				},
+				cli.StringFlag{
+					Name:  FlagReasonWithAlias,
+					Usage: "Reason for putting the replication task into DLQ",
+				},
+			},
+			Action: func(c *cli.Context) {
+				AdminSkipReplicationTask(c)
+			},
+		},
+	}
+}
+
+func newAdminTokenCommands() []cli.Command {
+	return []cli.Command{
+		{
+			Name:    "decode",
+			Aliases: []string{"d"},
+			Usage:   "Decode a base64 history pagination token and print its fields",
+			Flags: []cli.Flag{
+				cli.StringFlag{
+					Name:  FlagPaginationToken,
+					Usage: "Base64 encoded pagination token",
+				},
+				cli.StringFlag{
+					Name:  FlagTokenType,
+					Usage: "Type of the token (raw or history), detected from the token if not provided",
+				},
+			},
+			Action: func(c *cli.Context) {
+				AdminDecodeToken(c)
+			},
+		},
 	}
 }
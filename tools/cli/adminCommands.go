@@ -21,22 +21,37 @@
 package cli
 
 import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
 	"encoding/json"
 	"fmt"
+	"io"
 	"io/ioutil"
+	"os"
+	"os/signal"
 	"strconv"
+	"strings"
+	"syscall"
 	"time"
 
 	"github.com/gocql/gocql"
+	"github.com/olekukonko/tablewriter"
 	"github.com/urfave/cli"
 	eventpb "go.temporal.io/temporal-proto/event"
 	executionpb "go.temporal.io/temporal-proto/execution"
+	"go.temporal.io/temporal-proto/workflowservice"
 
 	"github.com/temporalio/temporal/.gen/proto/adminservice"
 	"github.com/temporalio/temporal/.gen/proto/persistenceblobs"
 	"github.com/temporalio/temporal/common"
+	"github.com/temporalio/temporal/common/archiver"
+	"github.com/temporalio/temporal/common/archiver/provider"
 	"github.com/temporalio/temporal/common/auth"
 	"github.com/temporalio/temporal/common/codec"
+	"github.com/temporalio/temporal/common/headers"
 	"github.com/temporalio/temporal/common/log/loggerimpl"
 	"github.com/temporalio/temporal/common/persistence"
 	cassp "github.com/temporalio/temporal/common/persistence/cassandra"
@@ -57,6 +72,27 @@ func AdminShowWorkflow(c *cli.Context) {
 
 	session := connectToCassandra(c)
 	serializer := persistence.NewPayloadSerializer()
+	if c.Bool(FlagReverse) {
+		if len(tid) == 0 {
+			ErrorAndExit("need to specify TreeId/BranchId/ShardId", nil)
+		}
+		lastEventID := c.Int64(FlagMaxEventID)
+		if lastEventID <= 0 {
+			lastEventID = maxEventID
+		}
+		histV2 := cassp.NewHistoryV2PersistenceFromSession(session, loggerimpl.NewNopLogger())
+		events, err := readHistoryBranchReverse(histV2, serializer, &persistence.InternalReadHistoryBranchRequest{
+			TreeID:   primitives.MustParseUUID(tid),
+			BranchID: primitives.MustParseUUID(bid),
+			ShardID:  sid,
+		}, lastEventID, c.Int(FlagMaxEvents))
+		if err != nil {
+			ErrorAndExit("ReadHistoryBranch err", err)
+		}
+		printReverseHistory(events, outputFileName)
+		return
+	}
+
 	var history []*serialization.DataBlob
 	if len(tid) != 0 {
 		histV2 := cassp.NewHistoryV2PersistenceFromSession(session, loggerimpl.NewNopLogger())
@@ -112,6 +148,93 @@ func AdminShowWorkflow(c *cli.Context) {
 	}
 }
 
+// readHistoryBranchReverse reads up to maxEvents events of a branch from lastEventID backward and
+// returns them newest first. The branch is read in windows of node ids going back from lastEventID,
+// so only the tail of a long history is loaded
+func readHistoryBranchReverse(
+	histV2 persistence.HistoryStore,
+	serializer persistence.PayloadSerializer,
+	branch *persistence.InternalReadHistoryBranchRequest,
+	lastEventID int64,
+	maxEvents int,
+) ([]*eventpb.HistoryEvent, error) {
+
+	if maxEvents <= 0 {
+		return nil, fmt.Errorf("--%v must be greater than 0", FlagMaxEvents)
+	}
+
+	var events []*eventpb.HistoryEvent
+	maxNodeID := lastEventID + 1
+	for maxNodeID > 1 && len(events) < maxEvents {
+		minNodeID := maxNodeID - int64(maxEvents)
+		if minNodeID < 1 {
+			minNodeID = 1
+		}
+
+		var batches [][]*eventpb.HistoryEvent
+		request := &persistence.InternalReadHistoryBranchRequest{
+			TreeID:    branch.TreeID,
+			BranchID:  branch.BranchID,
+			MinNodeID: minNodeID,
+			MaxNodeID: maxNodeID,
+			PageSize:  int(maxNodeID - minNodeID),
+			ShardID:   branch.ShardID,
+		}
+		for {
+			resp, err := histV2.ReadHistoryBranch(request)
+			if err != nil {
+				return nil, err
+			}
+			for _, blob := range resp.History {
+				batch, err := serializer.DeserializeBatchEvents(blob)
+				if err != nil {
+					return nil, err
+				}
+				batches = append(batches, batch)
+			}
+			if len(resp.NextPageToken) == 0 {
+				break
+			}
+			request.NextPageToken = resp.NextPageToken
+			request.LastNodeID = resp.LastNodeID
+			request.LastTransactionID = resp.LastTransactionID
+		}
+
+		// batches are read in ascending node id order, walk them and their events backward
+		for i := len(batches) - 1; i >= 0 && len(events) < maxEvents; i-- {
+			batch := batches[i]
+			for j := len(batch) - 1; j >= 0 && len(events) < maxEvents; j-- {
+				if batch[j].GetEventId() <= lastEventID {
+					events = append(events, batch[j])
+				}
+			}
+		}
+		maxNodeID = minNodeID
+	}
+	return events, nil
+}
+
+func printReverseHistory(events []*eventpb.HistoryEvent, outputFileName string) {
+	if len(events) == 0 {
+		ErrorAndExit("no events", nil)
+	}
+
+	encoder := codec.NewJSONPBEncoder()
+	data, err := encoder.EncodeHistoryEvents(events)
+	if err != nil {
+		ErrorAndExit("EncodeHistoryEvents err", err)
+	}
+	fmt.Printf("======== %v events in reverse order, newest first: %v to %v ======\n",
+		len(events), events[0].GetEventId(), events[len(events)-1].GetEventId())
+	fmt.Println(string(data))
+
+	if outputFileName != "" {
+		if err := ioutil.WriteFile(outputFileName, data, 0777); err != nil {
+			ErrorAndExit("Failed to export history data file.", err)
+		}
+	}
+}
+
 // AdminDescribeWorkflow describe a new workflow execution for admin
 func AdminDescribeWorkflow(c *cli.Context) {
 
@@ -151,9 +274,128 @@ func AdminDescribeWorkflow(c *cli.Context) {
 				fmt.Println(p.GetBinaryChecksum(), p.GetRunId(), p.GetFirstDecisionCompletedId(), p.GetResettable(), createT, expireT)
 			}
 		}
+		fmt.Println("buffered-events-count:", resp.GetBufferedEventsCount())
+		if len(resp.GetPendingChildren()) > 0 {
+			fmt.Println("pending-children:")
+			for _, ch := range resp.GetPendingChildren() {
+				initiatedT := time.Unix(0, ch.GetInitiatedTime())
+				fmt.Println(ch.GetNamespace(), ch.GetWorkflowId(), ch.GetRunId(), ch.GetWorkflowTypeName(), ch.GetInitiatedId(), initiatedT, ch.GetParentClosePolicy())
+			}
+		}
+
+		if c.Bool(FlagShowBranches) {
+			if ms.VersionHistories == nil {
+				ErrorAndExit("workflow does not have version histories", nil)
+			}
+			branches, err := buildVersionHistoryBranchTree(ms.VersionHistories)
+			if err != nil {
+				ErrorAndExit("failed to build version history branch tree", err)
+			}
+			switch format := c.String(FlagFormat); format {
+			case formatJSON:
+				prettyPrintJSONObject(branches)
+			case formatText:
+				fmt.Print(formatVersionHistoryBranchTree(branches))
+			default:
+				ErrorAndExit(fmt.Sprintf("unknown format: %v", format), nil)
+			}
+		}
 	}
 }
 
+type (
+	// versionHistoryBranch is the printable form of one branch of the version histories
+	versionHistoryBranch struct {
+		Index        int
+		IsCurrent    bool
+		BranchToken  []byte
+		TreeID       string
+		BranchID     string
+		BeginEventID int64
+		EndEventID   int64
+		Ancestors    []versionHistoryBranchAncestor
+		Items        []*persistence.VersionHistoryItem
+	}
+
+	// versionHistoryBranchAncestor is the printable form of a branch range this branch is forked from
+	versionHistoryBranchAncestor struct {
+		BranchID     string
+		BeginEventID int64
+		EndEventID   int64
+	}
+)
+
+func buildVersionHistoryBranchTree(
+	versionHistories *persistence.VersionHistories,
+) ([]*versionHistoryBranch, error) {
+
+	var branches []*versionHistoryBranch
+	for index, versionHistory := range versionHistories.Histories {
+		branchInfo, err := serialization.HistoryBranchFromBlob(versionHistory.GetBranchToken(), common.EncodingTypeProto3.String())
+		if err != nil {
+			return nil, err
+		}
+
+		branch := &versionHistoryBranch{
+			Index:        index,
+			IsCurrent:    index == versionHistories.GetCurrentVersionHistoryIndex(),
+			BranchToken:  versionHistory.GetBranchToken(),
+			TreeID:       primitives.UUIDString(branchInfo.GetTreeId()),
+			BranchID:     primitives.UUIDString(branchInfo.GetBranchId()),
+			BeginEventID: common.FirstEventID,
+			EndEventID:   common.EmptyEventID,
+			Items:        versionHistory.Items,
+		}
+		for _, ancestor := range branchInfo.GetAncestors() {
+			branch.Ancestors = append(branch.Ancestors, versionHistoryBranchAncestor{
+				BranchID:     primitives.UUIDString(ancestor.GetBranchId()),
+				BeginEventID: ancestor.GetBeginNodeId(),
+				EndEventID:   ancestor.GetEndNodeId(),
+			})
+			// ancestor end node ID is exclusive, so this branch starts right there
+			branch.BeginEventID = ancestor.GetEndNodeId()
+		}
+		if lastItem, err := versionHistory.GetLastItem(); err == nil {
+			branch.EndEventID = lastItem.GetEventID()
+		}
+		branches = append(branches, branch)
+	}
+	return branches, nil
+}
+
+func formatVersionHistoryBranchTree(
+	branches []*versionHistoryBranch,
+) string {
+
+	var builder strings.Builder
+	builder.WriteString("version history branches:\n")
+	for _, branch := range branches {
+		marker := " "
+		if branch.IsCurrent {
+			marker = "*"
+		}
+		builder.WriteString(fmt.Sprintf(
+			"%v [%v] tree: %v, branch: %v, events: [%v, %v], token: %v\n",
+			marker,
+			branch.Index,
+			branch.TreeID,
+			branch.BranchID,
+			branch.BeginEventID,
+			branch.EndEventID,
+			base64.StdEncoding.EncodeToString(branch.BranchToken),
+		))
+		for _, ancestor := range branch.Ancestors {
+			builder.WriteString(fmt.Sprintf(
+				"      ancestor branch: %v, events: [%v, %v)\n",
+				ancestor.BranchID,
+				ancestor.BeginEventID,
+				ancestor.EndEventID,
+			))
+		}
+	}
+	return builder.String()
+}
+
 func describeMutableState(c *cli.Context) *adminservice.DescribeWorkflowExecutionResponse {
 	adminClient := cFactory.AdminClient(c)
 
@@ -189,6 +431,12 @@ func AdminDeleteWorkflow(c *cli.Context) {
 	if err != nil {
 		ErrorAndExit("json.Unmarshal err", err)
 	}
+	fmt.Printf("workflow state: %v, status: %v\n", ms.ExecutionInfo.State, ms.ExecutionInfo.Status)
+	// the open workflow guard is not an error to skip, only force overrides it
+	if err := checkDeleteWorkflowAllowed(ms.ExecutionInfo, c.Bool(FlagForce)); err != nil {
+		ErrorAndExit("refused to delete workflow", err)
+	}
+
 	namespaceID := ms.ExecutionInfo.NamespaceID
 	skipError := c.Bool(FlagSkipErrorMode)
 	session := connectToCassandra(c)
@@ -262,6 +510,19 @@ func AdminDeleteWorkflow(c *cli.Context) {
 	fmt.Println("delete current row successfully")
 }
 
+// checkDeleteWorkflowAllowed returns an error if the workflow is still open and the deletion is not forced,
+// deleting the state of an open workflow can corrupt the processing of its tasks on the owning shard
+func checkDeleteWorkflowAllowed(executionInfo *persistence.WorkflowExecutionInfo, force bool) error {
+	switch executionInfo.State {
+	case persistence.WorkflowStateCreated, persistence.WorkflowStateRunning:
+		if !force {
+			return fmt.Errorf("workflow %v is still open, use --%v to delete it anyway", executionInfo.WorkflowID, FlagForce)
+		}
+		fmt.Println("workflow is still open, deleting it as forced")
+	}
+	return nil
+}
+
 func readOneRow(query *gocql.Query) (map[string]interface{}, error) {
 	result := make(map[string]interface{})
 	err := query.MapScan(result)
@@ -349,6 +610,33 @@ func AdminGetNamespaceIDOrName(c *cli.Context) {
 	}
 }
 
+// AdminRefreshNamespaceCache reloads a namespace into the namespace caches of the cluster
+func AdminRefreshNamespaceCache(c *cli.Context) {
+	adminClient := cFactory.AdminClient(c)
+	namespace := getRequiredGlobalOption(c, FlagNamespace)
+
+	ctx, cancel := newContext(c)
+	defer cancel()
+
+	resp, err := adminClient.RefreshNamespaceCache(ctx, &adminservice.RefreshNamespaceCacheRequest{
+		Namespace: namespace,
+	})
+	if err != nil {
+		ErrorAndExit("Refresh namespace cache failed", err)
+	}
+
+	failed := 0
+	for _, result := range resp.GetResults() {
+		if result.GetError() != "" {
+			failed++
+			fmt.Printf("%v %v: failed: %v\n", result.GetService(), result.GetHostAddress(), result.GetError())
+		} else {
+			fmt.Printf("%v %v: succeeded\n", result.GetService(), result.GetHostAddress())
+		}
+	}
+	fmt.Printf("Namespace %v refreshed on %v of %v hosts.\n", namespace, len(resp.GetResults())-failed, len(resp.GetResults()))
+}
+
 // AdminGetShardID get shardID
 func AdminGetShardID(c *cli.Context) {
 	wid := getRequiredOption(c, FlagWorkflowID)
@@ -402,6 +690,172 @@ func AdminShardManagement(c *cli.Context) {
 	}
 }
 
+// AdminResetReplicationAck resets the replication ack level of a remote cluster on a shard
+func AdminResetReplicationAck(c *cli.Context) {
+	adminClient := cFactory.AdminClient(c)
+	sid := getRequiredIntOption(c, FlagShardID)
+	cluster := getRequiredOption(c, FlagCluster)
+
+	ctx, cancel := newContext(c)
+	defer cancel()
+
+	resp, err := adminClient.ResetReplicationAckLevel(ctx, &adminservice.ResetReplicationAckLevelRequest{
+		ShardId:     int32(sid),
+		ClusterName: cluster,
+	})
+	if err != nil {
+		ErrorAndExit("Reset replication ack level has failed", err)
+	}
+	fmt.Printf("Replication ack level of cluster %v on shard %v is reset to %v\n", cluster, sid, resp.GetAckLevel())
+}
+
+// AdminRepairShardAckLevels recomputes the ack levels of a shard, and writes them back if asked to
+func AdminRepairShardAckLevels(c *cli.Context) {
+	adminClient := cFactory.AdminClient(c)
+	sid := getRequiredIntOption(c, FlagShardID)
+	apply := c.Bool(FlagApply)
+
+	if apply && !c.Bool(FlagYes) {
+		confirmOrExit(fmt.Sprintf("Are you sure to overwrite the ack levels of shard %v and close it?", sid))
+	}
+
+	ctx, cancel := newContext(c)
+	defer cancel()
+
+	resp, err := adminClient.RepairShardAckLevels(ctx, &adminservice.RepairShardAckLevelsRequest{
+		ShardId: int32(sid),
+		Apply:   apply,
+	})
+	if err != nil {
+		ErrorAndExit("Repair shard ack levels has failed", err)
+	}
+	printShardAckLevels(os.Stdout, resp.GetQueues())
+	if apply {
+		fmt.Printf("Ack levels of shard %v are repaired and the shard is closed\n", sid)
+	} else {
+		fmt.Printf("Dry run, ack levels of shard %v are not changed. Use --%v to write them\n", sid, FlagApply)
+	}
+}
+
+func printShardAckLevels(w io.Writer, queues []*adminservice.ShardQueueAckLevel) {
+	table := tablewriter.NewWriter(w)
+	table.SetBorder(false)
+	table.SetColumnSeparator("|")
+	table.SetHeader([]string{"Queue", "Ack Level", "Repaired Ack Level", "Min Task", "Max Task", "Tasks"})
+	table.SetHeaderLine(false)
+	table.SetHeaderColor(tableHeaderBlue, tableHeaderBlue, tableHeaderBlue, tableHeaderBlue, tableHeaderBlue, tableHeaderBlue)
+	for _, queue := range queues {
+		minTask, maxTask := "-", "-"
+		if queue.GetTaskCount() > 0 {
+			minTask = formatShardAckLevel(queue, queue.GetMinTaskLevel())
+			maxTask = formatShardAckLevel(queue, queue.GetMaxTaskLevel())
+		}
+		table.Append([]string{
+			queue.GetQueueType(),
+			formatShardAckLevel(queue, queue.GetAckLevel()),
+			formatShardAckLevel(queue, queue.GetRepairedAckLevel()),
+			minTask,
+			maxTask,
+			strconv.FormatInt(queue.GetTaskCount(), 10),
+		})
+	}
+	table.Render()
+}
+
+// formatShardAckLevel prints timer queue levels, which are visibility timestamps, as time
+func formatShardAckLevel(queue *adminservice.ShardQueueAckLevel, level int64) string {
+	if queue.GetQueueType() == "timer" {
+		return convertTime(level, false)
+	}
+	return strconv.FormatInt(level, 10)
+}
+
+// AdminShardQueueMetrics prints the task queue state of a shard as Prometheus text exposition,
+// for debugging without a metrics backend
+func AdminShardQueueMetrics(c *cli.Context) {
+	adminClient := cFactory.AdminClient(c)
+	sid := getRequiredIntOption(c, FlagShardID)
+
+	ctx, cancel := newContext(c)
+	defer cancel()
+
+	// a dry run repair scans the task queues of the shard without changing anything
+	resp, err := adminClient.RepairShardAckLevels(ctx, &adminservice.RepairShardAckLevelsRequest{
+		ShardId: int32(sid),
+	})
+	if err != nil {
+		ErrorAndExit("Get shard queue metrics has failed", err)
+	}
+	writeShardQueueMetrics(os.Stdout, sid, resp.GetQueues(), time.Now())
+}
+
+type shardQueueMetric struct {
+	name  string
+	help  string
+	value func(queue *adminservice.ShardQueueAckLevel) (float64, bool)
+}
+
+var shardQueueMetrics = []shardQueueMetric{
+	{
+		name: "temporal_shard_queue_ack_level",
+		help: "Persisted ack level of the queue, task id or unix nanoseconds for the timer queue.",
+		value: func(queue *adminservice.ShardQueueAckLevel) (float64, bool) {
+			return float64(queue.GetAckLevel()), true
+		},
+	},
+	{
+		name: "temporal_shard_queue_pending_tasks",
+		help: "Number of tasks of the queue in persistence.",
+		value: func(queue *adminservice.ShardQueueAckLevel) (float64, bool) {
+			return float64(queue.GetTaskCount()), true
+		},
+	},
+	{
+		name: "temporal_shard_queue_min_task_level",
+		help: "Level of the oldest task of the queue in persistence.",
+		value: func(queue *adminservice.ShardQueueAckLevel) (float64, bool) {
+			return float64(queue.GetMinTaskLevel()), queue.GetTaskCount() > 0
+		},
+	},
+	{
+		name: "temporal_shard_queue_max_task_level",
+		help: "Level of the newest task of the queue in persistence.",
+		value: func(queue *adminservice.ShardQueueAckLevel) (float64, bool) {
+			return float64(queue.GetMaxTaskLevel()), queue.GetTaskCount() > 0
+		},
+	},
+}
+
+// writeShardQueueMetrics writes the queues of a shard as gauges in the Prometheus text exposition
+// format, the timer queue latency is how far the oldest pending timer is behind now
+func writeShardQueueMetrics(w io.Writer, shardID int, queues []*adminservice.ShardQueueAckLevel, now time.Time) {
+	for _, metric := range shardQueueMetrics {
+		fmt.Fprintf(w, "# HELP %v %v\n", metric.name, metric.help)
+		fmt.Fprintf(w, "# TYPE %v gauge\n", metric.name)
+		for _, queue := range queues {
+			if value, ok := metric.value(queue); ok {
+				fmt.Fprintf(w, "%v{shard_id=\"%v\",queue=%q} %v\n", metric.name, shardID, queue.GetQueueType(), strconv.FormatFloat(value, 'f', -1, 64))
+			}
+		}
+	}
+
+	const latencyName = "temporal_shard_timer_queue_latency_seconds"
+	fmt.Fprintf(w, "# HELP %v Age of the oldest pending timer of the queue.\n", latencyName)
+	fmt.Fprintf(w, "# TYPE %v gauge\n", latencyName)
+	for _, queue := range queues {
+		if queue.GetQueueType() != "timer" {
+			continue
+		}
+		latency := time.Duration(0)
+		if queue.GetTaskCount() > 0 {
+			if latency = now.Sub(time.Unix(0, queue.GetMinTaskLevel())); latency < 0 {
+				latency = 0
+			}
+		}
+		fmt.Fprintf(w, "%v{shard_id=\"%v\"} %v\n", latencyName, shardID, strconv.FormatFloat(latency.Seconds(), 'f', -1, 64))
+	}
+}
+
 // AdminDescribeHistoryHost describes history host
 func AdminDescribeHistoryHost(c *cli.Context) {
 	adminClient := cFactory.AdminClient(c)
@@ -465,3 +919,347 @@ func AdminRefreshWorkflowTasks(c *cli.Context) {
 		fmt.Println("Refresh workflow task succeeded.")
 	}
 }
+
+// AdminForceTerminateWorkflow closes a workflow with a terminated event recording the given reason
+func AdminForceTerminateWorkflow(c *cli.Context) {
+	adminClient := cFactory.AdminClient(c)
+
+	namespace := getRequiredGlobalOption(c, FlagNamespace)
+	wid := getRequiredOption(c, FlagWorkflowID)
+	rid := c.String(FlagRunID)
+	reason := getRequiredOption(c, FlagReason)
+	var details []byte
+	if c.IsSet(FlagDetail) {
+		details = []byte(c.String(FlagDetail))
+	}
+
+	ctx, cancel := newContext(c)
+	defer cancel()
+
+	_, err := adminClient.ForceTerminateWorkflowExecution(ctx, &adminservice.ForceTerminateWorkflowExecutionRequest{
+		Namespace: namespace,
+		Execution: &executionpb.WorkflowExecution{
+			WorkflowId: wid,
+			RunId:      rid,
+		},
+		Reason:   reason,
+		Details:  details,
+		Identity: getCliIdentity(),
+	})
+	if err != nil {
+		ErrorAndExit("Force terminate workflow failed", err)
+	} else {
+		fmt.Println("Force terminate workflow succeeded.")
+	}
+}
+
+// AdminListWorkflowTimers lists the user timers of a workflow with their fire times
+func AdminListWorkflowTimers(c *cli.Context) {
+	adminClient := cFactory.AdminClient(c)
+
+	namespace := getRequiredGlobalOption(c, FlagNamespace)
+	wid := getRequiredOption(c, FlagWorkflowID)
+	rid := c.String(FlagRunID)
+
+	ctx, cancel := newContext(c)
+	defer cancel()
+
+	resp, err := adminClient.ListWorkflowTimers(ctx, &adminservice.ListWorkflowTimersRequest{
+		Namespace: namespace,
+		Execution: &executionpb.WorkflowExecution{
+			WorkflowId: wid,
+			RunId:      rid,
+		},
+	})
+	if err != nil {
+		ErrorAndExit("List workflow timers failed", err)
+	}
+	printWorkflowTimers(os.Stdout, resp.GetTimers())
+}
+
+func printWorkflowTimers(w io.Writer, timers []*adminservice.WorkflowTimerInfo) {
+	table := tablewriter.NewWriter(w)
+	table.SetBorder(false)
+	table.SetColumnSeparator("|")
+	table.SetHeader([]string{"Timer Id", "Started Event Id", "Fire Time", "Version", "Orphaned"})
+	table.SetHeaderLine(false)
+	table.SetHeaderColor(tableHeaderBlue, tableHeaderBlue, tableHeaderBlue, tableHeaderBlue, tableHeaderBlue)
+	for _, timer := range timers {
+		table.Append([]string{
+			timer.GetTimerId(),
+			strconv.FormatInt(timer.GetStartedEventId(), 10),
+			convertTime(timer.GetExpiryTime(), false),
+			strconv.FormatInt(timer.GetVersion(), 10),
+			strconv.FormatBool(timer.GetOrphaned()),
+		})
+	}
+	table.Render()
+}
+
+// AdminCancelOrphanedTimer removes an orphaned user timer from a workflow
+func AdminCancelOrphanedTimer(c *cli.Context) {
+	adminClient := cFactory.AdminClient(c)
+
+	namespace := getRequiredGlobalOption(c, FlagNamespace)
+	wid := getRequiredOption(c, FlagWorkflowID)
+	rid := c.String(FlagRunID)
+	timerID := getRequiredOption(c, FlagTimerID)
+
+	ctx, cancel := newContext(c)
+	defer cancel()
+
+	_, err := adminClient.CancelOrphanedTimer(ctx, &adminservice.CancelOrphanedTimerRequest{
+		Namespace: namespace,
+		Execution: &executionpb.WorkflowExecution{
+			WorkflowId: wid,
+			RunId:      rid,
+		},
+		TimerId: timerID,
+	})
+	if err != nil {
+		ErrorAndExit("Cancel orphaned timer failed", err)
+	} else {
+		fmt.Printf("Orphaned timer %v is canceled.\n", timerID)
+	}
+}
+
+// AdminTailWorkflow prints the history events of a workflow execution as they are written
+func AdminTailWorkflow(c *cli.Context) {
+	adminClient := cFactory.AdminClient(c)
+
+	namespace := getRequiredGlobalOption(c, FlagNamespace)
+	wid := getRequiredOption(c, FlagWorkflowID)
+	rid := c.String(FlagRunID)
+	showDetails := c.Bool(FlagShowDetail)
+	var maxFieldLength int
+	if c.IsSet(FlagMaxFieldLength) {
+		maxFieldLength = c.Int(FlagMaxFieldLength)
+	}
+
+	// canceling the stream on interrupt releases the history subscription on the server
+	ctx, cancel := context.WithCancel(headers.SetCLIVersions(context.Background()))
+	defer cancel()
+	sigC := make(chan os.Signal, 1)
+	signal.Notify(sigC, os.Interrupt, syscall.SIGTERM)
+	defer signal.Stop(sigC)
+	go func() {
+		select {
+		case <-sigC:
+			cancel()
+		case <-ctx.Done():
+		}
+	}()
+
+	stream, err := adminClient.TailWorkflowExecutionHistory(ctx, &adminservice.TailWorkflowExecutionHistoryRequest{
+		Namespace: namespace,
+		Execution: &executionpb.WorkflowExecution{
+			WorkflowId: wid,
+			RunId:      rid,
+		},
+	})
+	if err != nil {
+		ErrorAndExit("Tail workflow history failed", err)
+	}
+	if err := printTailedHistory(os.Stdout, stream, showDetails, maxFieldLength); err != nil && ctx.Err() == nil {
+		ErrorAndExit("Tail workflow history failed", err)
+	}
+}
+
+// printTailedHistory prints the events received from the stream until the stream ends
+func printTailedHistory(
+	w io.Writer,
+	stream adminservice.AdminService_TailWorkflowExecutionHistoryClient,
+	showDetails bool,
+	maxFieldLength int,
+) error {
+	for {
+		resp, err := stream.Recv()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		for _, event := range resp.GetEvents() {
+			if showDetails {
+				fmt.Fprintf(w, "  %d, %s, %s, %s\n", event.GetEventId(), convertTime(event.GetTimestamp(), false), ColorEvent(event), HistoryEventToString(event, true, maxFieldLength))
+			} else {
+				fmt.Fprintf(w, "  %d, %s, %s\n", event.GetEventId(), convertTime(event.GetTimestamp(), false), ColorEvent(event))
+			}
+		}
+	}
+}
+
+// AdminVerifyArchival reads the archived history of a workflow run back from the archival URI
+// and compares it with the history of the run, reporting any discrepancy
+func AdminVerifyArchival(c *cli.Context) {
+	namespace := getRequiredGlobalOption(c, FlagNamespace)
+	wid := getRequiredOption(c, FlagWorkflowID)
+	rid := getRequiredOption(c, FlagRunID)
+	archivalURI, err := archiver.NewURI(getRequiredOption(c, FlagHistoryArchivalURI))
+	if err != nil {
+		ErrorAndExit("Invalid history archival URI", err)
+	}
+
+	ctx, cancel := newContext(c)
+	defer cancel()
+
+	namespaceID := c.String(FlagNamespaceID)
+	if namespaceID == "" {
+		resp, err := cFactory.FrontendClient(c).DescribeNamespace(ctx, &workflowservice.DescribeNamespaceRequest{
+			Name: namespace,
+		})
+		if err != nil {
+			ErrorAndExit(fmt.Sprintf("Failed to describe namespace %v", namespace), err)
+		}
+		namespaceID = resp.NamespaceInfo.GetId()
+	}
+
+	var expected *eventpb.History
+	if c.IsSet(FlagInputFile) {
+		data, err := ioutil.ReadFile(c.String(FlagInputFile))
+		if err != nil {
+			ErrorAndExit("Failed to read expected history file", err)
+		}
+		expected = &eventpb.History{}
+		if err := codec.NewJSONPBEncoder().Decode(data, expected); err != nil {
+			ErrorAndExit("Failed to decode expected history file", err)
+		}
+	} else {
+		expected, err = GetHistory(ctx, getWorkflowClient(c), wid, rid)
+		if err != nil {
+			ErrorAndExit(fmt.Sprintf("Failed to get history on workflow id: %s, run id: %s.", wid, rid), err)
+		}
+	}
+
+	historyArchiver := newCLIHistoryArchiver(archivalURI.Scheme())
+	result, err := verifyArchivedHistory(ctx, historyArchiver, archivalURI, &archiver.GetHistoryRequest{
+		NamespaceID: namespaceID,
+		WorkflowID:  wid,
+		RunID:       rid,
+	}, expected.GetEvents())
+	if err != nil {
+		ErrorAndExit("Failed to read archived history", err)
+	}
+
+	fmt.Printf("Expected events: %v, sha256: %v\n", result.ExpectedEventCount, result.ExpectedHash)
+	fmt.Printf("Archived events: %v, sha256: %v\n", result.ArchivedEventCount, result.ArchivedHash)
+	if result.Match() {
+		fmt.Println("Archived history matches.")
+		return
+	}
+	for _, discrepancy := range result.Discrepancies {
+		fmt.Println(discrepancy)
+	}
+	ErrorAndExit("Archived history does not match.", nil)
+}
+
+// newCLIHistoryArchiver returns the history archiver for the given scheme, configured with the
+// defaults of the development config; s3 reads its region from the AWS_REGION environment variable
+func newCLIHistoryArchiver(scheme string) archiver.HistoryArchiver {
+	archiverProvider := provider.NewArchiverProvider(&config.HistoryArchiverProvider{
+		Filestore: &config.FilestoreArchiver{
+			FileMode: "0666",
+			DirMode:  "0766",
+		},
+		Gstorage: &config.GstorageArchiver{},
+		S3store: &config.S3Archiver{
+			Region: os.Getenv("AWS_REGION"),
+		},
+	}, nil)
+	err := archiverProvider.RegisterBootstrapContainer(common.FrontendServiceName, &archiver.HistoryBootstrapContainer{
+		Logger:        loggerimpl.NewNopLogger(),
+		MetricsClient: initializeMetricsClient(),
+	}, nil)
+	if err != nil {
+		ErrorAndExit("Error initializing archival provider.", err)
+	}
+	historyArchiver, err := archiverProvider.GetHistoryArchiver(scheme, common.FrontendServiceName)
+	if err != nil {
+		ErrorAndExit(fmt.Sprintf("Failed to get history archiver for scheme %v", scheme), err)
+	}
+	return historyArchiver
+}
+
+// archivalVerificationResult is the outcome of comparing archived history with the expected history
+type archivalVerificationResult struct {
+	ExpectedEventCount int
+	ArchivedEventCount int
+	ExpectedHash       string
+	ArchivedHash       string
+	Discrepancies      []string
+}
+
+// Match returns true if no discrepancy was found between archived and expected history
+func (r *archivalVerificationResult) Match() bool {
+	return len(r.Discrepancies) == 0
+}
+
+// verifyArchivedHistory pages through the archived history of a workflow run and compares it event by event
+// with the expected history. Events are compared on their encoded form, which also feeds the history hash.
+func verifyArchivedHistory(
+	ctx context.Context,
+	historyArchiver archiver.HistoryArchiver,
+	archivalURI archiver.URI,
+	request *archiver.GetHistoryRequest,
+	expected []*eventpb.HistoryEvent,
+) (*archivalVerificationResult, error) {
+	var archived []*eventpb.HistoryEvent
+	for {
+		resp, err := historyArchiver.Get(ctx, archivalURI, request)
+		if err != nil {
+			return nil, err
+		}
+		for _, batch := range resp.HistoryBatches {
+			archived = append(archived, batch.GetEvents()...)
+		}
+		if len(resp.NextPageToken) == 0 {
+			break
+		}
+		request.NextPageToken = resp.NextPageToken
+	}
+
+	expectedBlobs, expectedHash, err := encodeHistoryEvents(expected)
+	if err != nil {
+		return nil, err
+	}
+	archivedBlobs, archivedHash, err := encodeHistoryEvents(archived)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &archivalVerificationResult{
+		ExpectedEventCount: len(expected),
+		ArchivedEventCount: len(archived),
+		ExpectedHash:       expectedHash,
+		ArchivedHash:       archivedHash,
+	}
+	if len(expected) != len(archived) {
+		result.Discrepancies = append(result.Discrepancies,
+			fmt.Sprintf("event count differs: expected %v, archived %v", len(expected), len(archived)))
+	}
+	for i := 0; i < len(expected) && i < len(archived); i++ {
+		if !bytes.Equal(expectedBlobs[i], archivedBlobs[i]) {
+			result.Discrepancies = append(result.Discrepancies,
+				fmt.Sprintf("first mismatching event: expected event %v (%v), archived event %v (%v)",
+					expected[i].GetEventId(), expected[i].GetEventType(), archived[i].GetEventId(), archived[i].GetEventType()))
+			break
+		}
+	}
+	return result, nil
+}
+
+// encodeHistoryEvents returns the encoded form of each event along with the sha256 over all of them
+func encodeHistoryEvents(events []*eventpb.HistoryEvent) ([][]byte, string, error) {
+	hash := sha256.New()
+	blobs := make([][]byte, 0, len(events))
+	for _, event := range events {
+		blob, err := event.Marshal()
+		if err != nil {
+			return nil, "", err
+		}
+		hash.Write(blob)
+		blobs = append(blobs, blob)
+	}
+	return blobs, hex.EncodeToString(hash.Sum(nil)), nil
+}
@@ -0,0 +1,363 @@
+// The MIT License (MIT)
+//
+// Copyright (c) 2020 Temporal Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package cli
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/m3db/prometheus_common/expfmt"
+	"github.com/stretchr/testify/require"
+	eventpb "go.temporal.io/temporal-proto/event"
+	tasklistpb "go.temporal.io/temporal-proto/tasklist"
+	"google.golang.org/grpc"
+
+	"github.com/temporalio/temporal/.gen/proto/adminservice"
+	"github.com/temporalio/temporal/.gen/proto/persistenceblobs"
+	"github.com/temporalio/temporal/common"
+	"github.com/temporalio/temporal/common/archiver"
+	"github.com/temporalio/temporal/common/persistence"
+	"github.com/temporalio/temporal/common/persistence/serialization"
+	"github.com/temporalio/temporal/common/primitives"
+)
+
+func TestBuildVersionHistoryBranchTree(t *testing.T) {
+	treeID := primitives.MustParseUUID("11111111-1111-1111-1111-111111111111")
+	rootBranchID := primitives.MustParseUUID("22222222-2222-2222-2222-222222222222")
+	forkBranchID := primitives.MustParseUUID("33333333-3333-3333-3333-333333333333")
+
+	rootToken, err := serialization.HistoryBranchToBlob(&persistenceblobs.HistoryBranch{
+		TreeId:   treeID,
+		BranchId: rootBranchID,
+	})
+	require.NoError(t, err)
+	forkToken, err := serialization.HistoryBranchToBlob(&persistenceblobs.HistoryBranch{
+		TreeId:   treeID,
+		BranchId: forkBranchID,
+		Ancestors: []*persistenceblobs.HistoryBranchRange{
+			{BranchId: rootBranchID, BeginNodeId: 1, EndNodeId: 6},
+		},
+	})
+	require.NoError(t, err)
+
+	versionHistories := persistence.NewVersionHistories(persistence.NewVersionHistory(
+		rootToken.Data,
+		[]*persistence.VersionHistoryItem{
+			persistence.NewVersionHistoryItem(10, 1),
+		},
+	))
+	_, _, err = versionHistories.AddVersionHistory(persistence.NewVersionHistory(
+		forkToken.Data,
+		[]*persistence.VersionHistoryItem{
+			persistence.NewVersionHistoryItem(5, 1),
+			persistence.NewVersionHistoryItem(8, 2),
+		},
+	))
+	require.NoError(t, err)
+
+	branches, err := buildVersionHistoryBranchTree(versionHistories)
+	require.NoError(t, err)
+	require.Len(t, branches, 2)
+
+	require.False(t, branches[0].IsCurrent)
+	require.Equal(t, rootBranchID.String(), branches[0].BranchID)
+	require.Equal(t, int64(1), branches[0].BeginEventID)
+	require.Equal(t, int64(10), branches[0].EndEventID)
+	require.Empty(t, branches[0].Ancestors)
+
+	require.True(t, branches[1].IsCurrent)
+	require.Equal(t, forkBranchID.String(), branches[1].BranchID)
+	require.Equal(t, int64(6), branches[1].BeginEventID)
+	require.Equal(t, int64(8), branches[1].EndEventID)
+	require.Equal(t, []versionHistoryBranchAncestor{
+		{BranchID: rootBranchID.String(), BeginEventID: 1, EndEventID: 6},
+	}, branches[1].Ancestors)
+
+	output := formatVersionHistoryBranchTree(branches)
+	require.Contains(t, output, "  [0] tree: "+treeID.String()+", branch: "+rootBranchID.String()+", events: [1, 10]")
+	require.Contains(t, output, "* [1] tree: "+treeID.String()+", branch: "+forkBranchID.String()+", events: [6, 8]")
+	require.Contains(t, output, "      ancestor branch: "+rootBranchID.String()+", events: [1, 6)")
+}
+
+type testReverseHistoryStore struct {
+	persistence.HistoryStore
+
+	nodes map[int64]*serialization.DataBlob
+}
+
+func (s *testReverseHistoryStore) ReadHistoryBranch(
+	request *persistence.InternalReadHistoryBranchRequest,
+) (*persistence.InternalReadHistoryBranchResponse, error) {
+	resp := &persistence.InternalReadHistoryBranchResponse{}
+	for nodeID := request.MinNodeID; nodeID < request.MaxNodeID; nodeID++ {
+		if blob, ok := s.nodes[nodeID]; ok {
+			resp.History = append(resp.History, blob)
+		}
+	}
+	return resp, nil
+}
+
+func TestReadHistoryBranchReverse(t *testing.T) {
+	serializer := persistence.NewPayloadSerializer()
+	store := &testReverseHistoryStore{nodes: make(map[int64]*serialization.DataBlob)}
+	// batches of events 1-3, 4, 5-7 and 8-10, each stored under the node id of its first event
+	for _, batch := range [][2]int64{{1, 3}, {4, 4}, {5, 7}, {8, 10}} {
+		var events []*eventpb.HistoryEvent
+		for eventID := batch[0]; eventID <= batch[1]; eventID++ {
+			events = append(events, &eventpb.HistoryEvent{EventId: eventID})
+		}
+		blob, err := serializer.SerializeBatchEvents(events, common.EncodingTypeProto3)
+		require.NoError(t, err)
+		store.nodes[batch[0]] = blob
+	}
+
+	eventIDs := func(lastEventID int64, maxEvents int) []int64 {
+		events, err := readHistoryBranchReverse(store, serializer, &persistence.InternalReadHistoryBranchRequest{}, lastEventID, maxEvents)
+		require.NoError(t, err)
+		var ids []int64
+		for _, event := range events {
+			ids = append(ids, event.GetEventId())
+		}
+		return ids
+	}
+
+	require.Equal(t, []int64{10, 9, 8, 7, 6}, eventIDs(10, 5))
+	// reading back from the middle of a batch skips its newer events
+	require.Equal(t, []int64{9, 8, 7, 6, 5}, eventIDs(9, 5))
+	require.Equal(t, []int64{10, 9, 8, 7, 6, 5, 4, 3, 2, 1}, eventIDs(maxEventID, 20))
+
+	_, err := readHistoryBranchReverse(store, serializer, &persistence.InternalReadHistoryBranchRequest{}, 10, 0)
+	require.Error(t, err)
+}
+
+func TestWriteShardQueueMetrics(t *testing.T) {
+	now := time.Now()
+	queues := []*adminservice.ShardQueueAckLevel{
+		{QueueType: "transfer", AckLevel: 100, MinTaskLevel: 101, MaxTaskLevel: 180, TaskCount: 3},
+		{QueueType: "timer", AckLevel: now.Add(-time.Minute).UnixNano(), MinTaskLevel: now.Add(-time.Minute).UnixNano(), MaxTaskLevel: now.UnixNano(), TaskCount: 2},
+		{QueueType: "replication", AckLevel: 200},
+	}
+
+	var buf bytes.Buffer
+	writeShardQueueMetrics(&buf, 3, queues, now)
+
+	var parser expfmt.TextParser
+	families, err := parser.TextToMetricFamilies(&buf)
+	require.NoError(t, err)
+	require.Len(t, families, 5)
+	require.Len(t, families["temporal_shard_queue_ack_level"].GetMetric(), 3)
+	require.Len(t, families["temporal_shard_queue_pending_tasks"].GetMetric(), 3)
+	// the replication queue has no task, so it has no task levels
+	require.Len(t, families["temporal_shard_queue_min_task_level"].GetMetric(), 2)
+	require.Len(t, families["temporal_shard_queue_max_task_level"].GetMetric(), 2)
+
+	latency := families["temporal_shard_timer_queue_latency_seconds"].GetMetric()
+	require.Len(t, latency, 1)
+	require.Equal(t, time.Minute.Seconds(), latency[0].GetGauge().GetValue())
+	require.Equal(t, "shard_id", latency[0].GetLabel()[0].GetName())
+	require.Equal(t, "3", latency[0].GetLabel()[0].GetValue())
+}
+
+func TestPrintTailedHistory(t *testing.T) {
+	stream := &testTailHistoryStream{
+		responses: []*adminservice.TailWorkflowExecutionHistoryResponse{
+			{Events: []*eventpb.HistoryEvent{
+				{EventId: 4, EventType: eventpb.EventTypeDecisionTaskCompleted},
+				{EventId: 5, EventType: eventpb.EventTypeActivityTaskScheduled},
+			}},
+			{Events: []*eventpb.HistoryEvent{
+				{EventId: 6, EventType: eventpb.EventTypeWorkflowExecutionCompleted},
+			}},
+		},
+	}
+
+	var out bytes.Buffer
+	require.NoError(t, printTailedHistory(&out, stream, false, 0))
+
+	lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+	require.Len(t, lines, 3)
+	require.True(t, strings.HasPrefix(strings.TrimSpace(lines[0]), "4, "))
+	require.Contains(t, lines[0], "DecisionTaskCompleted")
+	require.True(t, strings.HasPrefix(strings.TrimSpace(lines[1]), "5, "))
+	require.Contains(t, lines[1], "ActivityTaskScheduled")
+	require.True(t, strings.HasPrefix(strings.TrimSpace(lines[2]), "6, "))
+	require.Contains(t, lines[2], "WorkflowExecutionCompleted")
+}
+
+func TestPrintOwnedTaskLists(t *testing.T) {
+	taskLists := []*adminservice.OwnedTaskList{
+		{
+			NamespaceId:      "namespace-id",
+			Namespace:        "test-namespace",
+			Name:             "normal-tl",
+			TaskListType:     int32(tasklistpb.TaskListTypeDecision),
+			Kind:             tasklistpb.TaskListKindNormal,
+			BacklogCountHint: 42,
+		},
+		{
+			NamespaceId:      "namespace-id",
+			Name:             "sticky-tl",
+			TaskListType:     int32(tasklistpb.TaskListTypeActivity),
+			Kind:             tasklistpb.TaskListKindSticky,
+			BacklogCountHint: 3,
+		},
+	}
+
+	var out bytes.Buffer
+	printOwnedTaskLists(&out, taskLists)
+
+	lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+	require.Len(t, lines, 3)
+	require.Contains(t, lines[1], "test-namespace")
+	require.Contains(t, lines[1], "normal-tl")
+	require.Contains(t, lines[1], tasklistpb.TaskListKindNormal.String())
+	require.Contains(t, lines[1], "42")
+	// namespace name falls back to the id when it could not be resolved
+	require.Contains(t, lines[2], "namespace-id")
+	require.Contains(t, lines[2], "sticky-tl")
+	require.Contains(t, lines[2], tasklistpb.TaskListKindSticky.String())
+	require.Contains(t, lines[2], "3")
+}
+
+// testTailHistoryStream replays the given responses and then ends the stream
+type testTailHistoryStream struct {
+	grpc.ClientStream
+	responses []*adminservice.TailWorkflowExecutionHistoryResponse
+}
+
+func (s *testTailHistoryStream) Recv() (*adminservice.TailWorkflowExecutionHistoryResponse, error) {
+	if len(s.responses) == 0 {
+		return nil, io.EOF
+	}
+	resp := s.responses[0]
+	s.responses = s.responses[1:]
+	return resp, nil
+}
+
+func TestCheckDeleteWorkflowAllowed(t *testing.T) {
+	testCases := []struct {
+		name    string
+		state   int
+		force   bool
+		allowed bool
+	}{
+		{"open without force", persistence.WorkflowStateRunning, false, false},
+		{"created without force", persistence.WorkflowStateCreated, false, false},
+		{"open with force", persistence.WorkflowStateRunning, true, true},
+		{"closed", persistence.WorkflowStateCompleted, false, true},
+		{"closed with force", persistence.WorkflowStateCompleted, true, true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := checkDeleteWorkflowAllowed(&persistence.WorkflowExecutionInfo{
+				WorkflowID: "some random workflow ID",
+				State:      tc.state,
+			}, tc.force)
+			if tc.allowed {
+				require.NoError(t, err)
+			} else {
+				require.Error(t, err)
+				require.Contains(t, err.Error(), "--force")
+			}
+		})
+	}
+}
+
+// testHistoryArchiver serves the given events as archived history, in pages of pageSize events
+type testHistoryArchiver struct {
+	archiver.HistoryArchiver
+
+	events   []*eventpb.HistoryEvent
+	pageSize int
+}
+
+func (a *testHistoryArchiver) Get(
+	_ context.Context,
+	_ archiver.URI,
+	request *archiver.GetHistoryRequest,
+) (*archiver.GetHistoryResponse, error) {
+	start := 0
+	if len(request.NextPageToken) > 0 {
+		start = int(request.NextPageToken[0])
+	}
+	end := start + a.pageSize
+	resp := &archiver.GetHistoryResponse{}
+	if end < len(a.events) {
+		resp.NextPageToken = []byte{byte(end)}
+	} else {
+		end = len(a.events)
+	}
+	resp.HistoryBatches = []*eventpb.History{{Events: a.events[start:end]}}
+	return resp, nil
+}
+
+func TestVerifyArchivedHistory(t *testing.T) {
+	archivalURI, err := archiver.NewURI("file:///tmp/temporal_archival/development")
+	require.NoError(t, err)
+	newEvents := func() []*eventpb.HistoryEvent {
+		return []*eventpb.HistoryEvent{
+			{EventId: 1, Version: 1, EventType: eventpb.EventTypeWorkflowExecutionStarted},
+			{EventId: 2, Version: 1, EventType: eventpb.EventTypeDecisionTaskScheduled},
+			{EventId: 3, Version: 1, EventType: eventpb.EventTypeDecisionTaskStarted},
+			{EventId: 4, Version: 1, EventType: eventpb.EventTypeDecisionTaskCompleted},
+			{EventId: 5, Version: 1, EventType: eventpb.EventTypeWorkflowExecutionCompleted},
+		}
+	}
+	verify := func(archived []*eventpb.HistoryEvent) *archivalVerificationResult {
+		result, err := verifyArchivedHistory(
+			context.Background(),
+			&testHistoryArchiver{events: archived, pageSize: 2},
+			archivalURI,
+			&archiver.GetHistoryRequest{NamespaceID: "namespace-id", WorkflowID: "workflow-id", RunID: "run-id"},
+			newEvents(),
+		)
+		require.NoError(t, err)
+		return result
+	}
+
+	result := verify(newEvents())
+	require.True(t, result.Match())
+	require.Equal(t, 5, result.ExpectedEventCount)
+	require.Equal(t, 5, result.ArchivedEventCount)
+	require.Equal(t, result.ExpectedHash, result.ArchivedHash)
+
+	corrupted := newEvents()
+	corrupted[3].Version = 2
+	result = verify(corrupted)
+	require.False(t, result.Match())
+	require.Equal(t, 5, result.ArchivedEventCount)
+	require.NotEqual(t, result.ExpectedHash, result.ArchivedHash)
+	require.Len(t, result.Discrepancies, 1)
+	require.Contains(t, result.Discrepancies[0], "expected event 4")
+
+	result = verify(newEvents()[:4])
+	require.False(t, result.Match())
+	require.Equal(t, 4, result.ArchivedEventCount)
+	require.Len(t, result.Discrepancies, 1)
+	require.Contains(t, result.Discrepancies[0], "event count differs")
+}
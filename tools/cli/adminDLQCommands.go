@@ -22,9 +22,14 @@ package cli
 
 import (
 	"bufio"
+	"context"
+	"encoding/json"
 	"fmt"
+	"io/ioutil"
 	"os"
+	"path/filepath"
 
+	"github.com/gogo/protobuf/proto"
 	"github.com/urfave/cli"
 
 	"github.com/temporalio/temporal/.gen/proto/adminservice"
@@ -36,9 +41,19 @@ import (
 )
 
 const (
-	defaultPageSize = 1000
+	defaultPageSize           = 1000
+	defaultDLQDiffMaxMessages = 10000
 )
 
+var defaultDLQPurgeStateFile = filepath.Join(os.TempDir(), "tctl_dlq_purge_state.json")
+
+type dlqDiffResult struct {
+	LocalMessageCount  int
+	RemoteMessageCount int
+	OnlyInLocal        []int64
+	OnlyInRemote       []int64
+}
+
 // AdminGetDLQMessages gets DLQ metadata
 func AdminGetDLQMessages(c *cli.Context) {
 	ctx, cancel := newContext(c)
@@ -58,24 +73,11 @@ func AdminGetDLQMessages(c *cli.Context) {
 		lastMessageID = c.Int64(FlagLastMessageID)
 	}
 
-	paginationFunc := func(paginationToken []byte) ([]interface{}, []byte, error) {
-		resp, err := adminClient.ReadDLQMessages(ctx, &adminservice.ReadDLQMessagesRequest{
-			Type:                  toQueueType(dlqType),
-			InclusiveEndMessageId: lastMessageID,
-			MaximumPageSize:       defaultPageSize,
-			NextPageToken:         paginationToken,
-		})
-		if err != nil {
-			return nil, nil, err
-		}
-		var paginateItems []interface{}
-		for _, item := range resp.GetReplicationTasks() {
-			paginateItems = append(paginateItems, item)
-		}
-		return paginateItems, resp.GetNextPageToken(), err
-	}
-
-	iterator := collection.NewPagingIterator(paginationFunc)
+	iterator := newDLQMessageIterator(ctx, adminClient, &adminservice.ReadDLQMessagesRequest{
+		Type:                  toQueueType(dlqType),
+		InclusiveEndMessageId: lastMessageID,
+		NamespaceId:           c.String(FlagNamespaceID),
+	})
 	var lastReadMessageID int
 	for iterator.HasNext() && remainingMessageCount > 0 {
 		item, err := iterator.Next()
@@ -83,14 +85,21 @@ func AdminGetDLQMessages(c *cli.Context) {
 			ErrorAndExit(fmt.Sprintf("fail to read dlq message. Last read message id: %v", lastReadMessageID), err)
 		}
 
-		task := item.(*replicationgenpb.ReplicationTask)
+		var task proto.Message
+		switch t := item.(type) {
+		case *replicationgenpb.ReplicationTask:
+			task = t
+			lastReadMessageID = int(t.GetSourceTaskId())
+		case *adminservice.DLQDecisionTask:
+			task = t
+			lastReadMessageID = int(t.GetTaskId())
+		}
 		encoder := codec.NewJSONPBIndentEncoder(" ")
 		taskStr, err := encoder.Encode(task)
 		if err != nil {
 			ErrorAndExit(fmt.Sprintf("fail to encode dlq message. Last read message id: %v", lastReadMessageID), err)
 		}
 
-		lastReadMessageID = int(task.SourceTaskId)
 		remainingMessageCount--
 		_, err = outputFile.WriteString(fmt.Sprintf("%v\n", string(taskStr)))
 		if err != nil {
@@ -104,25 +113,131 @@ func AdminPurgeDLQMessages(c *cli.Context) {
 	ctx, cancel := newContext(c)
 	defer cancel()
 
-	dlqType := getRequiredOption(c, FlagDLQType)
+	stateFile := c.String(FlagStateFile)
+	chunkSize := c.Int(FlagChunkSize)
+	if chunkSize <= 0 {
+		ErrorAndExit(fmt.Sprintf("%v must be positive", FlagChunkSize), nil)
+	}
 
-	var lastMessageID int64
-	if c.IsSet(FlagLastMessageID) {
-		lastMessageID = c.Int64(FlagLastMessageID)
+	var state *dlqPurgeState
+	if c.Bool(FlagResume) {
+		var err error
+		if state, err = loadDLQPurgeState(stateFile); err != nil {
+			ErrorAndExit("Failed to load dlq purge state", err)
+		}
+		fmt.Printf("Resuming purge after message id %v.\n", state.LastPurgedMessageID)
 	} else {
-		confirmOrExit("Are you sure to purge all DLQ messages without a upper boundary?")
+		state = &dlqPurgeState{
+			DLQType:     getRequiredOption(c, FlagDLQType),
+			ShardID:     int32(c.Int(FlagShardID)),
+			NamespaceID: c.String(FlagNamespaceID),
+		}
+		if c.IsSet(FlagLastMessageID) {
+			state.LastMessageID = c.Int64(FlagLastMessageID)
+		} else {
+			confirmOrExit("Are you sure to purge all DLQ messages without a upper boundary?")
+		}
 	}
 
 	adminClient := cFactory.AdminClient(c)
-	if _, err := adminClient.PurgeDLQMessages(ctx, &adminservice.PurgeDLQMessagesRequest{
-		Type:                  toQueueType(dlqType),
-		InclusiveEndMessageId: lastMessageID,
-	}); err != nil {
-		ErrorAndExit("Failed to purge dlq", nil)
+	progressFn := func(state *dlqPurgeState, purgedCount int) {
+		fmt.Printf("Purged %v messages, last purged message id: %v.\n", purgedCount, state.LastPurgedMessageID)
+	}
+	if err := purgeDLQMessagesInChunks(ctx, adminClient, state, chunkSize, stateFile, progressFn); err != nil {
+		ErrorAndExit(fmt.Sprintf("Failed to purge dlq, rerun with --%v to continue", FlagResume), err)
 	}
 	fmt.Println("Successfully purge DLQ Messages.")
 }
 
+type dlqPurgeState struct {
+	DLQType             string
+	ShardID             int32
+	NamespaceID         string
+	LastMessageID       int64
+	LastPurgedMessageID int64
+}
+
+// purgeDLQMessagesInChunks purges the DLQ one chunk at a time, persisting the
+// state to stateFile after each chunk so that an interrupted purge can be resumed
+func purgeDLQMessagesInChunks(
+	ctx context.Context,
+	adminClient adminservice.AdminServiceClient,
+	state *dlqPurgeState,
+	chunkSize int,
+	stateFile string,
+	progressFn func(state *dlqPurgeState, purgedCount int),
+) error {
+
+	// a purge filtered by namespace leaves the messages of other namespaces and the
+	// ack level in place, so it has to page past them instead of rereading from the start
+	var pageToken []byte
+	for {
+		resp, err := adminClient.ReadDLQMessages(ctx, &adminservice.ReadDLQMessagesRequest{
+			Type:                  toQueueType(state.DLQType),
+			ShardId:               state.ShardID,
+			InclusiveEndMessageId: state.LastMessageID,
+			MaximumPageSize:       int32(chunkSize),
+			NextPageToken:         pageToken,
+			NamespaceId:           state.NamespaceID,
+		})
+		if err != nil {
+			return err
+		}
+		tasks := resp.GetReplicationTasks()
+		if len(tasks) == 0 {
+			if state.NamespaceID != "" && len(resp.GetNextPageToken()) > 0 {
+				pageToken = resp.GetNextPageToken()
+				continue
+			}
+			break
+		}
+
+		chunkEndMessageID := tasks[len(tasks)-1].GetSourceTaskId()
+		if _, err := adminClient.PurgeDLQMessages(ctx, &adminservice.PurgeDLQMessagesRequest{
+			Type:                  toQueueType(state.DLQType),
+			ShardId:               state.ShardID,
+			InclusiveEndMessageId: chunkEndMessageID,
+			NamespaceId:           state.NamespaceID,
+		}); err != nil {
+			return err
+		}
+
+		state.LastPurgedMessageID = chunkEndMessageID
+		if err := saveDLQPurgeState(stateFile, state); err != nil {
+			return err
+		}
+		progressFn(state, len(tasks))
+
+		if len(resp.GetNextPageToken()) == 0 {
+			break
+		}
+		if state.NamespaceID != "" {
+			pageToken = resp.GetNextPageToken()
+		}
+	}
+	return os.Remove(stateFile)
+}
+
+func loadDLQPurgeState(stateFile string) (*dlqPurgeState, error) {
+	data, err := ioutil.ReadFile(stateFile)
+	if err != nil {
+		return nil, err
+	}
+	state := &dlqPurgeState{}
+	if err := json.Unmarshal(data, state); err != nil {
+		return nil, err
+	}
+	return state, nil
+}
+
+func saveDLQPurgeState(stateFile string, state *dlqPurgeState) error {
+	data, err := json.Marshal(state)
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(stateFile, data, 0644)
+}
+
 // AdminMergeDLQMessages merges message from DLQ
 func AdminMergeDLQMessages(c *cli.Context) {
 	ctx, cancel := newContext(c)
@@ -142,6 +257,7 @@ func AdminMergeDLQMessages(c *cli.Context) {
 		Type:                  toQueueType(dlqType),
 		InclusiveEndMessageId: lastMessageID,
 		MaximumPageSize:       defaultPageSize,
+		NamespaceId:           c.String(FlagNamespaceID),
 	}
 
 	var response *adminservice.MergeDLQMessagesResponse
@@ -158,12 +274,178 @@ func AdminMergeDLQMessages(c *cli.Context) {
 	fmt.Println("Successfully merged all messages.")
 }
 
+// AdminMergeOneDLQMessage merges a single message from DLQ
+func AdminMergeOneDLQMessage(c *cli.Context) {
+	ctx, cancel := newContext(c)
+	defer cancel()
+
+	dlqType := getRequiredOption(c, FlagDLQType)
+	messageID := getRequiredInt64Option(c, FlagMessageID)
+
+	adminClient := cFactory.AdminClient(c)
+	if _, err := adminClient.MergeOneDLQMessage(ctx, &adminservice.MergeOneDLQMessageRequest{
+		Type:      toQueueType(dlqType),
+		MessageId: messageID,
+	}); err != nil {
+		ErrorAndExit("Failed to merge DLQ message", err)
+	}
+	fmt.Printf("Successfully merged DLQ message %v.\n", messageID)
+}
+
+// AdminDiffDLQMessages compares the DLQ messages of the current cluster with the ones of a remote cluster
+func AdminDiffDLQMessages(c *cli.Context) {
+	ctx, cancel := newContext(c)
+	defer cancel()
+
+	dlqType := getRequiredOption(c, FlagDLQType)
+	remoteAddress := getRequiredOption(c, FlagRemoteAddress)
+	maxMessages := c.Int(FlagMaxMessages)
+	if maxMessages <= 0 {
+		ErrorAndExit(fmt.Sprintf("%v must be positive", FlagMaxMessages), nil)
+	}
+	var lastMessageID int64
+	if c.IsSet(FlagLastMessageID) {
+		lastMessageID = c.Int64(FlagLastMessageID)
+	}
+	request := &adminservice.ReadDLQMessagesRequest{
+		Type:                  toQueueType(dlqType),
+		ShardId:               int32(c.Int(FlagShardID)),
+		InclusiveEndMessageId: lastMessageID,
+	}
+
+	localTaskIDs, err := readDLQTaskIDs(ctx, cFactory.AdminClient(c), request, maxMessages)
+	if err != nil {
+		ErrorAndExit("fail to read local dlq messages.", err)
+	}
+	remoteTaskIDs, err := readDLQTaskIDs(ctx, cFactory.RemoteAdminClient(remoteAddress), request, maxMessages)
+	if err != nil {
+		ErrorAndExit("fail to read remote dlq messages.", err)
+	}
+
+	result := diffDLQTaskIDs(localTaskIDs, remoteTaskIDs)
+	switch format := c.String(FlagFormat); format {
+	case formatJSON:
+		prettyPrintJSONObject(result)
+	case formatText:
+		fmt.Printf("Local DLQ messages: %v, remote DLQ messages: %v\n", result.LocalMessageCount, result.RemoteMessageCount)
+		fmt.Printf("Only in local: %v\n", result.OnlyInLocal)
+		fmt.Printf("Only in remote: %v\n", result.OnlyInRemote)
+	default:
+		ErrorAndExit(fmt.Sprintf("unknown format: %v", format), nil)
+	}
+
+	if len(result.OnlyInLocal) > 0 || len(result.OnlyInRemote) > 0 {
+		osExit(1)
+	}
+}
+
+// AdminSkipReplicationTask puts a replication task into DLQ instead of applying it
+func AdminSkipReplicationTask(c *cli.Context) {
+	ctx, cancel := newContext(c)
+	defer cancel()
+
+	shardID := getRequiredIntOption(c, FlagShardID)
+	sourceCluster := getRequiredOption(c, FlagCluster)
+	taskID := getRequiredInt64Option(c, FlagRemoveTaskID)
+	reason := getRequiredOption(c, FlagReason)
+
+	adminClient := cFactory.AdminClient(c)
+	if _, err := adminClient.DLQReplicationTask(ctx, &adminservice.DLQReplicationTaskRequest{
+		ShardId:       int32(shardID),
+		SourceCluster: sourceCluster,
+		TaskId:        taskID,
+		Reason:        reason,
+	}); err != nil {
+		ErrorAndExit("Failed to put replication task into DLQ", err)
+	}
+	fmt.Printf("Replication task %v from cluster %v on shard %v is put into DLQ.\n", taskID, sourceCluster, shardID)
+}
+
+func newDLQMessageIterator(
+	ctx context.Context,
+	adminClient adminservice.AdminServiceClient,
+	request *adminservice.ReadDLQMessagesRequest,
+) collection.Iterator {
+
+	paginationFunc := func(paginationToken []byte) ([]interface{}, []byte, error) {
+		resp, err := adminClient.ReadDLQMessages(ctx, &adminservice.ReadDLQMessagesRequest{
+			Type:                  request.GetType(),
+			ShardId:               request.GetShardId(),
+			SourceCluster:         request.GetSourceCluster(),
+			InclusiveEndMessageId: request.GetInclusiveEndMessageId(),
+			MaximumPageSize:       defaultPageSize,
+			NextPageToken:         paginationToken,
+			NamespaceId:           request.GetNamespaceId(),
+		})
+		if err != nil {
+			return nil, nil, err
+		}
+		var paginateItems []interface{}
+		for _, item := range resp.GetReplicationTasks() {
+			paginateItems = append(paginateItems, item)
+		}
+		for _, item := range resp.GetDecisionTasks() {
+			paginateItems = append(paginateItems, item)
+		}
+		return paginateItems, resp.GetNextPageToken(), err
+	}
+	return collection.NewPagingIterator(paginationFunc)
+}
+
+func readDLQTaskIDs(
+	ctx context.Context,
+	adminClient adminservice.AdminServiceClient,
+	request *adminservice.ReadDLQMessagesRequest,
+	maxMessages int,
+) ([]int64, error) {
+
+	iterator := newDLQMessageIterator(ctx, adminClient, request)
+	var taskIDs []int64
+	for iterator.HasNext() && len(taskIDs) < maxMessages {
+		item, err := iterator.Next()
+		if err != nil {
+			return nil, err
+		}
+		taskIDs = append(taskIDs, item.(*replicationgenpb.ReplicationTask).GetSourceTaskId())
+	}
+	return taskIDs, nil
+}
+
+func diffDLQTaskIDs(localTaskIDs []int64, remoteTaskIDs []int64) *dlqDiffResult {
+	result := &dlqDiffResult{
+		LocalMessageCount:  len(localTaskIDs),
+		RemoteMessageCount: len(remoteTaskIDs),
+		OnlyInLocal:        []int64{},
+		OnlyInRemote:       []int64{},
+	}
+
+	localSet := make(map[int64]struct{}, len(localTaskIDs))
+	for _, taskID := range localTaskIDs {
+		localSet[taskID] = struct{}{}
+	}
+	remoteSet := make(map[int64]struct{}, len(remoteTaskIDs))
+	for _, taskID := range remoteTaskIDs {
+		remoteSet[taskID] = struct{}{}
+		if _, ok := localSet[taskID]; !ok {
+			result.OnlyInRemote = append(result.OnlyInRemote, taskID)
+		}
+	}
+	for _, taskID := range localTaskIDs {
+		if _, ok := remoteSet[taskID]; !ok {
+			result.OnlyInLocal = append(result.OnlyInLocal, taskID)
+		}
+	}
+	return result
+}
+
 func toQueueType(dlqType string) commongenpb.DLQType {
 	switch dlqType {
 	case "namespace":
 		return commongenpb.DLQTypeNamespace
 	case "history":
 		return commongenpb.DLQTypeReplication
+	case "decision":
+		return commongenpb.DLQTypeDecisionTask
 	default:
 		ErrorAndExit("The queue type is not supported.", fmt.Errorf("the queue type is not supported. Type: %v", dlqType))
 	}
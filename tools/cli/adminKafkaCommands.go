@@ -29,11 +29,13 @@ import (
 	"io"
 	"io/ioutil"
 	"os"
+	"os/signal"
 	"regexp"
 	"runtime"
 	"strconv"
 	"strings"
 	"sync/atomic"
+	"syscall"
 	"time"
 
 	"github.com/Shopify/sarama"
@@ -75,6 +77,8 @@ const (
 	malformedMessage           = "Input was malformed"
 	chanBufferSize             = 10000
 	maxRereplicateEventID      = 999999
+
+	rereplicateProgressInterval = 30 * time.Second
 )
 
 var (
@@ -479,7 +483,72 @@ type ClustersConfig struct {
 	TLS      auth.TLS
 }
 
-func doRereplicate(shardID int, namespaceID, wid, rid string, minID, maxID int64, targets []string, producer messaging.Producer, session *gocql.Session) {
+// rereplicateCheckpoint records the last event re-replicated for a single workflow so an interrupted run can resume
+type rereplicateCheckpoint struct {
+	path        string
+	RunID       string
+	LastEventID int64
+}
+
+// loadRereplicateCheckpoint reads the checkpoint file, a missing or empty file means there is nothing to resume
+func loadRereplicateCheckpoint(path string) (*rereplicateCheckpoint, error) {
+	checkpoint := &rereplicateCheckpoint{path: path}
+	// This code is executed from the CLI. All user input is from a CLI user.
+	// #nosec
+	data, err := ioutil.ReadFile(path)
+	if os.IsNotExist(err) {
+		return checkpoint, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	line := strings.TrimSpace(string(data))
+	if len(line) == 0 {
+		return checkpoint, nil
+	}
+	cols := strings.Split(line, ",")
+	if len(cols) != 2 {
+		return nil, fmt.Errorf("malformed checkpoint %q, expected runId,lastEventId", line)
+	}
+	lastEventID, err := strconv.ParseInt(strings.TrimSpace(cols[1]), 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("malformed checkpoint %q: %v", line, err)
+	}
+	checkpoint.RunID = strings.TrimSpace(cols[0])
+	checkpoint.LastEventID = lastEventID
+	return checkpoint, nil
+}
+
+func (c *rereplicateCheckpoint) update(runID string, lastEventID int64) {
+	if c == nil {
+		return
+	}
+	c.RunID = runID
+	c.LastEventID = lastEventID
+}
+
+// flush writes the checkpoint through a temp file so a crash never leaves a partially written checkpoint
+func (c *rereplicateCheckpoint) flush() error {
+	if c == nil || len(c.RunID) == 0 {
+		return nil
+	}
+	tmpPath := c.path + ".tmp"
+	if err := ioutil.WriteFile(tmpPath, []byte(fmt.Sprintf("%v,%v\n", c.RunID, c.LastEventID)), 0600); err != nil {
+		return err
+	}
+	return os.Rename(tmpPath, c.path)
+}
+
+func doRereplicate(
+	shardID int,
+	namespaceID, wid, rid string,
+	minID, maxID int64,
+	targets []string,
+	producer messaging.Producer,
+	session *gocql.Session,
+	checkpoint *rereplicateCheckpoint,
+	stopC <-chan struct{},
+) {
 	if minID <= 0 {
 		minID = 1
 	}
@@ -487,12 +556,22 @@ func doRereplicate(shardID int, namespaceID, wid, rid string, minID, maxID int64
 		maxID = maxRereplicateEventID
 	}
 
+	// errors exit the process, so the checkpoint has to be persisted first to be able to resume
+	exitWithCheckpoint := func(msg string, err error) {
+		if flushErr := checkpoint.flush(); flushErr != nil {
+			fmt.Printf("failed to flush checkpoint: %v \n", flushErr)
+		}
+		ErrorAndExit(msg, err)
+	}
+
 	histV2 := cassandra.NewHistoryV2PersistenceFromSession(session, loggerimpl.NewNopLogger())
 	historyV2Mgr := persistence.NewHistoryV2ManagerImpl(histV2, loggerimpl.NewNopLogger(), dynamicconfig.GetIntPropertyFn(common.DefaultTransactionSizeLimit))
 
 	exeM, _ := cassandra.NewWorkflowExecutionPersistence(shardID, session, loggerimpl.NewNopLogger())
 	exeMgr := persistence.NewExecutionManagerImpl(exeM, loggerimpl.NewNopLogger())
 
+	lastProgress := time.Now()
+	publishedEvents := int64(0)
 	for {
 		fmt.Printf("Start rereplicate for wid: %v, rid:%v \n", wid, rid)
 		resp, err := exeMgr.GetWorkflowExecution(&persistence.GetWorkflowExecutionRequest{
@@ -503,7 +582,14 @@ func doRereplicate(shardID int, namespaceID, wid, rid string, minID, maxID int64
 			},
 		})
 		if err != nil {
-			ErrorAndExit("GetWorkflowExecution error", err)
+			exitWithCheckpoint("GetWorkflowExecution error", err)
+		}
+
+		exeInfo := resp.State.ExecutionInfo
+		if minID >= exeInfo.NextEventID || minID >= maxID {
+			// only reachable when resuming from a checkpoint of a run which was already fully re-replicated
+			fmt.Printf("Nothing left to rereplicate for wid: %v, rid:%v \n", wid, rid)
+			break
 		}
 
 		currVersion := resp.State.ReplicationState.CurrentVersion
@@ -514,7 +600,6 @@ func doRereplicate(shardID int, namespaceID, wid, rid string, minID, maxID int64
 			},
 		}
 
-		exeInfo := resp.State.ExecutionInfo
 		taskTemplate := &persistenceblobs.ReplicationTaskInfo{
 			NamespaceId:         primitives.MustParseUUID(namespaceID),
 			WorkflowId:          wid,
@@ -528,12 +613,21 @@ func doRereplicate(shardID int, namespaceID, wid, rid string, minID, maxID int64
 			minID, maxID, exeInfo.BranchToken, common.IntPtr(shardID))
 
 		if err != nil {
-			ErrorAndExit("GetAllHistory error", err)
+			exitWithCheckpoint("GetAllHistory error", err)
 		}
 
 		continueAsNew := false
 		var newRunID string
 		for _, batch := range historyBatches {
+			select {
+			case <-stopC:
+				if err := checkpoint.flush(); err != nil {
+					ErrorAndExit("Flush checkpoint failed", err)
+				}
+				fmt.Printf("Interrupted, rereplicated through eventId %v of rid: %v \n", checkpoint.LastEventID, checkpoint.RunID)
+				return
+			default:
+			}
 
 			events := batch.Events
 			firstEvent := events[0]
@@ -549,7 +643,7 @@ func doRereplicate(shardID int, namespaceID, wid, rid string, minID, maxID int64
 					},
 				})
 				if err != nil {
-					ErrorAndExit("GetWorkflowExecution error", err)
+					exitWithCheckpoint("GetWorkflowExecution error", err)
 				}
 				taskTemplate.NewRunBranchToken = resp.State.ExecutionInfo.BranchToken
 			}
@@ -558,13 +652,23 @@ func doRereplicate(shardID int, namespaceID, wid, rid string, minID, maxID int64
 			taskTemplate.NextEventId = lastEvent.GetEventId() + 1
 			task, _, err := history.GenerateReplicationTask(targets, taskTemplate, historyV2Mgr, nil, batch, common.IntPtr(shardID))
 			if err != nil {
-				ErrorAndExit("GenerateReplicationTask error", err)
+				exitWithCheckpoint("GenerateReplicationTask error", err)
 			}
 			err = producer.Publish(task)
 			if err != nil {
-				ErrorAndExit("Publish task error", err)
+				exitWithCheckpoint("Publish task error", err)
 			}
 			fmt.Printf("publish task successfully firstEventId %v, lastEventId %v \n", firstEvent.GetEventId(), lastEvent.GetEventId())
+
+			checkpoint.update(rid, lastEvent.GetEventId())
+			publishedEvents += int64(len(events))
+			if time.Since(lastProgress) >= rereplicateProgressInterval {
+				lastProgress = time.Now()
+				fmt.Printf("Progress: published %v events, current rid: %v, lastEventId: %v \n", publishedEvents, rid, lastEvent.GetEventId())
+				if err := checkpoint.flush(); err != nil {
+					ErrorAndExit("Flush checkpoint failed", err)
+				}
+			}
 		}
 
 		fmt.Printf("Done rereplicate for wid: %v, rid:%v \n", wid, rid)
@@ -573,10 +677,14 @@ func doRereplicate(shardID int, namespaceID, wid, rid string, minID, maxID int64
 			rid = newRunID
 			minID = 1
 			maxID = maxRereplicateEventID
+			checkpoint.update(rid, 0)
 		} else {
 			break
 		}
 	}
+	if err := checkpoint.flush(); err != nil {
+		ErrorAndExit("Flush checkpoint failed", err)
+	}
 }
 
 // AdminRereplicate parses will re-publish replication tasks to topic
@@ -637,7 +745,7 @@ func AdminRereplicate(c *cli.Context) {
 			}
 
 			shardID := common.WorkflowIDToHistoryShard(wid, numberOfShards)
-			doRereplicate(shardID, namespaceID, wid, rid, minID, maxID, targets, producer, session)
+			doRereplicate(shardID, namespaceID, wid, rid, minID, maxID, targets, producer, session, nil, nil)
 			fmt.Printf("Done processing line %v ...\n", idx)
 		}
 		if err := scanner.Err(); err != nil {
@@ -650,8 +758,37 @@ func AdminRereplicate(c *cli.Context) {
 		minID := c.Int64(FlagMinEventID)
 		maxID := c.Int64(FlagMaxEventID)
 
+		var checkpoint *rereplicateCheckpoint
+		var stopC chan struct{}
+		if c.IsSet(FlagCheckpointFile) {
+			var err error
+			checkpoint, err = loadRereplicateCheckpoint(c.String(FlagCheckpointFile))
+			if err != nil {
+				ErrorAndExit("Load checkpoint failed", err)
+			}
+			if len(checkpoint.RunID) > 0 {
+				fmt.Printf("Resume rereplicate from checkpoint rid: %v, lastEventId: %v \n", checkpoint.RunID, checkpoint.LastEventID)
+				if checkpoint.RunID != rid {
+					// the checkpoint is in a continued-as-new run, which is always re-replicated in full
+					maxID = 0
+				}
+				rid = checkpoint.RunID
+				minID = checkpoint.LastEventID + 1
+			}
+
+			// stop between batches on interrupt so that the checkpoint can be flushed
+			stopC = make(chan struct{})
+			sigC := make(chan os.Signal, 1)
+			signal.Notify(sigC, os.Interrupt, syscall.SIGTERM)
+			defer signal.Stop(sigC)
+			go func() {
+				<-sigC
+				close(stopC)
+			}()
+		}
+
 		shardID := common.WorkflowIDToHistoryShard(wid, numberOfShards)
-		doRereplicate(shardID, namespaceID, wid, rid, minID, maxID, targets, producer, session)
+		doRereplicate(shardID, namespaceID, wid, rid, minID, maxID, targets, producer, session, checkpoint, stopC)
 	}
 }
 
@@ -679,7 +816,7 @@ func newKafkaProducer(c *cli.Context) messaging.Producer {
 	}
 	logger := loggerimpl.NewNopLogger()
 
-	producer := messaging.NewKafkaProducer(destTopic, sproducer, logger)
+	producer := messaging.NewKafkaProducer(destTopic, sproducer, messaging.CompressionConfig{}, nil, nil, nil, logger)
 	return producer
 }
 
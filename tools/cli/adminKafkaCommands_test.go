@@ -0,0 +1,78 @@
+// The MIT License (MIT)
+//
+// Copyright (c) 2020 Temporal Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package cli
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRereplicateCheckpoint_RoundTrip(t *testing.T) {
+	dir, err := ioutil.TempDir("", "rereplicate")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "checkpoint")
+
+	checkpoint, err := loadRereplicateCheckpoint(path)
+	require.NoError(t, err)
+	require.Empty(t, checkpoint.RunID)
+	require.Equal(t, int64(0), checkpoint.LastEventID)
+
+	// nothing is written until an event has been re-replicated
+	require.NoError(t, checkpoint.flush())
+	_, err = os.Stat(path)
+	require.True(t, os.IsNotExist(err))
+
+	checkpoint.update("run-1", 1234)
+	require.NoError(t, checkpoint.flush())
+
+	loaded, err := loadRereplicateCheckpoint(path)
+	require.NoError(t, err)
+	require.Equal(t, "run-1", loaded.RunID)
+	require.Equal(t, int64(1234), loaded.LastEventID)
+}
+
+func TestRereplicateCheckpoint_Malformed(t *testing.T) {
+	dir, err := ioutil.TempDir("", "rereplicate")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "checkpoint")
+
+	require.NoError(t, ioutil.WriteFile(path, []byte("run-1"), 0600))
+	_, err = loadRereplicateCheckpoint(path)
+	require.Error(t, err)
+
+	require.NoError(t, ioutil.WriteFile(path, []byte("run-1,abc"), 0600))
+	_, err = loadRereplicateCheckpoint(path)
+	require.Error(t, err)
+}
+
+func TestRereplicateCheckpoint_Nil(t *testing.T) {
+	var checkpoint *rereplicateCheckpoint
+	checkpoint.update("run-1", 10)
+	require.NoError(t, checkpoint.flush())
+}
@@ -22,6 +22,7 @@ package cli
 
 import (
 	"fmt"
+	"io"
 	"os"
 	"strconv"
 	"strings"
@@ -30,11 +31,14 @@ import (
 	"github.com/urfave/cli"
 	tasklistpb "go.temporal.io/temporal-proto/tasklist"
 	"go.temporal.io/temporal-proto/workflowservice"
+
+	"github.com/temporalio/temporal/.gen/proto/adminservice"
 )
 
 // AdminDescribeTaskList displays poller and status information of task list.
 func AdminDescribeTaskList(c *cli.Context) {
 	frontendClient := cFactory.FrontendClient(c)
+	adminClient := cFactory.AdminClient(c)
 	namespace := getRequiredGlobalOption(c, FlagNamespace)
 	taskList := getRequiredOption(c, FlagTaskList)
 	taskListType := tasklistpb.TaskListTypeDecision
@@ -60,7 +64,27 @@ func AdminDescribeTaskList(c *cli.Context) {
 	if taskListStatus == nil {
 		ErrorAndExit(colorMagenta("No tasklist status information."), nil)
 	}
-	printTaskListStatus(taskListStatus)
+
+	backlogResponse, err := adminClient.EstimateTaskListBacklog(ctx, &adminservice.EstimateTaskListBacklogRequest{
+		Namespace:    namespace,
+		TaskList:     taskList,
+		TaskListType: int32(taskListType),
+	})
+	if err != nil {
+		ErrorAndExit("Operation EstimateTaskListBacklog failed.", err)
+	}
+	printTaskListStatus(taskListStatus, backlogResponse.GetBacklogCount())
+	fmt.Printf("\n")
+
+	statusResponse, err := adminClient.DescribeTaskListStatus(ctx, &adminservice.DescribeTaskListStatusRequest{
+		Namespace:    namespace,
+		TaskList:     taskList,
+		TaskListType: int32(taskListType),
+	})
+	if err != nil {
+		ErrorAndExit("Operation DescribeTaskListStatus failed.", err)
+	}
+	printTaskListBacklogStatus(statusResponse.GetBacklogStatus())
 	fmt.Printf("\n")
 
 	pollers := response.Pollers
@@ -70,23 +94,38 @@ func AdminDescribeTaskList(c *cli.Context) {
 	printPollerInfo(pollers, taskListType)
 }
 
-func printTaskListStatus(taskListStatus *tasklistpb.TaskListStatus) {
+func printTaskListStatus(taskListStatus *tasklistpb.TaskListStatus, estimatedBacklog int64) {
 	taskIDBlock := taskListStatus.GetTaskIdBlock()
 
 	table := tablewriter.NewWriter(os.Stdout)
 	table.SetBorder(false)
 	table.SetColumnSeparator("|")
-	table.SetHeader([]string{"Read Level", "Ack Level", "Backlog", "Lease Start TaskId", "Lease End TaskId"})
+	table.SetHeader([]string{"Read Level", "Ack Level", "Backlog", "Estimated Persisted Backlog", "Lease Start TaskId", "Lease End TaskId"})
 	table.SetHeaderLine(false)
-	table.SetHeaderColor(tableHeaderBlue, tableHeaderBlue, tableHeaderBlue, tableHeaderBlue, tableHeaderBlue)
+	table.SetHeaderColor(tableHeaderBlue, tableHeaderBlue, tableHeaderBlue, tableHeaderBlue, tableHeaderBlue, tableHeaderBlue)
 	table.Append([]string{strconv.FormatInt(taskListStatus.GetReadLevel(), 10),
 		strconv.FormatInt(taskListStatus.GetAckLevel(), 10),
 		strconv.FormatInt(taskListStatus.GetBacklogCountHint(), 10),
+		strconv.FormatInt(estimatedBacklog, 10),
 		strconv.FormatInt(taskIDBlock.GetStartId(), 10),
 		strconv.FormatInt(taskIDBlock.GetEndId(), 10)})
 	table.Render()
 }
 
+func printTaskListBacklogStatus(backlogStatus *adminservice.TaskListBacklogStatus) {
+	table := tablewriter.NewWriter(os.Stdout)
+	table.SetBorder(false)
+	table.SetColumnSeparator("|")
+	table.SetHeader([]string{"Backlog Mode", "Backlog Read Level", "Backlog Ack Level", "Forwarding Active"})
+	table.SetHeaderLine(false)
+	table.SetHeaderColor(tableHeaderBlue, tableHeaderBlue, tableHeaderBlue, tableHeaderBlue)
+	table.Append([]string{strconv.FormatBool(backlogStatus.GetBacklogMode()),
+		strconv.FormatInt(backlogStatus.GetReadLevel(), 10),
+		strconv.FormatInt(backlogStatus.GetAckLevel(), 10),
+		strconv.FormatBool(backlogStatus.GetForwardingActive())})
+	table.Render()
+}
+
 func printPollerInfo(pollers []*tasklistpb.PollerInfo, taskListType tasklistpb.TaskListType) {
 	table := tablewriter.NewWriter(os.Stdout)
 	table.SetBorder(false)
@@ -103,3 +142,107 @@ func printPollerInfo(pollers []*tasklistpb.PollerInfo, taskListType tasklistpb.T
 	}
 	table.Render()
 }
+
+// AdminListOwnedTaskLists displays the task lists a matching host currently owns
+func AdminListOwnedTaskLists(c *cli.Context) {
+	adminClient := cFactory.AdminClient(c)
+	host := getRequiredOption(c, FlagHost)
+
+	ctx, cancel := newContext(c)
+	defer cancel()
+
+	response, err := adminClient.ListOwnedTaskLists(ctx, &adminservice.ListOwnedTaskListsRequest{
+		HostAddress: host,
+	})
+	if err != nil {
+		ErrorAndExit("Operation ListOwnedTaskLists failed.", err)
+	}
+	if len(response.GetTaskLists()) == 0 {
+		fmt.Printf("No task list is owned by host %v\n", host)
+		return
+	}
+	printOwnedTaskLists(os.Stdout, response.GetTaskLists())
+}
+
+func printOwnedTaskLists(w io.Writer, taskLists []*adminservice.OwnedTaskList) {
+	table := tablewriter.NewWriter(w)
+	table.SetBorder(false)
+	table.SetColumnSeparator("|")
+	table.SetHeader([]string{"Namespace", "TaskList", "Type", "Kind", "Backlog"})
+	table.SetHeaderLine(false)
+	table.SetHeaderColor(tableHeaderBlue, tableHeaderBlue, tableHeaderBlue, tableHeaderBlue, tableHeaderBlue)
+	for _, taskList := range taskLists {
+		namespace := taskList.GetNamespace()
+		if namespace == "" {
+			namespace = taskList.GetNamespaceId()
+		}
+		table.Append([]string{
+			namespace,
+			taskList.GetName(),
+			tasklistpb.TaskListType(taskList.GetTaskListType()).String(),
+			taskList.GetKind().String(),
+			strconv.FormatInt(taskList.GetBacklogCountHint(), 10),
+		})
+	}
+	table.Render()
+}
+
+// AdminInjectTask enqueues a synthetic task into the backlog of a task list, matching must have
+// task injection enabled for it to be accepted
+func AdminInjectTask(c *cli.Context) {
+	adminClient := cFactory.AdminClient(c)
+	namespace := getRequiredGlobalOption(c, FlagNamespace)
+	taskList := getRequiredOption(c, FlagTaskList)
+	taskListType := tasklistpb.TaskListTypeDecision
+	if strings.ToLower(c.String(FlagTaskListType)) == "activity" {
+		taskListType = tasklistpb.TaskListTypeActivity
+	}
+
+	ctx, cancel := newContext(c)
+	defer cancel()
+
+	response, err := adminClient.InjectTask(ctx, &adminservice.InjectTaskRequest{
+		Namespace:                     namespace,
+		TaskList:                      taskList,
+		TaskListType:                  int32(taskListType),
+		ScheduleToStartTimeoutSeconds: int32(c.Int(FlagScheduleToStartTimeout)),
+	})
+	if err != nil {
+		ErrorAndExit("Operation InjectTask failed.", err)
+	}
+	fmt.Printf("Injected %v task with WorkflowId %v, RunId %v and ScheduleId %v\n",
+		taskListType,
+		response.GetExecution().GetWorkflowId(),
+		response.GetExecution().GetRunId(),
+		response.GetScheduleId())
+}
+
+// AdminCompleteTasksBelow completes all tasks of a task list with a task id at or below the given one
+func AdminCompleteTasksBelow(c *cli.Context) {
+	adminClient := cFactory.AdminClient(c)
+	namespace := getRequiredGlobalOption(c, FlagNamespace)
+	taskList := getRequiredOption(c, FlagTaskList)
+	taskID := getRequiredInt64Option(c, FlagTaskID)
+	taskListType := tasklistpb.TaskListTypeDecision
+	if strings.ToLower(c.String(FlagTaskListType)) == "activity" {
+		taskListType = tasklistpb.TaskListTypeActivity
+	}
+
+	ctx, cancel := newContext(c)
+	defer cancel()
+
+	response, err := adminClient.CompleteTasksBelow(ctx, &adminservice.CompleteTasksBelowRequest{
+		Namespace:    namespace,
+		TaskList:     taskList,
+		TaskListType: int32(taskListType),
+		TaskId:       taskID,
+	})
+	if err != nil {
+		ErrorAndExit("Operation CompleteTasksBelow failed.", err)
+	}
+	fmt.Printf("Completed tasks of %v tasklist %v up to task id %v, ack level is now %v\n",
+		taskListType,
+		taskList,
+		taskID,
+		response.GetAckLevel())
+}
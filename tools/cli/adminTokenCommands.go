@@ -0,0 +1,72 @@
+// The MIT License (MIT)
+//
+// Copyright (c) 2020 Temporal Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package cli
+
+import (
+	"encoding/base64"
+	"fmt"
+	"strings"
+
+	"github.com/gogo/protobuf/proto"
+	"github.com/urfave/cli"
+
+	"github.com/temporalio/temporal/service/frontend"
+)
+
+// AdminDecodeToken decodes a history pagination token and prints its fields
+func AdminDecodeToken(c *cli.Context) {
+	encodedToken := getRequiredOption(c, FlagPaginationToken)
+	tokenType := c.String(FlagTokenType)
+
+	token, err := decodePaginationToken(encodedToken, tokenType)
+	if err != nil {
+		ErrorAndExit("Unable to decode pagination token", err)
+	}
+	prettyPrintJSONObject(token)
+}
+
+// decodePaginationToken decodes a base64 encoded history or raw history continuation token. When no token type is
+// given, the token is decoded as a history token first and as a raw history token if that fails.
+func decodePaginationToken(encodedToken string, tokenType string) (proto.Message, error) {
+	data, err := base64.StdEncoding.DecodeString(strings.TrimSpace(encodedToken))
+	if err != nil {
+		return nil, fmt.Errorf("token is not base64 encoded: %v", err)
+	}
+
+	switch tokenType {
+	case tokenTypeHistory:
+		return frontend.DeserializeHistoryToken(data)
+	case tokenTypeRaw:
+		return frontend.DeserializeRawHistoryToken(data)
+	case "":
+		if token, err := frontend.DeserializeHistoryToken(data); err == nil {
+			return token, nil
+		}
+		if token, err := frontend.DeserializeRawHistoryToken(data); err == nil {
+			return token, nil
+		}
+		return nil, fmt.Errorf("token is neither a %v nor a %v token", tokenTypeHistory, tokenTypeRaw)
+	default:
+		return nil, fmt.Errorf("unknown token type %v, expected %v or %v", tokenType, tokenTypeHistory, tokenTypeRaw)
+	}
+}
@@ -0,0 +1,87 @@
+// The MIT License (MIT)
+//
+// Copyright (c) 2020 Temporal Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package cli
+
+import (
+	"encoding/base64"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	eventgenpb "github.com/temporalio/temporal/.gen/proto/event"
+	tokengenpb "github.com/temporalio/temporal/.gen/proto/token"
+)
+
+func TestDecodePaginationToken_RoundTrip(t *testing.T) {
+	rawToken := &tokengenpb.RawHistoryContinuation{
+		Namespace:         "test-namespace",
+		WorkflowId:        "test-workflow-id",
+		RunId:             "test-run-id",
+		StartEventId:      10,
+		StartEventVersion: 100,
+		EndEventId:        20,
+		EndEventVersion:   200,
+		PersistenceToken:  []byte("persistence-token"),
+		VersionHistories: &eventgenpb.VersionHistories{
+			CurrentVersionHistoryIndex: 0,
+			Histories: []*eventgenpb.VersionHistory{{
+				BranchToken: []byte("branch-token"),
+				Items:       []*eventgenpb.VersionHistoryItem{{EventId: 20, Version: 200}},
+			}},
+		},
+	}
+	historyToken := &tokengenpb.HistoryContinuation{
+		RunId:             "test-run-id",
+		FirstEventId:      1,
+		NextEventId:       42,
+		IsWorkflowRunning: true,
+		PersistenceToken:  []byte("persistence-token"),
+		BranchToken:       []byte("branch-token"),
+	}
+
+	rawData, err := rawToken.Marshal()
+	require.NoError(t, err)
+	historyData, err := historyToken.Marshal()
+	require.NoError(t, err)
+	encodedRaw := base64.StdEncoding.EncodeToString(rawData)
+	encodedHistory := base64.StdEncoding.EncodeToString(historyData)
+
+	for _, tokenType := range []string{"", tokenTypeRaw} {
+		decoded, err := decodePaginationToken(encodedRaw, tokenType)
+		require.NoError(t, err)
+		require.Equal(t, rawToken, decoded)
+	}
+	for _, tokenType := range []string{"", tokenTypeHistory} {
+		decoded, err := decodePaginationToken(encodedHistory, tokenType)
+		require.NoError(t, err)
+		require.Equal(t, historyToken, decoded)
+	}
+}
+
+func TestDecodePaginationToken_Invalid(t *testing.T) {
+	_, err := decodePaginationToken("not base64!", "")
+	require.Error(t, err)
+
+	_, err = decodePaginationToken(base64.StdEncoding.EncodeToString([]byte("token")), "unknown")
+	require.Error(t, err)
+}
@@ -139,6 +139,12 @@ func NewCliApp() *cli.App {
 					Usage:       "Run admin operation on DLQ",
 					Subcommands: newAdminDLQCommands(),
 				},
+				{
+					Name:        "token",
+					Aliases:     []string{"tok"},
+					Usage:       "Run admin operation on pagination tokens",
+					Subcommands: newAdminTokenCommands(),
+				},
 			},
 		},
 		{
@@ -21,6 +21,10 @@
 package cli
 
 import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
 	"strings"
 	"testing"
 	"time"
@@ -43,9 +47,12 @@ import (
 	"go.temporal.io/temporal-proto/workflowservicemock"
 	sdkclient "go.temporal.io/temporal/client"
 	sdkmocks "go.temporal.io/temporal/mocks"
+	"google.golang.org/grpc"
 
 	"github.com/temporalio/temporal/.gen/proto/adminservice"
 	"github.com/temporalio/temporal/.gen/proto/adminservicemock"
+	commongenpb "github.com/temporalio/temporal/.gen/proto/common"
+	replicationgenpb "github.com/temporalio/temporal/.gen/proto/replication"
 )
 
 type cliAppSuite struct {
@@ -54,12 +61,14 @@ type cliAppSuite struct {
 	mockCtrl          *gomock.Controller
 	frontendClient    *workflowservicemock.MockWorkflowServiceClient
 	serverAdminClient *adminservicemock.MockAdminServiceClient
+	remoteAdminClient *adminservicemock.MockAdminServiceClient
 	sdkClient         *sdkmocks.Client
 }
 
 type clientFactoryMock struct {
 	frontendClient    workflowservice.WorkflowServiceClient
 	serverAdminClient adminservice.AdminServiceClient
+	remoteAdminClient adminservice.AdminServiceClient
 	sdkClient         *sdkmocks.Client
 }
 
@@ -71,6 +80,10 @@ func (m *clientFactoryMock) AdminClient(c *cli.Context) adminservice.AdminServic
 	return m.serverAdminClient
 }
 
+func (m *clientFactoryMock) RemoteAdminClient(hostPort string) adminservice.AdminServiceClient {
+	return m.remoteAdminClient
+}
+
 func (m *clientFactoryMock) SDKClient(c *cli.Context, namespace string) sdkclient.Client {
 	return m.sdkClient
 }
@@ -97,10 +110,12 @@ func (s *cliAppSuite) SetupTest() {
 
 	s.frontendClient = workflowservicemock.NewMockWorkflowServiceClient(s.mockCtrl)
 	s.serverAdminClient = adminservicemock.NewMockAdminServiceClient(s.mockCtrl)
+	s.remoteAdminClient = adminservicemock.NewMockAdminServiceClient(s.mockCtrl)
 	s.sdkClient = &sdkmocks.Client{}
 	SetFactory(&clientFactoryMock{
 		frontendClient:    s.frontendClient,
 		serverAdminClient: s.serverAdminClient,
+		remoteAdminClient: s.remoteAdminClient,
 		sdkClient:         s.sdkClient,
 	})
 }
@@ -533,11 +548,331 @@ func (s *cliAppSuite) TestAdminDescribeWorkflow_Failed() {
 	s.Equal(1, errorCode)
 }
 
+func (s *cliAppSuite) TestAdminForceTerminateWorkflow() {
+	s.serverAdminClient.EXPECT().ForceTerminateWorkflowExecution(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, request *adminservice.ForceTerminateWorkflowExecutionRequest, _ ...grpc.CallOption) (*adminservice.ForceTerminateWorkflowExecutionResponse, error) {
+			s.Equal(cliTestNamespace, request.GetNamespace())
+			s.Equal("test-wf-id", request.GetExecution().GetWorkflowId())
+			s.Equal("stuck on a bad deployment", request.GetReason())
+			s.Equal([]byte("ticket 42"), request.GetDetails())
+			s.NotEmpty(request.GetIdentity())
+			return &adminservice.ForceTerminateWorkflowExecutionResponse{}, nil
+		})
+	err := s.app.Run([]string{"", "--ns", cliTestNamespace, "admin", "wf", "force-terminate", "-w", "test-wf-id",
+		"--reason", "stuck on a bad deployment", "--detail", "ticket 42"})
+	s.Nil(err)
+}
+
+func (s *cliAppSuite) TestAdminForceTerminateWorkflow_MissingReason() {
+	errorCode := s.RunErrorExitCode([]string{"", "--ns", cliTestNamespace, "admin", "wf", "force-terminate", "-w", "test-wf-id"})
+	s.Equal(1, errorCode)
+}
+
+func (s *cliAppSuite) TestAdminListWorkflowTimers() {
+	s.serverAdminClient.EXPECT().ListWorkflowTimers(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, request *adminservice.ListWorkflowTimersRequest, _ ...grpc.CallOption) (*adminservice.ListWorkflowTimersResponse, error) {
+			s.Equal(cliTestNamespace, request.GetNamespace())
+			s.Equal("test-wf-id", request.GetExecution().GetWorkflowId())
+			return &adminservice.ListWorkflowTimersResponse{
+				Timers: []*adminservice.WorkflowTimerInfo{
+					{TimerId: "timer-1", StartedEventId: 5, ExpiryTime: time.Now().UnixNano()},
+					{TimerId: "timer-2", StartedEventId: 50, ExpiryTime: time.Now().UnixNano(), Orphaned: true},
+				},
+			}, nil
+		})
+	err := s.app.Run([]string{"", "--ns", cliTestNamespace, "admin", "wf", "timers", "-w", "test-wf-id"})
+	s.Nil(err)
+}
+
+func (s *cliAppSuite) TestAdminCancelOrphanedTimer() {
+	s.serverAdminClient.EXPECT().CancelOrphanedTimer(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, request *adminservice.CancelOrphanedTimerRequest, _ ...grpc.CallOption) (*adminservice.CancelOrphanedTimerResponse, error) {
+			s.Equal(cliTestNamespace, request.GetNamespace())
+			s.Equal("test-wf-id", request.GetExecution().GetWorkflowId())
+			s.Equal("timer-2", request.GetTimerId())
+			return &adminservice.CancelOrphanedTimerResponse{}, nil
+		})
+	err := s.app.Run([]string{"", "--ns", cliTestNamespace, "admin", "wf", "cancel-orphaned-timer", "-w", "test-wf-id", "--tid", "timer-2"})
+	s.Nil(err)
+}
+
+func (s *cliAppSuite) TestAdminDescribeTaskList() {
+	resp := &workflowservice.DescribeTaskListResponse{
+		Pollers:        describeTaskListResponse.Pollers,
+		TaskListStatus: &tasklistpb.TaskListStatus{ReadLevel: 10, AckLevel: 5, BacklogCountHint: 5},
+	}
+	s.frontendClient.EXPECT().DescribeTaskList(gomock.Any(), gomock.Any()).Return(resp, nil)
+	s.serverAdminClient.EXPECT().EstimateTaskListBacklog(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, request *adminservice.EstimateTaskListBacklogRequest, _ ...grpc.CallOption) (*adminservice.EstimateTaskListBacklogResponse, error) {
+			s.Equal(cliTestNamespace, request.GetNamespace())
+			s.Equal("test-taskList", request.GetTaskList())
+			s.Equal(int32(tasklistpb.TaskListTypeActivity), request.GetTaskListType())
+			return &adminservice.EstimateTaskListBacklogResponse{BacklogCount: 7}, nil
+		})
+	s.serverAdminClient.EXPECT().DescribeTaskListStatus(gomock.Any(), &adminservice.DescribeTaskListStatusRequest{
+		Namespace:    cliTestNamespace,
+		TaskList:     "test-taskList",
+		TaskListType: int32(tasklistpb.TaskListTypeActivity),
+	}).Return(&adminservice.DescribeTaskListStatusResponse{
+		BacklogStatus: &adminservice.TaskListBacklogStatus{BacklogMode: true, ReadLevel: 10, AckLevel: 5},
+	}, nil)
+	err := s.app.Run([]string{"", "--ns", cliTestNamespace, "admin", "tl", "describe", "-tl", "test-taskList", "-tlt", "activity"})
+	s.Nil(err)
+}
+
+func (s *cliAppSuite) TestAdminListOwnedTaskLists() {
+	s.serverAdminClient.EXPECT().ListOwnedTaskLists(gomock.Any(), &adminservice.ListOwnedTaskListsRequest{
+		HostAddress: "127.0.0.1:7235",
+	}).Return(&adminservice.ListOwnedTaskListsResponse{
+		TaskLists: []*adminservice.OwnedTaskList{
+			{NamespaceId: "namespace-id", Namespace: cliTestNamespace, Name: "test-taskList", Kind: tasklistpb.TaskListKindSticky, BacklogCountHint: 12},
+		},
+	}, nil)
+	err := s.app.Run([]string{"", "admin", "tl", "list-owned", "--host", "127.0.0.1:7235"})
+	s.Nil(err)
+}
+
+func (s *cliAppSuite) TestAdminInjectTask() {
+	s.serverAdminClient.EXPECT().InjectTask(gomock.Any(), &adminservice.InjectTaskRequest{
+		Namespace:                     cliTestNamespace,
+		TaskList:                      "test-taskList",
+		TaskListType:                  int32(tasklistpb.TaskListTypeActivity),
+		ScheduleToStartTimeoutSeconds: 30,
+	}).Return(&adminservice.InjectTaskResponse{
+		Execution:  &executionpb.WorkflowExecution{WorkflowId: uuid.New(), RunId: uuid.New()},
+		ScheduleId: 7,
+	}, nil)
+	err := s.app.Run([]string{"", "--ns", cliTestNamespace, "admin", "tl", "inject-task", "--tl", "test-taskList", "--tlt", "activity", "--schedule_to_start_timeout", "30"})
+	s.Nil(err)
+}
+
+func (s *cliAppSuite) TestAdminCompleteTasksBelow() {
+	s.serverAdminClient.EXPECT().CompleteTasksBelow(gomock.Any(), &adminservice.CompleteTasksBelowRequest{
+		Namespace:    cliTestNamespace,
+		TaskList:     "test-taskList",
+		TaskListType: int32(tasklistpb.TaskListTypeActivity),
+		TaskId:       1234,
+	}).Return(&adminservice.CompleteTasksBelowResponse{AckLevel: 1234}, nil)
+	err := s.app.Run([]string{"", "--ns", cliTestNamespace, "admin", "tl", "complete-below", "--tl", "test-taskList", "--tlt", "activity", "--task_id", "1234"})
+	s.Nil(err)
+}
+
+func (s *cliAppSuite) TestAdminMergeOneDLQMessage() {
+	s.serverAdminClient.EXPECT().MergeOneDLQMessage(gomock.Any(), &adminservice.MergeOneDLQMessageRequest{
+		Type:      commongenpb.DLQTypeNamespace,
+		MessageId: 12,
+	}).Return(&adminservice.MergeOneDLQMessageResponse{}, nil)
+	err := s.app.Run([]string{"", "admin", "dlq", "merge-one", "--dlq_type", "namespace", "--message_id", "12"})
+	s.Nil(err)
+}
+
 func (s *cliAppSuite) TestAdminAddSearchAttribute() {
 	err := s.app.Run([]string{"", "--ns", cliTestNamespace, "admin", "cl", "asa", "--search_attr_key", "testKey", "--search_attr_type", "1"})
 	s.Nil(err)
 }
 
+func (s *cliAppSuite) TestAdminResetReplicationAck() {
+	s.serverAdminClient.EXPECT().ResetReplicationAckLevel(gomock.Any(), &adminservice.ResetReplicationAckLevelRequest{
+		ShardId:     3,
+		ClusterName: "standby",
+	}).Return(&adminservice.ResetReplicationAckLevelResponse{AckLevel: 100}, nil)
+	err := s.app.Run([]string{"", "admin", "shard", "resetReplicationAck", "--shard_id", "3", "--cluster", "standby"})
+	s.Nil(err)
+}
+
+func (s *cliAppSuite) TestAdminRepairShardAckLevels() {
+	s.serverAdminClient.EXPECT().RepairShardAckLevels(gomock.Any(), &adminservice.RepairShardAckLevelsRequest{
+		ShardId: 3,
+	}).Return(&adminservice.RepairShardAckLevelsResponse{
+		Queues: []*adminservice.ShardQueueAckLevel{
+			{QueueType: "transfer", AckLevel: 150, RepairedAckLevel: 100, MinTaskLevel: 101, MaxTaskLevel: 180, TaskCount: 3},
+			{QueueType: "timer", AckLevel: time.Now().UnixNano(), RepairedAckLevel: time.Now().UnixNano()},
+		},
+	}, nil)
+	err := s.app.Run([]string{"", "admin", "shard", "repair-ack-levels", "--shard_id", "3"})
+	s.Nil(err)
+}
+
+func (s *cliAppSuite) TestAdminRepairShardAckLevels_Apply() {
+	s.serverAdminClient.EXPECT().RepairShardAckLevels(gomock.Any(), &adminservice.RepairShardAckLevelsRequest{
+		ShardId: 3,
+		Apply:   true,
+	}).Return(&adminservice.RepairShardAckLevelsResponse{}, nil)
+	err := s.app.Run([]string{"", "admin", "shard", "repair-ack-levels", "--shard_id", "3", "--apply", "--yes"})
+	s.Nil(err)
+}
+
+func (s *cliAppSuite) TestAdminShardQueueMetrics() {
+	s.serverAdminClient.EXPECT().RepairShardAckLevels(gomock.Any(), &adminservice.RepairShardAckLevelsRequest{
+		ShardId: 3,
+	}).Return(&adminservice.RepairShardAckLevelsResponse{
+		Queues: []*adminservice.ShardQueueAckLevel{
+			{QueueType: "transfer", AckLevel: 100, MinTaskLevel: 101, MaxTaskLevel: 180, TaskCount: 3},
+			{QueueType: "timer", AckLevel: time.Now().UnixNano()},
+		},
+	}, nil)
+	err := s.app.Run([]string{"", "admin", "shard", "queue-metrics", "--shard_id", "3"})
+	s.Nil(err)
+}
+
+func (s *cliAppSuite) TestAdminDiffDLQMessages() {
+	dlqMessages := func(taskIDs ...int64) *adminservice.ReadDLQMessagesResponse {
+		resp := &adminservice.ReadDLQMessagesResponse{Type: commongenpb.DLQTypeReplication}
+		for _, taskID := range taskIDs {
+			resp.ReplicationTasks = append(resp.ReplicationTasks, &replicationgenpb.ReplicationTask{SourceTaskId: taskID})
+		}
+		return resp
+	}
+
+	s.serverAdminClient.EXPECT().ReadDLQMessages(gomock.Any(), gomock.Any()).Return(dlqMessages(1, 2, 3), nil)
+	s.remoteAdminClient.EXPECT().ReadDLQMessages(gomock.Any(), gomock.Any()).Return(dlqMessages(1, 2, 3), nil)
+	errorCode := s.RunErrorExitCode([]string{"", "admin", "dlq", "diff", "--dlq_type", "history", "--shard_id", "1", "--remote_address", "remote:7233"})
+	s.Equal(0, errorCode)
+
+	s.serverAdminClient.EXPECT().ReadDLQMessages(gomock.Any(), gomock.Any()).Return(dlqMessages(1, 2, 3), nil)
+	s.remoteAdminClient.EXPECT().ReadDLQMessages(gomock.Any(), gomock.Any()).Return(dlqMessages(2, 3, 4), nil)
+	errorCode = s.RunErrorExitCode([]string{"", "admin", "dlq", "diff", "--dlq_type", "history", "--shard_id", "1", "--remote_address", "remote:7233", "--format", "json"})
+	s.Equal(1, errorCode)
+
+	s.serverAdminClient.EXPECT().ReadDLQMessages(gomock.Any(), gomock.Any()).Return(dlqMessages(1, 2, 3), nil)
+	s.remoteAdminClient.EXPECT().ReadDLQMessages(gomock.Any(), gomock.Any()).Return(dlqMessages(1, 2, 4), nil)
+	errorCode = s.RunErrorExitCode([]string{"", "admin", "dlq", "diff", "--dlq_type", "history", "--shard_id", "1", "--remote_address", "remote:7233", "--max_messages", "2"})
+	s.Equal(0, errorCode)
+}
+
+func (s *cliAppSuite) TestPurgeDLQMessagesInChunks() {
+	const messageCount = 2500
+	const chunkSize = 1000
+	var dlq []int64
+	for i := int64(1); i <= messageCount; i++ {
+		dlq = append(dlq, i)
+	}
+
+	s.serverAdminClient.EXPECT().ReadDLQMessages(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, request *adminservice.ReadDLQMessagesRequest, _ ...grpc.CallOption) (*adminservice.ReadDLQMessagesResponse, error) {
+			resp := &adminservice.ReadDLQMessagesResponse{Type: request.GetType()}
+			for _, taskID := range dlq {
+				if len(resp.ReplicationTasks) == int(request.GetMaximumPageSize()) {
+					resp.NextPageToken = []byte("next")
+					break
+				}
+				resp.ReplicationTasks = append(resp.ReplicationTasks, &replicationgenpb.ReplicationTask{SourceTaskId: taskID})
+			}
+			return resp, nil
+		}).Times(3)
+	s.serverAdminClient.EXPECT().PurgeDLQMessages(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, request *adminservice.PurgeDLQMessagesRequest, _ ...grpc.CallOption) (*adminservice.PurgeDLQMessagesResponse, error) {
+			var remaining []int64
+			for _, taskID := range dlq {
+				if taskID > request.GetInclusiveEndMessageId() {
+					remaining = append(remaining, taskID)
+				}
+			}
+			dlq = remaining
+			return &adminservice.PurgeDLQMessagesResponse{}, nil
+		}).Times(3)
+
+	stateDir, err := ioutil.TempDir("", "TestPurgeDLQMessagesInChunks")
+	s.NoError(err)
+	defer os.RemoveAll(stateDir)
+	stateFile := filepath.Join(stateDir, "state.json")
+
+	var progress []int64
+	state := &dlqPurgeState{DLQType: "history", ShardID: 1}
+	err = purgeDLQMessagesInChunks(context.Background(), s.serverAdminClient, state, chunkSize, stateFile, func(state *dlqPurgeState, purgedCount int) {
+		persisted, err := loadDLQPurgeState(stateFile)
+		s.NoError(err)
+		s.Equal(state, persisted)
+		progress = append(progress, state.LastPurgedMessageID)
+	})
+	s.NoError(err)
+	s.Empty(dlq)
+	s.Equal([]int64{1000, 2000, 2500}, progress)
+	_, err = os.Stat(stateFile)
+	s.True(os.IsNotExist(err))
+}
+
+func (s *cliAppSuite) TestAdminPurgeDLQMessages_Resume() {
+	stateDir, err := ioutil.TempDir("", "TestAdminPurgeDLQMessages_Resume")
+	s.NoError(err)
+	defer os.RemoveAll(stateDir)
+	stateFile := filepath.Join(stateDir, "state.json")
+	s.NoError(saveDLQPurgeState(stateFile, &dlqPurgeState{DLQType: "history", ShardID: 1, LastMessageID: 10, LastPurgedMessageID: 5}))
+
+	s.serverAdminClient.EXPECT().ReadDLQMessages(gomock.Any(), &adminservice.ReadDLQMessagesRequest{
+		Type:                  commongenpb.DLQTypeReplication,
+		ShardId:               1,
+		InclusiveEndMessageId: 10,
+		MaximumPageSize:       defaultPageSize,
+	}).Return(&adminservice.ReadDLQMessagesResponse{
+		ReplicationTasks: []*replicationgenpb.ReplicationTask{{SourceTaskId: 6}, {SourceTaskId: 10}},
+	}, nil)
+	s.serverAdminClient.EXPECT().PurgeDLQMessages(gomock.Any(), &adminservice.PurgeDLQMessagesRequest{
+		Type:                  commongenpb.DLQTypeReplication,
+		ShardId:               1,
+		InclusiveEndMessageId: 10,
+	}).Return(&adminservice.PurgeDLQMessagesResponse{}, nil)
+
+	err = s.app.Run([]string{"", "admin", "dlq", "purge", "--resume", "--state_file", stateFile})
+	s.Nil(err)
+}
+
+func (s *cliAppSuite) TestAdminPurgeDLQMessages_FilterByNamespace() {
+	stateDir, err := ioutil.TempDir("", "TestAdminPurgeDLQMessages_FilterByNamespace")
+	s.NoError(err)
+	defer os.RemoveAll(stateDir)
+	stateFile := filepath.Join(stateDir, "state.json")
+
+	s.serverAdminClient.EXPECT().ReadDLQMessages(gomock.Any(), &adminservice.ReadDLQMessagesRequest{
+		Type:                  commongenpb.DLQTypeNamespace,
+		InclusiveEndMessageId: 10,
+		MaximumPageSize:       defaultPageSize,
+		NamespaceId:           "namespace-id",
+	}).Return(&adminservice.ReadDLQMessagesResponse{
+		ReplicationTasks: []*replicationgenpb.ReplicationTask{{SourceTaskId: 3}, {SourceTaskId: 7}},
+	}, nil)
+	s.serverAdminClient.EXPECT().PurgeDLQMessages(gomock.Any(), &adminservice.PurgeDLQMessagesRequest{
+		Type:                  commongenpb.DLQTypeNamespace,
+		InclusiveEndMessageId: 7,
+		NamespaceId:           "namespace-id",
+	}).Return(&adminservice.PurgeDLQMessagesResponse{}, nil)
+
+	err = s.app.Run([]string{"", "admin", "dlq", "purge", "--dlq_type", "namespace", "--namespace_id", "namespace-id", "--last_message_id", "10", "--state_file", stateFile})
+	s.Nil(err)
+}
+
+func (s *cliAppSuite) TestDiffDLQTaskIDs() {
+	result := diffDLQTaskIDs([]int64{1, 2, 3}, []int64{2, 3, 4, 5})
+	s.Equal(3, result.LocalMessageCount)
+	s.Equal(4, result.RemoteMessageCount)
+	s.Equal([]int64{1}, result.OnlyInLocal)
+	s.Equal([]int64{4, 5}, result.OnlyInRemote)
+}
+
+func (s *cliAppSuite) TestAdminSkipReplicationTask() {
+	s.serverAdminClient.EXPECT().DLQReplicationTask(gomock.Any(), &adminservice.DLQReplicationTaskRequest{
+		ShardId:       3,
+		SourceCluster: "standby",
+		TaskId:        100,
+		Reason:        "poison task",
+	}).Return(&adminservice.DLQReplicationTaskResponse{}, nil)
+	err := s.app.Run([]string{"", "admin", "dlq", "skip", "--shard_id", "3", "--cluster", "standby", "--task_id", "100", "--reason", "poison task"})
+	s.Nil(err)
+}
+
+func (s *cliAppSuite) TestAdminRefreshNamespaceCache() {
+	s.serverAdminClient.EXPECT().RefreshNamespaceCache(gomock.Any(), &adminservice.RefreshNamespaceCacheRequest{
+		Namespace: cliTestNamespace,
+	}).Return(&adminservice.RefreshNamespaceCacheResponse{
+		Results: []*adminservice.RefreshNamespaceCacheHostResult{
+			{Service: "history", HostAddress: "127.0.0.1:7234"},
+			{Service: "matching", HostAddress: "127.0.0.1:7235", Error: "some random error"},
+		},
+	}, nil)
+	err := s.app.Run([]string{"", "--ns", cliTestNamespace, "admin", "namespace", "refresh-cache"})
+	s.Nil(err)
+}
+
 func (s *cliAppSuite) TestDescribeTaskList() {
 	s.sdkClient.On("DescribeTaskList", mock.Anything, mock.Anything, mock.Anything).Return(describeTaskListResponse, nil).Once()
 	err := s.app.Run([]string{"", "--ns", cliTestNamespace, "tasklist", "describe", "-tl", "test-taskList"})
@@ -66,6 +66,12 @@ const (
 	showErrorStackEnv    = `TEMPORAL_CLI_SHOW_STACKS`
 
 	searchAttrInputSeparator = "|"
+
+	formatText = "text"
+	formatJSON = "json"
+
+	tokenTypeRaw     = "raw"
+	tokenTypeHistory = "history"
 )
 
 var envKeysForUserName = []string{
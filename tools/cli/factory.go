@@ -35,6 +35,7 @@ import (
 type ClientFactory interface {
 	FrontendClient(c *cli.Context) workflowservice.WorkflowServiceClient
 	AdminClient(c *cli.Context) adminservice.AdminServiceClient
+	RemoteAdminClient(hostPort string) adminservice.AdminServiceClient
 	SDKClient(c *cli.Context, namespace string) sdkclient.Client
 }
 
@@ -68,6 +69,13 @@ func (b *clientFactory) AdminClient(c *cli.Context) adminservice.AdminServiceCli
 	return adminservice.NewAdminServiceClient(connection)
 }
 
+// RemoteAdminClient builds an admin client for the given address instead of the global one
+func (b *clientFactory) RemoteAdminClient(hostPort string) adminservice.AdminServiceClient {
+	connection := b.createGRPCConnection(hostPort)
+
+	return adminservice.NewAdminServiceClient(connection)
+}
+
 // AdminClient builds an admin client (based on server side thrift interface)
 func (b *clientFactory) SDKClient(c *cli.Context, namespace string) sdkclient.Client {
 	hostPort := c.GlobalString(FlagAddress)
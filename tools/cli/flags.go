@@ -32,7 +32,9 @@ const (
 	FlagHistoryAddress                    = "history_address"
 	FlagDBAddress                         = "db_address"
 	FlagDBPort                            = "db_port"
+	FlagDBType                            = "db_type"
 	FlagHistoryAddressWithAlias           = FlagHistoryAddress + ", had"
+	FlagHost                              = "host"
 	FlagNamespaceID                       = "namespace_id"
 	FlagNamespace                         = "namespace"
 	FlagNamespaceWithAlias                = FlagNamespace + ", ns"
@@ -48,6 +50,9 @@ const (
 	FlagTargetCluster                     = "target_cluster"
 	FlagMinEventID                        = "min_event_id"
 	FlagMaxEventID                        = "max_event_id"
+	FlagReverse                           = "reverse"
+	FlagMaxEvents                         = "max_events"
+	FlagCheckpointFile                    = "checkpoint_file"
 	FlagTaskList                          = "tasklist"
 	FlagTaskListWithAlias                 = FlagTaskList + ", tl"
 	FlagTaskListType                      = "tasklisttype"
@@ -65,6 +70,8 @@ const (
 	FlagDecisionTimeoutWithAlias          = FlagDecisionTimeout + ", dt"
 	FlagContextTimeout                    = "context_timeout"
 	FlagContextTimeoutWithAlias           = FlagContextTimeout + ", ct"
+	FlagScheduleToStartTimeout            = "schedule_to_start_timeout"
+	FlagTaskID                            = "task_id"
 	FlagInput                             = "input"
 	FlagInputWithAlias                    = FlagInput + ", i"
 	FlagInputFile                         = "input_file"
@@ -156,12 +163,15 @@ const (
 	FlagEventIDWithAlias                  = FlagEventID + ", eid"
 	FlagActivityID                        = "activity_id"
 	FlagActivityIDWithAlias               = FlagActivityID + ", aid"
+	FlagTimerID                           = "timer_id"
+	FlagTimerIDWithAlias                  = FlagTimerID + ", tid"
 	FlagMaxFieldLength                    = "max_field_length"
 	FlagMaxFieldLengthWithAlias           = FlagMaxFieldLength + ", maxl"
 	FlagSecurityToken                     = "security_token"
 	FlagSecurityTokenWithAlias            = FlagSecurityToken + ", st"
 	FlagSkipErrorMode                     = "skip_errors"
 	FlagSkipErrorModeWithAlias            = FlagSkipErrorMode + ", serr"
+	FlagForce                             = "force"
 	FlagHeadersMode                       = "headers"
 	FlagHeadersModeWithAlias              = FlagHeadersMode + ", he"
 	FlagMessageType                       = "message_type"
@@ -193,6 +203,8 @@ const (
 	FlagJobID                             = "job_id"
 	FlagJobIDWithAlias                    = FlagJobID + ", jid"
 	FlagYes                               = "yes"
+	FlagApply                             = "apply"
+	FlagFilter                            = "filter"
 	FlagServiceConfigDir                  = "service_config_dir"
 	FlagServiceConfigDirWithAlias         = FlagServiceConfigDir + ", scd"
 	FlagServiceEnv                        = "service_env"
@@ -210,6 +222,18 @@ const (
 	FlagMaxMessageCountWithAlias          = FlagMaxMessageCount + ", mmc"
 	FlagLastMessageID                     = "last_message_id"
 	FlagLastMessageIDWithAlias            = FlagLastMessageID + ", lm"
+	FlagMessageID                         = "message_id"
+	FlagShowBranches                      = "show_branches"
+	FlagShowBranchesWithAlias             = FlagShowBranches + ", sb"
+	FlagFormat                            = "format"
+	FlagRemoteAddress                     = "remote_address"
+	FlagRemoteAddressWithAlias            = FlagRemoteAddress + ", ra"
+	FlagMaxMessages                       = "max_messages"
+	FlagChunkSize                         = "chunk_size"
+	FlagResume                            = "resume"
+	FlagStateFile                         = "state_file"
+	FlagPaginationToken                   = "token"
+	FlagTokenType                         = "token_type"
 )
 
 var flagsForExecution = []cli.Flag{
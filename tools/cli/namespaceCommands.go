@@ -26,6 +26,7 @@ import (
 	"fmt"
 	"os"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/gogo/protobuf/types"
@@ -47,6 +48,31 @@ type (
 		// act as admin to modify namespace in DB directly
 		namespaceHandler namespace.Handler
 	}
+
+	// namespaceDescribeRecord is the description of a single namespace
+	// emitted by the list-all command
+	namespaceDescribeRecord struct {
+		Name                     string            `json:"name"`
+		ID                       string            `json:"id"`
+		Description              string            `json:"description"`
+		OwnerEmail               string            `json:"ownerEmail"`
+		Data                     map[string]string `json:"data,omitempty"`
+		Status                   string            `json:"status"`
+		RetentionInDays          int32             `json:"retentionInDays"`
+		EmitMetrics              bool              `json:"emitMetrics"`
+		IsGlobalNamespace        bool              `json:"isGlobalNamespace"`
+		FailoverVersion          int64             `json:"failoverVersion"`
+		ActiveClusterName        string            `json:"activeClusterName"`
+		Clusters                 []string          `json:"clusters"`
+		HistoryArchivalStatus    string            `json:"historyArchivalStatus"`
+		HistoryArchivalURI       string            `json:"historyArchivalURI,omitempty"`
+		VisibilityArchivalStatus string            `json:"visibilityArchivalStatus"`
+		VisibilityArchivalURI    string            `json:"visibilityArchivalURI,omitempty"`
+	}
+)
+
+const (
+	listAllNamespacesPageSize = 100
 )
 
 // newNamespaceCLI creates a namespace CLI
@@ -349,6 +375,73 @@ func (d *namespaceCLIImpl) DescribeNamespace(c *cli.Context) {
 	}
 }
 
+// ListAllNamespaces describes all namespaces as a JSON array
+func (d *namespaceCLIImpl) ListAllNamespaces(c *cli.Context) {
+	ctx, cancel := newContext(c)
+	defer cancel()
+	records, err := d.listAllNamespaces(ctx, c.String(FlagFilter))
+	if err != nil {
+		ErrorAndExit("Operation ListNamespaces failed.", err)
+	}
+	prettyPrintJSONObject(records)
+	// the count goes to stderr so that stdout remains valid JSON
+	fmt.Fprintf(os.Stderr, "Total namespaces: %v\n", len(records))
+}
+
+// listAllNamespaces pages through all namespaces and returns the description
+// of those whose name starts with the given prefix
+func (d *namespaceCLIImpl) listAllNamespaces(
+	ctx context.Context,
+	prefix string,
+) ([]*namespaceDescribeRecord, error) {
+
+	records := []*namespaceDescribeRecord{}
+	var token []byte
+	for {
+		resp, err := d.listNamespaces(ctx, &workflowservice.ListNamespacesRequest{
+			PageSize:      listAllNamespacesPageSize,
+			NextPageToken: token,
+		})
+		if err != nil {
+			return nil, err
+		}
+		for _, ns := range resp.Namespaces {
+			if strings.HasPrefix(ns.NamespaceInfo.GetName(), prefix) {
+				records = append(records, newNamespaceDescribeRecord(ns))
+			}
+		}
+		token = resp.NextPageToken
+		if len(token) == 0 {
+			return records, nil
+		}
+	}
+}
+
+func newNamespaceDescribeRecord(resp *workflowservice.DescribeNamespaceResponse) *namespaceDescribeRecord {
+	var clusters []string
+	for _, cluster := range resp.ReplicationConfiguration.GetClusters() {
+		clusters = append(clusters, cluster.GetClusterName())
+	}
+	return &namespaceDescribeRecord{
+		Name:                     resp.NamespaceInfo.GetName(),
+		ID:                       resp.NamespaceInfo.GetId(),
+		Description:              resp.NamespaceInfo.GetDescription(),
+		OwnerEmail:               resp.NamespaceInfo.GetOwnerEmail(),
+		Data:                     resp.NamespaceInfo.GetData(),
+		Status:                   resp.NamespaceInfo.GetStatus().String(),
+		RetentionInDays:          resp.Configuration.GetWorkflowExecutionRetentionPeriodInDays(),
+		EmitMetrics:              resp.Configuration.GetEmitMetric().GetValue(),
+		IsGlobalNamespace:        resp.GetIsGlobalNamespace(),
+		FailoverVersion:          resp.GetFailoverVersion(),
+		ActiveClusterName:        resp.ReplicationConfiguration.GetActiveClusterName(),
+		Clusters:                 clusters,
+		HistoryArchivalStatus:    resp.Configuration.GetHistoryArchivalStatus().String(),
+		HistoryArchivalURI:       resp.Configuration.GetHistoryArchivalURI(),
+		VisibilityArchivalStatus: resp.Configuration.GetVisibilityArchivalStatus().String(),
+		VisibilityArchivalURI:    resp.Configuration.GetVisibilityArchivalURI(),
+	}
+}
+
 func (d *namespaceCLIImpl) registerNamespace(
 	ctx context.Context,
 	request *workflowservice.RegisterNamespaceRequest,
@@ -388,6 +481,18 @@ func (d *namespaceCLIImpl) describeNamespace(
 	return resp, err
 }
 
+func (d *namespaceCLIImpl) listNamespaces(
+	ctx context.Context,
+	request *workflowservice.ListNamespacesRequest,
+) (*workflowservice.ListNamespacesResponse, error) {
+
+	if d.frontendClient != nil {
+		return d.frontendClient.ListNamespaces(ctx, request)
+	}
+
+	return d.namespaceHandler.ListNamespaces(ctx, request)
+}
+
 func clustersToString(clusters []*replicationpb.ClusterReplicationConfiguration) string {
 	var res string
 	for i, cluster := range clusters {
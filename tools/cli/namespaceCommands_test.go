@@ -0,0 +1,134 @@
+// The MIT License (MIT)
+//
+// Copyright (c) 2020 Temporal Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package cli
+
+import (
+	"context"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+	namespacepb "go.temporal.io/temporal-proto/namespace"
+	replicationpb "go.temporal.io/temporal-proto/replication"
+	"go.temporal.io/temporal-proto/workflowservice"
+
+	"github.com/temporalio/temporal/common/namespace"
+	"github.com/temporalio/temporal/common/service/config"
+)
+
+func newTestDescribeNamespaceResponse(name string, retentionDays int32) *workflowservice.DescribeNamespaceResponse {
+	return &workflowservice.DescribeNamespaceResponse{
+		NamespaceInfo: &namespacepb.NamespaceInfo{
+			Name:   name,
+			Id:     name + "-id",
+			Status: namespacepb.NamespaceStatusRegistered,
+		},
+		Configuration: &namespacepb.NamespaceConfiguration{
+			WorkflowExecutionRetentionPeriodInDays: retentionDays,
+			HistoryArchivalStatus:                  namespacepb.ArchivalStatusEnabled,
+			HistoryArchivalURI:                     "file:///tmp/history",
+			VisibilityArchivalStatus:               namespacepb.ArchivalStatusDisabled,
+		},
+		ReplicationConfiguration: &replicationpb.NamespaceReplicationConfiguration{
+			ActiveClusterName: "active",
+			Clusters: []*replicationpb.ClusterReplicationConfiguration{
+				{ClusterName: "active"},
+				{ClusterName: "standby"},
+			},
+		},
+		IsGlobalNamespace: true,
+	}
+}
+
+func TestListAllNamespaces(t *testing.T) {
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+
+	handler := namespace.NewMockHandler(controller)
+	handler.EXPECT().ListNamespaces(gomock.Any(), &workflowservice.ListNamespacesRequest{
+		PageSize: listAllNamespacesPageSize,
+	}).Return(&workflowservice.ListNamespacesResponse{
+		Namespaces: []*workflowservice.DescribeNamespaceResponse{
+			newTestDescribeNamespaceResponse("team-a-orders", 3),
+			newTestDescribeNamespaceResponse("team-b-billing", 7),
+		},
+		NextPageToken: []byte("page-2"),
+	}, nil).Times(2)
+	handler.EXPECT().ListNamespaces(gomock.Any(), &workflowservice.ListNamespacesRequest{
+		PageSize:      listAllNamespacesPageSize,
+		NextPageToken: []byte("page-2"),
+	}).Return(&workflowservice.ListNamespacesResponse{
+		Namespaces: []*workflowservice.DescribeNamespaceResponse{
+			newTestDescribeNamespaceResponse("team-a-payments", 30),
+		},
+	}, nil).Times(2)
+	nsCLI := &namespaceCLIImpl{namespaceHandler: handler}
+
+	records, err := nsCLI.listAllNamespaces(context.Background(), "")
+	require.NoError(t, err)
+	require.Len(t, records, 3)
+	require.Equal(t, "team-a-orders", records[0].Name)
+	require.Equal(t, "team-a-orders-id", records[0].ID)
+	require.EqualValues(t, 3, records[0].RetentionInDays)
+	require.True(t, records[0].IsGlobalNamespace)
+	require.Equal(t, "active", records[0].ActiveClusterName)
+	require.Equal(t, []string{"active", "standby"}, records[0].Clusters)
+	require.Equal(t, namespacepb.ArchivalStatusEnabled.String(), records[0].HistoryArchivalStatus)
+	require.Equal(t, "file:///tmp/history", records[0].HistoryArchivalURI)
+	require.Equal(t, namespacepb.ArchivalStatusDisabled.String(), records[0].VisibilityArchivalStatus)
+	require.Equal(t, "team-b-billing", records[1].Name)
+	require.Equal(t, "team-a-payments", records[2].Name)
+
+	records, err = nsCLI.listAllNamespaces(context.Background(), "team-a-")
+	require.NoError(t, err)
+	require.Len(t, records, 2)
+	require.Equal(t, "team-a-orders", records[0].Name)
+	require.Equal(t, "team-a-payments", records[1].Name)
+}
+
+func TestSelectDefaultStore(t *testing.T) {
+	newConfig := func() *config.Persistence {
+		return &config.Persistence{
+			DefaultStore: "default",
+			DataStores: map[string]config.DataStore{
+				"default":    {Cassandra: &config.Cassandra{}},
+				"mysql":      {SQL: &config.SQL{}},
+				"visibility": {SQL: &config.SQL{}},
+			},
+		}
+	}
+
+	cfg := newConfig()
+	require.NoError(t, selectDefaultStore(cfg, config.StoreTypeCassandra))
+	require.Equal(t, "default", cfg.DefaultStore)
+
+	cfg = newConfig()
+	require.NoError(t, selectDefaultStore(cfg, config.StoreTypeSQL))
+	require.Equal(t, "mysql", cfg.DefaultStore)
+
+	cfg = newConfig()
+	delete(cfg.DataStores, "mysql")
+	delete(cfg.DataStores, "visibility")
+	require.Error(t, selectDefaultStore(cfg, config.StoreTypeSQL))
+	require.Error(t, selectDefaultStore(cfg, "oracle"))
+}
@@ -21,6 +21,8 @@
 package cli
 
 import (
+	"fmt"
+	"sort"
 	"strings"
 
 	"github.com/stretchr/testify/mock"
@@ -200,6 +202,20 @@ var (
 		updateNamespaceFlags,
 		adminNamespaceCommonFlags...,
 	)
+
+	adminListAllNamespacesFlags = append(
+		[]cli.Flag{
+			cli.StringFlag{
+				Name:  FlagFilter,
+				Usage: "Optional namespace name prefix to filter on",
+			},
+			cli.StringFlag{
+				Name:  FlagDBType,
+				Usage: "Optional type of the datastore to read from, valid values are \"cassandra\" and \"sql\"",
+			},
+		},
+		adminNamespaceCommonFlags...,
+	)
 )
 
 func initializeFrontendClient(
@@ -213,6 +229,11 @@ func initializeAdminNamespaceHandler(
 ) namespace.Handler {
 
 	configuration := loadConfig(context)
+	if dbType := context.String(FlagDBType); dbType != "" {
+		if err := selectDefaultStore(&configuration.Persistence, dbType); err != nil {
+			ErrorAndExit("Unable to select datastore.", err)
+		}
+	}
 	metricsClient := initializeMetricsClient()
 	logger := initializeLogger(configuration)
 	clusterMetadata := initializeClusterMetadata(
@@ -268,6 +289,36 @@ func initializeNamespaceHandler(
 	)
 }
 
+// selectDefaultStore points the default store of the persistence config at a
+// datastore of the given type. The configured default store is kept if it is
+// already of that type, otherwise the first matching datastore by name is used
+func selectDefaultStore(
+	persistenceConfig *config.Persistence,
+	dbType string,
+) error {
+
+	if dbType != config.StoreTypeCassandra && dbType != config.StoreTypeSQL {
+		return fmt.Errorf("unknown datastore type %v", dbType)
+	}
+	if persistenceConfig.DefaultStoreType() == dbType {
+		return nil
+	}
+
+	var names []string
+	for name := range persistenceConfig.DataStores {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		ds := persistenceConfig.DataStores[name]
+		if (dbType == config.StoreTypeSQL && ds.SQL != nil) || (dbType == config.StoreTypeCassandra && ds.Cassandra != nil) {
+			persistenceConfig.DefaultStore = name
+			return nil
+		}
+	}
+	return fmt.Errorf("no %v datastore is configured", dbType)
+}
+
 func initializeLogger(
 	serviceConfig *config.Config,
 ) log.Logger {